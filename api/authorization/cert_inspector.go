@@ -51,8 +51,10 @@ func (c *CertInspector) WhoAmI(ctx context.Context, certPEM []byte) (Identity, e
 		return Identity{}, apierrors.FromK8sError(err, "")
 	}
 
+	// Kubernetes maps the organizations of client certificates to groups
 	return Identity{
-		Name: cert.Subject.CommonName,
-		Kind: rbacv1.UserKind,
+		Name:   cert.Subject.CommonName,
+		Kind:   rbacv1.UserKind,
+		Groups: cert.Subject.Organization,
 	}, nil
 }
@@ -0,0 +1,120 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	jose "gopkg.in/square/go-jose.v2"
+)
+
+type KeySet struct {
+	KeysStub        func(context.Context, string) ([]jose.JSONWebKey, error)
+	keysMutex       sync.RWMutex
+	keysArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	keysReturns struct {
+		result1 []jose.JSONWebKey
+		result2 error
+	}
+	keysReturnsOnCall map[int]struct {
+		result1 []jose.JSONWebKey
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *KeySet) Keys(arg1 context.Context, arg2 string) ([]jose.JSONWebKey, error) {
+	fake.keysMutex.Lock()
+	ret, specificReturn := fake.keysReturnsOnCall[len(fake.keysArgsForCall)]
+	fake.keysArgsForCall = append(fake.keysArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.KeysStub
+	fakeReturns := fake.keysReturns
+	fake.recordInvocation("Keys", []interface{}{arg1, arg2})
+	fake.keysMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *KeySet) KeysCallCount() int {
+	fake.keysMutex.RLock()
+	defer fake.keysMutex.RUnlock()
+	return len(fake.keysArgsForCall)
+}
+
+func (fake *KeySet) KeysCalls(stub func(context.Context, string) ([]jose.JSONWebKey, error)) {
+	fake.keysMutex.Lock()
+	defer fake.keysMutex.Unlock()
+	fake.KeysStub = stub
+}
+
+func (fake *KeySet) KeysArgsForCall(i int) (context.Context, string) {
+	fake.keysMutex.RLock()
+	defer fake.keysMutex.RUnlock()
+	argsForCall := fake.keysArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *KeySet) KeysReturns(result1 []jose.JSONWebKey, result2 error) {
+	fake.keysMutex.Lock()
+	defer fake.keysMutex.Unlock()
+	fake.KeysStub = nil
+	fake.keysReturns = struct {
+		result1 []jose.JSONWebKey
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *KeySet) KeysReturnsOnCall(i int, result1 []jose.JSONWebKey, result2 error) {
+	fake.keysMutex.Lock()
+	defer fake.keysMutex.Unlock()
+	fake.KeysStub = nil
+	if fake.keysReturnsOnCall == nil {
+		fake.keysReturnsOnCall = make(map[int]struct {
+			result1 []jose.JSONWebKey
+			result2 error
+		})
+	}
+	fake.keysReturnsOnCall[i] = struct {
+		result1 []jose.JSONWebKey
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *KeySet) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.keysMutex.RLock()
+	defer fake.keysMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *KeySet) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ authorization.KeySet = new(KeySet)
@@ -5,6 +5,8 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+
+	rbacv1 "k8s.io/api/rbac/v1"
 )
 
 const (
@@ -17,8 +19,19 @@ const (
 //counterfeiter:generate -o fake -fake-name CertIdentityInspector . CertIdentityInspector
 
 type Identity struct {
-	Name string
-	Kind string
+	Name   string
+	Kind   string
+	Groups []string
+}
+
+// Matches returns true if the subject refers to the identity itself or to one
+// of its groups
+func (i *Identity) Matches(subject rbacv1.Subject) bool {
+	if subject.Kind == i.Kind && subject.Name == i.Name {
+		return true
+	}
+
+	return subject.Kind == rbacv1.GroupKind && contains(i.Groups, subject.Name)
 }
 
 func (i *Identity) Hash() string {
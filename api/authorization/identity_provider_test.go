@@ -101,3 +101,20 @@ var _ = Describe("IdentityProvider", func() {
 		})
 	})
 })
+
+var _ = DescribeTable("Identity.Matches",
+	func(subject rbacv1.Subject, expected bool) {
+		identity := authorization.Identity{
+			Name:   "alice",
+			Kind:   rbacv1.UserKind,
+			Groups: []string{"developers", "auditors"},
+		}
+		Expect(identity.Matches(subject)).To(Equal(expected))
+	},
+	Entry("the user itself", rbacv1.Subject{Kind: rbacv1.UserKind, Name: "alice"}, true),
+	Entry("another user", rbacv1.Subject{Kind: rbacv1.UserKind, Name: "bob"}, false),
+	Entry("a service account with the same name", rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Name: "alice"}, false),
+	Entry("a group of the user", rbacv1.Subject{Kind: rbacv1.GroupKind, Name: "auditors"}, true),
+	Entry("another group", rbacv1.Subject{Kind: rbacv1.GroupKind, Name: "admins"}, false),
+	Entry("a user named like a group of the user", rbacv1.Subject{Kind: rbacv1.UserKind, Name: "developers"}, false),
+)
@@ -21,15 +21,27 @@ type IdentityProvider interface {
 	GetIdentity(context.Context, Info) (Identity, error)
 }
 
+// NamespacePermissions works out which CF orgs and spaces a user can see
+// from the role bindings of the user. Users bound to one of the global
+// cluster roles in the root namespace can see all orgs and spaces.
 type NamespacePermissions struct {
-	privilegedClient client.Client
-	identityProvider IdentityProvider
+	privilegedClient   client.Client
+	identityProvider   IdentityProvider
+	rootNamespace      string
+	globalClusterRoles map[string]bool
 }
 
-func NewNamespacePermissions(privilegedClient client.Client, identityProvider IdentityProvider) *NamespacePermissions {
+func NewNamespacePermissions(privilegedClient client.Client, identityProvider IdentityProvider, rootNamespace string, globalClusterRoles []string) *NamespacePermissions {
+	globalClusterRolesSet := map[string]bool{}
+	for _, clusterRole := range globalClusterRoles {
+		globalClusterRolesSet[clusterRole] = true
+	}
+
 	return &NamespacePermissions{
-		privilegedClient: privilegedClient,
-		identityProvider: identityProvider,
+		privilegedClient:   privilegedClient,
+		identityProvider:   identityProvider,
+		rootNamespace:      rootNamespace,
+		globalClusterRoles: globalClusterRolesSet,
 	}
 }
 
@@ -62,11 +74,15 @@ func (o *NamespacePermissions) getAuthorizedNamespaces(ctx context.Context, info
 		cfNamespaces[ns.Name] = true
 	}
 
+	if o.hasGlobalRole(identity, rolebindings.Items) {
+		return cfNamespaces, nil
+	}
+
 	authorizedNamespaces := map[string]bool{}
 
 	for _, roleBinding := range rolebindings.Items {
 		for _, subject := range roleBinding.Subjects {
-			if subject.Kind == identity.Kind && subject.Name == identity.Name {
+			if identity.Matches(subject) {
 				if cfNamespaces[roleBinding.Namespace] {
 					authorizedNamespaces[roleBinding.Namespace] = true
 				}
@@ -77,6 +93,38 @@ func (o *NamespacePermissions) getAuthorizedNamespaces(ctx context.Context, info
 	return authorizedNamespaces, nil
 }
 
+// HasGlobalRole returns true when the user is bound to one of the global
+// cluster roles in the root namespace
+func (o *NamespacePermissions) HasGlobalRole(ctx context.Context, info Info) (bool, error) {
+	identity, err := o.identityProvider.GetIdentity(ctx, info)
+	if err != nil {
+		return false, fmt.Errorf("failed to get identity: %w", err)
+	}
+
+	var rolebindings rbacv1.RoleBindingList
+	if err := o.privilegedClient.List(ctx, &rolebindings, client.InNamespace(o.rootNamespace)); err != nil {
+		return false, fmt.Errorf("failed to list rolebindings: %w", apierrors.FromK8sError(err, ""))
+	}
+
+	return o.hasGlobalRole(identity, rolebindings.Items), nil
+}
+
+func (o *NamespacePermissions) hasGlobalRole(identity Identity, rolebindings []rbacv1.RoleBinding) bool {
+	for _, roleBinding := range rolebindings {
+		if roleBinding.Namespace != o.rootNamespace || !o.globalClusterRoles[roleBinding.RoleRef.Name] {
+			continue
+		}
+
+		for _, subject := range roleBinding.Subjects {
+			if identity.Matches(subject) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 func (o *NamespacePermissions) AuthorizedIn(ctx context.Context, identity Identity, namespace string) (bool, error) {
 	var rolebindings rbacv1.RoleBindingList
 	err := o.privilegedClient.List(ctx, &rolebindings, client.InNamespace(namespace))
@@ -86,7 +134,7 @@ func (o *NamespacePermissions) AuthorizedIn(ctx context.Context, identity Identi
 
 	for _, roleBinding := range rolebindings.Items {
 		for _, subject := range roleBinding.Subjects {
-			if subject.Kind == identity.Kind && subject.Name == identity.Name {
+			if identity.Matches(subject) {
 				return true, nil
 			}
 		}
@@ -31,8 +31,10 @@ var _ = Describe("Namespace Permissions", func() {
 		space1NS, space2NS   string
 		org1NS, org2NS       string
 		nonCFNS              string
+		rootNS               string
 		userName             string
 		roleName1, roleName2 string
+		globalRoleName       string
 	)
 
 	createNamespace := func(name string, labels map[string]string) string {
@@ -59,16 +61,16 @@ var _ = Describe("Namespace Permissions", func() {
 		return role
 	}
 
-	createRoleBindingForUser := func(user, roleName, namespace string) *rbacv1.RoleBinding {
+	createRoleBindingForSubject := func(kind, name, roleName, namespace string) *rbacv1.RoleBinding {
 		role := &rbacv1.RoleBinding{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:      fmt.Sprintf("%s-%s", user, roleName),
+				Name:      fmt.Sprintf("%s-%s", name, roleName),
 				Namespace: namespace,
 			},
 			Subjects: []rbacv1.Subject{
 				{
-					Name: user,
-					Kind: "User",
+					Name: name,
+					Kind: kind,
 				},
 			},
 			RoleRef: rbacv1.RoleRef{
@@ -83,6 +85,10 @@ var _ = Describe("Namespace Permissions", func() {
 		return role
 	}
 
+	createRoleBindingForUser := func(user, roleName, namespace string) *rbacv1.RoleBinding {
+		return createRoleBindingForSubject(rbacv1.UserKind, user, roleName, namespace)
+	}
+
 	BeforeEach(func() {
 		userName = generateGUID("alice")
 		ctx = context.Background()
@@ -97,23 +103,28 @@ var _ = Describe("Namespace Permissions", func() {
 		identityProvider = new(fake.IdentityProvider)
 		identityProvider.GetIdentityReturns(identity, nil)
 
-		nsPerms = authorization.NewNamespacePermissions(k8sClient, identityProvider)
-
 		nonCFNS = createNamespace("non-cf", nil)
+		rootNS = createNamespace("root", nil)
 
 		roleName1 = generateGUID("org-user-1")
 		roleName2 = generateGUID("org-user-2")
+		globalRoleName = generateGUID("global-auditor")
 
 		createClusterRole(roleName1)
 		createClusterRole(roleName2)
+		createClusterRole(globalRoleName)
 		createRoleBindingForUser(userName, roleName2, nonCFNS)
+
+		nsPerms = authorization.NewNamespacePermissions(k8sClient, identityProvider, rootNS, []string{globalRoleName})
 	})
 
 	AfterEach(func() {
 		ctx = context.Background()
 		Expect(k8sClient.Delete(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: nonCFNS}})).To(Succeed())
+		Expect(k8sClient.Delete(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: rootNS}})).To(Succeed())
 		Expect(k8sClient.Delete(ctx, &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: roleName1}})).To(Succeed())
 		Expect(k8sClient.Delete(ctx, &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: roleName2}})).To(Succeed())
+		Expect(k8sClient.Delete(ctx, &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: globalRoleName}})).To(Succeed())
 	})
 
 	Describe("Get Authorized Org Namespaces", func() {
@@ -141,6 +152,48 @@ var _ = Describe("Namespace Permissions", func() {
 			Expect(namespaces).To(Equal(map[string]bool{org1NS: true}))
 		})
 
+		When("the user is a member of a group with a rolebinding", func() {
+			var groupName string
+
+			BeforeEach(func() {
+				groupName = generateGUID("developers")
+				createRoleBindingForSubject(rbacv1.GroupKind, groupName, roleName1, org2NS)
+
+				identity.Groups = []string{"some-other-group", groupName}
+				identityProvider.GetIdentityReturns(identity, nil)
+			})
+
+			It("includes the namespaces with bindings for the group", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(namespaces).To(Equal(map[string]bool{org1NS: true, org2NS: true}))
+			})
+		})
+
+		When("the user is bound to a global role in the root namespace", func() {
+			BeforeEach(func() {
+				createRoleBindingForUser(userName, globalRoleName, rootNS)
+			})
+
+			It("includes all org namespaces", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(namespaces).To(HaveKeyWithValue(org1NS, true))
+				Expect(namespaces).To(HaveKeyWithValue(org2NS, true))
+				Expect(namespaces).NotTo(HaveKey(nonCFNS))
+				Expect(namespaces).NotTo(HaveKey(rootNS))
+			})
+		})
+
+		When("the user is bound to a global role outside the root namespace", func() {
+			BeforeEach(func() {
+				createRoleBindingForUser(userName, globalRoleName, nonCFNS)
+			})
+
+			It("only lists the namespaces with bindings for current user", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(namespaces).To(Equal(map[string]bool{org1NS: true}))
+			})
+		})
+
 		When("the id provider fails", func() {
 			BeforeEach(func() {
 				identityProvider.GetIdentityReturns(authorization.Identity{}, errors.New("boom"))
@@ -218,6 +271,70 @@ var _ = Describe("Namespace Permissions", func() {
 				Expect(namespaces).To(BeEmpty())
 			})
 		})
+
+		When("a group of the user is bound to a global role in the root namespace", func() {
+			BeforeEach(func() {
+				groupName := generateGUID("auditors")
+				createRoleBindingForSubject(rbacv1.GroupKind, groupName, globalRoleName, rootNS)
+
+				identity.Groups = []string{groupName}
+				identityProvider.GetIdentityReturns(identity, nil)
+			})
+
+			It("includes all space namespaces", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(namespaces).To(HaveKeyWithValue(space1NS, true))
+				Expect(namespaces).To(HaveKeyWithValue(space2NS, true))
+			})
+		})
+	})
+
+	Describe("Has Global Role", func() {
+		var (
+			hasGlobalRole bool
+			err           error
+		)
+
+		JustBeforeEach(func() {
+			hasGlobalRole, err = nsPerms.HasGlobalRole(ctx, authInfo)
+		})
+
+		It("returns false", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hasGlobalRole).To(BeFalse())
+		})
+
+		When("the user is bound to a global role in the root namespace", func() {
+			BeforeEach(func() {
+				createRoleBindingForUser(userName, globalRoleName, rootNS)
+			})
+
+			It("returns true", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hasGlobalRole).To(BeTrue())
+			})
+		})
+
+		When("the user is bound to another role in the root namespace", func() {
+			BeforeEach(func() {
+				createRoleBindingForUser(userName, roleName1, rootNS)
+			})
+
+			It("returns false", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hasGlobalRole).To(BeFalse())
+			})
+		})
+
+		When("the id provider fails", func() {
+			BeforeEach(func() {
+				identityProvider.GetIdentityReturns(authorization.Identity{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				Expect(err).To(MatchError(ContainSubstring("failed to get identity")))
+			})
+		})
 	})
 
 	Describe("Authorized In", func() {
@@ -249,6 +366,20 @@ var _ = Describe("Namespace Permissions", func() {
 				Expect(authorized).To(BeFalse())
 			})
 		})
+
+		When("a group of the user has a RoleBinding in the namespace", func() {
+			BeforeEach(func() {
+				groupName := generateGUID("developers")
+				createRoleBindingForSubject(rbacv1.GroupKind, groupName, roleName1, org2NS)
+				identity.Groups = []string{groupName}
+			})
+
+			It("returns true", func() {
+				authorized, err := nsPerms.AuthorizedIn(ctx, identity, org2NS)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(authorized).To(BeTrue())
+			})
+		})
 	})
 })
 
@@ -0,0 +1,48 @@
+package authorization
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type oidcDiscovery struct {
+	Issuer        string `json:"issuer"`
+	JWKSURI       string `json:"jwks_uri"`
+	TokenEndpoint string `json:"token_endpoint"`
+}
+
+func discoverOIDCIssuer(ctx context.Context, httpClient *http.Client, issuerURL string) (oidcDiscovery, error) {
+	discovery := oidcDiscovery{}
+	err := getJSON(ctx, httpClient, strings.TrimSuffix(issuerURL, "/")+"/.well-known/openid-configuration", &discovery)
+	if err != nil {
+		return oidcDiscovery{}, fmt.Errorf("failed to discover OIDC issuer %q: %w", issuerURL, err)
+	}
+
+	if discovery.Issuer != issuerURL {
+		return oidcDiscovery{}, fmt.Errorf("OIDC discovery returned issuer %q, expected %q", discovery.Issuer, issuerURL)
+	}
+
+	return discovery, nil
+}
+
+func getJSON(ctx context.Context, httpClient *http.Client, url string, target interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s returned status %d", url, resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(target)
+}
@@ -0,0 +1,107 @@
+package authorization
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"gopkg.in/square/go-jose.v2"
+)
+
+// minKeySetRefreshInterval stops tokens signed with unknown keys from
+// triggering a request to the issuer every time they are presented
+const minKeySetRefreshInterval = 10 * time.Second
+
+//counterfeiter:generate -o fake -fake-name KeySet . KeySet
+
+type KeySet interface {
+	// Keys returns the keys that could have signed a token with the given key
+	// id. An empty key id matches all keys.
+	Keys(ctx context.Context, keyID string) ([]jose.JSONWebKey, error)
+}
+
+type StaticKeySet struct {
+	keySet jose.JSONWebKeySet
+}
+
+func NewStaticKeySet(keySet jose.JSONWebKeySet) *StaticKeySet {
+	return &StaticKeySet{keySet: keySet}
+}
+
+func ParseStaticKeySet(jwks []byte) (*StaticKeySet, error) {
+	keySet := jose.JSONWebKeySet{}
+	if err := json.Unmarshal(jwks, &keySet); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON web key set: %w", err)
+	}
+
+	return NewStaticKeySet(keySet), nil
+}
+
+func (s *StaticKeySet) Keys(_ context.Context, keyID string) ([]jose.JSONWebKey, error) {
+	return matchingKeys(s.keySet, keyID), nil
+}
+
+// RemoteKeySet serves the keys published by an OIDC issuer. The key set is
+// discovered via the issuer's /.well-known/openid-configuration and fetched
+// again whenever a token refers to a key that is not known yet, so that key
+// rotations on the issuer side are picked up.
+type RemoteKeySet struct {
+	issuerURL  string
+	httpClient *http.Client
+
+	mutex       sync.Mutex
+	keySet      jose.JSONWebKeySet
+	lastRefresh time.Time
+}
+
+func NewRemoteKeySet(issuerURL string, httpClient *http.Client) *RemoteKeySet {
+	return &RemoteKeySet{
+		issuerURL:  issuerURL,
+		httpClient: httpClient,
+	}
+}
+
+func (s *RemoteKeySet) Keys(ctx context.Context, keyID string) ([]jose.JSONWebKey, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	keys := matchingKeys(s.keySet, keyID)
+	if len(keys) > 0 || time.Since(s.lastRefresh) < minKeySetRefreshInterval {
+		return keys, nil
+	}
+
+	keySet, err := s.fetchKeySet(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.keySet = keySet
+	s.lastRefresh = time.Now()
+
+	return matchingKeys(s.keySet, keyID), nil
+}
+
+func (s *RemoteKeySet) fetchKeySet(ctx context.Context) (jose.JSONWebKeySet, error) {
+	discovery, err := discoverOIDCIssuer(ctx, s.httpClient, s.issuerURL)
+	if err != nil {
+		return jose.JSONWebKeySet{}, err
+	}
+
+	keySet := jose.JSONWebKeySet{}
+	err = getJSON(ctx, s.httpClient, discovery.JWKSURI, &keySet)
+	if err != nil {
+		return jose.JSONWebKeySet{}, fmt.Errorf("failed to fetch JSON web key set: %w", err)
+	}
+
+	return keySet, nil
+}
+
+func matchingKeys(keySet jose.JSONWebKeySet, keyID string) []jose.JSONWebKey {
+	if keyID == "" {
+		return keySet.Keys
+	}
+
+	return keySet.Key(keyID)
+}
@@ -0,0 +1,150 @@
+package authorization_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	"gopkg.in/square/go-jose.v2"
+)
+
+var _ = Describe("KeySets", func() {
+	var (
+		ctx       context.Context
+		publicKey *rsa.PublicKey
+		jwks      jose.JSONWebKeySet
+		keys      []jose.JSONWebKey
+		keyID     string
+		err       error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		signingKey, keyErr := rsa.GenerateKey(rand.Reader, 2048)
+		Expect(keyErr).NotTo(HaveOccurred())
+		publicKey = &signingKey.PublicKey
+
+		jwks = jose.JSONWebKeySet{
+			Keys: []jose.JSONWebKey{
+				{Key: publicKey, KeyID: "key-1", Algorithm: "RS256", Use: "sig"},
+				{Key: publicKey, KeyID: "key-2", Algorithm: "RS256", Use: "sig"},
+			},
+		}
+		keyID = "key-1"
+	})
+
+	Describe("StaticKeySet", func() {
+		JustBeforeEach(func() {
+			jwksBytes, marshalErr := json.Marshal(jwks)
+			Expect(marshalErr).NotTo(HaveOccurred())
+
+			var keySet *authorization.StaticKeySet
+			keySet, err = authorization.ParseStaticKeySet(jwksBytes)
+			Expect(err).NotTo(HaveOccurred())
+
+			keys, err = keySet.Keys(ctx, keyID)
+		})
+
+		It("returns the key with the given id", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys).To(HaveLen(1))
+			Expect(keys[0].KeyID).To(Equal("key-1"))
+			Expect(keys[0].Key).To(Equal(publicKey))
+		})
+
+		When("the key id is empty", func() {
+			BeforeEach(func() {
+				keyID = ""
+			})
+
+			It("returns all keys", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(keys).To(HaveLen(2))
+			})
+		})
+
+		When("the key id is unknown", func() {
+			BeforeEach(func() {
+				keyID = "key-3"
+			})
+
+			It("returns no keys", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(keys).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("RemoteKeySet", func() {
+		var (
+			server    *ghttp.Server
+			issuerURL string
+			keySet    *authorization.RemoteKeySet
+		)
+
+		BeforeEach(func() {
+			server = ghttp.NewServer()
+			issuerURL = server.URL()
+
+			server.RouteToHandler(http.MethodGet, "/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+				ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]string{
+					"issuer":   issuerURL,
+					"jwks_uri": server.URL() + "/keys",
+				})(w, r)
+			})
+			server.RouteToHandler(http.MethodGet, "/keys", func(w http.ResponseWriter, r *http.Request) {
+				ghttp.RespondWithJSONEncoded(http.StatusOK, jwks)(w, r)
+			})
+
+			keySet = authorization.NewRemoteKeySet(issuerURL, http.DefaultClient)
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		JustBeforeEach(func() {
+			keys, err = keySet.Keys(ctx, keyID)
+		})
+
+		It("fetches the keys advertised by the issuer", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys).To(HaveLen(1))
+			Expect(keys[0].KeyID).To(Equal("key-1"))
+			Expect(server.ReceivedRequests()).To(HaveLen(2))
+		})
+
+		It("caches the keys", func() {
+			_, err = keySet.Keys(ctx, "key-2")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(server.ReceivedRequests()).To(HaveLen(2))
+		})
+
+		When("the issuer is not available", func() {
+			BeforeEach(func() {
+				server.RouteToHandler(http.MethodGet, "/.well-known/openid-configuration", ghttp.RespondWith(http.StatusInternalServerError, nil))
+			})
+
+			It("returns an error", func() {
+				Expect(err).To(MatchError(ContainSubstring("failed to discover OIDC issuer")))
+			})
+		})
+
+		When("the discovery document refers to another issuer", func() {
+			BeforeEach(func() {
+				issuerURL = "https://another-issuer.example.com"
+			})
+
+			It("returns an error", func() {
+				Expect(err).To(MatchError(ContainSubstring("expected")))
+			})
+		})
+	})
+})
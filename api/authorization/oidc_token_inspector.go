@@ -0,0 +1,142 @@
+package authorization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"gopkg.in/square/go-jose.v2/jwt"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+const defaultUsernameClaim = "sub"
+
+type OIDCConfig struct {
+	IssuerURL string
+	ClientID  string
+
+	// UsernameClaim defaults to "sub"
+	UsernameClaim  string
+	UsernamePrefix string
+	// GroupsClaim is optional, tokens are not mapped to any groups when it is empty
+	GroupsClaim  string
+	GroupsPrefix string
+}
+
+// OIDCTokenInspector identifies users by verifying JWTs issued by an OIDC
+// issuer. Tokens coming from other issuers (e.g. service account tokens) are
+// passed on to the fallback inspector. The prefixes should match the ones
+// passed to the Kubernetes API server so that the identity matches the
+// subjects of the role bindings.
+type OIDCTokenInspector struct {
+	config   OIDCConfig
+	keySet   KeySet
+	fallback TokenIdentityInspector
+}
+
+func NewOIDCTokenInspector(config OIDCConfig, keySet KeySet, fallback TokenIdentityInspector) *OIDCTokenInspector {
+	if config.UsernameClaim == "" {
+		config.UsernameClaim = defaultUsernameClaim
+	}
+
+	return &OIDCTokenInspector{
+		config:   config,
+		keySet:   keySet,
+		fallback: fallback,
+	}
+}
+
+func (i *OIDCTokenInspector) WhoAmI(ctx context.Context, token string) (Identity, error) {
+	parsedToken, err := jwt.ParseSigned(token)
+	if err != nil {
+		return i.fallback.WhoAmI(ctx, token)
+	}
+
+	unverifiedClaims := jwt.Claims{}
+	if err = parsedToken.UnsafeClaimsWithoutVerification(&unverifiedClaims); err != nil || unverifiedClaims.Issuer != i.config.IssuerURL {
+		return i.fallback.WhoAmI(ctx, token)
+	}
+
+	claims, customClaims, err := i.verify(ctx, parsedToken)
+	if err != nil {
+		return Identity{}, apierrors.NewInvalidAuthError(err)
+	}
+
+	err = claims.ValidateWithLeeway(jwt.Expected{
+		Issuer:   i.config.IssuerURL,
+		Audience: jwt.Audience{i.config.ClientID},
+		Time:     time.Now(),
+	}, jwt.DefaultLeeway)
+	if err != nil {
+		return Identity{}, apierrors.NewInvalidAuthError(err)
+	}
+
+	username, ok := customClaims[i.config.UsernameClaim].(string)
+	if !ok || username == "" {
+		return Identity{}, apierrors.NewInvalidAuthError(fmt.Errorf("token has no %q claim", i.config.UsernameClaim))
+	}
+
+	groups, err := i.groups(customClaims)
+	if err != nil {
+		return Identity{}, apierrors.NewInvalidAuthError(err)
+	}
+
+	return Identity{
+		Name:   i.config.UsernamePrefix + username,
+		Kind:   rbacv1.UserKind,
+		Groups: groups,
+	}, nil
+}
+
+func (i *OIDCTokenInspector) verify(ctx context.Context, token *jwt.JSONWebToken) (jwt.Claims, map[string]interface{}, error) {
+	if len(token.Headers) != 1 {
+		return jwt.Claims{}, nil, errors.New("token must have exactly one signature")
+	}
+
+	keys, err := i.keySet.Keys(ctx, token.Headers[0].KeyID)
+	if err != nil {
+		return jwt.Claims{}, nil, fmt.Errorf("failed to get the keys of issuer %q: %w", i.config.IssuerURL, err)
+	}
+
+	for _, key := range keys {
+		claims := jwt.Claims{}
+		customClaims := map[string]interface{}{}
+		if token.Claims(key, &claims, &customClaims) == nil {
+			return claims, customClaims, nil
+		}
+	}
+
+	return jwt.Claims{}, nil, errors.New("failed to verify token signature")
+}
+
+func (i *OIDCTokenInspector) groups(customClaims map[string]interface{}) ([]string, error) {
+	if i.config.GroupsClaim == "" {
+		return nil, nil
+	}
+
+	var groups []string
+	switch claim := customClaims[i.config.GroupsClaim].(type) {
+	case nil:
+		return nil, nil
+	case string:
+		groups = []string{claim}
+	case []interface{}:
+		for _, group := range claim {
+			groupName, ok := group.(string)
+			if !ok {
+				return nil, fmt.Errorf("claim %q must only contain strings", i.config.GroupsClaim)
+			}
+			groups = append(groups, groupName)
+		}
+	default:
+		return nil, fmt.Errorf("claim %q must be a string or a list of strings", i.config.GroupsClaim)
+	}
+
+	for idx := range groups {
+		groups[idx] = i.config.GroupsPrefix + groups[idx]
+	}
+
+	return groups, nil
+}
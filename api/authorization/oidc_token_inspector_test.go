@@ -0,0 +1,214 @@
+package authorization_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/authorization/fake"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+var _ = Describe("OIDCTokenInspector", func() {
+	const issuerURL = "https://issuer.example.com"
+
+	var (
+		signingKey      *rsa.PrivateKey
+		verificationKey *rsa.PublicKey
+		keyID           string
+		config          authorization.OIDCConfig
+		fallback        *fake.TokenIdentityInspector
+		inspector       *authorization.OIDCTokenInspector
+		claims          map[string]interface{}
+		token           string
+		id              authorization.Identity
+		err             error
+	)
+
+	signToken := func(key *rsa.PrivateKey, keyID string, claims map[string]interface{}) string {
+		signer, signErr := jose.NewSigner(
+			jose.SigningKey{Algorithm: jose.RS256, Key: key},
+			(&jose.SignerOptions{}).WithType("JWT").WithHeader("kid", keyID),
+		)
+		Expect(signErr).NotTo(HaveOccurred())
+
+		signedToken, signErr := jwt.Signed(signer).Claims(claims).CompactSerialize()
+		Expect(signErr).NotTo(HaveOccurred())
+
+		return signedToken
+	}
+
+	BeforeEach(func() {
+		signingKey, err = rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).NotTo(HaveOccurred())
+		verificationKey = &signingKey.PublicKey
+		keyID = "key-1"
+
+		config = authorization.OIDCConfig{
+			IssuerURL:      issuerURL,
+			ClientID:       "korifi",
+			UsernamePrefix: "oidc:",
+			GroupsClaim:    "groups",
+			GroupsPrefix:   "oidc-group:",
+		}
+
+		fallback = new(fake.TokenIdentityInspector)
+		fallback.WhoAmIReturns(authorization.Identity{Name: "fallback-user", Kind: rbacv1.UserKind}, nil)
+
+		claims = map[string]interface{}{
+			"iss":    issuerURL,
+			"aud":    "korifi",
+			"sub":    "alice",
+			"email":  "alice@example.com",
+			"exp":    time.Now().Add(time.Hour).Unix(),
+			"groups": []string{"developers", "auditors"},
+		}
+	})
+
+	JustBeforeEach(func() {
+		keySet := authorization.NewStaticKeySet(jose.JSONWebKeySet{
+			Keys: []jose.JSONWebKey{{Key: verificationKey, KeyID: "key-1", Algorithm: "RS256", Use: "sig"}},
+		})
+		inspector = authorization.NewOIDCTokenInspector(config, keySet, fallback)
+
+		token = signToken(signingKey, keyID, claims)
+		id, err = inspector.WhoAmI(context.Background(), token)
+	})
+
+	It("extracts the prefixed user and groups from the token", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(id).To(Equal(authorization.Identity{
+			Name:   "oidc:alice",
+			Kind:   rbacv1.UserKind,
+			Groups: []string{"oidc-group:developers", "oidc-group:auditors"},
+		}))
+		Expect(fallback.WhoAmICallCount()).To(BeZero())
+	})
+
+	When("the username claim is configured", func() {
+		BeforeEach(func() {
+			config.UsernameClaim = "email"
+		})
+
+		It("uses that claim as the user name", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id.Name).To(Equal("oidc:alice@example.com"))
+		})
+	})
+
+	When("the token does not have the username claim", func() {
+		BeforeEach(func() {
+			delete(claims, "sub")
+		})
+
+		It("returns an invalid auth error", func() {
+			Expect(err).To(BeAssignableToTypeOf(apierrors.InvalidAuthError{}))
+		})
+	})
+
+	When("the groups claim is a single string", func() {
+		BeforeEach(func() {
+			claims["groups"] = "developers"
+		})
+
+		It("maps it to a single group", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id.Groups).To(ConsistOf("oidc-group:developers"))
+		})
+	})
+
+	When("the groups claim is not configured", func() {
+		BeforeEach(func() {
+			config.GroupsClaim = ""
+		})
+
+		It("does not map any groups", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id.Groups).To(BeEmpty())
+		})
+	})
+
+	When("the token does not specify a key id", func() {
+		BeforeEach(func() {
+			keyID = ""
+		})
+
+		It("tries all keys of the key set", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id.Name).To(Equal("oidc:alice"))
+		})
+	})
+
+	When("the token is signed with an unknown key", func() {
+		BeforeEach(func() {
+			keyID = "key-2"
+		})
+
+		It("returns an invalid auth error", func() {
+			Expect(err).To(BeAssignableToTypeOf(apierrors.InvalidAuthError{}))
+		})
+	})
+
+	When("the token signature does not match the key", func() {
+		BeforeEach(func() {
+			signingKey, err = rsa.GenerateKey(rand.Reader, 2048)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns an invalid auth error", func() {
+			Expect(err).To(BeAssignableToTypeOf(apierrors.InvalidAuthError{}))
+		})
+	})
+
+	When("the token has expired", func() {
+		BeforeEach(func() {
+			claims["exp"] = time.Now().Add(-time.Hour).Unix()
+		})
+
+		It("returns an invalid auth error", func() {
+			Expect(err).To(BeAssignableToTypeOf(apierrors.InvalidAuthError{}))
+		})
+	})
+
+	When("the token was issued for another audience", func() {
+		BeforeEach(func() {
+			claims["aud"] = "another-client"
+		})
+
+		It("returns an invalid auth error", func() {
+			Expect(err).To(BeAssignableToTypeOf(apierrors.InvalidAuthError{}))
+		})
+	})
+
+	When("the token was issued by another issuer", func() {
+		BeforeEach(func() {
+			claims["iss"] = "https://kubernetes.default.svc"
+		})
+
+		It("delegates to the fallback inspector", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id.Name).To(Equal("fallback-user"))
+			Expect(fallback.WhoAmICallCount()).To(Equal(1))
+			_, actualToken := fallback.WhoAmIArgsForCall(0)
+			Expect(actualToken).To(Equal(token))
+		})
+
+		When("the fallback inspector fails", func() {
+			BeforeEach(func() {
+				fallback.WhoAmIReturns(authorization.Identity{}, errors.New("boom"))
+			})
+
+			It("returns the error", func() {
+				Expect(err).To(MatchError("boom"))
+			})
+		})
+	})
+})
@@ -0,0 +1,129 @@
+package authorization
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+)
+
+type OAuthToken struct {
+	AccessToken  string
+	RefreshToken string
+}
+
+// OIDCTokenRefresher redeems refresh tokens at the token endpoint of the OIDC
+// issuer. The ID token of the response becomes the new access token, as that
+// is the token the Kubernetes API server accepts. The token endpoint is
+// discovered once and reused for all refreshes.
+type OIDCTokenRefresher struct {
+	issuerURL    string
+	clientID     string
+	clientSecret string
+	httpClient   *http.Client
+
+	mutex         sync.Mutex
+	tokenEndpoint string
+}
+
+func NewOIDCTokenRefresher(issuerURL, clientID, clientSecret string, httpClient *http.Client) *OIDCTokenRefresher {
+	return &OIDCTokenRefresher{
+		issuerURL:    issuerURL,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		httpClient:   httpClient,
+	}
+}
+
+func (r *OIDCTokenRefresher) Refresh(ctx context.Context, refreshToken string) (OAuthToken, error) {
+	tokenEndpoint, err := r.getTokenEndpoint(ctx)
+	if err != nil {
+		return OAuthToken{}, err
+	}
+
+	form := url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {refreshToken},
+		"client_id":     {r.clientID},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return OAuthToken{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if r.clientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(r.clientID), url.QueryEscape(r.clientSecret))
+	}
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return OAuthToken{}, fmt.Errorf("failed to refresh token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	tokenResponse := struct {
+		IDToken          string `json:"id_token"`
+		RefreshToken     string `json:"refresh_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}{}
+	if err = json.NewDecoder(resp.Body).Decode(&tokenResponse); err != nil {
+		return OAuthToken{}, fmt.Errorf("failed to decode token response with status %d: %w", resp.StatusCode, err)
+	}
+
+	// RFC 6749 returns 400 for rejected grants, e.g. expired or revoked
+	// refresh tokens. The user has to log in again in that case.
+	if resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusUnauthorized {
+		return OAuthToken{}, apierrors.NewInvalidAuthError(fmt.Errorf("refresh token rejected: %s %s", tokenResponse.Error, tokenResponse.ErrorDescription))
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return OAuthToken{}, fmt.Errorf("token endpoint returned status %d", resp.StatusCode)
+	}
+
+	if tokenResponse.IDToken == "" {
+		return OAuthToken{}, errors.New("token response does not contain an id_token")
+	}
+
+	// issuers are not required to rotate refresh tokens
+	if tokenResponse.RefreshToken == "" {
+		tokenResponse.RefreshToken = refreshToken
+	}
+
+	// the expires_in of the response refers to the access token of the issuer,
+	// the expiry of the ID token is read from its exp claim instead
+	return OAuthToken{
+		AccessToken:  tokenResponse.IDToken,
+		RefreshToken: tokenResponse.RefreshToken,
+	}, nil
+}
+
+// getTokenEndpoint discovers the token endpoint of the issuer on first use.
+// Failed discoveries are not cached, so that the next refresh tries again.
+func (r *OIDCTokenRefresher) getTokenEndpoint(ctx context.Context) (string, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.tokenEndpoint != "" {
+		return r.tokenEndpoint, nil
+	}
+
+	discovery, err := discoverOIDCIssuer(ctx, r.httpClient, r.issuerURL)
+	if err != nil {
+		return "", err
+	}
+
+	if discovery.TokenEndpoint == "" {
+		return "", fmt.Errorf("OIDC issuer %q does not advertise a token endpoint", r.issuerURL)
+	}
+
+	r.tokenEndpoint = discovery.TokenEndpoint
+	return r.tokenEndpoint, nil
+}
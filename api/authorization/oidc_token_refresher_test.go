@@ -0,0 +1,164 @@
+package authorization_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("OIDCTokenRefresher", func() {
+	var (
+		server        *ghttp.Server
+		refresher     *authorization.OIDCTokenRefresher
+		clientSecret  string
+		tokenStatus   int
+		tokenResponse map[string]interface{}
+		tokenRequest  *http.Request
+		token         authorization.OAuthToken
+		err           error
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		clientSecret = "client-secret"
+		tokenStatus = http.StatusOK
+		tokenResponse = map[string]interface{}{
+			"access_token":  "upstream-access-token",
+			"id_token":      "new-id-token",
+			"refresh_token": "new-refresh-token",
+			"expires_in":    300,
+		}
+
+		server.RouteToHandler(http.MethodGet, "/.well-known/openid-configuration", ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]string{
+			"issuer":         server.URL(),
+			"token_endpoint": server.URL() + "/token",
+		}))
+		server.RouteToHandler(http.MethodPost, "/token", func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.ParseForm()).To(Succeed())
+			tokenRequest = r
+			ghttp.RespondWithJSONEncoded(tokenStatus, tokenResponse)(w, r)
+		})
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	JustBeforeEach(func() {
+		refresher = authorization.NewOIDCTokenRefresher(server.URL(), "korifi", clientSecret, http.DefaultClient)
+		token, err = refresher.Refresh(context.Background(), "the-refresh-token")
+	})
+
+	It("returns the id token as the access token", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(token).To(Equal(authorization.OAuthToken{
+			AccessToken:  "new-id-token",
+			RefreshToken: "new-refresh-token",
+		}))
+	})
+
+	It("discovers the token endpoint only once", func() {
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = refresher.Refresh(context.Background(), "the-refresh-token")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(server.ReceivedRequests()).To(HaveLen(3))
+		Expect(server.ReceivedRequests()[2].URL.Path).To(Equal("/token"))
+	})
+
+	It("redeems the refresh token with the client credentials", func() {
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(server.ReceivedRequests()).To(HaveLen(2))
+		clientID, secret, ok := tokenRequest.BasicAuth()
+		Expect(ok).To(BeTrue())
+		Expect(clientID).To(Equal("korifi"))
+		Expect(secret).To(Equal("client-secret"))
+
+		Expect(tokenRequest.PostForm).To(Equal(url.Values{
+			"grant_type":    {"refresh_token"},
+			"refresh_token": {"the-refresh-token"},
+			"client_id":     {"korifi"},
+		}))
+	})
+
+	When("the client has no secret", func() {
+		BeforeEach(func() {
+			clientSecret = ""
+		})
+
+		It("does not authenticate the client", func() {
+			Expect(err).NotTo(HaveOccurred())
+			_, _, ok := tokenRequest.BasicAuth()
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	When("the issuer does not rotate the refresh token", func() {
+		BeforeEach(func() {
+			delete(tokenResponse, "refresh_token")
+		})
+
+		It("keeps the original refresh token", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(token.RefreshToken).To(Equal("the-refresh-token"))
+		})
+	})
+
+	When("the response does not contain an id token", func() {
+		BeforeEach(func() {
+			delete(tokenResponse, "id_token")
+		})
+
+		It("returns an error", func() {
+			Expect(err).To(MatchError(ContainSubstring("id_token")))
+		})
+	})
+
+	When("the refresh token is rejected", func() {
+		BeforeEach(func() {
+			tokenStatus = http.StatusBadRequest
+			tokenResponse = map[string]interface{}{
+				"error":             "invalid_grant",
+				"error_description": "Token is not active",
+			}
+		})
+
+		It("returns an invalid auth error", func() {
+			Expect(err).To(BeAssignableToTypeOf(apierrors.InvalidAuthError{}))
+		})
+	})
+
+	When("the discovery fails", func() {
+		BeforeEach(func() {
+			server.RouteToHandler(http.MethodGet, "/.well-known/openid-configuration", ghttp.RespondWith(http.StatusInternalServerError, nil))
+		})
+
+		It("returns an error", func() {
+			Expect(err).To(MatchError(ContainSubstring("failed to discover OIDC issuer")))
+		})
+
+		It("discovers the issuer again on the next refresh", func() {
+			_, err = refresher.Refresh(context.Background(), "the-refresh-token")
+			Expect(err).To(HaveOccurred())
+			Expect(server.ReceivedRequests()).To(HaveLen(2))
+		})
+	})
+
+	When("the token endpoint fails", func() {
+		BeforeEach(func() {
+			tokenStatus = http.StatusInternalServerError
+		})
+
+		It("returns an error", func() {
+			Expect(err).To(MatchError(ContainSubstring("status 500")))
+		})
+	})
+})
@@ -3,6 +3,8 @@ package config
 import (
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/korifi/tools"
@@ -35,8 +37,32 @@ type APIConfig struct {
 
 	RoleMappings map[string]Role `yaml:"roleMappings"`
 
+	RouterGroups []RouterGroup `yaml:"routerGroups"`
+
 	AuthProxyHost   string `yaml:"authProxyHost"`
 	AuthProxyCACert string `yaml:"authProxyCACert"`
+
+	OIDC *OIDCConfig `yaml:"oidc"`
+}
+
+// OIDCConfig configures authentication with bearer tokens issued by an OpenID Connect provider
+type OIDCConfig struct {
+	IssuerURL string `yaml:"issuerURL"`
+	// ClientID is the audience that tokens must have been issued for
+	ClientID string `yaml:"clientID"`
+	// CACert is an optional PEM encoded CA used to verify the TLS certificate of the issuer
+	CACert string `yaml:"caCert"`
+	// JWKS is an optional static JSON web key set used to verify tokens instead of the issuer's published keys.
+	// The issuer is never contacted when it is set, so tokens cannot be refreshed.
+	JWKS string `yaml:"jwks"`
+
+	UsernameClaim  string `yaml:"usernameClaim"`
+	UsernamePrefix string `yaml:"usernamePrefix"`
+	GroupsClaim    string `yaml:"groupsClaim"`
+	GroupsPrefix   string `yaml:"groupsPrefix"`
+
+	// PasscodeURL is where users obtain the token they paste into `cf login --sso`
+	PasscodeURL string `yaml:"passcodeURL"`
 }
 
 type Role struct {
@@ -44,6 +70,80 @@ type Role struct {
 	Propagate bool   `yaml:"propagate"`
 }
 
+// GlobalRoleTypes are the role types that are bound in the root namespace
+// and apply to all orgs and spaces
+var GlobalRoleTypes = []string{"admin", "admin_read_only", "global_auditor"}
+
+func IsGlobalRoleType(roleType string) bool {
+	for _, globalRoleType := range GlobalRoleTypes {
+		if roleType == globalRoleType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GlobalClusterRoles returns the names of the cluster roles the global role
+// types are mapped to
+func (c *APIConfig) GlobalClusterRoles() []string {
+	var clusterRoles []string
+	for _, roleType := range GlobalRoleTypes {
+		if role, ok := c.RoleMappings[roleType]; ok {
+			clusterRoles = append(clusterRoles, role.Name)
+		}
+	}
+
+	return clusterRoles
+}
+
+// RouterGroup is a named set of ports that routes on tcp domains can be reserved from
+type RouterGroup struct {
+	Name string `yaml:"name"`
+	// ReservablePorts is a comma separated list of ports and port ranges, e.g. "1024-1033,2000"
+	ReservablePorts string `yaml:"reservablePorts"`
+}
+
+// Ports returns all the ports in the ReservablePorts of the router group
+func (g RouterGroup) Ports() ([]int, error) {
+	var ports []int
+	for _, portRange := range strings.Split(g.ReservablePorts, ",") {
+		bounds := strings.SplitN(strings.TrimSpace(portRange), "-", 2)
+
+		from, err := parsePort(bounds[0])
+		if err != nil {
+			return nil, err
+		}
+
+		to := from
+		if len(bounds) == 2 {
+			to, err = parsePort(bounds[1])
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if from > to {
+			return nil, fmt.Errorf("invalid port range %q", portRange)
+		}
+
+		for port := from; port <= to; port++ {
+			ports = append(ports, port)
+		}
+	}
+
+	return ports, nil
+}
+
+func parsePort(value string) (int, error) {
+	port, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || port < 1 || port > 65535 {
+		return 0, fmt.Errorf("invalid port %q", value)
+	}
+
+	return port, nil
+}
+
 // DefaultLifecycleConfig contains default values of the Lifecycle block of CFApps and Builds created by the Shim
 type DefaultLifecycleConfig struct {
 	Type            string `yaml:"type"`
@@ -95,6 +195,32 @@ func (c *APIConfig) validate() error {
 		return errors.New("BuilderName must have a value")
 	}
 
+	routerGroupNames := map[string]bool{}
+	for _, routerGroup := range c.RouterGroups {
+		if routerGroup.Name == "" {
+			return errors.New("RouterGroups must have a name")
+		}
+
+		if routerGroupNames[routerGroup.Name] {
+			return fmt.Errorf("duplicate router group %q", routerGroup.Name)
+		}
+		routerGroupNames[routerGroup.Name] = true
+
+		if _, err := routerGroup.Ports(); err != nil {
+			return fmt.Errorf("invalid reservable ports for router group %q: %w", routerGroup.Name, err)
+		}
+	}
+
+	if c.OIDC != nil {
+		if c.OIDC.IssuerURL == "" {
+			return errors.New("OIDC requires a value for IssuerURL")
+		}
+
+		if c.OIDC.ClientID == "" {
+			return errors.New("OIDC requires a value for ClientID")
+		}
+	}
+
 	return nil
 }
 
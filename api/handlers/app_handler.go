@@ -31,6 +31,8 @@ const (
 	AppRestartPath                    = "/v3/apps/{guid}/actions/restart"
 	AppEnvVarsPath                    = "/v3/apps/{guid}/environment_variables"
 	AppEnvPath                        = "/v3/apps/{guid}/env"
+	AppFeaturesPath                   = "/v3/apps/{guid}/features"
+	AppFeaturePath                    = "/v3/apps/{guid}/features/{name}"
 	invalidDropletMsg                 = "Unable to assign current droplet. Ensure the droplet exists and belongs to this app."
 
 	AppStartedState = "STARTED"
@@ -48,6 +50,7 @@ type CFAppRepository interface {
 	DeleteApp(context.Context, authorization.Info, repositories.DeleteAppMessage) error
 	GetAppEnv(context.Context, authorization.Info, string) (repositories.AppEnvRecord, error)
 	PatchAppMetadata(context.Context, authorization.Info, repositories.PatchAppMetadataMessage) (repositories.AppRecord, error)
+	PatchAppFeatures(context.Context, authorization.Info, repositories.PatchAppFeaturesMessage) (repositories.AppRecord, error)
 }
 
 //counterfeiter:generate -o fake -fake-name AppProcessScaler . AppProcessScaler
@@ -485,6 +488,65 @@ func (h *AppHandler) appPatchHandler(ctx context.Context, logger logr.Logger, au
 	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForApp(app, h.serverURL)), nil
 }
 
+func (h *AppHandler) appListFeaturesHandler(ctx context.Context, logger logr.Logger, authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	appGUID := mux.Vars(r)["guid"]
+
+	app, err := h.appRepo.GetApp(ctx, authInfo, appGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch app from Kubernetes", "AppGUID", appGUID)
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForAppFeatureList(app, h.serverURL, *r.URL)), nil
+}
+
+func (h *AppHandler) appGetFeatureHandler(ctx context.Context, logger logr.Logger, authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	vars := mux.Vars(r)
+	appGUID := vars["guid"]
+	featureName := vars["name"]
+
+	if !isSupportedAppFeature(featureName) {
+		return nil, apierrors.LogAndReturn(logger, apierrors.NewNotFoundError(nil, "Feature"), "Unsupported app feature", "feature", featureName)
+	}
+
+	app, err := h.appRepo.GetApp(ctx, authInfo, appGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch app from Kubernetes", "AppGUID", appGUID)
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForAppFeature(app, featureName)), nil
+}
+
+func (h *AppHandler) appPatchFeatureHandler(ctx context.Context, logger logr.Logger, authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	vars := mux.Vars(r)
+	appGUID := vars["guid"]
+	featureName := vars["name"]
+
+	if !isSupportedAppFeature(featureName) {
+		return nil, apierrors.LogAndReturn(logger, apierrors.NewNotFoundError(nil, "Feature"), "Unsupported app feature", "feature", featureName)
+	}
+
+	var payload payloads.AppFeaturePatch
+	if err := h.decoderValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	app, err := h.appRepo.GetApp(ctx, authInfo, appGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch app from Kubernetes", "AppGUID", appGUID)
+	}
+
+	app, err = h.appRepo.PatchAppFeatures(ctx, authInfo, payload.ToMessage(appGUID, app.SpaceGUID, featureName))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to patch app features", "AppGUID", appGUID)
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForAppFeature(app, featureName)), nil
+}
+
+func isSupportedAppFeature(featureName string) bool {
+	return featureName == repositories.AppFeatureServiceBindingK8s
+}
+
 func (h *AppHandler) RegisterRoutes(router *mux.Router) {
 	router.Path(AppPath).Methods("GET").HandlerFunc(h.handlerWrapper.Wrap(h.appGetHandler))
 	router.Path(AppsPath).Methods("GET").HandlerFunc(h.handlerWrapper.Wrap(h.appListHandler))
@@ -502,4 +564,7 @@ func (h *AppHandler) RegisterRoutes(router *mux.Router) {
 	router.Path(AppEnvVarsPath).Methods("PATCH").HandlerFunc(h.handlerWrapper.Wrap(h.appPatchEnvVarsHandler))
 	router.Path(AppEnvPath).Methods("GET").HandlerFunc(h.handlerWrapper.Wrap(h.appGetEnvHandler))
 	router.Path(AppPath).Methods("PATCH").HandlerFunc(h.handlerWrapper.Wrap(h.appPatchHandler))
+	router.Path(AppFeaturesPath).Methods("GET").HandlerFunc(h.handlerWrapper.Wrap(h.appListFeaturesHandler))
+	router.Path(AppFeaturePath).Methods("GET").HandlerFunc(h.handlerWrapper.Wrap(h.appGetFeatureHandler))
+	router.Path(AppFeaturePath).Methods("PATCH").HandlerFunc(h.handlerWrapper.Wrap(h.appPatchFeatureHandler))
 }
@@ -16,6 +16,7 @@ import (
 	. "code.cloudfoundry.org/korifi/api/handlers"
 	"code.cloudfoundry.org/korifi/api/handlers/fake"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -3168,6 +3169,163 @@ var _ = Describe("AppHandler", func() {
 			})
 		})
 	})
+
+	Describe("the GET /v3/apps/:guid/features endpoint", func() {
+		BeforeEach(func() {
+			appRepo.GetAppReturns(repositories.AppRecord{
+				GUID:      appGUID,
+				SpaceGUID: spaceGUID,
+				Features:  repositories.AppFeatures{ServiceBindingK8sEnabled: true},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/apps/"+appGUID+"/features", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the app features", func() {
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(rr.Body.String()).To(MatchJSON(fmt.Sprintf(`{
+				"pagination": {
+					"total_results": 1,
+					"total_pages": 1,
+					"first": {"href": "%[1]s/v3/apps/%[2]s/features"},
+					"last": {"href": "%[1]s/v3/apps/%[2]s/features"},
+					"next": null,
+					"previous": null
+				},
+				"resources": [{
+					"name": "service-binding-k8s",
+					"description": "Enable k8s service bindings for the app",
+					"enabled": true
+				}]
+			}`, defaultServerURL, appGUID)))
+		})
+
+		When("the app is not accessible", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("App not found")
+			})
+		})
+	})
+
+	Describe("the GET /v3/apps/:guid/features/:name endpoint", func() {
+		queueGetRequest := func(featureName string) {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/apps/"+appGUID+"/features/"+featureName, nil)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		BeforeEach(func() {
+			appRepo.GetAppReturns(repositories.AppRecord{
+				GUID:      appGUID,
+				SpaceGUID: spaceGUID,
+				Features:  repositories.AppFeatures{ServiceBindingK8sEnabled: false},
+			}, nil)
+			queueGetRequest("service-binding-k8s")
+		})
+
+		It("returns the feature", func() {
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(rr.Body.String()).To(MatchJSON(`{
+				"name": "service-binding-k8s",
+				"description": "Enable k8s service bindings for the app",
+				"enabled": false
+			}`))
+		})
+
+		When("the feature is not supported", func() {
+			BeforeEach(func() {
+				queueGetRequest("ssh")
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Feature not found")
+			})
+		})
+	})
+
+	Describe("the PATCH /v3/apps/:guid/features/:name endpoint", func() {
+		queuePatchRequest := func(featureName, requestBody string) {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "PATCH", "/v3/apps/"+appGUID+"/features/"+featureName, strings.NewReader(requestBody))
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		BeforeEach(func() {
+			appRepo.GetAppReturns(repositories.AppRecord{GUID: appGUID, SpaceGUID: spaceGUID}, nil)
+			appRepo.PatchAppFeaturesReturns(repositories.AppRecord{
+				GUID:      appGUID,
+				SpaceGUID: spaceGUID,
+				Features:  repositories.AppFeatures{ServiceBindingK8sEnabled: false},
+			}, nil)
+			queuePatchRequest("service-binding-k8s", `{"enabled": false}`)
+		})
+
+		It("patches the app feature", func() {
+			Expect(appRepo.PatchAppFeaturesCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := appRepo.PatchAppFeaturesArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.PatchAppFeaturesMessage{
+				AppGUID:                  appGUID,
+				SpaceGUID:                spaceGUID,
+				ServiceBindingK8sEnabled: tools.PtrTo(false),
+			}))
+		})
+
+		It("returns the updated feature", func() {
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(rr.Body.String()).To(MatchJSON(`{
+				"name": "service-binding-k8s",
+				"description": "Enable k8s service bindings for the app",
+				"enabled": false
+			}`))
+		})
+
+		When("enabled is missing", func() {
+			BeforeEach(func() {
+				queuePatchRequest("service-binding-k8s", `{}`)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Enabled is a required field")
+			})
+		})
+
+		When("the feature is not supported", func() {
+			BeforeEach(func() {
+				queuePatchRequest("ssh", `{"enabled": false}`)
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Feature not found")
+			})
+		})
+
+		When("the app is not accessible", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("App not found")
+			})
+		})
+
+		When("patching the app fails", func() {
+			BeforeEach(func() {
+				appRepo.PatchAppFeaturesReturns(repositories.AppRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
 })
 
 func initializeCreateAppRequestBody(appName, spaceGUID string, envVars, labels, annotations map[string]string) string {
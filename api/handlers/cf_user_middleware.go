@@ -87,7 +87,7 @@ func (m *CFUserMiddleware) isCFUser(ctx context.Context, identity authorization.
 
 	for _, rb := range roleBindings.Items {
 		for _, subj := range rb.Subjects {
-			if subj.Name == identity.Name && subj.Kind == identity.Kind {
+			if identity.Matches(subj) {
 				m.cfUserCache.Set(identity.Hash(), struct{}{}, cacheTTL)
 				return true, nil
 			}
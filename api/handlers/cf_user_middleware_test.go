@@ -148,6 +148,35 @@ var _ = Describe("CfUserMiddleware", func() {
 		})
 	})
 
+	When("a group of the user has a rolebinding in the root namespace", func() {
+		BeforeEach(func() {
+			k8sClient.ListStub = func(_ context.Context, objectsList client.ObjectList, _ ...client.ListOption) error {
+				rbList, ok := objectsList.(*rbacv1.RoleBindingList)
+				Expect(ok).To(BeTrue())
+				*rbList = rbacv1.RoleBindingList{
+					Items: []rbacv1.RoleBinding{{
+						Subjects: []rbacv1.Subject{{
+							Kind: rbacv1.GroupKind,
+							Name: "developers",
+						}},
+					}},
+				}
+
+				return nil
+			}
+			identityProvider.GetIdentityReturns(authorization.Identity{
+				Name:   "jim",
+				Kind:   rbacv1.UserKind,
+				Groups: []string{"developers"},
+			}, nil)
+		})
+
+		It("does not set the X-Cf-Warning header", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusTeapot))
+			Expect(rr.Header().Get("X-Cf-Warnings")).To(BeEmpty())
+		})
+	})
+
 	When("the subject kind does not match", func() {
 		BeforeEach(func() {
 			identityProvider.GetIdentityReturns(authorization.Identity{
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/url"
 
@@ -18,8 +19,11 @@ import (
 )
 
 const (
-	DomainsPath = "/v3/domains"
-	DomainPath  = "/v3/domains/{guid}"
+	DomainsPath                   = "/v3/domains"
+	DomainPath                    = "/v3/domains/{guid}"
+	DomainSharedOrganizationsPath = "/v3/domains/{guid}/relationships/shared_organizations"
+	DomainSharedOrganizationPath  = "/v3/domains/{guid}/relationships/shared_organizations/{org_guid}"
+	DomainRouteReservationsPath   = "/v3/domains/{guid}/route_reservations"
 )
 
 //counterfeiter:generate -o fake -fake-name CFDomainRepository . CFDomainRepository
@@ -30,6 +34,9 @@ type CFDomainRepository interface {
 	UpdateDomain(context.Context, authorization.Info, repositories.UpdateDomainMessage) (repositories.DomainRecord, error)
 	ListDomains(context.Context, authorization.Info, repositories.ListDomainsMessage) ([]repositories.DomainRecord, error)
 	DeleteDomain(context.Context, authorization.Info, string) error
+	ShareDomain(context.Context, authorization.Info, repositories.ShareDomainMessage) (repositories.DomainRecord, error)
+	UnshareDomain(context.Context, authorization.Info, repositories.UnshareDomainMessage) error
+	IsRouteReserved(context.Context, authorization.Info, repositories.RouteReservationMessage) (bool, error)
 }
 
 type DomainHandler struct {
@@ -37,18 +44,24 @@ type DomainHandler struct {
 	serverURL            url.URL
 	requestJSONValidator RequestJSONValidator
 	domainRepo           CFDomainRepository
+	orgRepo              CFOrgRepository
+	routerGroupRepo      RouterGroupRepository
 }
 
 func NewDomainHandler(
 	serverURL url.URL,
 	requestJSONValidator RequestJSONValidator,
 	domainRepo CFDomainRepository,
+	orgRepo CFOrgRepository,
+	routerGroupRepo RouterGroupRepository,
 ) *DomainHandler {
 	return &DomainHandler{
 		handlerWrapper:       NewAuthAwareHandlerFuncWrapper(ctrl.Log.WithName("DomainHandler")),
 		serverURL:            serverURL,
 		requestJSONValidator: requestJSONValidator,
 		domainRepo:           domainRepo,
+		orgRepo:              orgRepo,
+		routerGroupRepo:      routerGroupRepo,
 	}
 }
 
@@ -64,6 +77,23 @@ func (h *DomainHandler) domainCreateHandler(ctx context.Context, logger logr.Log
 		return nil, apierrors.LogAndReturn(logger, apierr, apierr.Detail())
 	}
 
+	if domainCreateMessage.OrganizationGUID != "" {
+		orgGUIDs := append([]string{domainCreateMessage.OrganizationGUID}, domainCreateMessage.SharedOrganizationGUIDs...)
+		if err = h.checkOrgsExist(ctx, logger, authInfo, orgGUIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	if domainCreateMessage.RouterGroupGUID != "" {
+		if _, err = h.routerGroupRepo.GetRouterGroup(ctx, domainCreateMessage.RouterGroupGUID); err != nil {
+			return nil, apierrors.LogAndReturn(
+				logger,
+				apierrors.AsUnprocessableEntity(err, fmt.Sprintf("Router group with guid '%s' not found.", domainCreateMessage.RouterGroupGUID), apierrors.NotFoundError{}),
+				"Failed to fetch router group", "routerGroupGUID", domainCreateMessage.RouterGroupGUID,
+			)
+		}
+	}
+
 	domain, err := h.domainRepo.CreateDomain(ctx, authInfo, domainCreateMessage)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Error creating domain in repository")
@@ -140,10 +170,121 @@ func (h *DomainHandler) domainDeleteHandler(ctx context.Context, logger logr.Log
 	), nil
 }
 
+func (h *DomainHandler) domainShareHandler(ctx context.Context, logger logr.Logger, authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	domainGUID := mux.Vars(r)["guid"]
+
+	var payload payloads.DomainShare
+	if err := h.requestJSONValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	domain, err := h.domainRepo.GetDomain(ctx, authInfo, domainGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error getting domain in repository")
+	}
+
+	if !domain.IsPrivate() {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "Domains can not be shared with other organizations unless they are scoped to an organization."),
+			"Cannot share a shared domain", "guid", domainGUID,
+		)
+	}
+
+	message := payload.ToMessage(domainGUID)
+	for _, orgGUID := range message.SharedOrganizationGUIDs {
+		if orgGUID == domain.OrganizationGUID {
+			return nil, apierrors.LogAndReturn(
+				logger,
+				apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("Unable to share domain %s with organization %s. Domains cannot be shared with the organization that owns them.", domain.Name, orgGUID)),
+				"Cannot share domain with its owning org", "guid", domainGUID,
+			)
+		}
+	}
+
+	if err = h.checkOrgsExist(ctx, logger, authInfo, message.SharedOrganizationGUIDs); err != nil {
+		return nil, err
+	}
+
+	domain, err = h.domainRepo.ShareDomain(ctx, authInfo, message)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to share domain", "guid", domainGUID)
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForDomainSharedOrganizations(domain)), nil
+}
+
+func (h *DomainHandler) domainUnshareHandler(ctx context.Context, logger logr.Logger, authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	vars := mux.Vars(r)
+	domainGUID := vars["guid"]
+	orgGUID := vars["org_guid"]
+
+	domain, err := h.domainRepo.GetDomain(ctx, authInfo, domainGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error getting domain in repository")
+	}
+
+	if !domain.IsSharedWith(orgGUID) {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("Unable to unshare domain from organization %s. Ensure the domain is shared to this organization.", orgGUID)),
+			"Domain is not shared with org", "guid", domainGUID, "orgGUID", orgGUID,
+		)
+	}
+
+	err = h.domainRepo.UnshareDomain(ctx, authInfo, repositories.UnshareDomainMessage{
+		GUID:                   domainGUID,
+		SharedOrganizationGUID: orgGUID,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to unshare domain", "guid", domainGUID, "orgGUID", orgGUID)
+	}
+
+	return NewHandlerResponse(http.StatusNoContent), nil
+}
+
+func (h *DomainHandler) domainRouteReservationsHandler(ctx context.Context, logger logr.Logger, authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	domainGUID := mux.Vars(r)["guid"]
+
+	if err := r.ParseForm(); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to parse request query parameters")
+	}
+
+	reservationFilter := new(payloads.RouteReservationList)
+	if err := payloads.Decode(reservationFilter, r.Form); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	matchingRoute, err := h.domainRepo.IsRouteReserved(ctx, authInfo, reservationFilter.ToMessage(domainGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to check route reservation", "guid", domainGUID)
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForRouteReservation(matchingRoute)), nil
+}
+
+func (h *DomainHandler) checkOrgsExist(ctx context.Context, logger logr.Logger, authInfo authorization.Info, orgGUIDs []string) error {
+	for _, orgGUID := range orgGUIDs {
+		_, err := h.orgRepo.GetOrg(ctx, authInfo, orgGUID)
+		if err != nil {
+			return apierrors.LogAndReturn(
+				logger,
+				apierrors.AsUnprocessableEntity(err, fmt.Sprintf("Organization with guid '%s' does not exist or you do not have access to it.", orgGUID), apierrors.NotFoundError{}, apierrors.ForbiddenError{}),
+				"Failed to fetch org", "orgGUID", orgGUID,
+			)
+		}
+	}
+
+	return nil
+}
+
 func (h *DomainHandler) RegisterRoutes(router *mux.Router) {
 	router.Path(DomainsPath).Methods("POST").HandlerFunc(h.handlerWrapper.Wrap(h.domainCreateHandler))
 	router.Path(DomainPath).Methods("GET").HandlerFunc(h.handlerWrapper.Wrap(h.domainGetHandler))
 	router.Path(DomainPath).Methods("PATCH").HandlerFunc(h.handlerWrapper.Wrap(h.domainUpdateHandler))
 	router.Path(DomainsPath).Methods("GET").HandlerFunc(h.handlerWrapper.Wrap(h.domainListHandler))
 	router.Path(DomainPath).Methods("DELETE").HandlerFunc(h.handlerWrapper.Wrap(h.domainDeleteHandler))
+	router.Path(DomainSharedOrganizationsPath).Methods("POST").HandlerFunc(h.handlerWrapper.Wrap(h.domainShareHandler))
+	router.Path(DomainSharedOrganizationPath).Methods("DELETE").HandlerFunc(h.handlerWrapper.Wrap(h.domainUnshareHandler))
+	router.Path(DomainRouteReservationsPath).Methods("GET").HandlerFunc(h.handlerWrapper.Wrap(h.domainRouteReservationsHandler))
 }
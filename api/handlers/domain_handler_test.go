@@ -1,6 +1,7 @@
 package handlers_test
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -21,6 +22,8 @@ var _ = Describe("DomainHandler", func() {
 	var (
 		domainHandler        *handlers.DomainHandler
 		domainRepo           *fake.CFDomainRepository
+		orgRepo              *fake.OrgRepository
+		routerGroupRepo      *fake.RouterGroupRepository
 		requestJSONValidator *fake.RequestJSONValidator
 		req                  *http.Request
 	)
@@ -28,10 +31,14 @@ var _ = Describe("DomainHandler", func() {
 	BeforeEach(func() {
 		requestJSONValidator = new(fake.RequestJSONValidator)
 		domainRepo = new(fake.CFDomainRepository)
+		orgRepo = new(fake.OrgRepository)
+		routerGroupRepo = new(fake.RouterGroupRepository)
 		domainHandler = handlers.NewDomainHandler(
 			*serverURL,
 			requestJSONValidator,
 			domainRepo,
+			orgRepo,
+			routerGroupRepo,
 		)
 		domainHandler.RegisterRoutes(router)
 	})
@@ -151,10 +158,11 @@ var _ = Describe("DomainHandler", func() {
 		When("the decoded payload is not valid", func() {
 			BeforeEach(func() {
 				payload.Internal = true
+				payload.RouterGroup = &payloads.RelationshipData{GUID: "default-tcp"}
 			})
 
 			It("returns an error", func() {
-				expectUnprocessableEntityError("Error converting domain payload to repository message: internal domains are not supported")
+				expectUnprocessableEntityError("Error converting domain payload to repository message: internal domains cannot have a router group")
 			})
 		})
 
@@ -167,6 +175,105 @@ var _ = Describe("DomainHandler", func() {
 				expectUnknownError()
 			})
 		})
+
+		When("the domain is owned by an org", func() {
+			BeforeEach(func() {
+				payload.Relationships = payloads.DomainRelationships{
+					Organization: &payloads.Relationship{Data: &payloads.RelationshipData{GUID: "org-guid"}},
+					SharedOrganizations: &payloads.DomainSharedOrganizations{
+						Data: []payloads.RelationshipData{{GUID: "shared-org-guid"}},
+					},
+				}
+
+				domainRepo.CreateDomainReturns(repositories.DomainRecord{
+					Name:                    "my.domain",
+					GUID:                    "domain-guid",
+					OrganizationGUID:        "org-guid",
+					SharedOrganizationGUIDs: []string{"shared-org-guid"},
+				}, nil)
+			})
+
+			It("checks the orgs exist", func() {
+				Expect(orgRepo.GetOrgCallCount()).To(Equal(2))
+				_, _, orgGUID := orgRepo.GetOrgArgsForCall(0)
+				Expect(orgGUID).To(Equal("org-guid"))
+				_, _, orgGUID = orgRepo.GetOrgArgsForCall(1)
+				Expect(orgGUID).To(Equal("shared-org-guid"))
+			})
+
+			It("creates a private domain", func() {
+				Expect(domainRepo.CreateDomainCallCount()).To(Equal(1))
+				_, _, createMessage := domainRepo.CreateDomainArgsForCall(0)
+				Expect(createMessage.OrganizationGUID).To(Equal("org-guid"))
+				Expect(createMessage.SharedOrganizationGUIDs).To(ConsistOf("shared-org-guid"))
+			})
+
+			It("returns the org relationships", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+				var bodyJSON map[string]interface{}
+				Expect(json.Unmarshal(rr.Body.Bytes(), &bodyJSON)).To(Succeed())
+				Expect(bodyJSON["relationships"]).To(Equal(map[string]interface{}{
+					"organization":         map[string]interface{}{"data": map[string]interface{}{"guid": "org-guid"}},
+					"shared_organizations": map[string]interface{}{"data": []interface{}{map[string]interface{}{"guid": "shared-org-guid"}}},
+				}))
+			})
+
+			When("an org does not exist", func() {
+				BeforeEach(func() {
+					orgRepo.GetOrgReturnsOnCall(1, repositories.OrgRecord{}, apierrors.NewNotFoundError(nil, repositories.OrgResourceType))
+				})
+
+				It("returns an unprocessable entity error", func() {
+					expectUnprocessableEntityError("Organization with guid 'shared-org-guid' does not exist or you do not have access to it.")
+					Expect(domainRepo.CreateDomainCallCount()).To(Equal(0))
+				})
+			})
+		})
+		When("the domain has a router group", func() {
+			BeforeEach(func() {
+				payload.RouterGroup = &payloads.RelationshipData{GUID: "default-tcp"}
+
+				domainRepo.CreateDomainReturns(repositories.DomainRecord{
+					Name:            "my.domain",
+					GUID:            "domain-guid",
+					RouterGroupGUID: "default-tcp",
+				}, nil)
+			})
+
+			It("checks the router group exists", func() {
+				Expect(routerGroupRepo.GetRouterGroupCallCount()).To(Equal(1))
+				_, routerGroupGUID := routerGroupRepo.GetRouterGroupArgsForCall(0)
+				Expect(routerGroupGUID).To(Equal("default-tcp"))
+			})
+
+			It("creates a tcp domain", func() {
+				Expect(domainRepo.CreateDomainCallCount()).To(Equal(1))
+				_, _, createMessage := domainRepo.CreateDomainArgsForCall(0)
+				Expect(createMessage.RouterGroupGUID).To(Equal("default-tcp"))
+			})
+
+			It("returns the router group and tcp protocol", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+				var bodyJSON map[string]interface{}
+				Expect(json.Unmarshal(rr.Body.Bytes(), &bodyJSON)).To(Succeed())
+				Expect(bodyJSON["router_group"]).To(Equal(map[string]interface{}{"guid": "default-tcp"}))
+				Expect(bodyJSON["supported_protocols"]).To(Equal([]interface{}{"tcp"}))
+				Expect(bodyJSON["links"]).To(HaveKeyWithValue("router_group", map[string]interface{}{
+					"href": "https://api.example.org/routing/v1/router_groups/default-tcp",
+				}))
+			})
+
+			When("the router group does not exist", func() {
+				BeforeEach(func() {
+					routerGroupRepo.GetRouterGroupReturns(repositories.RouterGroupRecord{}, apierrors.NewNotFoundError(nil, repositories.RouterGroupResourceType))
+				})
+
+				It("returns an unprocessable entity error", func() {
+					expectUnprocessableEntityError("Router group with guid 'default-tcp' not found.")
+					Expect(domainRepo.CreateDomainCallCount()).To(Equal(0))
+				})
+			})
+		})
 	})
 
 	Describe("GET /v3/domains/:guid", func() {
@@ -542,4 +649,235 @@ var _ = Describe("DomainHandler", func() {
 			})
 		})
 	})
+
+	Describe("POST /v3/domains/:guid/relationships/shared_organizations", func() {
+		var payload payloads.DomainShare
+
+		BeforeEach(func() {
+			payload = payloads.DomainShare{
+				Data: []payloads.RelationshipData{{GUID: "shared-org-guid"}},
+			}
+			requestJSONValidator.DecodeAndValidateJSONPayloadStub = func(_ *http.Request, i interface{}) error {
+				share, ok := i.(*payloads.DomainShare)
+				Expect(ok).To(BeTrue())
+				*share = payload
+
+				return nil
+			}
+
+			domainRepo.GetDomainReturns(repositories.DomainRecord{
+				Name:             "my.domain",
+				GUID:             "domain-guid",
+				OrganizationGUID: "org-guid",
+			}, nil)
+			domainRepo.ShareDomainReturns(repositories.DomainRecord{
+				Name:                    "my.domain",
+				GUID:                    "domain-guid",
+				OrganizationGUID:        "org-guid",
+				SharedOrganizationGUIDs: []string{"shared-org-guid"},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/domains/domain-guid/relationships/shared_organizations", strings.NewReader(""))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("shares the domain", func() {
+			Expect(orgRepo.GetOrgCallCount()).To(Equal(1))
+			_, _, orgGUID := orgRepo.GetOrgArgsForCall(0)
+			Expect(orgGUID).To(Equal("shared-org-guid"))
+
+			Expect(domainRepo.ShareDomainCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := domainRepo.ShareDomainArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.ShareDomainMessage{
+				GUID:                    "domain-guid",
+				SharedOrganizationGUIDs: []string{"shared-org-guid"},
+			}))
+		})
+
+		It("returns the shared orgs", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr.Body.String()).To(MatchJSON(`{"data": [{"guid": "shared-org-guid"}]}`))
+		})
+
+		When("the domain is not private", func() {
+			BeforeEach(func() {
+				domainRepo.GetDomainReturns(repositories.DomainRecord{GUID: "domain-guid"}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Domains can not be shared with other organizations unless they are scoped to an organization.")
+				Expect(domainRepo.ShareDomainCallCount()).To(Equal(0))
+			})
+		})
+
+		When("sharing with the owning org", func() {
+			BeforeEach(func() {
+				payload.Data = []payloads.RelationshipData{{GUID: "org-guid"}}
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Unable to share domain my.domain with organization org-guid. Domains cannot be shared with the organization that owns them.")
+			})
+		})
+
+		When("the org does not exist", func() {
+			BeforeEach(func() {
+				orgRepo.GetOrgReturns(repositories.OrgRecord{}, apierrors.NewForbiddenError(nil, repositories.OrgResourceType))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Organization with guid 'shared-org-guid' does not exist or you do not have access to it.")
+			})
+		})
+
+		When("the domain does not exist", func() {
+			BeforeEach(func() {
+				domainRepo.GetDomainReturns(repositories.DomainRecord{}, apierrors.NewForbiddenError(nil, repositories.DomainResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Domain not found")
+			})
+		})
+
+		When("sharing the domain fails", func() {
+			BeforeEach(func() {
+				domainRepo.ShareDomainReturns(repositories.DomainRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("DELETE /v3/domains/:guid/relationships/shared_organizations/:org_guid", func() {
+		BeforeEach(func() {
+			domainRepo.GetDomainReturns(repositories.DomainRecord{
+				GUID:                    "domain-guid",
+				OrganizationGUID:        "org-guid",
+				SharedOrganizationGUIDs: []string{"shared-org-guid"},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "DELETE", "/v3/domains/domain-guid/relationships/shared_organizations/shared-org-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("unshares the domain", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+
+			Expect(domainRepo.UnshareDomainCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := domainRepo.UnshareDomainArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.UnshareDomainMessage{
+				GUID:                   "domain-guid",
+				SharedOrganizationGUID: "shared-org-guid",
+			}))
+		})
+
+		When("the domain is not shared with the org", func() {
+			BeforeEach(func() {
+				domainRepo.GetDomainReturns(repositories.DomainRecord{GUID: "domain-guid", OrganizationGUID: "org-guid"}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Unable to unshare domain from organization shared-org-guid. Ensure the domain is shared to this organization.")
+				Expect(domainRepo.UnshareDomainCallCount()).To(Equal(0))
+			})
+		})
+
+		When("unsharing the domain fails", func() {
+			BeforeEach(func() {
+				domainRepo.UnshareDomainReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+	Describe("GET /v3/domains/:guid/route_reservations", func() {
+		BeforeEach(func() {
+			domainRepo.IsRouteReservedReturns(true, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/domains/domain-guid/route_reservations?host=my-host&path=/my-path", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("checks whether the route is reserved", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(MatchJSON(`{"matching_route": true}`)))
+
+			Expect(domainRepo.IsRouteReservedCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := domainRepo.IsRouteReservedArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.RouteReservationMessage{
+				DomainGUID: "domain-guid",
+				Host:       "my-host",
+				Path:       "/my-path",
+			}))
+		})
+
+		When("the route is not reserved", func() {
+			BeforeEach(func() {
+				domainRepo.IsRouteReservedReturns(false, nil)
+			})
+
+			It("returns no matching route", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(MatchJSON(`{"matching_route": false}`)))
+			})
+		})
+
+		When("a port is requested", func() {
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequestWithContext(ctx, "GET", "/v3/domains/domain-guid/route_reservations?port=1234", nil)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("passes the port to the repository", func() {
+				Expect(domainRepo.IsRouteReservedCallCount()).To(Equal(1))
+				_, _, message := domainRepo.IsRouteReservedArgsForCall(0)
+				Expect(message.Port).To(Equal(1234))
+			})
+		})
+
+		When("an unsupported query parameter is passed", func() {
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequestWithContext(ctx, "GET", "/v3/domains/domain-guid/route_reservations?foo=bar", nil)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns an unknown key error", func() {
+				expectUnknownKeyError("The query parameter is invalid: Valid parameters are: 'host, path, port'")
+			})
+		})
+
+		When("the domain does not exist", func() {
+			BeforeEach(func() {
+				domainRepo.IsRouteReservedReturns(false, apierrors.NewForbiddenError(nil, repositories.DomainResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Domain not found")
+			})
+		})
+
+		When("checking the reservation fails", func() {
+			BeforeEach(func() {
+				domainRepo.IsRouteReservedReturns(false, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
 })
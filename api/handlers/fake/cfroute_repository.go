@@ -130,6 +130,64 @@ type CFRouteRepository struct {
 		result1 repositories.RouteRecord
 		result2 error
 	}
+	ReplaceRouteDestinationsStub        func(context.Context, authorization.Info, repositories.ReplaceRouteDestinationsMessage) (repositories.RouteRecord, error)
+	replaceRouteDestinationsMutex       sync.RWMutex
+	replaceRouteDestinationsArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ReplaceRouteDestinationsMessage
+	}
+	replaceRouteDestinationsReturns struct {
+		result1 repositories.RouteRecord
+		result2 error
+	}
+	replaceRouteDestinationsReturnsOnCall map[int]struct {
+		result1 repositories.RouteRecord
+		result2 error
+	}
+	ShareRouteStub        func(context.Context, authorization.Info, repositories.ShareRouteMessage) (repositories.RouteRecord, error)
+	shareRouteMutex       sync.RWMutex
+	shareRouteArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ShareRouteMessage
+	}
+	shareRouteReturns struct {
+		result1 repositories.RouteRecord
+		result2 error
+	}
+	shareRouteReturnsOnCall map[int]struct {
+		result1 repositories.RouteRecord
+		result2 error
+	}
+	TransferRouteStub        func(context.Context, authorization.Info, repositories.TransferRouteMessage) (repositories.RouteRecord, error)
+	transferRouteMutex       sync.RWMutex
+	transferRouteArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.TransferRouteMessage
+	}
+	transferRouteReturns struct {
+		result1 repositories.RouteRecord
+		result2 error
+	}
+	transferRouteReturnsOnCall map[int]struct {
+		result1 repositories.RouteRecord
+		result2 error
+	}
+	UnshareRouteStub        func(context.Context, authorization.Info, repositories.UnshareRouteMessage) error
+	unshareRouteMutex       sync.RWMutex
+	unshareRouteArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UnshareRouteMessage
+	}
+	unshareRouteReturns struct {
+		result1 error
+	}
+	unshareRouteReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -660,6 +718,267 @@ func (fake *CFRouteRepository) RemoveDestinationFromRouteReturnsOnCall(i int, re
 	}{result1, result2}
 }
 
+func (fake *CFRouteRepository) ReplaceRouteDestinations(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ReplaceRouteDestinationsMessage) (repositories.RouteRecord, error) {
+	fake.replaceRouteDestinationsMutex.Lock()
+	ret, specificReturn := fake.replaceRouteDestinationsReturnsOnCall[len(fake.replaceRouteDestinationsArgsForCall)]
+	fake.replaceRouteDestinationsArgsForCall = append(fake.replaceRouteDestinationsArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ReplaceRouteDestinationsMessage
+	}{arg1, arg2, arg3})
+	stub := fake.ReplaceRouteDestinationsStub
+	fakeReturns := fake.replaceRouteDestinationsReturns
+	fake.recordInvocation("ReplaceRouteDestinations", []interface{}{arg1, arg2, arg3})
+	fake.replaceRouteDestinationsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFRouteRepository) ReplaceRouteDestinationsCallCount() int {
+	fake.replaceRouteDestinationsMutex.RLock()
+	defer fake.replaceRouteDestinationsMutex.RUnlock()
+	return len(fake.replaceRouteDestinationsArgsForCall)
+}
+
+func (fake *CFRouteRepository) ReplaceRouteDestinationsCalls(stub func(context.Context, authorization.Info, repositories.ReplaceRouteDestinationsMessage) (repositories.RouteRecord, error)) {
+	fake.replaceRouteDestinationsMutex.Lock()
+	defer fake.replaceRouteDestinationsMutex.Unlock()
+	fake.ReplaceRouteDestinationsStub = stub
+}
+
+func (fake *CFRouteRepository) ReplaceRouteDestinationsArgsForCall(i int) (context.Context, authorization.Info, repositories.ReplaceRouteDestinationsMessage) {
+	fake.replaceRouteDestinationsMutex.RLock()
+	defer fake.replaceRouteDestinationsMutex.RUnlock()
+	argsForCall := fake.replaceRouteDestinationsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFRouteRepository) ReplaceRouteDestinationsReturns(result1 repositories.RouteRecord, result2 error) {
+	fake.replaceRouteDestinationsMutex.Lock()
+	defer fake.replaceRouteDestinationsMutex.Unlock()
+	fake.ReplaceRouteDestinationsStub = nil
+	fake.replaceRouteDestinationsReturns = struct {
+		result1 repositories.RouteRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFRouteRepository) ReplaceRouteDestinationsReturnsOnCall(i int, result1 repositories.RouteRecord, result2 error) {
+	fake.replaceRouteDestinationsMutex.Lock()
+	defer fake.replaceRouteDestinationsMutex.Unlock()
+	fake.ReplaceRouteDestinationsStub = nil
+	if fake.replaceRouteDestinationsReturnsOnCall == nil {
+		fake.replaceRouteDestinationsReturnsOnCall = make(map[int]struct {
+			result1 repositories.RouteRecord
+			result2 error
+		})
+	}
+	fake.replaceRouteDestinationsReturnsOnCall[i] = struct {
+		result1 repositories.RouteRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFRouteRepository) ShareRoute(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ShareRouteMessage) (repositories.RouteRecord, error) {
+	fake.shareRouteMutex.Lock()
+	ret, specificReturn := fake.shareRouteReturnsOnCall[len(fake.shareRouteArgsForCall)]
+	fake.shareRouteArgsForCall = append(fake.shareRouteArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ShareRouteMessage
+	}{arg1, arg2, arg3})
+	stub := fake.ShareRouteStub
+	fakeReturns := fake.shareRouteReturns
+	fake.recordInvocation("ShareRoute", []interface{}{arg1, arg2, arg3})
+	fake.shareRouteMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFRouteRepository) ShareRouteCallCount() int {
+	fake.shareRouteMutex.RLock()
+	defer fake.shareRouteMutex.RUnlock()
+	return len(fake.shareRouteArgsForCall)
+}
+
+func (fake *CFRouteRepository) ShareRouteCalls(stub func(context.Context, authorization.Info, repositories.ShareRouteMessage) (repositories.RouteRecord, error)) {
+	fake.shareRouteMutex.Lock()
+	defer fake.shareRouteMutex.Unlock()
+	fake.ShareRouteStub = stub
+}
+
+func (fake *CFRouteRepository) ShareRouteArgsForCall(i int) (context.Context, authorization.Info, repositories.ShareRouteMessage) {
+	fake.shareRouteMutex.RLock()
+	defer fake.shareRouteMutex.RUnlock()
+	argsForCall := fake.shareRouteArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFRouteRepository) ShareRouteReturns(result1 repositories.RouteRecord, result2 error) {
+	fake.shareRouteMutex.Lock()
+	defer fake.shareRouteMutex.Unlock()
+	fake.ShareRouteStub = nil
+	fake.shareRouteReturns = struct {
+		result1 repositories.RouteRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFRouteRepository) ShareRouteReturnsOnCall(i int, result1 repositories.RouteRecord, result2 error) {
+	fake.shareRouteMutex.Lock()
+	defer fake.shareRouteMutex.Unlock()
+	fake.ShareRouteStub = nil
+	if fake.shareRouteReturnsOnCall == nil {
+		fake.shareRouteReturnsOnCall = make(map[int]struct {
+			result1 repositories.RouteRecord
+			result2 error
+		})
+	}
+	fake.shareRouteReturnsOnCall[i] = struct {
+		result1 repositories.RouteRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFRouteRepository) TransferRoute(arg1 context.Context, arg2 authorization.Info, arg3 repositories.TransferRouteMessage) (repositories.RouteRecord, error) {
+	fake.transferRouteMutex.Lock()
+	ret, specificReturn := fake.transferRouteReturnsOnCall[len(fake.transferRouteArgsForCall)]
+	fake.transferRouteArgsForCall = append(fake.transferRouteArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.TransferRouteMessage
+	}{arg1, arg2, arg3})
+	stub := fake.TransferRouteStub
+	fakeReturns := fake.transferRouteReturns
+	fake.recordInvocation("TransferRoute", []interface{}{arg1, arg2, arg3})
+	fake.transferRouteMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFRouteRepository) TransferRouteCallCount() int {
+	fake.transferRouteMutex.RLock()
+	defer fake.transferRouteMutex.RUnlock()
+	return len(fake.transferRouteArgsForCall)
+}
+
+func (fake *CFRouteRepository) TransferRouteCalls(stub func(context.Context, authorization.Info, repositories.TransferRouteMessage) (repositories.RouteRecord, error)) {
+	fake.transferRouteMutex.Lock()
+	defer fake.transferRouteMutex.Unlock()
+	fake.TransferRouteStub = stub
+}
+
+func (fake *CFRouteRepository) TransferRouteArgsForCall(i int) (context.Context, authorization.Info, repositories.TransferRouteMessage) {
+	fake.transferRouteMutex.RLock()
+	defer fake.transferRouteMutex.RUnlock()
+	argsForCall := fake.transferRouteArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFRouteRepository) TransferRouteReturns(result1 repositories.RouteRecord, result2 error) {
+	fake.transferRouteMutex.Lock()
+	defer fake.transferRouteMutex.Unlock()
+	fake.TransferRouteStub = nil
+	fake.transferRouteReturns = struct {
+		result1 repositories.RouteRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFRouteRepository) TransferRouteReturnsOnCall(i int, result1 repositories.RouteRecord, result2 error) {
+	fake.transferRouteMutex.Lock()
+	defer fake.transferRouteMutex.Unlock()
+	fake.TransferRouteStub = nil
+	if fake.transferRouteReturnsOnCall == nil {
+		fake.transferRouteReturnsOnCall = make(map[int]struct {
+			result1 repositories.RouteRecord
+			result2 error
+		})
+	}
+	fake.transferRouteReturnsOnCall[i] = struct {
+		result1 repositories.RouteRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFRouteRepository) UnshareRoute(arg1 context.Context, arg2 authorization.Info, arg3 repositories.UnshareRouteMessage) error {
+	fake.unshareRouteMutex.Lock()
+	ret, specificReturn := fake.unshareRouteReturnsOnCall[len(fake.unshareRouteArgsForCall)]
+	fake.unshareRouteArgsForCall = append(fake.unshareRouteArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UnshareRouteMessage
+	}{arg1, arg2, arg3})
+	stub := fake.UnshareRouteStub
+	fakeReturns := fake.unshareRouteReturns
+	fake.recordInvocation("UnshareRoute", []interface{}{arg1, arg2, arg3})
+	fake.unshareRouteMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *CFRouteRepository) UnshareRouteCallCount() int {
+	fake.unshareRouteMutex.RLock()
+	defer fake.unshareRouteMutex.RUnlock()
+	return len(fake.unshareRouteArgsForCall)
+}
+
+func (fake *CFRouteRepository) UnshareRouteCalls(stub func(context.Context, authorization.Info, repositories.UnshareRouteMessage) error) {
+	fake.unshareRouteMutex.Lock()
+	defer fake.unshareRouteMutex.Unlock()
+	fake.UnshareRouteStub = stub
+}
+
+func (fake *CFRouteRepository) UnshareRouteArgsForCall(i int) (context.Context, authorization.Info, repositories.UnshareRouteMessage) {
+	fake.unshareRouteMutex.RLock()
+	defer fake.unshareRouteMutex.RUnlock()
+	argsForCall := fake.unshareRouteArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFRouteRepository) UnshareRouteReturns(result1 error) {
+	fake.unshareRouteMutex.Lock()
+	defer fake.unshareRouteMutex.Unlock()
+	fake.UnshareRouteStub = nil
+	fake.unshareRouteReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFRouteRepository) UnshareRouteReturnsOnCall(i int, result1 error) {
+	fake.unshareRouteMutex.Lock()
+	defer fake.unshareRouteMutex.Unlock()
+	fake.UnshareRouteStub = nil
+	if fake.unshareRouteReturnsOnCall == nil {
+		fake.unshareRouteReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.unshareRouteReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *CFRouteRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -679,6 +998,14 @@ func (fake *CFRouteRepository) Invocations() map[string][][]interface{} {
 	defer fake.patchRouteMetadataMutex.RUnlock()
 	fake.removeDestinationFromRouteMutex.RLock()
 	defer fake.removeDestinationFromRouteMutex.RUnlock()
+	fake.replaceRouteDestinationsMutex.RLock()
+	defer fake.replaceRouteDestinationsMutex.RUnlock()
+	fake.shareRouteMutex.RLock()
+	defer fake.shareRouteMutex.RUnlock()
+	fake.transferRouteMutex.RLock()
+	defer fake.transferRouteMutex.RUnlock()
+	fake.unshareRouteMutex.RLock()
+	defer fake.unshareRouteMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
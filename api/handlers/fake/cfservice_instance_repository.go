@@ -69,6 +69,34 @@ type CFServiceInstanceRepository struct {
 		result1 []repositories.ServiceInstanceRecord
 		result2 error
 	}
+	ShareServiceInstanceStub        func(context.Context, authorization.Info, repositories.ShareServiceInstanceMessage) (repositories.ServiceInstanceRecord, error)
+	shareServiceInstanceMutex       sync.RWMutex
+	shareServiceInstanceArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ShareServiceInstanceMessage
+	}
+	shareServiceInstanceReturns struct {
+		result1 repositories.ServiceInstanceRecord
+		result2 error
+	}
+	shareServiceInstanceReturnsOnCall map[int]struct {
+		result1 repositories.ServiceInstanceRecord
+		result2 error
+	}
+	UnshareServiceInstanceStub        func(context.Context, authorization.Info, repositories.UnshareServiceInstanceMessage) error
+	unshareServiceInstanceMutex       sync.RWMutex
+	unshareServiceInstanceArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UnshareServiceInstanceMessage
+	}
+	unshareServiceInstanceReturns struct {
+		result1 error
+	}
+	unshareServiceInstanceReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -334,6 +362,135 @@ func (fake *CFServiceInstanceRepository) ListServiceInstancesReturnsOnCall(i int
 	}{result1, result2}
 }
 
+func (fake *CFServiceInstanceRepository) ShareServiceInstance(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ShareServiceInstanceMessage) (repositories.ServiceInstanceRecord, error) {
+	fake.shareServiceInstanceMutex.Lock()
+	ret, specificReturn := fake.shareServiceInstanceReturnsOnCall[len(fake.shareServiceInstanceArgsForCall)]
+	fake.shareServiceInstanceArgsForCall = append(fake.shareServiceInstanceArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ShareServiceInstanceMessage
+	}{arg1, arg2, arg3})
+	stub := fake.ShareServiceInstanceStub
+	fakeReturns := fake.shareServiceInstanceReturns
+	fake.recordInvocation("ShareServiceInstance", []interface{}{arg1, arg2, arg3})
+	fake.shareServiceInstanceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFServiceInstanceRepository) ShareServiceInstanceCallCount() int {
+	fake.shareServiceInstanceMutex.RLock()
+	defer fake.shareServiceInstanceMutex.RUnlock()
+	return len(fake.shareServiceInstanceArgsForCall)
+}
+
+func (fake *CFServiceInstanceRepository) ShareServiceInstanceCalls(stub func(context.Context, authorization.Info, repositories.ShareServiceInstanceMessage) (repositories.ServiceInstanceRecord, error)) {
+	fake.shareServiceInstanceMutex.Lock()
+	defer fake.shareServiceInstanceMutex.Unlock()
+	fake.ShareServiceInstanceStub = stub
+}
+
+func (fake *CFServiceInstanceRepository) ShareServiceInstanceArgsForCall(i int) (context.Context, authorization.Info, repositories.ShareServiceInstanceMessage) {
+	fake.shareServiceInstanceMutex.RLock()
+	defer fake.shareServiceInstanceMutex.RUnlock()
+	argsForCall := fake.shareServiceInstanceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFServiceInstanceRepository) ShareServiceInstanceReturns(result1 repositories.ServiceInstanceRecord, result2 error) {
+	fake.shareServiceInstanceMutex.Lock()
+	defer fake.shareServiceInstanceMutex.Unlock()
+	fake.ShareServiceInstanceStub = nil
+	fake.shareServiceInstanceReturns = struct {
+		result1 repositories.ServiceInstanceRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFServiceInstanceRepository) ShareServiceInstanceReturnsOnCall(i int, result1 repositories.ServiceInstanceRecord, result2 error) {
+	fake.shareServiceInstanceMutex.Lock()
+	defer fake.shareServiceInstanceMutex.Unlock()
+	fake.ShareServiceInstanceStub = nil
+	if fake.shareServiceInstanceReturnsOnCall == nil {
+		fake.shareServiceInstanceReturnsOnCall = make(map[int]struct {
+			result1 repositories.ServiceInstanceRecord
+			result2 error
+		})
+	}
+	fake.shareServiceInstanceReturnsOnCall[i] = struct {
+		result1 repositories.ServiceInstanceRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFServiceInstanceRepository) UnshareServiceInstance(arg1 context.Context, arg2 authorization.Info, arg3 repositories.UnshareServiceInstanceMessage) error {
+	fake.unshareServiceInstanceMutex.Lock()
+	ret, specificReturn := fake.unshareServiceInstanceReturnsOnCall[len(fake.unshareServiceInstanceArgsForCall)]
+	fake.unshareServiceInstanceArgsForCall = append(fake.unshareServiceInstanceArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UnshareServiceInstanceMessage
+	}{arg1, arg2, arg3})
+	stub := fake.UnshareServiceInstanceStub
+	fakeReturns := fake.unshareServiceInstanceReturns
+	fake.recordInvocation("UnshareServiceInstance", []interface{}{arg1, arg2, arg3})
+	fake.unshareServiceInstanceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *CFServiceInstanceRepository) UnshareServiceInstanceCallCount() int {
+	fake.unshareServiceInstanceMutex.RLock()
+	defer fake.unshareServiceInstanceMutex.RUnlock()
+	return len(fake.unshareServiceInstanceArgsForCall)
+}
+
+func (fake *CFServiceInstanceRepository) UnshareServiceInstanceCalls(stub func(context.Context, authorization.Info, repositories.UnshareServiceInstanceMessage) error) {
+	fake.unshareServiceInstanceMutex.Lock()
+	defer fake.unshareServiceInstanceMutex.Unlock()
+	fake.UnshareServiceInstanceStub = stub
+}
+
+func (fake *CFServiceInstanceRepository) UnshareServiceInstanceArgsForCall(i int) (context.Context, authorization.Info, repositories.UnshareServiceInstanceMessage) {
+	fake.unshareServiceInstanceMutex.RLock()
+	defer fake.unshareServiceInstanceMutex.RUnlock()
+	argsForCall := fake.unshareServiceInstanceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFServiceInstanceRepository) UnshareServiceInstanceReturns(result1 error) {
+	fake.unshareServiceInstanceMutex.Lock()
+	defer fake.unshareServiceInstanceMutex.Unlock()
+	fake.UnshareServiceInstanceStub = nil
+	fake.unshareServiceInstanceReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFServiceInstanceRepository) UnshareServiceInstanceReturnsOnCall(i int, result1 error) {
+	fake.unshareServiceInstanceMutex.Lock()
+	defer fake.unshareServiceInstanceMutex.Unlock()
+	fake.UnshareServiceInstanceStub = nil
+	if fake.unshareServiceInstanceReturnsOnCall == nil {
+		fake.unshareServiceInstanceReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.unshareServiceInstanceReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *CFServiceInstanceRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -345,6 +502,10 @@ func (fake *CFServiceInstanceRepository) Invocations() map[string][][]interface{
 	defer fake.getServiceInstanceMutex.RUnlock()
 	fake.listServiceInstancesMutex.RLock()
 	defer fake.listServiceInstancesMutex.RUnlock()
+	fake.shareServiceInstanceMutex.RLock()
+	defer fake.shareServiceInstanceMutex.RUnlock()
+	fake.unshareServiceInstanceMutex.RLock()
+	defer fake.unshareServiceInstanceMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
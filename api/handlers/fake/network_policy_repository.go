@@ -0,0 +1,284 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type NetworkPolicyRepository struct {
+	CreateNetworkPolicyStub        func(context.Context, authorization.Info, repositories.CreateNetworkPolicyMessage) (repositories.NetworkPolicyRecord, error)
+	createNetworkPolicyMutex       sync.RWMutex
+	createNetworkPolicyArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.CreateNetworkPolicyMessage
+	}
+	createNetworkPolicyReturns struct {
+		result1 repositories.NetworkPolicyRecord
+		result2 error
+	}
+	createNetworkPolicyReturnsOnCall map[int]struct {
+		result1 repositories.NetworkPolicyRecord
+		result2 error
+	}
+	DeleteNetworkPolicyStub        func(context.Context, authorization.Info, repositories.DeleteNetworkPolicyMessage) error
+	deleteNetworkPolicyMutex       sync.RWMutex
+	deleteNetworkPolicyArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.DeleteNetworkPolicyMessage
+	}
+	deleteNetworkPolicyReturns struct {
+		result1 error
+	}
+	deleteNetworkPolicyReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ListNetworkPoliciesStub        func(context.Context, authorization.Info, repositories.ListNetworkPoliciesMessage) ([]repositories.NetworkPolicyRecord, error)
+	listNetworkPoliciesMutex       sync.RWMutex
+	listNetworkPoliciesArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListNetworkPoliciesMessage
+	}
+	listNetworkPoliciesReturns struct {
+		result1 []repositories.NetworkPolicyRecord
+		result2 error
+	}
+	listNetworkPoliciesReturnsOnCall map[int]struct {
+		result1 []repositories.NetworkPolicyRecord
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *NetworkPolicyRepository) CreateNetworkPolicy(arg1 context.Context, arg2 authorization.Info, arg3 repositories.CreateNetworkPolicyMessage) (repositories.NetworkPolicyRecord, error) {
+	fake.createNetworkPolicyMutex.Lock()
+	ret, specificReturn := fake.createNetworkPolicyReturnsOnCall[len(fake.createNetworkPolicyArgsForCall)]
+	fake.createNetworkPolicyArgsForCall = append(fake.createNetworkPolicyArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.CreateNetworkPolicyMessage
+	}{arg1, arg2, arg3})
+	stub := fake.CreateNetworkPolicyStub
+	fakeReturns := fake.createNetworkPolicyReturns
+	fake.recordInvocation("CreateNetworkPolicy", []interface{}{arg1, arg2, arg3})
+	fake.createNetworkPolicyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *NetworkPolicyRepository) CreateNetworkPolicyCallCount() int {
+	fake.createNetworkPolicyMutex.RLock()
+	defer fake.createNetworkPolicyMutex.RUnlock()
+	return len(fake.createNetworkPolicyArgsForCall)
+}
+
+func (fake *NetworkPolicyRepository) CreateNetworkPolicyCalls(stub func(context.Context, authorization.Info, repositories.CreateNetworkPolicyMessage) (repositories.NetworkPolicyRecord, error)) {
+	fake.createNetworkPolicyMutex.Lock()
+	defer fake.createNetworkPolicyMutex.Unlock()
+	fake.CreateNetworkPolicyStub = stub
+}
+
+func (fake *NetworkPolicyRepository) CreateNetworkPolicyArgsForCall(i int) (context.Context, authorization.Info, repositories.CreateNetworkPolicyMessage) {
+	fake.createNetworkPolicyMutex.RLock()
+	defer fake.createNetworkPolicyMutex.RUnlock()
+	argsForCall := fake.createNetworkPolicyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *NetworkPolicyRepository) CreateNetworkPolicyReturns(result1 repositories.NetworkPolicyRecord, result2 error) {
+	fake.createNetworkPolicyMutex.Lock()
+	defer fake.createNetworkPolicyMutex.Unlock()
+	fake.CreateNetworkPolicyStub = nil
+	fake.createNetworkPolicyReturns = struct {
+		result1 repositories.NetworkPolicyRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *NetworkPolicyRepository) CreateNetworkPolicyReturnsOnCall(i int, result1 repositories.NetworkPolicyRecord, result2 error) {
+	fake.createNetworkPolicyMutex.Lock()
+	defer fake.createNetworkPolicyMutex.Unlock()
+	fake.CreateNetworkPolicyStub = nil
+	if fake.createNetworkPolicyReturnsOnCall == nil {
+		fake.createNetworkPolicyReturnsOnCall = make(map[int]struct {
+			result1 repositories.NetworkPolicyRecord
+			result2 error
+		})
+	}
+	fake.createNetworkPolicyReturnsOnCall[i] = struct {
+		result1 repositories.NetworkPolicyRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *NetworkPolicyRepository) DeleteNetworkPolicy(arg1 context.Context, arg2 authorization.Info, arg3 repositories.DeleteNetworkPolicyMessage) error {
+	fake.deleteNetworkPolicyMutex.Lock()
+	ret, specificReturn := fake.deleteNetworkPolicyReturnsOnCall[len(fake.deleteNetworkPolicyArgsForCall)]
+	fake.deleteNetworkPolicyArgsForCall = append(fake.deleteNetworkPolicyArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.DeleteNetworkPolicyMessage
+	}{arg1, arg2, arg3})
+	stub := fake.DeleteNetworkPolicyStub
+	fakeReturns := fake.deleteNetworkPolicyReturns
+	fake.recordInvocation("DeleteNetworkPolicy", []interface{}{arg1, arg2, arg3})
+	fake.deleteNetworkPolicyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *NetworkPolicyRepository) DeleteNetworkPolicyCallCount() int {
+	fake.deleteNetworkPolicyMutex.RLock()
+	defer fake.deleteNetworkPolicyMutex.RUnlock()
+	return len(fake.deleteNetworkPolicyArgsForCall)
+}
+
+func (fake *NetworkPolicyRepository) DeleteNetworkPolicyCalls(stub func(context.Context, authorization.Info, repositories.DeleteNetworkPolicyMessage) error) {
+	fake.deleteNetworkPolicyMutex.Lock()
+	defer fake.deleteNetworkPolicyMutex.Unlock()
+	fake.DeleteNetworkPolicyStub = stub
+}
+
+func (fake *NetworkPolicyRepository) DeleteNetworkPolicyArgsForCall(i int) (context.Context, authorization.Info, repositories.DeleteNetworkPolicyMessage) {
+	fake.deleteNetworkPolicyMutex.RLock()
+	defer fake.deleteNetworkPolicyMutex.RUnlock()
+	argsForCall := fake.deleteNetworkPolicyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *NetworkPolicyRepository) DeleteNetworkPolicyReturns(result1 error) {
+	fake.deleteNetworkPolicyMutex.Lock()
+	defer fake.deleteNetworkPolicyMutex.Unlock()
+	fake.DeleteNetworkPolicyStub = nil
+	fake.deleteNetworkPolicyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *NetworkPolicyRepository) DeleteNetworkPolicyReturnsOnCall(i int, result1 error) {
+	fake.deleteNetworkPolicyMutex.Lock()
+	defer fake.deleteNetworkPolicyMutex.Unlock()
+	fake.DeleteNetworkPolicyStub = nil
+	if fake.deleteNetworkPolicyReturnsOnCall == nil {
+		fake.deleteNetworkPolicyReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteNetworkPolicyReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *NetworkPolicyRepository) ListNetworkPolicies(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ListNetworkPoliciesMessage) ([]repositories.NetworkPolicyRecord, error) {
+	fake.listNetworkPoliciesMutex.Lock()
+	ret, specificReturn := fake.listNetworkPoliciesReturnsOnCall[len(fake.listNetworkPoliciesArgsForCall)]
+	fake.listNetworkPoliciesArgsForCall = append(fake.listNetworkPoliciesArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListNetworkPoliciesMessage
+	}{arg1, arg2, arg3})
+	stub := fake.ListNetworkPoliciesStub
+	fakeReturns := fake.listNetworkPoliciesReturns
+	fake.recordInvocation("ListNetworkPolicies", []interface{}{arg1, arg2, arg3})
+	fake.listNetworkPoliciesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *NetworkPolicyRepository) ListNetworkPoliciesCallCount() int {
+	fake.listNetworkPoliciesMutex.RLock()
+	defer fake.listNetworkPoliciesMutex.RUnlock()
+	return len(fake.listNetworkPoliciesArgsForCall)
+}
+
+func (fake *NetworkPolicyRepository) ListNetworkPoliciesCalls(stub func(context.Context, authorization.Info, repositories.ListNetworkPoliciesMessage) ([]repositories.NetworkPolicyRecord, error)) {
+	fake.listNetworkPoliciesMutex.Lock()
+	defer fake.listNetworkPoliciesMutex.Unlock()
+	fake.ListNetworkPoliciesStub = stub
+}
+
+func (fake *NetworkPolicyRepository) ListNetworkPoliciesArgsForCall(i int) (context.Context, authorization.Info, repositories.ListNetworkPoliciesMessage) {
+	fake.listNetworkPoliciesMutex.RLock()
+	defer fake.listNetworkPoliciesMutex.RUnlock()
+	argsForCall := fake.listNetworkPoliciesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *NetworkPolicyRepository) ListNetworkPoliciesReturns(result1 []repositories.NetworkPolicyRecord, result2 error) {
+	fake.listNetworkPoliciesMutex.Lock()
+	defer fake.listNetworkPoliciesMutex.Unlock()
+	fake.ListNetworkPoliciesStub = nil
+	fake.listNetworkPoliciesReturns = struct {
+		result1 []repositories.NetworkPolicyRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *NetworkPolicyRepository) ListNetworkPoliciesReturnsOnCall(i int, result1 []repositories.NetworkPolicyRecord, result2 error) {
+	fake.listNetworkPoliciesMutex.Lock()
+	defer fake.listNetworkPoliciesMutex.Unlock()
+	fake.ListNetworkPoliciesStub = nil
+	if fake.listNetworkPoliciesReturnsOnCall == nil {
+		fake.listNetworkPoliciesReturnsOnCall = make(map[int]struct {
+			result1 []repositories.NetworkPolicyRecord
+			result2 error
+		})
+	}
+	fake.listNetworkPoliciesReturnsOnCall[i] = struct {
+		result1 []repositories.NetworkPolicyRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *NetworkPolicyRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.createNetworkPolicyMutex.RLock()
+	defer fake.createNetworkPolicyMutex.RUnlock()
+	fake.deleteNetworkPolicyMutex.RLock()
+	defer fake.deleteNetworkPolicyMutex.RUnlock()
+	fake.listNetworkPoliciesMutex.RLock()
+	defer fake.listNetworkPoliciesMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *NetworkPolicyRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.NetworkPolicyRepository = new(NetworkPolicyRepository)
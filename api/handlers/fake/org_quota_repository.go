@@ -0,0 +1,372 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type OrgQuotaRepository struct {
+	ApplyOrgQuotaStub        func(context.Context, authorization.Info, repositories.ApplyOrgQuotaMessage) (repositories.OrgQuotaRecord, error)
+	applyOrgQuotaMutex       sync.RWMutex
+	applyOrgQuotaArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ApplyOrgQuotaMessage
+	}
+	applyOrgQuotaReturns struct {
+		result1 repositories.OrgQuotaRecord
+		result2 error
+	}
+	applyOrgQuotaReturnsOnCall map[int]struct {
+		result1 repositories.OrgQuotaRecord
+		result2 error
+	}
+	CreateOrgQuotaStub        func(context.Context, authorization.Info, repositories.CreateOrgQuotaMessage) (repositories.OrgQuotaRecord, error)
+	createOrgQuotaMutex       sync.RWMutex
+	createOrgQuotaArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.CreateOrgQuotaMessage
+	}
+	createOrgQuotaReturns struct {
+		result1 repositories.OrgQuotaRecord
+		result2 error
+	}
+	createOrgQuotaReturnsOnCall map[int]struct {
+		result1 repositories.OrgQuotaRecord
+		result2 error
+	}
+	GetOrgQuotaStub        func(context.Context, authorization.Info, string) (repositories.OrgQuotaRecord, error)
+	getOrgQuotaMutex       sync.RWMutex
+	getOrgQuotaArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	getOrgQuotaReturns struct {
+		result1 repositories.OrgQuotaRecord
+		result2 error
+	}
+	getOrgQuotaReturnsOnCall map[int]struct {
+		result1 repositories.OrgQuotaRecord
+		result2 error
+	}
+	ListOrgQuotasStub        func(context.Context, authorization.Info, repositories.ListOrgQuotasMessage) ([]repositories.OrgQuotaRecord, error)
+	listOrgQuotasMutex       sync.RWMutex
+	listOrgQuotasArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListOrgQuotasMessage
+	}
+	listOrgQuotasReturns struct {
+		result1 []repositories.OrgQuotaRecord
+		result2 error
+	}
+	listOrgQuotasReturnsOnCall map[int]struct {
+		result1 []repositories.OrgQuotaRecord
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *OrgQuotaRepository) ApplyOrgQuota(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ApplyOrgQuotaMessage) (repositories.OrgQuotaRecord, error) {
+	fake.applyOrgQuotaMutex.Lock()
+	ret, specificReturn := fake.applyOrgQuotaReturnsOnCall[len(fake.applyOrgQuotaArgsForCall)]
+	fake.applyOrgQuotaArgsForCall = append(fake.applyOrgQuotaArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ApplyOrgQuotaMessage
+	}{arg1, arg2, arg3})
+	stub := fake.ApplyOrgQuotaStub
+	fakeReturns := fake.applyOrgQuotaReturns
+	fake.recordInvocation("ApplyOrgQuota", []interface{}{arg1, arg2, arg3})
+	fake.applyOrgQuotaMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *OrgQuotaRepository) ApplyOrgQuotaCallCount() int {
+	fake.applyOrgQuotaMutex.RLock()
+	defer fake.applyOrgQuotaMutex.RUnlock()
+	return len(fake.applyOrgQuotaArgsForCall)
+}
+
+func (fake *OrgQuotaRepository) ApplyOrgQuotaCalls(stub func(context.Context, authorization.Info, repositories.ApplyOrgQuotaMessage) (repositories.OrgQuotaRecord, error)) {
+	fake.applyOrgQuotaMutex.Lock()
+	defer fake.applyOrgQuotaMutex.Unlock()
+	fake.ApplyOrgQuotaStub = stub
+}
+
+func (fake *OrgQuotaRepository) ApplyOrgQuotaArgsForCall(i int) (context.Context, authorization.Info, repositories.ApplyOrgQuotaMessage) {
+	fake.applyOrgQuotaMutex.RLock()
+	defer fake.applyOrgQuotaMutex.RUnlock()
+	argsForCall := fake.applyOrgQuotaArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *OrgQuotaRepository) ApplyOrgQuotaReturns(result1 repositories.OrgQuotaRecord, result2 error) {
+	fake.applyOrgQuotaMutex.Lock()
+	defer fake.applyOrgQuotaMutex.Unlock()
+	fake.ApplyOrgQuotaStub = nil
+	fake.applyOrgQuotaReturns = struct {
+		result1 repositories.OrgQuotaRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *OrgQuotaRepository) ApplyOrgQuotaReturnsOnCall(i int, result1 repositories.OrgQuotaRecord, result2 error) {
+	fake.applyOrgQuotaMutex.Lock()
+	defer fake.applyOrgQuotaMutex.Unlock()
+	fake.ApplyOrgQuotaStub = nil
+	if fake.applyOrgQuotaReturnsOnCall == nil {
+		fake.applyOrgQuotaReturnsOnCall = make(map[int]struct {
+			result1 repositories.OrgQuotaRecord
+			result2 error
+		})
+	}
+	fake.applyOrgQuotaReturnsOnCall[i] = struct {
+		result1 repositories.OrgQuotaRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *OrgQuotaRepository) CreateOrgQuota(arg1 context.Context, arg2 authorization.Info, arg3 repositories.CreateOrgQuotaMessage) (repositories.OrgQuotaRecord, error) {
+	fake.createOrgQuotaMutex.Lock()
+	ret, specificReturn := fake.createOrgQuotaReturnsOnCall[len(fake.createOrgQuotaArgsForCall)]
+	fake.createOrgQuotaArgsForCall = append(fake.createOrgQuotaArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.CreateOrgQuotaMessage
+	}{arg1, arg2, arg3})
+	stub := fake.CreateOrgQuotaStub
+	fakeReturns := fake.createOrgQuotaReturns
+	fake.recordInvocation("CreateOrgQuota", []interface{}{arg1, arg2, arg3})
+	fake.createOrgQuotaMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *OrgQuotaRepository) CreateOrgQuotaCallCount() int {
+	fake.createOrgQuotaMutex.RLock()
+	defer fake.createOrgQuotaMutex.RUnlock()
+	return len(fake.createOrgQuotaArgsForCall)
+}
+
+func (fake *OrgQuotaRepository) CreateOrgQuotaCalls(stub func(context.Context, authorization.Info, repositories.CreateOrgQuotaMessage) (repositories.OrgQuotaRecord, error)) {
+	fake.createOrgQuotaMutex.Lock()
+	defer fake.createOrgQuotaMutex.Unlock()
+	fake.CreateOrgQuotaStub = stub
+}
+
+func (fake *OrgQuotaRepository) CreateOrgQuotaArgsForCall(i int) (context.Context, authorization.Info, repositories.CreateOrgQuotaMessage) {
+	fake.createOrgQuotaMutex.RLock()
+	defer fake.createOrgQuotaMutex.RUnlock()
+	argsForCall := fake.createOrgQuotaArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *OrgQuotaRepository) CreateOrgQuotaReturns(result1 repositories.OrgQuotaRecord, result2 error) {
+	fake.createOrgQuotaMutex.Lock()
+	defer fake.createOrgQuotaMutex.Unlock()
+	fake.CreateOrgQuotaStub = nil
+	fake.createOrgQuotaReturns = struct {
+		result1 repositories.OrgQuotaRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *OrgQuotaRepository) CreateOrgQuotaReturnsOnCall(i int, result1 repositories.OrgQuotaRecord, result2 error) {
+	fake.createOrgQuotaMutex.Lock()
+	defer fake.createOrgQuotaMutex.Unlock()
+	fake.CreateOrgQuotaStub = nil
+	if fake.createOrgQuotaReturnsOnCall == nil {
+		fake.createOrgQuotaReturnsOnCall = make(map[int]struct {
+			result1 repositories.OrgQuotaRecord
+			result2 error
+		})
+	}
+	fake.createOrgQuotaReturnsOnCall[i] = struct {
+		result1 repositories.OrgQuotaRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *OrgQuotaRepository) GetOrgQuota(arg1 context.Context, arg2 authorization.Info, arg3 string) (repositories.OrgQuotaRecord, error) {
+	fake.getOrgQuotaMutex.Lock()
+	ret, specificReturn := fake.getOrgQuotaReturnsOnCall[len(fake.getOrgQuotaArgsForCall)]
+	fake.getOrgQuotaArgsForCall = append(fake.getOrgQuotaArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetOrgQuotaStub
+	fakeReturns := fake.getOrgQuotaReturns
+	fake.recordInvocation("GetOrgQuota", []interface{}{arg1, arg2, arg3})
+	fake.getOrgQuotaMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *OrgQuotaRepository) GetOrgQuotaCallCount() int {
+	fake.getOrgQuotaMutex.RLock()
+	defer fake.getOrgQuotaMutex.RUnlock()
+	return len(fake.getOrgQuotaArgsForCall)
+}
+
+func (fake *OrgQuotaRepository) GetOrgQuotaCalls(stub func(context.Context, authorization.Info, string) (repositories.OrgQuotaRecord, error)) {
+	fake.getOrgQuotaMutex.Lock()
+	defer fake.getOrgQuotaMutex.Unlock()
+	fake.GetOrgQuotaStub = stub
+}
+
+func (fake *OrgQuotaRepository) GetOrgQuotaArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.getOrgQuotaMutex.RLock()
+	defer fake.getOrgQuotaMutex.RUnlock()
+	argsForCall := fake.getOrgQuotaArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *OrgQuotaRepository) GetOrgQuotaReturns(result1 repositories.OrgQuotaRecord, result2 error) {
+	fake.getOrgQuotaMutex.Lock()
+	defer fake.getOrgQuotaMutex.Unlock()
+	fake.GetOrgQuotaStub = nil
+	fake.getOrgQuotaReturns = struct {
+		result1 repositories.OrgQuotaRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *OrgQuotaRepository) GetOrgQuotaReturnsOnCall(i int, result1 repositories.OrgQuotaRecord, result2 error) {
+	fake.getOrgQuotaMutex.Lock()
+	defer fake.getOrgQuotaMutex.Unlock()
+	fake.GetOrgQuotaStub = nil
+	if fake.getOrgQuotaReturnsOnCall == nil {
+		fake.getOrgQuotaReturnsOnCall = make(map[int]struct {
+			result1 repositories.OrgQuotaRecord
+			result2 error
+		})
+	}
+	fake.getOrgQuotaReturnsOnCall[i] = struct {
+		result1 repositories.OrgQuotaRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *OrgQuotaRepository) ListOrgQuotas(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ListOrgQuotasMessage) ([]repositories.OrgQuotaRecord, error) {
+	fake.listOrgQuotasMutex.Lock()
+	ret, specificReturn := fake.listOrgQuotasReturnsOnCall[len(fake.listOrgQuotasArgsForCall)]
+	fake.listOrgQuotasArgsForCall = append(fake.listOrgQuotasArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListOrgQuotasMessage
+	}{arg1, arg2, arg3})
+	stub := fake.ListOrgQuotasStub
+	fakeReturns := fake.listOrgQuotasReturns
+	fake.recordInvocation("ListOrgQuotas", []interface{}{arg1, arg2, arg3})
+	fake.listOrgQuotasMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *OrgQuotaRepository) ListOrgQuotasCallCount() int {
+	fake.listOrgQuotasMutex.RLock()
+	defer fake.listOrgQuotasMutex.RUnlock()
+	return len(fake.listOrgQuotasArgsForCall)
+}
+
+func (fake *OrgQuotaRepository) ListOrgQuotasCalls(stub func(context.Context, authorization.Info, repositories.ListOrgQuotasMessage) ([]repositories.OrgQuotaRecord, error)) {
+	fake.listOrgQuotasMutex.Lock()
+	defer fake.listOrgQuotasMutex.Unlock()
+	fake.ListOrgQuotasStub = stub
+}
+
+func (fake *OrgQuotaRepository) ListOrgQuotasArgsForCall(i int) (context.Context, authorization.Info, repositories.ListOrgQuotasMessage) {
+	fake.listOrgQuotasMutex.RLock()
+	defer fake.listOrgQuotasMutex.RUnlock()
+	argsForCall := fake.listOrgQuotasArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *OrgQuotaRepository) ListOrgQuotasReturns(result1 []repositories.OrgQuotaRecord, result2 error) {
+	fake.listOrgQuotasMutex.Lock()
+	defer fake.listOrgQuotasMutex.Unlock()
+	fake.ListOrgQuotasStub = nil
+	fake.listOrgQuotasReturns = struct {
+		result1 []repositories.OrgQuotaRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *OrgQuotaRepository) ListOrgQuotasReturnsOnCall(i int, result1 []repositories.OrgQuotaRecord, result2 error) {
+	fake.listOrgQuotasMutex.Lock()
+	defer fake.listOrgQuotasMutex.Unlock()
+	fake.ListOrgQuotasStub = nil
+	if fake.listOrgQuotasReturnsOnCall == nil {
+		fake.listOrgQuotasReturnsOnCall = make(map[int]struct {
+			result1 []repositories.OrgQuotaRecord
+			result2 error
+		})
+	}
+	fake.listOrgQuotasReturnsOnCall[i] = struct {
+		result1 []repositories.OrgQuotaRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *OrgQuotaRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.applyOrgQuotaMutex.RLock()
+	defer fake.applyOrgQuotaMutex.RUnlock()
+	fake.createOrgQuotaMutex.RLock()
+	defer fake.createOrgQuotaMutex.RUnlock()
+	fake.getOrgQuotaMutex.RLock()
+	defer fake.getOrgQuotaMutex.RUnlock()
+	fake.listOrgQuotasMutex.RLock()
+	defer fake.listOrgQuotasMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *OrgQuotaRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.OrgQuotaRepository = new(OrgQuotaRepository)
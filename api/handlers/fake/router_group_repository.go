@@ -0,0 +1,201 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type RouterGroupRepository struct {
+	GetRouterGroupStub        func(context.Context, string) (repositories.RouterGroupRecord, error)
+	getRouterGroupMutex       sync.RWMutex
+	getRouterGroupArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getRouterGroupReturns struct {
+		result1 repositories.RouterGroupRecord
+		result2 error
+	}
+	getRouterGroupReturnsOnCall map[int]struct {
+		result1 repositories.RouterGroupRecord
+		result2 error
+	}
+	ListRouterGroupsStub        func(context.Context, repositories.ListRouterGroupsMessage) ([]repositories.RouterGroupRecord, error)
+	listRouterGroupsMutex       sync.RWMutex
+	listRouterGroupsArgsForCall []struct {
+		arg1 context.Context
+		arg2 repositories.ListRouterGroupsMessage
+	}
+	listRouterGroupsReturns struct {
+		result1 []repositories.RouterGroupRecord
+		result2 error
+	}
+	listRouterGroupsReturnsOnCall map[int]struct {
+		result1 []repositories.RouterGroupRecord
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *RouterGroupRepository) GetRouterGroup(arg1 context.Context, arg2 string) (repositories.RouterGroupRecord, error) {
+	fake.getRouterGroupMutex.Lock()
+	ret, specificReturn := fake.getRouterGroupReturnsOnCall[len(fake.getRouterGroupArgsForCall)]
+	fake.getRouterGroupArgsForCall = append(fake.getRouterGroupArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetRouterGroupStub
+	fakeReturns := fake.getRouterGroupReturns
+	fake.recordInvocation("GetRouterGroup", []interface{}{arg1, arg2})
+	fake.getRouterGroupMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *RouterGroupRepository) GetRouterGroupCallCount() int {
+	fake.getRouterGroupMutex.RLock()
+	defer fake.getRouterGroupMutex.RUnlock()
+	return len(fake.getRouterGroupArgsForCall)
+}
+
+func (fake *RouterGroupRepository) GetRouterGroupCalls(stub func(context.Context, string) (repositories.RouterGroupRecord, error)) {
+	fake.getRouterGroupMutex.Lock()
+	defer fake.getRouterGroupMutex.Unlock()
+	fake.GetRouterGroupStub = stub
+}
+
+func (fake *RouterGroupRepository) GetRouterGroupArgsForCall(i int) (context.Context, string) {
+	fake.getRouterGroupMutex.RLock()
+	defer fake.getRouterGroupMutex.RUnlock()
+	argsForCall := fake.getRouterGroupArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *RouterGroupRepository) GetRouterGroupReturns(result1 repositories.RouterGroupRecord, result2 error) {
+	fake.getRouterGroupMutex.Lock()
+	defer fake.getRouterGroupMutex.Unlock()
+	fake.GetRouterGroupStub = nil
+	fake.getRouterGroupReturns = struct {
+		result1 repositories.RouterGroupRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RouterGroupRepository) GetRouterGroupReturnsOnCall(i int, result1 repositories.RouterGroupRecord, result2 error) {
+	fake.getRouterGroupMutex.Lock()
+	defer fake.getRouterGroupMutex.Unlock()
+	fake.GetRouterGroupStub = nil
+	if fake.getRouterGroupReturnsOnCall == nil {
+		fake.getRouterGroupReturnsOnCall = make(map[int]struct {
+			result1 repositories.RouterGroupRecord
+			result2 error
+		})
+	}
+	fake.getRouterGroupReturnsOnCall[i] = struct {
+		result1 repositories.RouterGroupRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RouterGroupRepository) ListRouterGroups(arg1 context.Context, arg2 repositories.ListRouterGroupsMessage) ([]repositories.RouterGroupRecord, error) {
+	fake.listRouterGroupsMutex.Lock()
+	ret, specificReturn := fake.listRouterGroupsReturnsOnCall[len(fake.listRouterGroupsArgsForCall)]
+	fake.listRouterGroupsArgsForCall = append(fake.listRouterGroupsArgsForCall, struct {
+		arg1 context.Context
+		arg2 repositories.ListRouterGroupsMessage
+	}{arg1, arg2})
+	stub := fake.ListRouterGroupsStub
+	fakeReturns := fake.listRouterGroupsReturns
+	fake.recordInvocation("ListRouterGroups", []interface{}{arg1, arg2})
+	fake.listRouterGroupsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *RouterGroupRepository) ListRouterGroupsCallCount() int {
+	fake.listRouterGroupsMutex.RLock()
+	defer fake.listRouterGroupsMutex.RUnlock()
+	return len(fake.listRouterGroupsArgsForCall)
+}
+
+func (fake *RouterGroupRepository) ListRouterGroupsCalls(stub func(context.Context, repositories.ListRouterGroupsMessage) ([]repositories.RouterGroupRecord, error)) {
+	fake.listRouterGroupsMutex.Lock()
+	defer fake.listRouterGroupsMutex.Unlock()
+	fake.ListRouterGroupsStub = stub
+}
+
+func (fake *RouterGroupRepository) ListRouterGroupsArgsForCall(i int) (context.Context, repositories.ListRouterGroupsMessage) {
+	fake.listRouterGroupsMutex.RLock()
+	defer fake.listRouterGroupsMutex.RUnlock()
+	argsForCall := fake.listRouterGroupsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *RouterGroupRepository) ListRouterGroupsReturns(result1 []repositories.RouterGroupRecord, result2 error) {
+	fake.listRouterGroupsMutex.Lock()
+	defer fake.listRouterGroupsMutex.Unlock()
+	fake.ListRouterGroupsStub = nil
+	fake.listRouterGroupsReturns = struct {
+		result1 []repositories.RouterGroupRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RouterGroupRepository) ListRouterGroupsReturnsOnCall(i int, result1 []repositories.RouterGroupRecord, result2 error) {
+	fake.listRouterGroupsMutex.Lock()
+	defer fake.listRouterGroupsMutex.Unlock()
+	fake.ListRouterGroupsStub = nil
+	if fake.listRouterGroupsReturnsOnCall == nil {
+		fake.listRouterGroupsReturnsOnCall = make(map[int]struct {
+			result1 []repositories.RouterGroupRecord
+			result2 error
+		})
+	}
+	fake.listRouterGroupsReturnsOnCall[i] = struct {
+		result1 []repositories.RouterGroupRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RouterGroupRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getRouterGroupMutex.RLock()
+	defer fake.getRouterGroupMutex.RUnlock()
+	fake.listRouterGroupsMutex.RLock()
+	defer fake.listRouterGroupsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *RouterGroupRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.RouterGroupRepository = new(RouterGroupRepository)
@@ -0,0 +1,611 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type SecurityGroupRepository struct {
+	BindSecurityGroupStub        func(context.Context, authorization.Info, repositories.BindSecurityGroupMessage) (repositories.SecurityGroupRecord, error)
+	bindSecurityGroupMutex       sync.RWMutex
+	bindSecurityGroupArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.BindSecurityGroupMessage
+	}
+	bindSecurityGroupReturns struct {
+		result1 repositories.SecurityGroupRecord
+		result2 error
+	}
+	bindSecurityGroupReturnsOnCall map[int]struct {
+		result1 repositories.SecurityGroupRecord
+		result2 error
+	}
+	CreateSecurityGroupStub        func(context.Context, authorization.Info, repositories.CreateSecurityGroupMessage) (repositories.SecurityGroupRecord, error)
+	createSecurityGroupMutex       sync.RWMutex
+	createSecurityGroupArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.CreateSecurityGroupMessage
+	}
+	createSecurityGroupReturns struct {
+		result1 repositories.SecurityGroupRecord
+		result2 error
+	}
+	createSecurityGroupReturnsOnCall map[int]struct {
+		result1 repositories.SecurityGroupRecord
+		result2 error
+	}
+	DeleteSecurityGroupStub        func(context.Context, authorization.Info, string) error
+	deleteSecurityGroupMutex       sync.RWMutex
+	deleteSecurityGroupArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	deleteSecurityGroupReturns struct {
+		result1 error
+	}
+	deleteSecurityGroupReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GetSecurityGroupStub        func(context.Context, authorization.Info, string) (repositories.SecurityGroupRecord, error)
+	getSecurityGroupMutex       sync.RWMutex
+	getSecurityGroupArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	getSecurityGroupReturns struct {
+		result1 repositories.SecurityGroupRecord
+		result2 error
+	}
+	getSecurityGroupReturnsOnCall map[int]struct {
+		result1 repositories.SecurityGroupRecord
+		result2 error
+	}
+	ListSecurityGroupsStub        func(context.Context, authorization.Info, repositories.ListSecurityGroupsMessage) ([]repositories.SecurityGroupRecord, error)
+	listSecurityGroupsMutex       sync.RWMutex
+	listSecurityGroupsArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListSecurityGroupsMessage
+	}
+	listSecurityGroupsReturns struct {
+		result1 []repositories.SecurityGroupRecord
+		result2 error
+	}
+	listSecurityGroupsReturnsOnCall map[int]struct {
+		result1 []repositories.SecurityGroupRecord
+		result2 error
+	}
+	UnbindSecurityGroupStub        func(context.Context, authorization.Info, repositories.UnbindSecurityGroupMessage) error
+	unbindSecurityGroupMutex       sync.RWMutex
+	unbindSecurityGroupArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UnbindSecurityGroupMessage
+	}
+	unbindSecurityGroupReturns struct {
+		result1 error
+	}
+	unbindSecurityGroupReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateSecurityGroupStub        func(context.Context, authorization.Info, repositories.UpdateSecurityGroupMessage) (repositories.SecurityGroupRecord, error)
+	updateSecurityGroupMutex       sync.RWMutex
+	updateSecurityGroupArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UpdateSecurityGroupMessage
+	}
+	updateSecurityGroupReturns struct {
+		result1 repositories.SecurityGroupRecord
+		result2 error
+	}
+	updateSecurityGroupReturnsOnCall map[int]struct {
+		result1 repositories.SecurityGroupRecord
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *SecurityGroupRepository) BindSecurityGroup(arg1 context.Context, arg2 authorization.Info, arg3 repositories.BindSecurityGroupMessage) (repositories.SecurityGroupRecord, error) {
+	fake.bindSecurityGroupMutex.Lock()
+	ret, specificReturn := fake.bindSecurityGroupReturnsOnCall[len(fake.bindSecurityGroupArgsForCall)]
+	fake.bindSecurityGroupArgsForCall = append(fake.bindSecurityGroupArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.BindSecurityGroupMessage
+	}{arg1, arg2, arg3})
+	stub := fake.BindSecurityGroupStub
+	fakeReturns := fake.bindSecurityGroupReturns
+	fake.recordInvocation("BindSecurityGroup", []interface{}{arg1, arg2, arg3})
+	fake.bindSecurityGroupMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *SecurityGroupRepository) BindSecurityGroupCallCount() int {
+	fake.bindSecurityGroupMutex.RLock()
+	defer fake.bindSecurityGroupMutex.RUnlock()
+	return len(fake.bindSecurityGroupArgsForCall)
+}
+
+func (fake *SecurityGroupRepository) BindSecurityGroupCalls(stub func(context.Context, authorization.Info, repositories.BindSecurityGroupMessage) (repositories.SecurityGroupRecord, error)) {
+	fake.bindSecurityGroupMutex.Lock()
+	defer fake.bindSecurityGroupMutex.Unlock()
+	fake.BindSecurityGroupStub = stub
+}
+
+func (fake *SecurityGroupRepository) BindSecurityGroupArgsForCall(i int) (context.Context, authorization.Info, repositories.BindSecurityGroupMessage) {
+	fake.bindSecurityGroupMutex.RLock()
+	defer fake.bindSecurityGroupMutex.RUnlock()
+	argsForCall := fake.bindSecurityGroupArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *SecurityGroupRepository) BindSecurityGroupReturns(result1 repositories.SecurityGroupRecord, result2 error) {
+	fake.bindSecurityGroupMutex.Lock()
+	defer fake.bindSecurityGroupMutex.Unlock()
+	fake.BindSecurityGroupStub = nil
+	fake.bindSecurityGroupReturns = struct {
+		result1 repositories.SecurityGroupRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SecurityGroupRepository) BindSecurityGroupReturnsOnCall(i int, result1 repositories.SecurityGroupRecord, result2 error) {
+	fake.bindSecurityGroupMutex.Lock()
+	defer fake.bindSecurityGroupMutex.Unlock()
+	fake.BindSecurityGroupStub = nil
+	if fake.bindSecurityGroupReturnsOnCall == nil {
+		fake.bindSecurityGroupReturnsOnCall = make(map[int]struct {
+			result1 repositories.SecurityGroupRecord
+			result2 error
+		})
+	}
+	fake.bindSecurityGroupReturnsOnCall[i] = struct {
+		result1 repositories.SecurityGroupRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SecurityGroupRepository) CreateSecurityGroup(arg1 context.Context, arg2 authorization.Info, arg3 repositories.CreateSecurityGroupMessage) (repositories.SecurityGroupRecord, error) {
+	fake.createSecurityGroupMutex.Lock()
+	ret, specificReturn := fake.createSecurityGroupReturnsOnCall[len(fake.createSecurityGroupArgsForCall)]
+	fake.createSecurityGroupArgsForCall = append(fake.createSecurityGroupArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.CreateSecurityGroupMessage
+	}{arg1, arg2, arg3})
+	stub := fake.CreateSecurityGroupStub
+	fakeReturns := fake.createSecurityGroupReturns
+	fake.recordInvocation("CreateSecurityGroup", []interface{}{arg1, arg2, arg3})
+	fake.createSecurityGroupMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *SecurityGroupRepository) CreateSecurityGroupCallCount() int {
+	fake.createSecurityGroupMutex.RLock()
+	defer fake.createSecurityGroupMutex.RUnlock()
+	return len(fake.createSecurityGroupArgsForCall)
+}
+
+func (fake *SecurityGroupRepository) CreateSecurityGroupCalls(stub func(context.Context, authorization.Info, repositories.CreateSecurityGroupMessage) (repositories.SecurityGroupRecord, error)) {
+	fake.createSecurityGroupMutex.Lock()
+	defer fake.createSecurityGroupMutex.Unlock()
+	fake.CreateSecurityGroupStub = stub
+}
+
+func (fake *SecurityGroupRepository) CreateSecurityGroupArgsForCall(i int) (context.Context, authorization.Info, repositories.CreateSecurityGroupMessage) {
+	fake.createSecurityGroupMutex.RLock()
+	defer fake.createSecurityGroupMutex.RUnlock()
+	argsForCall := fake.createSecurityGroupArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *SecurityGroupRepository) CreateSecurityGroupReturns(result1 repositories.SecurityGroupRecord, result2 error) {
+	fake.createSecurityGroupMutex.Lock()
+	defer fake.createSecurityGroupMutex.Unlock()
+	fake.CreateSecurityGroupStub = nil
+	fake.createSecurityGroupReturns = struct {
+		result1 repositories.SecurityGroupRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SecurityGroupRepository) CreateSecurityGroupReturnsOnCall(i int, result1 repositories.SecurityGroupRecord, result2 error) {
+	fake.createSecurityGroupMutex.Lock()
+	defer fake.createSecurityGroupMutex.Unlock()
+	fake.CreateSecurityGroupStub = nil
+	if fake.createSecurityGroupReturnsOnCall == nil {
+		fake.createSecurityGroupReturnsOnCall = make(map[int]struct {
+			result1 repositories.SecurityGroupRecord
+			result2 error
+		})
+	}
+	fake.createSecurityGroupReturnsOnCall[i] = struct {
+		result1 repositories.SecurityGroupRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SecurityGroupRepository) DeleteSecurityGroup(arg1 context.Context, arg2 authorization.Info, arg3 string) error {
+	fake.deleteSecurityGroupMutex.Lock()
+	ret, specificReturn := fake.deleteSecurityGroupReturnsOnCall[len(fake.deleteSecurityGroupArgsForCall)]
+	fake.deleteSecurityGroupArgsForCall = append(fake.deleteSecurityGroupArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.DeleteSecurityGroupStub
+	fakeReturns := fake.deleteSecurityGroupReturns
+	fake.recordInvocation("DeleteSecurityGroup", []interface{}{arg1, arg2, arg3})
+	fake.deleteSecurityGroupMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *SecurityGroupRepository) DeleteSecurityGroupCallCount() int {
+	fake.deleteSecurityGroupMutex.RLock()
+	defer fake.deleteSecurityGroupMutex.RUnlock()
+	return len(fake.deleteSecurityGroupArgsForCall)
+}
+
+func (fake *SecurityGroupRepository) DeleteSecurityGroupCalls(stub func(context.Context, authorization.Info, string) error) {
+	fake.deleteSecurityGroupMutex.Lock()
+	defer fake.deleteSecurityGroupMutex.Unlock()
+	fake.DeleteSecurityGroupStub = stub
+}
+
+func (fake *SecurityGroupRepository) DeleteSecurityGroupArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.deleteSecurityGroupMutex.RLock()
+	defer fake.deleteSecurityGroupMutex.RUnlock()
+	argsForCall := fake.deleteSecurityGroupArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *SecurityGroupRepository) DeleteSecurityGroupReturns(result1 error) {
+	fake.deleteSecurityGroupMutex.Lock()
+	defer fake.deleteSecurityGroupMutex.Unlock()
+	fake.DeleteSecurityGroupStub = nil
+	fake.deleteSecurityGroupReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *SecurityGroupRepository) DeleteSecurityGroupReturnsOnCall(i int, result1 error) {
+	fake.deleteSecurityGroupMutex.Lock()
+	defer fake.deleteSecurityGroupMutex.Unlock()
+	fake.DeleteSecurityGroupStub = nil
+	if fake.deleteSecurityGroupReturnsOnCall == nil {
+		fake.deleteSecurityGroupReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteSecurityGroupReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *SecurityGroupRepository) GetSecurityGroup(arg1 context.Context, arg2 authorization.Info, arg3 string) (repositories.SecurityGroupRecord, error) {
+	fake.getSecurityGroupMutex.Lock()
+	ret, specificReturn := fake.getSecurityGroupReturnsOnCall[len(fake.getSecurityGroupArgsForCall)]
+	fake.getSecurityGroupArgsForCall = append(fake.getSecurityGroupArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetSecurityGroupStub
+	fakeReturns := fake.getSecurityGroupReturns
+	fake.recordInvocation("GetSecurityGroup", []interface{}{arg1, arg2, arg3})
+	fake.getSecurityGroupMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *SecurityGroupRepository) GetSecurityGroupCallCount() int {
+	fake.getSecurityGroupMutex.RLock()
+	defer fake.getSecurityGroupMutex.RUnlock()
+	return len(fake.getSecurityGroupArgsForCall)
+}
+
+func (fake *SecurityGroupRepository) GetSecurityGroupCalls(stub func(context.Context, authorization.Info, string) (repositories.SecurityGroupRecord, error)) {
+	fake.getSecurityGroupMutex.Lock()
+	defer fake.getSecurityGroupMutex.Unlock()
+	fake.GetSecurityGroupStub = stub
+}
+
+func (fake *SecurityGroupRepository) GetSecurityGroupArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.getSecurityGroupMutex.RLock()
+	defer fake.getSecurityGroupMutex.RUnlock()
+	argsForCall := fake.getSecurityGroupArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *SecurityGroupRepository) GetSecurityGroupReturns(result1 repositories.SecurityGroupRecord, result2 error) {
+	fake.getSecurityGroupMutex.Lock()
+	defer fake.getSecurityGroupMutex.Unlock()
+	fake.GetSecurityGroupStub = nil
+	fake.getSecurityGroupReturns = struct {
+		result1 repositories.SecurityGroupRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SecurityGroupRepository) GetSecurityGroupReturnsOnCall(i int, result1 repositories.SecurityGroupRecord, result2 error) {
+	fake.getSecurityGroupMutex.Lock()
+	defer fake.getSecurityGroupMutex.Unlock()
+	fake.GetSecurityGroupStub = nil
+	if fake.getSecurityGroupReturnsOnCall == nil {
+		fake.getSecurityGroupReturnsOnCall = make(map[int]struct {
+			result1 repositories.SecurityGroupRecord
+			result2 error
+		})
+	}
+	fake.getSecurityGroupReturnsOnCall[i] = struct {
+		result1 repositories.SecurityGroupRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SecurityGroupRepository) ListSecurityGroups(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ListSecurityGroupsMessage) ([]repositories.SecurityGroupRecord, error) {
+	fake.listSecurityGroupsMutex.Lock()
+	ret, specificReturn := fake.listSecurityGroupsReturnsOnCall[len(fake.listSecurityGroupsArgsForCall)]
+	fake.listSecurityGroupsArgsForCall = append(fake.listSecurityGroupsArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListSecurityGroupsMessage
+	}{arg1, arg2, arg3})
+	stub := fake.ListSecurityGroupsStub
+	fakeReturns := fake.listSecurityGroupsReturns
+	fake.recordInvocation("ListSecurityGroups", []interface{}{arg1, arg2, arg3})
+	fake.listSecurityGroupsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *SecurityGroupRepository) ListSecurityGroupsCallCount() int {
+	fake.listSecurityGroupsMutex.RLock()
+	defer fake.listSecurityGroupsMutex.RUnlock()
+	return len(fake.listSecurityGroupsArgsForCall)
+}
+
+func (fake *SecurityGroupRepository) ListSecurityGroupsCalls(stub func(context.Context, authorization.Info, repositories.ListSecurityGroupsMessage) ([]repositories.SecurityGroupRecord, error)) {
+	fake.listSecurityGroupsMutex.Lock()
+	defer fake.listSecurityGroupsMutex.Unlock()
+	fake.ListSecurityGroupsStub = stub
+}
+
+func (fake *SecurityGroupRepository) ListSecurityGroupsArgsForCall(i int) (context.Context, authorization.Info, repositories.ListSecurityGroupsMessage) {
+	fake.listSecurityGroupsMutex.RLock()
+	defer fake.listSecurityGroupsMutex.RUnlock()
+	argsForCall := fake.listSecurityGroupsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *SecurityGroupRepository) ListSecurityGroupsReturns(result1 []repositories.SecurityGroupRecord, result2 error) {
+	fake.listSecurityGroupsMutex.Lock()
+	defer fake.listSecurityGroupsMutex.Unlock()
+	fake.ListSecurityGroupsStub = nil
+	fake.listSecurityGroupsReturns = struct {
+		result1 []repositories.SecurityGroupRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SecurityGroupRepository) ListSecurityGroupsReturnsOnCall(i int, result1 []repositories.SecurityGroupRecord, result2 error) {
+	fake.listSecurityGroupsMutex.Lock()
+	defer fake.listSecurityGroupsMutex.Unlock()
+	fake.ListSecurityGroupsStub = nil
+	if fake.listSecurityGroupsReturnsOnCall == nil {
+		fake.listSecurityGroupsReturnsOnCall = make(map[int]struct {
+			result1 []repositories.SecurityGroupRecord
+			result2 error
+		})
+	}
+	fake.listSecurityGroupsReturnsOnCall[i] = struct {
+		result1 []repositories.SecurityGroupRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SecurityGroupRepository) UnbindSecurityGroup(arg1 context.Context, arg2 authorization.Info, arg3 repositories.UnbindSecurityGroupMessage) error {
+	fake.unbindSecurityGroupMutex.Lock()
+	ret, specificReturn := fake.unbindSecurityGroupReturnsOnCall[len(fake.unbindSecurityGroupArgsForCall)]
+	fake.unbindSecurityGroupArgsForCall = append(fake.unbindSecurityGroupArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UnbindSecurityGroupMessage
+	}{arg1, arg2, arg3})
+	stub := fake.UnbindSecurityGroupStub
+	fakeReturns := fake.unbindSecurityGroupReturns
+	fake.recordInvocation("UnbindSecurityGroup", []interface{}{arg1, arg2, arg3})
+	fake.unbindSecurityGroupMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *SecurityGroupRepository) UnbindSecurityGroupCallCount() int {
+	fake.unbindSecurityGroupMutex.RLock()
+	defer fake.unbindSecurityGroupMutex.RUnlock()
+	return len(fake.unbindSecurityGroupArgsForCall)
+}
+
+func (fake *SecurityGroupRepository) UnbindSecurityGroupCalls(stub func(context.Context, authorization.Info, repositories.UnbindSecurityGroupMessage) error) {
+	fake.unbindSecurityGroupMutex.Lock()
+	defer fake.unbindSecurityGroupMutex.Unlock()
+	fake.UnbindSecurityGroupStub = stub
+}
+
+func (fake *SecurityGroupRepository) UnbindSecurityGroupArgsForCall(i int) (context.Context, authorization.Info, repositories.UnbindSecurityGroupMessage) {
+	fake.unbindSecurityGroupMutex.RLock()
+	defer fake.unbindSecurityGroupMutex.RUnlock()
+	argsForCall := fake.unbindSecurityGroupArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *SecurityGroupRepository) UnbindSecurityGroupReturns(result1 error) {
+	fake.unbindSecurityGroupMutex.Lock()
+	defer fake.unbindSecurityGroupMutex.Unlock()
+	fake.UnbindSecurityGroupStub = nil
+	fake.unbindSecurityGroupReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *SecurityGroupRepository) UnbindSecurityGroupReturnsOnCall(i int, result1 error) {
+	fake.unbindSecurityGroupMutex.Lock()
+	defer fake.unbindSecurityGroupMutex.Unlock()
+	fake.UnbindSecurityGroupStub = nil
+	if fake.unbindSecurityGroupReturnsOnCall == nil {
+		fake.unbindSecurityGroupReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.unbindSecurityGroupReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *SecurityGroupRepository) UpdateSecurityGroup(arg1 context.Context, arg2 authorization.Info, arg3 repositories.UpdateSecurityGroupMessage) (repositories.SecurityGroupRecord, error) {
+	fake.updateSecurityGroupMutex.Lock()
+	ret, specificReturn := fake.updateSecurityGroupReturnsOnCall[len(fake.updateSecurityGroupArgsForCall)]
+	fake.updateSecurityGroupArgsForCall = append(fake.updateSecurityGroupArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UpdateSecurityGroupMessage
+	}{arg1, arg2, arg3})
+	stub := fake.UpdateSecurityGroupStub
+	fakeReturns := fake.updateSecurityGroupReturns
+	fake.recordInvocation("UpdateSecurityGroup", []interface{}{arg1, arg2, arg3})
+	fake.updateSecurityGroupMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *SecurityGroupRepository) UpdateSecurityGroupCallCount() int {
+	fake.updateSecurityGroupMutex.RLock()
+	defer fake.updateSecurityGroupMutex.RUnlock()
+	return len(fake.updateSecurityGroupArgsForCall)
+}
+
+func (fake *SecurityGroupRepository) UpdateSecurityGroupCalls(stub func(context.Context, authorization.Info, repositories.UpdateSecurityGroupMessage) (repositories.SecurityGroupRecord, error)) {
+	fake.updateSecurityGroupMutex.Lock()
+	defer fake.updateSecurityGroupMutex.Unlock()
+	fake.UpdateSecurityGroupStub = stub
+}
+
+func (fake *SecurityGroupRepository) UpdateSecurityGroupArgsForCall(i int) (context.Context, authorization.Info, repositories.UpdateSecurityGroupMessage) {
+	fake.updateSecurityGroupMutex.RLock()
+	defer fake.updateSecurityGroupMutex.RUnlock()
+	argsForCall := fake.updateSecurityGroupArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *SecurityGroupRepository) UpdateSecurityGroupReturns(result1 repositories.SecurityGroupRecord, result2 error) {
+	fake.updateSecurityGroupMutex.Lock()
+	defer fake.updateSecurityGroupMutex.Unlock()
+	fake.UpdateSecurityGroupStub = nil
+	fake.updateSecurityGroupReturns = struct {
+		result1 repositories.SecurityGroupRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SecurityGroupRepository) UpdateSecurityGroupReturnsOnCall(i int, result1 repositories.SecurityGroupRecord, result2 error) {
+	fake.updateSecurityGroupMutex.Lock()
+	defer fake.updateSecurityGroupMutex.Unlock()
+	fake.UpdateSecurityGroupStub = nil
+	if fake.updateSecurityGroupReturnsOnCall == nil {
+		fake.updateSecurityGroupReturnsOnCall = make(map[int]struct {
+			result1 repositories.SecurityGroupRecord
+			result2 error
+		})
+	}
+	fake.updateSecurityGroupReturnsOnCall[i] = struct {
+		result1 repositories.SecurityGroupRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SecurityGroupRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.bindSecurityGroupMutex.RLock()
+	defer fake.bindSecurityGroupMutex.RUnlock()
+	fake.createSecurityGroupMutex.RLock()
+	defer fake.createSecurityGroupMutex.RUnlock()
+	fake.deleteSecurityGroupMutex.RLock()
+	defer fake.deleteSecurityGroupMutex.RUnlock()
+	fake.getSecurityGroupMutex.RLock()
+	defer fake.getSecurityGroupMutex.RUnlock()
+	fake.listSecurityGroupsMutex.RLock()
+	defer fake.listSecurityGroupsMutex.RUnlock()
+	fake.unbindSecurityGroupMutex.RLock()
+	defer fake.unbindSecurityGroupMutex.RUnlock()
+	fake.updateSecurityGroupMutex.RLock()
+	defer fake.updateSecurityGroupMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *SecurityGroupRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.SecurityGroupRepository = new(SecurityGroupRepository)
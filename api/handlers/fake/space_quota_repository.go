@@ -0,0 +1,372 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type SpaceQuotaRepository struct {
+	ApplySpaceQuotaStub        func(context.Context, authorization.Info, repositories.ApplySpaceQuotaMessage) (repositories.SpaceQuotaRecord, error)
+	applySpaceQuotaMutex       sync.RWMutex
+	applySpaceQuotaArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ApplySpaceQuotaMessage
+	}
+	applySpaceQuotaReturns struct {
+		result1 repositories.SpaceQuotaRecord
+		result2 error
+	}
+	applySpaceQuotaReturnsOnCall map[int]struct {
+		result1 repositories.SpaceQuotaRecord
+		result2 error
+	}
+	CreateSpaceQuotaStub        func(context.Context, authorization.Info, repositories.CreateSpaceQuotaMessage) (repositories.SpaceQuotaRecord, error)
+	createSpaceQuotaMutex       sync.RWMutex
+	createSpaceQuotaArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.CreateSpaceQuotaMessage
+	}
+	createSpaceQuotaReturns struct {
+		result1 repositories.SpaceQuotaRecord
+		result2 error
+	}
+	createSpaceQuotaReturnsOnCall map[int]struct {
+		result1 repositories.SpaceQuotaRecord
+		result2 error
+	}
+	GetSpaceQuotaStub        func(context.Context, authorization.Info, string) (repositories.SpaceQuotaRecord, error)
+	getSpaceQuotaMutex       sync.RWMutex
+	getSpaceQuotaArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	getSpaceQuotaReturns struct {
+		result1 repositories.SpaceQuotaRecord
+		result2 error
+	}
+	getSpaceQuotaReturnsOnCall map[int]struct {
+		result1 repositories.SpaceQuotaRecord
+		result2 error
+	}
+	ListSpaceQuotasStub        func(context.Context, authorization.Info, repositories.ListSpaceQuotasMessage) ([]repositories.SpaceQuotaRecord, error)
+	listSpaceQuotasMutex       sync.RWMutex
+	listSpaceQuotasArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListSpaceQuotasMessage
+	}
+	listSpaceQuotasReturns struct {
+		result1 []repositories.SpaceQuotaRecord
+		result2 error
+	}
+	listSpaceQuotasReturnsOnCall map[int]struct {
+		result1 []repositories.SpaceQuotaRecord
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *SpaceQuotaRepository) ApplySpaceQuota(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ApplySpaceQuotaMessage) (repositories.SpaceQuotaRecord, error) {
+	fake.applySpaceQuotaMutex.Lock()
+	ret, specificReturn := fake.applySpaceQuotaReturnsOnCall[len(fake.applySpaceQuotaArgsForCall)]
+	fake.applySpaceQuotaArgsForCall = append(fake.applySpaceQuotaArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ApplySpaceQuotaMessage
+	}{arg1, arg2, arg3})
+	stub := fake.ApplySpaceQuotaStub
+	fakeReturns := fake.applySpaceQuotaReturns
+	fake.recordInvocation("ApplySpaceQuota", []interface{}{arg1, arg2, arg3})
+	fake.applySpaceQuotaMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *SpaceQuotaRepository) ApplySpaceQuotaCallCount() int {
+	fake.applySpaceQuotaMutex.RLock()
+	defer fake.applySpaceQuotaMutex.RUnlock()
+	return len(fake.applySpaceQuotaArgsForCall)
+}
+
+func (fake *SpaceQuotaRepository) ApplySpaceQuotaCalls(stub func(context.Context, authorization.Info, repositories.ApplySpaceQuotaMessage) (repositories.SpaceQuotaRecord, error)) {
+	fake.applySpaceQuotaMutex.Lock()
+	defer fake.applySpaceQuotaMutex.Unlock()
+	fake.ApplySpaceQuotaStub = stub
+}
+
+func (fake *SpaceQuotaRepository) ApplySpaceQuotaArgsForCall(i int) (context.Context, authorization.Info, repositories.ApplySpaceQuotaMessage) {
+	fake.applySpaceQuotaMutex.RLock()
+	defer fake.applySpaceQuotaMutex.RUnlock()
+	argsForCall := fake.applySpaceQuotaArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *SpaceQuotaRepository) ApplySpaceQuotaReturns(result1 repositories.SpaceQuotaRecord, result2 error) {
+	fake.applySpaceQuotaMutex.Lock()
+	defer fake.applySpaceQuotaMutex.Unlock()
+	fake.ApplySpaceQuotaStub = nil
+	fake.applySpaceQuotaReturns = struct {
+		result1 repositories.SpaceQuotaRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SpaceQuotaRepository) ApplySpaceQuotaReturnsOnCall(i int, result1 repositories.SpaceQuotaRecord, result2 error) {
+	fake.applySpaceQuotaMutex.Lock()
+	defer fake.applySpaceQuotaMutex.Unlock()
+	fake.ApplySpaceQuotaStub = nil
+	if fake.applySpaceQuotaReturnsOnCall == nil {
+		fake.applySpaceQuotaReturnsOnCall = make(map[int]struct {
+			result1 repositories.SpaceQuotaRecord
+			result2 error
+		})
+	}
+	fake.applySpaceQuotaReturnsOnCall[i] = struct {
+		result1 repositories.SpaceQuotaRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SpaceQuotaRepository) CreateSpaceQuota(arg1 context.Context, arg2 authorization.Info, arg3 repositories.CreateSpaceQuotaMessage) (repositories.SpaceQuotaRecord, error) {
+	fake.createSpaceQuotaMutex.Lock()
+	ret, specificReturn := fake.createSpaceQuotaReturnsOnCall[len(fake.createSpaceQuotaArgsForCall)]
+	fake.createSpaceQuotaArgsForCall = append(fake.createSpaceQuotaArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.CreateSpaceQuotaMessage
+	}{arg1, arg2, arg3})
+	stub := fake.CreateSpaceQuotaStub
+	fakeReturns := fake.createSpaceQuotaReturns
+	fake.recordInvocation("CreateSpaceQuota", []interface{}{arg1, arg2, arg3})
+	fake.createSpaceQuotaMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *SpaceQuotaRepository) CreateSpaceQuotaCallCount() int {
+	fake.createSpaceQuotaMutex.RLock()
+	defer fake.createSpaceQuotaMutex.RUnlock()
+	return len(fake.createSpaceQuotaArgsForCall)
+}
+
+func (fake *SpaceQuotaRepository) CreateSpaceQuotaCalls(stub func(context.Context, authorization.Info, repositories.CreateSpaceQuotaMessage) (repositories.SpaceQuotaRecord, error)) {
+	fake.createSpaceQuotaMutex.Lock()
+	defer fake.createSpaceQuotaMutex.Unlock()
+	fake.CreateSpaceQuotaStub = stub
+}
+
+func (fake *SpaceQuotaRepository) CreateSpaceQuotaArgsForCall(i int) (context.Context, authorization.Info, repositories.CreateSpaceQuotaMessage) {
+	fake.createSpaceQuotaMutex.RLock()
+	defer fake.createSpaceQuotaMutex.RUnlock()
+	argsForCall := fake.createSpaceQuotaArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *SpaceQuotaRepository) CreateSpaceQuotaReturns(result1 repositories.SpaceQuotaRecord, result2 error) {
+	fake.createSpaceQuotaMutex.Lock()
+	defer fake.createSpaceQuotaMutex.Unlock()
+	fake.CreateSpaceQuotaStub = nil
+	fake.createSpaceQuotaReturns = struct {
+		result1 repositories.SpaceQuotaRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SpaceQuotaRepository) CreateSpaceQuotaReturnsOnCall(i int, result1 repositories.SpaceQuotaRecord, result2 error) {
+	fake.createSpaceQuotaMutex.Lock()
+	defer fake.createSpaceQuotaMutex.Unlock()
+	fake.CreateSpaceQuotaStub = nil
+	if fake.createSpaceQuotaReturnsOnCall == nil {
+		fake.createSpaceQuotaReturnsOnCall = make(map[int]struct {
+			result1 repositories.SpaceQuotaRecord
+			result2 error
+		})
+	}
+	fake.createSpaceQuotaReturnsOnCall[i] = struct {
+		result1 repositories.SpaceQuotaRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SpaceQuotaRepository) GetSpaceQuota(arg1 context.Context, arg2 authorization.Info, arg3 string) (repositories.SpaceQuotaRecord, error) {
+	fake.getSpaceQuotaMutex.Lock()
+	ret, specificReturn := fake.getSpaceQuotaReturnsOnCall[len(fake.getSpaceQuotaArgsForCall)]
+	fake.getSpaceQuotaArgsForCall = append(fake.getSpaceQuotaArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetSpaceQuotaStub
+	fakeReturns := fake.getSpaceQuotaReturns
+	fake.recordInvocation("GetSpaceQuota", []interface{}{arg1, arg2, arg3})
+	fake.getSpaceQuotaMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *SpaceQuotaRepository) GetSpaceQuotaCallCount() int {
+	fake.getSpaceQuotaMutex.RLock()
+	defer fake.getSpaceQuotaMutex.RUnlock()
+	return len(fake.getSpaceQuotaArgsForCall)
+}
+
+func (fake *SpaceQuotaRepository) GetSpaceQuotaCalls(stub func(context.Context, authorization.Info, string) (repositories.SpaceQuotaRecord, error)) {
+	fake.getSpaceQuotaMutex.Lock()
+	defer fake.getSpaceQuotaMutex.Unlock()
+	fake.GetSpaceQuotaStub = stub
+}
+
+func (fake *SpaceQuotaRepository) GetSpaceQuotaArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.getSpaceQuotaMutex.RLock()
+	defer fake.getSpaceQuotaMutex.RUnlock()
+	argsForCall := fake.getSpaceQuotaArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *SpaceQuotaRepository) GetSpaceQuotaReturns(result1 repositories.SpaceQuotaRecord, result2 error) {
+	fake.getSpaceQuotaMutex.Lock()
+	defer fake.getSpaceQuotaMutex.Unlock()
+	fake.GetSpaceQuotaStub = nil
+	fake.getSpaceQuotaReturns = struct {
+		result1 repositories.SpaceQuotaRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SpaceQuotaRepository) GetSpaceQuotaReturnsOnCall(i int, result1 repositories.SpaceQuotaRecord, result2 error) {
+	fake.getSpaceQuotaMutex.Lock()
+	defer fake.getSpaceQuotaMutex.Unlock()
+	fake.GetSpaceQuotaStub = nil
+	if fake.getSpaceQuotaReturnsOnCall == nil {
+		fake.getSpaceQuotaReturnsOnCall = make(map[int]struct {
+			result1 repositories.SpaceQuotaRecord
+			result2 error
+		})
+	}
+	fake.getSpaceQuotaReturnsOnCall[i] = struct {
+		result1 repositories.SpaceQuotaRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SpaceQuotaRepository) ListSpaceQuotas(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ListSpaceQuotasMessage) ([]repositories.SpaceQuotaRecord, error) {
+	fake.listSpaceQuotasMutex.Lock()
+	ret, specificReturn := fake.listSpaceQuotasReturnsOnCall[len(fake.listSpaceQuotasArgsForCall)]
+	fake.listSpaceQuotasArgsForCall = append(fake.listSpaceQuotasArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListSpaceQuotasMessage
+	}{arg1, arg2, arg3})
+	stub := fake.ListSpaceQuotasStub
+	fakeReturns := fake.listSpaceQuotasReturns
+	fake.recordInvocation("ListSpaceQuotas", []interface{}{arg1, arg2, arg3})
+	fake.listSpaceQuotasMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *SpaceQuotaRepository) ListSpaceQuotasCallCount() int {
+	fake.listSpaceQuotasMutex.RLock()
+	defer fake.listSpaceQuotasMutex.RUnlock()
+	return len(fake.listSpaceQuotasArgsForCall)
+}
+
+func (fake *SpaceQuotaRepository) ListSpaceQuotasCalls(stub func(context.Context, authorization.Info, repositories.ListSpaceQuotasMessage) ([]repositories.SpaceQuotaRecord, error)) {
+	fake.listSpaceQuotasMutex.Lock()
+	defer fake.listSpaceQuotasMutex.Unlock()
+	fake.ListSpaceQuotasStub = stub
+}
+
+func (fake *SpaceQuotaRepository) ListSpaceQuotasArgsForCall(i int) (context.Context, authorization.Info, repositories.ListSpaceQuotasMessage) {
+	fake.listSpaceQuotasMutex.RLock()
+	defer fake.listSpaceQuotasMutex.RUnlock()
+	argsForCall := fake.listSpaceQuotasArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *SpaceQuotaRepository) ListSpaceQuotasReturns(result1 []repositories.SpaceQuotaRecord, result2 error) {
+	fake.listSpaceQuotasMutex.Lock()
+	defer fake.listSpaceQuotasMutex.Unlock()
+	fake.ListSpaceQuotasStub = nil
+	fake.listSpaceQuotasReturns = struct {
+		result1 []repositories.SpaceQuotaRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SpaceQuotaRepository) ListSpaceQuotasReturnsOnCall(i int, result1 []repositories.SpaceQuotaRecord, result2 error) {
+	fake.listSpaceQuotasMutex.Lock()
+	defer fake.listSpaceQuotasMutex.Unlock()
+	fake.ListSpaceQuotasStub = nil
+	if fake.listSpaceQuotasReturnsOnCall == nil {
+		fake.listSpaceQuotasReturnsOnCall = make(map[int]struct {
+			result1 []repositories.SpaceQuotaRecord
+			result2 error
+		})
+	}
+	fake.listSpaceQuotasReturnsOnCall[i] = struct {
+		result1 []repositories.SpaceQuotaRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SpaceQuotaRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.applySpaceQuotaMutex.RLock()
+	defer fake.applySpaceQuotaMutex.RUnlock()
+	fake.createSpaceQuotaMutex.RLock()
+	defer fake.createSpaceQuotaMutex.RUnlock()
+	fake.getSpaceQuotaMutex.RLock()
+	defer fake.getSpaceQuotaMutex.RUnlock()
+	fake.listSpaceQuotasMutex.RLock()
+	defer fake.listSpaceQuotasMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *SpaceQuotaRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.SpaceQuotaRepository = new(SpaceQuotaRepository)
@@ -0,0 +1,120 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/handlers"
+)
+
+type TokenRefresher struct {
+	RefreshStub        func(context.Context, string) (authorization.OAuthToken, error)
+	refreshMutex       sync.RWMutex
+	refreshArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	refreshReturns struct {
+		result1 authorization.OAuthToken
+		result2 error
+	}
+	refreshReturnsOnCall map[int]struct {
+		result1 authorization.OAuthToken
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *TokenRefresher) Refresh(arg1 context.Context, arg2 string) (authorization.OAuthToken, error) {
+	fake.refreshMutex.Lock()
+	ret, specificReturn := fake.refreshReturnsOnCall[len(fake.refreshArgsForCall)]
+	fake.refreshArgsForCall = append(fake.refreshArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.RefreshStub
+	fakeReturns := fake.refreshReturns
+	fake.recordInvocation("Refresh", []interface{}{arg1, arg2})
+	fake.refreshMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *TokenRefresher) RefreshCallCount() int {
+	fake.refreshMutex.RLock()
+	defer fake.refreshMutex.RUnlock()
+	return len(fake.refreshArgsForCall)
+}
+
+func (fake *TokenRefresher) RefreshCalls(stub func(context.Context, string) (authorization.OAuthToken, error)) {
+	fake.refreshMutex.Lock()
+	defer fake.refreshMutex.Unlock()
+	fake.RefreshStub = stub
+}
+
+func (fake *TokenRefresher) RefreshArgsForCall(i int) (context.Context, string) {
+	fake.refreshMutex.RLock()
+	defer fake.refreshMutex.RUnlock()
+	argsForCall := fake.refreshArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *TokenRefresher) RefreshReturns(result1 authorization.OAuthToken, result2 error) {
+	fake.refreshMutex.Lock()
+	defer fake.refreshMutex.Unlock()
+	fake.RefreshStub = nil
+	fake.refreshReturns = struct {
+		result1 authorization.OAuthToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TokenRefresher) RefreshReturnsOnCall(i int, result1 authorization.OAuthToken, result2 error) {
+	fake.refreshMutex.Lock()
+	defer fake.refreshMutex.Unlock()
+	fake.RefreshStub = nil
+	if fake.refreshReturnsOnCall == nil {
+		fake.refreshReturnsOnCall = make(map[int]struct {
+			result1 authorization.OAuthToken
+			result2 error
+		})
+	}
+	fake.refreshReturnsOnCall[i] = struct {
+		result1 authorization.OAuthToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TokenRefresher) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.refreshMutex.RLock()
+	defer fake.refreshMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *TokenRefresher) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.TokenRefresher = new(TokenRefresher)
@@ -24,9 +24,9 @@ var _ = Describe("GET /v3/apps/:guid/env", func() {
 
 	BeforeEach(func() {
 		appRepo := repositories.NewAppRepo(namespaceRetriever, clientFactory, nsPermissions, conditions.NewConditionAwaiter[*korifiv1alpha1.CFApp, korifiv1alpha1.CFAppList](2*time.Second))
-		domainRepo := repositories.NewDomainRepo(clientFactory, namespaceRetriever, rootNamespace)
+		domainRepo := repositories.NewDomainRepo(clientFactory, namespaceRetriever, nsPermissions, k8sClient, rootNamespace)
 		processRepo := repositories.NewProcessRepo(namespaceRetriever, clientFactory, nsPermissions)
-		routeRepo := repositories.NewRouteRepo(namespaceRetriever, clientFactory, nsPermissions)
+		routeRepo := repositories.NewRouteRepo(namespaceRetriever, clientFactory, nsPermissions, rootNamespace)
 		dropletRepo := repositories.NewDropletRepo(clientFactory, namespaceRetriever, nsPermissions)
 		orgRepo := repositories.NewOrgRepo("root-ns", k8sClient, clientFactory, nsPermissions, time.Minute)
 		spaceRepo := repositories.NewSpaceRepo(namespaceRetriever, orgRepo, clientFactory, nsPermissions, time.Minute)
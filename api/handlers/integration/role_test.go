@@ -32,11 +32,11 @@ var _ = Describe("Role", func() {
 		}
 		orgRepo := repositories.NewOrgRepo(rootNamespace, k8sClient, clientFactory, nsPermissions, time.Minute)
 		spaceRepo := repositories.NewSpaceRepo(namespaceRetriever, orgRepo, clientFactory, nsPermissions, time.Minute)
-		roleRepo := repositories.NewRoleRepo(clientFactory, spaceRepo, nsPermissions, rootNamespace, roleMappings)
+		roleRepo := repositories.NewRoleRepo(clientFactory, k8sClient, spaceRepo, nsPermissions, nsPermissions, rootNamespace, roleMappings)
 		decoderValidator, err := handlers.NewDefaultDecoderValidator()
 		Expect(err).NotTo(HaveOccurred())
 
-		apiHandler = handlers.NewRoleHandler(*serverURL, roleRepo, decoderValidator)
+		apiHandler = handlers.NewRoleHandler(*serverURL, roleRepo, orgRepo, spaceRepo, decoderValidator)
 		apiHandler.RegisterRoutes(router)
 
 		org = createOrgWithCleanup(ctx, generateGUID())
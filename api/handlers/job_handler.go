@@ -17,13 +17,15 @@ import (
 )
 
 const (
-	JobPath            = "/v3/jobs/{guid}"
-	syncSpacePrefix    = "space.apply_manifest"
-	appDeletePrefix    = "app.delete"
-	orgDeletePrefix    = "org.delete"
-	routeDeletePrefix  = "route.delete"
-	spaceDeletePrefix  = "space.delete"
-	domainDeletePrefix = "domain.delete"
+	JobPath                   = "/v3/jobs/{guid}"
+	syncSpacePrefix           = "space.apply_manifest"
+	appDeletePrefix           = "app.delete"
+	orgDeletePrefix           = "org.delete"
+	routeDeletePrefix         = "route.delete"
+	spaceDeletePrefix         = "space.delete"
+	domainDeletePrefix        = "domain.delete"
+	roleDeletePrefix          = "role.delete"
+	securityGroupDeletePrefix = "security_group.delete"
 )
 
 const JobResourceType = "Job"
@@ -59,7 +61,7 @@ func (h *JobHandler) jobGetHandler(ctx context.Context, logger logr.Logger, auth
 	switch jobType {
 	case syncSpacePrefix:
 		jobResponse = presenter.ForManifestApplyJob(jobGUID, resourceGUID, h.serverURL)
-	case appDeletePrefix, orgDeletePrefix, spaceDeletePrefix, routeDeletePrefix, domainDeletePrefix:
+	case appDeletePrefix, orgDeletePrefix, spaceDeletePrefix, routeDeletePrefix, domainDeletePrefix, roleDeletePrefix, securityGroupDeletePrefix:
 		jobResponse = presenter.ForDeleteJob(jobGUID, jobType, h.serverURL)
 	default:
 		return nil, apierrors.LogAndReturn(
@@ -189,6 +189,53 @@ var _ = Describe("JobHandler", func() {
 					}`, defaultServerURL, jobGUID)))
 				})
 			})
+
+			When("the existing job operation is role.delete", func() {
+				BeforeEach(func() {
+					resourceGUID = uuid.NewString()
+					jobGUID = "role.delete~" + resourceGUID
+				})
+
+				It("returns the job", func() {
+					Expect(rr.Body).To(MatchJSON(fmt.Sprintf(`{
+						"created_at": "",
+						"errors": null,
+						"guid": "%[2]s",
+						"links": {
+							"self": {
+								"href": "%[1]s/v3/jobs/%[2]s"
+							}
+						},
+						"operation": "role.delete",
+						"state": "COMPLETE",
+						"updated_at": "",
+						"warnings": null
+					}`, defaultServerURL, jobGUID)))
+				})
+			})
+			When("the existing job operation is security_group.delete", func() {
+				BeforeEach(func() {
+					resourceGUID = uuid.NewString()
+					jobGUID = "security_group.delete~" + resourceGUID
+				})
+
+				It("returns the job", func() {
+					Expect(rr.Body).To(MatchJSON(fmt.Sprintf(`{
+						"created_at": "",
+						"errors": null,
+						"guid": "%[2]s",
+						"links": {
+							"self": {
+								"href": "%[1]s/v3/jobs/%[2]s"
+							}
+						},
+						"operation": "security_group.delete",
+						"state": "COMPLETE",
+						"updated_at": "",
+						"warnings": null
+					}`, defaultServerURL, jobGUID)))
+				})
+			})
 		})
 
 		Describe("job guid validation", func() {
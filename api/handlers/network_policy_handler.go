@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	"github.com/go-logr/logr"
+	"github.com/gorilla/mux"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	NetworkPoliciesPath               = "/networking/v1/external/policies"
+	NetworkPoliciesDeletePath         = "/networking/v1/external/policies/delete"
+	appNotFoundOrForbiddenMessageTmpl = "App with guid %s does not exist, or you do not have access to it."
+)
+
+//counterfeiter:generate -o fake -fake-name NetworkPolicyRepository . NetworkPolicyRepository
+
+type NetworkPolicyRepository interface {
+	CreateNetworkPolicy(context.Context, authorization.Info, repositories.CreateNetworkPolicyMessage) (repositories.NetworkPolicyRecord, error)
+	ListNetworkPolicies(context.Context, authorization.Info, repositories.ListNetworkPoliciesMessage) ([]repositories.NetworkPolicyRecord, error)
+	DeleteNetworkPolicy(context.Context, authorization.Info, repositories.DeleteNetworkPolicyMessage) error
+}
+
+// NetworkPolicyHandler implements the container-to-container policy endpoints of the Cloud Foundry policy
+// server external API, which the CF CLI network policy commands use
+type NetworkPolicyHandler struct {
+	handlerWrapper    *AuthAwareHandlerFuncWrapper
+	networkPolicyRepo NetworkPolicyRepository
+	appRepo           CFAppRepository
+	decoderValidator  *DecoderValidator
+}
+
+func NewNetworkPolicyHandler(networkPolicyRepo NetworkPolicyRepository, appRepo CFAppRepository, decoderValidator *DecoderValidator) *NetworkPolicyHandler {
+	return &NetworkPolicyHandler{
+		handlerWrapper:    NewAuthAwareHandlerFuncWrapper(ctrl.Log.WithName("NetworkPolicyHandler")),
+		networkPolicyRepo: networkPolicyRepo,
+		appRepo:           appRepo,
+		decoderValidator:  decoderValidator,
+	}
+}
+
+func (h *NetworkPolicyHandler) networkPolicyCreateHandler(ctx context.Context, logger logr.Logger, authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	var payload payloads.NetworkPolicies
+	if err := h.decoderValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	messages := make([]repositories.CreateNetworkPolicyMessage, 0, len(payload.Policies))
+	for _, policy := range payload.Policies {
+		sourceApp, err := h.getApp(ctx, logger, authInfo, policy.Source.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		destinationApp, err := h.getApp(ctx, logger, authInfo, policy.Destination.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		messages = append(messages, repositories.CreateNetworkPolicyMessage{
+			SourceAppGUID:        sourceApp.GUID,
+			SourceSpaceGUID:      sourceApp.SpaceGUID,
+			DestinationAppGUID:   destinationApp.GUID,
+			DestinationSpaceGUID: destinationApp.SpaceGUID,
+			Protocol:             policy.Destination.Protocol,
+			StartPort:            policy.Destination.Ports.Start,
+			EndPort:              policy.Destination.Ports.End,
+		})
+	}
+
+	for _, message := range messages {
+		if _, err := h.networkPolicyRepo.CreateNetworkPolicy(ctx, authInfo, message); err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "Failed to create network policy", "source", message.SourceAppGUID, "destination", message.DestinationAppGUID)
+		}
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(map[string]interface{}{}), nil
+}
+
+func (h *NetworkPolicyHandler) networkPolicyListHandler(ctx context.Context, logger logr.Logger, authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to parse request query parameters")
+	}
+
+	listFilter := new(payloads.NetworkPolicyList)
+	if err := payloads.Decode(listFilter, r.Form); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	policies, err := h.networkPolicyRepo.ListNetworkPolicies(ctx, authInfo, listFilter.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to list network policies")
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForNetworkPolicyList(policies)), nil
+}
+
+func (h *NetworkPolicyHandler) networkPolicyDeleteHandler(ctx context.Context, logger logr.Logger, authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	var payload payloads.NetworkPolicies
+	if err := h.decoderValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	for _, policy := range payload.Policies {
+		destinationApp, err := h.getApp(ctx, logger, authInfo, policy.Destination.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		message := repositories.DeleteNetworkPolicyMessage{
+			SourceAppGUID:        policy.Source.ID,
+			DestinationAppGUID:   destinationApp.GUID,
+			DestinationSpaceGUID: destinationApp.SpaceGUID,
+			Protocol:             policy.Destination.Protocol,
+			StartPort:            policy.Destination.Ports.Start,
+			EndPort:              policy.Destination.Ports.End,
+		}
+		if err := h.networkPolicyRepo.DeleteNetworkPolicy(ctx, authInfo, message); err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "Failed to delete network policy", "source", message.SourceAppGUID, "destination", message.DestinationAppGUID)
+		}
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(map[string]interface{}{}), nil
+}
+
+func (h *NetworkPolicyHandler) getApp(ctx context.Context, logger logr.Logger, authInfo authorization.Info, appGUID string) (repositories.AppRecord, error) {
+	app, err := h.appRepo.GetApp(ctx, authInfo, appGUID)
+	if err != nil {
+		return repositories.AppRecord{}, apierrors.LogAndReturn(
+			logger,
+			apierrors.AsUnprocessableEntity(err, fmt.Sprintf(appNotFoundOrForbiddenMessageTmpl, appGUID), apierrors.NotFoundError{}, apierrors.ForbiddenError{}),
+			"Failed to fetch app", "appGUID", appGUID,
+		)
+	}
+
+	return app, nil
+}
+
+func (h *NetworkPolicyHandler) RegisterRoutes(router *mux.Router) {
+	router.Path(NetworkPoliciesPath).Methods("POST").HandlerFunc(h.handlerWrapper.Wrap(h.networkPolicyCreateHandler))
+	router.Path(NetworkPoliciesPath).Methods("GET").HandlerFunc(h.handlerWrapper.Wrap(h.networkPolicyListHandler))
+	router.Path(NetworkPoliciesDeletePath).Methods("POST").HandlerFunc(h.handlerWrapper.Wrap(h.networkPolicyDeleteHandler))
+}
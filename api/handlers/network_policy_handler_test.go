@@ -0,0 +1,198 @@
+package handlers_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apis "code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NetworkPolicyHandler", func() {
+	const policiesJSON = `{
+        "policies": [{
+            "source": {"id": "source-app-guid"},
+            "destination": {"id": "destination-app-guid", "protocol": "tcp", "ports": {"start": 8080, "end": 8090}}
+        }]
+    }`
+
+	var (
+		networkPolicyRepo *fake.NetworkPolicyRepository
+		appRepo           *fake.CFAppRepository
+	)
+
+	BeforeEach(func() {
+		networkPolicyRepo = new(fake.NetworkPolicyRepository)
+		appRepo = new(fake.CFAppRepository)
+		decoderValidator, err := apis.NewDefaultDecoderValidator()
+		Expect(err).NotTo(HaveOccurred())
+
+		appRepo.GetAppStub = func(_ context.Context, _ authorization.Info, appGUID string) (repositories.AppRecord, error) {
+			return repositories.AppRecord{GUID: appGUID, SpaceGUID: strings.Replace(appGUID, "app", "space", 1)}, nil
+		}
+
+		apis.NewNetworkPolicyHandler(networkPolicyRepo, appRepo, decoderValidator).RegisterRoutes(router)
+	})
+
+	serveRequest := func(method, path, body string) {
+		req, err := http.NewRequestWithContext(ctx, method, path, strings.NewReader(body))
+		Expect(err).NotTo(HaveOccurred())
+
+		router.ServeHTTP(rr, req)
+	}
+
+	Describe("POST /networking/v1/external/policies", func() {
+		It("creates the policies in the destination app space", func() {
+			serveRequest("POST", "/networking/v1/external/policies", policiesJSON)
+
+			expectJSONResponse(http.StatusOK, `{}`)
+
+			Expect(networkPolicyRepo.CreateNetworkPolicyCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := networkPolicyRepo.CreateNetworkPolicyArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.CreateNetworkPolicyMessage{
+				SourceAppGUID:        "source-app-guid",
+				SourceSpaceGUID:      "source-space-guid",
+				DestinationAppGUID:   "destination-app-guid",
+				DestinationSpaceGUID: "destination-space-guid",
+				Protocol:             "tcp",
+				StartPort:            8080,
+				EndPort:              8090,
+			}))
+		})
+
+		When("the protocol is invalid", func() {
+			It("returns an unprocessable entity error", func() {
+				serveRequest("POST", "/networking/v1/external/policies", `{
+                    "policies": [{
+                        "source": {"id": "source-app-guid"},
+                        "destination": {"id": "destination-app-guid", "protocol": "icmp", "ports": {"start": 8080, "end": 8080}}
+                    }]
+                }`)
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusUnprocessableEntity))
+				Expect(networkPolicyRepo.CreateNetworkPolicyCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the end port is lower than the start port", func() {
+			It("returns an unprocessable entity error", func() {
+				serveRequest("POST", "/networking/v1/external/policies", `{
+                    "policies": [{
+                        "source": {"id": "source-app-guid"},
+                        "destination": {"id": "destination-app-guid", "protocol": "tcp", "ports": {"start": 8080, "end": 80}}
+                    }]
+                }`)
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusUnprocessableEntity))
+				Expect(networkPolicyRepo.CreateNetworkPolicyCallCount()).To(Equal(0))
+			})
+		})
+
+		When("an app does not exist", func() {
+			BeforeEach(func() {
+				appRepo.GetAppStub = nil
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewNotFoundError(nil, repositories.AppResourceType))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				serveRequest("POST", "/networking/v1/external/policies", policiesJSON)
+
+				expectUnprocessableEntityError("App with guid source-app-guid does not exist, or you do not have access to it.")
+				Expect(networkPolicyRepo.CreateNetworkPolicyCallCount()).To(Equal(0))
+			})
+		})
+
+		When("creating the policy fails", func() {
+			BeforeEach(func() {
+				networkPolicyRepo.CreateNetworkPolicyReturns(repositories.NetworkPolicyRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				serveRequest("POST", "/networking/v1/external/policies", policiesJSON)
+
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /networking/v1/external/policies", func() {
+		BeforeEach(func() {
+			networkPolicyRepo.ListNetworkPoliciesReturns([]repositories.NetworkPolicyRecord{{
+				SourceAppGUID:        "source-app-guid",
+				SourceSpaceGUID:      "source-space-guid",
+				DestinationAppGUID:   "destination-app-guid",
+				DestinationSpaceGUID: "destination-space-guid",
+				Protocol:             "tcp",
+				StartPort:            8080,
+				EndPort:              8090,
+			}}, nil)
+		})
+
+		It("lists the policies in the policy server format", func() {
+			serveRequest("GET", "/networking/v1/external/policies?id=source-app-guid,other-app-guid", "")
+
+			expectJSONResponse(http.StatusOK, `{
+                "total_policies": 1,
+                "policies": [{
+                    "source": {"id": "source-app-guid"},
+                    "destination": {"id": "destination-app-guid", "protocol": "tcp", "ports": {"start": 8080, "end": 8090}}
+                }]
+            }`)
+
+			_, actualAuthInfo, message := networkPolicyRepo.ListNetworkPoliciesArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.AppGUIDs).To(ConsistOf("source-app-guid", "other-app-guid"))
+		})
+
+		When("an unknown query parameter is used", func() {
+			It("returns an unknown key error", func() {
+				serveRequest("GET", "/networking/v1/external/policies?foo=bar", "")
+
+				expectUnknownKeyError("The query parameter is invalid: Valid parameters are: 'id, source_id, dest_id'")
+			})
+		})
+	})
+
+	Describe("POST /networking/v1/external/policies/delete", func() {
+		It("deletes the policies", func() {
+			serveRequest("POST", "/networking/v1/external/policies/delete", policiesJSON)
+
+			expectJSONResponse(http.StatusOK, `{}`)
+
+			Expect(networkPolicyRepo.DeleteNetworkPolicyCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := networkPolicyRepo.DeleteNetworkPolicyArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.DeleteNetworkPolicyMessage{
+				SourceAppGUID:        "source-app-guid",
+				DestinationAppGUID:   "destination-app-guid",
+				DestinationSpaceGUID: "destination-space-guid",
+				Protocol:             "tcp",
+				StartPort:            8080,
+				EndPort:              8090,
+			}))
+		})
+
+		When("the destination app does not exist", func() {
+			BeforeEach(func() {
+				appRepo.GetAppStub = nil
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				serveRequest("POST", "/networking/v1/external/policies/delete", policiesJSON)
+
+				expectUnprocessableEntityError("App with guid destination-app-guid does not exist, or you do not have access to it.")
+				Expect(networkPolicyRepo.DeleteNetworkPolicyCallCount()).To(Equal(0))
+			})
+		})
+	})
+})
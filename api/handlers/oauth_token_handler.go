@@ -2,15 +2,18 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/url"
 	"time"
 
+	"code.cloudfoundry.org/korifi/api/apierrors"
 	"code.cloudfoundry.org/korifi/api/authorization"
 
 	"github.com/go-logr/logr"
 	"github.com/golang-jwt/jwt"
 	"github.com/gorilla/mux"
+	josejwt "gopkg.in/square/go-jose.v2/jwt"
 
 	ctrl "sigs.k8s.io/controller-runtime"
 )
@@ -19,19 +22,48 @@ const (
 	OAuthTokenPath = "/oauth/token"
 )
 
+//counterfeiter:generate -o fake -fake-name TokenRefresher . TokenRefresher
+
+type TokenRefresher interface {
+	Refresh(ctx context.Context, refreshToken string) (authorization.OAuthToken, error)
+}
+
 type OAuthTokenHandler struct {
-	handlerWrapper *AuthAwareHandlerFuncWrapper
-	apiBaseURL     url.URL
+	handlerWrapper   *AuthAwareHandlerFuncWrapper
+	apiBaseURL       url.URL
+	identityProvider IdentityProvider
+	tokenRefresher   TokenRefresher
+}
+
+type oauthTokenResponse struct {
+	TokenType    string `json:"token_type"`
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	ExpiresIn    int64  `json:"expires_in,omitempty"`
 }
 
-func NewOAuthToken(apiBaseURL url.URL) *OAuthTokenHandler {
+// NewOAuthToken creates the handler for the token endpoint of the CF CLI. The
+// tokenRefresher is optional, without it refresh grants are answered with a
+// placeholder token, which is what clients authenticating with their
+// kubeconfig expect.
+func NewOAuthToken(apiBaseURL url.URL, identityProvider IdentityProvider, tokenRefresher TokenRefresher) *OAuthTokenHandler {
 	return &OAuthTokenHandler{
-		handlerWrapper: NewUnauthenticatedHandlerFuncWrapper(ctrl.Log.WithName("OAuthTokenHandler")),
-		apiBaseURL:     apiBaseURL,
+		handlerWrapper:   NewUnauthenticatedHandlerFuncWrapper(ctrl.Log.WithName("OAuthTokenHandler")),
+		apiBaseURL:       apiBaseURL,
+		identityProvider: identityProvider,
+		tokenRefresher:   tokenRefresher,
 	}
 }
 
 func (h *OAuthTokenHandler) oauthTokenHandler(ctx context.Context, logger logr.Logger, authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	if r.PostFormValue("grant_type") == "refresh_token" && h.tokenRefresher != nil {
+		return h.refreshGrant(ctx, logger, r.PostFormValue("refresh_token"))
+	}
+
+	if passcode := r.PostFormValue("passcode"); passcode != "" {
+		return h.passcodeGrant(ctx, logger, passcode)
+	}
+
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
 		"exp": time.Now().Add(time.Hour).Unix(),
 	})
@@ -42,12 +74,60 @@ func (h *OAuthTokenHandler) oauthTokenHandler(ctx context.Context, logger logr.L
 		panic(err.Error())
 	}
 
-	return NewHandlerResponse(http.StatusOK).WithBody(map[string]string{
-		"token_type":   "bearer",
-		"access_token": tokenString,
+	return NewHandlerResponse(http.StatusOK).WithBody(oauthTokenResponse{
+		TokenType:   "bearer",
+		AccessToken: tokenString,
 	}), nil
 }
 
+// passcodeGrant serves `cf login --sso`, where the passcode is a token
+// obtained from the identity provider. Once it has been verified it is handed
+// back to the CLI as the access token. Passcodes are never redeemed as refresh
+// tokens, only the refresh_token grant reaches the issuer.
+func (h *OAuthTokenHandler) passcodeGrant(ctx context.Context, logger logr.Logger, passcode string) (*HandlerResponse, error) {
+	if _, err := h.identityProvider.GetIdentity(ctx, authorization.Info{Token: passcode}); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to verify passcode")
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(forOAuthToken(authorization.OAuthToken{AccessToken: passcode})), nil
+}
+
+func (h *OAuthTokenHandler) refreshGrant(ctx context.Context, logger logr.Logger, refreshToken string) (*HandlerResponse, error) {
+	if refreshToken == "" {
+		return nil, apierrors.LogAndReturn(logger, apierrors.NewInvalidAuthError(errors.New("missing refresh token")), "failed to refresh token")
+	}
+
+	token, err := h.tokenRefresher.Refresh(ctx, refreshToken)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to refresh token")
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(forOAuthToken(token)), nil
+}
+
+func forOAuthToken(token authorization.OAuthToken) oauthTokenResponse {
+	return oauthTokenResponse{
+		TokenType:    "bearer",
+		AccessToken:  token.AccessToken,
+		RefreshToken: token.RefreshToken,
+		ExpiresIn:    expiresIn(token.AccessToken),
+	}
+}
+
+func expiresIn(token string) int64 {
+	parsedToken, err := josejwt.ParseSigned(token)
+	if err != nil {
+		return 0
+	}
+
+	claims := josejwt.Claims{}
+	if err = parsedToken.UnsafeClaimsWithoutVerification(&claims); err != nil || claims.Expiry == nil {
+		return 0
+	}
+
+	return int64(time.Until(claims.Expiry.Time()).Seconds())
+}
+
 func (h *OAuthTokenHandler) RegisterRoutes(router *mux.Router) {
 	router.Path(OAuthTokenPath).Methods("POST").HandlerFunc(h.handlerWrapper.Wrap(h.oauthTokenHandler))
 }
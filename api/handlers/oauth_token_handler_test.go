@@ -2,14 +2,21 @@ package handlers_test
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
+	"code.cloudfoundry.org/korifi/api/apierrors"
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apis "code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
 
 	"github.com/SermoDigital/jose/jws"
 	"github.com/go-http-utils/headers"
+	"github.com/golang-jwt/jwt"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -19,22 +26,43 @@ var _ = Describe("OAuthToken", func() {
 
 	var (
 		OAuthTokenHandler *apis.OAuthTokenHandler
+		identityProvider  *fake.IdentityProvider
+		tokenRefresher    *fake.TokenRefresher
+		refreshEnabled    bool
 		requestMethod     string
 		requestPath       string
+		requestForm       url.Values
 	)
 
 	BeforeEach(func() {
 		requestPath = oauthTokenBase
 		requestMethod = http.MethodPost
+		requestForm = nil
 		ctx = authorization.NewContext(ctx, &authorization.Info{Token: "the-token"})
-		OAuthTokenHandler = apis.NewOAuthToken(*serverURL)
-		OAuthTokenHandler.RegisterRoutes(router)
+		identityProvider = new(fake.IdentityProvider)
+		tokenRefresher = new(fake.TokenRefresher)
+		refreshEnabled = false
 	})
 
 	JustBeforeEach(func() {
-		req, err := http.NewRequestWithContext(ctx, requestMethod, requestPath, nil)
-		req.Header.Add(headers.Authorization, authHeader)
+		if refreshEnabled {
+			OAuthTokenHandler = apis.NewOAuthToken(*serverURL, identityProvider, tokenRefresher)
+		} else {
+			OAuthTokenHandler = apis.NewOAuthToken(*serverURL, identityProvider, nil)
+		}
+		OAuthTokenHandler.RegisterRoutes(router)
+
+		var requestBody io.Reader
+		if requestForm != nil {
+			requestBody = strings.NewReader(requestForm.Encode())
+		}
+
+		req, err := http.NewRequestWithContext(ctx, requestMethod, requestPath, requestBody)
 		Expect(err).NotTo(HaveOccurred())
+		req.Header.Add(headers.Authorization, authHeader)
+		if requestForm != nil {
+			req.Header.Add(headers.ContentType, "application/x-www-form-urlencoded")
+		}
 
 		router.ServeHTTP(rr, req)
 	})
@@ -54,5 +82,158 @@ var _ = Describe("OAuthToken", func() {
 			Expect(ok).To(BeTrue())
 			Expect(expiration.Unix()).To(BeNumerically(">", time.Now().Add(time.Minute*59).Unix()))
 		})
+
+		When("a passcode is provided", func() {
+			var passcode string
+
+			BeforeEach(func() {
+				var err error
+				passcode, err = jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+					"exp": time.Now().Add(time.Hour).Unix(),
+				}).SignedString([]byte("a-secret"))
+				Expect(err).NotTo(HaveOccurred())
+
+				requestForm = url.Values{
+					"grant_type": {"password"},
+					"passcode":   {passcode},
+				}
+
+				identityProvider.GetIdentityReturns(authorization.Identity{Name: "alice"}, nil)
+			})
+
+			It("verifies the passcode", func() {
+				Expect(identityProvider.GetIdentityCallCount()).To(Equal(1))
+				_, actualAuthInfo := identityProvider.GetIdentityArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authorization.Info{Token: passcode}))
+			})
+
+			It("returns the passcode as the access token", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				jsonBody := map[string]interface{}{}
+				Expect(json.NewDecoder(rr.Body).Decode(&jsonBody)).To(Succeed())
+				Expect(jsonBody).To(HaveKeyWithValue("token_type", "bearer"))
+				Expect(jsonBody).To(HaveKeyWithValue("access_token", passcode))
+				Expect(jsonBody).To(HaveKeyWithValue("expires_in", BeNumerically("~", time.Hour.Seconds(), 10)))
+			})
+
+			When("the passcode is not valid", func() {
+				BeforeEach(func() {
+					identityProvider.GetIdentityReturns(authorization.Identity{}, apierrors.NewInvalidAuthError(errors.New("invalid token")))
+				})
+
+				It("returns an unauthorized error", func() {
+					Expect(rr).To(HaveHTTPStatus(http.StatusUnauthorized))
+				})
+
+				It("does not try to refresh it", func() {
+					Expect(tokenRefresher.RefreshCallCount()).To(BeZero())
+				})
+
+				When("refreshing is enabled", func() {
+					BeforeEach(func() {
+						refreshEnabled = true
+						tokenRefresher.RefreshReturns(authorization.OAuthToken{
+							AccessToken:  "new-access-token",
+							RefreshToken: "new-refresh-token",
+						}, nil)
+					})
+
+					It("returns an unauthorized error", func() {
+						Expect(rr).To(HaveHTTPStatus(http.StatusUnauthorized))
+					})
+
+					It("does not redeem the passcode as a refresh token", func() {
+						Expect(tokenRefresher.RefreshCallCount()).To(BeZero())
+					})
+				})
+			})
+
+			When("refreshing is enabled", func() {
+				BeforeEach(func() {
+					refreshEnabled = true
+				})
+
+				It("returns the verified passcode without refreshing it", func() {
+					Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+					jsonBody := map[string]interface{}{}
+					Expect(json.NewDecoder(rr.Body).Decode(&jsonBody)).To(Succeed())
+					Expect(jsonBody).To(HaveKeyWithValue("access_token", passcode))
+					Expect(tokenRefresher.RefreshCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		When("a token is refreshed", func() {
+			BeforeEach(func() {
+				requestForm = url.Values{
+					"grant_type":    {"refresh_token"},
+					"refresh_token": {"the-refresh-token"},
+				}
+			})
+
+			It("returns a placeholder token", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				jsonBody := map[string]string{}
+				Expect(json.NewDecoder(rr.Body).Decode(&jsonBody)).To(Succeed())
+				Expect(jsonBody).To(HaveKeyWithValue("access_token", Not(BeEmpty())))
+				Expect(tokenRefresher.RefreshCallCount()).To(BeZero())
+			})
+
+			When("refreshing is enabled", func() {
+				var newAccessToken string
+
+				BeforeEach(func() {
+					refreshEnabled = true
+
+					var err error
+					newAccessToken, err = jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+						"exp": time.Now().Add(5 * time.Minute).Unix(),
+					}).SignedString([]byte("a-secret"))
+					Expect(err).NotTo(HaveOccurred())
+
+					tokenRefresher.RefreshReturns(authorization.OAuthToken{
+						AccessToken:  newAccessToken,
+						RefreshToken: "new-refresh-token",
+					}, nil)
+				})
+
+				It("redeems the refresh token", func() {
+					Expect(tokenRefresher.RefreshCallCount()).To(Equal(1))
+					_, actualRefreshToken := tokenRefresher.RefreshArgsForCall(0)
+					Expect(actualRefreshToken).To(Equal("the-refresh-token"))
+				})
+
+				It("returns the refreshed tokens expiring with the access token", func() {
+					Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+					jsonBody := map[string]interface{}{}
+					Expect(json.NewDecoder(rr.Body).Decode(&jsonBody)).To(Succeed())
+					Expect(jsonBody).To(HaveKeyWithValue("token_type", "bearer"))
+					Expect(jsonBody).To(HaveKeyWithValue("access_token", newAccessToken))
+					Expect(jsonBody).To(HaveKeyWithValue("refresh_token", "new-refresh-token"))
+					Expect(jsonBody).To(HaveKeyWithValue("expires_in", BeNumerically("~", (5*time.Minute).Seconds(), 10)))
+				})
+
+				When("the refresh token is rejected", func() {
+					BeforeEach(func() {
+						tokenRefresher.RefreshReturns(authorization.OAuthToken{}, apierrors.NewInvalidAuthError(errors.New("invalid_grant")))
+					})
+
+					It("returns an unauthorized error", func() {
+						Expect(rr).To(HaveHTTPStatus(http.StatusUnauthorized))
+					})
+				})
+
+				When("there is no refresh token", func() {
+					BeforeEach(func() {
+						requestForm.Set("refresh_token", "")
+					})
+
+					It("returns an unauthorized error without refreshing", func() {
+						Expect(rr).To(HaveHTTPStatus(http.StatusUnauthorized))
+						Expect(tokenRefresher.RefreshCallCount()).To(BeZero())
+					})
+				})
+			})
+		})
 	})
 })
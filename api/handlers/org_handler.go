@@ -143,7 +143,10 @@ func (h *OrgHandler) orgListDomainHandler(ctx context.Context, logger logr.Logge
 		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
 	}
 
-	domainList, err := h.domainRepo.ListDomains(ctx, authInfo, domainListFilter.ToMessage())
+	domainListMessage := domainListFilter.ToMessage()
+	domainListMessage.AvailableInOrgGUID = orgGUID
+
+	domainList, err := h.domainRepo.ListDomains(ctx, authInfo, domainListMessage)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch domain(s) from Kubernetes")
 	}
@@ -691,6 +691,13 @@ var _ = Describe("OrgHandler", func() {
 				contentTypeHeader := rr.Header().Get("Content-Type")
 				Expect(contentTypeHeader).To(Equal(jsonHeader), "Matching Content-Type header:")
 			})
+
+			It("only lists domains available in the org", func() {
+				Expect(domainRepo.ListDomainsCallCount()).To(Equal(1))
+				_, _, message := domainRepo.ListDomainsArgsForCall(0)
+				Expect(message.AvailableInOrgGUID).To(Equal(testOrganizationGUID))
+			})
+
 			It("returns the Pagination Data and Domain Resources in the response", func() {
 				Expect(rr.Body.String()).To(MatchJSON(fmt.Sprintf(`{
 				"pagination": {
@@ -812,7 +819,7 @@ var _ = Describe("OrgHandler", func() {
 			})
 
 			It("returns an Unknown key error", func() {
-				expectUnknownKeyError("The query parameter is invalid: Valid parameters are: 'names'")
+				expectUnknownKeyError("The query parameter is invalid: Valid parameters are: 'names, organization_guids'")
 			})
 		})
 	})
@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	"github.com/go-logr/logr"
+	"github.com/gorilla/mux"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	OrgQuotasPath              = "/v3/organization_quotas"
+	OrgQuotaPath               = "/v3/organization_quotas/{guid}"
+	OrgQuotaOrganizationsPath  = "/v3/organization_quotas/{guid}/relationships/organizations"
+	orgNotFoundOrForbiddenTmpl = "Organization with guid '%s' does not exist or you do not have access to it."
+)
+
+//counterfeiter:generate -o fake -fake-name OrgQuotaRepository . OrgQuotaRepository
+
+type OrgQuotaRepository interface {
+	CreateOrgQuota(context.Context, authorization.Info, repositories.CreateOrgQuotaMessage) (repositories.OrgQuotaRecord, error)
+	ListOrgQuotas(context.Context, authorization.Info, repositories.ListOrgQuotasMessage) ([]repositories.OrgQuotaRecord, error)
+	GetOrgQuota(context.Context, authorization.Info, string) (repositories.OrgQuotaRecord, error)
+	ApplyOrgQuota(context.Context, authorization.Info, repositories.ApplyOrgQuotaMessage) (repositories.OrgQuotaRecord, error)
+}
+
+type OrgQuotaHandler struct {
+	handlerWrapper   *AuthAwareHandlerFuncWrapper
+	apiBaseURL       url.URL
+	orgQuotaRepo     OrgQuotaRepository
+	orgRepo          CFOrgRepository
+	decoderValidator *DecoderValidator
+}
+
+func NewOrgQuotaHandler(apiBaseURL url.URL, orgQuotaRepo OrgQuotaRepository, orgRepo CFOrgRepository, decoderValidator *DecoderValidator) *OrgQuotaHandler {
+	return &OrgQuotaHandler{
+		handlerWrapper:   NewAuthAwareHandlerFuncWrapper(ctrl.Log.WithName("OrgQuotaHandler")),
+		apiBaseURL:       apiBaseURL,
+		orgQuotaRepo:     orgQuotaRepo,
+		orgRepo:          orgRepo,
+		decoderValidator: decoderValidator,
+	}
+}
+
+func (h *OrgQuotaHandler) orgQuotaCreateHandler(ctx context.Context, logger logr.Logger, authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	var payload payloads.OrgQuotaCreate
+	if err := h.decoderValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	message := payload.ToMessage()
+	if err := h.checkOrgsExist(ctx, logger, authInfo, message.OrganizationGUIDs); err != nil {
+		return nil, err
+	}
+
+	quota, err := h.orgQuotaRepo.CreateOrgQuota(ctx, authInfo, message)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to create org quota", "name", message.Name)
+	}
+
+	return NewHandlerResponse(http.StatusCreated).WithBody(presenter.ForOrgQuota(quota, h.apiBaseURL)), nil
+}
+
+func (h *OrgQuotaHandler) orgQuotaListHandler(ctx context.Context, logger logr.Logger, authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to parse request query parameters")
+	}
+
+	listFilter := new(payloads.OrgQuotaList)
+	if err := payloads.Decode(listFilter, r.Form); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	quotas, err := h.orgQuotaRepo.ListOrgQuotas(ctx, authInfo, listFilter.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to list org quotas")
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForOrgQuotaList(quotas, h.apiBaseURL, *r.URL)), nil
+}
+
+func (h *OrgQuotaHandler) orgQuotaGetHandler(ctx context.Context, logger logr.Logger, authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	quotaGUID := mux.Vars(r)["guid"]
+
+	quota, err := h.orgQuotaRepo.GetOrgQuota(ctx, authInfo, quotaGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to get org quota", "guid", quotaGUID)
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForOrgQuota(quota, h.apiBaseURL)), nil
+}
+
+func (h *OrgQuotaHandler) orgQuotaApplyHandler(ctx context.Context, logger logr.Logger, authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	quotaGUID := mux.Vars(r)["guid"]
+
+	var payload payloads.OrgQuotaApply
+	if err := h.decoderValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	if _, err := h.orgQuotaRepo.GetOrgQuota(ctx, authInfo, quotaGUID); err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to get org quota", "guid", quotaGUID)
+	}
+
+	message := payload.ToMessage(quotaGUID)
+	if err := h.checkOrgsExist(ctx, logger, authInfo, message.OrganizationGUIDs); err != nil {
+		return nil, err
+	}
+
+	quota, err := h.orgQuotaRepo.ApplyOrgQuota(ctx, authInfo, message)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to apply org quota", "guid", quotaGUID)
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForOrgQuotaOrganizations(quota, h.apiBaseURL)), nil
+}
+
+func (h *OrgQuotaHandler) checkOrgsExist(ctx context.Context, logger logr.Logger, authInfo authorization.Info, orgGUIDs []string) error {
+	for _, orgGUID := range orgGUIDs {
+		if _, err := h.orgRepo.GetOrg(ctx, authInfo, orgGUID); err != nil {
+			return apierrors.LogAndReturn(
+				logger,
+				apierrors.AsUnprocessableEntity(err, fmt.Sprintf(orgNotFoundOrForbiddenTmpl, orgGUID), apierrors.NotFoundError{}, apierrors.ForbiddenError{}),
+				"Failed to fetch org", "orgGUID", orgGUID,
+			)
+		}
+	}
+
+	return nil
+}
+
+func (h *OrgQuotaHandler) RegisterRoutes(router *mux.Router) {
+	router.Path(OrgQuotasPath).Methods("POST").HandlerFunc(h.handlerWrapper.Wrap(h.orgQuotaCreateHandler))
+	router.Path(OrgQuotasPath).Methods("GET").HandlerFunc(h.handlerWrapper.Wrap(h.orgQuotaListHandler))
+	router.Path(OrgQuotaPath).Methods("GET").HandlerFunc(h.handlerWrapper.Wrap(h.orgQuotaGetHandler))
+	router.Path(OrgQuotaOrganizationsPath).Methods("POST").HandlerFunc(h.handlerWrapper.Wrap(h.orgQuotaApplyHandler))
+}
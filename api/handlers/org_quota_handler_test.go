@@ -0,0 +1,276 @@
+package handlers_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	apis "code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OrgQuotaHandler", func() {
+	var (
+		orgQuotaRepo *fake.OrgQuotaRepository
+		orgRepo      *fake.OrgRepository
+		quotaRecord  repositories.OrgQuotaRecord
+	)
+
+	BeforeEach(func() {
+		orgQuotaRepo = new(fake.OrgQuotaRepository)
+		orgRepo = new(fake.OrgRepository)
+		decoderValidator, err := apis.NewDefaultDecoderValidator()
+		Expect(err).NotTo(HaveOccurred())
+
+		quotaRecord = repositories.OrgQuotaRecord{
+			GUID: "quota-guid",
+			Name: "my-quota",
+			Limits: repositories.QuotaLimits{
+				TotalMemoryInMB: tools.PtrTo(int64(1024)),
+				TotalRoutes:     tools.PtrTo(int64(10)),
+			},
+			OrganizationGUIDs: []string{"org-guid"},
+			CreatedAt:         "2022-11-01T10:00:00Z",
+			UpdatedAt:         "2022-11-01T11:00:00Z",
+		}
+		orgQuotaRepo.CreateOrgQuotaReturns(quotaRecord, nil)
+		orgQuotaRepo.GetOrgQuotaReturns(quotaRecord, nil)
+		orgQuotaRepo.ListOrgQuotasReturns([]repositories.OrgQuotaRecord{quotaRecord}, nil)
+		orgQuotaRepo.ApplyOrgQuotaReturns(quotaRecord, nil)
+
+		apis.NewOrgQuotaHandler(*serverURL, orgQuotaRepo, orgRepo, decoderValidator).RegisterRoutes(router)
+	})
+
+	serveRequest := func(method, path, body string) {
+		req, err := http.NewRequestWithContext(ctx, method, path, strings.NewReader(body))
+		Expect(err).NotTo(HaveOccurred())
+
+		router.ServeHTTP(rr, req)
+	}
+
+	quotaJSON := func() string {
+		return fmt.Sprintf(`{
+            "guid": "quota-guid",
+            "created_at": "2022-11-01T10:00:00Z",
+            "updated_at": "2022-11-01T11:00:00Z",
+            "name": "my-quota",
+            "apps": {
+                "total_memory_in_mb": 1024,
+                "per_process_memory_in_mb": null,
+                "total_instances": null,
+                "per_app_tasks": null,
+                "log_rate_limit_in_bytes_per_second": null
+            },
+            "services": {
+                "paid_services_allowed": true,
+                "total_service_instances": null,
+                "total_service_keys": null
+            },
+            "routes": {
+                "total_routes": 10,
+                "total_reserved_ports": null
+            },
+            "domains": {
+                "total_domains": null
+            },
+            "relationships": {
+                "organizations": {"data": [{"guid": "org-guid"}]}
+            },
+            "links": {
+                "self": {"href": "%s/v3/organization_quotas/quota-guid"}
+            }
+        }`, defaultServerURL)
+	}
+
+	Describe("POST /v3/organization_quotas", func() {
+		It("creates the quota", func() {
+			serveRequest("POST", "/v3/organization_quotas", `{
+                "name": "my-quota",
+                "apps": {"total_memory_in_mb": 1024, "per_app_tasks": 2},
+                "services": {"paid_services_allowed": true, "total_service_instances": 5},
+                "routes": {"total_routes": 10},
+                "relationships": {"organizations": {"data": [{"guid": "org-guid"}]}}
+            }`)
+
+			expectJSONResponse(http.StatusCreated, quotaJSON())
+
+			Expect(orgRepo.GetOrgCallCount()).To(Equal(1))
+			_, _, orgGUID := orgRepo.GetOrgArgsForCall(0)
+			Expect(orgGUID).To(Equal("org-guid"))
+
+			Expect(orgQuotaRepo.CreateOrgQuotaCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := orgQuotaRepo.CreateOrgQuotaArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.CreateOrgQuotaMessage{
+				Name: "my-quota",
+				Limits: repositories.QuotaLimits{
+					TotalMemoryInMB:       tools.PtrTo(int64(1024)),
+					PerAppTasks:           tools.PtrTo(int64(2)),
+					TotalServiceInstances: tools.PtrTo(int64(5)),
+					TotalRoutes:           tools.PtrTo(int64(10)),
+				},
+				OrganizationGUIDs: []string{"org-guid"},
+			}))
+		})
+
+		When("a limit is negative", func() {
+			It("returns an unprocessable entity error", func() {
+				serveRequest("POST", "/v3/organization_quotas", `{"name": "my-quota", "apps": {"total_instances": -1}}`)
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusUnprocessableEntity))
+				Expect(orgQuotaRepo.CreateOrgQuotaCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the name is missing", func() {
+			It("returns an unprocessable entity error", func() {
+				serveRequest("POST", "/v3/organization_quotas", `{"apps": {"total_instances": 1}}`)
+
+				expectUnprocessableEntityError("Name is a required field")
+			})
+		})
+
+		When("an org does not exist", func() {
+			BeforeEach(func() {
+				orgRepo.GetOrgReturns(repositories.OrgRecord{}, apierrors.NewNotFoundError(nil, repositories.OrgResourceType))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				serveRequest("POST", "/v3/organization_quotas", `{
+                    "name": "my-quota",
+                    "relationships": {"organizations": {"data": [{"guid": "org-guid"}]}}
+                }`)
+
+				expectUnprocessableEntityError("Organization with guid 'org-guid' does not exist or you do not have access to it.")
+				Expect(orgQuotaRepo.CreateOrgQuotaCallCount()).To(Equal(0))
+			})
+		})
+
+		When("creating the quota fails", func() {
+			BeforeEach(func() {
+				orgQuotaRepo.CreateOrgQuotaReturns(repositories.OrgQuotaRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				serveRequest("POST", "/v3/organization_quotas", `{"name": "my-quota"}`)
+
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/organization_quotas", func() {
+		It("lists the quotas", func() {
+			serveRequest("GET", "/v3/organization_quotas?names=my-quota&organization_guids=o1,o2", "")
+
+			expectJSONResponse(http.StatusOK, fmt.Sprintf(`{
+                "pagination": {
+                    "total_results": 1,
+                    "total_pages": 1,
+                    "first": {"href": "%[1]s/v3/organization_quotas?names=my-quota&organization_guids=o1,o2"},
+                    "last": {"href": "%[1]s/v3/organization_quotas?names=my-quota&organization_guids=o1,o2"},
+                    "next": null,
+                    "previous": null
+                },
+                "resources": [%[2]s]
+            }`, defaultServerURL, quotaJSON()))
+
+			_, _, message := orgQuotaRepo.ListOrgQuotasArgsForCall(0)
+			Expect(message).To(Equal(repositories.ListOrgQuotasMessage{
+				GUIDs:             []string{},
+				Names:             []string{"my-quota"},
+				OrganizationGUIDs: []string{"o1", "o2"},
+			}))
+		})
+
+		When("an unknown query parameter is passed", func() {
+			It("returns an error", func() {
+				serveRequest("GET", "/v3/organization_quotas?foo=bar", "")
+
+				expectUnknownKeyError("The query parameter is invalid: Valid parameters are: 'guids, names, organization_guids'")
+			})
+		})
+	})
+
+	Describe("GET /v3/organization_quotas/{guid}", func() {
+		It("returns the quota", func() {
+			serveRequest("GET", "/v3/organization_quotas/quota-guid", "")
+
+			expectJSONResponse(http.StatusOK, quotaJSON())
+			_, _, guid := orgQuotaRepo.GetOrgQuotaArgsForCall(0)
+			Expect(guid).To(Equal("quota-guid"))
+		})
+
+		When("the quota is not found", func() {
+			BeforeEach(func() {
+				orgQuotaRepo.GetOrgQuotaReturns(repositories.OrgQuotaRecord{}, apierrors.NewNotFoundError(nil, repositories.OrgQuotaResourceType))
+			})
+
+			It("returns a not found error", func() {
+				serveRequest("GET", "/v3/organization_quotas/quota-guid", "")
+
+				expectNotFoundError("Organization Quota not found")
+			})
+		})
+	})
+
+	Describe("POST /v3/organization_quotas/{guid}/relationships/organizations", func() {
+		It("applies the quota to the orgs", func() {
+			serveRequest("POST", "/v3/organization_quotas/quota-guid/relationships/organizations", `{"data": [{"guid": "org-guid"}]}`)
+
+			expectJSONResponse(http.StatusOK, fmt.Sprintf(`{
+                "data": [{"guid": "org-guid"}],
+                "links": {
+                    "self": {"href": "%s/v3/organization_quotas/quota-guid/relationships/organizations"}
+                }
+            }`, defaultServerURL))
+
+			_, _, message := orgQuotaRepo.ApplyOrgQuotaArgsForCall(0)
+			Expect(message).To(Equal(repositories.ApplyOrgQuotaMessage{
+				GUID:              "quota-guid",
+				OrganizationGUIDs: []string{"org-guid"},
+			}))
+		})
+
+		When("no orgs are given", func() {
+			It("returns an unprocessable entity error", func() {
+				serveRequest("POST", "/v3/organization_quotas/quota-guid/relationships/organizations", `{"data": []}`)
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusUnprocessableEntity))
+				Expect(orgQuotaRepo.ApplyOrgQuotaCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the quota is not found", func() {
+			BeforeEach(func() {
+				orgQuotaRepo.GetOrgQuotaReturns(repositories.OrgQuotaRecord{}, apierrors.NewForbiddenError(nil, repositories.OrgQuotaResourceType))
+			})
+
+			It("returns a not found error", func() {
+				serveRequest("POST", "/v3/organization_quotas/quota-guid/relationships/organizations", `{"data": [{"guid": "org-guid"}]}`)
+
+				expectNotFoundError("Organization Quota not found")
+				Expect(orgQuotaRepo.ApplyOrgQuotaCallCount()).To(Equal(0))
+			})
+		})
+
+		When("an org is not accessible", func() {
+			BeforeEach(func() {
+				orgRepo.GetOrgReturns(repositories.OrgRecord{}, apierrors.NewForbiddenError(nil, repositories.OrgResourceType))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				serveRequest("POST", "/v3/organization_quotas/quota-guid/relationships/organizations", `{"data": [{"guid": "org-guid"}]}`)
+
+				expectUnprocessableEntityError("Organization with guid 'org-guid' does not exist or you do not have access to it.")
+			})
+		})
+	})
+})
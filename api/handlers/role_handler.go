@@ -2,8 +2,10 @@ package handlers
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 
 	"code.cloudfoundry.org/korifi/api/apierrors"
 	"code.cloudfoundry.org/korifi/api/authorization"
@@ -13,11 +15,13 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
+	rbacv1 "k8s.io/api/rbac/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
 const (
 	RolesPath = "/v3/roles"
+	RolePath  = "/v3/roles/{guid}"
 )
 
 type RoleName string
@@ -36,24 +40,33 @@ const (
 	RoleSpaceSupporter             RoleName = "space_supporter"
 )
 
+var roleListIncludes = []string{"user", "space", "organization"}
+
 //counterfeiter:generate -o fake -fake-name CFRoleRepository . CFRoleRepository
 
 type CFRoleRepository interface {
 	CreateRole(context.Context, authorization.Info, repositories.CreateRoleMessage) (repositories.RoleRecord, error)
+	ListRoles(context.Context, authorization.Info, repositories.ListRolesMessage) ([]repositories.RoleRecord, error)
+	GetRole(context.Context, authorization.Info, string) (repositories.RoleRecord, error)
+	DeleteRole(context.Context, authorization.Info, repositories.DeleteRoleMessage) error
 }
 
 type RoleHandler struct {
 	handlerWrapper   *AuthAwareHandlerFuncWrapper
 	apiBaseURL       url.URL
 	roleRepo         CFRoleRepository
+	orgRepo          CFOrgRepository
+	spaceRepo        SpaceRepository
 	decoderValidator *DecoderValidator
 }
 
-func NewRoleHandler(apiBaseURL url.URL, roleRepo CFRoleRepository, decoderValidator *DecoderValidator) *RoleHandler {
+func NewRoleHandler(apiBaseURL url.URL, roleRepo CFRoleRepository, orgRepo CFOrgRepository, spaceRepo SpaceRepository, decoderValidator *DecoderValidator) *RoleHandler {
 	return &RoleHandler{
 		handlerWrapper:   NewAuthAwareHandlerFuncWrapper(ctrl.Log.WithName("RoleHandler")),
 		apiBaseURL:       apiBaseURL,
 		roleRepo:         roleRepo,
+		orgRepo:          orgRepo,
+		spaceRepo:        spaceRepo,
 		decoderValidator: decoderValidator,
 	}
 }
@@ -75,6 +88,145 @@ func (h *RoleHandler) roleCreateHandler(ctx context.Context, logger logr.Logger,
 	return NewHandlerResponse(http.StatusCreated).WithBody(presenter.ForCreateRole(record, h.apiBaseURL)), nil
 }
 
+func (h *RoleHandler) roleListHandler(ctx context.Context, logger logr.Logger, authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.NewUnprocessableEntityError(err, "unable to parse query"), "Unable to parse request query parameters")
+	}
+
+	listFilter := new(payloads.RoleList)
+	if err := payloads.Decode(listFilter, r.Form); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	includes := payloads.ParseArrayParam(listFilter.Include)
+	for _, include := range includes {
+		if !contains(roleListIncludes, include) {
+			return nil, apierrors.LogAndReturn(
+				logger,
+				apierrors.NewUnprocessableEntityError(
+					fmt.Errorf("invalid include %q", include),
+					fmt.Sprintf("Invalid included resource: '%s'. Valid included resources are: '%s'", include, strings.Join(roleListIncludes, "', '")),
+				),
+				"Invalid include parameter",
+			)
+		}
+	}
+
+	roles, err := h.roleRepo.ListRoles(ctx, authInfo, listFilter.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to list roles")
+	}
+
+	var (
+		users  []repositories.UserRecord
+		spaces []repositories.SpaceRecord
+		orgs   []repositories.OrgRecord
+	)
+
+	if contains(includes, "user") {
+		users = usersForRoles(roles)
+	}
+
+	spaceGUIDs := uniqueValues(roles, func(role repositories.RoleRecord) string { return role.Space })
+	if contains(includes, "space") && len(spaceGUIDs) > 0 {
+		spaces, err = h.spaceRepo.ListSpaces(ctx, authInfo, repositories.ListSpacesMessage{GUIDs: spaceGUIDs})
+		if err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "Failed to list spaces for included resources")
+		}
+	}
+
+	orgGUIDs := uniqueValues(roles, func(role repositories.RoleRecord) string { return role.Org })
+	if contains(includes, "organization") && len(orgGUIDs) > 0 {
+		orgs, err = h.orgRepo.ListOrgs(ctx, authInfo, repositories.ListOrgsMessage{GUIDs: orgGUIDs})
+		if err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "Failed to list orgs for included resources")
+		}
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForRoleList(roles, users, spaces, orgs, h.apiBaseURL, *r.URL)), nil
+}
+
+func (h *RoleHandler) roleGetHandler(ctx context.Context, logger logr.Logger, authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	roleGUID := mux.Vars(r)["guid"]
+
+	role, err := h.roleRepo.GetRole(ctx, authInfo, roleGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to get role", "RoleGUID", roleGUID)
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForRole(role, h.apiBaseURL)), nil
+}
+
+func (h *RoleHandler) roleDeleteHandler(ctx context.Context, logger logr.Logger, authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	roleGUID := mux.Vars(r)["guid"]
+
+	role, err := h.roleRepo.GetRole(ctx, authInfo, roleGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to get role", "RoleGUID", roleGUID)
+	}
+
+	err = h.roleRepo.DeleteRole(ctx, authInfo, repositories.DeleteRoleMessage{
+		GUID:  role.GUID,
+		Type:  role.Type,
+		Space: role.Space,
+		Org:   role.Org,
+		User:  role.User,
+		Kind:  role.Kind,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to delete role", "RoleGUID", roleGUID)
+	}
+
+	return NewHandlerResponse(http.StatusAccepted).WithHeader("Location", presenter.JobURLForRedirects(roleGUID, presenter.RoleDeleteOperation, h.apiBaseURL)), nil
+}
+
 func (h *RoleHandler) RegisterRoutes(router *mux.Router) {
 	router.Path(RolesPath).Methods("POST").HandlerFunc(h.handlerWrapper.Wrap(h.roleCreateHandler))
+	router.Path(RolesPath).Methods("GET").HandlerFunc(h.handlerWrapper.Wrap(h.roleListHandler))
+	router.Path(RolePath).Methods("GET").HandlerFunc(h.handlerWrapper.Wrap(h.roleGetHandler))
+	router.Path(RolePath).Methods("DELETE").HandlerFunc(h.handlerWrapper.Wrap(h.roleDeleteHandler))
+}
+
+func usersForRoles(roles []repositories.RoleRecord) []repositories.UserRecord {
+	seen := map[string]bool{}
+	var users []repositories.UserRecord
+	for _, role := range roles {
+		if role.Kind == rbacv1.GroupKind {
+			continue
+		}
+
+		user := repositories.UserRecordForRole(role)
+		if seen[user.GUID] {
+			continue
+		}
+		seen[user.GUID] = true
+		users = append(users, user)
+	}
+
+	return users
+}
+
+func uniqueValues(roles []repositories.RoleRecord, field func(repositories.RoleRecord) string) []string {
+	seen := map[string]bool{}
+	var values []string
+	for _, role := range roles {
+		value := field(role)
+		if value == "" || seen[value] {
+			continue
+		}
+		seen[value] = true
+		values = append(values, value)
+	}
+
+	return values
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
 }
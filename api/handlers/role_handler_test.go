@@ -2,12 +2,14 @@ package handlers_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"strings"
 	"time"
 
+	"code.cloudfoundry.org/korifi/api/apierrors"
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apis "code.cloudfoundry.org/korifi/api/handlers"
 	"code.cloudfoundry.org/korifi/api/handlers/fake"
@@ -25,6 +27,8 @@ var _ = Describe("RoleHandler", func() {
 	var (
 		roleHandler *apis.RoleHandler
 		roleRepo    *fake.CFRoleRepository
+		orgRepo     *fake.OrgRepository
+		spaceRepo   *fake.SpaceRepository
 		now         time.Time
 	)
 
@@ -32,10 +36,12 @@ var _ = Describe("RoleHandler", func() {
 		now = time.Unix(1631892190, 0) // 2021-09-17T15:23:10Z
 
 		roleRepo = new(fake.CFRoleRepository)
+		orgRepo = new(fake.OrgRepository)
+		spaceRepo = new(fake.SpaceRepository)
 		decoderValidator, err := apis.NewDefaultDecoderValidator()
 		Expect(err).NotTo(HaveOccurred())
 
-		roleHandler = apis.NewRoleHandler(*serverURL, roleRepo, decoderValidator)
+		roleHandler = apis.NewRoleHandler(*serverURL, roleRepo, orgRepo, spaceRepo, decoderValidator)
 		roleHandler.RegisterRoutes(router)
 	})
 
@@ -87,6 +93,10 @@ var _ = Describe("RoleHandler", func() {
 		Entry("space supporter w org", string(apis.RoleSpaceSupporter), "organization", false, "relationships.space is a required field"),
 		Entry("space supporter w space", string(apis.RoleSpaceSupporter), "space", true, ""),
 
+		Entry("admin w org", string(apis.RoleAdmin), "organization", false, "Cannot pass 'organization' or 'space' for the admin role"),
+		Entry("admin read only w space", string(apis.RoleAdminReadOnly), "space", false, "Cannot pass 'organization' or 'space' for the admin_read_only role"),
+		Entry("global auditor w org", string(apis.RoleGlobalAuditor), "organization", false, "Cannot pass 'organization' or 'space' for the global_auditor role"),
+
 		Entry("invalid role name", "does-not-exist", "organization", false, "does-not-exist is not a valid role"),
 	)
 
@@ -327,6 +337,98 @@ var _ = Describe("RoleHandler", func() {
 			})
 		})
 
+		When("the kind is a group", func() {
+			BeforeEach(func() {
+				roleRepo.CreateRoleReturns(repositories.RoleRecord{
+					GUID:      "t-h-e-r-o-l-e",
+					CreatedAt: now,
+					UpdatedAt: now,
+					Type:      "organization_manager",
+					Org:       "my-org",
+					User:      "my-group",
+					Kind:      rbacv1.GroupKind,
+				}, nil)
+
+				createRoleRequestBody = `{
+                    "type": "organization_manager",
+                    "relationships": {
+                        "group": {
+                            "data": {
+                                "guid": "my-group"
+                            }
+                        },
+                        "organization": {
+                            "data": {
+                                "guid": "my-org"
+                            }
+                        }
+                    }
+                }`
+			})
+
+			It("creates a group role binding", func() {
+				Expect(roleRepo.CreateRoleCallCount()).To(Equal(1))
+				_, _, roleRecord := roleRepo.CreateRoleArgsForCall(0)
+				Expect(roleRecord.Type).To(Equal("organization_manager"))
+				Expect(roleRecord.Org).To(Equal("my-org"))
+				Expect(roleRecord.User).To(Equal("my-group"))
+				Expect(roleRecord.Kind).To(Equal(rbacv1.GroupKind))
+			})
+
+			It("presents the group relationship", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+				var response struct {
+					Relationships map[string]struct {
+						Data *struct {
+							GUID string `json:"guid"`
+						} `json:"data"`
+					} `json:"relationships"`
+				}
+				Expect(json.Unmarshal(rr.Body.Bytes(), &response)).To(Succeed())
+				Expect(response.Relationships["group"].Data.GUID).To(Equal("my-group"))
+				Expect(response.Relationships["user"].Data).To(BeNil())
+			})
+		})
+
+		When("the role is a global role", func() {
+			BeforeEach(func() {
+				createRoleRequestBody = `{
+                    "type": "global_auditor",
+                    "relationships": {
+                        "user": {
+                            "data": {
+                                "username": "my-user"
+                            }
+                        }
+                    }
+                }`
+			})
+
+			It("creates a role without an org or space", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+
+				Expect(roleRepo.CreateRoleCallCount()).To(Equal(1))
+				_, _, roleRecord := roleRepo.CreateRoleArgsForCall(0)
+				Expect(roleRecord.Type).To(Equal("global_auditor"))
+				Expect(roleRecord.Org).To(BeEmpty())
+				Expect(roleRecord.Space).To(BeEmpty())
+				Expect(roleRecord.User).To(Equal("my-user"))
+			})
+
+			It("presents empty org and space relationships", func() {
+				var response struct {
+					Relationships map[string]struct {
+						Data *struct {
+							GUID string `json:"guid"`
+						} `json:"data"`
+					} `json:"relationships"`
+				}
+				Expect(json.Unmarshal(rr.Body.Bytes(), &response)).To(Succeed())
+				Expect(response.Relationships["organization"].Data).To(BeNil())
+				Expect(response.Relationships["space"].Data).To(BeNil())
+			})
+		})
+
 		When("the role does not contain a user or service account", func() {
 			BeforeEach(func() {
 				createRoleRequestBody = `{
@@ -345,8 +447,9 @@ var _ = Describe("RoleHandler", func() {
 				Expect(rr).To(HaveHTTPStatus(http.StatusUnprocessableEntity))
 				Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 				Expect(rr).To(HaveHTTPBody(SatisfyAll(
-					ContainSubstring("Field validation for 'User' failed on the 'required_without' tag"),
-					ContainSubstring("Field validation for 'KubernetesServiceAccount' failed on the 'required_without' tag"),
+					ContainSubstring("Field validation for 'User' failed on the 'required_without_all' tag"),
+					ContainSubstring("Field validation for 'KubernetesServiceAccount' failed on the 'required_without_all' tag"),
+					ContainSubstring("Field validation for 'Group' failed on the 'required_without_all' tag"),
 				)))
 			})
 		})
@@ -509,4 +612,331 @@ var _ = Describe("RoleHandler", func() {
 			})
 		})
 	})
+
+	Describe("List Roles", func() {
+		var query string
+
+		BeforeEach(func() {
+			query = ""
+			roleRepo.ListRolesReturns([]repositories.RoleRecord{
+				{
+					GUID:      "role-1",
+					CreatedAt: now,
+					UpdatedAt: now,
+					Type:      "space_developer",
+					Space:     "my-space",
+					User:      "my-user",
+					Kind:      rbacv1.UserKind,
+				},
+				{
+					GUID:      "role-2",
+					CreatedAt: now,
+					UpdatedAt: now,
+					Type:      "organization_manager",
+					Org:       "my-org",
+					User:      "my-user",
+					Kind:      rbacv1.UserKind,
+				},
+			}, nil)
+			spaceRepo.ListSpacesReturns([]repositories.SpaceRecord{
+				{Name: "space-name", GUID: "my-space", OrganizationGUID: "my-org"},
+			}, nil)
+			orgRepo.ListOrgsReturns([]repositories.OrgRecord{
+				{Name: "org-name", GUID: "my-org"},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequestWithContext(ctx, "GET", rolesBase+query, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			router.ServeHTTP(rr, req)
+		})
+
+		It("lists the roles", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(MatchJSON(fmt.Sprintf(`{
+                "pagination": {
+                    "total_results": 2,
+                    "total_pages": 1,
+                    "first": {"href": "%[1]s/v3/roles"},
+                    "last": {"href": "%[1]s/v3/roles"},
+                    "next": null,
+                    "previous": null
+                },
+                "resources": [
+                    {
+                        "guid": "role-1",
+                        "created_at": "2021-09-17T15:23:10Z",
+                        "updated_at": "2021-09-17T15:23:10Z",
+                        "type": "space_developer",
+                        "relationships": {
+                            "user": {"data": {"guid": "my-user"}},
+                            "space": {"data": {"guid": "my-space"}},
+                            "organization": {"data": null}
+                        },
+                        "links": {
+                            "self": {"href": "%[1]s/v3/roles/role-1"},
+                            "space": {"href": "%[1]s/v3/spaces/my-space"}
+                        }
+                    },
+                    {
+                        "guid": "role-2",
+                        "created_at": "2021-09-17T15:23:10Z",
+                        "updated_at": "2021-09-17T15:23:10Z",
+                        "type": "organization_manager",
+                        "relationships": {
+                            "user": {"data": {"guid": "my-user"}},
+                            "space": {"data": null},
+                            "organization": {"data": {"guid": "my-org"}}
+                        },
+                        "links": {
+                            "self": {"href": "%[1]s/v3/roles/role-2"},
+                            "organization": {"href": "%[1]s/v3/organizations/my-org"}
+                        }
+                    }
+                ]
+            }`, defaultServerURL))))
+
+			Expect(roleRepo.ListRolesCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := roleRepo.ListRolesArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.ListRolesMessage{
+				Types:      []string{},
+				SpaceGUIDs: []string{},
+				OrgGUIDs:   []string{},
+				UserGUIDs:  []string{},
+			}))
+
+			Expect(spaceRepo.ListSpacesCallCount()).To(Equal(0))
+			Expect(orgRepo.ListOrgsCallCount()).To(Equal(0))
+		})
+
+		When("filters are specified", func() {
+			BeforeEach(func() {
+				query = "?types=space_developer,space_manager&space_guids=s1&organization_guids=o1,o2&user_guids=u1"
+			})
+
+			It("passes them to the repository", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(roleRepo.ListRolesCallCount()).To(Equal(1))
+				_, _, message := roleRepo.ListRolesArgsForCall(0)
+				Expect(message).To(Equal(repositories.ListRolesMessage{
+					Types:      []string{"space_developer", "space_manager"},
+					SpaceGUIDs: []string{"s1"},
+					OrgGUIDs:   []string{"o1", "o2"},
+					UserGUIDs:  []string{"u1"},
+				}))
+			})
+		})
+
+		When("users, spaces and organizations are included", func() {
+			BeforeEach(func() {
+				query = "?include=user,space,organization"
+			})
+
+			It("includes the related resources", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				var response struct {
+					Included struct {
+						Users         []map[string]interface{} `json:"users"`
+						Spaces        []map[string]interface{} `json:"spaces"`
+						Organizations []map[string]interface{} `json:"organizations"`
+					} `json:"included"`
+				}
+				Expect(json.Unmarshal(rr.Body.Bytes(), &response)).To(Succeed())
+
+				Expect(response.Included.Users).To(HaveLen(1))
+				Expect(response.Included.Users[0]).To(HaveKeyWithValue("guid", "my-user"))
+				Expect(response.Included.Users[0]).To(HaveKeyWithValue("username", "my-user"))
+				Expect(response.Included.Spaces).To(HaveLen(1))
+				Expect(response.Included.Spaces[0]).To(HaveKeyWithValue("guid", "my-space"))
+				Expect(response.Included.Organizations).To(HaveLen(1))
+				Expect(response.Included.Organizations[0]).To(HaveKeyWithValue("guid", "my-org"))
+
+				Expect(spaceRepo.ListSpacesCallCount()).To(Equal(1))
+				_, _, spaceMessage := spaceRepo.ListSpacesArgsForCall(0)
+				Expect(spaceMessage.GUIDs).To(ConsistOf("my-space"))
+
+				Expect(orgRepo.ListOrgsCallCount()).To(Equal(1))
+				_, _, orgMessage := orgRepo.ListOrgsArgsForCall(0)
+				Expect(orgMessage.GUIDs).To(ConsistOf("my-org"))
+			})
+		})
+
+		When("a role is bound to a group and users are included", func() {
+			BeforeEach(func() {
+				query = "?include=user"
+				roleRepo.ListRolesReturns([]repositories.RoleRecord{
+					{GUID: "role-1", Type: "space_developer", Space: "my-space", User: "my-user", Kind: rbacv1.UserKind},
+					{GUID: "role-2", Type: "space_developer", Space: "my-space", User: "my-group", Kind: rbacv1.GroupKind},
+				}, nil)
+			})
+
+			It("does not include the group as a user", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				var response struct {
+					Included struct {
+						Users []map[string]interface{} `json:"users"`
+					} `json:"included"`
+				}
+				Expect(json.Unmarshal(rr.Body.Bytes(), &response)).To(Succeed())
+				Expect(response.Included.Users).To(HaveLen(1))
+				Expect(response.Included.Users[0]).To(HaveKeyWithValue("guid", "my-user"))
+			})
+		})
+
+		When("an unsupported resource is included", func() {
+			BeforeEach(func() {
+				query = "?include=app"
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Invalid included resource: 'app'. Valid included resources are: 'user', 'space', 'organization'")
+			})
+		})
+
+		When("an unknown query parameter is passed", func() {
+			BeforeEach(func() {
+				query = "?foo=bar"
+			})
+
+			It("returns an error", func() {
+				expectUnknownKeyError("The query parameter is invalid: Valid parameters are: 'types, space_guids, organization_guids, user_guids, include'")
+			})
+		})
+
+		When("listing the roles fails", func() {
+			BeforeEach(func() {
+				roleRepo.ListRolesReturns(nil, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("listing the included spaces fails", func() {
+			BeforeEach(func() {
+				query = "?include=space"
+				spaceRepo.ListSpacesReturns(nil, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("Get Role", func() {
+		BeforeEach(func() {
+			roleRepo.GetRoleReturns(repositories.RoleRecord{
+				GUID:      "role-guid",
+				CreatedAt: now,
+				UpdatedAt: now,
+				Type:      "organization_manager",
+				Org:       "my-org",
+				User:      "my-user",
+				Kind:      rbacv1.UserKind,
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequestWithContext(ctx, "GET", rolesBase+"/role-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			router.ServeHTTP(rr, req)
+		})
+
+		It("returns the role", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSON(fmt.Sprintf(`{
+                "guid": "role-guid",
+                "created_at": "2021-09-17T15:23:10Z",
+                "updated_at": "2021-09-17T15:23:10Z",
+                "type": "organization_manager",
+                "relationships": {
+                    "user": {"data": {"guid": "my-user"}},
+                    "space": {"data": null},
+                    "organization": {"data": {"guid": "my-org"}}
+                },
+                "links": {
+                    "self": {"href": "%[1]s/v3/roles/role-guid"},
+                    "organization": {"href": "%[1]s/v3/organizations/my-org"}
+                }
+            }`, defaultServerURL))))
+
+			Expect(roleRepo.GetRoleCallCount()).To(Equal(1))
+			_, actualAuthInfo, guid := roleRepo.GetRoleArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(guid).To(Equal("role-guid"))
+		})
+
+		When("the role is not accessible", func() {
+			BeforeEach(func() {
+				roleRepo.GetRoleReturns(repositories.RoleRecord{}, apierrors.NewForbiddenError(nil, repositories.RoleResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Role not found")
+			})
+		})
+	})
+
+	Describe("Delete Role", func() {
+		BeforeEach(func() {
+			roleRepo.GetRoleReturns(repositories.RoleRecord{
+				GUID:  "role-guid",
+				Type:  "space_developer",
+				Space: "my-space",
+				User:  "my-user",
+				Kind:  rbacv1.UserKind,
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequestWithContext(ctx, "DELETE", rolesBase+"/role-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			router.ServeHTTP(rr, req)
+		})
+
+		It("deletes the role and returns a job", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Location", defaultServerURL+"/v3/jobs/role.delete~role-guid"))
+
+			Expect(roleRepo.DeleteRoleCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := roleRepo.DeleteRoleArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.DeleteRoleMessage{
+				GUID:  "role-guid",
+				Type:  "space_developer",
+				Space: "my-space",
+				User:  "my-user",
+				Kind:  rbacv1.UserKind,
+			}))
+		})
+
+		When("the role does not exist", func() {
+			BeforeEach(func() {
+				roleRepo.GetRoleReturns(repositories.RoleRecord{}, apierrors.NewNotFoundError(nil, repositories.RoleResourceType))
+			})
+
+			It("returns a not found error and does not delete anything", func() {
+				expectNotFoundError("Role not found")
+				Expect(roleRepo.DeleteRoleCallCount()).To(Equal(0))
+			})
+		})
+
+		When("deleting the role fails", func() {
+			BeforeEach(func() {
+				roleRepo.DeleteRoleReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
 })
@@ -12,25 +12,38 @@ import (
 )
 
 const (
-	RootPath = "/"
+	RootPath  = "/"
+	LoginPath = "/login"
 )
 
 type RootHandler struct {
 	serverURL                     string
+	ssoEnabled                    bool
+	passcodeURL                   string
 	unauthenticatedHandlerWrapper *AuthAwareHandlerFuncWrapper
 }
 
-func NewRootHandler(serverURL string) *RootHandler {
+// NewRootHandler creates the handler for the API root and login info. When
+// ssoEnabled is set, the API advertises itself as the UAA and asks `cf login
+// --sso` users for a passcode, which they can obtain from passcodeURL.
+func NewRootHandler(serverURL string, ssoEnabled bool, passcodeURL string) *RootHandler {
 	return &RootHandler{
 		serverURL:                     serverURL,
+		ssoEnabled:                    ssoEnabled,
+		passcodeURL:                   passcodeURL,
 		unauthenticatedHandlerWrapper: NewUnauthenticatedHandlerFuncWrapper(ctrl.Log.WithName("RootHandler")),
 	}
 }
 
 func (h *RootHandler) rootGetHandler(ctx context.Context, logger logr.Logger, _ authorization.Info, r *http.Request) (*HandlerResponse, error) {
-	return NewHandlerResponse(http.StatusOK).WithBody(presenter.GetRootResponse(h.serverURL)), nil
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.GetRootResponse(h.serverURL, h.ssoEnabled)), nil
+}
+
+func (h *RootHandler) loginInfoGetHandler(ctx context.Context, logger logr.Logger, _ authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForLoginInfo(h.serverURL, h.ssoEnabled, h.passcodeURL)), nil
 }
 
 func (h *RootHandler) RegisterRoutes(router *mux.Router) {
 	router.Path(RootPath).Methods("GET").HandlerFunc(h.unauthenticatedHandlerWrapper.Wrap(h.rootGetHandler))
+	router.Path(LoginPath).Methods("GET").HandlerFunc(h.unauthenticatedHandlerWrapper.Wrap(h.loginInfoGetHandler))
 }
@@ -12,16 +12,25 @@ import (
 )
 
 var _ = Describe("RootHandler", func() {
-	var req *http.Request
+	var (
+		req         *http.Request
+		ssoEnabled  bool
+		passcodeURL string
+	)
 
 	BeforeEach(func() {
+		ssoEnabled = false
+		passcodeURL = ""
+	})
+
+	JustBeforeEach(func() {
 		apiHandler := apis.NewRootHandler(
 			defaultServerURL,
+			ssoEnabled,
+			passcodeURL,
 		)
 		apiHandler.RegisterRoutes(router)
-	})
 
-	JustBeforeEach(func() {
 		router.ServeHTTP(rr, req)
 	})
 
@@ -61,13 +70,17 @@ var _ = Describe("RootHandler", func() {
 						Meta: presenter.APILinkMeta{Version: presenter.V3APIVersion},
 					},
 					"network_policy_v0": nil,
-					"network_policy_v1": nil,
+					"network_policy_v1": {
+						Link: presenter.Link{HRef: defaultServerURL + "/networking/v1/external"},
+					},
 					"login": {
 						Link: presenter.Link{HRef: defaultServerURL},
 					},
 					"uaa":     nil,
 					"credhub": nil,
-					"routing": nil,
+					"routing": {
+						Link: presenter.Link{HRef: defaultServerURL + "/routing"},
+					},
 					"logging": nil,
 					"log_cache": {
 						Link: presenter.Link{HRef: defaultServerURL},
@@ -78,5 +91,74 @@ var _ = Describe("RootHandler", func() {
 				"CFOnK8s": Equal(true),
 			}))
 		})
+
+		When("single sign-on is enabled", func() {
+			BeforeEach(func() {
+				ssoEnabled = true
+			})
+
+			It("advertises the API as the UAA", func() {
+				var resp presenter.RootResponse
+				Expect(json.Unmarshal(rr.Body.Bytes(), &resp)).To(Succeed())
+				Expect(resp.Links).To(HaveKeyWithValue("uaa", &presenter.APILink{
+					Link: presenter.Link{HRef: defaultServerURL},
+				}))
+			})
+		})
+	})
+
+	Describe("GET /login endpoint", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequest("GET", "/login", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the login info without any prompts", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", jsonHeader))
+			Expect(rr).To(HaveHTTPBody(MatchJSON(`{
+				"app": {
+					"version": "` + presenter.V3APIVersion + `"
+				},
+				"showLoginLinks": false,
+				"links": {
+					"login": "` + defaultServerURL + `",
+					"uaa": "` + defaultServerURL + `"
+				},
+				"zone_name": "korifi",
+				"prompts": {}
+			}`)))
+		})
+
+		When("single sign-on is enabled", func() {
+			BeforeEach(func() {
+				ssoEnabled = true
+				passcodeURL = "https://login.example.com/passcode"
+			})
+
+			It("prompts for a passcode", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+
+				var resp presenter.LoginInfoResponse
+				Expect(json.Unmarshal(rr.Body.Bytes(), &resp)).To(Succeed())
+				Expect(resp.ShowLoginLinks).To(BeTrue())
+				Expect(resp.Prompts).To(Equal(map[string][]string{
+					"passcode": {"password", "Temporary Authentication Code ( Get one at https://login.example.com/passcode )"},
+				}))
+			})
+
+			When("there is no passcode url", func() {
+				BeforeEach(func() {
+					passcodeURL = ""
+				})
+
+				It("prompts for a passcode without a link", func() {
+					var resp presenter.LoginInfoResponse
+					Expect(json.Unmarshal(rr.Body.Bytes(), &resp)).To(Succeed())
+					Expect(resp.Prompts).To(HaveKeyWithValue("passcode", []string{"password", "Temporary Authentication Code"}))
+				})
+			})
+		})
 	})
 })
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/url"
 
@@ -21,6 +22,9 @@ const (
 	RoutesPath            = "/v3/routes"
 	RouteDestinationsPath = "/v3/routes/{guid}/destinations"
 	RouteDestinationPath  = "/v3/routes/{guid}/destinations/{destination_guid}"
+	RouteSpacePath        = "/v3/routes/{guid}/relationships/space"
+	RouteSharedSpacesPath = "/v3/routes/{guid}/relationships/shared_spaces"
+	RouteSharedSpacePath  = "/v3/routes/{guid}/relationships/shared_spaces/{space_guid}"
 )
 
 //counterfeiter:generate -o fake -fake-name CFRouteRepository . CFRouteRepository
@@ -32,8 +36,12 @@ type CFRouteRepository interface {
 	CreateRoute(context.Context, authorization.Info, repositories.CreateRouteMessage) (repositories.RouteRecord, error)
 	DeleteRoute(context.Context, authorization.Info, repositories.DeleteRouteMessage) error
 	AddDestinationsToRoute(ctx context.Context, c authorization.Info, message repositories.AddDestinationsToRouteMessage) (repositories.RouteRecord, error)
+	ReplaceRouteDestinations(ctx context.Context, authInfo authorization.Info, message repositories.ReplaceRouteDestinationsMessage) (repositories.RouteRecord, error)
 	RemoveDestinationFromRoute(ctx context.Context, authInfo authorization.Info, message repositories.RemoveDestinationFromRouteMessage) (repositories.RouteRecord, error)
 	PatchRouteMetadata(context.Context, authorization.Info, repositories.PatchRouteMetadataMessage) (repositories.RouteRecord, error)
+	ShareRoute(context.Context, authorization.Info, repositories.ShareRouteMessage) (repositories.RouteRecord, error)
+	UnshareRoute(context.Context, authorization.Info, repositories.UnshareRouteMessage) error
+	TransferRoute(context.Context, authorization.Info, repositories.TransferRouteMessage) (repositories.RouteRecord, error)
 }
 
 type RouteHandler struct {
@@ -43,6 +51,7 @@ type RouteHandler struct {
 	domainRepo       CFDomainRepository
 	appRepo          CFAppRepository
 	spaceRepo        SpaceRepository
+	routerGroupRepo  RouterGroupRepository
 	decoderValidator *DecoderValidator
 }
 
@@ -52,6 +61,7 @@ func NewRouteHandler(
 	domainRepo CFDomainRepository,
 	appRepo CFAppRepository,
 	spaceRepo SpaceRepository,
+	routerGroupRepo RouterGroupRepository,
 	decoderValidator *DecoderValidator,
 ) *RouteHandler {
 	return &RouteHandler{
@@ -61,6 +71,7 @@ func NewRouteHandler(
 		domainRepo:       domainRepo,
 		appRepo:          appRepo,
 		spaceRepo:        spaceRepo,
+		routerGroupRepo:  routerGroupRepo,
 		decoderValidator: decoderValidator,
 	}
 }
@@ -115,7 +126,7 @@ func (h *RouteHandler) routeCreateHandler(ctx context.Context, logger logr.Logge
 	}
 
 	spaceGUID := payload.Relationships.Space.Data.GUID
-	_, err := h.spaceRepo.GetSpace(ctx, authInfo, spaceGUID)
+	space, err := h.spaceRepo.GetSpace(ctx, authInfo, spaceGUID)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(
 			logger,
@@ -144,7 +155,48 @@ func (h *RouteHandler) routeCreateHandler(ctx context.Context, logger logr.Logge
 		)
 	}
 
+	if !domain.IsAvailableIn(space.OrganizationGUID) {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("Invalid domain. Domain '%s' is not available in organization '%s'.", domain.Name, space.OrganizationGUID)),
+			"Domain is not available in the space's org", "domainGUID", domainGUID, "spaceGUID", spaceGUID,
+		)
+	}
+
 	createRouteMessage := payload.ToMessage(domain.Namespace, domain.Name)
+	if domain.IsTCP() {
+		createRouteMessage, err = h.tcpRouteMessage(ctx, logger, domain, createRouteMessage)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		if payload.Port != nil {
+			return nil, apierrors.LogAndReturn(
+				logger,
+				apierrors.NewUnprocessableEntityError(nil, "Ports are not supported for HTTP routes."),
+				"Port requested for http route", "domainGUID", domainGUID,
+			)
+		}
+
+		if payload.Host == "" && !domain.IsPrivate() {
+			return nil, apierrors.LogAndReturn(
+				logger,
+				apierrors.NewUnprocessableEntityError(nil, "Missing host. Routes in shared domains must have a host defined."),
+				"Missing host for route on shared domain", "domainGUID", domainGUID,
+			)
+		}
+
+		if payload.Path != "" && domain.Internal {
+			return nil, apierrors.LogAndReturn(
+				logger,
+				apierrors.NewUnprocessableEntityError(nil, "Paths are not supported for internal domains."),
+				"Path requested for route on internal domain", "domainGUID", domainGUID,
+			)
+		}
+
+		createRouteMessage.Protocol = "http"
+	}
+
 	responseRouteRecord, err := h.routeRepo.CreateRoute(ctx, authInfo, createRouteMessage)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to create route", "Route Host", payload.Host)
@@ -155,6 +207,54 @@ func (h *RouteHandler) routeCreateHandler(ctx context.Context, logger logr.Logge
 	return NewHandlerResponse(http.StatusCreated).WithBody(presenter.ForRoute(responseRouteRecord, h.serverURL)), nil
 }
 
+// tcpRouteMessage validates the route against the router group of its tcp domain and adds the ports
+// the route can be allocated on to the message
+func (h *RouteHandler) tcpRouteMessage(ctx context.Context, logger logr.Logger, domain repositories.DomainRecord, message repositories.CreateRouteMessage) (repositories.CreateRouteMessage, error) {
+	if message.Host != "" {
+		return repositories.CreateRouteMessage{}, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "Hosts are not supported for TCP routes."),
+			"Host requested for tcp route", "domainGUID", domain.GUID,
+		)
+	}
+
+	if message.Path != "" {
+		return repositories.CreateRouteMessage{}, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "Paths are not supported for TCP routes."),
+			"Path requested for tcp route", "domainGUID", domain.GUID,
+		)
+	}
+
+	routerGroup, err := h.routerGroupRepo.GetRouterGroup(ctx, domain.RouterGroupGUID)
+	if err != nil {
+		return repositories.CreateRouteMessage{}, apierrors.LogAndReturn(logger, err, "Failed to fetch router group", "routerGroupGUID", domain.RouterGroupGUID)
+	}
+
+	if message.Port != 0 && !containsPort(routerGroup.Ports, message.Port) {
+		return repositories.CreateRouteMessage{}, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("Port %d is not available. Ports must be in the router group's reservable port range '%s'.", message.Port, routerGroup.ReservablePorts)),
+			"Requested port is not reservable", "port", message.Port, "routerGroupGUID", routerGroup.GUID,
+		)
+	}
+
+	message.Protocol = "tcp"
+	message.ReservablePorts = routerGroup.Ports
+
+	return message, nil
+}
+
+func containsPort(ports []int, port int) bool {
+	for _, p := range ports {
+		if p == port {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (h *RouteHandler) routeAddDestinationsHandler(ctx context.Context, logger logr.Logger, authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
 	var destinationCreatePayload payloads.DestinationListCreate
 	if err := h.decoderValidator.DecodeAndValidateJSONPayload(r, &destinationCreatePayload); err != nil {
@@ -170,6 +270,23 @@ func (h *RouteHandler) routeAddDestinationsHandler(ctx context.Context, logger l
 	}
 
 	destinationListCreateMessage := destinationCreatePayload.ToMessage(routeRecord)
+	for _, destination := range destinationListCreateMessage.NewDestinations {
+		if destination.Weight != nil {
+			return nil, apierrors.LogAndReturn(
+				logger,
+				apierrors.NewUnprocessableEntityError(nil, "Destinations with weights cannot be added. Replace all destinations of the route instead."),
+				"Weighted destinations can only be set by replacing all destinations", "routeGUID", routeRecord.GUID,
+			)
+		}
+	}
+
+	if err = validateDestinationProtocols(routeRecord, destinationListCreateMessage.NewDestinations); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Destination protocol does not match the route protocol", "routeGUID", routeRecord.GUID)
+	}
+
+	if err = h.resolveDestinationSpaces(ctx, authInfo, routeRecord, destinationListCreateMessage.NewDestinations); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to resolve the spaces of the destination apps", "routeGUID", routeRecord.GUID)
+	}
 
 	responseRouteRecord, err := h.routeRepo.AddDestinationsToRoute(ctx, authInfo, destinationListCreateMessage)
 	if err != nil {
@@ -179,6 +296,106 @@ func (h *RouteHandler) routeAddDestinationsHandler(ctx context.Context, logger l
 	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForRouteDestinations(responseRouteRecord, h.serverURL)), nil
 }
 
+func (h *RouteHandler) routeReplaceDestinationsHandler(ctx context.Context, logger logr.Logger, authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	var destinationListPayload payloads.DestinationListCreate
+	if err := h.decoderValidator.DecodeAndValidateJSONPayload(r, &destinationListPayload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	vars := mux.Vars(r)
+	routeGUID := vars["guid"]
+
+	routeRecord, err := h.lookupRouteAndDomain(ctx, logger, authInfo, routeGUID)
+	if err != nil {
+		return nil, err
+	}
+
+	replaceDestinationsMessage := destinationListPayload.ToReplaceMessage(routeRecord)
+	if err = validateDestinationProtocols(routeRecord, replaceDestinationsMessage.NewDestinations); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Destination protocol does not match the route protocol", "routeGUID", routeRecord.GUID)
+	}
+
+	if err = validateDestinationWeights(replaceDestinationsMessage.NewDestinations); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Invalid destination weights", "routeGUID", routeRecord.GUID)
+	}
+
+	if err = h.resolveDestinationSpaces(ctx, authInfo, routeRecord, replaceDestinationsMessage.NewDestinations); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to resolve the spaces of the destination apps", "routeGUID", routeRecord.GUID)
+	}
+
+	responseRouteRecord, err := h.routeRepo.ReplaceRouteDestinations(ctx, authInfo, replaceDestinationsMessage)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to replace destinations on route", "Route GUID", routeRecord.GUID)
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForRouteDestinations(responseRouteRecord, h.serverURL)), nil
+}
+
+func validateDestinationProtocols(routeRecord repositories.RouteRecord, destinations []repositories.DestinationMessage) error {
+	for _, destination := range destinations {
+		if (routeRecord.Protocol == "tcp") != (destination.Protocol == "tcp") {
+			return apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("Destination protocol '%s' is not supported by routes with protocol '%s'.", destination.Protocol, routeRecord.Protocol))
+		}
+	}
+
+	return nil
+}
+
+// resolveDestinationSpaces sets the space of the destination apps that are in one of the shared spaces
+// of the route. Apps of routes that are not shared must be in the space of the route, which the route
+// webhook checks, so they are not looked up
+func (h *RouteHandler) resolveDestinationSpaces(ctx context.Context, authInfo authorization.Info, routeRecord repositories.RouteRecord, destinations []repositories.DestinationMessage) error {
+	if len(routeRecord.SharedSpaceGUIDs) == 0 {
+		return nil
+	}
+
+	for i, destination := range destinations {
+		app, err := h.appRepo.GetApp(ctx, authInfo, destination.AppGUID)
+		if err != nil {
+			return apierrors.AsUnprocessableEntity(err, fmt.Sprintf("App with guid '%s' not found.", destination.AppGUID), apierrors.NotFoundError{}, apierrors.ForbiddenError{})
+		}
+
+		if app.SpaceGUID == routeRecord.SpaceGUID {
+			continue
+		}
+
+		if !isRouteSharedWith(routeRecord, app.SpaceGUID) {
+			return apierrors.NewUnprocessableEntityError(nil, "Routes cannot be mapped to destinations in different spaces.")
+		}
+
+		destinations[i].SpaceGUID = app.SpaceGUID
+	}
+
+	return nil
+}
+
+// validateDestinationWeights checks that either none or all of the destinations are weighted, and
+// that the weights split the whole route traffic between the destinations
+func validateDestinationWeights(destinations []repositories.DestinationMessage) error {
+	weighted := 0
+	totalWeight := 0
+	for _, destination := range destinations {
+		if destination.Weight != nil {
+			weighted++
+			totalWeight += *destination.Weight
+		}
+	}
+
+	if weighted == 0 {
+		return nil
+	}
+
+	if weighted != len(destinations) {
+		return apierrors.NewUnprocessableEntityError(nil, "Destinations cannot contain both weighted and unweighted destinations.")
+	}
+
+	if totalWeight != 100 {
+		return apierrors.NewUnprocessableEntityError(nil, "Weights for each destination must total 100.")
+	}
+
+	return nil
+}
+
 func (h *RouteHandler) routeDeleteDestinationHandler(ctx context.Context, logger logr.Logger, authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
 	vars := mux.Vars(r)
 	routeGUID := vars["guid"]
@@ -204,6 +421,129 @@ func (h *RouteHandler) routeDeleteDestinationHandler(ctx context.Context, logger
 	return NewHandlerResponse(http.StatusNoContent), nil
 }
 
+func (h *RouteHandler) routeTransferHandler(ctx context.Context, logger logr.Logger, authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	var payload payloads.RouteTransfer
+	if err := h.decoderValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	routeGUID := mux.Vars(r)["guid"]
+	routeRecord, err := h.lookupRouteAndDomain(ctx, logger, authInfo, routeGUID)
+	if err != nil {
+		return nil, err
+	}
+
+	targetSpaceGUID := payload.Data.GUID
+	if targetSpaceGUID == routeRecord.SpaceGUID {
+		return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForRouteSpace(routeRecord, h.serverURL)), nil
+	}
+
+	_, err = h.spaceRepo.GetSpace(ctx, authInfo, targetSpaceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.AsUnprocessableEntity(err, fmt.Sprintf("Unable to transfer owner of route '%s' to space '%s'. Ensure the space exists and that you have access to it.", routeGUID, targetSpaceGUID), apierrors.NotFoundError{}, apierrors.ForbiddenError{}),
+			"Failed to fetch space", "spaceGUID", targetSpaceGUID,
+		)
+	}
+
+	routeRecord, err = h.routeRepo.TransferRoute(ctx, authInfo, payload.ToMessage(routeGUID, routeRecord.SpaceGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to transfer route", "routeGUID", routeGUID, "spaceGUID", targetSpaceGUID)
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForRouteSpace(routeRecord, h.serverURL)), nil
+}
+
+func (h *RouteHandler) routeShareHandler(ctx context.Context, logger logr.Logger, authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	var payload payloads.RouteShare
+	if err := h.decoderValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	routeGUID := mux.Vars(r)["guid"]
+	routeRecord, err := h.lookupRouteAndDomain(ctx, logger, authInfo, routeGUID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, data := range payload.Data {
+		if data.GUID == routeRecord.SpaceGUID {
+			return nil, apierrors.LogAndReturn(
+				logger,
+				apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("Unable to share route '%s' with space '%s'. Routes cannot be shared into the space where they were created.", routeGUID, data.GUID)),
+				"Cannot share route into its own space", "routeGUID", routeGUID,
+			)
+		}
+
+		_, err = h.spaceRepo.GetSpace(ctx, authInfo, data.GUID)
+		if err != nil {
+			return nil, apierrors.LogAndReturn(
+				logger,
+				apierrors.AsUnprocessableEntity(err, fmt.Sprintf("Unable to share route '%s' with spaces ['%s']. Ensure the spaces exist and that you have access to them.", routeGUID, data.GUID), apierrors.NotFoundError{}, apierrors.ForbiddenError{}),
+				"Failed to fetch space", "spaceGUID", data.GUID,
+			)
+		}
+	}
+
+	routeRecord, err = h.routeRepo.ShareRoute(ctx, authInfo, payload.ToMessage(routeGUID, routeRecord.SpaceGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to share route", "routeGUID", routeGUID)
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForRouteSharedSpaces(routeRecord, h.serverURL)), nil
+}
+
+func (h *RouteHandler) routeListSharedSpacesHandler(ctx context.Context, logger logr.Logger, authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	routeGUID := mux.Vars(r)["guid"]
+	routeRecord, err := h.lookupRouteAndDomain(ctx, logger, authInfo, routeGUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForRouteSharedSpaces(routeRecord, h.serverURL)), nil
+}
+
+func (h *RouteHandler) routeUnshareHandler(ctx context.Context, logger logr.Logger, authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	vars := mux.Vars(r)
+	routeGUID := vars["guid"]
+	spaceGUID := vars["space_guid"]
+
+	routeRecord, err := h.lookupRouteAndDomain(ctx, logger, authInfo, routeGUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isRouteSharedWith(routeRecord, spaceGUID) {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("Unable to unshare route '%s' from space '%s'. Ensure the space exists and the route has been shared to this space.", routeGUID, spaceGUID)),
+			"Route is not shared with space", "routeGUID", routeGUID, "spaceGUID", spaceGUID,
+		)
+	}
+
+	err = h.routeRepo.UnshareRoute(ctx, authInfo, repositories.UnshareRouteMessage{
+		RouteGUID:       routeGUID,
+		SpaceGUID:       routeRecord.SpaceGUID,
+		SharedSpaceGUID: spaceGUID,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to unshare route", "routeGUID", routeGUID, "spaceGUID", spaceGUID)
+	}
+
+	return NewHandlerResponse(http.StatusNoContent), nil
+}
+
+func isRouteSharedWith(route repositories.RouteRecord, spaceGUID string) bool {
+	for _, sharedSpaceGUID := range route.SharedSpaceGUIDs {
+		if sharedSpaceGUID == spaceGUID {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (h *RouteHandler) routeDeleteHandler(ctx context.Context, logger logr.Logger, authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
 	vars := mux.Vars(r)
 	routeGUID := vars["guid"]
@@ -231,8 +571,13 @@ func (h *RouteHandler) RegisterRoutes(router *mux.Router) {
 	router.Path(RoutesPath).Methods("POST").HandlerFunc(h.handlerWrapper.Wrap(h.routeCreateHandler))
 	router.Path(RoutePath).Methods("DELETE").HandlerFunc(h.handlerWrapper.Wrap(h.routeDeleteHandler))
 	router.Path(RouteDestinationsPath).Methods("POST").HandlerFunc(h.handlerWrapper.Wrap(h.routeAddDestinationsHandler))
+	router.Path(RouteDestinationsPath).Methods("PATCH").HandlerFunc(h.handlerWrapper.Wrap(h.routeReplaceDestinationsHandler))
 	router.Path(RouteDestinationPath).Methods("DELETE").HandlerFunc(h.handlerWrapper.Wrap(h.routeDeleteDestinationHandler))
 	router.Path(RoutePath).Methods("PATCH").HandlerFunc(h.handlerWrapper.Wrap(h.routePatchHandler))
+	router.Path(RouteSpacePath).Methods("PATCH").HandlerFunc(h.handlerWrapper.Wrap(h.routeTransferHandler))
+	router.Path(RouteSharedSpacesPath).Methods("GET").HandlerFunc(h.handlerWrapper.Wrap(h.routeListSharedSpacesHandler))
+	router.Path(RouteSharedSpacesPath).Methods("POST").HandlerFunc(h.handlerWrapper.Wrap(h.routeShareHandler))
+	router.Path(RouteSharedSpacePath).Methods("DELETE").HandlerFunc(h.handlerWrapper.Wrap(h.routeUnshareHandler))
 }
 
 // Fetch Route and compose related Domain information within
@@ -1,6 +1,7 @@
 package handlers_test
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -12,6 +13,8 @@ import (
 	"code.cloudfoundry.org/korifi/api/repositories"
 
 	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/tools"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
@@ -33,6 +36,8 @@ var _ = Describe("RouteHandler", func() {
 		appRepo    *fake.CFAppRepository
 		spaceRepo  *fake.SpaceRepository
 
+		routerGroupRepo *fake.RouterGroupRepository
+
 		requestMethod string
 		requestPath   string
 		requestBody   string
@@ -43,6 +48,7 @@ var _ = Describe("RouteHandler", func() {
 		domainRepo = new(fake.CFDomainRepository)
 		appRepo = new(fake.CFAppRepository)
 		spaceRepo = new(fake.SpaceRepository)
+		routerGroupRepo = new(fake.RouterGroupRepository)
 		decoderValidator, err := NewDefaultDecoderValidator()
 		Expect(err).NotTo(HaveOccurred())
 
@@ -52,6 +58,7 @@ var _ = Describe("RouteHandler", func() {
 			domainRepo,
 			appRepo,
 			spaceRepo,
+			routerGroupRepo,
 			decoderValidator,
 		)
 		routeHandler.RegisterRoutes(router)
@@ -831,6 +838,278 @@ var _ = Describe("RouteHandler", func() {
 			})
 		})
 
+		When("the host is empty", func() {
+			BeforeEach(func() {
+				requestBody = initializeCreateRouteRequestBody("", testRoutePath, testSpaceGUID, testDomainGUID, nil, nil)
+			})
+
+			It("returns an error because the domain is shared", func() {
+				expectUnprocessableEntityError("Missing host. Routes in shared domains must have a host defined.")
+				Expect(routeRepo.CreateRouteCallCount()).To(Equal(0))
+			})
+
+			When("the domain is private", func() {
+				BeforeEach(func() {
+					spaceRepo.GetSpaceReturns(repositories.SpaceRecord{
+						Name:             testSpaceGUID,
+						OrganizationGUID: "org-guid",
+					}, nil)
+					domainRepo.GetDomainReturns(repositories.DomainRecord{
+						GUID:             testDomainGUID,
+						Name:             testDomainName,
+						OrganizationGUID: "org-guid",
+					}, nil)
+				})
+
+				It("creates the route", func() {
+					Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+					Expect(routeRepo.CreateRouteCallCount()).To(Equal(1))
+					_, _, createRouteMessage := routeRepo.CreateRouteArgsForCall(0)
+					Expect(createRouteMessage.Host).To(BeEmpty())
+				})
+			})
+		})
+
+		When("the domain is internal", func() {
+			BeforeEach(func() {
+				domainRepo.GetDomainReturns(repositories.DomainRecord{
+					GUID:     testDomainGUID,
+					Name:     testDomainName,
+					Internal: true,
+				}, nil)
+			})
+
+			It("returns an error because paths are not supported", func() {
+				expectUnprocessableEntityError("Paths are not supported for internal domains.")
+				Expect(routeRepo.CreateRouteCallCount()).To(Equal(0))
+			})
+
+			When("the route has no path", func() {
+				BeforeEach(func() {
+					requestBody = initializeCreateRouteRequestBody(testRouteHost, "", testSpaceGUID, testDomainGUID, nil, nil)
+				})
+
+				It("creates the route", func() {
+					Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+					Expect(routeRepo.CreateRouteCallCount()).To(Equal(1))
+				})
+			})
+		})
+
+		When("the domain is private to another org", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{
+					Name:             testSpaceGUID,
+					OrganizationGUID: "org-guid",
+				}, nil)
+				domainRepo.GetDomainReturns(repositories.DomainRecord{
+					GUID:             testDomainGUID,
+					Name:             testDomainName,
+					OrganizationGUID: "other-org-guid",
+				}, nil)
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError(fmt.Sprintf("Invalid domain. Domain '%s' is not available in organization 'org-guid'.", testDomainName))
+				Expect(routeRepo.CreateRouteCallCount()).To(Equal(0))
+			})
+
+			When("the domain is shared with the org", func() {
+				BeforeEach(func() {
+					domainRepo.GetDomainReturns(repositories.DomainRecord{
+						GUID:                    testDomainGUID,
+						Name:                    testDomainName,
+						OrganizationGUID:        "other-org-guid",
+						SharedOrganizationGUIDs: []string{"org-guid"},
+					}, nil)
+				})
+
+				It("creates the route", func() {
+					Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+					Expect(routeRepo.CreateRouteCallCount()).To(Equal(1))
+				})
+			})
+		})
+
+		When("a port is requested on an http domain", func() {
+			BeforeEach(func() {
+				requestBody = `{
+					"host": "test-route-host",
+					"port": 1024,
+					"relationships": {
+						"domain": { "data": { "guid": "test-domain-guid" } },
+						"space": { "data": { "guid": "test-space-guid" } }
+					}
+				}`
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Ports are not supported for HTTP routes.")
+				Expect(routeRepo.CreateRouteCallCount()).To(Equal(0))
+			})
+		})
+
+		It("creates an http route", func() {
+			Expect(routeRepo.CreateRouteCallCount()).To(Equal(1))
+			_, _, createRouteMessage := routeRepo.CreateRouteArgsForCall(0)
+			Expect(createRouteMessage.Protocol).To(Equal("http"))
+		})
+
+		When("the route has options", func() {
+			BeforeEach(func() {
+				routeRepo.CreateRouteReturns(repositories.RouteRecord{
+					GUID:      testRouteGUID,
+					SpaceGUID: testSpaceGUID,
+					Domain:    repositories.DomainRecord{GUID: testDomainGUID},
+					Host:      "test-route-host",
+					Protocol:  "http",
+					Options:   map[string]string{"loadbalancing": "least-connection"},
+				}, nil)
+
+				requestBody = `{
+					"host": "test-route-host",
+					"options": { "loadbalancing": "least-connection" },
+					"relationships": {
+						"domain": { "data": { "guid": "test-domain-guid" } },
+						"space": { "data": { "guid": "test-space-guid" } }
+					}
+				}`
+			})
+
+			It("creates the route with the options", func() {
+				Expect(routeRepo.CreateRouteCallCount()).To(Equal(1))
+				_, _, createRouteMessage := routeRepo.CreateRouteArgsForCall(0)
+				Expect(createRouteMessage.Options).To(Equal(map[string]string{"loadbalancing": "least-connection"}))
+			})
+
+			It("returns the options of the route", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+
+				var jsonBody struct {
+					Options map[string]string `json:"options"`
+				}
+				Expect(json.NewDecoder(rr.Body).Decode(&jsonBody)).To(Succeed())
+				Expect(jsonBody.Options).To(Equal(map[string]string{"loadbalancing": "least-connection"}))
+			})
+		})
+
+		When("the domain is a tcp domain", func() {
+			BeforeEach(func() {
+				domainRepo.GetDomainReturns(repositories.DomainRecord{
+					GUID:            testDomainGUID,
+					Name:            testDomainName,
+					RouterGroupGUID: "default-tcp",
+				}, nil)
+
+				routerGroupRepo.GetRouterGroupReturns(repositories.RouterGroupRecord{
+					GUID:            "default-tcp",
+					ReservablePorts: "1024-1025",
+					Ports:           []int{1024, 1025},
+				}, nil)
+
+				routeRepo.CreateRouteReturns(repositories.RouteRecord{
+					GUID:      testRouteGUID,
+					SpaceGUID: testSpaceGUID,
+					Domain:    repositories.DomainRecord{GUID: testDomainGUID},
+					Protocol:  "tcp",
+					Port:      1025,
+				}, nil)
+
+				requestBody = `{
+					"relationships": {
+						"domain": { "data": { "guid": "test-domain-guid" } },
+						"space": { "data": { "guid": "test-space-guid" } }
+					}
+				}`
+			})
+
+			It("creates a tcp route on the router group ports", func() {
+				Expect(routerGroupRepo.GetRouterGroupCallCount()).To(Equal(1))
+				_, routerGroupGUID := routerGroupRepo.GetRouterGroupArgsForCall(0)
+				Expect(routerGroupGUID).To(Equal("default-tcp"))
+
+				Expect(routeRepo.CreateRouteCallCount()).To(Equal(1))
+				_, _, createRouteMessage := routeRepo.CreateRouteArgsForCall(0)
+				Expect(createRouteMessage.Protocol).To(Equal("tcp"))
+				Expect(createRouteMessage.Port).To(BeZero())
+				Expect(createRouteMessage.ReservablePorts).To(Equal([]int{1024, 1025}))
+			})
+
+			It("returns the port and url of the route", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+				var bodyJSON map[string]interface{}
+				Expect(json.Unmarshal(rr.Body.Bytes(), &bodyJSON)).To(Succeed())
+				Expect(bodyJSON["protocol"]).To(Equal("tcp"))
+				Expect(bodyJSON["port"]).To(BeEquivalentTo(1025))
+				Expect(bodyJSON["url"]).To(Equal(testDomainName + ":1025"))
+			})
+
+			When("a port in the reservable range is requested", func() {
+				BeforeEach(func() {
+					requestBody = `{
+						"port": 1024,
+						"relationships": {
+							"domain": { "data": { "guid": "test-domain-guid" } },
+							"space": { "data": { "guid": "test-space-guid" } }
+						}
+					}`
+				})
+
+				It("creates the route on the requested port", func() {
+					Expect(routeRepo.CreateRouteCallCount()).To(Equal(1))
+					_, _, createRouteMessage := routeRepo.CreateRouteArgsForCall(0)
+					Expect(createRouteMessage.Port).To(Equal(1024))
+				})
+			})
+
+			When("a port outside the reservable range is requested", func() {
+				BeforeEach(func() {
+					requestBody = `{
+						"port": 2000,
+						"relationships": {
+							"domain": { "data": { "guid": "test-domain-guid" } },
+							"space": { "data": { "guid": "test-space-guid" } }
+						}
+					}`
+				})
+
+				It("returns an error", func() {
+					expectUnprocessableEntityError("Port 2000 is not available. Ports must be in the router group's reservable port range '1024-1025'.")
+					Expect(routeRepo.CreateRouteCallCount()).To(Equal(0))
+				})
+			})
+
+			When("a host is requested", func() {
+				BeforeEach(func() {
+					requestBody = initializeCreateRouteRequestBody(testRouteHost, "", testSpaceGUID, testDomainGUID, nil, nil)
+				})
+
+				It("returns an error", func() {
+					expectUnprocessableEntityError("Hosts are not supported for TCP routes.")
+				})
+			})
+
+			When("a path is requested", func() {
+				BeforeEach(func() {
+					requestBody = initializeCreateRouteRequestBody("", testRoutePath, testSpaceGUID, testDomainGUID, nil, nil)
+				})
+
+				It("returns an error", func() {
+					expectUnprocessableEntityError("Paths are not supported for TCP routes.")
+				})
+			})
+
+			When("fetching the router group fails", func() {
+				BeforeEach(func() {
+					routerGroupRepo.GetRouterGroupReturns(repositories.RouterGroupRecord{}, errors.New("boom"))
+				})
+
+				It("returns an error", func() {
+					expectUnknownError()
+				})
+			})
+		})
+
 		When("CreateRoute returns an unknown error", func() {
 			BeforeEach(func() {
 				routeRepo.CreateRouteReturns(repositories.RouteRecord{},
@@ -920,6 +1199,30 @@ var _ = Describe("RouteHandler", func() {
 			})
 		})
 
+		When("we patch the options", func() {
+			BeforeEach(func() {
+				routeRepo.GetRouteReturns(repositories.RouteRecord{
+					GUID:      testRouteGUID,
+					SpaceGUID: spaceGUID,
+				}, nil)
+				requestBody = `{
+				  "options": {
+					"request_timeout": "30s",
+					"loadbalancing": null
+				  }
+				}`
+			})
+
+			It("patches the options of the route", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+
+				Expect(routeRepo.PatchRouteMetadataCallCount()).To(Equal(1))
+				_, _, msg := routeRepo.PatchRouteMetadataArgsForCall(0)
+				Expect(msg.Options).To(HaveKeyWithValue("request_timeout", PointTo(Equal("30s"))))
+				Expect(msg.Options).To(HaveKeyWithValue("loadbalancing", BeNil()))
+			})
+		})
+
 		When("the user doesn't have permission to get the Route", func() {
 			BeforeEach(func() {
 				routeRepo.GetRouteReturns(repositories.RouteRecord{}, apierrors.NewForbiddenError(nil, repositories.RouteResourceType))
@@ -1392,12 +1695,16 @@ var _ = Describe("RouteHandler", func() {
 						"ProcessType": Equal("web"),
 						"Port":        Equal(8080),
 						"Protocol":    Equal("http1"),
+						"Weight":      BeNil(),
+						"SpaceGUID":   BeEmpty(),
 					}),
 					MatchAllFields(Fields{
 						"AppGUID":     Equal(destination2AppGUID),
 						"ProcessType": Equal(destination2ProcessType),
 						"Port":        Equal(destination2Port),
 						"Protocol":    Equal("http1"),
+						"Weight":      BeNil(),
+						"SpaceGUID":   BeEmpty(),
 					}),
 				))
 			})
@@ -1471,6 +1778,29 @@ var _ = Describe("RouteHandler", func() {
 				})
 			})
 
+			When("the destination protocol is http2", func() {
+				BeforeEach(func() {
+					requestBody = fmt.Sprintf(`{
+						"destinations": [
+							{
+								"app": {
+									"guid": %q
+								},
+								"protocol": "http2"
+							}
+						]
+					}`, destination1AppGUID)
+				})
+
+				It("adds an http2 destination", func() {
+					Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+					Expect(routeRepo.AddDestinationsToRouteCallCount()).To(Equal(1))
+					_, _, message := routeRepo.AddDestinationsToRouteArgsForCall(0)
+					Expect(message.NewDestinations).To(HaveLen(1))
+					Expect(message.NewDestinations[0].Protocol).To(Equal("http2"))
+				})
+			})
+
 			When("fetching the route errors", func() {
 				BeforeEach(func() {
 					routeRepo.GetRouteReturns(repositories.RouteRecord{}, errors.New("boom"))
@@ -1506,6 +1836,38 @@ var _ = Describe("RouteHandler", func() {
 			})
 		})
 
+		When("the destination protocol does not match the route protocol", func() {
+			BeforeEach(func() {
+				routeRecord.Protocol = "tcp"
+				routeRepo.GetRouteReturns(routeRecord, nil)
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Destination protocol 'http1' is not supported by routes with protocol 'tcp'.")
+				Expect(routeRepo.AddDestinationsToRouteCallCount()).To(Equal(0))
+			})
+		})
+
+		When("a destination is weighted", func() {
+			BeforeEach(func() {
+				requestBody = fmt.Sprintf(`{
+					"destinations": [
+						{
+							"app": {
+								"guid": %q
+							},
+							"weight": 100
+						}
+					]
+				}`, destination1AppGUID)
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Destinations with weights cannot be added. Replace all destinations of the route instead.")
+				Expect(routeRepo.AddDestinationsToRouteCallCount()).To(Equal(0))
+			})
+		})
+
 		When("the request body is invalid", func() {
 			When("JSON is invalid", func() {
 				BeforeEach(func() {
@@ -1599,7 +1961,7 @@ var _ = Describe("RouteHandler", func() {
 				})
 			})
 
-			When("destination protocol is not http1", func() {
+			When("destination protocol is not supported", func() {
 				BeforeEach(func() {
 					requestBody = `{
 							"destinations": [
@@ -1615,7 +1977,7 @@ var _ = Describe("RouteHandler", func() {
 				})
 
 				It("returns a status 422 Unprocessable Entity ", func() {
-					expectUnprocessableEntityError("Protocol must be one of [http1]")
+					expectUnprocessableEntityError("Protocol must be one of [http1 http2 tcp]")
 				})
 
 				It("doesn't add any destinations to a route", func() {
@@ -1625,6 +1987,530 @@ var _ = Describe("RouteHandler", func() {
 		})
 	})
 
+	Describe("the PATCH /v3/routes/:guid/destinations endpoint", func() {
+		const (
+			routeGUID           = "test-route-guid"
+			spaceGUID           = "test-space-guid"
+			blueAppGUID         = "blue-app-guid"
+			greenAppGUID        = "green-app-guid"
+			blueDestinationGUID = "blue-destination-guid"
+		)
+
+		var routeRecord repositories.RouteRecord
+
+		BeforeEach(func() {
+			routeRecord = repositories.RouteRecord{
+				GUID:      routeGUID,
+				SpaceGUID: spaceGUID,
+				Domain:    repositories.DomainRecord{GUID: "test-domain-guid"},
+				Host:      "test-app",
+				Protocol:  "http",
+				Destinations: []repositories.DestinationRecord{
+					{
+						GUID:        blueDestinationGUID,
+						AppGUID:     blueAppGUID,
+						ProcessType: "web",
+						Port:        8080,
+						Protocol:    "http1",
+					},
+				},
+			}
+			routeRepo.GetRouteReturns(routeRecord, nil)
+			domainRepo.GetDomainReturns(repositories.DomainRecord{GUID: "test-domain-guid", Name: "my-tld.com"}, nil)
+
+			updatedRoute := routeRecord
+			updatedRoute.Destinations = []repositories.DestinationRecord{
+				{
+					GUID:        blueDestinationGUID,
+					AppGUID:     blueAppGUID,
+					ProcessType: "web",
+					Port:        8080,
+					Protocol:    "http1",
+					Weight:      tools.PtrTo(20),
+				},
+				{
+					GUID:        "green-destination-guid",
+					AppGUID:     greenAppGUID,
+					ProcessType: "web",
+					Port:        8080,
+					Protocol:    "http1",
+					Weight:      tools.PtrTo(80),
+				},
+			}
+			routeRepo.ReplaceRouteDestinationsReturns(updatedRoute, nil)
+
+			requestMethod = http.MethodPatch
+			requestPath = "/v3/routes/" + routeGUID + "/destinations"
+			requestBody = fmt.Sprintf(`{
+				"destinations": [
+					{ "app": { "guid": %q }, "weight": 20 },
+					{ "app": { "guid": %q }, "weight": 80 }
+				]
+			}`, blueAppGUID, greenAppGUID)
+		})
+
+		It("replaces the destinations of the route", func() {
+			Expect(routeRepo.ReplaceRouteDestinationsCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := routeRepo.ReplaceRouteDestinationsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.RouteGUID).To(Equal(routeGUID))
+			Expect(message.SpaceGUID).To(Equal(spaceGUID))
+			Expect(message.ExistingDestinations).To(Equal(routeRecord.Destinations))
+			Expect(message.NewDestinations).To(Equal([]repositories.DestinationMessage{
+				{AppGUID: blueAppGUID, ProcessType: "web", Port: 8080, Protocol: "http1", Weight: tools.PtrTo(20)},
+				{AppGUID: greenAppGUID, ProcessType: "web", Port: 8080, Protocol: "http1", Weight: tools.PtrTo(80)},
+			}))
+		})
+
+		It("returns the new destinations", func() {
+			expectJSONResponse(http.StatusOK, `{
+				"destinations": [
+					{
+						"guid": "blue-destination-guid",
+						"app": { "guid": "blue-app-guid", "process": { "type": "web" } },
+						"weight": 20,
+						"port": 8080,
+						"protocol": "http1"
+					},
+					{
+						"guid": "green-destination-guid",
+						"app": { "guid": "green-app-guid", "process": { "type": "web" } },
+						"weight": 80,
+						"port": 8080,
+						"protocol": "http1"
+					}
+				],
+				"links": {
+					"self": { "href": "https://api.example.org/v3/routes/test-route-guid/destinations" },
+					"route": { "href": "https://api.example.org/v3/routes/test-route-guid" }
+				}
+			}`)
+		})
+
+		When("the destination list is empty", func() {
+			BeforeEach(func() {
+				requestBody = `{ "destinations": [] }`
+			})
+
+			It("removes all destinations from the route", func() {
+				Expect(routeRepo.ReplaceRouteDestinationsCallCount()).To(Equal(1))
+				_, _, message := routeRepo.ReplaceRouteDestinationsArgsForCall(0)
+				Expect(message.NewDestinations).To(BeEmpty())
+			})
+		})
+
+		When("only some destinations are weighted", func() {
+			BeforeEach(func() {
+				requestBody = fmt.Sprintf(`{
+					"destinations": [
+						{ "app": { "guid": %q }, "weight": 20 },
+						{ "app": { "guid": %q } }
+					]
+				}`, blueAppGUID, greenAppGUID)
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Destinations cannot contain both weighted and unweighted destinations.")
+				Expect(routeRepo.ReplaceRouteDestinationsCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the weights do not total 100", func() {
+			BeforeEach(func() {
+				requestBody = fmt.Sprintf(`{
+					"destinations": [
+						{ "app": { "guid": %q }, "weight": 20 },
+						{ "app": { "guid": %q }, "weight": 70 }
+					]
+				}`, blueAppGUID, greenAppGUID)
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Weights for each destination must total 100.")
+				Expect(routeRepo.ReplaceRouteDestinationsCallCount()).To(Equal(0))
+			})
+		})
+
+		When("a weight is out of range", func() {
+			BeforeEach(func() {
+				requestBody = fmt.Sprintf(`{
+					"destinations": [
+						{ "app": { "guid": %q }, "weight": 0 },
+						{ "app": { "guid": %q }, "weight": 100 }
+					]
+				}`, blueAppGUID, greenAppGUID)
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Weight must be 1 or greater")
+			})
+		})
+
+		When("the destination protocol does not match the route protocol", func() {
+			BeforeEach(func() {
+				routeRecord.Protocol = "tcp"
+				routeRepo.GetRouteReturns(routeRecord, nil)
+				requestBody = fmt.Sprintf(`{
+					"destinations": [
+						{ "app": { "guid": %q }, "protocol": "http1" }
+					]
+				}`, blueAppGUID)
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Destination protocol 'http1' is not supported by routes with protocol 'tcp'.")
+				Expect(routeRepo.ReplaceRouteDestinationsCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the route is shared with other spaces", func() {
+			BeforeEach(func() {
+				routeRecord.SharedSpaceGUIDs = []string{"shared-space-guid"}
+				routeRepo.GetRouteReturns(routeRecord, nil)
+
+				appRepo.GetAppStub = func(_ context.Context, _ authorization.Info, appGUID string) (repositories.AppRecord, error) {
+					if appGUID == greenAppGUID {
+						return repositories.AppRecord{GUID: appGUID, SpaceGUID: "shared-space-guid"}, nil
+					}
+					return repositories.AppRecord{GUID: appGUID, SpaceGUID: spaceGUID}, nil
+				}
+			})
+
+			It("sets the space of the destinations in the shared spaces", func() {
+				Expect(appRepo.GetAppCallCount()).To(Equal(2))
+				Expect(routeRepo.ReplaceRouteDestinationsCallCount()).To(Equal(1))
+				_, _, message := routeRepo.ReplaceRouteDestinationsArgsForCall(0)
+				Expect(message.NewDestinations).To(Equal([]repositories.DestinationMessage{
+					{AppGUID: blueAppGUID, ProcessType: "web", Port: 8080, Protocol: "http1", Weight: tools.PtrTo(20)},
+					{AppGUID: greenAppGUID, ProcessType: "web", Port: 8080, Protocol: "http1", Weight: tools.PtrTo(80), SpaceGUID: "shared-space-guid"},
+				}))
+			})
+
+			When("a destination app is in a space the route is not shared with", func() {
+				BeforeEach(func() {
+					appRepo.GetAppReturns(repositories.AppRecord{GUID: greenAppGUID, SpaceGUID: "other-space-guid"}, nil)
+					appRepo.GetAppStub = nil
+				})
+
+				It("returns an error", func() {
+					expectUnprocessableEntityError("Routes cannot be mapped to destinations in different spaces.")
+					Expect(routeRepo.ReplaceRouteDestinationsCallCount()).To(Equal(0))
+				})
+			})
+
+			When("a destination app does not exist", func() {
+				BeforeEach(func() {
+					appRepo.GetAppStub = nil
+					appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewNotFoundError(nil, repositories.AppResourceType))
+				})
+
+				It("returns an error", func() {
+					expectUnprocessableEntityError("App with guid 'blue-app-guid' not found.")
+					Expect(routeRepo.ReplaceRouteDestinationsCallCount()).To(Equal(0))
+				})
+			})
+		})
+
+		When("the route is not shared", func() {
+			It("does not look up the destination apps", func() {
+				Expect(appRepo.GetAppCallCount()).To(BeZero())
+			})
+		})
+
+		When("the route doesn't exist", func() {
+			BeforeEach(func() {
+				routeRepo.GetRouteReturns(repositories.RouteRecord{}, apierrors.NewNotFoundError(nil, repositories.RouteResourceType))
+			})
+
+			It("responds with 404 and an error", func() {
+				expectNotFoundError("Route not found")
+				Expect(routeRepo.ReplaceRouteDestinationsCallCount()).To(Equal(0))
+			})
+		})
+
+		When("replacing the destinations errors", func() {
+			BeforeEach(func() {
+				routeRepo.ReplaceRouteDestinationsReturns(repositories.RouteRecord{}, errors.New("boom"))
+			})
+
+			It("responds with an Unknown Error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("the PATCH /v3/routes/:guid/relationships/space endpoint", func() {
+		BeforeEach(func() {
+			routeRepo.GetRouteReturns(repositories.RouteRecord{
+				GUID:      testRouteGUID,
+				SpaceGUID: testSpaceGUID,
+				Domain:    repositories.DomainRecord{GUID: testDomainGUID},
+			}, nil)
+			domainRepo.GetDomainReturns(repositories.DomainRecord{GUID: testDomainGUID, Name: testDomainName}, nil)
+			spaceRepo.GetSpaceReturns(repositories.SpaceRecord{GUID: "target-space-guid"}, nil)
+			routeRepo.TransferRouteReturns(repositories.RouteRecord{
+				GUID:      testRouteGUID,
+				SpaceGUID: "target-space-guid",
+			}, nil)
+
+			requestMethod = http.MethodPatch
+			requestPath = "/v3/routes/" + testRouteGUID + "/relationships/space"
+			requestBody = `{ "data": { "guid": "target-space-guid" } }`
+		})
+
+		It("transfers the route to the target space", func() {
+			Expect(spaceRepo.GetSpaceCallCount()).To(Equal(1))
+			_, _, actualSpaceGUID := spaceRepo.GetSpaceArgsForCall(0)
+			Expect(actualSpaceGUID).To(Equal("target-space-guid"))
+
+			Expect(routeRepo.TransferRouteCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := routeRepo.TransferRouteArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.TransferRouteMessage{
+				RouteGUID:       testRouteGUID,
+				SpaceGUID:       testSpaceGUID,
+				TargetSpaceGUID: "target-space-guid",
+			}))
+		})
+
+		It("returns the new space relationship", func() {
+			expectJSONResponse(http.StatusOK, `{
+				"data": { "guid": "target-space-guid" },
+				"links": {
+					"self": { "href": "https://api.example.org/v3/routes/test-route-guid/relationships/space" },
+					"related": { "href": "https://api.example.org/v3/spaces/target-space-guid" }
+				}
+			}`)
+		})
+
+		When("the target space is the space of the route", func() {
+			BeforeEach(func() {
+				requestBody = fmt.Sprintf(`{ "data": { "guid": %q } }`, testSpaceGUID)
+			})
+
+			It("does not transfer the route", func() {
+				Expect(routeRepo.TransferRouteCallCount()).To(BeZero())
+				Expect(rr.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		When("the target space does not exist", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, apierrors.NewNotFoundError(nil, repositories.SpaceResourceType))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Unable to transfer owner of route 'test-route-guid' to space 'target-space-guid'. Ensure the space exists and that you have access to it.")
+				Expect(routeRepo.TransferRouteCallCount()).To(BeZero())
+			})
+		})
+
+		When("the payload has no data", func() {
+			BeforeEach(func() {
+				requestBody = `{}`
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Data is a required field")
+			})
+		})
+
+		When("the route does not exist", func() {
+			BeforeEach(func() {
+				routeRepo.GetRouteReturns(repositories.RouteRecord{}, apierrors.NewNotFoundError(nil, repositories.RouteResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Route not found")
+			})
+		})
+
+		When("transferring the route fails", func() {
+			BeforeEach(func() {
+				routeRepo.TransferRouteReturns(repositories.RouteRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("the GET /v3/routes/:guid/relationships/shared_spaces endpoint", func() {
+		BeforeEach(func() {
+			routeRepo.GetRouteReturns(repositories.RouteRecord{
+				GUID:             testRouteGUID,
+				SpaceGUID:        testSpaceGUID,
+				Domain:           repositories.DomainRecord{GUID: testDomainGUID},
+				SharedSpaceGUIDs: []string{"shared-space-1", "shared-space-2"},
+			}, nil)
+			domainRepo.GetDomainReturns(repositories.DomainRecord{GUID: testDomainGUID, Name: testDomainName}, nil)
+
+			requestMethod = http.MethodGet
+			requestPath = "/v3/routes/" + testRouteGUID + "/relationships/shared_spaces"
+			requestBody = ""
+		})
+
+		It("returns the shared spaces", func() {
+			expectJSONResponse(http.StatusOK, `{
+				"data": [
+					{ "guid": "shared-space-1" },
+					{ "guid": "shared-space-2" }
+				],
+				"links": {
+					"self": { "href": "https://api.example.org/v3/routes/test-route-guid/relationships/shared_spaces" }
+				}
+			}`)
+		})
+
+		When("the route does not exist", func() {
+			BeforeEach(func() {
+				routeRepo.GetRouteReturns(repositories.RouteRecord{}, apierrors.NewNotFoundError(nil, repositories.RouteResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Route not found")
+			})
+		})
+	})
+
+	Describe("the POST /v3/routes/:guid/relationships/shared_spaces endpoint", func() {
+		BeforeEach(func() {
+			routeRepo.GetRouteReturns(repositories.RouteRecord{
+				GUID:      testRouteGUID,
+				SpaceGUID: testSpaceGUID,
+				Domain:    repositories.DomainRecord{GUID: testDomainGUID},
+			}, nil)
+			domainRepo.GetDomainReturns(repositories.DomainRecord{GUID: testDomainGUID, Name: testDomainName}, nil)
+			routeRepo.ShareRouteReturns(repositories.RouteRecord{
+				GUID:             testRouteGUID,
+				SpaceGUID:        testSpaceGUID,
+				SharedSpaceGUIDs: []string{"shared-space-1", "shared-space-2"},
+			}, nil)
+
+			requestMethod = http.MethodPost
+			requestPath = "/v3/routes/" + testRouteGUID + "/relationships/shared_spaces"
+			requestBody = `{ "data": [ { "guid": "shared-space-1" }, { "guid": "shared-space-2" } ] }`
+		})
+
+		It("shares the route with the spaces", func() {
+			Expect(spaceRepo.GetSpaceCallCount()).To(Equal(2))
+
+			Expect(routeRepo.ShareRouteCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := routeRepo.ShareRouteArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.ShareRouteMessage{
+				RouteGUID:        testRouteGUID,
+				SpaceGUID:        testSpaceGUID,
+				SharedSpaceGUIDs: []string{"shared-space-1", "shared-space-2"},
+			}))
+		})
+
+		It("returns the shared spaces", func() {
+			expectJSONResponse(http.StatusOK, `{
+				"data": [
+					{ "guid": "shared-space-1" },
+					{ "guid": "shared-space-2" }
+				],
+				"links": {
+					"self": { "href": "https://api.example.org/v3/routes/test-route-guid/relationships/shared_spaces" }
+				}
+			}`)
+		})
+
+		When("sharing with the space of the route", func() {
+			BeforeEach(func() {
+				requestBody = fmt.Sprintf(`{ "data": [ { "guid": %q } ] }`, testSpaceGUID)
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Unable to share route 'test-route-guid' with space 'test-space-guid'. Routes cannot be shared into the space where they were created.")
+				Expect(routeRepo.ShareRouteCallCount()).To(BeZero())
+			})
+		})
+
+		When("a space does not exist", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, apierrors.NewForbiddenError(nil, repositories.SpaceResourceType))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Unable to share route 'test-route-guid' with spaces ['shared-space-1']. Ensure the spaces exist and that you have access to them.")
+				Expect(routeRepo.ShareRouteCallCount()).To(BeZero())
+			})
+		})
+
+		When("the payload has no spaces", func() {
+			BeforeEach(func() {
+				requestBody = `{ "data": [] }`
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Data must contain at least 1 item")
+			})
+		})
+
+		When("sharing the route fails", func() {
+			BeforeEach(func() {
+				routeRepo.ShareRouteReturns(repositories.RouteRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("the DELETE /v3/routes/:guid/relationships/shared_spaces/:space_guid endpoint", func() {
+		BeforeEach(func() {
+			routeRepo.GetRouteReturns(repositories.RouteRecord{
+				GUID:             testRouteGUID,
+				SpaceGUID:        testSpaceGUID,
+				Domain:           repositories.DomainRecord{GUID: testDomainGUID},
+				SharedSpaceGUIDs: []string{"shared-space-1"},
+			}, nil)
+			domainRepo.GetDomainReturns(repositories.DomainRecord{GUID: testDomainGUID, Name: testDomainName}, nil)
+
+			requestMethod = http.MethodDelete
+			requestPath = "/v3/routes/" + testRouteGUID + "/relationships/shared_spaces/shared-space-1"
+			requestBody = ""
+		})
+
+		It("unshares the route from the space", func() {
+			Expect(rr.Code).To(Equal(http.StatusNoContent))
+
+			Expect(routeRepo.UnshareRouteCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := routeRepo.UnshareRouteArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.UnshareRouteMessage{
+				RouteGUID:       testRouteGUID,
+				SpaceGUID:       testSpaceGUID,
+				SharedSpaceGUID: "shared-space-1",
+			}))
+		})
+
+		When("the route is not shared with the space", func() {
+			BeforeEach(func() {
+				requestPath = "/v3/routes/" + testRouteGUID + "/relationships/shared_spaces/other-space"
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Unable to unshare route 'test-route-guid' from space 'other-space'. Ensure the space exists and the route has been shared to this space.")
+				Expect(routeRepo.UnshareRouteCallCount()).To(BeZero())
+			})
+		})
+
+		When("unsharing the route fails", func() {
+			BeforeEach(func() {
+				routeRepo.UnshareRouteReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
 	Describe("the DELETE /v3/routes/:guid/destinations/:destination_guid endpoint", func() {
 		const (
 			routeGuid       = "test-route-guid"
@@ -1707,6 +2593,8 @@ var _ = Describe("RouteHandler", func() {
 						"ProcessType": Equal("web"),
 						"Port":        Equal(8080),
 						"Protocol":    Equal("http1"),
+						"Weight":      BeNil(),
+						"SpaceGUID":   BeEmpty(),
 					}),
 				))
 			})
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	"github.com/go-logr/logr"
+	"github.com/gorilla/mux"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	RouterGroupsPath = "/routing/v1/router_groups"
+	RouterGroupPath  = "/routing/v1/router_groups/{guid}"
+)
+
+//counterfeiter:generate -o fake -fake-name RouterGroupRepository . RouterGroupRepository
+type RouterGroupRepository interface {
+	ListRouterGroups(context.Context, repositories.ListRouterGroupsMessage) ([]repositories.RouterGroupRecord, error)
+	GetRouterGroup(context.Context, string) (repositories.RouterGroupRecord, error)
+}
+
+type RouterGroupHandler struct {
+	handlerWrapper  *AuthAwareHandlerFuncWrapper
+	routerGroupRepo RouterGroupRepository
+}
+
+func NewRouterGroupHandler(
+	routerGroupRepo RouterGroupRepository,
+) *RouterGroupHandler {
+	return &RouterGroupHandler{
+		handlerWrapper:  NewAuthAwareHandlerFuncWrapper(ctrl.Log.WithName("RouterGroupHandler")),
+		routerGroupRepo: routerGroupRepo,
+	}
+}
+
+func (h *RouterGroupHandler) routerGroupListHandler(ctx context.Context, logger logr.Logger, _ authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to parse request query parameters")
+	}
+
+	routerGroupListFilter := new(payloads.RouterGroupList)
+	err := payloads.Decode(routerGroupListFilter, r.Form)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	routerGroups, err := h.routerGroupRepo.ListRouterGroups(ctx, routerGroupListFilter.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to list router groups")
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForRouterGroupList(routerGroups)), nil
+}
+
+func (h *RouterGroupHandler) routerGroupGetHandler(ctx context.Context, logger logr.Logger, _ authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	routerGroupGUID := mux.Vars(r)["guid"]
+
+	routerGroup, err := h.routerGroupRepo.GetRouterGroup(ctx, routerGroupGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch router group", "routerGroupGUID", routerGroupGUID)
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForRouterGroup(routerGroup)), nil
+}
+
+func (h *RouterGroupHandler) RegisterRoutes(router *mux.Router) {
+	router.Path(RouterGroupsPath).Methods("GET").HandlerFunc(h.handlerWrapper.Wrap(h.routerGroupListHandler))
+	router.Path(RouterGroupPath).Methods("GET").HandlerFunc(h.handlerWrapper.Wrap(h.routerGroupGetHandler))
+}
@@ -0,0 +1,113 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	. "code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RouterGroupHandler", func() {
+	var (
+		routerGroupRepo *fake.RouterGroupRepository
+		req             *http.Request
+	)
+
+	BeforeEach(func() {
+		routerGroupRepo = new(fake.RouterGroupRepository)
+		routerGroupRepo.ListRouterGroupsReturns([]repositories.RouterGroupRecord{
+			{
+				GUID:            "default-tcp",
+				Name:            "default-tcp",
+				Type:            "tcp",
+				ReservablePorts: "1024-1033",
+			},
+		}, nil)
+
+		apiHandler := NewRouterGroupHandler(routerGroupRepo)
+		apiHandler.RegisterRoutes(router)
+	})
+
+	JustBeforeEach(func() {
+		router.ServeHTTP(rr, req)
+	})
+
+	Describe("the GET /routing/v1/router_groups endpoint", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/routing/v1/router_groups?name=default-tcp", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the router groups", func() {
+			expectJSONResponse(http.StatusOK, `[{
+				"guid": "default-tcp",
+				"name": "default-tcp",
+				"type": "tcp",
+				"reservable_ports": "1024-1033"
+			}]`)
+		})
+
+		It("filters by name", func() {
+			Expect(routerGroupRepo.ListRouterGroupsCallCount()).To(Equal(1))
+			_, message := routerGroupRepo.ListRouterGroupsArgsForCall(0)
+			Expect(message.Names).To(ConsistOf("default-tcp"))
+		})
+
+		When("an invalid query parameter is provided", func() {
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequestWithContext(ctx, "GET", "/routing/v1/router_groups?foo=bar", nil)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns an unknown key error", func() {
+				expectUnknownKeyError("The query parameter is invalid: Valid parameters are: 'name'")
+			})
+		})
+	})
+
+	Describe("the GET /routing/v1/router_groups/{guid} endpoint", func() {
+		BeforeEach(func() {
+			routerGroupRepo.GetRouterGroupReturns(repositories.RouterGroupRecord{
+				GUID:            "default-tcp",
+				Name:            "default-tcp",
+				Type:            "tcp",
+				ReservablePorts: "1024-1033",
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/routing/v1/router_groups/default-tcp", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the router group", func() {
+			Expect(routerGroupRepo.GetRouterGroupCallCount()).To(Equal(1))
+			_, guid := routerGroupRepo.GetRouterGroupArgsForCall(0)
+			Expect(guid).To(Equal("default-tcp"))
+
+			expectJSONResponse(http.StatusOK, `{
+				"guid": "default-tcp",
+				"name": "default-tcp",
+				"type": "tcp",
+				"reservable_ports": "1024-1033"
+			}`)
+		})
+
+		When("the router group does not exist", func() {
+			BeforeEach(func() {
+				routerGroupRepo.GetRouterGroupReturns(repositories.RouterGroupRecord{}, apierrors.NewNotFoundError(errors.New("nope"), repositories.RouterGroupResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Router Group not found")
+			})
+		})
+	})
+})
@@ -0,0 +1,214 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	"github.com/go-logr/logr"
+	"github.com/gorilla/mux"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	SecurityGroupsPath                   = "/v3/security_groups"
+	SecurityGroupPath                    = "/v3/security_groups/{guid}"
+	SecurityGroupRunningSpacesPath       = "/v3/security_groups/{guid}/relationships/running_spaces"
+	SecurityGroupRunningSpacePath        = "/v3/security_groups/{guid}/relationships/running_spaces/{space_guid}"
+	SecurityGroupStagingSpacesPath       = "/v3/security_groups/{guid}/relationships/staging_spaces"
+	SecurityGroupStagingSpacePath        = "/v3/security_groups/{guid}/relationships/staging_spaces/{space_guid}"
+	spacesNotFoundOrForbiddenMessageTmpl = "Spaces with guids %s do not exist, or you do not have access to them."
+)
+
+//counterfeiter:generate -o fake -fake-name SecurityGroupRepository . SecurityGroupRepository
+
+type SecurityGroupRepository interface {
+	CreateSecurityGroup(context.Context, authorization.Info, repositories.CreateSecurityGroupMessage) (repositories.SecurityGroupRecord, error)
+	ListSecurityGroups(context.Context, authorization.Info, repositories.ListSecurityGroupsMessage) ([]repositories.SecurityGroupRecord, error)
+	GetSecurityGroup(context.Context, authorization.Info, string) (repositories.SecurityGroupRecord, error)
+	UpdateSecurityGroup(context.Context, authorization.Info, repositories.UpdateSecurityGroupMessage) (repositories.SecurityGroupRecord, error)
+	DeleteSecurityGroup(context.Context, authorization.Info, string) error
+	BindSecurityGroup(context.Context, authorization.Info, repositories.BindSecurityGroupMessage) (repositories.SecurityGroupRecord, error)
+	UnbindSecurityGroup(context.Context, authorization.Info, repositories.UnbindSecurityGroupMessage) error
+}
+
+type SecurityGroupHandler struct {
+	handlerWrapper    *AuthAwareHandlerFuncWrapper
+	apiBaseURL        url.URL
+	securityGroupRepo SecurityGroupRepository
+	spaceRepo         SpaceRepository
+	decoderValidator  *DecoderValidator
+}
+
+func NewSecurityGroupHandler(apiBaseURL url.URL, securityGroupRepo SecurityGroupRepository, spaceRepo SpaceRepository, decoderValidator *DecoderValidator) *SecurityGroupHandler {
+	return &SecurityGroupHandler{
+		handlerWrapper:    NewAuthAwareHandlerFuncWrapper(ctrl.Log.WithName("SecurityGroupHandler")),
+		apiBaseURL:        apiBaseURL,
+		securityGroupRepo: securityGroupRepo,
+		spaceRepo:         spaceRepo,
+		decoderValidator:  decoderValidator,
+	}
+}
+
+func (h *SecurityGroupHandler) securityGroupCreateHandler(ctx context.Context, logger logr.Logger, authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	var payload payloads.SecurityGroupCreate
+	if err := h.decoderValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	message := payload.ToMessage()
+	if err := h.checkSpacesExist(ctx, logger, authInfo, append(message.RunningSpaceGUIDs, message.StagingSpaceGUIDs...)); err != nil {
+		return nil, err
+	}
+
+	securityGroup, err := h.securityGroupRepo.CreateSecurityGroup(ctx, authInfo, message)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to create security group", "name", message.Name)
+	}
+
+	return NewHandlerResponse(http.StatusCreated).WithBody(presenter.ForSecurityGroup(securityGroup, h.apiBaseURL)), nil
+}
+
+func (h *SecurityGroupHandler) securityGroupListHandler(ctx context.Context, logger logr.Logger, authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to parse request query parameters")
+	}
+
+	listFilter := new(payloads.SecurityGroupList)
+	if err := payloads.Decode(listFilter, r.Form); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	securityGroups, err := h.securityGroupRepo.ListSecurityGroups(ctx, authInfo, listFilter.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to list security groups")
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForSecurityGroupList(securityGroups, h.apiBaseURL, *r.URL)), nil
+}
+
+func (h *SecurityGroupHandler) securityGroupGetHandler(ctx context.Context, logger logr.Logger, authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	securityGroupGUID := mux.Vars(r)["guid"]
+
+	securityGroup, err := h.securityGroupRepo.GetSecurityGroup(ctx, authInfo, securityGroupGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to get security group", "guid", securityGroupGUID)
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForSecurityGroup(securityGroup, h.apiBaseURL)), nil
+}
+
+func (h *SecurityGroupHandler) securityGroupUpdateHandler(ctx context.Context, logger logr.Logger, authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	securityGroupGUID := mux.Vars(r)["guid"]
+
+	var payload payloads.SecurityGroupUpdate
+	if err := h.decoderValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	securityGroup, err := h.securityGroupRepo.UpdateSecurityGroup(ctx, authInfo, payload.ToMessage(securityGroupGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to update security group", "guid", securityGroupGUID)
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForSecurityGroup(securityGroup, h.apiBaseURL)), nil
+}
+
+func (h *SecurityGroupHandler) securityGroupDeleteHandler(ctx context.Context, logger logr.Logger, authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	securityGroupGUID := mux.Vars(r)["guid"]
+
+	err := h.securityGroupRepo.DeleteSecurityGroup(ctx, authInfo, securityGroupGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to delete security group", "guid", securityGroupGUID)
+	}
+
+	return NewHandlerResponse(http.StatusAccepted).WithHeader(
+		"Location",
+		presenter.JobURLForRedirects(securityGroupGUID, presenter.SecurityGroupDeleteOperation, h.apiBaseURL),
+	), nil
+}
+
+func (h *SecurityGroupHandler) bindHandler(workload string) AuthAwareHandlerFunc {
+	return func(ctx context.Context, logger logr.Logger, authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+		securityGroupGUID := mux.Vars(r)["guid"]
+
+		var payload payloads.SecurityGroupBind
+		if err := h.decoderValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+		}
+
+		if _, err := h.securityGroupRepo.GetSecurityGroup(ctx, authInfo, securityGroupGUID); err != nil {
+			return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to get security group", "guid", securityGroupGUID)
+		}
+
+		message := payload.ToMessage(securityGroupGUID, workload)
+		if err := h.checkSpacesExist(ctx, logger, authInfo, message.SpaceGUIDs); err != nil {
+			return nil, err
+		}
+
+		securityGroup, err := h.securityGroupRepo.BindSecurityGroup(ctx, authInfo, message)
+		if err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "Failed to bind security group", "guid", securityGroupGUID, "workload", workload)
+		}
+
+		return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForSecurityGroupSpaces(securityGroup, workload, h.apiBaseURL)), nil
+	}
+}
+
+func (h *SecurityGroupHandler) unbindHandler(workload string) AuthAwareHandlerFunc {
+	return func(ctx context.Context, logger logr.Logger, authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+		vars := mux.Vars(r)
+		message := repositories.UnbindSecurityGroupMessage{
+			GUID:      vars["guid"],
+			Workload:  workload,
+			SpaceGUID: vars["space_guid"],
+		}
+
+		err := h.securityGroupRepo.UnbindSecurityGroup(ctx, authInfo, message)
+		if err != nil {
+			return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to unbind security group", "guid", message.GUID, "workload", workload)
+		}
+
+		return NewHandlerResponse(http.StatusNoContent), nil
+	}
+}
+
+func (h *SecurityGroupHandler) checkSpacesExist(ctx context.Context, logger logr.Logger, authInfo authorization.Info, spaceGUIDs []string) error {
+	var invalidGUIDs []string
+	for _, spaceGUID := range spaceGUIDs {
+		if _, err := h.spaceRepo.GetSpace(ctx, authInfo, spaceGUID); err != nil {
+			invalidGUIDs = append(invalidGUIDs, spaceGUID)
+		}
+	}
+
+	if len(invalidGUIDs) == 0 {
+		return nil
+	}
+
+	guids, _ := json.Marshal(invalidGUIDs)
+	return apierrors.LogAndReturn(
+		logger,
+		apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf(spacesNotFoundOrForbiddenMessageTmpl, guids)),
+		"Failed to fetch spaces", "spaceGUIDs", invalidGUIDs,
+	)
+}
+
+func (h *SecurityGroupHandler) RegisterRoutes(router *mux.Router) {
+	router.Path(SecurityGroupsPath).Methods("POST").HandlerFunc(h.handlerWrapper.Wrap(h.securityGroupCreateHandler))
+	router.Path(SecurityGroupsPath).Methods("GET").HandlerFunc(h.handlerWrapper.Wrap(h.securityGroupListHandler))
+	router.Path(SecurityGroupPath).Methods("GET").HandlerFunc(h.handlerWrapper.Wrap(h.securityGroupGetHandler))
+	router.Path(SecurityGroupPath).Methods("PATCH").HandlerFunc(h.handlerWrapper.Wrap(h.securityGroupUpdateHandler))
+	router.Path(SecurityGroupPath).Methods("DELETE").HandlerFunc(h.handlerWrapper.Wrap(h.securityGroupDeleteHandler))
+	router.Path(SecurityGroupRunningSpacesPath).Methods("POST").HandlerFunc(h.handlerWrapper.Wrap(h.bindHandler(repositories.SecurityGroupWorkloadRunning)))
+	router.Path(SecurityGroupStagingSpacesPath).Methods("POST").HandlerFunc(h.handlerWrapper.Wrap(h.bindHandler(repositories.SecurityGroupWorkloadStaging)))
+	router.Path(SecurityGroupRunningSpacePath).Methods("DELETE").HandlerFunc(h.handlerWrapper.Wrap(h.unbindHandler(repositories.SecurityGroupWorkloadRunning)))
+	router.Path(SecurityGroupStagingSpacePath).Methods("DELETE").HandlerFunc(h.handlerWrapper.Wrap(h.unbindHandler(repositories.SecurityGroupWorkloadStaging)))
+}
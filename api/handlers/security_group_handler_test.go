@@ -0,0 +1,323 @@
+package handlers_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	apis "code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SecurityGroupHandler", func() {
+	var (
+		securityGroupRepo *fake.SecurityGroupRepository
+		spaceRepo         *fake.SpaceRepository
+		record            repositories.SecurityGroupRecord
+	)
+
+	BeforeEach(func() {
+		securityGroupRepo = new(fake.SecurityGroupRepository)
+		spaceRepo = new(fake.SpaceRepository)
+		decoderValidator, err := apis.NewDefaultDecoderValidator()
+		Expect(err).NotTo(HaveOccurred())
+
+		record = repositories.SecurityGroupRecord{
+			GUID:            "sg-guid",
+			Name:            "my-group",
+			GloballyEnabled: repositories.SecurityGroupWorkloads{Staging: true},
+			Rules: []repositories.SecurityGroupRule{
+				{Protocol: "tcp", Destination: "10.0.0.0/16", Ports: "443"},
+				{Protocol: "icmp", Destination: "10.0.0.1", Type: tools.PtrTo(0), Code: tools.PtrTo(0), Description: "ping"},
+			},
+			RunningSpaceGUIDs: []string{"space-guid"},
+			StagingSpaceGUIDs: []string{},
+			CreatedAt:         "2022-11-01T10:00:00Z",
+			UpdatedAt:         "2022-11-01T11:00:00Z",
+		}
+		securityGroupRepo.CreateSecurityGroupReturns(record, nil)
+		securityGroupRepo.GetSecurityGroupReturns(record, nil)
+		securityGroupRepo.ListSecurityGroupsReturns([]repositories.SecurityGroupRecord{record}, nil)
+		securityGroupRepo.UpdateSecurityGroupReturns(record, nil)
+		securityGroupRepo.BindSecurityGroupReturns(record, nil)
+
+		apis.NewSecurityGroupHandler(*serverURL, securityGroupRepo, spaceRepo, decoderValidator).RegisterRoutes(router)
+	})
+
+	serveRequest := func(method, path, body string) {
+		req, err := http.NewRequestWithContext(ctx, method, path, strings.NewReader(body))
+		Expect(err).NotTo(HaveOccurred())
+
+		router.ServeHTTP(rr, req)
+	}
+
+	securityGroupJSON := func() string {
+		return fmt.Sprintf(`{
+            "guid": "sg-guid",
+            "created_at": "2022-11-01T10:00:00Z",
+            "updated_at": "2022-11-01T11:00:00Z",
+            "name": "my-group",
+            "globally_enabled": {"running": false, "staging": true},
+            "rules": [
+                {"protocol": "tcp", "destination": "10.0.0.0/16", "ports": "443"},
+                {"protocol": "icmp", "destination": "10.0.0.1", "type": 0, "code": 0, "description": "ping"}
+            ],
+            "relationships": {
+                "running_spaces": {"data": [{"guid": "space-guid"}]},
+                "staging_spaces": {"data": []}
+            },
+            "links": {
+                "self": {"href": "%s/v3/security_groups/sg-guid"}
+            }
+        }`, defaultServerURL)
+	}
+
+	Describe("POST /v3/security_groups", func() {
+		It("creates the security group", func() {
+			serveRequest("POST", "/v3/security_groups", `{
+                "name": "my-group",
+                "globally_enabled": {"staging": true},
+                "rules": [{"protocol": "tcp", "destination": "10.0.0.0/16", "ports": "443"}],
+                "relationships": {"running_spaces": {"data": [{"guid": "space-guid"}]}}
+            }`)
+
+			expectJSONResponse(http.StatusCreated, securityGroupJSON())
+
+			_, _, spaceGUID := spaceRepo.GetSpaceArgsForCall(0)
+			Expect(spaceGUID).To(Equal("space-guid"))
+
+			_, actualAuthInfo, message := securityGroupRepo.CreateSecurityGroupArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.CreateSecurityGroupMessage{
+				Name:              "my-group",
+				GloballyEnabled:   repositories.SecurityGroupWorkloads{Staging: true},
+				Rules:             []repositories.SecurityGroupRule{{Protocol: "tcp", Destination: "10.0.0.0/16", Ports: "443"}},
+				RunningSpaceGUIDs: []string{"space-guid"},
+				StagingSpaceGUIDs: []string{},
+			}))
+		})
+
+		When("a rule has an invalid protocol", func() {
+			It("returns an unprocessable entity error", func() {
+				serveRequest("POST", "/v3/security_groups", `{"name": "my-group", "rules": [{"protocol": "sctp", "destination": "10.0.0.0/16"}]}`)
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusUnprocessableEntity))
+				Expect(securityGroupRepo.CreateSecurityGroupCallCount()).To(Equal(0))
+			})
+		})
+
+		When("a rule has an invalid destination", func() {
+			It("returns an unprocessable entity error", func() {
+				serveRequest("POST", "/v3/security_groups", `{"name": "my-group", "rules": [{"protocol": "all", "destination": "example.com"}]}`)
+
+				expectUnprocessableEntityError(`Rules are invalid: invalid destination "example.com"`)
+			})
+		})
+
+		When("a tcp rule has no ports", func() {
+			It("returns an unprocessable entity error", func() {
+				serveRequest("POST", "/v3/security_groups", `{"name": "my-group", "rules": [{"protocol": "tcp", "destination": "10.0.0.1"}]}`)
+
+				expectUnprocessableEntityError("Rules are invalid: ports are required for protocols of type tcp")
+			})
+		})
+
+		When("a space does not exist", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, apierrors.NewNotFoundError(nil, repositories.SpaceResourceType))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				serveRequest("POST", "/v3/security_groups", `{
+                    "name": "my-group",
+                    "relationships": {"staging_spaces": {"data": [{"guid": "space-guid"}]}}
+                }`)
+
+				expectUnprocessableEntityError(`Spaces with guids ["space-guid"] do not exist, or you do not have access to them.`)
+				Expect(securityGroupRepo.CreateSecurityGroupCallCount()).To(Equal(0))
+			})
+		})
+
+		When("creating the security group fails", func() {
+			BeforeEach(func() {
+				securityGroupRepo.CreateSecurityGroupReturns(repositories.SecurityGroupRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				serveRequest("POST", "/v3/security_groups", `{"name": "my-group"}`)
+
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/security_groups", func() {
+		It("lists the security groups", func() {
+			serveRequest("GET", "/v3/security_groups?names=my-group&globally_enabled_staging=true&running_space_guids=s1,s2", "")
+
+			expectJSONResponse(http.StatusOK, fmt.Sprintf(`{
+                "pagination": {
+                    "total_results": 1,
+                    "total_pages": 1,
+                    "first": {"href": "%[1]s/v3/security_groups?names=my-group&globally_enabled_staging=true&running_space_guids=s1,s2"},
+                    "last": {"href": "%[1]s/v3/security_groups?names=my-group&globally_enabled_staging=true&running_space_guids=s1,s2"},
+                    "next": null,
+                    "previous": null
+                },
+                "resources": [%[2]s]
+            }`, defaultServerURL, securityGroupJSON()))
+
+			_, _, message := securityGroupRepo.ListSecurityGroupsArgsForCall(0)
+			Expect(message).To(Equal(repositories.ListSecurityGroupsMessage{
+				GUIDs:                  []string{},
+				Names:                  []string{"my-group"},
+				GloballyEnabledStaging: tools.PtrTo(true),
+				RunningSpaceGUIDs:      []string{"s1", "s2"},
+				StagingSpaceGUIDs:      []string{},
+			}))
+		})
+
+		When("an unknown query parameter is passed", func() {
+			It("returns an error", func() {
+				serveRequest("GET", "/v3/security_groups?foo=bar", "")
+
+				expectUnknownKeyError("The query parameter is invalid: Valid parameters are: 'guids, names, globally_enabled_running, globally_enabled_staging, running_space_guids, staging_space_guids'")
+			})
+		})
+	})
+
+	Describe("GET /v3/security_groups/{guid}", func() {
+		It("returns the security group", func() {
+			serveRequest("GET", "/v3/security_groups/sg-guid", "")
+
+			expectJSONResponse(http.StatusOK, securityGroupJSON())
+		})
+
+		When("the security group is not found", func() {
+			BeforeEach(func() {
+				securityGroupRepo.GetSecurityGroupReturns(repositories.SecurityGroupRecord{}, apierrors.NewNotFoundError(nil, repositories.SecurityGroupResourceType))
+			})
+
+			It("returns a not found error", func() {
+				serveRequest("GET", "/v3/security_groups/sg-guid", "")
+
+				expectNotFoundError("Security Group not found")
+			})
+		})
+	})
+
+	Describe("PATCH /v3/security_groups/{guid}", func() {
+		It("updates the security group", func() {
+			serveRequest("PATCH", "/v3/security_groups/sg-guid", `{
+                "name": "new-name",
+                "globally_enabled": {"running": true},
+                "rules": [{"protocol": "udp", "destination": "10.0.0.1-10.0.0.4", "ports": "53"}]
+            }`)
+
+			expectJSONResponse(http.StatusOK, securityGroupJSON())
+
+			_, _, message := securityGroupRepo.UpdateSecurityGroupArgsForCall(0)
+			Expect(message).To(Equal(repositories.UpdateSecurityGroupMessage{
+				GUID:                   "sg-guid",
+				Name:                   tools.PtrTo("new-name"),
+				GloballyEnabledRunning: tools.PtrTo(true),
+				Rules:                  &[]repositories.SecurityGroupRule{{Protocol: "udp", Destination: "10.0.0.1-10.0.0.4", Ports: "53"}},
+			}))
+		})
+
+		When("the security group is forbidden", func() {
+			BeforeEach(func() {
+				securityGroupRepo.UpdateSecurityGroupReturns(repositories.SecurityGroupRecord{}, apierrors.NewForbiddenError(nil, repositories.SecurityGroupResourceType))
+			})
+
+			It("returns a not found error", func() {
+				serveRequest("PATCH", "/v3/security_groups/sg-guid", `{"name": "new-name"}`)
+
+				expectNotFoundError("Security Group not found")
+			})
+		})
+	})
+
+	Describe("DELETE /v3/security_groups/{guid}", func() {
+		It("deletes the security group", func() {
+			serveRequest("DELETE", "/v3/security_groups/sg-guid", "")
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Location", defaultServerURL+"/v3/jobs/security_group.delete~sg-guid"))
+
+			_, _, guid := securityGroupRepo.DeleteSecurityGroupArgsForCall(0)
+			Expect(guid).To(Equal("sg-guid"))
+		})
+	})
+
+	Describe("POST /v3/security_groups/{guid}/relationships/running_spaces", func() {
+		It("binds the security group to the spaces", func() {
+			serveRequest("POST", "/v3/security_groups/sg-guid/relationships/running_spaces", `{"data": [{"guid": "space-guid"}]}`)
+
+			expectJSONResponse(http.StatusOK, fmt.Sprintf(`{
+                "data": [{"guid": "space-guid"}],
+                "links": {
+                    "self": {"href": "%s/v3/security_groups/sg-guid/relationships/running_spaces"}
+                }
+            }`, defaultServerURL))
+
+			_, _, message := securityGroupRepo.BindSecurityGroupArgsForCall(0)
+			Expect(message).To(Equal(repositories.BindSecurityGroupMessage{
+				GUID:       "sg-guid",
+				Workload:   repositories.SecurityGroupWorkloadRunning,
+				SpaceGUIDs: []string{"space-guid"},
+			}))
+		})
+
+		When("the security group is not found", func() {
+			BeforeEach(func() {
+				securityGroupRepo.GetSecurityGroupReturns(repositories.SecurityGroupRecord{}, apierrors.NewNotFoundError(nil, repositories.SecurityGroupResourceType))
+			})
+
+			It("returns a not found error", func() {
+				serveRequest("POST", "/v3/security_groups/sg-guid/relationships/running_spaces", `{"data": [{"guid": "space-guid"}]}`)
+
+				expectNotFoundError("Security Group not found")
+				Expect(securityGroupRepo.BindSecurityGroupCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("POST /v3/security_groups/{guid}/relationships/staging_spaces", func() {
+		It("binds the security group to the spaces for staging", func() {
+			serveRequest("POST", "/v3/security_groups/sg-guid/relationships/staging_spaces", `{"data": [{"guid": "space-guid"}]}`)
+
+			expectJSONResponse(http.StatusOK, fmt.Sprintf(`{
+                "data": [],
+                "links": {
+                    "self": {"href": "%s/v3/security_groups/sg-guid/relationships/staging_spaces"}
+                }
+            }`, defaultServerURL))
+
+			_, _, message := securityGroupRepo.BindSecurityGroupArgsForCall(0)
+			Expect(message.Workload).To(Equal(repositories.SecurityGroupWorkloadStaging))
+		})
+	})
+
+	Describe("DELETE /v3/security_groups/{guid}/relationships/staging_spaces/{space_guid}", func() {
+		It("unbinds the security group from the space", func() {
+			serveRequest("DELETE", "/v3/security_groups/sg-guid/relationships/staging_spaces/space-guid", "")
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+
+			_, _, message := securityGroupRepo.UnbindSecurityGroupArgsForCall(0)
+			Expect(message).To(Equal(repositories.UnbindSecurityGroupMessage{
+				GUID:      "sg-guid",
+				Workload:  repositories.SecurityGroupWorkloadStaging,
+				SpaceGUID: "space-guid",
+			}))
+		})
+	})
+})
@@ -65,7 +65,7 @@ func (h *ServiceBindingHandler) createHandler(ctx context.Context, logger logr.L
 		return nil, apierrors.LogAndReturn(logger, err, fmt.Sprintf("failed to get %s", repositories.ServiceInstanceResourceType))
 	}
 
-	if app.SpaceGUID != serviceInstance.SpaceGUID {
+	if app.SpaceGUID != serviceInstance.SpaceGUID && !isSharedWith(serviceInstance, app.SpaceGUID) {
 		return nil, apierrors.LogAndReturn(
 			logger,
 			apierrors.NewUnprocessableEntityError(nil, "The service instance and the app are in different spaces"),
@@ -74,7 +74,7 @@ func (h *ServiceBindingHandler) createHandler(ctx context.Context, logger logr.L
 		)
 	}
 
-	serviceBinding, err := h.serviceBindingRepo.CreateServiceBinding(ctx, authInfo, payload.ToMessage(app.SpaceGUID))
+	serviceBinding, err := h.serviceBindingRepo.CreateServiceBinding(ctx, authInfo, payload.ToMessage(app.SpaceGUID, serviceInstance.SpaceGUID))
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "failed to create ServiceBinding", "App GUID", app.GUID, "ServiceInstance GUID", serviceInstance.GUID)
 	}
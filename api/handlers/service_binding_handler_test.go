@@ -202,6 +202,24 @@ var _ = Describe("ServiceBindingHandler", func() {
 			It("doesn't create the ServiceBinding", func() {
 				Expect(serviceBindingRepo.CreateServiceBindingCallCount()).To(Equal(0))
 			})
+
+			When("the ServiceInstance is shared with the App space", func() {
+				BeforeEach(func() {
+					serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+						GUID:             serviceInstanceGUID,
+						SpaceGUID:        "another-space-guid",
+						SharedSpaceGUIDs: []string{spaceGUID},
+					}, nil)
+				})
+
+				It("creates the ServiceBinding in the App space", func() {
+					Expect(rr.Code).To(Equal(http.StatusCreated))
+					Expect(serviceBindingRepo.CreateServiceBindingCallCount()).To(Equal(1))
+					_, _, message := serviceBindingRepo.CreateServiceBindingArgsForCall(0)
+					Expect(message.SpaceGUID).To(Equal(spaceGUID))
+					Expect(message.ServiceInstanceSpaceGUID).To(Equal("another-space-guid"))
+				})
+			})
 		})
 
 		When("getting the App errors", func() {
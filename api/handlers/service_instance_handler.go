@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/url"
 	"strings"
@@ -23,8 +24,11 @@ import (
 )
 
 const (
-	ServiceInstancesPath = "/v3/service_instances"
-	ServiceInstancePath  = "/v3/service_instances/{guid}"
+	ServiceInstancesPath            = "/v3/service_instances"
+	ServiceInstancePath             = "/v3/service_instances/{guid}"
+	ServiceInstanceParametersPath   = "/v3/service_instances/{guid}/parameters"
+	ServiceInstanceSharedSpacesPath = "/v3/service_instances/{guid}/relationships/shared_spaces"
+	ServiceInstanceSharedSpacePath  = "/v3/service_instances/{guid}/relationships/shared_spaces/{space_guid}"
 )
 
 //counterfeiter:generate -o fake -fake-name CFServiceInstanceRepository . CFServiceInstanceRepository
@@ -33,6 +37,8 @@ type CFServiceInstanceRepository interface {
 	ListServiceInstances(context.Context, authorization.Info, repositories.ListServiceInstanceMessage) ([]repositories.ServiceInstanceRecord, error)
 	GetServiceInstance(context.Context, authorization.Info, string) (repositories.ServiceInstanceRecord, error)
 	DeleteServiceInstance(context.Context, authorization.Info, repositories.DeleteServiceInstanceMessage) error
+	ShareServiceInstance(context.Context, authorization.Info, repositories.ShareServiceInstanceMessage) (repositories.ServiceInstanceRecord, error)
+	UnshareServiceInstance(context.Context, authorization.Info, repositories.UnshareServiceInstanceMessage) error
 }
 
 type ServiceInstanceHandler struct {
@@ -129,8 +135,119 @@ func (h *ServiceInstanceHandler) serviceInstanceDeleteHandler(ctx context.Contex
 	return NewHandlerResponse(http.StatusNoContent), nil
 }
 
+func (h *ServiceInstanceHandler) serviceInstanceGetParametersHandler(ctx context.Context, logger logr.Logger, authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	serviceInstanceGUID := mux.Vars(r)["guid"]
+
+	serviceInstance, err := h.serviceInstanceRepo.GetServiceInstance(ctx, authInfo, serviceInstanceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get service instance", "guid", serviceInstanceGUID)
+	}
+
+	// parameters are only ever sent to service brokers, so user-provided instances have none
+	return nil, apierrors.LogAndReturn(
+		logger,
+		apierrors.NewUnprocessableEntityError(nil, "Cannot get parameters of a user-provided service instance"),
+		"Service instance parameters not supported", "guid", serviceInstanceGUID, "type", serviceInstance.Type,
+	)
+}
+
+func (h *ServiceInstanceHandler) serviceInstanceShareHandler(ctx context.Context, logger logr.Logger, authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	serviceInstanceGUID := mux.Vars(r)["guid"]
+
+	var payload payloads.ServiceInstanceShare
+	if err := h.decoderValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	serviceInstance, err := h.serviceInstanceRepo.GetServiceInstance(ctx, authInfo, serviceInstanceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get service instance", "guid", serviceInstanceGUID)
+	}
+
+	for _, data := range payload.Data {
+		if data.GUID == serviceInstance.SpaceGUID {
+			return nil, apierrors.LogAndReturn(
+				logger,
+				apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("Unable to share service instance '%s' with space '%s'. Service instances cannot be shared into the space where they were created.", serviceInstance.Name, data.GUID)),
+				"Cannot share service instance into its own space", "guid", serviceInstanceGUID,
+			)
+		}
+
+		_, err = h.spaceRepo.GetSpace(ctx, authInfo, data.GUID)
+		if err != nil {
+			return nil, apierrors.LogAndReturn(
+				logger,
+				apierrors.AsUnprocessableEntity(err, fmt.Sprintf("Unable to share service instance %s with spaces ['%s']. Ensure the spaces exist and that you have access to them.", serviceInstance.Name, data.GUID), apierrors.NotFoundError{}, apierrors.ForbiddenError{}),
+				"Failed to fetch space", "spaceGUID", data.GUID,
+			)
+		}
+	}
+
+	serviceInstance, err = h.serviceInstanceRepo.ShareServiceInstance(ctx, authInfo, payload.ToMessage(serviceInstanceGUID, serviceInstance.SpaceGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to share service instance", "guid", serviceInstanceGUID)
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForServiceInstanceSharedSpaces(serviceInstance, h.serverURL)), nil
+}
+
+func (h *ServiceInstanceHandler) serviceInstanceListSharedSpacesHandler(ctx context.Context, logger logr.Logger, authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	serviceInstanceGUID := mux.Vars(r)["guid"]
+
+	serviceInstance, err := h.serviceInstanceRepo.GetServiceInstance(ctx, authInfo, serviceInstanceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get service instance", "guid", serviceInstanceGUID)
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForServiceInstanceSharedSpaces(serviceInstance, h.serverURL)), nil
+}
+
+func (h *ServiceInstanceHandler) serviceInstanceUnshareHandler(ctx context.Context, logger logr.Logger, authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	vars := mux.Vars(r)
+	serviceInstanceGUID := vars["guid"]
+	spaceGUID := vars["space_guid"]
+
+	serviceInstance, err := h.serviceInstanceRepo.GetServiceInstance(ctx, authInfo, serviceInstanceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get service instance", "guid", serviceInstanceGUID)
+	}
+
+	if !isSharedWith(serviceInstance, spaceGUID) {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("Unable to unshare service instance from space %s. Ensure the space exists and the service instance has been shared to this space.", spaceGUID)),
+			"Service instance is not shared with space", "guid", serviceInstanceGUID, "spaceGUID", spaceGUID,
+		)
+	}
+
+	err = h.serviceInstanceRepo.UnshareServiceInstance(ctx, authInfo, repositories.UnshareServiceInstanceMessage{
+		GUID:            serviceInstanceGUID,
+		SpaceGUID:       serviceInstance.SpaceGUID,
+		SharedSpaceGUID: spaceGUID,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to unshare service instance", "guid", serviceInstanceGUID, "spaceGUID", spaceGUID)
+	}
+
+	return NewHandlerResponse(http.StatusNoContent), nil
+}
+
 func (h *ServiceInstanceHandler) RegisterRoutes(router *mux.Router) {
 	router.Path(ServiceInstancesPath).Methods(http.MethodPost).HandlerFunc(h.handlerWrapper.Wrap(h.serviceInstanceCreateHandler))
 	router.Path(ServiceInstancesPath).Methods(http.MethodGet).HandlerFunc(h.handlerWrapper.Wrap(h.serviceInstanceListHandler))
 	router.Path(ServiceInstancePath).Methods(http.MethodDelete).HandlerFunc(h.handlerWrapper.Wrap(h.serviceInstanceDeleteHandler))
+	router.Path(ServiceInstanceParametersPath).Methods(http.MethodGet).HandlerFunc(h.handlerWrapper.Wrap(h.serviceInstanceGetParametersHandler))
+	router.Path(ServiceInstanceSharedSpacesPath).Methods(http.MethodPost).HandlerFunc(h.handlerWrapper.Wrap(h.serviceInstanceShareHandler))
+	router.Path(ServiceInstanceSharedSpacesPath).Methods(http.MethodGet).HandlerFunc(h.handlerWrapper.Wrap(h.serviceInstanceListSharedSpacesHandler))
+	router.Path(ServiceInstanceSharedSpacePath).Methods(http.MethodDelete).HandlerFunc(h.handlerWrapper.Wrap(h.serviceInstanceUnshareHandler))
+}
+
+func isSharedWith(serviceInstance repositories.ServiceInstanceRecord, spaceGUID string) bool {
+	for _, sharedSpaceGUID := range serviceInstance.SharedSpaceGUIDs {
+		if sharedSpaceGUID == spaceGUID {
+			return true
+		}
+	}
+
+	return false
 }
@@ -13,11 +13,13 @@ import (
 	"code.cloudfoundry.org/korifi/api/repositories"
 
 	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/tools"
 
 	. "code.cloudfoundry.org/korifi/api/handlers"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
 )
 
 var _ = Describe("ServiceInstanceHandler", func() {
@@ -136,6 +138,9 @@ var _ = Describe("ServiceInstanceHandler", func() {
 					"service_route_bindings": {
 					  "href": "%[1]s/v3/service_route_bindings?service_instance_guids=%[2]s"
 					},
+					"shared_spaces": {
+					  "href": "%[1]s/v3/service_instances/%[2]s/relationships/shared_spaces"
+					},
 					"space": {
 					  "href": "%[1]s/v3/spaces/%[3]s"
 					}
@@ -173,21 +178,115 @@ var _ = Describe("ServiceInstanceHandler", func() {
 
 		When("the request body has route_service_url set", func() {
 			BeforeEach(func() {
-				makePostRequest(`{"route_service_url" : "Invalid Request"}`)
+				serviceInstanceRepo.CreateServiceInstanceReturns(repositories.ServiceInstanceRecord{
+					Name:            serviceInstanceName,
+					GUID:            serviceInstanceGUID,
+					SpaceGUID:       serviceInstanceSpaceGUID,
+					Type:            serviceInstanceTypeUserProvided,
+					RouteServiceURL: tools.PtrTo("https://route-service.example.com"),
+				}, nil)
+
+				makePostRequest(`{
+					"name": "` + serviceInstanceName + `",
+					"route_service_url": "https://route-service.example.com",
+					"relationships": {
+						"space": {
+							"data": {
+								"guid": "` + serviceInstanceSpaceGUID + `"
+							}
+						}
+					},
+					"type": "` + serviceInstanceTypeUserProvided + `"
+				}`)
 			})
 
-			It("returns an error", func() {
-				expectUnprocessableEntityError(`invalid request body: json: unknown field "route_service_url"`)
+			It("passes the route service url to the repository", func() {
+				Expect(serviceInstanceRepo.CreateServiceInstanceCallCount()).To(Equal(1))
+				_, _, actualCreate := serviceInstanceRepo.CreateServiceInstanceArgsForCall(0)
+				Expect(actualCreate.RouteServiceURL).To(PointTo(Equal("https://route-service.example.com")))
+			})
+
+			It("returns the route service url in the response", func() {
+				Expect(rr.Code).To(Equal(http.StatusCreated))
+				Expect(rr.Body.String()).To(ContainSubstring(`"route_service_url":"https://route-service.example.com"`))
+			})
+
+			When("the route_service_url is not a valid URL", func() {
+				BeforeEach(func() {
+					makePostRequest(`{
+						"name": "` + serviceInstanceName + `",
+						"route_service_url": "not a url",
+						"relationships": {
+							"space": {
+								"data": {
+									"guid": "` + serviceInstanceSpaceGUID + `"
+								}
+							}
+						},
+						"type": "` + serviceInstanceTypeUserProvided + `"
+					}`)
+				})
+
+				It("returns an error", func() {
+					expectUnprocessableEntityError("RouteServiceURL must be a valid URL")
+				})
 			})
 		})
 
 		When("the request body has syslog_drain_url set", func() {
 			BeforeEach(func() {
-				makePostRequest(`{"syslog_drain_url" : "Invalid Request"}`)
+				serviceInstanceRepo.CreateServiceInstanceReturns(repositories.ServiceInstanceRecord{
+					Name:           serviceInstanceName,
+					GUID:           serviceInstanceGUID,
+					SpaceGUID:      serviceInstanceSpaceGUID,
+					Type:           serviceInstanceTypeUserProvided,
+					SyslogDrainURL: tools.PtrTo("syslog-tls://logs.example.com:6514"),
+				}, nil)
+
+				makePostRequest(`{
+					"name": "` + serviceInstanceName + `",
+					"syslog_drain_url": "syslog-tls://logs.example.com:6514",
+					"relationships": {
+						"space": {
+							"data": {
+								"guid": "` + serviceInstanceSpaceGUID + `"
+							}
+						}
+					},
+					"type": "` + serviceInstanceTypeUserProvided + `"
+				}`)
 			})
 
-			It("returns an error", func() {
-				expectUnprocessableEntityError(`invalid request body: json: unknown field "syslog_drain_url"`)
+			It("passes the syslog drain url to the repository", func() {
+				Expect(serviceInstanceRepo.CreateServiceInstanceCallCount()).To(Equal(1))
+				_, _, actualCreate := serviceInstanceRepo.CreateServiceInstanceArgsForCall(0)
+				Expect(actualCreate.SyslogDrainURL).To(PointTo(Equal("syslog-tls://logs.example.com:6514")))
+			})
+
+			It("returns the syslog drain url in the response", func() {
+				Expect(rr.Code).To(Equal(http.StatusCreated))
+				Expect(rr.Body.String()).To(ContainSubstring(`"syslog_drain_url":"syslog-tls://logs.example.com:6514"`))
+			})
+
+			When("the syslog_drain_url is not a valid URL", func() {
+				BeforeEach(func() {
+					makePostRequest(`{
+						"name": "` + serviceInstanceName + `",
+						"syslog_drain_url": "not a url",
+						"relationships": {
+							"space": {
+								"data": {
+									"guid": "` + serviceInstanceSpaceGUID + `"
+								}
+							}
+						},
+						"type": "` + serviceInstanceTypeUserProvided + `"
+					}`)
+				})
+
+				It("returns an error", func() {
+					expectUnprocessableEntityError("SyslogDrainURL must be a valid URL")
+				})
 			})
 		})
 
@@ -464,6 +563,9 @@ var _ = Describe("ServiceInstanceHandler", func() {
 							},
 							"service_route_bindings": {
 							  "href": "%[1]s/v3/service_route_bindings?service_instance_guids=%[3]s"
+							},
+							"shared_spaces": {
+							  "href": "%[1]s/v3/service_instances/%[3]s/relationships/shared_spaces"
 							}
 						  }
 						},
@@ -509,6 +611,9 @@ var _ = Describe("ServiceInstanceHandler", func() {
 							},
 							"service_route_bindings": {
 							  "href": "%[1]s/v3/service_route_bindings?service_instance_guids=%[7]s"
+							},
+							"shared_spaces": {
+							  "href": "%[1]s/v3/service_instances/%[7]s/relationships/shared_spaces"
 							}
 						  }
 						}
@@ -624,6 +729,36 @@ var _ = Describe("ServiceInstanceHandler", func() {
 			})
 		})
 
+		When("the service instances report their last operation", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.ListServiceInstancesReturns([]repositories.ServiceInstanceRecord{{
+					Name:      serviceInstanceName1,
+					GUID:      serviceInstanceGUID1,
+					SpaceGUID: serviceInstanceSpaceGUID,
+					Type:      serviceInstanceTypeUserProvided,
+					CreatedAt: "1906-04-18T13:12:00Z",
+					UpdatedAt: "1906-04-18T13:12:05Z",
+					LastOperation: &repositories.ServiceInstanceLastOperation{
+						Type:        "update",
+						State:       "in progress",
+						Description: "Waiting for the credentials secret",
+						UpdatedAt:   "1906-04-18T13:12:03Z",
+					},
+				}}, nil)
+				makeListRequest()
+			})
+
+			It("presents the reported last operation", func() {
+				Expect(rr.Code).To(Equal(http.StatusOK))
+				Expect(rr.Body.String()).To(ContainSubstring(`"last_operation":{` +
+					`"created_at":"1906-04-18T13:12:00Z",` +
+					`"updated_at":"1906-04-18T13:12:03Z",` +
+					`"description":"Waiting for the credentials secret",` +
+					`"state":"in progress",` +
+					`"type":"update"}`))
+			})
+		})
+
 		When("there is an error fetching service instances", func() {
 			BeforeEach(func() {
 				serviceInstanceRepo.ListServiceInstancesReturns([]repositories.ServiceInstanceRecord{}, errors.New("unknown!"))
@@ -705,6 +840,257 @@ var _ = Describe("ServiceInstanceHandler", func() {
 			})
 		})
 	})
+
+	Describe("the GET /v3/service_instances/{guid}/parameters endpoint", func() {
+		BeforeEach(func() {
+			serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+				GUID:      serviceInstanceGUID,
+				SpaceGUID: serviceInstanceSpaceGUID,
+				Type:      serviceInstanceTypeUserProvided,
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, http.MethodGet, "/v3/service_instances/"+serviceInstanceGUID+"/parameters", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("gets the service instance", func() {
+			Expect(serviceInstanceRepo.GetServiceInstanceCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := serviceInstanceRepo.GetServiceInstanceArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal(serviceInstanceGUID))
+		})
+
+		It("returns an unprocessable entity error for user-provided service instances", func() {
+			expectUnprocessableEntityError("Cannot get parameters of a user-provided service instance")
+		})
+
+		When("the service instance is not accessible", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{}, apierrors.NewForbiddenError(nil, repositories.ServiceInstanceResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.ServiceInstanceResourceType + " not found")
+			})
+		})
+	})
+
+	Describe("the POST /v3/service_instances/{guid}/relationships/shared_spaces endpoint", func() {
+		makeShareRequest := func(body string) {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, http.MethodPost, "/v3/service_instances/"+serviceInstanceGUID+"/relationships/shared_spaces", strings.NewReader(body))
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		BeforeEach(func() {
+			serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+				GUID:      serviceInstanceGUID,
+				Name:      "my-upsi",
+				SpaceGUID: serviceInstanceSpaceGUID,
+			}, nil)
+			serviceInstanceRepo.ShareServiceInstanceReturns(repositories.ServiceInstanceRecord{
+				GUID:             serviceInstanceGUID,
+				SpaceGUID:        serviceInstanceSpaceGUID,
+				SharedSpaceGUIDs: []string{"space-1", "space-2"},
+			}, nil)
+
+			makeShareRequest(`{"data": [{"guid": "space-1"}, {"guid": "space-2"}]}`)
+		})
+
+		It("checks that the target spaces exist", func() {
+			Expect(spaceRepo.GetSpaceCallCount()).To(Equal(2))
+			_, _, actualSpaceGUID := spaceRepo.GetSpaceArgsForCall(0)
+			Expect(actualSpaceGUID).To(Equal("space-1"))
+			_, _, actualSpaceGUID = spaceRepo.GetSpaceArgsForCall(1)
+			Expect(actualSpaceGUID).To(Equal("space-2"))
+		})
+
+		It("shares the service instance using the repo", func() {
+			Expect(serviceInstanceRepo.ShareServiceInstanceCallCount()).To(Equal(1))
+			_, _, message := serviceInstanceRepo.ShareServiceInstanceArgsForCall(0)
+			Expect(message).To(Equal(repositories.ShareServiceInstanceMessage{
+				GUID:             serviceInstanceGUID,
+				SpaceGUID:        serviceInstanceSpaceGUID,
+				SharedSpaceGUIDs: []string{"space-1", "space-2"},
+			}))
+		})
+
+		It("returns the shared spaces", func() {
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(rr.Body.String()).To(MatchJSON(`{
+				"data": [{"guid": "space-1"}, {"guid": "space-2"}],
+				"links": {
+					"self": {"href": "https://api.example.org/v3/service_instances/` + serviceInstanceGUID + `/relationships/shared_spaces"}
+				}
+			}`))
+		})
+
+		When("the request body is invalid", func() {
+			BeforeEach(func() {
+				makeShareRequest(`{"data": []}`)
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Data must contain at least 1 item")
+			})
+		})
+
+		When("the service instance is not accessible", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{}, apierrors.NewForbiddenError(nil, repositories.ServiceInstanceResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.ServiceInstanceResourceType + " not found")
+			})
+		})
+
+		When("sharing into the space the service instance was created in", func() {
+			BeforeEach(func() {
+				makeShareRequest(`{"data": [{"guid": "` + serviceInstanceSpaceGUID + `"}]}`)
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Unable to share service instance 'my-upsi' with space '" + serviceInstanceSpaceGUID + "'. Service instances cannot be shared into the space where they were created.")
+			})
+
+			It("does not share the service instance", func() {
+				Expect(serviceInstanceRepo.ShareServiceInstanceCallCount()).To(BeZero())
+			})
+		})
+
+		When("a target space does not exist", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, apierrors.NewNotFoundError(nil, repositories.SpaceResourceType))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Unable to share service instance my-upsi with spaces ['space-1']. Ensure the spaces exist and that you have access to them.")
+			})
+
+			It("does not share the service instance", func() {
+				Expect(serviceInstanceRepo.ShareServiceInstanceCallCount()).To(BeZero())
+			})
+		})
+
+		When("sharing the service instance fails", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.ShareServiceInstanceReturns(repositories.ServiceInstanceRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("the GET /v3/service_instances/{guid}/relationships/shared_spaces endpoint", func() {
+		BeforeEach(func() {
+			serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+				GUID:             serviceInstanceGUID,
+				SpaceGUID:        serviceInstanceSpaceGUID,
+				SharedSpaceGUIDs: []string{"space-1"},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, http.MethodGet, "/v3/service_instances/"+serviceInstanceGUID+"/relationships/shared_spaces", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the shared spaces", func() {
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(rr.Body.String()).To(MatchJSON(`{
+				"data": [{"guid": "space-1"}],
+				"links": {
+					"self": {"href": "https://api.example.org/v3/service_instances/` + serviceInstanceGUID + `/relationships/shared_spaces"}
+				}
+			}`))
+		})
+
+		When("the service instance is not shared", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{GUID: serviceInstanceGUID}, nil)
+			})
+
+			It("returns an empty list", func() {
+				Expect(rr.Code).To(Equal(http.StatusOK))
+				Expect(rr.Body.String()).To(ContainSubstring(`"data":[]`))
+			})
+		})
+
+		When("the service instance is not accessible", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{}, apierrors.NewForbiddenError(nil, repositories.ServiceInstanceResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.ServiceInstanceResourceType + " not found")
+			})
+		})
+	})
+
+	Describe("the DELETE /v3/service_instances/{guid}/relationships/shared_spaces/{space_guid} endpoint", func() {
+		BeforeEach(func() {
+			serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+				GUID:             serviceInstanceGUID,
+				SpaceGUID:        serviceInstanceSpaceGUID,
+				SharedSpaceGUIDs: []string{"space-1"},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, http.MethodDelete, "/v3/service_instances/"+serviceInstanceGUID+"/relationships/shared_spaces/space-1", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns status 204 No Content", func() {
+			Expect(rr.Code).To(Equal(http.StatusNoContent))
+		})
+
+		It("unshares the service instance using the repo", func() {
+			Expect(serviceInstanceRepo.UnshareServiceInstanceCallCount()).To(Equal(1))
+			_, _, message := serviceInstanceRepo.UnshareServiceInstanceArgsForCall(0)
+			Expect(message).To(Equal(repositories.UnshareServiceInstanceMessage{
+				GUID:            serviceInstanceGUID,
+				SpaceGUID:       serviceInstanceSpaceGUID,
+				SharedSpaceGUID: "space-1",
+			}))
+		})
+
+		When("the service instance is not shared with the space", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{GUID: serviceInstanceGUID, SpaceGUID: serviceInstanceSpaceGUID}, nil)
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Unable to unshare service instance from space space-1. Ensure the space exists and the service instance has been shared to this space.")
+			})
+
+			It("does not unshare the service instance", func() {
+				Expect(serviceInstanceRepo.UnshareServiceInstanceCallCount()).To(BeZero())
+			})
+		})
+
+		When("the service instance is not accessible", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{}, apierrors.NewForbiddenError(nil, repositories.ServiceInstanceResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.ServiceInstanceResourceType + " not found")
+			})
+		})
+
+		When("unsharing the service instance fails", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.UnshareServiceInstanceReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
 })
 
 func randomString(length int) string {
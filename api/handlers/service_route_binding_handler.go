@@ -2,11 +2,15 @@ package handlers
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/url"
 
+	"code.cloudfoundry.org/korifi/api/apierrors"
 	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/go-logr/logr"
@@ -15,26 +19,136 @@ import (
 
 const (
 	ServiceRouteBindingsPath = "/v3/service_route_bindings"
+	ServiceRouteBindingPath  = "/v3/service_route_bindings/{guid}"
 )
 
+//counterfeiter:generate -o fake -fake-name CFServiceRouteBindingRepository . CFServiceRouteBindingRepository
+type CFServiceRouteBindingRepository interface {
+	CreateServiceRouteBinding(context.Context, authorization.Info, repositories.CreateServiceRouteBindingMessage) (repositories.ServiceRouteBindingRecord, error)
+	GetServiceRouteBinding(context.Context, authorization.Info, string) (repositories.ServiceRouteBindingRecord, error)
+	ListServiceRouteBindings(context.Context, authorization.Info, repositories.ListServiceRouteBindingsMessage) ([]repositories.ServiceRouteBindingRecord, error)
+	DeleteServiceRouteBinding(context.Context, authorization.Info, string) error
+}
+
 type ServiceRouteBindingHandler struct {
-	handlerWrapper *AuthAwareHandlerFuncWrapper
-	serverURL      url.URL
+	handlerWrapper          *AuthAwareHandlerFuncWrapper
+	serverURL               url.URL
+	serviceRouteBindingRepo CFServiceRouteBindingRepository
+	routeRepo               CFRouteRepository
+	serviceInstanceRepo     CFServiceInstanceRepository
+	decoderValidator        *DecoderValidator
 }
 
 func NewServiceRouteBindingHandler(
 	serverURL url.URL,
+	serviceRouteBindingRepo CFServiceRouteBindingRepository,
+	routeRepo CFRouteRepository,
+	serviceInstanceRepo CFServiceInstanceRepository,
+	decoderValidator *DecoderValidator,
 ) *ServiceRouteBindingHandler {
 	return &ServiceRouteBindingHandler{
-		handlerWrapper: NewAuthAwareHandlerFuncWrapper(ctrl.Log.WithName("ServiceRouteBindingHandler")),
-		serverURL:      serverURL,
+		handlerWrapper:          NewAuthAwareHandlerFuncWrapper(ctrl.Log.WithName("ServiceRouteBindingHandler")),
+		serverURL:               serverURL,
+		serviceRouteBindingRepo: serviceRouteBindingRepo,
+		routeRepo:               routeRepo,
+		serviceInstanceRepo:     serviceInstanceRepo,
+		decoderValidator:        decoderValidator,
+	}
+}
+
+func (h *ServiceRouteBindingHandler) createHandler(ctx context.Context, logger logr.Logger, authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	var payload payloads.ServiceRouteBindingCreate
+	if err := h.decoderValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	route, err := h.routeRepo.GetRoute(ctx, authInfo, payload.Relationships.Route.Data.GUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.AsUnprocessableEntity(err, "Unable to use route. Ensure that the route exists and you have access to it.", apierrors.NotFoundError{}, apierrors.ForbiddenError{}),
+			fmt.Sprintf("failed to get %s", repositories.RouteResourceType),
+		)
+	}
+
+	serviceInstance, err := h.serviceInstanceRepo.GetServiceInstance(ctx, authInfo, payload.Relationships.ServiceInstance.Data.GUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.AsUnprocessableEntity(err, "Unable to use service instance. Ensure that the service instance exists and you have access to it.", apierrors.NotFoundError{}, apierrors.ForbiddenError{}),
+			fmt.Sprintf("failed to get %s", repositories.ServiceInstanceResourceType),
+		)
+	}
+
+	if route.SpaceGUID != serviceInstance.SpaceGUID {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "The service instance and the route are in different spaces."),
+			"Route and ServiceInstance in different spaces", "Route GUID", route.GUID,
+			"ServiceInstance GUID", serviceInstance.GUID,
+		)
+	}
+
+	if serviceInstance.RouteServiceURL == nil {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "This service instance does not support route binding."),
+			"ServiceInstance has no route service URL", "ServiceInstance GUID", serviceInstance.GUID,
+		)
+	}
+
+	routeBinding, err := h.serviceRouteBindingRepo.CreateServiceRouteBinding(ctx, authInfo, payload.ToMessage(route.SpaceGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to create ServiceRouteBinding", "Route GUID", route.GUID, "ServiceInstance GUID", serviceInstance.GUID)
+	}
+
+	return NewHandlerResponse(http.StatusCreated).WithBody(presenter.ForServiceRouteBinding(routeBinding, h.serverURL)), nil
+}
+
+func (h *ServiceRouteBindingHandler) getHandler(ctx context.Context, logger logr.Logger, authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	routeBindingGUID := mux.Vars(r)["guid"]
+
+	routeBinding, err := h.serviceRouteBindingRepo.GetServiceRouteBinding(ctx, authInfo, routeBindingGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get service route binding", "guid", routeBindingGUID)
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForServiceRouteBinding(routeBinding, h.serverURL)), nil
+}
+
+func (h *ServiceRouteBindingHandler) listHandler(ctx context.Context, logger logr.Logger, authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.NewUnprocessableEntityError(err, "unable to parse query"), "Unable to parse request query parameters")
 	}
+
+	listFilter := new(payloads.ServiceRouteBindingList)
+	err := payloads.Decode(listFilter, r.Form)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	routeBindings, err := h.serviceRouteBindingRepo.ListServiceRouteBindings(ctx, authInfo, listFilter.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, fmt.Sprintf("failed to list %s", repositories.ServiceRouteBindingResourceType))
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForServiceRouteBindingList(routeBindings, h.serverURL, *r.URL)), nil
 }
 
-func (h *ServiceRouteBindingHandler) serviceRouteBindingsListHandler(ctx context.Context, logger logr.Logger, authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
-	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForServiceRouteBindingsList(h.serverURL, *r.URL)), nil
+func (h *ServiceRouteBindingHandler) deleteHandler(ctx context.Context, logger logr.Logger, authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	routeBindingGUID := mux.Vars(r)["guid"]
+
+	err := h.serviceRouteBindingRepo.DeleteServiceRouteBinding(ctx, authInfo, routeBindingGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "error when deleting service route binding", "guid", routeBindingGUID)
+	}
+
+	return NewHandlerResponse(http.StatusNoContent), nil
 }
 
 func (h *ServiceRouteBindingHandler) RegisterRoutes(router *mux.Router) {
-	router.Path(ServiceRouteBindingsPath).Methods("GET").HandlerFunc(h.handlerWrapper.Wrap(h.serviceRouteBindingsListHandler))
+	router.Path(ServiceRouteBindingsPath).Methods("POST").HandlerFunc(h.handlerWrapper.Wrap(h.createHandler))
+	router.Path(ServiceRouteBindingsPath).Methods("GET").HandlerFunc(h.handlerWrapper.Wrap(h.listHandler))
+	router.Path(ServiceRouteBindingPath).Methods("GET").HandlerFunc(h.handlerWrapper.Wrap(h.getHandler))
+	router.Path(ServiceRouteBindingPath).Methods("DELETE").HandlerFunc(h.handlerWrapper.Wrap(h.deleteHandler))
 }
@@ -1,53 +1,398 @@
 package handlers_test
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"strings"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "code.cloudfoundry.org/korifi/api/handlers"
 
-	apis "code.cloudfoundry.org/korifi/api/handlers"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
 
-var _ = Describe("ServiceRouteBinding", func() {
-	Describe("the GET /v3/service_route_binding endpoint", func() {
+var _ = Describe("ServiceRouteBindingHandler", func() {
+	const (
+		routeGUID               = "test-route-guid"
+		serviceRouteBindingGUID = "test-service-route-binding-guid"
+		serviceInstanceGUID     = "test-service-instance-guid"
+		spaceGUID               = "test-space-guid"
+		routeServiceURL         = "https://route-service.example.com"
+	)
+
+	var (
+		req                     *http.Request
+		serviceRouteBindingRepo *fake.CFServiceRouteBindingRepository
+		routeRepo               *fake.CFRouteRepository
+		serviceInstanceRepo     *fake.CFServiceInstanceRepository
+		routeBindingRecord      repositories.ServiceRouteBindingRecord
+	)
+
+	BeforeEach(func() {
+		serviceRouteBindingRepo = new(fake.CFServiceRouteBindingRepository)
+		routeRepo = new(fake.CFRouteRepository)
+		serviceInstanceRepo = new(fake.CFServiceInstanceRepository)
+		decoderValidator, err := NewDefaultDecoderValidator()
+		Expect(err).NotTo(HaveOccurred())
+
+		routeBindingRecord = repositories.ServiceRouteBindingRecord{
+			GUID:                serviceRouteBindingGUID,
+			RouteGUID:           routeGUID,
+			ServiceInstanceGUID: serviceInstanceGUID,
+			SpaceGUID:           spaceGUID,
+			RouteServiceURL:     tools.PtrTo(routeServiceURL),
+			CreatedAt:           "2019-05-10T17:17:48Z",
+			UpdatedAt:           "2019-05-10T17:17:48Z",
+		}
+
+		handler := NewServiceRouteBindingHandler(
+			*serverURL,
+			serviceRouteBindingRepo,
+			routeRepo,
+			serviceInstanceRepo,
+			decoderValidator,
+		)
+		handler.RegisterRoutes(router)
+	})
+
+	JustBeforeEach(func() {
+		router.ServeHTTP(rr, req)
+	})
+
+	Describe("the POST /v3/service_route_bindings endpoint", func() {
 		BeforeEach(func() {
-			req, err := http.NewRequestWithContext(ctx, "GET", "/v3/service_route_bindings", nil)
+			routeRepo.GetRouteReturns(repositories.RouteRecord{
+				GUID:      routeGUID,
+				SpaceGUID: spaceGUID,
+			}, nil)
+
+			serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+				GUID:            serviceInstanceGUID,
+				SpaceGUID:       spaceGUID,
+				RouteServiceURL: tools.PtrTo(routeServiceURL),
+			}, nil)
+
+			serviceRouteBindingRepo.CreateServiceRouteBindingReturns(routeBindingRecord, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/service_route_bindings", strings.NewReader(fmt.Sprintf(`{
+				"relationships": {
+					"route": {
+						"data": {
+							"guid": %q
+						}
+					},
+					"service_instance": {
+						"data": {
+							"guid": %q
+						}
+					}
+				},
+				"metadata": {
+					"labels": {"foo": "bar"}
+				}
+			}`, routeGUID, serviceInstanceGUID)))
 			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("creates the service route binding", func() {
+			Expect(serviceRouteBindingRepo.CreateServiceRouteBindingCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := serviceRouteBindingRepo.CreateServiceRouteBindingArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.CreateServiceRouteBindingMessage{
+				RouteGUID:           routeGUID,
+				ServiceInstanceGUID: serviceInstanceGUID,
+				SpaceGUID:           spaceGUID,
+				Labels:              map[string]string{"foo": "bar"},
+			}))
+		})
+
+		It("returns the created service route binding", func() {
+			Expect(rr.Code).To(Equal(http.StatusCreated))
+			Expect(rr.Header().Get("Content-Type")).To(Equal(jsonHeader))
+			Expect(rr.Body.String()).To(MatchJSON(fmt.Sprintf(`{
+				"guid": %[2]q,
+				"route_service_url": %[3]q,
+				"created_at": "2019-05-10T17:17:48Z",
+				"updated_at": "2019-05-10T17:17:48Z",
+				"last_operation": {
+					"type": "create",
+					"state": "succeeded",
+					"description": "Operation succeeded",
+					"created_at": "2019-05-10T17:17:48Z",
+					"updated_at": "2019-05-10T17:17:48Z"
+				},
+				"metadata": {
+					"labels": {},
+					"annotations": {}
+				},
+				"relationships": {
+					"route": {
+						"data": {
+							"guid": %[4]q
+						}
+					},
+					"service_instance": {
+						"data": {
+							"guid": %[5]q
+						}
+					}
+				},
+				"links": {
+					"self": {
+						"href": "%[1]s/v3/service_route_bindings/%[2]s"
+					},
+					"service_instance": {
+						"href": "%[1]s/v3/service_instances/%[5]s"
+					},
+					"route": {
+						"href": "%[1]s/v3/routes/%[4]s"
+					},
+					"parameters": {
+						"href": "%[1]s/v3/service_route_bindings/%[2]s/parameters"
+					}
+				}
+			}`, defaultServerURL, serviceRouteBindingGUID, routeServiceURL, routeGUID, serviceInstanceGUID)))
+		})
 
-			apiHandler := apis.NewServiceRouteBindingHandler(
-				*serverURL)
+		When("the request body is invalid json", func() {
+			BeforeEach(func() {
+				req.Body = io.NopCloser(strings.NewReader(`{"description"`))
+			})
 
-			apiHandler.RegisterRoutes(router)
+			It("returns an error", func() {
+				expectBadRequestError()
+			})
 
-			router.ServeHTTP(rr, req)
+			It("doesn't create the service route binding", func() {
+				Expect(serviceRouteBindingRepo.CreateServiceRouteBindingCallCount()).To(Equal(0))
+			})
 		})
 
-		It("returns status 200 OK", func() {
-			Expect(rr.Code).To(Equal(http.StatusOK), "Matching HTTP response code:")
+		When("the route relationship is missing", func() {
+			BeforeEach(func() {
+				req.Body = io.NopCloser(strings.NewReader(fmt.Sprintf(`{
+					"relationships": {
+						"service_instance": {
+							"data": {
+								"guid": %q
+							}
+						}
+					}
+				}`, serviceInstanceGUID)))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Route is a required field")
+			})
 		})
 
-		It("returns Content-Type as JSON in header", func() {
-			contentTypeHeader := rr.Header().Get("Content-Type")
-			Expect(contentTypeHeader).To(Equal(jsonHeader), "Matching Content-Type header:")
+		When("the route does not exist", func() {
+			BeforeEach(func() {
+				routeRepo.GetRouteReturns(repositories.RouteRecord{}, apierrors.NewNotFoundError(nil, repositories.RouteResourceType))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Unable to use route. Ensure that the route exists and you have access to it.")
+			})
+
+			It("doesn't create the service route binding", func() {
+				Expect(serviceRouteBindingRepo.CreateServiceRouteBindingCallCount()).To(Equal(0))
+			})
 		})
 
-		It("matches the expected response body format", func() {
-			Expect(rr.Body.String()).To(MatchJSON(fmt.Sprintf(`{
-				"pagination": {
-				  "total_results": 0,
-				  "total_pages": 1,
-				  "first": {
-					"href": "%[1]s/v3/service_route_bindings"
-				  },
-				  "last": {
-					"href": "%[1]s/v3/service_route_bindings"
-				  },
-				  "next": null,
-				  "previous": null
-				},
-				"resources": []
-			}`, defaultServerURL)), "Response body matches LogCacheHandler response:")
+		When("the service instance does not exist", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{}, apierrors.NewForbiddenError(nil, repositories.ServiceInstanceResourceType))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Unable to use service instance. Ensure that the service instance exists and you have access to it.")
+			})
+
+			It("doesn't create the service route binding", func() {
+				Expect(serviceRouteBindingRepo.CreateServiceRouteBindingCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the route and the service instance are in different spaces", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+					GUID:            serviceInstanceGUID,
+					SpaceGUID:       "another-space-guid",
+					RouteServiceURL: tools.PtrTo(routeServiceURL),
+				}, nil)
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("The service instance and the route are in different spaces.")
+			})
+
+			It("doesn't create the service route binding", func() {
+				Expect(serviceRouteBindingRepo.CreateServiceRouteBindingCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the service instance does not have a route service url", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+					GUID:      serviceInstanceGUID,
+					SpaceGUID: spaceGUID,
+				}, nil)
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("This service instance does not support route binding.")
+			})
+
+			It("doesn't create the service route binding", func() {
+				Expect(serviceRouteBindingRepo.CreateServiceRouteBindingCallCount()).To(Equal(0))
+			})
+		})
+
+		When("creating the service route binding errors", func() {
+			BeforeEach(func() {
+				serviceRouteBindingRepo.CreateServiceRouteBindingReturns(repositories.ServiceRouteBindingRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("the GET /v3/service_route_bindings/:guid endpoint", func() {
+		BeforeEach(func() {
+			serviceRouteBindingRepo.GetServiceRouteBindingReturns(routeBindingRecord, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/service_route_bindings/"+serviceRouteBindingGUID, nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the service route binding", func() {
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(rr.Body.String()).To(ContainSubstring(serviceRouteBindingGUID))
+
+			Expect(serviceRouteBindingRepo.GetServiceRouteBindingCallCount()).To(Equal(1))
+			_, _, guid := serviceRouteBindingRepo.GetServiceRouteBindingArgsForCall(0)
+			Expect(guid).To(Equal(serviceRouteBindingGUID))
+		})
+
+		When("the user is not authorized to get the service route binding", func() {
+			BeforeEach(func() {
+				serviceRouteBindingRepo.GetServiceRouteBindingReturns(repositories.ServiceRouteBindingRecord{}, apierrors.NewForbiddenError(nil, repositories.ServiceRouteBindingResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Service Route Binding not found")
+			})
+		})
+	})
+
+	Describe("the GET /v3/service_route_bindings endpoint", func() {
+		BeforeEach(func() {
+			serviceRouteBindingRepo.ListServiceRouteBindingsReturns([]repositories.ServiceRouteBindingRecord{routeBindingRecord}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/service_route_bindings", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the service route bindings available to the user", func() {
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(rr.Body.String()).To(ContainSubstring(serviceRouteBindingGUID))
+		})
+
+		When("there are no service route bindings", func() {
+			BeforeEach(func() {
+				serviceRouteBindingRepo.ListServiceRouteBindingsReturns([]repositories.ServiceRouteBindingRecord{}, nil)
+			})
+
+			It("returns a CF API formatted empty resource list", func() {
+				Expect(rr.Code).To(Equal(http.StatusOK))
+				Expect(rr.Header().Get("Content-Type")).To(Equal(jsonHeader))
+				Expect(rr.Body.String()).To(MatchJSON(fmt.Sprintf(`{
+					"pagination": {
+						"total_results": 0,
+						"total_pages": 1,
+						"first": {
+							"href": "%[1]s/v3/service_route_bindings"
+						},
+						"last": {
+							"href": "%[1]s/v3/service_route_bindings"
+						},
+						"next": null,
+						"previous": null
+					},
+					"resources": []
+				}`, defaultServerURL)))
+			})
+		})
+
+		When("filtering query parameters are provided", func() {
+			BeforeEach(func() {
+				req.URL.RawQuery = "route_guids=r1,r2&service_instance_guids=s1"
+			})
+
+			It("passes them to the repository", func() {
+				Expect(serviceRouteBindingRepo.ListServiceRouteBindingsCallCount()).To(Equal(1))
+				_, _, message := serviceRouteBindingRepo.ListServiceRouteBindingsArgsForCall(0)
+				Expect(message.RouteGUIDs).To(ConsistOf("r1", "r2"))
+				Expect(message.ServiceInstanceGUIDs).To(ConsistOf("s1"))
+			})
+		})
+
+		When("invalid query parameters are provided", func() {
+			BeforeEach(func() {
+				req.URL.RawQuery = "foo=bar"
+			})
+
+			It("returns an Unknown key error", func() {
+				expectUnknownKeyError("The query parameter is invalid: Valid parameters are: 'route_guids, service_instance_guids, per_page'")
+			})
+		})
+
+		When("listing the service route bindings errors", func() {
+			BeforeEach(func() {
+				serviceRouteBindingRepo.ListServiceRouteBindingsReturns(nil, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("the DELETE /v3/service_route_bindings/:guid endpoint", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "DELETE", "/v3/service_route_bindings/"+serviceRouteBindingGUID, nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("deletes the service route binding", func() {
+			Expect(rr.Code).To(Equal(http.StatusNoContent))
+			Expect(serviceRouteBindingRepo.DeleteServiceRouteBindingCallCount()).To(Equal(1))
+			_, _, guid := serviceRouteBindingRepo.DeleteServiceRouteBindingArgsForCall(0)
+			Expect(guid).To(Equal(serviceRouteBindingGUID))
+		})
+
+		When("deleting the service route binding errors", func() {
+			BeforeEach(func() {
+				serviceRouteBindingRepo.DeleteServiceRouteBindingReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
 		})
 	})
 })
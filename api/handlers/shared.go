@@ -11,6 +11,8 @@ import (
 
 	"code.cloudfoundry.org/korifi/api/apierrors"
 	"code.cloudfoundry.org/korifi/api/payloads"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/securitygroups"
 
 	"code.cloudfoundry.org/bytefmt"
 	"github.com/go-playground/locales/en"
@@ -139,6 +141,17 @@ func wireValidator() (*validator.Validate, ut.Translator, error) {
 	v.RegisterStructValidation(checkDiskQuotaUnderscoreAndHyphenProc, payloads.ManifestApplicationProcess{})
 
 	v.RegisterStructValidation(checkRoleTypeAndOrgSpace, payloads.RoleCreate{})
+	v.RegisterStructValidation(checkSecurityGroupRule, payloads.SecurityGroupRule{})
+
+	err = v.RegisterTranslation("security_group_rule", trans, func(ut ut.Translator) error {
+		return ut.Add("security_group_rule", "Rules are invalid: {0}", false)
+	}, func(ut ut.Translator, fe validator.FieldError) string {
+		t, _ := ut.T("security_group_rule", fe.Param())
+		return t
+	})
+	if err != nil {
+		return nil, nil, err
+	}
 
 	err = v.RegisterTranslation("cannot_have_both_org_and_space_set", trans, func(ut ut.Translator) error {
 		return ut.Add("cannot_have_both_org_and_space_set", "Cannot pass both 'organization' and 'space' in a create role request", false)
@@ -150,6 +163,16 @@ func wireValidator() (*validator.Validate, ut.Translator, error) {
 		return nil, nil, err
 	}
 
+	err = v.RegisterTranslation("global_role_cannot_have_org_or_space", trans, func(ut ut.Translator) error {
+		return ut.Add("global_role_cannot_have_org_or_space", "Cannot pass 'organization' or 'space' for the {0} role", false)
+	}, func(ut ut.Translator, fe validator.FieldError) string {
+		t, _ := ut.T("global_role_cannot_have_org_or_space", fmt.Sprintf("%v", fe.Value()))
+		return t
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
 	err = v.RegisterTranslation("valid_role", trans, func(ut ut.Translator) error {
 		return ut.Add("valid_role", "{0} is not a valid role", false)
 	}, func(ut ut.Translator, fe validator.FieldError) string {
@@ -225,6 +248,19 @@ func checkDiskQuotaUnderscoreAndHyphenProc(sl validator.StructLevel) {
 	}
 }
 
+func checkSecurityGroupRule(sl validator.StructLevel) {
+	rule := sl.Current().Interface().(payloads.SecurityGroupRule)
+
+	// protocol and destination presence are reported by their own validations
+	if rule.Protocol == "" || rule.Destination == "" {
+		return
+	}
+
+	if err := securitygroups.ValidateRule(korifiv1alpha1.SecurityGroupRule(rule)); err != nil {
+		sl.ReportError(rule.Destination, "destination", "Destination", "security_group_rule", err.Error())
+	}
+}
+
 func checkRoleTypeAndOrgSpace(sl validator.StructLevel) {
 	roleCreate := sl.Current().Interface().(payloads.RoleCreate)
 
@@ -255,6 +291,14 @@ func checkRoleTypeAndOrgSpace(sl validator.StructLevel) {
 		if roleCreate.Relationships.Organization == nil {
 			sl.ReportError(roleCreate.Relationships.Organization, "relationships.organization", "Organization", "required", "")
 		}
+	case RoleAdmin:
+		fallthrough
+	case RoleAdminReadOnly:
+		fallthrough
+	case RoleGlobalAuditor:
+		if roleCreate.Relationships.Organization != nil || roleCreate.Relationships.Space != nil {
+			sl.ReportError(roleCreate.Type, "type", "Role type", "global_role_cannot_have_org_or_space", "")
+		}
 
 	case RoleName(""):
 	default:
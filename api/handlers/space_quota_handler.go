@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	"github.com/go-logr/logr"
+	"github.com/gorilla/mux"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	SpaceQuotasPath       = "/v3/space_quotas"
+	SpaceQuotaPath        = "/v3/space_quotas/{guid}"
+	SpaceQuotaSpacesPath  = "/v3/space_quotas/{guid}/relationships/spaces"
+	spacesNotInOrgMessage = "Spaces with guids %s do not exist within the organization specified, or you do not have access to them."
+)
+
+//counterfeiter:generate -o fake -fake-name SpaceQuotaRepository . SpaceQuotaRepository
+
+type SpaceQuotaRepository interface {
+	CreateSpaceQuota(context.Context, authorization.Info, repositories.CreateSpaceQuotaMessage) (repositories.SpaceQuotaRecord, error)
+	ListSpaceQuotas(context.Context, authorization.Info, repositories.ListSpaceQuotasMessage) ([]repositories.SpaceQuotaRecord, error)
+	GetSpaceQuota(context.Context, authorization.Info, string) (repositories.SpaceQuotaRecord, error)
+	ApplySpaceQuota(context.Context, authorization.Info, repositories.ApplySpaceQuotaMessage) (repositories.SpaceQuotaRecord, error)
+}
+
+type SpaceQuotaHandler struct {
+	handlerWrapper   *AuthAwareHandlerFuncWrapper
+	apiBaseURL       url.URL
+	spaceQuotaRepo   SpaceQuotaRepository
+	orgRepo          CFOrgRepository
+	spaceRepo        SpaceRepository
+	decoderValidator *DecoderValidator
+}
+
+func NewSpaceQuotaHandler(
+	apiBaseURL url.URL,
+	spaceQuotaRepo SpaceQuotaRepository,
+	orgRepo CFOrgRepository,
+	spaceRepo SpaceRepository,
+	decoderValidator *DecoderValidator,
+) *SpaceQuotaHandler {
+	return &SpaceQuotaHandler{
+		handlerWrapper:   NewAuthAwareHandlerFuncWrapper(ctrl.Log.WithName("SpaceQuotaHandler")),
+		apiBaseURL:       apiBaseURL,
+		spaceQuotaRepo:   spaceQuotaRepo,
+		orgRepo:          orgRepo,
+		spaceRepo:        spaceRepo,
+		decoderValidator: decoderValidator,
+	}
+}
+
+func (h *SpaceQuotaHandler) spaceQuotaCreateHandler(ctx context.Context, logger logr.Logger, authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	var payload payloads.SpaceQuotaCreate
+	if err := h.decoderValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	message := payload.ToMessage()
+	if _, err := h.orgRepo.GetOrg(ctx, authInfo, message.OrganizationGUID); err != nil {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.AsUnprocessableEntity(err, fmt.Sprintf(orgNotFoundOrForbiddenTmpl, message.OrganizationGUID), apierrors.NotFoundError{}, apierrors.ForbiddenError{}),
+			"Failed to fetch org", "orgGUID", message.OrganizationGUID,
+		)
+	}
+
+	if err := h.checkSpacesInOrg(ctx, logger, authInfo, message.OrganizationGUID, message.SpaceGUIDs); err != nil {
+		return nil, err
+	}
+
+	quota, err := h.spaceQuotaRepo.CreateSpaceQuota(ctx, authInfo, message)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to create space quota", "name", message.Name)
+	}
+
+	return NewHandlerResponse(http.StatusCreated).WithBody(presenter.ForSpaceQuota(quota, h.apiBaseURL)), nil
+}
+
+func (h *SpaceQuotaHandler) spaceQuotaListHandler(ctx context.Context, logger logr.Logger, authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to parse request query parameters")
+	}
+
+	listFilter := new(payloads.SpaceQuotaList)
+	if err := payloads.Decode(listFilter, r.Form); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	quotas, err := h.spaceQuotaRepo.ListSpaceQuotas(ctx, authInfo, listFilter.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to list space quotas")
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForSpaceQuotaList(quotas, h.apiBaseURL, *r.URL)), nil
+}
+
+func (h *SpaceQuotaHandler) spaceQuotaGetHandler(ctx context.Context, logger logr.Logger, authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	quotaGUID := mux.Vars(r)["guid"]
+
+	quota, err := h.spaceQuotaRepo.GetSpaceQuota(ctx, authInfo, quotaGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to get space quota", "guid", quotaGUID)
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForSpaceQuota(quota, h.apiBaseURL)), nil
+}
+
+func (h *SpaceQuotaHandler) spaceQuotaApplyHandler(ctx context.Context, logger logr.Logger, authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	quotaGUID := mux.Vars(r)["guid"]
+
+	var payload payloads.SpaceQuotaApply
+	if err := h.decoderValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	existing, err := h.spaceQuotaRepo.GetSpaceQuota(ctx, authInfo, quotaGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to get space quota", "guid", quotaGUID)
+	}
+
+	message := payload.ToMessage(quotaGUID)
+	if err = h.checkSpacesInOrg(ctx, logger, authInfo, existing.OrganizationGUID, message.SpaceGUIDs); err != nil {
+		return nil, err
+	}
+
+	quota, err := h.spaceQuotaRepo.ApplySpaceQuota(ctx, authInfo, message)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to apply space quota", "guid", quotaGUID)
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForSpaceQuotaSpaces(quota, h.apiBaseURL)), nil
+}
+
+// checkSpacesInOrg ensures that a space quota is only applied to visible spaces of the org owning it
+func (h *SpaceQuotaHandler) checkSpacesInOrg(ctx context.Context, logger logr.Logger, authInfo authorization.Info, orgGUID string, spaceGUIDs []string) error {
+	var invalidGUIDs []string
+	for _, spaceGUID := range spaceGUIDs {
+		space, err := h.spaceRepo.GetSpace(ctx, authInfo, spaceGUID)
+		if err != nil || space.OrganizationGUID != orgGUID {
+			invalidGUIDs = append(invalidGUIDs, spaceGUID)
+		}
+	}
+
+	if len(invalidGUIDs) == 0 {
+		return nil
+	}
+
+	guids, _ := json.Marshal(invalidGUIDs)
+	return apierrors.LogAndReturn(
+		logger,
+		apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf(spacesNotInOrgMessage, guids)),
+		"Spaces are not in the org of the space quota", "orgGUID", orgGUID, "spaceGUIDs", invalidGUIDs,
+	)
+}
+
+func (h *SpaceQuotaHandler) RegisterRoutes(router *mux.Router) {
+	router.Path(SpaceQuotasPath).Methods("POST").HandlerFunc(h.handlerWrapper.Wrap(h.spaceQuotaCreateHandler))
+	router.Path(SpaceQuotasPath).Methods("GET").HandlerFunc(h.handlerWrapper.Wrap(h.spaceQuotaListHandler))
+	router.Path(SpaceQuotaPath).Methods("GET").HandlerFunc(h.handlerWrapper.Wrap(h.spaceQuotaGetHandler))
+	router.Path(SpaceQuotaSpacesPath).Methods("POST").HandlerFunc(h.handlerWrapper.Wrap(h.spaceQuotaApplyHandler))
+}
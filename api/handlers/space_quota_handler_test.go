@@ -0,0 +1,232 @@
+package handlers_test
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	apis "code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SpaceQuotaHandler", func() {
+	var (
+		spaceQuotaRepo *fake.SpaceQuotaRepository
+		orgRepo        *fake.OrgRepository
+		spaceRepo      *fake.SpaceRepository
+		quotaRecord    repositories.SpaceQuotaRecord
+	)
+
+	BeforeEach(func() {
+		spaceQuotaRepo = new(fake.SpaceQuotaRepository)
+		orgRepo = new(fake.OrgRepository)
+		spaceRepo = new(fake.SpaceRepository)
+		decoderValidator, err := apis.NewDefaultDecoderValidator()
+		Expect(err).NotTo(HaveOccurred())
+
+		quotaRecord = repositories.SpaceQuotaRecord{
+			GUID: "quota-guid",
+			Name: "my-quota",
+			Limits: repositories.QuotaLimits{
+				PerProcessMemoryInMB: tools.PtrTo(int64(512)),
+			},
+			OrganizationGUID: "org-guid",
+			SpaceGUIDs:       []string{"space-guid"},
+			CreatedAt:        "2022-11-01T10:00:00Z",
+			UpdatedAt:        "2022-11-01T11:00:00Z",
+		}
+		spaceQuotaRepo.CreateSpaceQuotaReturns(quotaRecord, nil)
+		spaceQuotaRepo.GetSpaceQuotaReturns(quotaRecord, nil)
+		spaceQuotaRepo.ListSpaceQuotasReturns([]repositories.SpaceQuotaRecord{quotaRecord}, nil)
+		spaceQuotaRepo.ApplySpaceQuotaReturns(quotaRecord, nil)
+		spaceRepo.GetSpaceReturns(repositories.SpaceRecord{GUID: "space-guid", OrganizationGUID: "org-guid"}, nil)
+
+		apis.NewSpaceQuotaHandler(*serverURL, spaceQuotaRepo, orgRepo, spaceRepo, decoderValidator).RegisterRoutes(router)
+	})
+
+	serveRequest := func(method, path, body string) {
+		req, err := http.NewRequestWithContext(ctx, method, path, strings.NewReader(body))
+		Expect(err).NotTo(HaveOccurred())
+
+		router.ServeHTTP(rr, req)
+	}
+
+	quotaJSON := func() string {
+		return fmt.Sprintf(`{
+            "guid": "quota-guid",
+            "created_at": "2022-11-01T10:00:00Z",
+            "updated_at": "2022-11-01T11:00:00Z",
+            "name": "my-quota",
+            "apps": {
+                "total_memory_in_mb": null,
+                "per_process_memory_in_mb": 512,
+                "total_instances": null,
+                "per_app_tasks": null,
+                "log_rate_limit_in_bytes_per_second": null
+            },
+            "services": {
+                "paid_services_allowed": true,
+                "total_service_instances": null,
+                "total_service_keys": null
+            },
+            "routes": {
+                "total_routes": null,
+                "total_reserved_ports": null
+            },
+            "relationships": {
+                "organization": {"data": {"guid": "org-guid"}},
+                "spaces": {"data": [{"guid": "space-guid"}]}
+            },
+            "links": {
+                "self": {"href": "%s/v3/space_quotas/quota-guid"}
+            }
+        }`, defaultServerURL)
+	}
+
+	Describe("POST /v3/space_quotas", func() {
+		It("creates the quota", func() {
+			serveRequest("POST", "/v3/space_quotas", `{
+                "name": "my-quota",
+                "apps": {"per_process_memory_in_mb": 512},
+                "relationships": {
+                    "organization": {"data": {"guid": "org-guid"}},
+                    "spaces": {"data": [{"guid": "space-guid"}]}
+                }
+            }`)
+
+			expectJSONResponse(http.StatusCreated, quotaJSON())
+
+			_, _, orgGUID := orgRepo.GetOrgArgsForCall(0)
+			Expect(orgGUID).To(Equal("org-guid"))
+			_, _, spaceGUID := spaceRepo.GetSpaceArgsForCall(0)
+			Expect(spaceGUID).To(Equal("space-guid"))
+
+			_, _, message := spaceQuotaRepo.CreateSpaceQuotaArgsForCall(0)
+			Expect(message).To(Equal(repositories.CreateSpaceQuotaMessage{
+				Name:             "my-quota",
+				Limits:           repositories.QuotaLimits{PerProcessMemoryInMB: tools.PtrTo(int64(512))},
+				OrganizationGUID: "org-guid",
+				SpaceGUIDs:       []string{"space-guid"},
+			}))
+		})
+
+		When("the organization relationship is missing", func() {
+			It("returns an unprocessable entity error", func() {
+				serveRequest("POST", "/v3/space_quotas", `{"name": "my-quota", "relationships": {}}`)
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusUnprocessableEntity))
+				Expect(spaceQuotaRepo.CreateSpaceQuotaCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the org does not exist", func() {
+			BeforeEach(func() {
+				orgRepo.GetOrgReturns(repositories.OrgRecord{}, apierrors.NewNotFoundError(nil, repositories.OrgResourceType))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				serveRequest("POST", "/v3/space_quotas", `{"name": "my-quota", "relationships": {"organization": {"data": {"guid": "org-guid"}}}}`)
+
+				expectUnprocessableEntityError("Organization with guid 'org-guid' does not exist or you do not have access to it.")
+			})
+		})
+
+		When("a space belongs to another org", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{GUID: "space-guid", OrganizationGUID: "another-org"}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				serveRequest("POST", "/v3/space_quotas", `{
+                    "name": "my-quota",
+                    "relationships": {
+                        "organization": {"data": {"guid": "org-guid"}},
+                        "spaces": {"data": [{"guid": "space-guid"}]}
+                    }
+                }`)
+
+				expectUnprocessableEntityError(`Spaces with guids ["space-guid"] do not exist within the organization specified, or you do not have access to them.`)
+				Expect(spaceQuotaRepo.CreateSpaceQuotaCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("GET /v3/space_quotas", func() {
+		It("lists the quotas", func() {
+			serveRequest("GET", "/v3/space_quotas?space_guids=space-guid", "")
+
+			expectJSONResponse(http.StatusOK, fmt.Sprintf(`{
+                "pagination": {
+                    "total_results": 1,
+                    "total_pages": 1,
+                    "first": {"href": "%[1]s/v3/space_quotas?space_guids=space-guid"},
+                    "last": {"href": "%[1]s/v3/space_quotas?space_guids=space-guid"},
+                    "next": null,
+                    "previous": null
+                },
+                "resources": [%[2]s]
+            }`, defaultServerURL, quotaJSON()))
+
+			_, _, message := spaceQuotaRepo.ListSpaceQuotasArgsForCall(0)
+			Expect(message.SpaceGUIDs).To(Equal([]string{"space-guid"}))
+		})
+	})
+
+	Describe("GET /v3/space_quotas/{guid}", func() {
+		It("returns the quota", func() {
+			serveRequest("GET", "/v3/space_quotas/quota-guid", "")
+
+			expectJSONResponse(http.StatusOK, quotaJSON())
+		})
+
+		When("the quota is not accessible", func() {
+			BeforeEach(func() {
+				spaceQuotaRepo.GetSpaceQuotaReturns(repositories.SpaceQuotaRecord{}, apierrors.NewForbiddenError(nil, repositories.SpaceQuotaResourceType))
+			})
+
+			It("returns a not found error", func() {
+				serveRequest("GET", "/v3/space_quotas/quota-guid", "")
+
+				expectNotFoundError("Space Quota not found")
+			})
+		})
+	})
+
+	Describe("POST /v3/space_quotas/{guid}/relationships/spaces", func() {
+		It("applies the quota to the spaces", func() {
+			serveRequest("POST", "/v3/space_quotas/quota-guid/relationships/spaces", `{"data": [{"guid": "space-guid"}]}`)
+
+			expectJSONResponse(http.StatusOK, fmt.Sprintf(`{
+                "data": [{"guid": "space-guid"}],
+                "links": {
+                    "self": {"href": "%s/v3/space_quotas/quota-guid/relationships/spaces"}
+                }
+            }`, defaultServerURL))
+
+			_, _, message := spaceQuotaRepo.ApplySpaceQuotaArgsForCall(0)
+			Expect(message).To(Equal(repositories.ApplySpaceQuotaMessage{
+				GUID:       "quota-guid",
+				SpaceGUIDs: []string{"space-guid"},
+			}))
+		})
+
+		When("a space is not visible", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, apierrors.NewNotFoundError(nil, repositories.SpaceResourceType))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				serveRequest("POST", "/v3/space_quotas/quota-guid/relationships/spaces", `{"data": [{"guid": "space-guid"}]}`)
+
+				expectUnprocessableEntityError(`Spaces with guids ["space-guid"] do not exist within the organization specified, or you do not have access to them.`)
+				Expect(spaceQuotaRepo.ApplySpaceQuotaCallCount()).To(Equal(0))
+			})
+		})
+	})
+})
@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	"github.com/go-logr/logr"
+	"github.com/gorilla/mux"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	UsersPath      = "/v3/users"
+	UserPath       = "/v3/users/{guid}"
+	OrgUsersPath   = "/v3/organizations/{guid}/users"
+	SpaceUsersPath = "/v3/spaces/{guid}/users"
+)
+
+//counterfeiter:generate -o fake -fake-name UserRepository . UserRepository
+
+type UserRepository interface {
+	ListUsers(context.Context, authorization.Info, repositories.ListUsersMessage) ([]repositories.UserRecord, error)
+	GetUser(context.Context, authorization.Info, string) (repositories.UserRecord, error)
+}
+
+type UserHandler struct {
+	handlerWrapper *AuthAwareHandlerFuncWrapper
+	apiBaseURL     url.URL
+	userRepo       UserRepository
+	orgRepo        CFOrgRepository
+	spaceRepo      SpaceRepository
+}
+
+func NewUserHandler(apiBaseURL url.URL, userRepo UserRepository, orgRepo CFOrgRepository, spaceRepo SpaceRepository) *UserHandler {
+	return &UserHandler{
+		handlerWrapper: NewAuthAwareHandlerFuncWrapper(ctrl.Log.WithName("UserHandler")),
+		apiBaseURL:     apiBaseURL,
+		userRepo:       userRepo,
+		orgRepo:        orgRepo,
+		spaceRepo:      spaceRepo,
+	}
+}
+
+func (h *UserHandler) userListHandler(ctx context.Context, logger logr.Logger, authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	message, err := h.decodeListFilter(logger, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.listUsers(ctx, logger, authInfo, r, message)
+}
+
+func (h *UserHandler) userGetHandler(ctx context.Context, logger logr.Logger, authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	userGUID := mux.Vars(r)["guid"]
+
+	user, err := h.userRepo.GetUser(ctx, authInfo, userGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch user", "UserGUID", userGUID)
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForUser(user, h.apiBaseURL)), nil
+}
+
+func (h *UserHandler) orgUserListHandler(ctx context.Context, logger logr.Logger, authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	orgGUID := mux.Vars(r)["guid"]
+
+	message, err := h.decodeListFilter(logger, r)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = h.orgRepo.GetOrg(ctx, authInfo, orgGUID); err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch org", "OrgGUID", orgGUID)
+	}
+
+	message.OrgGUIDs = []string{orgGUID}
+
+	return h.listUsers(ctx, logger, authInfo, r, message)
+}
+
+func (h *UserHandler) spaceUserListHandler(ctx context.Context, logger logr.Logger, authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	spaceGUID := mux.Vars(r)["guid"]
+
+	message, err := h.decodeListFilter(logger, r)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = h.spaceRepo.GetSpace(ctx, authInfo, spaceGUID); err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch space", "SpaceGUID", spaceGUID)
+	}
+
+	message.SpaceGUIDs = []string{spaceGUID}
+
+	return h.listUsers(ctx, logger, authInfo, r, message)
+}
+
+func (h *UserHandler) decodeListFilter(logger logr.Logger, r *http.Request) (repositories.ListUsersMessage, error) {
+	if err := r.ParseForm(); err != nil {
+		return repositories.ListUsersMessage{}, apierrors.LogAndReturn(logger, apierrors.NewUnprocessableEntityError(err, "unable to parse query"), "Unable to parse request query parameters")
+	}
+
+	listFilter := new(payloads.UserList)
+	if err := payloads.Decode(listFilter, r.Form); err != nil {
+		return repositories.ListUsersMessage{}, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	return listFilter.ToMessage(), nil
+}
+
+func (h *UserHandler) listUsers(ctx context.Context, logger logr.Logger, authInfo authorization.Info, r *http.Request, message repositories.ListUsersMessage) (*HandlerResponse, error) {
+	users, err := h.userRepo.ListUsers(ctx, authInfo, message)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to list users")
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForUserList(users, h.apiBaseURL, *r.URL)), nil
+}
+
+func (h *UserHandler) RegisterRoutes(router *mux.Router) {
+	router.Path(UsersPath).Methods("GET").HandlerFunc(h.handlerWrapper.Wrap(h.userListHandler))
+	router.Path(UserPath).Methods("GET").HandlerFunc(h.handlerWrapper.Wrap(h.userGetHandler))
+	router.Path(OrgUsersPath).Methods("GET").HandlerFunc(h.handlerWrapper.Wrap(h.orgUserListHandler))
+	router.Path(SpaceUsersPath).Methods("GET").HandlerFunc(h.handlerWrapper.Wrap(h.spaceUserListHandler))
+}
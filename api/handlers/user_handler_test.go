@@ -0,0 +1,224 @@
+package handlers_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	apis "code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+var _ = Describe("UserHandler", func() {
+	var (
+		userRepo  *fake.UserRepository
+		orgRepo   *fake.OrgRepository
+		spaceRepo *fake.SpaceRepository
+	)
+
+	BeforeEach(func() {
+		userRepo = new(fake.UserRepository)
+		orgRepo = new(fake.OrgRepository)
+		spaceRepo = new(fake.SpaceRepository)
+
+		userRepo.ListUsersReturns([]repositories.UserRecord{
+			{GUID: "alice", Name: "alice", Kind: rbacv1.UserKind, Origin: repositories.UserOriginKubernetes},
+			{GUID: "kubernetes-service-account:robot", Name: "robot", Kind: rbacv1.ServiceAccountKind, Origin: repositories.UserOriginServiceAccount},
+		}, nil)
+
+		apis.NewUserHandler(*serverURL, userRepo, orgRepo, spaceRepo).RegisterRoutes(router)
+	})
+
+	serveRequest := func(path string) {
+		req, err := http.NewRequestWithContext(ctx, "GET", path, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		router.ServeHTTP(rr, req)
+	}
+
+	expectUserList := func(path string) {
+		Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+		Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+		Expect(rr).To(HaveHTTPBody(MatchJSON(fmt.Sprintf(`{
+            "pagination": {
+                "total_results": 2,
+                "total_pages": 1,
+                "first": {"href": "%[1]s%[2]s"},
+                "last": {"href": "%[1]s%[2]s"},
+                "next": null,
+                "previous": null
+            },
+            "resources": [
+                {
+                    "guid": "alice",
+                    "created_at": "",
+                    "updated_at": "",
+                    "username": "alice",
+                    "presentation_name": "alice",
+                    "origin": "kubernetes",
+                    "metadata": {"labels": {}, "annotations": {}},
+                    "links": {"self": {"href": "%[1]s/v3/users/alice"}}
+                },
+                {
+                    "guid": "kubernetes-service-account:robot",
+                    "created_at": "",
+                    "updated_at": "",
+                    "username": "robot",
+                    "presentation_name": "robot",
+                    "origin": "kubernetes-service-account",
+                    "metadata": {"labels": {}, "annotations": {}},
+                    "links": {"self": {"href": "%[1]s/v3/users/kubernetes-service-account:robot"}}
+                }
+            ]
+        }`, defaultServerURL, path))))
+	}
+
+	Describe("GET /v3/users", func() {
+		It("lists the users", func() {
+			serveRequest("/v3/users")
+
+			expectUserList("/v3/users")
+			Expect(userRepo.ListUsersCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := userRepo.ListUsersArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.OrgGUIDs).To(BeEmpty())
+			Expect(message.SpaceGUIDs).To(BeEmpty())
+		})
+
+		It("passes the filters to the repository", func() {
+			serveRequest("/v3/users?guids=g1,g2&usernames=u1&origins=kubernetes&role_types=space_developer")
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			_, _, message := userRepo.ListUsersArgsForCall(0)
+			Expect(message).To(Equal(repositories.ListUsersMessage{
+				GUIDs:     []string{"g1", "g2"},
+				Names:     []string{"u1"},
+				Origins:   []string{"kubernetes"},
+				RoleTypes: []string{"space_developer"},
+			}))
+		})
+
+		When("an unknown query parameter is passed", func() {
+			It("returns an error", func() {
+				serveRequest("/v3/users?foo=bar")
+
+				expectUnknownKeyError("The query parameter is invalid: Valid parameters are: 'guids, usernames, origins, role_types'")
+			})
+		})
+
+		When("listing the users fails", func() {
+			BeforeEach(func() {
+				userRepo.ListUsersReturns(nil, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				serveRequest("/v3/users")
+
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/users/{guid}", func() {
+		BeforeEach(func() {
+			userRepo.GetUserReturns(repositories.UserRecord{
+				GUID:   "alice",
+				Name:   "alice",
+				Kind:   rbacv1.UserKind,
+				Origin: repositories.UserOriginKubernetes,
+			}, nil)
+		})
+
+		It("returns the user", func() {
+			serveRequest("/v3/users/alice")
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSON(fmt.Sprintf(`{
+                "guid": "alice",
+                "created_at": "",
+                "updated_at": "",
+                "username": "alice",
+                "presentation_name": "alice",
+                "origin": "kubernetes",
+                "metadata": {"labels": {}, "annotations": {}},
+                "links": {"self": {"href": "%s/v3/users/alice"}}
+            }`, defaultServerURL))))
+
+			_, _, guid := userRepo.GetUserArgsForCall(0)
+			Expect(guid).To(Equal("alice"))
+		})
+
+		When("the user is not found", func() {
+			BeforeEach(func() {
+				userRepo.GetUserReturns(repositories.UserRecord{}, apierrors.NewNotFoundError(nil, repositories.UserResourceType))
+			})
+
+			It("returns a not found error", func() {
+				serveRequest("/v3/users/alice")
+
+				expectNotFoundError("User not found")
+			})
+		})
+	})
+
+	Describe("GET /v3/organizations/{guid}/users", func() {
+		It("lists the users with a role in the org", func() {
+			serveRequest("/v3/organizations/my-org/users?role_types=organization_manager")
+
+			expectUserList("/v3/organizations/my-org/users?role_types=organization_manager")
+
+			Expect(orgRepo.GetOrgCallCount()).To(Equal(1))
+			_, _, orgGUID := orgRepo.GetOrgArgsForCall(0)
+			Expect(orgGUID).To(Equal("my-org"))
+
+			_, _, message := userRepo.ListUsersArgsForCall(0)
+			Expect(message.OrgGUIDs).To(Equal([]string{"my-org"}))
+			Expect(message.RoleTypes).To(Equal([]string{"organization_manager"}))
+		})
+
+		When("the org is not accessible", func() {
+			BeforeEach(func() {
+				orgRepo.GetOrgReturns(repositories.OrgRecord{}, apierrors.NewForbiddenError(nil, repositories.OrgResourceType))
+			})
+
+			It("returns a not found error", func() {
+				serveRequest("/v3/organizations/my-org/users")
+
+				expectNotFoundError("Org not found")
+				Expect(userRepo.ListUsersCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("GET /v3/spaces/{guid}/users", func() {
+		It("lists the users with a role in the space", func() {
+			serveRequest("/v3/spaces/my-space/users")
+
+			expectUserList("/v3/spaces/my-space/users")
+
+			Expect(spaceRepo.GetSpaceCallCount()).To(Equal(1))
+			_, _, spaceGUID := spaceRepo.GetSpaceArgsForCall(0)
+			Expect(spaceGUID).To(Equal("my-space"))
+
+			_, _, message := userRepo.ListUsersArgsForCall(0)
+			Expect(message.SpaceGUIDs).To(Equal([]string{"my-space"}))
+		})
+
+		When("the space is not accessible", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, apierrors.NewNotFoundError(nil, repositories.SpaceResourceType))
+			})
+
+			It("returns a not found error", func() {
+				serveRequest("/v3/spaces/my-space/users")
+
+				expectNotFoundError("Space not found")
+			})
+		})
+	})
+})
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"log"
@@ -10,6 +11,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/korifi/api/actions"
@@ -109,9 +111,17 @@ func main() {
 
 	userClientFactory := authorization.NewUnprivilegedClientFactory(k8sClientConfig, mapper, authorization.NewDefaultBackoff())
 
-	identityProvider := wireIdentityProvider(privilegedCRClient, k8sClientConfig)
+	identityProvider, err := wireIdentityProvider(privilegedCRClient, k8sClientConfig, config.OIDC)
+	if err != nil {
+		panic(fmt.Sprintf("could not create identity provider: %v", err))
+	}
+
+	tokenRefresher, err := wireTokenRefresher(config.OIDC)
+	if err != nil {
+		panic(fmt.Sprintf("could not create token refresher: %v", err))
+	}
 	cachingIdentityProvider := authorization.NewCachingIdentityProvider(identityProvider, cache.NewExpiring())
-	nsPermissions := authorization.NewNamespacePermissions(privilegedCRClient, cachingIdentityProvider)
+	nsPermissions := authorization.NewNamespacePermissions(privilegedCRClient, cachingIdentityProvider, config.RootNamespace, config.GlobalClusterRoles())
 
 	serverURL, err := url.Parse(config.ServerURL)
 	if err != nil {
@@ -129,21 +139,31 @@ func main() {
 	cfAppConditionAwaiter := conditions.NewConditionAwaiter[*korifiv1alpha1.CFApp, korifiv1alpha1.CFAppList](createTimeout)
 	appRepo := repositories.NewAppRepo(namespaceRetriever, userClientFactory, nsPermissions, cfAppConditionAwaiter)
 	dropletRepo := repositories.NewDropletRepo(userClientFactory, namespaceRetriever, nsPermissions)
-	routeRepo := repositories.NewRouteRepo(namespaceRetriever, userClientFactory, nsPermissions)
-	domainRepo := repositories.NewDomainRepo(userClientFactory, namespaceRetriever, config.RootNamespace)
+	routeRepo := repositories.NewRouteRepo(namespaceRetriever, userClientFactory, nsPermissions, config.RootNamespace)
+	domainRepo := repositories.NewDomainRepo(userClientFactory, namespaceRetriever, nsPermissions, privilegedCRClient, config.RootNamespace)
+	routerGroupRepo := repositories.NewRouterGroupRepo(config.RouterGroups)
 	buildRepo := repositories.NewBuildRepo(namespaceRetriever, userClientFactory)
 	packageRepo := repositories.NewPackageRepo(userClientFactory, namespaceRetriever, nsPermissions)
-	serviceInstanceRepo := repositories.NewServiceInstanceRepo(namespaceRetriever, userClientFactory, nsPermissions)
+	serviceInstanceRepo := repositories.NewServiceInstanceRepo(namespaceRetriever, userClientFactory, nsPermissions, privilegedCRClient)
 	bindingConditionAwaiter := conditions.NewConditionAwaiter[*korifiv1alpha1.CFServiceBinding, korifiv1alpha1.CFServiceBindingList](createTimeout)
 	serviceBindingRepo := repositories.NewServiceBindingRepo(namespaceRetriever, userClientFactory, nsPermissions, bindingConditionAwaiter)
+	routeBindingConditionAwaiter := conditions.NewConditionAwaiter[*korifiv1alpha1.CFServiceRouteBinding, korifiv1alpha1.CFServiceRouteBindingList](createTimeout)
+	serviceRouteBindingRepo := repositories.NewServiceRouteBindingRepo(namespaceRetriever, userClientFactory, nsPermissions, routeBindingConditionAwaiter)
 	buildpackRepo := repositories.NewBuildpackRepository(config.BuilderName, userClientFactory, config.RootNamespace)
 	roleRepo := repositories.NewRoleRepo(
 		userClientFactory,
+		privilegedCRClient,
 		spaceRepo,
-		authorization.NewNamespacePermissions(privilegedCRClient, cachingIdentityProvider),
+		nsPermissions,
+		nsPermissions,
 		config.RootNamespace,
 		config.RoleMappings,
 	)
+	userRepo := repositories.NewUserRepo(roleRepo)
+	orgQuotaRepo := repositories.NewOrgQuotaRepo(userClientFactory, config.RootNamespace)
+	spaceQuotaRepo := repositories.NewSpaceQuotaRepo(userClientFactory, namespaceRetriever, nsPermissions)
+	securityGroupRepo := repositories.NewSecurityGroupRepo(userClientFactory, config.RootNamespace)
+	networkPolicyRepo := repositories.NewNetworkPolicyRepo(userClientFactory, nsPermissions)
 	registryCAPath, found := os.LookupEnv("REGISTRY_CA_FILE")
 	if !found {
 		registryCAPath = ""
@@ -185,6 +205,8 @@ func main() {
 		handlers.NewRootV3Handler(config.ServerURL),
 		handlers.NewRootHandler(
 			config.ServerURL,
+			config.OIDC != nil,
+			oidcPasscodeURL(config.OIDC),
 		),
 		handlers.NewResourceMatchesHandler(),
 		handlers.NewAppHandler(
@@ -204,10 +226,15 @@ func main() {
 			domainRepo,
 			appRepo,
 			spaceRepo,
+			routerGroupRepo,
 			decoderValidator,
 		),
 		handlers.NewServiceRouteBindingHandler(
 			*serverURL,
+			serviceRouteBindingRepo,
+			routeRepo,
+			serviceInstanceRepo,
+			decoderValidator,
 		),
 		handlers.NewPackageHandler(
 			*serverURL,
@@ -241,6 +268,11 @@ func main() {
 			*serverURL,
 			decoderValidator,
 			domainRepo,
+			orgRepo,
+			routerGroupRepo,
+		),
+		handlers.NewRouterGroupHandler(
+			routerGroupRepo,
 		),
 		handlers.NewJobHandler(
 			*serverURL,
@@ -274,6 +306,43 @@ func main() {
 		handlers.NewRoleHandler(
 			*serverURL,
 			roleRepo,
+			orgRepo,
+			spaceRepo,
+			decoderValidator,
+		),
+
+		handlers.NewUserHandler(
+			*serverURL,
+			userRepo,
+			orgRepo,
+			spaceRepo,
+		),
+
+		handlers.NewOrgQuotaHandler(
+			*serverURL,
+			orgQuotaRepo,
+			orgRepo,
+			decoderValidator,
+		),
+
+		handlers.NewSpaceQuotaHandler(
+			*serverURL,
+			spaceQuotaRepo,
+			orgRepo,
+			spaceRepo,
+			decoderValidator,
+		),
+
+		handlers.NewSecurityGroupHandler(
+			*serverURL,
+			securityGroupRepo,
+			spaceRepo,
+			decoderValidator,
+		),
+
+		handlers.NewNetworkPolicyHandler(
+			networkPolicyRepo,
+			appRepo,
 			decoderValidator,
 		),
 
@@ -308,6 +377,8 @@ func main() {
 
 		handlers.NewOAuthToken(
 			*serverURL,
+			cachingIdentityProvider,
+			tokenRefresher,
 		),
 	}
 
@@ -388,8 +459,98 @@ func main() {
 	}
 }
 
-func wireIdentityProvider(client client.Client, restConfig *rest.Config) authorization.IdentityProvider {
-	tokenReviewer := authorization.NewTokenReviewer(client)
+func wireIdentityProvider(client client.Client, restConfig *rest.Config, oidcConfig *config.OIDCConfig) (authorization.IdentityProvider, error) {
+	var tokenInspector authorization.TokenIdentityInspector = authorization.NewTokenReviewer(client)
 	certInspector := authorization.NewCertInspector(restConfig)
-	return authorization.NewCertTokenIdentityProvider(tokenReviewer, certInspector)
+
+	if oidcConfig != nil {
+		keySet, err := wireOIDCKeySet(oidcConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		tokenInspector = authorization.NewOIDCTokenInspector(authorization.OIDCConfig{
+			IssuerURL:      oidcConfig.IssuerURL,
+			ClientID:       oidcConfig.ClientID,
+			UsernameClaim:  oidcConfig.UsernameClaim,
+			UsernamePrefix: oidcConfig.UsernamePrefix,
+			GroupsClaim:    oidcConfig.GroupsClaim,
+			GroupsPrefix:   oidcConfig.GroupsPrefix,
+		}, keySet, tokenInspector)
+	}
+
+	return authorization.NewCertTokenIdentityProvider(tokenInspector, certInspector), nil
+}
+
+func wireOIDCKeySet(oidcConfig *config.OIDCConfig) (authorization.KeySet, error) {
+	if oidcConfig.JWKS != "" {
+		return authorization.ParseStaticKeySet([]byte(oidcConfig.JWKS))
+	}
+
+	httpClient, err := oidcHTTPClient(oidcConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return authorization.NewRemoteKeySet(oidcConfig.IssuerURL, httpClient), nil
+}
+
+// wireTokenRefresher returns nil unless tokens can be refreshed at an OIDC
+// issuer, in which case the token handler keeps serving placeholder tokens
+func wireTokenRefresher(oidcConfig *config.OIDCConfig) (handlers.TokenRefresher, error) {
+	if oidcConfig == nil || oidcConfig.JWKS != "" {
+		return nil, nil
+	}
+
+	httpClient, err := oidcHTTPClient(oidcConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	clientSecret, err := oidcClientSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	return authorization.NewOIDCTokenRefresher(oidcConfig.IssuerURL, oidcConfig.ClientID, clientSecret, httpClient), nil
+}
+
+// oidcClientSecret reads the client secret used to refresh tokens from the
+// file mounted from its Secret. Public clients have no secret.
+func oidcClientSecret() (string, error) {
+	clientSecretPath, found := os.LookupEnv("OIDC_CLIENT_SECRET_FILE")
+	if !found {
+		return "", nil
+	}
+
+	clientSecret, err := os.ReadFile(clientSecretPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read OIDC client secret: %w", err)
+	}
+
+	return strings.TrimSpace(string(clientSecret)), nil
+}
+
+func oidcHTTPClient(oidcConfig *config.OIDCConfig) (*http.Client, error) {
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	if oidcConfig.CACert != "" {
+		certPool := x509.NewCertPool()
+		if !certPool.AppendCertsFromPEM([]byte(oidcConfig.CACert)) {
+			return nil, errors.New("failed to parse OIDC CA certificate")
+		}
+		httpClient.Transport = &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: &tls.Config{RootCAs: certPool, MinVersion: tls.VersionTLS12},
+		}
+	}
+
+	return httpClient, nil
+}
+
+func oidcPasscodeURL(oidcConfig *config.OIDCConfig) string {
+	if oidcConfig == nil {
+		return ""
+	}
+
+	return oidcConfig.PasscodeURL
 }
@@ -119,3 +119,20 @@ func (a *AppPatch) ToMessage(appGUID, spaceGUID string) repositories.PatchAppMet
 		},
 	}
 }
+
+type AppFeaturePatch struct {
+	Enabled *bool `json:"enabled" validate:"required"`
+}
+
+func (a *AppFeaturePatch) ToMessage(appGUID, spaceGUID, featureName string) repositories.PatchAppFeaturesMessage {
+	message := repositories.PatchAppFeaturesMessage{
+		AppGUID:   appGUID,
+		SpaceGUID: spaceGUID,
+	}
+
+	if featureName == repositories.AppFeatureServiceBindingK8s {
+		message.ServiceBindingK8sEnabled = a.Enabled
+	}
+
+	return message
+}
@@ -12,7 +12,8 @@ type DestinationListCreate struct {
 type Destination struct {
 	App      *AppResource `json:"app" validate:"required"`
 	Port     *int         `json:"port"`
-	Protocol *string      `json:"protocol" validate:"omitempty,oneof=http1"`
+	Protocol *string      `json:"protocol" validate:"omitempty,oneof=http1 http2 tcp"`
+	Weight   *int         `json:"weight" validate:"omitempty,gte=1,lte=100"`
 }
 
 type AppResource struct {
@@ -25,7 +26,25 @@ type DestinationAppProcess struct {
 }
 
 func (dc DestinationListCreate) ToMessage(routeRecord repositories.RouteRecord) repositories.AddDestinationsToRouteMessage {
-	addDestinations := make([]repositories.DestinationMessage, 0, len(dc.Destinations))
+	return repositories.AddDestinationsToRouteMessage{
+		RouteGUID:            routeRecord.GUID,
+		SpaceGUID:            routeRecord.SpaceGUID,
+		ExistingDestinations: routeRecord.Destinations,
+		NewDestinations:      dc.toDestinationMessages(routeRecord),
+	}
+}
+
+func (dc DestinationListCreate) ToReplaceMessage(routeRecord repositories.RouteRecord) repositories.ReplaceRouteDestinationsMessage {
+	return repositories.ReplaceRouteDestinationsMessage{
+		RouteGUID:            routeRecord.GUID,
+		SpaceGUID:            routeRecord.SpaceGUID,
+		ExistingDestinations: routeRecord.Destinations,
+		NewDestinations:      dc.toDestinationMessages(routeRecord),
+	}
+}
+
+func (dc DestinationListCreate) toDestinationMessages(routeRecord repositories.RouteRecord) []repositories.DestinationMessage {
+	destinations := make([]repositories.DestinationMessage, 0, len(dc.Destinations))
 	for _, destination := range dc.Destinations {
 		processType := korifiv1alpha1.ProcessTypeWeb
 		if destination.App.Process != nil {
@@ -38,21 +57,21 @@ func (dc DestinationListCreate) ToMessage(routeRecord repositories.RouteRecord)
 		}
 
 		protocol := "http1"
+		if routeRecord.Protocol == "tcp" {
+			protocol = "tcp"
+		}
 		if destination.Protocol != nil {
 			protocol = *destination.Protocol
 		}
 
-		addDestinations = append(addDestinations, repositories.DestinationMessage{
+		destinations = append(destinations, repositories.DestinationMessage{
 			AppGUID:     destination.App.GUID,
 			ProcessType: processType,
 			Port:        port,
 			Protocol:    protocol,
+			Weight:      destination.Weight,
 		})
 	}
-	return repositories.AddDestinationsToRouteMessage{
-		RouteGUID:            routeRecord.GUID,
-		SpaceGUID:            routeRecord.SpaceGUID,
-		ExistingDestinations: routeRecord.Destinations,
-		NewDestinations:      addDestinations,
-	}
+
+	return destinations
 }
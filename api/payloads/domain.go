@@ -7,23 +7,62 @@ import (
 )
 
 type DomainCreate struct {
-	Name          string                  `json:"name" validate:"required"`
-	Internal      bool                    `json:"internal"`
-	Metadata      Metadata                `json:"metadata"`
-	Relationships map[string]Relationship `json:"relationships"`
+	Name          string              `json:"name" validate:"required"`
+	Internal      bool                `json:"internal"`
+	RouterGroup   *RelationshipData   `json:"router_group"`
+	Metadata      Metadata            `json:"metadata"`
+	Relationships DomainRelationships `json:"relationships"`
+}
+
+type DomainRelationships struct {
+	Organization        *Relationship              `json:"organization"`
+	SharedOrganizations *DomainSharedOrganizations `json:"shared_organizations"`
+}
+
+type DomainSharedOrganizations struct {
+	Data []RelationshipData `json:"data" validate:"dive"`
 }
 
 func (c *DomainCreate) ToMessage() (repositories.CreateDomainMessage, error) {
-	if c.Internal {
-		return repositories.CreateDomainMessage{}, errors.New("internal domains are not supported")
+	var orgGUID string
+	if c.Relationships.Organization != nil {
+		orgGUID = c.Relationships.Organization.Data.GUID
+	}
+
+	if c.Internal && orgGUID != "" {
+		return repositories.CreateDomainMessage{}, errors.New("internal domains cannot be scoped to an organization")
+	}
+
+	var sharedOrgGUIDs []string
+	if c.Relationships.SharedOrganizations != nil {
+		for _, data := range c.Relationships.SharedOrganizations.Data {
+			sharedOrgGUIDs = append(sharedOrgGUIDs, data.GUID)
+		}
+	}
+
+	if orgGUID == "" && len(sharedOrgGUIDs) > 0 {
+		return repositories.CreateDomainMessage{}, errors.New("domains cannot be shared with other organizations unless they are scoped to an organization")
+	}
+
+	var routerGroupGUID string
+	if c.RouterGroup != nil {
+		routerGroupGUID = c.RouterGroup.GUID
+	}
+
+	if orgGUID != "" && routerGroupGUID != "" {
+		return repositories.CreateDomainMessage{}, errors.New("router groups are only supported for shared domains")
 	}
 
-	if len(c.Relationships) > 0 {
-		return repositories.CreateDomainMessage{}, errors.New("private domains are not supported")
+	if c.Internal && routerGroupGUID != "" {
+		return repositories.CreateDomainMessage{}, errors.New("internal domains cannot have a router group")
 	}
 
 	return repositories.CreateDomainMessage{
-		Name: c.Name,
+		Name:                    c.Name,
+		OrganizationGUID:        orgGUID,
+		SharedOrganizationGUIDs: sharedOrgGUIDs,
+		RouterGroupGUID:         routerGroupGUID,
+		Internal:                c.Internal,
 		Metadata: repositories.Metadata{
 			Labels:      c.Metadata.Labels,
 			Annotations: c.Metadata.Annotations,
@@ -46,15 +85,52 @@ func (c *DomainUpdate) ToMessage(domainGUID string) repositories.UpdateDomainMes
 }
 
 type DomainList struct {
-	Names *string `schema:"names"`
+	Names             *string `schema:"names"`
+	OrganizationGUIDs *string `schema:"organization_guids"`
 }
 
 func (d *DomainList) ToMessage() repositories.ListDomainsMessage {
 	return repositories.ListDomainsMessage{
-		Names: ParseArrayParam(d.Names),
+		Names:             ParseArrayParam(d.Names),
+		OrganizationGUIDs: ParseArrayParam(d.OrganizationGUIDs),
 	}
 }
 
 func (d *DomainList) SupportedKeys() []string {
-	return []string{"names"}
+	return []string{"names", "organization_guids"}
+}
+
+type DomainShare struct {
+	Data []RelationshipData `json:"data" validate:"required,min=1,dive"`
+}
+
+func (p DomainShare) ToMessage(domainGUID string) repositories.ShareDomainMessage {
+	sharedOrgGUIDs := make([]string, 0, len(p.Data))
+	for _, data := range p.Data {
+		sharedOrgGUIDs = append(sharedOrgGUIDs, data.GUID)
+	}
+
+	return repositories.ShareDomainMessage{
+		GUID:                    domainGUID,
+		SharedOrganizationGUIDs: sharedOrgGUIDs,
+	}
+}
+
+type RouteReservationList struct {
+	Host string `schema:"host"`
+	Path string `schema:"path"`
+	Port int    `schema:"port"`
+}
+
+func (l *RouteReservationList) ToMessage(domainGUID string) repositories.RouteReservationMessage {
+	return repositories.RouteReservationMessage{
+		DomainGUID: domainGUID,
+		Host:       l.Host,
+		Path:       l.Path,
+		Port:       l.Port,
+	}
+}
+
+func (l *RouteReservationList) SupportedKeys() []string {
+	return []string{"host", "path", "port"}
 }
@@ -129,20 +129,85 @@ var _ = Describe("DomainCreate", func() {
 				createPayload.Internal = true
 			})
 
-			It("errors", func() {
-				Expect(err).To(MatchError(ContainSubstring("internal domains are not supported")))
+			It("returns an internal domain message", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(createMessage.Internal).To(BeTrue())
+			})
+
+			When("the domain is scoped to an organization", func() {
+				BeforeEach(func() {
+					createPayload.Relationships = payloads.DomainRelationships{
+						Organization: &payloads.Relationship{Data: &payloads.RelationshipData{GUID: "org-guid"}},
+					}
+				})
+
+				It("errors", func() {
+					Expect(err).To(MatchError(ContainSubstring("internal domains cannot be scoped to an organization")))
+				})
+			})
+
+			When("the domain has a router group", func() {
+				BeforeEach(func() {
+					createPayload.RouterGroup = &payloads.RelationshipData{GUID: "default-tcp"}
+				})
+
+				It("errors", func() {
+					Expect(err).To(MatchError(ContainSubstring("internal domains cannot have a router group")))
+				})
 			})
 		})
 
-		When("the payload has relationships", func() {
+		When("the payload has an organization relationship", func() {
 			BeforeEach(func() {
-				createPayload.Relationships = map[string]payloads.Relationship{
-					"foo": {},
+				createPayload.Relationships = payloads.DomainRelationships{
+					Organization: &payloads.Relationship{Data: &payloads.RelationshipData{GUID: "org-guid"}},
+					SharedOrganizations: &payloads.DomainSharedOrganizations{
+						Data: []payloads.RelationshipData{{GUID: "shared-org-guid"}},
+					},
+				}
+			})
+
+			It("returns a private domain create message", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(createMessage.OrganizationGUID).To(Equal("org-guid"))
+				Expect(createMessage.SharedOrganizationGUIDs).To(ConsistOf("shared-org-guid"))
+			})
+		})
+
+		When("the payload has a router group", func() {
+			BeforeEach(func() {
+				createPayload.RouterGroup = &payloads.RelationshipData{GUID: "default-tcp"}
+			})
+
+			It("returns a tcp domain create message", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(createMessage.RouterGroupGUID).To(Equal("default-tcp"))
+			})
+
+			When("the payload also has an organization relationship", func() {
+				BeforeEach(func() {
+					createPayload.Relationships = payloads.DomainRelationships{
+						Organization: &payloads.Relationship{Data: &payloads.RelationshipData{GUID: "org-guid"}},
+					}
+				})
+
+				It("errors", func() {
+					Expect(err).To(MatchError(ContainSubstring("router groups are only supported for shared domains")))
+				})
+			})
+		})
+
+		When("the payload has shared organizations but no organization", func() {
+			BeforeEach(func() {
+				createPayload.Relationships = payloads.DomainRelationships{
+					SharedOrganizations: &payloads.DomainSharedOrganizations{
+						Data: []payloads.RelationshipData{{GUID: "shared-org-guid"}},
+					},
 				}
 			})
 
 			It("errors", func() {
-				Expect(err).To(MatchError(ContainSubstring("private domains are not supported")))
+				Expect(err).To(MatchError(ContainSubstring("domains cannot be shared with other organizations unless they are scoped to an organization")))
 			})
 		})
 	})
@@ -240,13 +305,15 @@ var _ = Describe("DomainList", func() {
 		When("the form has valid keys", func() {
 			BeforeEach(func() {
 				form = url.Values{
-					"names": []string{"foo,bar"},
+					"names":              []string{"foo,bar"},
+					"organization_guids": []string{"org1,org2"},
 				}
 			})
 
 			It("succeeds", func() {
 				Expect(decodeErr).NotTo(HaveOccurred())
 				Expect(payload.Names).To(gstruct.PointTo(Equal("foo,bar")))
+				Expect(payload.OrganizationGUIDs).To(gstruct.PointTo(Equal("org1,org2")))
 			})
 		})
 
@@ -268,7 +335,8 @@ var _ = Describe("DomainList", func() {
 
 		BeforeEach(func() {
 			payload = payloads.DomainList{
-				Names: tools.PtrTo("foo,bar"),
+				Names:             tools.PtrTo("foo,bar"),
+				OrganizationGUIDs: tools.PtrTo("org1,org2"),
 			}
 		})
 
@@ -279,5 +347,9 @@ var _ = Describe("DomainList", func() {
 		It("splits names to strings", func() {
 			Expect(listDomainsMessage.Names).To(ConsistOf("foo", "bar"))
 		})
+
+		It("splits organization guids to strings", func() {
+			Expect(listDomainsMessage.OrganizationGUIDs).To(ConsistOf("org1", "org2"))
+		})
 	})
 })
@@ -0,0 +1,47 @@
+package payloads
+
+import "code.cloudfoundry.org/korifi/api/repositories"
+
+// The network policy payloads follow the Cloud Foundry policy server external API rather than the v3 API
+
+type NetworkPolicySource struct {
+	ID string `json:"id" validate:"required"`
+}
+
+type NetworkPolicyPorts struct {
+	Start int `json:"start" validate:"required,min=1,max=65535"`
+	End   int `json:"end" validate:"required,min=1,max=65535,gtefield=Start"`
+}
+
+type NetworkPolicyDestination struct {
+	ID       string             `json:"id" validate:"required"`
+	Protocol string             `json:"protocol" validate:"required,oneof=tcp udp"`
+	Ports    NetworkPolicyPorts `json:"ports" validate:"required"`
+}
+
+type NetworkPolicy struct {
+	Source      NetworkPolicySource      `json:"source" validate:"required"`
+	Destination NetworkPolicyDestination `json:"destination" validate:"required"`
+}
+
+type NetworkPolicies struct {
+	Policies []NetworkPolicy `json:"policies" validate:"required,min=1,dive"`
+}
+
+type NetworkPolicyList struct {
+	ID       *string `schema:"id"`
+	SourceID *string `schema:"source_id"`
+	DestID   *string `schema:"dest_id"`
+}
+
+func (l *NetworkPolicyList) ToMessage() repositories.ListNetworkPoliciesMessage {
+	return repositories.ListNetworkPoliciesMessage{
+		AppGUIDs:            ParseArrayParam(l.ID),
+		SourceAppGUIDs:      ParseArrayParam(l.SourceID),
+		DestinationAppGUIDs: ParseArrayParam(l.DestID),
+	}
+}
+
+func (l *NetworkPolicyList) SupportedKeys() []string {
+	return []string{"id", "source_id", "dest_id"}
+}
@@ -0,0 +1,96 @@
+package payloads
+
+import "code.cloudfoundry.org/korifi/api/repositories"
+
+// QuotaApps, QuotaServices and QuotaRoutes hold the limits shared by org and space quotas. A null limit is unlimited
+type QuotaApps struct {
+	TotalMemoryInMB      *int64 `json:"total_memory_in_mb" validate:"omitempty,gte=0"`
+	PerProcessMemoryInMB *int64 `json:"per_process_memory_in_mb" validate:"omitempty,gte=0"`
+	TotalInstances       *int64 `json:"total_instances" validate:"omitempty,gte=0"`
+	PerAppTasks          *int64 `json:"per_app_tasks" validate:"omitempty,gte=0"`
+}
+
+type QuotaServices struct {
+	// Service plans are not distinguished as paid or free, so this is accepted for compatibility only
+	PaidServicesAllowed   *bool  `json:"paid_services_allowed"`
+	TotalServiceInstances *int64 `json:"total_service_instances" validate:"omitempty,gte=0"`
+}
+
+type QuotaRoutes struct {
+	TotalRoutes *int64 `json:"total_routes" validate:"omitempty,gte=0"`
+}
+
+func quotaLimits(apps *QuotaApps, services *QuotaServices, routes *QuotaRoutes) repositories.QuotaLimits {
+	var limits repositories.QuotaLimits
+
+	if apps != nil {
+		limits.TotalMemoryInMB = apps.TotalMemoryInMB
+		limits.PerProcessMemoryInMB = apps.PerProcessMemoryInMB
+		limits.TotalInstances = apps.TotalInstances
+		limits.PerAppTasks = apps.PerAppTasks
+	}
+
+	if services != nil {
+		limits.TotalServiceInstances = services.TotalServiceInstances
+	}
+
+	if routes != nil {
+		limits.TotalRoutes = routes.TotalRoutes
+	}
+
+	return limits
+}
+
+type OrgQuotaCreate struct {
+	Name          string                 `json:"name" validate:"required"`
+	Apps          *QuotaApps             `json:"apps"`
+	Services      *QuotaServices         `json:"services"`
+	Routes        *QuotaRoutes           `json:"routes"`
+	Relationships *OrgQuotaRelationships `json:"relationships"`
+}
+
+type OrgQuotaRelationships struct {
+	Organizations ToManyRelationship `json:"organizations"`
+}
+
+func (p OrgQuotaCreate) ToMessage() repositories.CreateOrgQuotaMessage {
+	message := repositories.CreateOrgQuotaMessage{
+		Name:   p.Name,
+		Limits: quotaLimits(p.Apps, p.Services, p.Routes),
+	}
+
+	if p.Relationships != nil {
+		message.OrganizationGUIDs = p.Relationships.Organizations.GUIDs()
+	}
+
+	return message
+}
+
+type OrgQuotaList struct {
+	GUIDs             *string `schema:"guids"`
+	Names             *string `schema:"names"`
+	OrganizationGUIDs *string `schema:"organization_guids"`
+}
+
+func (l *OrgQuotaList) ToMessage() repositories.ListOrgQuotasMessage {
+	return repositories.ListOrgQuotasMessage{
+		GUIDs:             ParseArrayParam(l.GUIDs),
+		Names:             ParseArrayParam(l.Names),
+		OrganizationGUIDs: ParseArrayParam(l.OrganizationGUIDs),
+	}
+}
+
+func (l *OrgQuotaList) SupportedKeys() []string {
+	return []string{"guids", "names", "organization_guids"}
+}
+
+type OrgQuotaApply struct {
+	Data []RelationshipData `json:"data" validate:"required,min=1,dive"`
+}
+
+func (p OrgQuotaApply) ToMessage(quotaGUID string) repositories.ApplyOrgQuotaMessage {
+	return repositories.ApplyOrgQuotaMessage{
+		GUID:              quotaGUID,
+		OrganizationGUIDs: ToManyRelationship{Data: p.Data}.GUIDs(),
+	}
+}
@@ -20,8 +20,9 @@ type UserRelationshipData struct {
 }
 
 type RoleRelationships struct {
-	User                     *UserRelationship `json:"user" validate:"required_without=KubernetesServiceAccount"`
-	KubernetesServiceAccount *Relationship     `json:"kubernetesServiceAccount" validate:"required_without=User"`
+	User                     *UserRelationship `json:"user" validate:"required_without_all=KubernetesServiceAccount Group"`
+	KubernetesServiceAccount *Relationship     `json:"kubernetesServiceAccount" validate:"required_without_all=User Group"`
+	Group                    *Relationship     `json:"group" validate:"required_without_all=User KubernetesServiceAccount"`
 	Space                    *Relationship     `json:"space"`
 	Organization             *Relationship     `json:"organization"`
 }
@@ -39,16 +40,41 @@ func (p RoleCreate) ToMessage() repositories.CreateRoleMessage {
 		record.Org = p.Relationships.Organization.Data.GUID
 	}
 
-	if p.Relationships.User != nil {
+	switch {
+	case p.Relationships.User != nil:
 		record.Kind = rbacv1.UserKind
 		record.User = p.Relationships.User.Data.Username
 		if p.Relationships.User.Data.GUID != "" {
 			record.User = p.Relationships.User.Data.GUID
 		}
-	} else {
+	case p.Relationships.KubernetesServiceAccount != nil:
 		record.Kind = rbacv1.ServiceAccountKind
 		record.User = p.Relationships.KubernetesServiceAccount.Data.GUID
+	default:
+		record.Kind = rbacv1.GroupKind
+		record.User = p.Relationships.Group.Data.GUID
 	}
 
 	return record
 }
+
+type RoleList struct {
+	Types             *string `schema:"types"`
+	SpaceGUIDs        *string `schema:"space_guids"`
+	OrganizationGUIDs *string `schema:"organization_guids"`
+	UserGUIDs         *string `schema:"user_guids"`
+	Include           *string `schema:"include"`
+}
+
+func (l *RoleList) ToMessage() repositories.ListRolesMessage {
+	return repositories.ListRolesMessage{
+		Types:      ParseArrayParam(l.Types),
+		SpaceGUIDs: ParseArrayParam(l.SpaceGUIDs),
+		OrgGUIDs:   ParseArrayParam(l.OrganizationGUIDs),
+		UserGUIDs:  ParseArrayParam(l.UserGUIDs),
+	}
+}
+
+func (l *RoleList) SupportedKeys() []string {
+	return []string{"types", "space_guids", "organization_guids", "user_guids", "include"}
+}
@@ -5,8 +5,10 @@ import (
 )
 
 type RouteCreate struct {
-	Host          string             `json:"host" validate:"required"` // TODO: Not required when we support private domains
+	Host          string             `json:"host"`
 	Path          string             `json:"path"`
+	Port          *int               `json:"port" validate:"omitempty,min=1,max=65535"`
+	Options       map[string]string  `json:"options"`
 	Relationships RouteRelationships `json:"relationships" validate:"required"`
 	Metadata      Metadata           `json:"metadata"`
 }
@@ -17,13 +19,20 @@ type RouteRelationships struct {
 }
 
 func (p RouteCreate) ToMessage(domainNamespace, domainName string) repositories.CreateRouteMessage {
+	var port int
+	if p.Port != nil {
+		port = *p.Port
+	}
+
 	return repositories.CreateRouteMessage{
 		Host:            p.Host,
 		Path:            p.Path,
+		Port:            port,
 		SpaceGUID:       p.Relationships.Space.Data.GUID,
 		DomainGUID:      p.Relationships.Domain.Data.GUID,
 		DomainNamespace: domainNamespace,
 		DomainName:      domainName,
+		Options:         p.Options,
 		Labels:          p.Metadata.Labels,
 		Annotations:     p.Metadata.Annotations,
 	}
@@ -52,7 +61,8 @@ func (p *RouteList) SupportedKeys() []string {
 }
 
 type RoutePatch struct {
-	Metadata MetadataPatch `json:"metadata"`
+	Metadata MetadataPatch      `json:"metadata"`
+	Options  map[string]*string `json:"options"`
 }
 
 func (a *RoutePatch) ToMessage(routeGUID, spaceGUID string) repositories.PatchRouteMetadataMessage {
@@ -63,5 +73,35 @@ func (a *RoutePatch) ToMessage(routeGUID, spaceGUID string) repositories.PatchRo
 			Annotations: a.Metadata.Annotations,
 			Labels:      a.Metadata.Labels,
 		},
+		Options: a.Options,
+	}
+}
+
+type RouteShare struct {
+	Data []RelationshipData `json:"data" validate:"required,min=1,dive"`
+}
+
+func (p RouteShare) ToMessage(routeGUID, spaceGUID string) repositories.ShareRouteMessage {
+	sharedSpaceGUIDs := make([]string, 0, len(p.Data))
+	for _, data := range p.Data {
+		sharedSpaceGUIDs = append(sharedSpaceGUIDs, data.GUID)
+	}
+
+	return repositories.ShareRouteMessage{
+		RouteGUID:        routeGUID,
+		SpaceGUID:        spaceGUID,
+		SharedSpaceGUIDs: sharedSpaceGUIDs,
+	}
+}
+
+type RouteTransfer struct {
+	Data *RelationshipData `json:"data" validate:"required"`
+}
+
+func (p RouteTransfer) ToMessage(routeGUID, spaceGUID string) repositories.TransferRouteMessage {
+	return repositories.TransferRouteMessage{
+		RouteGUID:       routeGUID,
+		SpaceGUID:       spaceGUID,
+		TargetSpaceGUID: p.Data.GUID,
 	}
 }
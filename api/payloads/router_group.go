@@ -0,0 +1,17 @@
+package payloads
+
+import "code.cloudfoundry.org/korifi/api/repositories"
+
+type RouterGroupList struct {
+	Name *string `schema:"name"`
+}
+
+func (p *RouterGroupList) ToMessage() repositories.ListRouterGroupsMessage {
+	return repositories.ListRouterGroupsMessage{
+		Names: ParseArrayParam(p.Name),
+	}
+}
+
+func (p *RouterGroupList) SupportedKeys() []string {
+	return []string{"name"}
+}
@@ -0,0 +1,119 @@
+package payloads
+
+import "code.cloudfoundry.org/korifi/api/repositories"
+
+type SecurityGroupRule struct {
+	Protocol    string `json:"protocol" validate:"required,oneof=tcp udp icmp all"`
+	Destination string `json:"destination" validate:"required"`
+	Ports       string `json:"ports"`
+	Type        *int   `json:"type"`
+	Code        *int   `json:"code"`
+	Description string `json:"description"`
+	Log         bool   `json:"log"`
+}
+
+type SecurityGroupGloballyEnabled struct {
+	Running *bool `json:"running"`
+	Staging *bool `json:"staging"`
+}
+
+type SecurityGroupRelationships struct {
+	RunningSpaces ToManyRelationship `json:"running_spaces"`
+	StagingSpaces ToManyRelationship `json:"staging_spaces"`
+}
+
+type SecurityGroupCreate struct {
+	Name            string                        `json:"name" validate:"required"`
+	GloballyEnabled *SecurityGroupGloballyEnabled `json:"globally_enabled"`
+	Rules           []SecurityGroupRule           `json:"rules" validate:"dive"`
+	Relationships   *SecurityGroupRelationships   `json:"relationships"`
+}
+
+func (p SecurityGroupCreate) ToMessage() repositories.CreateSecurityGroupMessage {
+	message := repositories.CreateSecurityGroupMessage{
+		Name:  p.Name,
+		Rules: securityGroupRules(p.Rules),
+	}
+
+	if p.GloballyEnabled != nil {
+		message.GloballyEnabled.Running = p.GloballyEnabled.Running != nil && *p.GloballyEnabled.Running
+		message.GloballyEnabled.Staging = p.GloballyEnabled.Staging != nil && *p.GloballyEnabled.Staging
+	}
+
+	if p.Relationships != nil {
+		message.RunningSpaceGUIDs = p.Relationships.RunningSpaces.GUIDs()
+		message.StagingSpaceGUIDs = p.Relationships.StagingSpaces.GUIDs()
+	}
+
+	return message
+}
+
+type SecurityGroupUpdate struct {
+	Name            *string                       `json:"name" validate:"omitempty,min=1"`
+	GloballyEnabled *SecurityGroupGloballyEnabled `json:"globally_enabled"`
+	Rules           *[]SecurityGroupRule          `json:"rules" validate:"omitempty,dive"`
+}
+
+func (p SecurityGroupUpdate) ToMessage(guid string) repositories.UpdateSecurityGroupMessage {
+	message := repositories.UpdateSecurityGroupMessage{
+		GUID: guid,
+		Name: p.Name,
+	}
+
+	if p.GloballyEnabled != nil {
+		message.GloballyEnabledRunning = p.GloballyEnabled.Running
+		message.GloballyEnabledStaging = p.GloballyEnabled.Staging
+	}
+
+	if p.Rules != nil {
+		rules := securityGroupRules(*p.Rules)
+		message.Rules = &rules
+	}
+
+	return message
+}
+
+func securityGroupRules(rules []SecurityGroupRule) []repositories.SecurityGroupRule {
+	result := make([]repositories.SecurityGroupRule, 0, len(rules))
+	for _, rule := range rules {
+		result = append(result, repositories.SecurityGroupRule(rule))
+	}
+
+	return result
+}
+
+type SecurityGroupList struct {
+	GUIDs                  *string `schema:"guids"`
+	Names                  *string `schema:"names"`
+	GloballyEnabledRunning *bool   `schema:"globally_enabled_running"`
+	GloballyEnabledStaging *bool   `schema:"globally_enabled_staging"`
+	RunningSpaceGUIDs      *string `schema:"running_space_guids"`
+	StagingSpaceGUIDs      *string `schema:"staging_space_guids"`
+}
+
+func (l *SecurityGroupList) ToMessage() repositories.ListSecurityGroupsMessage {
+	return repositories.ListSecurityGroupsMessage{
+		GUIDs:                  ParseArrayParam(l.GUIDs),
+		Names:                  ParseArrayParam(l.Names),
+		GloballyEnabledRunning: l.GloballyEnabledRunning,
+		GloballyEnabledStaging: l.GloballyEnabledStaging,
+		RunningSpaceGUIDs:      ParseArrayParam(l.RunningSpaceGUIDs),
+		StagingSpaceGUIDs:      ParseArrayParam(l.StagingSpaceGUIDs),
+	}
+}
+
+func (l *SecurityGroupList) SupportedKeys() []string {
+	return []string{"guids", "names", "globally_enabled_running", "globally_enabled_staging", "running_space_guids", "staging_space_guids"}
+}
+
+type SecurityGroupBind struct {
+	Data []RelationshipData `json:"data" validate:"required,min=1,dive"`
+}
+
+func (p SecurityGroupBind) ToMessage(guid, workload string) repositories.BindSecurityGroupMessage {
+	return repositories.BindSecurityGroupMessage{
+		GUID:       guid,
+		Workload:   workload,
+		SpaceGUIDs: ToManyRelationship{Data: p.Data}.GUIDs(),
+	}
+}
@@ -15,12 +15,13 @@ type ServiceBindingRelationships struct {
 	ServiceInstance *Relationship `json:"service_instance" validate:"required"`
 }
 
-func (p ServiceBindingCreate) ToMessage(spaceGUID string) repositories.CreateServiceBindingMessage {
+func (p ServiceBindingCreate) ToMessage(spaceGUID, serviceInstanceSpaceGUID string) repositories.CreateServiceBindingMessage {
 	return repositories.CreateServiceBindingMessage{
-		Name:                p.Name,
-		ServiceInstanceGUID: p.Relationships.ServiceInstance.Data.GUID,
-		AppGUID:             p.Relationships.App.Data.GUID,
-		SpaceGUID:           spaceGUID,
+		Name:                     p.Name,
+		ServiceInstanceGUID:      p.Relationships.ServiceInstance.Data.GUID,
+		ServiceInstanceSpaceGUID: serviceInstanceSpaceGUID,
+		AppGUID:                  p.Relationships.App.Data.GUID,
+		SpaceGUID:                spaceGUID,
 	}
 }
 
@@ -7,12 +7,14 @@ import (
 )
 
 type ServiceInstanceCreate struct {
-	Name          string                       `json:"name" validate:"required"`
-	Type          string                       `json:"type" validate:"required,oneof=user-provided"`
-	Tags          []string                     `json:"tags" validate:"serviceinstancetaglength"`
-	Credentials   map[string]string            `json:"credentials"`
-	Relationships ServiceInstanceRelationships `json:"relationships" validate:"required"`
-	Metadata      Metadata                     `json:"metadata"`
+	Name            string                       `json:"name" validate:"required"`
+	Type            string                       `json:"type" validate:"required,oneof=user-provided"`
+	Tags            []string                     `json:"tags" validate:"serviceinstancetaglength"`
+	Credentials     map[string]string            `json:"credentials"`
+	RouteServiceURL *string                      `json:"route_service_url" validate:"omitempty,url"`
+	SyslogDrainURL  *string                      `json:"syslog_drain_url" validate:"omitempty,url"`
+	Relationships   ServiceInstanceRelationships `json:"relationships" validate:"required"`
+	Metadata        Metadata                     `json:"metadata"`
 }
 
 type ServiceInstanceRelationships struct {
@@ -21,13 +23,15 @@ type ServiceInstanceRelationships struct {
 
 func (p ServiceInstanceCreate) ToServiceInstanceCreateMessage() repositories.CreateServiceInstanceMessage {
 	return repositories.CreateServiceInstanceMessage{
-		Name:        p.Name,
-		SpaceGUID:   p.Relationships.Space.Data.GUID,
-		Credentials: p.Credentials,
-		Type:        p.Type,
-		Tags:        p.Tags,
-		Labels:      p.Metadata.Labels,
-		Annotations: p.Metadata.Annotations,
+		Name:            p.Name,
+		SpaceGUID:       p.Relationships.Space.Data.GUID,
+		Credentials:     p.Credentials,
+		Type:            p.Type,
+		Tags:            p.Tags,
+		RouteServiceURL: p.RouteServiceURL,
+		SyslogDrainURL:  p.SyslogDrainURL,
+		Labels:          p.Metadata.Labels,
+		Annotations:     p.Metadata.Annotations,
 	}
 }
 
@@ -49,3 +53,20 @@ func (l *ServiceInstanceList) ToMessage() repositories.ListServiceInstanceMessag
 func (l *ServiceInstanceList) SupportedKeys() []string {
 	return []string{"names", "space_guids", "fields", "order_by", "per_page"}
 }
+
+type ServiceInstanceShare struct {
+	Data []RelationshipData `json:"data" validate:"required,min=1,dive"`
+}
+
+func (p ServiceInstanceShare) ToMessage(guid, spaceGUID string) repositories.ShareServiceInstanceMessage {
+	sharedSpaceGUIDs := make([]string, 0, len(p.Data))
+	for _, data := range p.Data {
+		sharedSpaceGUIDs = append(sharedSpaceGUIDs, data.GUID)
+	}
+
+	return repositories.ShareServiceInstanceMessage{
+		GUID:             guid,
+		SpaceGUID:        spaceGUID,
+		SharedSpaceGUIDs: sharedSpaceGUIDs,
+	}
+}
@@ -0,0 +1,41 @@
+package payloads
+
+import (
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type ServiceRouteBindingCreate struct {
+	Relationships *ServiceRouteBindingRelationships `json:"relationships" validate:"required"`
+	Metadata      Metadata                          `json:"metadata"`
+}
+
+type ServiceRouteBindingRelationships struct {
+	Route           *Relationship `json:"route" validate:"required"`
+	ServiceInstance *Relationship `json:"service_instance" validate:"required"`
+}
+
+func (p ServiceRouteBindingCreate) ToMessage(spaceGUID string) repositories.CreateServiceRouteBindingMessage {
+	return repositories.CreateServiceRouteBindingMessage{
+		RouteGUID:           p.Relationships.Route.Data.GUID,
+		ServiceInstanceGUID: p.Relationships.ServiceInstance.Data.GUID,
+		SpaceGUID:           spaceGUID,
+		Labels:              p.Metadata.Labels,
+		Annotations:         p.Metadata.Annotations,
+	}
+}
+
+type ServiceRouteBindingList struct {
+	RouteGUIDs           *string `schema:"route_guids"`
+	ServiceInstanceGUIDs *string `schema:"service_instance_guids"`
+}
+
+func (l *ServiceRouteBindingList) ToMessage() repositories.ListServiceRouteBindingsMessage {
+	return repositories.ListServiceRouteBindingsMessage{
+		RouteGUIDs:           ParseArrayParam(l.RouteGUIDs),
+		ServiceInstanceGUIDs: ParseArrayParam(l.ServiceInstanceGUIDs),
+	}
+}
+
+func (l *ServiceRouteBindingList) SupportedKeys() []string {
+	return []string{"route_guids", "service_instance_guids", "per_page"}
+}
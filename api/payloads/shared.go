@@ -20,6 +20,19 @@ type RelationshipData struct {
 	GUID string `json:"guid" validate:"required"`
 }
 
+type ToManyRelationship struct {
+	Data []RelationshipData `json:"data" validate:"dive"`
+}
+
+func (r ToManyRelationship) GUIDs() []string {
+	guids := make([]string, 0, len(r.Data))
+	for _, data := range r.Data {
+		guids = append(guids, data.GUID)
+	}
+
+	return guids
+}
+
 func ParseArrayParam(arrayParam *string) []string {
 	if arrayParam == nil {
 		return []string{}
@@ -0,0 +1,56 @@
+package payloads
+
+import "code.cloudfoundry.org/korifi/api/repositories"
+
+type SpaceQuotaCreate struct {
+	Name          string                  `json:"name" validate:"required"`
+	Apps          *QuotaApps              `json:"apps"`
+	Services      *QuotaServices          `json:"services"`
+	Routes        *QuotaRoutes            `json:"routes"`
+	Relationships SpaceQuotaRelationships `json:"relationships" validate:"required"`
+}
+
+type SpaceQuotaRelationships struct {
+	Organization *Relationship      `json:"organization" validate:"required"`
+	Spaces       ToManyRelationship `json:"spaces"`
+}
+
+func (p SpaceQuotaCreate) ToMessage() repositories.CreateSpaceQuotaMessage {
+	return repositories.CreateSpaceQuotaMessage{
+		Name:             p.Name,
+		Limits:           quotaLimits(p.Apps, p.Services, p.Routes),
+		OrganizationGUID: p.Relationships.Organization.Data.GUID,
+		SpaceGUIDs:       p.Relationships.Spaces.GUIDs(),
+	}
+}
+
+type SpaceQuotaList struct {
+	GUIDs             *string `schema:"guids"`
+	Names             *string `schema:"names"`
+	OrganizationGUIDs *string `schema:"organization_guids"`
+	SpaceGUIDs        *string `schema:"space_guids"`
+}
+
+func (l *SpaceQuotaList) ToMessage() repositories.ListSpaceQuotasMessage {
+	return repositories.ListSpaceQuotasMessage{
+		GUIDs:             ParseArrayParam(l.GUIDs),
+		Names:             ParseArrayParam(l.Names),
+		OrganizationGUIDs: ParseArrayParam(l.OrganizationGUIDs),
+		SpaceGUIDs:        ParseArrayParam(l.SpaceGUIDs),
+	}
+}
+
+func (l *SpaceQuotaList) SupportedKeys() []string {
+	return []string{"guids", "names", "organization_guids", "space_guids"}
+}
+
+type SpaceQuotaApply struct {
+	Data []RelationshipData `json:"data" validate:"required,min=1,dive"`
+}
+
+func (p SpaceQuotaApply) ToMessage(quotaGUID string) repositories.ApplySpaceQuotaMessage {
+	return repositories.ApplySpaceQuotaMessage{
+		GUID:       quotaGUID,
+		SpaceGUIDs: ToManyRelationship{Data: p.Data}.GUIDs(),
+	}
+}
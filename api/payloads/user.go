@@ -0,0 +1,23 @@
+package payloads
+
+import "code.cloudfoundry.org/korifi/api/repositories"
+
+type UserList struct {
+	GUIDs     *string `schema:"guids"`
+	Usernames *string `schema:"usernames"`
+	Origins   *string `schema:"origins"`
+	RoleTypes *string `schema:"role_types"`
+}
+
+func (l *UserList) ToMessage() repositories.ListUsersMessage {
+	return repositories.ListUsersMessage{
+		GUIDs:     ParseArrayParam(l.GUIDs),
+		Names:     ParseArrayParam(l.Usernames),
+		Origins:   ParseArrayParam(l.Origins),
+		RoleTypes: ParseArrayParam(l.RoleTypes),
+	}
+}
+
+func (l *UserList) SupportedKeys() []string {
+	return []string{"guids", "usernames", "origins", "role_types"}
+}
@@ -188,3 +188,28 @@ func ForAppEnv(envVarRecord repositories.AppEnvRecord) AppEnvResponse {
 		ApplicationEnvJSON:   map[string]string{},
 	}
 }
+
+type AppFeatureResponse struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Enabled     bool   `json:"enabled"`
+}
+
+func ForAppFeature(appRecord repositories.AppRecord, featureName string) AppFeatureResponse {
+	switch featureName {
+	case repositories.AppFeatureServiceBindingK8s:
+		return AppFeatureResponse{
+			Name:        repositories.AppFeatureServiceBindingK8s,
+			Description: "Enable k8s service bindings for the app",
+			Enabled:     appRecord.Features.ServiceBindingK8sEnabled,
+		}
+	default:
+		return AppFeatureResponse{Name: featureName}
+	}
+}
+
+func ForAppFeatureList(appRecord repositories.AppRecord, baseURL, requestURL url.URL) ListResponse {
+	return ForList([]interface{}{
+		ForAppFeature(appRecord, repositories.AppFeatureServiceBindingK8s),
+	}, baseURL, requestURL)
+}
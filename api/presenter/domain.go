@@ -7,15 +7,16 @@ import (
 )
 
 const (
-	domainsBase = "/v3/domains"
+	domainsBase      = "/v3/domains"
+	routerGroupsBase = "/routing/v1/router_groups"
 )
 
 type DomainResponse struct {
-	Name               string   `json:"name"`
-	GUID               string   `json:"guid"`
-	Internal           bool     `json:"internal"`
-	RouterGroup        *string  `json:"router_group"`
-	SupportedProtocols []string `json:"supported_protocols"`
+	Name               string            `json:"name"`
+	GUID               string            `json:"guid"`
+	Internal           bool              `json:"internal"`
+	RouterGroup        *RelationshipData `json:"router_group"`
+	SupportedProtocols []string          `json:"supported_protocols"`
 
 	CreatedAt     string              `json:"created_at"`
 	UpdatedAt     string              `json:"updated_at"`
@@ -31,16 +32,12 @@ type DomainLinks struct {
 }
 
 type DomainRelationships struct {
-	Organization        `json:"organization"`
-	SharedOrganizations `json:"shared_organizations"`
+	Organization        Relationship              `json:"organization"`
+	SharedOrganizations DomainSharedOrganizations `json:"shared_organizations"`
 }
 
-type Organization struct {
-	Data *string `json:"data"`
-}
-
-type SharedOrganizations struct {
-	Data []string `json:"data"`
+type DomainSharedOrganizations struct {
+	Data []RelationshipData `json:"data"`
 }
 
 func ForDomain(responseDomain repositories.DomainRecord, baseURL url.URL) DomainResponse {
@@ -54,9 +51,9 @@ func ForDomain(responseDomain repositories.DomainRecord, baseURL url.URL) Domain
 	return DomainResponse{
 		Name:               responseDomain.Name,
 		GUID:               responseDomain.GUID,
-		Internal:           false,
-		RouterGroup:        nil,
-		SupportedProtocols: []string{"http"},
+		Internal:           responseDomain.Internal,
+		RouterGroup:        forDomainRouterGroup(responseDomain),
+		SupportedProtocols: forDomainSupportedProtocols(responseDomain),
 		CreatedAt:          responseDomain.CreatedAt,
 		UpdatedAt:          responseDomain.UpdatedAt,
 
@@ -65,12 +62,8 @@ func ForDomain(responseDomain repositories.DomainRecord, baseURL url.URL) Domain
 			Annotations: responseDomain.Annotations,
 		},
 		Relationships: DomainRelationships{
-			Organization: Organization{
-				Data: nil,
-			},
-			SharedOrganizations: SharedOrganizations{
-				Data: []string{},
-			},
+			Organization:        forDomainOrganization(responseDomain),
+			SharedOrganizations: ForDomainSharedOrganizations(responseDomain),
 		},
 		Links: DomainLinks{
 			Self: Link{
@@ -79,7 +72,7 @@ func ForDomain(responseDomain repositories.DomainRecord, baseURL url.URL) Domain
 			RouteReservations: Link{
 				HRef: buildURL(baseURL).appendPath(domainsBase, responseDomain.GUID, "route_reservations").build(),
 			},
-			RouterGroup: nil,
+			RouterGroup: forDomainRouterGroupLink(responseDomain, baseURL),
 		},
 	}
 }
@@ -92,3 +85,52 @@ func ForDomainList(domainListRecords []repositories.DomainRecord, baseURL, reque
 
 	return ForList(domainResponses, baseURL, requestURL)
 }
+
+func forDomainOrganization(domain repositories.DomainRecord) Relationship {
+	if !domain.IsPrivate() {
+		return Relationship{Data: nil}
+	}
+
+	return Relationship{Data: &RelationshipData{GUID: domain.OrganizationGUID}}
+}
+
+func ForDomainSharedOrganizations(domain repositories.DomainRecord) DomainSharedOrganizations {
+	data := make([]RelationshipData, 0, len(domain.SharedOrganizationGUIDs))
+	for _, orgGUID := range domain.SharedOrganizationGUIDs {
+		data = append(data, RelationshipData{GUID: orgGUID})
+	}
+
+	return DomainSharedOrganizations{Data: data}
+}
+
+func forDomainRouterGroup(domain repositories.DomainRecord) *RelationshipData {
+	if domain.RouterGroupGUID == "" {
+		return nil
+	}
+
+	return &RelationshipData{GUID: domain.RouterGroupGUID}
+}
+
+func forDomainSupportedProtocols(domain repositories.DomainRecord) []string {
+	if domain.RouterGroupGUID != "" {
+		return []string{"tcp"}
+	}
+
+	return []string{"http"}
+}
+
+func forDomainRouterGroupLink(domain repositories.DomainRecord, baseURL url.URL) *Link {
+	if domain.RouterGroupGUID == "" {
+		return nil
+	}
+
+	return &Link{HRef: buildURL(baseURL).appendPath(routerGroupsBase, domain.RouterGroupGUID).build()}
+}
+
+type RouteReservationResponse struct {
+	MatchingRoute bool `json:"matching_route"`
+}
+
+func ForRouteReservation(matchingRoute bool) RouteReservationResponse {
+	return RouteReservationResponse{MatchingRoute: matchingRoute}
+}
@@ -8,12 +8,14 @@ import (
 const (
 	JobGUIDDelimiter = "~"
 
-	AppDeleteOperation          = "app.delete"
-	OrgDeleteOperation          = "org.delete"
-	RouteDeleteOperation        = "route.delete"
-	SpaceApplyManifestOperation = "space.apply_manifest"
-	SpaceDeleteOperation        = "space.delete"
-	DomainDeleteOperation       = "domain.delete"
+	AppDeleteOperation           = "app.delete"
+	OrgDeleteOperation           = "org.delete"
+	RouteDeleteOperation         = "route.delete"
+	SpaceApplyManifestOperation  = "space.apply_manifest"
+	SpaceDeleteOperation         = "space.delete"
+	DomainDeleteOperation        = "domain.delete"
+	RoleDeleteOperation          = "role.delete"
+	SecurityGroupDeleteOperation = "security_group.delete"
 )
 
 type JobResponse struct {
@@ -0,0 +1,51 @@
+package presenter
+
+import "code.cloudfoundry.org/korifi/api/repositories"
+
+type NetworkPolicyListResponse struct {
+	TotalPolicies int                     `json:"total_policies"`
+	Policies      []NetworkPolicyResponse `json:"policies"`
+}
+
+type NetworkPolicyResponse struct {
+	Source      NetworkPolicySource      `json:"source"`
+	Destination NetworkPolicyDestination `json:"destination"`
+}
+
+type NetworkPolicySource struct {
+	ID string `json:"id"`
+}
+
+type NetworkPolicyDestination struct {
+	ID       string             `json:"id"`
+	Protocol string             `json:"protocol"`
+	Ports    NetworkPolicyPorts `json:"ports"`
+}
+
+type NetworkPolicyPorts struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// ForNetworkPolicyList presents the policies in the Cloud Foundry policy server format
+func ForNetworkPolicyList(records []repositories.NetworkPolicyRecord) NetworkPolicyListResponse {
+	policies := make([]NetworkPolicyResponse, 0, len(records))
+	for _, record := range records {
+		policies = append(policies, NetworkPolicyResponse{
+			Source: NetworkPolicySource{ID: record.SourceAppGUID},
+			Destination: NetworkPolicyDestination{
+				ID:       record.DestinationAppGUID,
+				Protocol: record.Protocol,
+				Ports: NetworkPolicyPorts{
+					Start: record.StartPort,
+					End:   record.EndPort,
+				},
+			},
+		})
+	}
+
+	return NetworkPolicyListResponse{
+		TotalPolicies: len(policies),
+		Policies:      policies,
+	}
+}
@@ -0,0 +1,181 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+const (
+	orgQuotasBase   = "/v3/organization_quotas"
+	spaceQuotasBase = "/v3/space_quotas"
+)
+
+// The limits that are not enforced are always presented as unlimited
+type QuotaApps struct {
+	TotalMemoryInMB              *int64 `json:"total_memory_in_mb"`
+	PerProcessMemoryInMB         *int64 `json:"per_process_memory_in_mb"`
+	TotalInstances               *int64 `json:"total_instances"`
+	PerAppTasks                  *int64 `json:"per_app_tasks"`
+	LogRateLimitInBytesPerSecond *int64 `json:"log_rate_limit_in_bytes_per_second"`
+}
+
+type QuotaServices struct {
+	PaidServicesAllowed   bool   `json:"paid_services_allowed"`
+	TotalServiceInstances *int64 `json:"total_service_instances"`
+	TotalServiceKeys      *int64 `json:"total_service_keys"`
+}
+
+type QuotaRoutes struct {
+	TotalRoutes        *int64 `json:"total_routes"`
+	TotalReservedPorts *int64 `json:"total_reserved_ports"`
+}
+
+type QuotaDomains struct {
+	TotalDomains *int64 `json:"total_domains"`
+}
+
+type ToManyRelationship struct {
+	Data []RelationshipData `json:"data"`
+}
+
+type ToManyRelationshipResponse struct {
+	Data  []RelationshipData `json:"data"`
+	Links SelfLink           `json:"links"`
+}
+
+type SelfLink struct {
+	Self Link `json:"self"`
+}
+
+type OrgQuotaResponse struct {
+	GUID          string                `json:"guid"`
+	CreatedAt     string                `json:"created_at"`
+	UpdatedAt     string                `json:"updated_at"`
+	Name          string                `json:"name"`
+	Apps          QuotaApps             `json:"apps"`
+	Services      QuotaServices         `json:"services"`
+	Routes        QuotaRoutes           `json:"routes"`
+	Domains       QuotaDomains          `json:"domains"`
+	Relationships OrgQuotaRelationships `json:"relationships"`
+	Links         SelfLink              `json:"links"`
+}
+
+type OrgQuotaRelationships struct {
+	Organizations ToManyRelationship `json:"organizations"`
+}
+
+type SpaceQuotaResponse struct {
+	GUID          string                  `json:"guid"`
+	CreatedAt     string                  `json:"created_at"`
+	UpdatedAt     string                  `json:"updated_at"`
+	Name          string                  `json:"name"`
+	Apps          QuotaApps               `json:"apps"`
+	Services      QuotaServices           `json:"services"`
+	Routes        QuotaRoutes             `json:"routes"`
+	Relationships SpaceQuotaRelationships `json:"relationships"`
+	Links         SelfLink                `json:"links"`
+}
+
+type SpaceQuotaRelationships struct {
+	Organization Relationship       `json:"organization"`
+	Spaces       ToManyRelationship `json:"spaces"`
+}
+
+func ForOrgQuota(record repositories.OrgQuotaRecord, baseURL url.URL) OrgQuotaResponse {
+	return OrgQuotaResponse{
+		GUID:      record.GUID,
+		CreatedAt: record.CreatedAt,
+		UpdatedAt: record.UpdatedAt,
+		Name:      record.Name,
+		Apps:      forQuotaApps(record.Limits),
+		Services:  forQuotaServices(record.Limits),
+		Routes:    QuotaRoutes{TotalRoutes: record.Limits.TotalRoutes},
+		Relationships: OrgQuotaRelationships{
+			Organizations: forToManyRelationship(record.OrganizationGUIDs),
+		},
+		Links: SelfLink{
+			Self: Link{HRef: buildURL(baseURL).appendPath(orgQuotasBase, record.GUID).build()},
+		},
+	}
+}
+
+func ForOrgQuotaList(records []repositories.OrgQuotaRecord, baseURL, requestURL url.URL) ListResponse {
+	responses := make([]interface{}, 0, len(records))
+	for _, record := range records {
+		responses = append(responses, ForOrgQuota(record, baseURL))
+	}
+
+	return ForList(responses, baseURL, requestURL)
+}
+
+func ForOrgQuotaOrganizations(record repositories.OrgQuotaRecord, baseURL url.URL) ToManyRelationshipResponse {
+	return ToManyRelationshipResponse{
+		Data: forToManyRelationship(record.OrganizationGUIDs).Data,
+		Links: SelfLink{
+			Self: Link{HRef: buildURL(baseURL).appendPath(orgQuotasBase, record.GUID, "relationships", "organizations").build()},
+		},
+	}
+}
+
+func ForSpaceQuota(record repositories.SpaceQuotaRecord, baseURL url.URL) SpaceQuotaResponse {
+	return SpaceQuotaResponse{
+		GUID:      record.GUID,
+		CreatedAt: record.CreatedAt,
+		UpdatedAt: record.UpdatedAt,
+		Name:      record.Name,
+		Apps:      forQuotaApps(record.Limits),
+		Services:  forQuotaServices(record.Limits),
+		Routes:    QuotaRoutes{TotalRoutes: record.Limits.TotalRoutes},
+		Relationships: SpaceQuotaRelationships{
+			Organization: Relationship{Data: &RelationshipData{GUID: record.OrganizationGUID}},
+			Spaces:       forToManyRelationship(record.SpaceGUIDs),
+		},
+		Links: SelfLink{
+			Self: Link{HRef: buildURL(baseURL).appendPath(spaceQuotasBase, record.GUID).build()},
+		},
+	}
+}
+
+func ForSpaceQuotaList(records []repositories.SpaceQuotaRecord, baseURL, requestURL url.URL) ListResponse {
+	responses := make([]interface{}, 0, len(records))
+	for _, record := range records {
+		responses = append(responses, ForSpaceQuota(record, baseURL))
+	}
+
+	return ForList(responses, baseURL, requestURL)
+}
+
+func ForSpaceQuotaSpaces(record repositories.SpaceQuotaRecord, baseURL url.URL) ToManyRelationshipResponse {
+	return ToManyRelationshipResponse{
+		Data: forToManyRelationship(record.SpaceGUIDs).Data,
+		Links: SelfLink{
+			Self: Link{HRef: buildURL(baseURL).appendPath(spaceQuotasBase, record.GUID, "relationships", "spaces").build()},
+		},
+	}
+}
+
+func forQuotaApps(limits repositories.QuotaLimits) QuotaApps {
+	return QuotaApps{
+		TotalMemoryInMB:      limits.TotalMemoryInMB,
+		PerProcessMemoryInMB: limits.PerProcessMemoryInMB,
+		TotalInstances:       limits.TotalInstances,
+		PerAppTasks:          limits.PerAppTasks,
+	}
+}
+
+func forQuotaServices(limits repositories.QuotaLimits) QuotaServices {
+	return QuotaServices{
+		PaidServicesAllowed:   true,
+		TotalServiceInstances: limits.TotalServiceInstances,
+	}
+}
+
+func forToManyRelationship(guids []string) ToManyRelationship {
+	data := make([]RelationshipData, 0, len(guids))
+	for _, guid := range guids {
+		data = append(data, RelationshipData{GUID: guid})
+	}
+
+	return ToManyRelationship{Data: data}
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/korifi/api/repositories"
+	rbacv1 "k8s.io/api/rbac/v1"
 )
 
 const (
@@ -31,6 +32,36 @@ func ForCreateRole(role repositories.RoleRecord, apiBaseURL url.URL) RoleRespons
 	return toRoleResponse(role, apiBaseURL)
 }
 
+func ForRole(role repositories.RoleRecord, apiBaseURL url.URL) RoleResponse {
+	return toRoleResponse(role, apiBaseURL)
+}
+
+func ForRoleList(roles []repositories.RoleRecord, users []repositories.UserRecord, spaces []repositories.SpaceRecord, orgs []repositories.OrgRecord, apiBaseURL, requestURL url.URL) ListResponse {
+	roleResponses := make([]interface{}, 0, len(roles))
+	for _, role := range roles {
+		roleResponses = append(roleResponses, ForRole(role, apiBaseURL))
+	}
+
+	ret := ForList(roleResponses, apiBaseURL, requestURL)
+	if len(users) == 0 && len(spaces) == 0 && len(orgs) == 0 {
+		return ret
+	}
+
+	included := IncludedData{}
+	for _, user := range users {
+		included.Users = append(included.Users, ForUser(user, apiBaseURL))
+	}
+	for _, space := range spaces {
+		included.Spaces = append(included.Spaces, ForSpace(space, apiBaseURL))
+	}
+	for _, org := range orgs {
+		included.Organizations = append(included.Organizations, ForOrg(org, apiBaseURL))
+	}
+	ret.Included = &included
+
+	return ret
+}
+
 func toRoleResponse(role repositories.RoleRecord, apiBaseURL url.URL) RoleResponse {
 	resp := RoleResponse{
 		GUID:      role.GUID,
@@ -38,7 +69,7 @@ func toRoleResponse(role repositories.RoleRecord, apiBaseURL url.URL) RoleRespon
 		UpdatedAt: role.CreatedAt.UTC().Format(time.RFC3339),
 		Type:      role.Type,
 		Relationships: Relationships{
-			"user":         Relationship{Data: &RelationshipData{GUID: role.User}},
+			"user":         Relationship{Data: &RelationshipData{GUID: repositories.UserRecordForRole(role).GUID}},
 			"space":        Relationship{Data: nil},
 			"organization": Relationship{Data: nil},
 		},
@@ -49,6 +80,11 @@ func toRoleResponse(role repositories.RoleRecord, apiBaseURL url.URL) RoleRespon
 		},
 	}
 
+	if role.Kind == rbacv1.GroupKind {
+		resp.Relationships["user"] = Relationship{Data: nil}
+		resp.Relationships["group"] = Relationship{Data: &RelationshipData{GUID: role.User}}
+	}
+
 	if role.Org != "" {
 		resp.Relationships["organization"] = Relationship{Data: &RelationshipData{GUID: role.Org}}
 		resp.Links.Organization = &Link{
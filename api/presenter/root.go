@@ -1,5 +1,7 @@
 package presenter
 
+import "fmt"
+
 type APILink struct {
 	Link
 	Meta APILinkMeta `json:"meta"`
@@ -16,7 +18,12 @@ type RootResponse struct {
 
 const V3APIVersion = "3.117.0+cf-k8s"
 
-func GetRootResponse(serverURL string) RootResponse {
+func GetRootResponse(serverURL string, ssoEnabled bool) RootResponse {
+	var uaaLink *APILink
+	if ssoEnabled {
+		uaaLink = &APILink{Link: Link{HRef: serverURL}}
+	}
+
 	return RootResponse{
 		Links: map[string]*APILink{
 			"self":                {Link: Link{HRef: serverURL}},
@@ -24,11 +31,11 @@ func GetRootResponse(serverURL string) RootResponse {
 			"cloud_controller_v2": nil,
 			"cloud_controller_v3": {Link: Link{HRef: serverURL + "/v3"}, Meta: APILinkMeta{Version: V3APIVersion}},
 			"network_policy_v0":   nil,
-			"network_policy_v1":   nil,
+			"network_policy_v1":   {Link: Link{HRef: serverURL + "/networking/v1/external"}},
 			"login":               {Link: Link{HRef: serverURL}},
-			"uaa":                 nil,
+			"uaa":                 uaaLink,
 			"credhub":             nil,
-			"routing":             nil,
+			"routing":             {Link: Link{HRef: serverURL + "/routing"}},
 			"logging":             nil,
 			"log_cache":           {Link: Link{HRef: serverURL}},
 			"log_stream":          nil,
@@ -37,3 +44,39 @@ func GetRootResponse(serverURL string) RootResponse {
 		CFOnK8s: true,
 	}
 }
+
+// LoginInfoResponse mimics the /login endpoint of the UAA login server, which
+// the CF CLI queries for the prompts to show on `cf login`
+type LoginInfoResponse struct {
+	App            LoginInfoApp        `json:"app"`
+	ShowLoginLinks bool                `json:"showLoginLinks"`
+	Links          map[string]string   `json:"links"`
+	ZoneName       string              `json:"zone_name"`
+	Prompts        map[string][]string `json:"prompts"`
+}
+
+type LoginInfoApp struct {
+	Version string `json:"version"`
+}
+
+func ForLoginInfo(serverURL string, ssoEnabled bool, passcodeURL string) LoginInfoResponse {
+	prompts := map[string][]string{}
+	if ssoEnabled {
+		passcodePrompt := "Temporary Authentication Code"
+		if passcodeURL != "" {
+			passcodePrompt += fmt.Sprintf(" ( Get one at %s )", passcodeURL)
+		}
+		prompts["passcode"] = []string{"password", passcodePrompt}
+	}
+
+	return LoginInfoResponse{
+		App:            LoginInfoApp{Version: V3APIVersion},
+		ShowLoginLinks: ssoEnabled,
+		Links: map[string]string{
+			"login": serverURL,
+			"uaa":   serverURL,
+		},
+		ZoneName: "korifi",
+		Prompts:  prompts,
+	}
+}
@@ -19,6 +19,7 @@ type RouteResponse struct {
 	Path         string             `json:"path"`
 	URL          string             `json:"url"`
 	Destinations []routeDestination `json:"destinations"`
+	Options      map[string]string  `json:"options,omitempty"`
 
 	CreatedAt     string        `json:"created_at"`
 	UpdatedAt     string        `json:"updated_at"`
@@ -69,9 +70,11 @@ func ForRoute(route repositories.RouteRecord, baseURL url.URL) RouteResponse {
 	return RouteResponse{
 		GUID:      route.GUID,
 		Protocol:  route.Protocol,
+		Port:      routePort(route),
 		Host:      route.Host,
 		Path:      route.Path,
 		URL:       routeURL(route),
+		Options:   route.Options,
 		CreatedAt: route.CreatedAt,
 		UpdatedAt: route.UpdatedAt,
 		Relationships: Relationships{
@@ -126,7 +129,7 @@ func forDestination(destination repositories.DestinationRecord) routeDestination
 				Type: destination.ProcessType,
 			},
 		},
-		Weight:   nil,
+		Weight:   destination.Weight,
 		Port:     destination.Port,
 		Protocol: destination.Protocol,
 	}
@@ -150,10 +153,71 @@ func ForRouteDestinations(route repositories.RouteRecord, baseURL url.URL) Route
 	}
 }
 
+func routePort(route repositories.RouteRecord) *int {
+	if route.Port == 0 {
+		return nil
+	}
+
+	return &route.Port
+}
+
 func routeURL(route repositories.RouteRecord) string {
+	if route.Port != 0 {
+		return fmt.Sprintf("%s:%d", route.Domain.Name, route.Port)
+	}
+
 	if route.Host != "" {
 		return fmt.Sprintf("%s.%s%s", route.Host, route.Domain.Name, route.Path)
 	} else {
 		return fmt.Sprintf("%s%s", route.Domain.Name, route.Path)
 	}
 }
+
+type RouteSharedSpacesResponse struct {
+	Data  []RelationshipData     `json:"data"`
+	Links routeSharedSpacesLinks `json:"links"`
+}
+
+type routeSharedSpacesLinks struct {
+	Self Link `json:"self"`
+}
+
+type RouteSpaceResponse struct {
+	Data  RelationshipData `json:"data"`
+	Links routeSpaceLinks  `json:"links"`
+}
+
+type routeSpaceLinks struct {
+	Self    Link `json:"self"`
+	Related Link `json:"related"`
+}
+
+func ForRouteSharedSpaces(route repositories.RouteRecord, baseURL url.URL) RouteSharedSpacesResponse {
+	data := make([]RelationshipData, 0, len(route.SharedSpaceGUIDs))
+	for _, spaceGUID := range route.SharedSpaceGUIDs {
+		data = append(data, RelationshipData{GUID: spaceGUID})
+	}
+
+	return RouteSharedSpacesResponse{
+		Data: data,
+		Links: routeSharedSpacesLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(routesBase, route.GUID, "relationships", "shared_spaces").build(),
+			},
+		},
+	}
+}
+
+func ForRouteSpace(route repositories.RouteRecord, baseURL url.URL) RouteSpaceResponse {
+	return RouteSpaceResponse{
+		Data: RelationshipData{GUID: route.SpaceGUID},
+		Links: routeSpaceLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(routesBase, route.GUID, "relationships", "space").build(),
+			},
+			Related: Link{
+				HRef: buildURL(baseURL).appendPath(spacesBase, route.SpaceGUID).build(),
+			},
+		},
+	}
+}
@@ -0,0 +1,31 @@
+package presenter
+
+import (
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+// RouterGroupResponse follows the routing API format rather than the v3 one, as it is served under /routing
+type RouterGroupResponse struct {
+	GUID            string `json:"guid"`
+	Name            string `json:"name"`
+	Type            string `json:"type"`
+	ReservablePorts string `json:"reservable_ports"`
+}
+
+func ForRouterGroup(routerGroup repositories.RouterGroupRecord) RouterGroupResponse {
+	return RouterGroupResponse{
+		GUID:            routerGroup.GUID,
+		Name:            routerGroup.Name,
+		Type:            routerGroup.Type,
+		ReservablePorts: routerGroup.ReservablePorts,
+	}
+}
+
+func ForRouterGroupList(routerGroups []repositories.RouterGroupRecord) []RouterGroupResponse {
+	responses := make([]RouterGroupResponse, 0, len(routerGroups))
+	for _, routerGroup := range routerGroups {
+		responses = append(responses, ForRouterGroup(routerGroup))
+	}
+
+	return responses
+}
@@ -0,0 +1,89 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+const (
+	securityGroupsBase = "/v3/security_groups"
+)
+
+type SecurityGroupResponse struct {
+	GUID            string                       `json:"guid"`
+	CreatedAt       string                       `json:"created_at"`
+	UpdatedAt       string                       `json:"updated_at"`
+	Name            string                       `json:"name"`
+	GloballyEnabled SecurityGroupGloballyEnabled `json:"globally_enabled"`
+	Rules           []SecurityGroupRule          `json:"rules"`
+	Relationships   SecurityGroupRelationships   `json:"relationships"`
+	Links           SelfLink                     `json:"links"`
+}
+
+type SecurityGroupGloballyEnabled struct {
+	Running bool `json:"running"`
+	Staging bool `json:"staging"`
+}
+
+type SecurityGroupRule struct {
+	Protocol    string `json:"protocol"`
+	Destination string `json:"destination"`
+	Ports       string `json:"ports,omitempty"`
+	Type        *int   `json:"type,omitempty"`
+	Code        *int   `json:"code,omitempty"`
+	Description string `json:"description,omitempty"`
+	Log         bool   `json:"log,omitempty"`
+}
+
+type SecurityGroupRelationships struct {
+	RunningSpaces ToManyRelationship `json:"running_spaces"`
+	StagingSpaces ToManyRelationship `json:"staging_spaces"`
+}
+
+func ForSecurityGroup(record repositories.SecurityGroupRecord, baseURL url.URL) SecurityGroupResponse {
+	rules := make([]SecurityGroupRule, 0, len(record.Rules))
+	for _, rule := range record.Rules {
+		rules = append(rules, SecurityGroupRule(rule))
+	}
+
+	return SecurityGroupResponse{
+		GUID:            record.GUID,
+		CreatedAt:       record.CreatedAt,
+		UpdatedAt:       record.UpdatedAt,
+		Name:            record.Name,
+		GloballyEnabled: SecurityGroupGloballyEnabled(record.GloballyEnabled),
+		Rules:           rules,
+		Relationships: SecurityGroupRelationships{
+			RunningSpaces: forToManyRelationship(record.RunningSpaceGUIDs),
+			StagingSpaces: forToManyRelationship(record.StagingSpaceGUIDs),
+		},
+		Links: SelfLink{
+			Self: Link{HRef: buildURL(baseURL).appendPath(securityGroupsBase, record.GUID).build()},
+		},
+	}
+}
+
+func ForSecurityGroupList(records []repositories.SecurityGroupRecord, baseURL, requestURL url.URL) ListResponse {
+	responses := make([]interface{}, 0, len(records))
+	for _, record := range records {
+		responses = append(responses, ForSecurityGroup(record, baseURL))
+	}
+
+	return ForList(responses, baseURL, requestURL)
+}
+
+// ForSecurityGroupSpaces presents the spaces the group is bound to for the running or staging workload
+func ForSecurityGroupSpaces(record repositories.SecurityGroupRecord, workload string, baseURL url.URL) ToManyRelationshipResponse {
+	spaceGUIDs := record.RunningSpaceGUIDs
+	if workload == repositories.SecurityGroupWorkloadStaging {
+		spaceGUIDs = record.StagingSpaceGUIDs
+	}
+
+	return ToManyRelationshipResponse{
+		Data: forToManyRelationship(spaceGUIDs).Data,
+		Links: SelfLink{
+			Self: Link{HRef: buildURL(baseURL).appendPath(securityGroupsBase, record.GUID, "relationships", workload+"_spaces").build()},
+		},
+	}
+}
@@ -41,28 +41,20 @@ type ServiceInstanceLinks struct {
 	Credentials               Link `json:"credentials"`
 	ServiceCredentialBindings Link `json:"service_credential_bindings"`
 	ServiceRouteBindings      Link `json:"service_route_bindings"`
+	SharedSpaces              Link `json:"shared_spaces"`
 }
 
 func ForServiceInstance(serviceInstanceRecord repositories.ServiceInstanceRecord, baseURL url.URL) ServiceInstanceResponse {
-	lastOperationType := "update"
-	if serviceInstanceRecord.CreatedAt == serviceInstanceRecord.UpdatedAt {
-		lastOperationType = "create"
-	}
-
 	return ServiceInstanceResponse{
-		Name: serviceInstanceRecord.Name,
-		GUID: serviceInstanceRecord.GUID,
-		Type: serviceInstanceRecord.Type,
-		Tags: emptySliceIfNil(serviceInstanceRecord.Tags),
-		LastOperation: lastOperation{
-			CreatedAt:   serviceInstanceRecord.CreatedAt,
-			UpdatedAt:   serviceInstanceRecord.UpdatedAt,
-			Description: "Operation succeeded",
-			State:       "succeeded",
-			Type:        lastOperationType,
-		},
-		CreatedAt: serviceInstanceRecord.CreatedAt,
-		UpdatedAt: serviceInstanceRecord.UpdatedAt,
+		Name:            serviceInstanceRecord.Name,
+		GUID:            serviceInstanceRecord.GUID,
+		Type:            serviceInstanceRecord.Type,
+		Tags:            emptySliceIfNil(serviceInstanceRecord.Tags),
+		LastOperation:   forServiceInstanceLastOperation(serviceInstanceRecord),
+		RouteServiceURL: serviceInstanceRecord.RouteServiceURL,
+		SyslogDrainURL:  serviceInstanceRecord.SyslogDrainURL,
+		CreatedAt:       serviceInstanceRecord.CreatedAt,
+		UpdatedAt:       serviceInstanceRecord.UpdatedAt,
 		Relationships: Relationships{
 			"space": Relationship{
 				Data: &RelationshipData{
@@ -90,6 +82,9 @@ func ForServiceInstance(serviceInstanceRecord repositories.ServiceInstanceRecord
 			ServiceRouteBindings: Link{
 				HRef: buildURL(baseURL).appendPath(serviceRouteBindingsBase).setQuery("service_instance_guids=" + serviceInstanceRecord.GUID).build(),
 			},
+			SharedSpaces: Link{
+				HRef: buildURL(baseURL).appendPath(serviceInstancesBase, serviceInstanceRecord.GUID, "relationships", "shared_spaces").build(),
+			},
 		},
 	}
 }
@@ -102,3 +97,55 @@ func ForServiceInstanceList(serviceInstanceRecord []repositories.ServiceInstance
 
 	return ForList(serviceInstanceResponses, baseURL, requestURL)
 }
+
+type ServiceInstanceSharedSpacesResponse struct {
+	Data  []RelationshipData               `json:"data"`
+	Links ServiceInstanceSharedSpacesLinks `json:"links"`
+}
+
+type ServiceInstanceSharedSpacesLinks struct {
+	Self Link `json:"self"`
+}
+
+// forServiceInstanceLastOperation falls back to a succeeded operation for instances whose
+// status has not been reported yet, as user-provided instances are available right away
+func forServiceInstanceLastOperation(serviceInstanceRecord repositories.ServiceInstanceRecord) lastOperation {
+	if serviceInstanceRecord.LastOperation == nil {
+		lastOperationType := "update"
+		if serviceInstanceRecord.CreatedAt == serviceInstanceRecord.UpdatedAt {
+			lastOperationType = "create"
+		}
+
+		return lastOperation{
+			CreatedAt:   serviceInstanceRecord.CreatedAt,
+			UpdatedAt:   serviceInstanceRecord.UpdatedAt,
+			Description: "Operation succeeded",
+			State:       "succeeded",
+			Type:        lastOperationType,
+		}
+	}
+
+	return lastOperation{
+		CreatedAt:   serviceInstanceRecord.CreatedAt,
+		UpdatedAt:   serviceInstanceRecord.LastOperation.UpdatedAt,
+		Description: serviceInstanceRecord.LastOperation.Description,
+		State:       serviceInstanceRecord.LastOperation.State,
+		Type:        serviceInstanceRecord.LastOperation.Type,
+	}
+}
+
+func ForServiceInstanceSharedSpaces(serviceInstanceRecord repositories.ServiceInstanceRecord, baseURL url.URL) ServiceInstanceSharedSpacesResponse {
+	data := make([]RelationshipData, 0, len(serviceInstanceRecord.SharedSpaceGUIDs))
+	for _, spaceGUID := range serviceInstanceRecord.SharedSpaceGUIDs {
+		data = append(data, RelationshipData{GUID: spaceGUID})
+	}
+
+	return ServiceInstanceSharedSpacesResponse{
+		Data: data,
+		Links: ServiceInstanceSharedSpacesLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(serviceInstancesBase, serviceInstanceRecord.GUID, "relationships", "shared_spaces").build(),
+			},
+		},
+	}
+}
@@ -1,9 +1,72 @@
 package presenter
 
-import "net/url"
+import (
+	"net/url"
 
-type ServiceRouteBinding struct{}
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
 
-func ForServiceRouteBindingsList(baseURL, requestURL url.URL) ListResponse {
-	return ForList([]interface{}{}, baseURL, requestURL)
+type ServiceRouteBindingResponse struct {
+	GUID            string                           `json:"guid"`
+	RouteServiceURL *string                          `json:"route_service_url"`
+	CreatedAt       string                           `json:"created_at"`
+	UpdatedAt       string                           `json:"updated_at"`
+	LastOperation   lastOperation                    `json:"last_operation"`
+	Metadata        Metadata                         `json:"metadata"`
+	Relationships   Relationships                    `json:"relationships"`
+	Links           ServiceRouteBindingLinksResponse `json:"links"`
+}
+
+type ServiceRouteBindingLinksResponse struct {
+	Self            Link `json:"self"`
+	ServiceInstance Link `json:"service_instance"`
+	Route           Link `json:"route"`
+	Parameters      Link `json:"parameters"`
+}
+
+func ForServiceRouteBinding(record repositories.ServiceRouteBindingRecord, baseURL url.URL) ServiceRouteBindingResponse {
+	return ServiceRouteBindingResponse{
+		GUID:            record.GUID,
+		RouteServiceURL: record.RouteServiceURL,
+		CreatedAt:       record.CreatedAt,
+		UpdatedAt:       record.UpdatedAt,
+		LastOperation: lastOperation{
+			CreatedAt:   record.CreatedAt,
+			UpdatedAt:   record.UpdatedAt,
+			Description: "Operation succeeded",
+			State:       "succeeded",
+			Type:        "create",
+		},
+		Metadata: Metadata{
+			Labels:      emptyMapIfNil(record.Labels),
+			Annotations: emptyMapIfNil(record.Annotations),
+		},
+		Relationships: Relationships{
+			"route":            {&RelationshipData{record.RouteGUID}},
+			"service_instance": {&RelationshipData{record.ServiceInstanceGUID}},
+		},
+		Links: ServiceRouteBindingLinksResponse{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(serviceRouteBindingsBase, record.GUID).build(),
+			},
+			ServiceInstance: Link{
+				HRef: buildURL(baseURL).appendPath(serviceInstancesBase, record.ServiceInstanceGUID).build(),
+			},
+			Route: Link{
+				HRef: buildURL(baseURL).appendPath(routesBase, record.RouteGUID).build(),
+			},
+			Parameters: Link{
+				HRef: buildURL(baseURL).appendPath(serviceRouteBindingsBase, record.GUID, "parameters").build(),
+			},
+		},
+	}
+}
+
+func ForServiceRouteBindingList(records []repositories.ServiceRouteBindingRecord, baseURL, requestURL url.URL) ListResponse {
+	responses := make([]interface{}, 0, len(records))
+	for _, record := range records {
+		responses = append(responses, ForServiceRouteBinding(record, baseURL))
+	}
+
+	return ForList(responses, baseURL, requestURL)
 }
@@ -51,7 +51,10 @@ type PaginationData struct {
 }
 
 type IncludedData struct {
-	Apps []interface{} `json:"apps"`
+	Apps          []interface{} `json:"apps,omitempty"`
+	Users         []interface{} `json:"users,omitempty"`
+	Spaces        []interface{} `json:"spaces,omitempty"`
+	Organizations []interface{} `json:"organizations,omitempty"`
 }
 
 type PageRef struct {
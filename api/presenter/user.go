@@ -0,0 +1,53 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+const (
+	usersBase = "/v3/users"
+)
+
+type UserResponse struct {
+	GUID             string    `json:"guid"`
+	CreatedAt        string    `json:"created_at"`
+	UpdatedAt        string    `json:"updated_at"`
+	Username         string    `json:"username"`
+	PresentationName string    `json:"presentation_name"`
+	Origin           string    `json:"origin"`
+	Metadata         Metadata  `json:"metadata"`
+	Links            UserLinks `json:"links"`
+}
+
+type UserLinks struct {
+	Self *Link `json:"self"`
+}
+
+func ForUserList(users []repositories.UserRecord, apiBaseURL, requestURL url.URL) ListResponse {
+	userResponses := make([]interface{}, 0, len(users))
+	for _, user := range users {
+		userResponses = append(userResponses, ForUser(user, apiBaseURL))
+	}
+
+	return ForList(userResponses, apiBaseURL, requestURL)
+}
+
+func ForUser(user repositories.UserRecord, apiBaseURL url.URL) UserResponse {
+	return UserResponse{
+		GUID:             user.GUID,
+		Username:         user.Name,
+		PresentationName: user.Name,
+		Origin:           user.Origin,
+		Metadata: Metadata{
+			Labels:      map[string]string{},
+			Annotations: map[string]string{},
+		},
+		Links: UserLinks{
+			Self: &Link{
+				HRef: buildURL(apiBaseURL).appendPath(usersBase, user.GUID).build(),
+			},
+		},
+	}
+}
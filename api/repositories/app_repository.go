@@ -36,6 +36,8 @@ const (
 	CFAppGUIDLabel     string = "korifi.cloudfoundry.org/app-guid"
 	AppResourceType    string = "App"
 	AppEnvResourceType string = "App Env"
+
+	AppFeatureServiceBindingK8s string = "service-binding-k8s"
 )
 
 type AppRepo struct {
@@ -73,12 +75,17 @@ type AppRecord struct {
 	CreatedAt             string
 	UpdatedAt             string
 	IsStaged              bool
+	Features              AppFeatures
 	envSecretName         string
 	vcapServiceSecretName string
 }
 
 type DesiredState string
 
+type AppFeatures struct {
+	ServiceBindingK8sEnabled bool
+}
+
 type Lifecycle struct {
 	Type string
 	Data LifecycleData
@@ -165,6 +172,12 @@ type SetAppDesiredStateMessage struct {
 	DesiredState string
 }
 
+type PatchAppFeaturesMessage struct {
+	AppGUID                  string
+	SpaceGUID                string
+	ServiceBindingK8sEnabled *bool
+}
+
 type ListAppsMessage struct {
 	Names      []string
 	Guids      []string
@@ -534,6 +547,31 @@ func (f *AppRepo) SetAppDesiredState(ctx context.Context, authInfo authorization
 	return cfAppToAppRecord(*cfApp), nil
 }
 
+func (f *AppRepo) PatchAppFeatures(ctx context.Context, authInfo authorization.Info, message PatchAppFeaturesMessage) (AppRecord, error) {
+	userClient, err := f.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return AppRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfApp := &korifiv1alpha1.CFApp{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      message.AppGUID,
+			Namespace: message.SpaceGUID,
+		},
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfApp, func() {
+		if message.ServiceBindingK8sEnabled != nil {
+			cfApp.Spec.ServiceBindingK8sEnabled = message.ServiceBindingK8sEnabled
+		}
+	})
+	if err != nil {
+		return AppRecord{}, fmt.Errorf("failed to patch app features: %w", apierrors.FromK8sError(err, AppResourceType))
+	}
+
+	return cfAppToAppRecord(*cfApp), nil
+}
+
 func (f *AppRepo) DeleteApp(ctx context.Context, authInfo authorization.Info, message DeleteAppMessage) error {
 	cfApp := &korifiv1alpha1.CFApp{
 		ObjectMeta: metav1.ObjectMeta{
@@ -679,9 +717,12 @@ func cfAppToAppRecord(cfApp korifiv1alpha1.CFApp) AppRecord {
 				Stack:      cfApp.Spec.Lifecycle.Data.Stack,
 			},
 		},
-		CreatedAt:             cfApp.CreationTimestamp.UTC().Format(TimestampFormat),
-		UpdatedAt:             updatedAtTime,
-		IsStaged:              meta.IsStatusConditionTrue(cfApp.Status.Conditions, workloads.StatusConditionStaged),
+		CreatedAt: cfApp.CreationTimestamp.UTC().Format(TimestampFormat),
+		UpdatedAt: updatedAtTime,
+		IsStaged:  meta.IsStatusConditionTrue(cfApp.Status.Conditions, workloads.StatusConditionStaged),
+		Features: AppFeatures{
+			ServiceBindingK8sEnabled: cfApp.IsServiceBindingK8sEnabled(),
+		},
 		envSecretName:         cfApp.Spec.EnvSecretName,
 		vcapServiceSecretName: cfApp.Status.VCAPServicesSecretName,
 	}
@@ -16,6 +16,7 @@ import (
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/env"
 	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -92,6 +93,7 @@ var _ = Describe("AppRepository", func() {
 					},
 				}))
 				Expect(app.IsStaged).To(BeFalse())
+				Expect(app.Features.ServiceBindingK8sEnabled).To(BeTrue())
 			})
 
 			When("the app has staged condition true", func() {
@@ -1270,6 +1272,51 @@ var _ = Describe("AppRepository", func() {
 		})
 	})
 
+	Describe("PatchAppFeatures", func() {
+		var (
+			appGUID   string
+			appRecord AppRecord
+			patchErr  error
+		)
+
+		BeforeEach(func() {
+			appGUID = generateGUID()
+			_ = createAppCR(testCtx, k8sClient, "some-app", appGUID, cfSpace.Name, "STOPPED")
+		})
+
+		JustBeforeEach(func() {
+			appRecord, patchErr = appRepo.PatchAppFeatures(testCtx, authInfo, PatchAppFeaturesMessage{
+				AppGUID:                  appGUID,
+				SpaceGUID:                cfSpace.Name,
+				ServiceBindingK8sEnabled: tools.PtrTo(false),
+			})
+		})
+
+		When("the user has permission to patch the app", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("returns the updated app record", func() {
+				Expect(patchErr).NotTo(HaveOccurred())
+				Expect(appRecord.GUID).To(Equal(appGUID))
+				Expect(appRecord.Features.ServiceBindingK8sEnabled).To(BeFalse())
+			})
+
+			It("disables the service-binding-k8s feature on the app", func() {
+				updatedCFApp := new(korifiv1alpha1.CFApp)
+				Expect(k8sClient.Get(testCtx, types.NamespacedName{Name: appGUID, Namespace: cfSpace.Name}, updatedCFApp)).To(Succeed())
+				Expect(updatedCFApp.Spec.ServiceBindingK8sEnabled).To(PointTo(BeFalse()))
+			})
+		})
+
+		When("the user is not authorized", func() {
+			It("returns a forbidden error", func() {
+				Expect(patchErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+		})
+	})
+
 	Describe("DeleteApp", func() {
 		var (
 			appGUID      string
@@ -8,49 +8,95 @@ import (
 	"code.cloudfoundry.org/korifi/api/apierrors"
 	"code.cloudfoundry.org/korifi/api/authorization"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/coordination"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/networking"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 	"github.com/google/uuid"
 
+	v1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+//+kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get,namespace=ROOT_NAMESPACE
+
 const (
 	DomainResourceType = "Domain"
 )
 
 type DomainRepo struct {
-	userClientFactory  authorization.UserK8sClientFactory
-	namespaceRetriever NamespaceRetriever
-	rootNamespace      string
+	userClientFactory    authorization.UserK8sClientFactory
+	namespaceRetriever   NamespaceRetriever
+	namespacePermissions *authorization.NamespacePermissions
+	privilegedClient     client.Client
+	routeNameRegistry    coordination.NameRegistry
+	rootNamespace        string
 }
 
 func NewDomainRepo(
 	userClientFactory authorization.UserK8sClientFactory,
 	namespaceRetriever NamespaceRetriever,
+	namespacePermissions *authorization.NamespacePermissions,
+	privilegedClient client.Client,
 	rootNamespace string,
 ) *DomainRepo {
 	return &DomainRepo{
-		userClientFactory:  userClientFactory,
-		namespaceRetriever: namespaceRetriever,
-		rootNamespace:      rootNamespace,
+		userClientFactory:    userClientFactory,
+		namespaceRetriever:   namespaceRetriever,
+		namespacePermissions: namespacePermissions,
+		privilegedClient:     privilegedClient,
+		routeNameRegistry:    coordination.NewNameRegistry(privilegedClient, networking.RouteEntityType),
+		rootNamespace:        rootNamespace,
 	}
 }
 
 type DomainRecord struct {
-	Name        string
-	GUID        string
-	Labels      map[string]string
-	Annotations map[string]string
-	Namespace   string
-	CreatedAt   string
-	UpdatedAt   string
+	Name                    string
+	GUID                    string
+	Labels                  map[string]string
+	Annotations             map[string]string
+	Namespace               string
+	OrganizationGUID        string
+	SharedOrganizationGUIDs []string
+	RouterGroupGUID         string
+	Internal                bool
+	CreatedAt               string
+	UpdatedAt               string
+}
+
+// IsTCP reports whether the domain has a router group, in which case it only supports tcp routes
+func (r DomainRecord) IsTCP() bool {
+	return r.RouterGroupGUID != ""
+}
+
+// IsPrivate reports whether the domain is owned by an org rather than shared across the platform
+func (r DomainRecord) IsPrivate() bool {
+	return r.OrganizationGUID != ""
+}
+
+// IsAvailableIn reports whether routes for the domain can be created in the spaces of the given org
+func (r DomainRecord) IsAvailableIn(orgGUID string) bool {
+	return !r.IsPrivate() || r.OrganizationGUID == orgGUID || r.IsSharedWith(orgGUID)
+}
+
+func (r DomainRecord) IsSharedWith(orgGUID string) bool {
+	for _, sharedOrgGUID := range r.SharedOrganizationGUIDs {
+		if sharedOrgGUID == orgGUID {
+			return true
+		}
+	}
+
+	return false
 }
 
 type CreateDomainMessage struct {
-	Name     string
-	Metadata Metadata
+	Name                    string
+	OrganizationGUID        string
+	SharedOrganizationGUIDs []string
+	RouterGroupGUID         string
+	Internal                bool
+	Metadata                Metadata
 }
 
 type UpdateDomainMessage struct {
@@ -59,7 +105,27 @@ type UpdateDomainMessage struct {
 }
 
 type ListDomainsMessage struct {
-	Names []string
+	Names             []string
+	OrganizationGUIDs []string
+	// When set, only domains that routes can be created on in this org are returned
+	AvailableInOrgGUID string
+}
+
+type ShareDomainMessage struct {
+	GUID                    string
+	SharedOrganizationGUIDs []string
+}
+
+type UnshareDomainMessage struct {
+	GUID                   string
+	SharedOrganizationGUID string
+}
+
+type RouteReservationMessage struct {
+	DomainGUID string
+	Host       string
+	Path       string
+	Port       int
 }
 
 func (r *DomainRepo) GetDomain(ctx context.Context, authInfo authorization.Info, domainGUID string) (DomainRecord, error) {
@@ -76,10 +142,34 @@ func (r *DomainRepo) GetDomain(ctx context.Context, authInfo authorization.Info,
 	domain := &korifiv1alpha1.CFDomain{}
 	err = userClient.Get(ctx, client.ObjectKey{Namespace: ns, Name: domainGUID}, domain)
 	if err != nil {
-		return DomainRecord{}, apierrors.NewForbiddenError(err, DomainResourceType)
+		if ns == r.rootNamespace || !k8serrors.IsForbidden(err) {
+			return DomainRecord{}, apierrors.NewForbiddenError(err, DomainResourceType)
+		}
+
+		// users of the orgs a private domain has been shared with have no access to the namespace of the owning org
+		return r.getSharedDomain(ctx, authInfo, ns, domainGUID, err)
+	}
+
+	return r.cfDomainToDomainRecord(domain), nil
+}
+
+func (r *DomainRepo) getSharedDomain(ctx context.Context, authInfo authorization.Info, ns, domainGUID string, userErr error) (DomainRecord, error) {
+	authorizedOrgs, err := r.namespacePermissions.GetAuthorizedOrgNamespaces(ctx, authInfo)
+	if err != nil {
+		return DomainRecord{}, fmt.Errorf("failed to list namespaces for orgs with user role bindings: %w", err)
+	}
+
+	domain := &korifiv1alpha1.CFDomain{}
+	err = r.privilegedClient.Get(ctx, client.ObjectKey{Namespace: ns, Name: domainGUID}, domain)
+	if err != nil {
+		return DomainRecord{}, fmt.Errorf("failed to get domain: %w", apierrors.FromK8sError(err, DomainResourceType))
+	}
+
+	if !r.isVisible(*domain, authorizedOrgs) {
+		return DomainRecord{}, apierrors.NewForbiddenError(userErr, DomainResourceType)
 	}
 
-	return cfDomainToDomainRecord(domain), nil
+	return r.cfDomainToDomainRecord(domain), nil
 }
 
 func (r *DomainRepo) CreateDomain(ctx context.Context, authInfo authorization.Info, message CreateDomainMessage) (DomainRecord, error) {
@@ -88,15 +178,23 @@ func (r *DomainRepo) CreateDomain(ctx context.Context, authInfo authorization.In
 		return DomainRecord{}, fmt.Errorf("create-domain failed to create user client: %w", err)
 	}
 
+	namespace := r.rootNamespace
+	if message.OrganizationGUID != "" {
+		namespace = message.OrganizationGUID
+	}
+
 	cfDomain := &korifiv1alpha1.CFDomain{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        uuid.NewString(),
-			Namespace:   r.rootNamespace,
+			Namespace:   namespace,
 			Labels:      message.Metadata.Labels,
 			Annotations: message.Metadata.Annotations,
 		},
 		Spec: korifiv1alpha1.CFDomainSpec{
-			Name: message.Name,
+			Name:                message.Name,
+			SharedOrganizations: message.SharedOrganizationGUIDs,
+			RouterGroup:         message.RouterGroupGUID,
+			Internal:            message.Internal,
 		},
 	}
 
@@ -105,10 +203,15 @@ func (r *DomainRepo) CreateDomain(ctx context.Context, authInfo authorization.In
 		return DomainRecord{}, fmt.Errorf("create-domain failed: %w", apierrors.FromK8sError(err, DomainResourceType))
 	}
 
-	return cfDomainToDomainRecord(cfDomain), nil
+	return r.cfDomainToDomainRecord(cfDomain), nil
 }
 
 func (r *DomainRepo) UpdateDomain(ctx context.Context, authInfo authorization.Info, message UpdateDomainMessage) (DomainRecord, error) {
+	ns, err := r.namespaceRetriever.NamespaceFor(ctx, message.GUID, DomainResourceType)
+	if err != nil {
+		return DomainRecord{}, err
+	}
+
 	userClient, err := r.userClientFactory.BuildClient(authInfo)
 	if err != nil {
 		return DomainRecord{}, fmt.Errorf("create-domain failed to create user client: %w", err)
@@ -117,7 +220,7 @@ func (r *DomainRepo) UpdateDomain(ctx context.Context, authInfo authorization.In
 	domain := &korifiv1alpha1.CFDomain{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      message.GUID,
-			Namespace: r.rootNamespace,
+			Namespace: ns,
 		},
 	}
 
@@ -133,9 +236,11 @@ func (r *DomainRepo) UpdateDomain(ctx context.Context, authInfo authorization.In
 		return DomainRecord{}, fmt.Errorf("failed to patch domain metadata: %w", apierrors.FromK8sError(err, DomainResourceType))
 	}
 
-	return cfDomainToDomainRecord(domain), nil
+	return r.cfDomainToDomainRecord(domain), nil
 }
 
+// ListDomains returns the domains shared across the platform along with the private domains owned by
+// or shared with the orgs the user has a role in
 func (r *DomainRepo) ListDomains(ctx context.Context, authInfo authorization.Info, message ListDomainsMessage) ([]DomainRecord, error) {
 	userClient, err := r.userClientFactory.BuildClient(authInfo)
 	if err != nil {
@@ -152,9 +257,54 @@ func (r *DomainRepo) ListDomains(ctx context.Context, authInfo authorization.Inf
 		return []DomainRecord{}, fmt.Errorf("failed to list domains in namespace %s: %w", r.rootNamespace, apierrors.FromK8sError(err, DomainResourceType))
 	}
 
-	filtered := applyDomainListFilterAndOrder(cfdomainList.Items, message)
+	privateDomains, err := r.listPrivateDomains(ctx, authInfo)
+	if err != nil {
+		return []DomainRecord{}, err
+	}
+
+	filtered := r.applyDomainListFilterAndOrder(append(cfdomainList.Items, privateDomains...), message)
+
+	return r.returnDomainList(filtered), nil
+}
+
+func (r *DomainRepo) listPrivateDomains(ctx context.Context, authInfo authorization.Info) ([]korifiv1alpha1.CFDomain, error) {
+	authorizedOrgs, err := r.namespacePermissions.GetAuthorizedOrgNamespaces(ctx, authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces for orgs with user role bindings: %w", err)
+	}
+
+	if len(authorizedOrgs) == 0 {
+		return nil, nil
+	}
+
+	cfdomainList := &korifiv1alpha1.CFDomainList{}
+	err = r.privilegedClient.List(ctx, cfdomainList)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list private domains: %w", apierrors.FromK8sError(err, DomainResourceType))
+	}
+
+	var privateDomains []korifiv1alpha1.CFDomain
+	for _, domain := range cfdomainList.Items {
+		if domain.Namespace != r.rootNamespace && r.isVisible(domain, authorizedOrgs) {
+			privateDomains = append(privateDomains, domain)
+		}
+	}
+
+	return privateDomains, nil
+}
 
-	return returnDomainList(filtered), nil
+func (r *DomainRepo) isVisible(domain korifiv1alpha1.CFDomain, authorizedOrgs map[string]bool) bool {
+	if domain.Namespace == r.rootNamespace || authorizedOrgs[domain.Namespace] {
+		return true
+	}
+
+	for _, orgGUID := range domain.Spec.SharedOrganizations {
+		if authorizedOrgs[orgGUID] {
+			return true
+		}
+	}
+
+	return false
 }
 
 func (r *DomainRepo) GetDomainByName(ctx context.Context, authInfo authorization.Info, domainName string) (DomainRecord, error) {
@@ -173,6 +323,11 @@ func (r *DomainRepo) GetDomainByName(ctx context.Context, authInfo authorization
 }
 
 func (r *DomainRepo) DeleteDomain(ctx context.Context, authInfo authorization.Info, domainGUID string) error {
+	ns, err := r.namespaceRetriever.NamespaceFor(ctx, domainGUID, DomainResourceType)
+	if err != nil {
+		return err
+	}
+
 	userClient, err := r.userClientFactory.BuildClient(authInfo)
 	if err != nil {
 		return fmt.Errorf("delete-domain failed to create user client: %w", err)
@@ -180,7 +335,7 @@ func (r *DomainRepo) DeleteDomain(ctx context.Context, authInfo authorization.In
 
 	cfDomain := &korifiv1alpha1.CFDomain{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace: r.rootNamespace,
+			Namespace: ns,
 			Name:      domainGUID,
 		},
 	}
@@ -193,18 +348,116 @@ func (r *DomainRepo) DeleteDomain(ctx context.Context, authInfo authorization.In
 	return nil
 }
 
-func applyDomainListFilterAndOrder(domainList []korifiv1alpha1.CFDomain, message ListDomainsMessage) []korifiv1alpha1.CFDomain {
-	var filtered []korifiv1alpha1.CFDomain
-	if len(message.Names) > 0 {
-		for _, domain := range domainList {
-			for _, name := range message.Names {
-				if domain.Spec.Name == name {
-					filtered = append(filtered, domain)
-				}
+// IsRouteReserved reports whether a route with the host and path (or the port, on tcp domains) of the
+// message exists on the domain, in any space. It checks the route names registered by the route webhook,
+// so it is only as accurate as the duplicate route validation itself
+func (r *DomainRepo) IsRouteReserved(ctx context.Context, authInfo authorization.Info, message RouteReservationMessage) (bool, error) {
+	domain, err := r.GetDomain(ctx, authInfo, message.DomainGUID)
+	if err != nil {
+		return false, err
+	}
+
+	route := korifiv1alpha1.CFRoute{
+		Spec: korifiv1alpha1.CFRouteSpec{
+			Host: message.Host,
+			Path: message.Path,
+			DomainRef: v1.ObjectReference{
+				Name:      domain.GUID,
+				Namespace: domain.Namespace,
+			},
+		},
+	}
+	if domain.IsTCP() {
+		route.Spec.Protocol = korifiv1alpha1.TCPProtocol
+		route.Spec.Port = message.Port
+	}
+
+	registered, err := r.routeNameRegistry.IsNameRegistered(ctx, r.rootNamespace, route.UniqueName(domain.RouterGroupGUID))
+	if err != nil {
+		return false, fmt.Errorf("failed to check route reservation: %w", err)
+	}
+
+	return registered, nil
+}
+
+func (r *DomainRepo) ShareDomain(ctx context.Context, authInfo authorization.Info, message ShareDomainMessage) (DomainRecord, error) {
+	domain, userClient, err := r.getPrivateDomain(ctx, authInfo, message.GUID)
+	if err != nil {
+		return DomainRecord{}, err
+	}
+
+	err = k8s.PatchResource(ctx, userClient, domain, func() {
+		for _, orgGUID := range message.SharedOrganizationGUIDs {
+			if !domain.IsSharedWith(orgGUID) {
+				domain.Spec.SharedOrganizations = append(domain.Spec.SharedOrganizations, orgGUID)
 			}
 		}
-	} else {
-		filtered = domainList
+	})
+	if err != nil {
+		return DomainRecord{}, fmt.Errorf("failed to share domain: %w", apierrors.FromK8sError(err, DomainResourceType))
+	}
+
+	return r.cfDomainToDomainRecord(domain), nil
+}
+
+func (r *DomainRepo) UnshareDomain(ctx context.Context, authInfo authorization.Info, message UnshareDomainMessage) error {
+	domain, userClient, err := r.getPrivateDomain(ctx, authInfo, message.GUID)
+	if err != nil {
+		return err
+	}
+
+	err = k8s.PatchResource(ctx, userClient, domain, func() {
+		sharedOrgs := []string{}
+		for _, orgGUID := range domain.Spec.SharedOrganizations {
+			if orgGUID != message.SharedOrganizationGUID {
+				sharedOrgs = append(sharedOrgs, orgGUID)
+			}
+		}
+		domain.Spec.SharedOrganizations = sharedOrgs
+	})
+	if err != nil {
+		return fmt.Errorf("failed to unshare domain: %w", apierrors.FromK8sError(err, DomainResourceType))
+	}
+
+	return nil
+}
+
+func (r *DomainRepo) getPrivateDomain(ctx context.Context, authInfo authorization.Info, domainGUID string) (*korifiv1alpha1.CFDomain, client.WithWatch, error) {
+	ns, err := r.namespaceRetriever.NamespaceFor(ctx, domainGUID, DomainResourceType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	domain := new(korifiv1alpha1.CFDomain)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: ns, Name: domainGUID}, domain)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get domain: %w", apierrors.FromK8sError(err, DomainResourceType))
+	}
+
+	return domain, userClient, nil
+}
+
+func (r *DomainRepo) applyDomainListFilterAndOrder(domainList []korifiv1alpha1.CFDomain, message ListDomainsMessage) []korifiv1alpha1.CFDomain {
+	var filtered []korifiv1alpha1.CFDomain
+	for _, domain := range domainList {
+		if !matchesFilter(domain.Spec.Name, message.Names) {
+			continue
+		}
+
+		if !matchesFilter(r.owningOrg(domain), message.OrganizationGUIDs) {
+			continue
+		}
+
+		if message.AvailableInOrgGUID != "" && !r.cfDomainToDomainRecord(&domain).IsAvailableIn(message.AvailableInOrgGUID) {
+			continue
+		}
+
+		filtered = append(filtered, domain)
 	}
 
 	// TODO: use the future message.Order fields to reorder the list of results
@@ -216,24 +469,37 @@ func applyDomainListFilterAndOrder(domainList []korifiv1alpha1.CFDomain, message
 	return filtered
 }
 
-func returnDomainList(domainList []korifiv1alpha1.CFDomain) []DomainRecord {
+func (r *DomainRepo) returnDomainList(domainList []korifiv1alpha1.CFDomain) []DomainRecord {
 	domainRecords := make([]DomainRecord, 0, len(domainList))
 
 	for i := range domainList {
-		domainRecords = append(domainRecords, cfDomainToDomainRecord(&domainList[i]))
+		domainRecords = append(domainRecords, r.cfDomainToDomainRecord(&domainList[i]))
 	}
 	return domainRecords
 }
 
-func cfDomainToDomainRecord(cfDomain *korifiv1alpha1.CFDomain) DomainRecord {
+// owningOrg returns the GUID of the org owning a private domain, or an empty string for shared domains
+func (r *DomainRepo) owningOrg(cfDomain korifiv1alpha1.CFDomain) string {
+	if cfDomain.Namespace == r.rootNamespace {
+		return ""
+	}
+
+	return cfDomain.Namespace
+}
+
+func (r *DomainRepo) cfDomainToDomainRecord(cfDomain *korifiv1alpha1.CFDomain) DomainRecord {
 	updatedAtTime, _ := getTimeLastUpdatedTimestamp(&cfDomain.ObjectMeta)
 	return DomainRecord{
-		Name:        cfDomain.Spec.Name,
-		GUID:        cfDomain.Name,
-		Namespace:   cfDomain.Namespace,
-		CreatedAt:   cfDomain.CreationTimestamp.UTC().Format(TimestampFormat),
-		UpdatedAt:   updatedAtTime,
-		Labels:      cfDomain.Labels,
-		Annotations: cfDomain.Annotations,
+		Name:                    cfDomain.Spec.Name,
+		GUID:                    cfDomain.Name,
+		Namespace:               cfDomain.Namespace,
+		OrganizationGUID:        r.owningOrg(*cfDomain),
+		SharedOrganizationGUIDs: cfDomain.Spec.SharedOrganizations,
+		RouterGroupGUID:         cfDomain.Spec.RouterGroup,
+		Internal:                cfDomain.Spec.Internal,
+		CreatedAt:               cfDomain.CreationTimestamp.UTC().Format(TimestampFormat),
+		UpdatedAt:               updatedAtTime,
+		Labels:                  cfDomain.Labels,
+		Annotations:             cfDomain.Annotations,
 	}
 }
@@ -7,6 +7,8 @@ import (
 	"code.cloudfoundry.org/korifi/api/apierrors"
 	. "code.cloudfoundry.org/korifi/api/repositories"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/coordination"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/networking"
 	"code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
@@ -16,6 +18,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -43,7 +46,7 @@ var _ = Describe("DomainRepository", func() {
 		}
 		Expect(k8sClient.Create(ctx, cfDomain)).To(Succeed())
 
-		domainRepo = NewDomainRepo(userClientFactory, namespaceRetriever, rootNamespace)
+		domainRepo = NewDomainRepo(userClientFactory, namespaceRetriever, nsPerms, k8sClient, rootNamespace)
 	})
 
 	AfterEach(func() {
@@ -144,6 +147,68 @@ var _ = Describe("DomainRepository", func() {
 				Expect(createdCFDomain.Labels).To(HaveKeyWithValue("foo", "bar"))
 				Expect(createdCFDomain.Annotations).To(HaveKeyWithValue("bar", "baz"))
 			})
+
+			When("the domain has a router group", func() {
+				BeforeEach(func() {
+					domainCreate.RouterGroupGUID = "default-tcp"
+				})
+
+				It("creates a tcp domain", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(createdDomain.RouterGroupGUID).To(Equal("default-tcp"))
+					Expect(createdDomain.IsTCP()).To(BeTrue())
+
+					createdCFDomain := new(korifiv1alpha1.CFDomain)
+					Expect(k8sClient.Get(ctx, types.NamespacedName{Name: createdDomain.GUID, Namespace: rootNamespace}, createdCFDomain)).To(Succeed())
+					Expect(createdCFDomain.Spec.RouterGroup).To(Equal("default-tcp"))
+				})
+			})
+
+			When("the domain is internal", func() {
+				BeforeEach(func() {
+					domainCreate.Internal = true
+				})
+
+				It("creates an internal domain", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(createdDomain.Internal).To(BeTrue())
+
+					createdCFDomain := new(korifiv1alpha1.CFDomain)
+					Expect(k8sClient.Get(ctx, types.NamespacedName{Name: createdDomain.GUID, Namespace: rootNamespace}, createdCFDomain)).To(Succeed())
+					Expect(createdCFDomain.Spec.Internal).To(BeTrue())
+				})
+			})
+		})
+
+		When("the domain is owned by an org", func() {
+			var org *korifiv1alpha1.CFOrg
+
+			BeforeEach(func() {
+				org = createOrgWithCleanup(ctx, prefixedGUID("org"))
+				domainCreate.OrganizationGUID = org.Name
+				domainCreate.SharedOrganizationGUIDs = []string{"other-org-guid"}
+			})
+
+			It("fails because the user is not an org manager", func() {
+				Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+
+			When("the user is an org manager", func() {
+				BeforeEach(func() {
+					createRoleBinding(ctx, userName, orgManagerRole.Name, org.Name)
+				})
+
+				It("creates a private domain in the org namespace", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(createdDomain.OrganizationGUID).To(Equal(org.Name))
+					Expect(createdDomain.SharedOrganizationGUIDs).To(ConsistOf("other-org-guid"))
+					Expect(createdDomain.IsPrivate()).To(BeTrue())
+
+					createdCFDomain := new(korifiv1alpha1.CFDomain)
+					Expect(k8sClient.Get(ctx, types.NamespacedName{Name: createdDomain.GUID, Namespace: org.Name}, createdCFDomain)).To(Succeed())
+					Expect(createdCFDomain.Spec.SharedOrganizations).To(ConsistOf("other-org-guid"))
+				})
+			})
 		})
 	})
 
@@ -340,6 +405,66 @@ var _ = Describe("DomainRepository", func() {
 		})
 	})
 
+	Describe("IsRouteReserved", func() {
+		var (
+			message  RouteReservationMessage
+			reserved bool
+			checkErr error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, rootNamespaceUserRole.Name, rootNamespace)
+
+			route := korifiv1alpha1.CFRoute{
+				Spec: korifiv1alpha1.CFRouteSpec{
+					Host: "my-host",
+					Path: "/my-path",
+					DomainRef: corev1.ObjectReference{
+						Name:      domainGUID,
+						Namespace: rootNamespace,
+					},
+				},
+			}
+			Expect(coordination.NewNameRegistry(k8sClient, networking.RouteEntityType).RegisterName(ctx, rootNamespace, route.UniqueName(""))).To(Succeed())
+
+			message = RouteReservationMessage{
+				DomainGUID: domainGUID,
+				Host:       "My-Host",
+				Path:       "/my-path",
+			}
+		})
+
+		JustBeforeEach(func() {
+			reserved, checkErr = domainRepo.IsRouteReserved(ctx, authInfo, message)
+		})
+
+		It("reports the route as reserved", func() {
+			Expect(checkErr).NotTo(HaveOccurred())
+			Expect(reserved).To(BeTrue())
+		})
+
+		When("no route has the path", func() {
+			BeforeEach(func() {
+				message.Path = "/another-path"
+			})
+
+			It("reports the route as not reserved", func() {
+				Expect(checkErr).NotTo(HaveOccurred())
+				Expect(reserved).To(BeFalse())
+			})
+		})
+
+		When("the domain does not exist", func() {
+			BeforeEach(func() {
+				message.DomainGUID = "i-dont-exist"
+			})
+
+			It("returns a not found error", func() {
+				Expect(checkErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+
 	Describe("Delete Domain", func() {
 		var (
 			deleteGUID string
@@ -373,4 +498,190 @@ var _ = Describe("DomainRepository", func() {
 			})
 		})
 	})
+
+	Describe("private domains", func() {
+		var (
+			ownerOrg      *korifiv1alpha1.CFOrg
+			sharedOrg     *korifiv1alpha1.CFOrg
+			privateDomain *korifiv1alpha1.CFDomain
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, rootNamespaceUserRole.Name, rootNamespace)
+
+			ownerOrg = createOrgWithCleanup(ctx, prefixedGUID("owner-org"))
+			sharedOrg = createOrgWithCleanup(ctx, prefixedGUID("shared-org"))
+
+			privateDomain = &korifiv1alpha1.CFDomain{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      generateGUID(),
+					Namespace: ownerOrg.Name,
+				},
+				Spec: korifiv1alpha1.CFDomainSpec{
+					Name:                "private.domain",
+					SharedOrganizations: []string{sharedOrg.Name},
+				},
+			}
+			Expect(k8sClient.Create(ctx, privateDomain)).To(Succeed())
+		})
+
+		Describe("GetDomain", func() {
+			var (
+				domain DomainRecord
+				getErr error
+			)
+
+			JustBeforeEach(func() {
+				domain, getErr = domainRepo.GetDomain(ctx, authInfo, privateDomain.Name)
+			})
+
+			It("returns a forbidden error", func() {
+				Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+
+			When("the user is a member of the owning org", func() {
+				BeforeEach(func() {
+					createRoleBinding(ctx, userName, orgUserRole.Name, ownerOrg.Name)
+				})
+
+				It("returns the domain", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(domain.GUID).To(Equal(privateDomain.Name))
+					Expect(domain.OrganizationGUID).To(Equal(ownerOrg.Name))
+					Expect(domain.SharedOrganizationGUIDs).To(ConsistOf(sharedOrg.Name))
+				})
+			})
+
+			When("the user is a member of an org the domain is shared with", func() {
+				BeforeEach(func() {
+					createRoleBinding(ctx, userName, orgUserRole.Name, sharedOrg.Name)
+				})
+
+				It("returns the domain", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(domain.GUID).To(Equal(privateDomain.Name))
+					Expect(domain.OrganizationGUID).To(Equal(ownerOrg.Name))
+				})
+			})
+		})
+
+		Describe("ListDomains", func() {
+			var (
+				message       ListDomainsMessage
+				domainRecords []DomainRecord
+				listErr       error
+			)
+
+			BeforeEach(func() {
+				message = ListDomainsMessage{}
+			})
+
+			JustBeforeEach(func() {
+				domainRecords, listErr = domainRepo.ListDomains(ctx, authInfo, message)
+			})
+
+			It("only returns shared domains", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(domainRecords).To(ContainElement(MatchFields(IgnoreExtras, Fields{"GUID": Equal(domainGUID)})))
+				Expect(domainRecords).NotTo(ContainElement(MatchFields(IgnoreExtras, Fields{"GUID": Equal(privateDomain.Name)})))
+			})
+
+			When("the user is a member of an org the domain is shared with", func() {
+				BeforeEach(func() {
+					createRoleBinding(ctx, userName, orgUserRole.Name, sharedOrg.Name)
+				})
+
+				It("returns the private domain", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(domainRecords).To(ContainElements(
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(domainGUID), "OrganizationGUID": BeEmpty()}),
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(privateDomain.Name), "OrganizationGUID": Equal(ownerOrg.Name)}),
+					))
+				})
+
+				When("filtering by owning organization", func() {
+					BeforeEach(func() {
+						message.OrganizationGUIDs = []string{ownerOrg.Name}
+					})
+
+					It("only returns the domains owned by the org", func() {
+						Expect(listErr).NotTo(HaveOccurred())
+						Expect(domainRecords).To(ConsistOf(MatchFields(IgnoreExtras, Fields{"GUID": Equal(privateDomain.Name)})))
+					})
+				})
+
+				When("filtering by the org the domains are available in", func() {
+					BeforeEach(func() {
+						message.AvailableInOrgGUID = "another-org"
+					})
+
+					It("only returns shared domains", func() {
+						Expect(listErr).NotTo(HaveOccurred())
+						Expect(domainRecords).To(ContainElement(MatchFields(IgnoreExtras, Fields{"GUID": Equal(domainGUID)})))
+						Expect(domainRecords).NotTo(ContainElement(MatchFields(IgnoreExtras, Fields{"GUID": Equal(privateDomain.Name)})))
+					})
+				})
+			})
+		})
+
+		Describe("ShareDomain", func() {
+			var (
+				domain   DomainRecord
+				shareErr error
+			)
+
+			JustBeforeEach(func() {
+				domain, shareErr = domainRepo.ShareDomain(ctx, authInfo, ShareDomainMessage{
+					GUID:                    privateDomain.Name,
+					SharedOrganizationGUIDs: []string{sharedOrg.Name, "another-org"},
+				})
+			})
+
+			It("returns a forbidden error", func() {
+				Expect(shareErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+
+			When("the user is a manager of the owning org", func() {
+				BeforeEach(func() {
+					createRoleBinding(ctx, userName, orgManagerRole.Name, ownerOrg.Name)
+				})
+
+				It("adds the orgs to the shared orgs of the domain", func() {
+					Expect(shareErr).NotTo(HaveOccurred())
+					Expect(domain.SharedOrganizationGUIDs).To(Equal([]string{sharedOrg.Name, "another-org"}))
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(privateDomain), privateDomain)).To(Succeed())
+					Expect(privateDomain.Spec.SharedOrganizations).To(Equal([]string{sharedOrg.Name, "another-org"}))
+				})
+			})
+		})
+
+		Describe("UnshareDomain", func() {
+			var unshareErr error
+
+			JustBeforeEach(func() {
+				unshareErr = domainRepo.UnshareDomain(ctx, authInfo, UnshareDomainMessage{
+					GUID:                   privateDomain.Name,
+					SharedOrganizationGUID: sharedOrg.Name,
+				})
+			})
+
+			It("returns a forbidden error", func() {
+				Expect(unshareErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+
+			When("the user is a manager of the owning org", func() {
+				BeforeEach(func() {
+					createRoleBinding(ctx, userName, orgManagerRole.Name, ownerOrg.Name)
+				})
+
+				It("removes the org from the shared orgs of the domain", func() {
+					Expect(unshareErr).NotTo(HaveOccurred())
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(privateDomain), privateDomain)).To(Succeed())
+					Expect(privateDomain.Spec.SharedOrganizations).To(BeEmpty())
+				})
+			})
+		})
+	})
 })
@@ -7,13 +7,15 @@ import (
 
 	"code.cloudfoundry.org/korifi/api/apierrors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
 )
 
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfapps;cfbuilds;cfpackages;cfprocesses;cfspaces;cftasks,verbs=list
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfdomains;cfroutes,verbs=list
-//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfservicebindings;cfserviceinstances,verbs=list
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfservicebindings;cfserviceinstances;cfserviceroutebindings,verbs=list
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfspacequotas,verbs=list
 
 var (
 	CFAppsGVR = schema.GroupVersionResource{
@@ -70,6 +72,18 @@ var (
 		Resource: "cfserviceinstances",
 	}
 
+	CFServiceRouteBindingsGVR = schema.GroupVersionResource{
+		Group:    "korifi.cloudfoundry.org",
+		Version:  "v1alpha1",
+		Resource: "cfserviceroutebindings",
+	}
+
+	CFSpaceQuotasGVR = schema.GroupVersionResource{
+		Group:    "korifi.cloudfoundry.org",
+		Version:  "v1alpha1",
+		Resource: "cfspacequotas",
+	}
+
 	CFSpacesGVR = schema.GroupVersionResource{
 		Group:    "korifi.cloudfoundry.org",
 		Version:  "v1alpha1",
@@ -83,17 +97,19 @@ var (
 	}
 
 	ResourceMap = map[string]schema.GroupVersionResource{
-		AppResourceType:             CFAppsGVR,
-		BuildResourceType:           CFBuildsGVR,
-		DropletResourceType:         CFDropletsGVR,
-		DomainResourceType:          CFDomainsGVR,
-		PackageResourceType:         CFPackagesGVR,
-		ProcessResourceType:         CFProcessesGVR,
-		RouteResourceType:           CFRoutesGVR,
-		ServiceBindingResourceType:  CFServiceBindingsGVR,
-		ServiceInstanceResourceType: CFServiceInstancesGVR,
-		SpaceResourceType:           CFSpacesGVR,
-		TaskResourceType:            CFTasksGVR,
+		AppResourceType:                 CFAppsGVR,
+		BuildResourceType:               CFBuildsGVR,
+		DropletResourceType:             CFDropletsGVR,
+		DomainResourceType:              CFDomainsGVR,
+		PackageResourceType:             CFPackagesGVR,
+		ProcessResourceType:             CFProcessesGVR,
+		RouteResourceType:               CFRoutesGVR,
+		ServiceBindingResourceType:      CFServiceBindingsGVR,
+		ServiceInstanceResourceType:     CFServiceInstancesGVR,
+		ServiceRouteBindingResourceType: CFServiceRouteBindingsGVR,
+		SpaceResourceType:               CFSpacesGVR,
+		SpaceQuotaResourceType:          CFSpaceQuotasGVR,
+		TaskResourceType:                CFTasksGVR,
 	}
 )
 
@@ -126,11 +142,18 @@ func (nr NamespaceRetriever) NamespaceFor(ctx context.Context, resourceGUID, res
 		return "", apierrors.NewNotFoundError(fmt.Errorf("resource %q not found", resourceGUID), resourceType)
 	}
 
-	if len(list.Items) > 1 {
+	items := list.Items
+	if len(items) > 1 {
+		// resources that move between namespaces, such as transferred routes, briefly exist
+		// in both namespaces while the old one is being deleted
+		items = withoutTerminating(items)
+	}
+
+	if len(items) != 1 {
 		return "", fmt.Errorf("get-%s duplicate records exist", strings.ToLower(resourceType))
 	}
 
-	metadata := list.Items[0].Object["metadata"].(map[string]interface{})
+	metadata := items[0].Object["metadata"].(map[string]interface{})
 
 	ns := metadata["namespace"].(string)
 
@@ -140,3 +163,14 @@ func (nr NamespaceRetriever) NamespaceFor(ctx context.Context, resourceGUID, res
 
 	return ns, nil
 }
+
+func withoutTerminating(items []unstructured.Unstructured) []unstructured.Unstructured {
+	var result []unstructured.Unstructured
+	for _, item := range items {
+		if item.GetDeletionTimestamp() == nil {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
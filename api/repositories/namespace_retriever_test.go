@@ -3,9 +3,11 @@ package repositories_test
 import (
 	"code.cloudfoundry.org/korifi/api/apierrors"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 var _ = Describe("NamespaceRetriever", func() {
@@ -67,4 +69,25 @@ var _ = Describe("NamespaceRetriever", func() {
 			Expect(retErr).To(MatchError(ContainSubstring("duplicate records exist")))
 		})
 	})
+
+	When("all but one of the duplicates are being deleted", func() {
+		BeforeEach(func() {
+			space2 := createSpaceWithCleanup(ctx, orgGUID, prefixedGUID("space2"))
+			app2 := createAppCR(ctx, k8sClient, "app2", appGUID, space2.Name, "STOPPED")
+			Expect(k8s.PatchResource(ctx, k8sClient, app2, func() {
+				controllerutil.AddFinalizer(app2, "test.korifi.cloudfoundry.org/keep")
+			})).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8s.PatchResource(ctx, k8sClient, app2, func() {
+					controllerutil.RemoveFinalizer(app2, "test.korifi.cloudfoundry.org/keep")
+				})).To(Succeed())
+			})
+			Expect(k8sClient.Delete(ctx, app2)).To(Succeed())
+		})
+
+		It("returns the namespace of the resource that is not being deleted", func() {
+			Expect(retErr).NotTo(HaveOccurred())
+			Expect(retNS).To(Equal(spaceGUID))
+		})
+	})
 })
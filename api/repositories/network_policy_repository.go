@@ -0,0 +1,233 @@
+package repositories
+
+import (
+	"context"
+	"crypto/sha1"
+	"fmt"
+	"sort"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	authv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const NetworkPolicyResourceType = "Network Policy"
+
+type NetworkPolicyRecord struct {
+	SourceAppGUID        string
+	SourceSpaceGUID      string
+	DestinationAppGUID   string
+	DestinationSpaceGUID string
+	Protocol             string
+	StartPort            int
+	EndPort              int
+}
+
+type CreateNetworkPolicyMessage struct {
+	SourceAppGUID        string
+	SourceSpaceGUID      string
+	DestinationAppGUID   string
+	DestinationSpaceGUID string
+	Protocol             string
+	StartPort            int
+	EndPort              int
+}
+
+type DeleteNetworkPolicyMessage struct {
+	SourceAppGUID        string
+	DestinationAppGUID   string
+	DestinationSpaceGUID string
+	Protocol             string
+	StartPort            int
+	EndPort              int
+}
+
+// ListNetworkPoliciesMessage filters policies by app GUID. An app matches if it is either the source or
+// the destination of the policy, unless the more specific source/destination filters are used
+type ListNetworkPoliciesMessage struct {
+	AppGUIDs            []string
+	SourceAppGUIDs      []string
+	DestinationAppGUIDs []string
+}
+
+// NetworkPolicyRepo manages the CFNetworkPolicies, which live in the namespace of the destination app
+type NetworkPolicyRepo struct {
+	userClientFactory    authorization.UserK8sClientFactory
+	namespacePermissions *authorization.NamespacePermissions
+}
+
+func NewNetworkPolicyRepo(userClientFactory authorization.UserK8sClientFactory, authPerms *authorization.NamespacePermissions) *NetworkPolicyRepo {
+	return &NetworkPolicyRepo{
+		userClientFactory:    userClientFactory,
+		namespacePermissions: authPerms,
+	}
+}
+
+// CreateNetworkPolicy creates the policy unless an identical one already exists. Creating the policy in
+// the destination space needs write access to that space, and the user must also be able to modify the
+// source app, as the policy opens the egress of its pods
+func (r *NetworkPolicyRepo) CreateNetworkPolicy(ctx context.Context, authInfo authorization.Info, message CreateNetworkPolicyMessage) (NetworkPolicyRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return NetworkPolicyRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	allowed, err := canIPatchCFApp(ctx, userClient, message.SourceSpaceGUID, message.SourceAppGUID)
+	if err != nil {
+		return NetworkPolicyRecord{}, err
+	}
+
+	if !allowed {
+		return NetworkPolicyRecord{}, apierrors.NewForbiddenError(
+			fmt.Errorf("cannot modify source app %q", message.SourceAppGUID),
+			NetworkPolicyResourceType,
+		)
+	}
+
+	cfNetworkPolicy := &korifiv1alpha1.CFNetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      networkPolicyName(message.SourceAppGUID, message.DestinationAppGUID, message.Protocol, message.StartPort, message.EndPort),
+			Namespace: message.DestinationSpaceGUID,
+		},
+		Spec: korifiv1alpha1.CFNetworkPolicySpec{
+			Source: korifiv1alpha1.NetworkPolicySource{
+				AppGUID:   message.SourceAppGUID,
+				SpaceGUID: message.SourceSpaceGUID,
+			},
+			DestinationAppRef: corev1.LocalObjectReference{Name: message.DestinationAppGUID},
+			Protocol:          message.Protocol,
+			Ports: korifiv1alpha1.NetworkPolicyPorts{
+				Start: int32(message.StartPort),
+				End:   int32(message.EndPort),
+			},
+		},
+	}
+
+	err = userClient.Create(ctx, cfNetworkPolicy)
+	if k8serrors.IsAlreadyExists(err) {
+		err = userClient.Get(ctx, client.ObjectKeyFromObject(cfNetworkPolicy), cfNetworkPolicy)
+	}
+	if err != nil {
+		return NetworkPolicyRecord{}, apierrors.FromK8sError(err, NetworkPolicyResourceType)
+	}
+
+	return cfNetworkPolicyToRecord(*cfNetworkPolicy), nil
+}
+
+func (r *NetworkPolicyRepo) ListNetworkPolicies(ctx context.Context, authInfo authorization.Info, message ListNetworkPoliciesMessage) ([]NetworkPolicyRecord, error) {
+	nsList, err := r.namespacePermissions.GetAuthorizedSpaceNamespaces(ctx, authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces for spaces with user role bindings: %w", err)
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	records := []NetworkPolicyRecord{}
+	for ns := range nsList {
+		policyList := &korifiv1alpha1.CFNetworkPolicyList{}
+		err := userClient.List(ctx, policyList, client.InNamespace(ns))
+		if k8serrors.IsForbidden(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list network policies in namespace %s: %w", ns, apierrors.FromK8sError(err, NetworkPolicyResourceType))
+		}
+
+		for _, policy := range policyList.Items {
+			record := cfNetworkPolicyToRecord(policy)
+			if matchesNetworkPolicyFilter(record, message) {
+				records = append(records, record)
+			}
+		}
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].SourceAppGUID != records[j].SourceAppGUID {
+			return records[i].SourceAppGUID < records[j].SourceAppGUID
+		}
+		if records[i].DestinationAppGUID != records[j].DestinationAppGUID {
+			return records[i].DestinationAppGUID < records[j].DestinationAppGUID
+		}
+		return records[i].StartPort < records[j].StartPort
+	})
+
+	return records, nil
+}
+
+// DeleteNetworkPolicy deletes the policy. Deleting a policy that does not exist is not an error
+func (r *NetworkPolicyRepo) DeleteNetworkPolicy(ctx context.Context, authInfo authorization.Info, message DeleteNetworkPolicyMessage) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	err = userClient.Delete(ctx, &korifiv1alpha1.CFNetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      networkPolicyName(message.SourceAppGUID, message.DestinationAppGUID, message.Protocol, message.StartPort, message.EndPort),
+			Namespace: message.DestinationSpaceGUID,
+		},
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+
+	return apierrors.FromK8sError(err, NetworkPolicyResourceType)
+}
+
+// networkPolicyName derives the name from the policy fields, so that a policy can only exist once and
+// can be deleted by its fields, as the policy server API does not expose policy GUIDs
+func networkPolicyName(sourceAppGUID, destinationAppGUID, protocol string, startPort, endPort int) string {
+	key := fmt.Sprintf("%s:%s:%s:%d:%d", sourceAppGUID, destinationAppGUID, protocol, startPort, endPort)
+	return fmt.Sprintf("%x", sha1.Sum([]byte(key)))
+}
+
+func matchesNetworkPolicyFilter(record NetworkPolicyRecord, message ListNetworkPoliciesMessage) bool {
+	if len(message.AppGUIDs) > 0 &&
+		!contains(message.AppGUIDs, record.SourceAppGUID) &&
+		!contains(message.AppGUIDs, record.DestinationAppGUID) {
+		return false
+	}
+
+	return matchesFilter(record.SourceAppGUID, message.SourceAppGUIDs) &&
+		matchesFilter(record.DestinationAppGUID, message.DestinationAppGUIDs)
+}
+
+func cfNetworkPolicyToRecord(policy korifiv1alpha1.CFNetworkPolicy) NetworkPolicyRecord {
+	return NetworkPolicyRecord{
+		SourceAppGUID:        policy.Spec.Source.AppGUID,
+		SourceSpaceGUID:      policy.Spec.Source.SpaceGUID,
+		DestinationAppGUID:   policy.Spec.DestinationAppRef.Name,
+		DestinationSpaceGUID: policy.Namespace,
+		Protocol:             policy.Spec.Protocol,
+		StartPort:            int(policy.Spec.Ports.Start),
+		EndPort:              int(policy.Spec.Ports.End),
+	}
+}
+
+func canIPatchCFApp(ctx context.Context, userClient client.Client, namespace, appGUID string) (bool, error) {
+	review := authv1.SelfSubjectAccessReview{
+		Spec: authv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      "patch",
+				Group:     "korifi.cloudfoundry.org",
+				Resource:  "cfapps",
+				Name:      appGUID,
+			},
+		},
+	}
+	if err := userClient.Create(ctx, &review); err != nil {
+		return false, fmt.Errorf("canIPatchCFApp: failed to create self subject access review: %w", apierrors.FromK8sError(err, AppResourceType))
+	}
+
+	return review.Status.Allowed, nil
+}
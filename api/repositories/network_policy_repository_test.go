@@ -0,0 +1,227 @@
+package repositories_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("NetworkPolicyRepository", func() {
+	var (
+		networkPolicyRepo *repositories.NetworkPolicyRepo
+		cfOrg             *korifiv1alpha1.CFOrg
+		sourceSpace       *korifiv1alpha1.CFSpace
+		destinationSpace  *korifiv1alpha1.CFSpace
+		createMessage     repositories.CreateNetworkPolicyMessage
+	)
+
+	BeforeEach(func() {
+		networkPolicyRepo = repositories.NewNetworkPolicyRepo(userClientFactory, nsPerms)
+		cfOrg = createOrgWithCleanup(ctx, uuid.NewString())
+		sourceSpace = createSpaceWithCleanup(ctx, cfOrg.Name, uuid.NewString())
+		destinationSpace = createSpaceWithCleanup(ctx, cfOrg.Name, uuid.NewString())
+
+		createMessage = repositories.CreateNetworkPolicyMessage{
+			SourceAppGUID:        "source-app-guid",
+			SourceSpaceGUID:      sourceSpace.Name,
+			DestinationAppGUID:   "destination-app-guid",
+			DestinationSpaceGUID: destinationSpace.Name,
+			Protocol:             "tcp",
+			StartPort:            8080,
+			EndPort:              8090,
+		}
+	})
+
+	Describe("CreateNetworkPolicy", func() {
+		var (
+			record    repositories.NetworkPolicyRecord
+			createErr error
+		)
+
+		JustBeforeEach(func() {
+			record, createErr = networkPolicyRepo.CreateNetworkPolicy(ctx, authInfo, createMessage)
+		})
+
+		When("the user is not a space developer in the destination space", func() {
+			It("returns a forbidden error", func() {
+				Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+		})
+
+		When("the user is a space developer in the destination space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, destinationSpace.Name)
+			})
+
+			It("returns a forbidden error as the user cannot modify the source app", func() {
+				Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+
+				policyList := new(korifiv1alpha1.CFNetworkPolicyList)
+				Expect(k8sClient.List(ctx, policyList, client.InNamespace(destinationSpace.Name))).To(Succeed())
+				Expect(policyList.Items).To(BeEmpty())
+			})
+
+			When("the user is a space auditor in the source space", func() {
+				BeforeEach(func() {
+					createRoleBinding(ctx, userName, spaceAuditorRole.Name, sourceSpace.Name)
+				})
+
+				It("returns a forbidden error", func() {
+					Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+				})
+			})
+		})
+
+		When("the user is a space developer in the source and destination spaces", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, sourceSpace.Name)
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, destinationSpace.Name)
+			})
+
+			It("creates the policy in the destination space", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(record).To(Equal(repositories.NetworkPolicyRecord(createMessage)))
+
+				policyList := new(korifiv1alpha1.CFNetworkPolicyList)
+				Expect(k8sClient.List(ctx, policyList, client.InNamespace(destinationSpace.Name))).To(Succeed())
+				Expect(policyList.Items).To(HaveLen(1))
+				Expect(policyList.Items[0].Spec.Source.SpaceGUID).To(Equal(sourceSpace.Name))
+				Expect(policyList.Items[0].Spec.DestinationAppRef.Name).To(Equal("destination-app-guid"))
+				Expect(policyList.Items[0].Spec.Ports).To(Equal(korifiv1alpha1.NetworkPolicyPorts{Start: 8080, End: 8090}))
+			})
+
+			When("the same policy already exists", func() {
+				BeforeEach(func() {
+					_, err := networkPolicyRepo.CreateNetworkPolicy(ctx, authInfo, createMessage)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("succeeds without creating a duplicate", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+
+					policyList := new(korifiv1alpha1.CFNetworkPolicyList)
+					Expect(k8sClient.List(ctx, policyList, client.InNamespace(destinationSpace.Name))).To(Succeed())
+					Expect(policyList.Items).To(HaveLen(1))
+				})
+			})
+		})
+	})
+
+	Describe("ListNetworkPolicies", func() {
+		var (
+			message    repositories.ListNetworkPoliciesMessage
+			records    []repositories.NetworkPolicyRecord
+			listErr    error
+			otherSpace *korifiv1alpha1.CFSpace
+		)
+
+		BeforeEach(func() {
+			message = repositories.ListNetworkPoliciesMessage{}
+
+			createRoleBinding(ctx, userName, spaceDeveloperRole.Name, destinationSpace.Name)
+			_, err := networkPolicyRepo.CreateNetworkPolicy(ctx, authInfo, createMessage)
+			Expect(err).NotTo(HaveOccurred())
+
+			otherMessage := createMessage
+			otherMessage.SourceAppGUID = "other-app-guid"
+			otherMessage.Protocol = "udp"
+			_, err = networkPolicyRepo.CreateNetworkPolicy(ctx, authInfo, otherMessage)
+			Expect(err).NotTo(HaveOccurred())
+
+			otherSpace = createSpaceWithCleanup(ctx, cfOrg.Name, uuid.NewString())
+			Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: otherSpace.Name,
+				},
+				Spec: korifiv1alpha1.CFNetworkPolicySpec{
+					Source:            korifiv1alpha1.NetworkPolicySource{AppGUID: "invisible-app-guid", SpaceGUID: otherSpace.Name},
+					DestinationAppRef: corev1.LocalObjectReference{Name: "destination-app-guid"},
+					Protocol:          "tcp",
+					Ports:             korifiv1alpha1.NetworkPolicyPorts{Start: 8080, End: 8080},
+				},
+			})).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			records, listErr = networkPolicyRepo.ListNetworkPolicies(ctx, authInfo, message)
+		})
+
+		It("lists the policies in the spaces the user has access to", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(records).To(ConsistOf(
+				HaveField("SourceAppGUID", "source-app-guid"),
+				HaveField("SourceAppGUID", "other-app-guid"),
+			))
+		})
+
+		When("filtering by app guid", func() {
+			BeforeEach(func() {
+				message.AppGUIDs = []string{"other-app-guid"}
+			})
+
+			It("returns the policies the app is the source or the destination of", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(records).To(ConsistOf(HaveField("Protocol", "udp")))
+			})
+		})
+
+		When("filtering by destination app guid", func() {
+			BeforeEach(func() {
+				message.DestinationAppGUIDs = []string{"source-app-guid"}
+			})
+
+			It("returns no policies", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(records).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("DeleteNetworkPolicy", func() {
+		var deleteErr error
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, spaceDeveloperRole.Name, destinationSpace.Name)
+			_, err := networkPolicyRepo.CreateNetworkPolicy(ctx, authInfo, createMessage)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			deleteErr = networkPolicyRepo.DeleteNetworkPolicy(ctx, authInfo, repositories.DeleteNetworkPolicyMessage{
+				SourceAppGUID:        createMessage.SourceAppGUID,
+				DestinationAppGUID:   createMessage.DestinationAppGUID,
+				DestinationSpaceGUID: createMessage.DestinationSpaceGUID,
+				Protocol:             createMessage.Protocol,
+				StartPort:            createMessage.StartPort,
+				EndPort:              createMessage.EndPort,
+			})
+		})
+
+		It("deletes the policy", func() {
+			Expect(deleteErr).NotTo(HaveOccurred())
+
+			policyList := new(korifiv1alpha1.CFNetworkPolicyList)
+			Expect(k8sClient.List(ctx, policyList, client.InNamespace(destinationSpace.Name))).To(Succeed())
+			Expect(policyList.Items).To(BeEmpty())
+		})
+
+		When("the policy does not exist", func() {
+			BeforeEach(func() {
+				createMessage.EndPort = 9000
+			})
+
+			It("succeeds", func() {
+				Expect(deleteErr).NotTo(HaveOccurred())
+			})
+		})
+	})
+})
@@ -0,0 +1,262 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/google/uuid"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	OrgQuotaResourceType = "Organization Quota"
+)
+
+// QuotaLimits holds the limits shared by org and space quotas. A nil limit is unlimited
+type QuotaLimits struct {
+	TotalMemoryInMB       *int64
+	PerProcessMemoryInMB  *int64
+	TotalInstances        *int64
+	PerAppTasks           *int64
+	TotalServiceInstances *int64
+	TotalRoutes           *int64
+}
+
+func (l QuotaLimits) toCRD() korifiv1alpha1.QuotaLimits {
+	return korifiv1alpha1.QuotaLimits{
+		Apps: korifiv1alpha1.AppsQuota{
+			TotalMemoryInMB:      l.TotalMemoryInMB,
+			PerProcessMemoryInMB: l.PerProcessMemoryInMB,
+			TotalInstances:       l.TotalInstances,
+			PerAppTasks:          l.PerAppTasks,
+		},
+		Services: korifiv1alpha1.ServicesQuota{
+			TotalServiceInstances: l.TotalServiceInstances,
+		},
+		Routes: korifiv1alpha1.RoutesQuota{
+			TotalRoutes: l.TotalRoutes,
+		},
+	}
+}
+
+func quotaLimitsFromCRD(limits korifiv1alpha1.QuotaLimits) QuotaLimits {
+	return QuotaLimits{
+		TotalMemoryInMB:       limits.Apps.TotalMemoryInMB,
+		PerProcessMemoryInMB:  limits.Apps.PerProcessMemoryInMB,
+		TotalInstances:        limits.Apps.TotalInstances,
+		PerAppTasks:           limits.Apps.PerAppTasks,
+		TotalServiceInstances: limits.Services.TotalServiceInstances,
+		TotalRoutes:           limits.Routes.TotalRoutes,
+	}
+}
+
+type OrgQuotaRecord struct {
+	GUID              string
+	Name              string
+	Limits            QuotaLimits
+	OrganizationGUIDs []string
+	CreatedAt         string
+	UpdatedAt         string
+}
+
+type CreateOrgQuotaMessage struct {
+	Name              string
+	Limits            QuotaLimits
+	OrganizationGUIDs []string
+}
+
+type ListOrgQuotasMessage struct {
+	GUIDs             []string
+	Names             []string
+	OrganizationGUIDs []string
+}
+
+type ApplyOrgQuotaMessage struct {
+	GUID              string
+	OrganizationGUIDs []string
+}
+
+// OrgQuotaRepo manages the CFOrgQuotas in the root namespace. An org is limited by at most one quota,
+// so applying a quota to an org removes the org from the quota previously applied to it
+type OrgQuotaRepo struct {
+	userClientFactory authorization.UserK8sClientFactory
+	rootNamespace     string
+}
+
+func NewOrgQuotaRepo(userClientFactory authorization.UserK8sClientFactory, rootNamespace string) *OrgQuotaRepo {
+	return &OrgQuotaRepo{
+		userClientFactory: userClientFactory,
+		rootNamespace:     rootNamespace,
+	}
+}
+
+func (r *OrgQuotaRepo) CreateOrgQuota(ctx context.Context, authInfo authorization.Info, message CreateOrgQuotaMessage) (OrgQuotaRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return OrgQuotaRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	quotas, err := r.listOrgQuotas(ctx, userClient)
+	if err != nil {
+		return OrgQuotaRecord{}, err
+	}
+
+	for _, quota := range quotas {
+		if quota.Spec.DisplayName == message.Name {
+			return OrgQuotaRecord{}, apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("Organization Quota '%s' already exists.", message.Name))
+		}
+	}
+
+	if err = r.removeOrgsFromQuotas(ctx, userClient, quotas, "", message.OrganizationGUIDs); err != nil {
+		return OrgQuotaRecord{}, err
+	}
+
+	cfOrgQuota := &korifiv1alpha1.CFOrgQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      uuid.NewString(),
+			Namespace: r.rootNamespace,
+		},
+		Spec: korifiv1alpha1.CFOrgQuotaSpec{
+			DisplayName:   message.Name,
+			QuotaLimits:   message.Limits.toCRD(),
+			Organizations: message.OrganizationGUIDs,
+		},
+	}
+
+	err = userClient.Create(ctx, cfOrgQuota)
+	if err != nil {
+		return OrgQuotaRecord{}, fmt.Errorf("failed to create org quota: %w", apierrors.FromK8sError(err, OrgQuotaResourceType))
+	}
+
+	return cfOrgQuotaToRecord(*cfOrgQuota), nil
+}
+
+func (r *OrgQuotaRepo) ListOrgQuotas(ctx context.Context, authInfo authorization.Info, message ListOrgQuotasMessage) ([]OrgQuotaRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	quotas, err := r.listOrgQuotas(ctx, userClient)
+	if err != nil {
+		return nil, err
+	}
+
+	records := []OrgQuotaRecord{}
+	for _, quota := range quotas {
+		if !matchesFilter(quota.Name, message.GUIDs) || !matchesFilter(quota.Spec.DisplayName, message.Names) {
+			continue
+		}
+
+		if len(message.OrganizationGUIDs) > 0 && !containsAny(quota.Spec.Organizations, message.OrganizationGUIDs) {
+			continue
+		}
+
+		records = append(records, cfOrgQuotaToRecord(quota))
+	}
+
+	return records, nil
+}
+
+func (r *OrgQuotaRepo) GetOrgQuota(ctx context.Context, authInfo authorization.Info, guid string) (OrgQuotaRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return OrgQuotaRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfOrgQuota := new(korifiv1alpha1.CFOrgQuota)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: guid}, cfOrgQuota)
+	if err != nil {
+		return OrgQuotaRecord{}, fmt.Errorf("failed to get org quota: %w", apierrors.FromK8sError(err, OrgQuotaResourceType))
+	}
+
+	return cfOrgQuotaToRecord(*cfOrgQuota), nil
+}
+
+func (r *OrgQuotaRepo) ApplyOrgQuota(ctx context.Context, authInfo authorization.Info, message ApplyOrgQuotaMessage) (OrgQuotaRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return OrgQuotaRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfOrgQuota := new(korifiv1alpha1.CFOrgQuota)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: message.GUID}, cfOrgQuota)
+	if err != nil {
+		return OrgQuotaRecord{}, fmt.Errorf("failed to get org quota: %w", apierrors.FromK8sError(err, OrgQuotaResourceType))
+	}
+
+	quotas, err := r.listOrgQuotas(ctx, userClient)
+	if err != nil {
+		return OrgQuotaRecord{}, err
+	}
+
+	if err = r.removeOrgsFromQuotas(ctx, userClient, quotas, message.GUID, message.OrganizationGUIDs); err != nil {
+		return OrgQuotaRecord{}, err
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfOrgQuota, func() {
+		for _, orgGUID := range message.OrganizationGUIDs {
+			if !contains(cfOrgQuota.Spec.Organizations, orgGUID) {
+				cfOrgQuota.Spec.Organizations = append(cfOrgQuota.Spec.Organizations, orgGUID)
+			}
+		}
+	})
+	if err != nil {
+		return OrgQuotaRecord{}, fmt.Errorf("failed to apply org quota: %w", apierrors.FromK8sError(err, OrgQuotaResourceType))
+	}
+
+	return cfOrgQuotaToRecord(*cfOrgQuota), nil
+}
+
+func (r *OrgQuotaRepo) listOrgQuotas(ctx context.Context, userClient client.Client) ([]korifiv1alpha1.CFOrgQuota, error) {
+	quotaList := new(korifiv1alpha1.CFOrgQuotaList)
+	err := userClient.List(ctx, quotaList, client.InNamespace(r.rootNamespace))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list org quotas: %w", apierrors.FromK8sError(err, OrgQuotaResourceType))
+	}
+
+	sort.Slice(quotaList.Items, func(i, j int) bool {
+		return quotaList.Items[i].CreationTimestamp.Before(&quotaList.Items[j].CreationTimestamp)
+	})
+
+	return quotaList.Items, nil
+}
+
+// removeOrgsFromQuotas detaches the orgs from any quota other than the one with the skipped guid
+func (r *OrgQuotaRepo) removeOrgsFromQuotas(ctx context.Context, userClient client.Client, quotas []korifiv1alpha1.CFOrgQuota, skipGUID string, orgGUIDs []string) error {
+	for i := range quotas {
+		quota := &quotas[i]
+		if quota.Name == skipGUID || !containsAny(quota.Spec.Organizations, orgGUIDs) {
+			continue
+		}
+
+		err := k8s.PatchResource(ctx, userClient, quota, func() {
+			quota.Spec.Organizations = without(quota.Spec.Organizations, orgGUIDs)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to remove orgs from org quota %q: %w", quota.Name, apierrors.FromK8sError(err, OrgQuotaResourceType))
+		}
+	}
+
+	return nil
+}
+
+func cfOrgQuotaToRecord(cfOrgQuota korifiv1alpha1.CFOrgQuota) OrgQuotaRecord {
+	updatedAt, _ := getTimeLastUpdatedTimestamp(&cfOrgQuota.ObjectMeta)
+
+	return OrgQuotaRecord{
+		GUID:              cfOrgQuota.Name,
+		Name:              cfOrgQuota.Spec.DisplayName,
+		Limits:            quotaLimitsFromCRD(cfOrgQuota.Spec.QuotaLimits),
+		OrganizationGUIDs: cfOrgQuota.Spec.Organizations,
+		CreatedAt:         formatTimestamp(cfOrgQuota.CreationTimestamp),
+		UpdatedAt:         updatedAt,
+	}
+}
@@ -0,0 +1,162 @@
+package repositories_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("OrgQuotaRepository", func() {
+	var (
+		orgQuotaRepo *repositories.OrgQuotaRepo
+		orgGUID      string
+	)
+
+	BeforeEach(func() {
+		orgQuotaRepo = repositories.NewOrgQuotaRepo(userClientFactory, rootNamespace)
+		orgGUID = uuid.NewString()
+	})
+
+	Describe("CreateOrgQuota", func() {
+		var (
+			message   repositories.CreateOrgQuotaMessage
+			record    repositories.OrgQuotaRecord
+			createErr error
+		)
+
+		BeforeEach(func() {
+			message = repositories.CreateOrgQuotaMessage{
+				Name: "my-quota",
+				Limits: repositories.QuotaLimits{
+					TotalMemoryInMB: tools.PtrTo(int64(1024)),
+					TotalRoutes:     tools.PtrTo(int64(5)),
+				},
+				OrganizationGUIDs: []string{orgGUID},
+			}
+		})
+
+		JustBeforeEach(func() {
+			record, createErr = orgQuotaRepo.CreateOrgQuota(ctx, authInfo, message)
+		})
+
+		When("the user is not an admin", func() {
+			It("returns a forbidden error", func() {
+				Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("creates the quota in the root namespace", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(record.Name).To(Equal("my-quota"))
+				Expect(record.Limits).To(Equal(message.Limits))
+				Expect(record.OrganizationGUIDs).To(ConsistOf(orgGUID))
+
+				cfOrgQuota := new(korifiv1alpha1.CFOrgQuota)
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: rootNamespace, Name: record.GUID}, cfOrgQuota)).To(Succeed())
+				Expect(cfOrgQuota.Spec.DisplayName).To(Equal("my-quota"))
+				Expect(cfOrgQuota.Spec.QuotaLimits.Apps.TotalMemoryInMB).To(Equal(tools.PtrTo(int64(1024))))
+				Expect(cfOrgQuota.Spec.Organizations).To(ConsistOf(orgGUID))
+			})
+
+			When("the org already has a quota", func() {
+				var existing repositories.OrgQuotaRecord
+
+				BeforeEach(func() {
+					var err error
+					existing, err = orgQuotaRepo.CreateOrgQuota(ctx, authInfo, repositories.CreateOrgQuotaMessage{
+						Name:              "old-quota",
+						OrganizationGUIDs: []string{orgGUID, "another-org"},
+					})
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("moves the org to the new quota", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+
+					cfOrgQuota := new(korifiv1alpha1.CFOrgQuota)
+					Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: rootNamespace, Name: existing.GUID}, cfOrgQuota)).To(Succeed())
+					Expect(cfOrgQuota.Spec.Organizations).To(ConsistOf("another-org"))
+				})
+			})
+
+			When("a quota with the same name exists", func() {
+				BeforeEach(func() {
+					_, err := orgQuotaRepo.CreateOrgQuota(ctx, authInfo, repositories.CreateOrgQuotaMessage{Name: "my-quota"})
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
+	})
+
+	Describe("List, get and apply", func() {
+		var quota1, quota2 repositories.OrgQuotaRecord
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+
+			var err error
+			quota1, err = orgQuotaRepo.CreateOrgQuota(ctx, authInfo, repositories.CreateOrgQuotaMessage{
+				Name:              "quota-1",
+				OrganizationGUIDs: []string{orgGUID},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			quota2, err = orgQuotaRepo.CreateOrgQuota(ctx, authInfo, repositories.CreateOrgQuotaMessage{Name: "quota-2"})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the quotas", func() {
+			records, err := orgQuotaRepo.ListOrgQuotas(ctx, authInfo, repositories.ListOrgQuotasMessage{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(records).To(HaveLen(2))
+			Expect(records[0].GUID).To(Equal(quota1.GUID))
+			Expect(records[1].GUID).To(Equal(quota2.GUID))
+		})
+
+		It("filters the quotas by organization", func() {
+			records, err := orgQuotaRepo.ListOrgQuotas(ctx, authInfo, repositories.ListOrgQuotasMessage{OrganizationGUIDs: []string{orgGUID}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(records).To(HaveLen(1))
+			Expect(records[0].GUID).To(Equal(quota1.GUID))
+		})
+
+		It("gets a quota", func() {
+			record, err := orgQuotaRepo.GetOrgQuota(ctx, authInfo, quota2.GUID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(record.Name).To(Equal("quota-2"))
+		})
+
+		It("returns a not found error for a missing quota", func() {
+			_, err := orgQuotaRepo.GetOrgQuota(ctx, authInfo, "does-not-exist")
+			Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+		})
+
+		It("moves the org to the applied quota", func() {
+			record, err := orgQuotaRepo.ApplyOrgQuota(ctx, authInfo, repositories.ApplyOrgQuotaMessage{
+				GUID:              quota2.GUID,
+				OrganizationGUIDs: []string{orgGUID},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(record.OrganizationGUIDs).To(ConsistOf(orgGUID))
+
+			record, err = orgQuotaRepo.GetOrgQuota(ctx, authInfo, quota1.GUID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(record.OrganizationGUIDs).To(BeEmpty())
+		})
+	})
+})
@@ -118,7 +118,7 @@ var _ = BeforeEach(func() {
 	certInspector := authorization.NewCertInspector(k8sConfig)
 	baseIDProvider := authorization.NewCertTokenIdentityProvider(tokenInspector, certInspector)
 	idProvider = authorization.NewCachingIdentityProvider(baseIDProvider, cache.NewExpiring())
-	nsPerms = authorization.NewNamespacePermissions(k8sClient, idProvider)
+	nsPerms = authorization.NewNamespacePermissions(k8sClient, idProvider, rootNamespace, nil)
 
 	mapper, err := apiutil.NewDynamicRESTMapper(k8sConfig)
 	Expect(err).NotTo(HaveOccurred())
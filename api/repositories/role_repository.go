@@ -11,6 +11,7 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"code.cloudfoundry.org/korifi/api/apierrors"
 	"code.cloudfoundry.org/korifi/api/authorization"
@@ -40,6 +41,23 @@ type CreateRoleMessage struct {
 	Kind  string
 }
 
+type ListRolesMessage struct {
+	GUIDs      []string
+	Types      []string
+	SpaceGUIDs []string
+	OrgGUIDs   []string
+	UserGUIDs  []string
+}
+
+type DeleteRoleMessage struct {
+	GUID  string
+	Type  string
+	Space string
+	Org   string
+	User  string
+	Kind  string
+}
+
 type RoleRecord struct {
 	GUID      string
 	CreatedAt time.Time
@@ -56,15 +74,27 @@ type RoleRepo struct {
 	roleMappings        map[string]config.Role
 	authorizedInChecker AuthorizedInChecker
 	userClientFactory   authorization.UserK8sClientFactory
+	privilegedClient    client.Client
+	nsPerms             *authorization.NamespacePermissions
 	spaceRepo           *SpaceRepo
 }
 
-func NewRoleRepo(userClientFactory authorization.UserK8sClientFactory, spaceRepo *SpaceRepo, authorizedInChecker AuthorizedInChecker, rootNamespace string, roleMappings map[string]config.Role) *RoleRepo {
+func NewRoleRepo(
+	userClientFactory authorization.UserK8sClientFactory,
+	privilegedClient client.Client,
+	spaceRepo *SpaceRepo,
+	authorizedInChecker AuthorizedInChecker,
+	nsPerms *authorization.NamespacePermissions,
+	rootNamespace string,
+	roleMappings map[string]config.Role,
+) *RoleRepo {
 	return &RoleRepo{
 		rootNamespace:       rootNamespace,
 		roleMappings:        roleMappings,
 		authorizedInChecker: authorizedInChecker,
 		userClientFactory:   userClientFactory,
+		privilegedClient:    privilegedClient,
+		nsPerms:             nsPerms,
 		spaceRepo:           spaceRepo,
 	}
 }
@@ -91,17 +121,18 @@ func (r *RoleRepo) CreateRole(ctx context.Context, authInfo authorization.Info,
 		}
 	}
 
-	ns := role.Space
-	if ns == "" {
-		ns = role.Org
-	}
+	ns := r.roleNamespace(role.Type, role.Space, role.Org)
 
 	roleBinding := createRoleBinding(ns, role.Type, role.Kind, role.User, role.GUID, k8sRoleConfig.Name, k8sRoleConfig.Propagate)
 
 	err = userClient.Create(ctx, &roleBinding)
 	if err != nil {
 		if k8serrors.IsAlreadyExists(err) {
-			errorDetail := fmt.Sprintf("User '%s' already has '%s' role", role.User, role.Type)
+			subjectKind := "User"
+			if role.Kind == rbacv1.GroupKind {
+				subjectKind = "Group"
+			}
+			errorDetail := fmt.Sprintf("%s '%s' already has '%s' role", subjectKind, role.User, role.Type)
 			return RoleRecord{}, apierrors.NewUnprocessableEntityError(
 				fmt.Errorf("rolebinding %s:%s already exists", roleBinding.Namespace, roleBinding.Name),
 				errorDetail,
@@ -137,6 +168,216 @@ func (r *RoleRepo) CreateRole(ctx context.Context, authInfo authorization.Info,
 	return roleRecord, nil
 }
 
+// ListRoles reconstructs roles from the role bindings created by CreateRole.
+// Role bindings are listed with the privileged client, as users are not
+// allowed to list them, and only roles in orgs and spaces the user has a role
+// in are returned. Global roles are only returned to users with a global role.
+func (r *RoleRepo) ListRoles(ctx context.Context, authInfo authorization.Info, message ListRolesMessage) ([]RoleRecord, error) {
+	authorizedOrgs, err := r.nsPerms.GetAuthorizedOrgNamespaces(ctx, authInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	hasGlobalRole, err := r.nsPerms.HasGlobalRole(ctx, authInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	authorizedSpaces, err := r.nsPerms.GetAuthorizedSpaceNamespaces(ctx, authInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	roleBindings := &rbacv1.RoleBindingList{}
+	err = r.privilegedClient.List(ctx, roleBindings, client.HasLabels{RoleGuidLabel})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rolebindings: %w", apierrors.FromK8sError(err, RoleResourceType))
+	}
+
+	var records []RoleRecord
+	for _, roleBinding := range roleBindings.Items {
+		inRootNamespace := roleBinding.Namespace == r.rootNamespace
+		if !authorizedOrgs[roleBinding.Namespace] && !authorizedSpaces[roleBinding.Namespace] && !(inRootNamespace && hasGlobalRole) {
+			continue
+		}
+
+		record, ok := r.roleBindingToRoleRecord(roleBinding, authorizedSpaces[roleBinding.Namespace])
+		if !ok {
+			continue
+		}
+
+		if !matchesFilter(record.GUID, message.GUIDs) ||
+			!matchesFilter(record.Type, message.Types) ||
+			!matchesFilter(record.Space, message.SpaceGUIDs) ||
+			!matchesFilter(record.Org, message.OrgGUIDs) ||
+			!matchesUserFilter(record, message.UserGUIDs) {
+			continue
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// matchesUserFilter compares the filter with the guid of the user the role is bound to, which is not the
+// subject name for service accounts. Roles bound to groups do not belong to any user
+func matchesUserFilter(record RoleRecord, userGUIDs []string) bool {
+	if len(userGUIDs) == 0 {
+		return true
+	}
+
+	if record.Kind == rbacv1.GroupKind {
+		return false
+	}
+
+	return matchesFilter(UserRecordForRole(record).GUID, userGUIDs)
+}
+
+func (r *RoleRepo) GetRole(ctx context.Context, authInfo authorization.Info, roleGUID string) (RoleRecord, error) {
+	records, err := r.ListRoles(ctx, authInfo, ListRolesMessage{GUIDs: []string{roleGUID}})
+	if err != nil {
+		return RoleRecord{}, err
+	}
+
+	if len(records) == 0 {
+		return RoleRecord{}, apierrors.NewNotFoundError(fmt.Errorf("role %q not found", roleGUID), RoleResourceType)
+	}
+
+	return records[0], nil
+}
+
+func (r *RoleRepo) DeleteRole(ctx context.Context, authInfo authorization.Info, message DeleteRoleMessage) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	ns := r.roleNamespace(message.Type, message.Space, message.Org)
+
+	err = userClient.Delete(ctx, &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: ns,
+			Name:      calculateRoleBindingName(message.Type, message.Kind, message.User),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete role %q: %w", message.GUID, apierrors.FromK8sError(err, RoleResourceType))
+	}
+
+	hasRoles, err := r.hasRemainingRoles(ctx, message.User, message.Kind)
+	if err != nil {
+		return err
+	}
+
+	if hasRoles {
+		return nil
+	}
+
+	err = userClient.Delete(ctx, &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      calculateRoleBindingName(cfUserRoleType, message.Kind, message.User),
+		},
+	})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to remove user %q from role %q: %w", message.User, cfUserRoleType, apierrors.FromK8sError(err, RoleResourceType))
+	}
+
+	return nil
+}
+
+func (r *RoleRepo) hasRemainingRoles(ctx context.Context, user, kind string) (bool, error) {
+	roleBindings := &rbacv1.RoleBindingList{}
+	err := r.privilegedClient.List(ctx, roleBindings, client.HasLabels{RoleGuidLabel})
+	if err != nil {
+		return false, fmt.Errorf("failed to list rolebindings: %w", apierrors.FromK8sError(err, RoleResourceType))
+	}
+
+	for _, roleBinding := range roleBindings.Items {
+		if r.isCFUserRoleBinding(roleBinding) || isPropagated(roleBinding) || roleBinding.DeletionTimestamp != nil {
+			continue
+		}
+
+		for _, subject := range roleBinding.Subjects {
+			if subject.Kind == kind && subject.Name == user {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+func (r *RoleRepo) roleBindingToRoleRecord(roleBinding rbacv1.RoleBinding, inSpace bool) (RoleRecord, bool) {
+	if isPropagated(roleBinding) || len(roleBinding.Subjects) == 0 {
+		return RoleRecord{}, false
+	}
+
+	roleType, ok := r.roleTypeFor(roleBinding.RoleRef.Name)
+	if !ok || roleType == cfUserRoleType {
+		return RoleRecord{}, false
+	}
+
+	inRootNamespace := roleBinding.Namespace == r.rootNamespace
+	if inRootNamespace != config.IsGlobalRoleType(roleType) {
+		return RoleRecord{}, false
+	}
+
+	record := RoleRecord{
+		GUID:      roleBinding.Labels[RoleGuidLabel],
+		CreatedAt: roleBinding.CreationTimestamp.Time,
+		UpdatedAt: roleBinding.CreationTimestamp.Time,
+		Type:      roleType,
+		User:      roleBinding.Subjects[0].Name,
+		Kind:      roleBinding.Subjects[0].Kind,
+	}
+
+	switch {
+	case inRootNamespace:
+		// global roles apply to all orgs and spaces
+	case inSpace:
+		record.Space = roleBinding.Namespace
+	default:
+		record.Org = roleBinding.Namespace
+	}
+
+	return record, true
+}
+
+// roleNamespace returns the namespace the role binding of a role lives in.
+// Global roles are bound in the root namespace.
+func (r *RoleRepo) roleNamespace(roleType, space, org string) string {
+	if config.IsGlobalRoleType(roleType) {
+		return r.rootNamespace
+	}
+
+	if space != "" {
+		return space
+	}
+
+	return org
+}
+
+func (r *RoleRepo) isCFUserRoleBinding(roleBinding rbacv1.RoleBinding) bool {
+	return roleBinding.Namespace == r.rootNamespace && roleBinding.RoleRef.Name == r.roleMappings[cfUserRoleType].Name
+}
+
+func (r *RoleRepo) roleTypeFor(clusterRoleName string) (string, bool) {
+	for roleType, roleConfig := range r.roleMappings {
+		if roleConfig.Name == clusterRoleName {
+			return roleType, true
+		}
+	}
+
+	return "", false
+}
+
+func isPropagated(roleBinding rbacv1.RoleBinding) bool {
+	_, ok := roleBinding.Labels[korifiv1alpha1.PropagatedFromLabel]
+	return ok
+}
+
 func (r *RoleRepo) validateOrgRequirements(ctx context.Context, role CreateRoleMessage, userIdentity authorization.Identity, authInfo authorization.Info) error {
 	space, err := r.spaceRepo.GetSpace(ctx, authInfo, role.Space)
 	if err != nil {
@@ -157,7 +398,14 @@ func (r *RoleRepo) validateOrgRequirements(ctx context.Context, role CreateRoleM
 	return nil
 }
 
-func calculateRoleBindingName(roleType, roleUser string) string {
+// calculateRoleBindingName keeps the names of user and service account role
+// bindings stable, while those of groups are kept apart from users with the
+// same name
+func calculateRoleBindingName(roleType, roleKind, roleUser string) string {
+	if roleKind == rbacv1.GroupKind {
+		roleUser = rbacv1.GroupKind + ":" + roleUser
+	}
+
 	plain := []byte(roleType + "::" + roleUser)
 	sum := sha256.Sum256(plain)
 
@@ -168,7 +416,7 @@ func createRoleBinding(namespace, roleType, roleKind, roleUser, roleGUID, roleCo
 	return rbacv1.RoleBinding{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: namespace,
-			Name:      calculateRoleBindingName(roleType, roleUser),
+			Name:      calculateRoleBindingName(roleType, roleKind, roleUser),
 			Labels: map[string]string{
 				RoleGuidLabel: roleGUID,
 			},
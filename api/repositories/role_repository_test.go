@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
 	"code.cloudfoundry.org/korifi/api/config"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/repositories/fake"
@@ -15,9 +16,12 @@ import (
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
 	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var _ = Describe("RoleRepository", func() {
@@ -42,8 +46,10 @@ var _ = Describe("RoleRepository", func() {
 		spaceRepo := repositories.NewSpaceRepo(namespaceRetriever, orgRepo, userClientFactory, nsPerms, time.Millisecond*2000)
 		roleRepo = repositories.NewRoleRepo(
 			userClientFactory,
+			k8sClient,
 			spaceRepo,
 			authorizedInChecker,
+			nsPerms,
 			rootNamespace,
 			roleMappings,
 		)
@@ -171,6 +177,44 @@ var _ = Describe("RoleRepository", func() {
 				})
 			})
 
+			When("using a group", func() {
+				BeforeEach(func() {
+					roleCreateMessage.Kind = rbacv1.GroupKind
+					roleCreateMessage.User = "developers"
+					// Sha256 sum of "organization_manager::Group:developers"
+					expectedName = "cf-397c1220863a34aad5077efe35fc761c4d2ec976af8a43402450146b5b48cac4"
+					// Sha256 sum of "cf_user::Group:developers"
+					cfUserExpectedName = "cf-05c9aceebd1ea257a4d8a67c5b9b21f7259249cba463d61eb3f31c463acbd4df"
+				})
+
+				It("succeeds and uses a group subject kind", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(createdRole.Kind).To(Equal(rbacv1.GroupKind))
+
+					roleBinding := getTheRoleBinding(expectedName, cfOrg.Name)
+					Expect(roleBinding.Subjects).To(HaveLen(1))
+					Expect(roleBinding.Subjects[0].Name).To(Equal("developers"))
+					Expect(roleBinding.Subjects[0].Kind).To(Equal(rbacv1.GroupKind))
+				})
+
+				It("binds the group to cf_user in the root namespace", func() {
+					roleBinding := getTheRoleBinding(cfUserExpectedName, rootNamespace)
+					Expect(roleBinding.Subjects).To(HaveLen(1))
+					Expect(roleBinding.Subjects[0].Kind).To(Equal(rbacv1.GroupKind))
+				})
+
+				When("the group is already bound to that role", func() {
+					It("returns an unprocessable entity error naming the group", func() {
+						anotherRoleCreateMessage := roleCreateMessage
+						anotherRoleCreateMessage.GUID = uuid.NewString()
+						_, createErr = roleRepo.CreateRole(ctx, authInfo, anotherRoleCreateMessage)
+						var apiErr apierrors.UnprocessableEntityError
+						Expect(errors.As(createErr, &apiErr)).To(BeTrue())
+						Expect(apiErr.Detail()).To(Equal("Group 'developers' already has 'organization_manager' role"))
+					})
+				})
+			})
+
 			When("the org does not exist", func() {
 				BeforeEach(func() {
 					roleCreateMessage.Org = "i-do-not-exist"
@@ -370,4 +414,404 @@ var _ = Describe("RoleRepository", func() {
 			})
 		})
 	})
+
+	Describe("List, get and delete roles", func() {
+		var (
+			cfSpace       *korifiv1alpha1.CFSpace
+			orgRole       repositories.RoleRecord
+			spaceRole     repositories.RoleRecord
+			otherUserRole repositories.RoleRecord
+		)
+
+		createRole := func(message repositories.CreateRoleMessage) repositories.RoleRecord {
+			role, err := roleRepo.CreateRole(ctx, authInfo, message)
+			ExpectWithOffset(1, err).NotTo(HaveOccurred())
+			return role
+		}
+
+		BeforeEach(func() {
+			authorizedInChecker.AuthorizedInReturns(true, nil)
+			cfSpace = createSpaceWithCleanup(ctx, cfOrg.Name, uuid.NewString())
+			createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			createRoleBinding(ctx, userName, adminRole.Name, cfOrg.Name)
+			createRoleBinding(ctx, userName, adminRole.Name, cfSpace.Name)
+
+			orgRole = createRole(repositories.CreateRoleMessage{
+				GUID: uuid.NewString(),
+				Type: "organization_user",
+				User: "myuser@example.com",
+				Kind: rbacv1.UserKind,
+				Org:  cfOrg.Name,
+			})
+			spaceRole = createRole(repositories.CreateRoleMessage{
+				GUID:  uuid.NewString(),
+				Type:  "space_developer",
+				User:  "myuser@example.com",
+				Kind:  rbacv1.UserKind,
+				Space: cfSpace.Name,
+			})
+			otherUserRole = createRole(repositories.CreateRoleMessage{
+				GUID: uuid.NewString(),
+				Type: "organization_manager",
+				User: "other-user@example.com",
+				Kind: rbacv1.UserKind,
+				Org:  cfOrg.Name,
+			})
+		})
+
+		Describe("ListRoles", func() {
+			var (
+				message repositories.ListRolesMessage
+				roles   []repositories.RoleRecord
+				listErr error
+			)
+
+			BeforeEach(func() {
+				message = repositories.ListRolesMessage{}
+			})
+
+			JustBeforeEach(func() {
+				roles, listErr = roleRepo.ListRoles(ctx, authInfo, message)
+			})
+
+			It("returns the roles created in the org and space", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(roles).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{
+						"GUID": Equal(orgRole.GUID),
+						"Type": Equal("organization_user"),
+						"Org":  Equal(cfOrg.Name),
+						"User": Equal("myuser@example.com"),
+						"Kind": Equal(rbacv1.UserKind),
+					}),
+					MatchFields(IgnoreExtras, Fields{
+						"GUID":  Equal(spaceRole.GUID),
+						"Type":  Equal("space_developer"),
+						"Space": Equal(cfSpace.Name),
+						"Org":   BeEmpty(),
+						"User":  Equal("myuser@example.com"),
+					}),
+					MatchFields(IgnoreExtras, Fields{
+						"GUID": Equal(otherUserRole.GUID),
+						"Type": Equal("organization_manager"),
+						"User": Equal("other-user@example.com"),
+					}),
+				))
+			})
+
+			When("filtering by type and user", func() {
+				BeforeEach(func() {
+					message = repositories.ListRolesMessage{
+						Types:     []string{"organization_user", "space_developer"},
+						UserGUIDs: []string{"myuser@example.com"},
+						OrgGUIDs:  []string{cfOrg.Name},
+					}
+				})
+
+				It("returns only the matching roles", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(roles).To(HaveLen(1))
+					Expect(roles[0].GUID).To(Equal(orgRole.GUID))
+				})
+			})
+
+			When("filtering by user and the user name is also bound as a group", func() {
+				BeforeEach(func() {
+					createRole(repositories.CreateRoleMessage{
+						GUID: uuid.NewString(),
+						Type: "organization_manager",
+						User: "myuser@example.com",
+						Kind: rbacv1.GroupKind,
+						Org:  cfOrg.Name,
+					})
+
+					message = repositories.ListRolesMessage{
+						UserGUIDs: []string{"myuser@example.com"},
+					}
+				})
+
+				It("does not return the group roles", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(roles).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(orgRole.GUID)}),
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(spaceRole.GUID)}),
+					))
+				})
+			})
+
+			When("filtering by a service account user", func() {
+				var serviceAccountRole repositories.RoleRecord
+
+				BeforeEach(func() {
+					serviceAccountRole = createRole(repositories.CreateRoleMessage{
+						GUID: uuid.NewString(),
+						Type: "organization_user",
+						User: "my-service-account",
+						Kind: rbacv1.ServiceAccountKind,
+						Org:  cfOrg.Name,
+					})
+				})
+
+				When("the filter is the user guid of the service account", func() {
+					BeforeEach(func() {
+						message = repositories.ListRolesMessage{
+							UserGUIDs: []string{repositories.UserOriginServiceAccount + ":my-service-account"},
+						}
+					})
+
+					It("returns the service account roles", func() {
+						Expect(listErr).NotTo(HaveOccurred())
+						Expect(roles).To(HaveLen(1))
+						Expect(roles[0].GUID).To(Equal(serviceAccountRole.GUID))
+					})
+				})
+
+				When("the filter is the name of the service account", func() {
+					BeforeEach(func() {
+						message = repositories.ListRolesMessage{
+							UserGUIDs: []string{"my-service-account"},
+						}
+					})
+
+					It("does not return the service account roles", func() {
+						Expect(listErr).NotTo(HaveOccurred())
+						Expect(roles).To(BeEmpty())
+					})
+				})
+			})
+
+			When("a role binding has been propagated from a parent namespace", func() {
+				BeforeEach(func() {
+					Expect(k8sClient.Create(ctx, &rbacv1.RoleBinding{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "propagated",
+							Namespace: cfSpace.Name,
+							Labels: map[string]string{
+								repositories.RoleGuidLabel:         otherUserRole.GUID,
+								korifiv1alpha1.PropagatedFromLabel: cfOrg.Name,
+							},
+						},
+						Subjects: []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "other-user@example.com"}},
+						RoleRef:  rbacv1.RoleRef{Kind: "ClusterRole", Name: orgManagerRole.Name},
+					})).To(Succeed())
+				})
+
+				It("does not list the propagated copy", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(roles).To(HaveLen(3))
+				})
+			})
+
+			When("the user has no roles in the org or space", func() {
+				BeforeEach(func() {
+					otherOrg := createOrgWithCleanup(ctx, uuid.NewString())
+					Expect(k8sClient.Create(ctx, &rbacv1.RoleBinding{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "invisible",
+							Namespace: otherOrg.Name,
+							Labels:    map[string]string{repositories.RoleGuidLabel: uuid.NewString()},
+						},
+						Subjects: []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "someone@example.com"}},
+						RoleRef:  rbacv1.RoleRef{Kind: "ClusterRole", Name: orgUserRole.Name},
+					})).To(Succeed())
+				})
+
+				It("does not list roles from that org", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(roles).To(HaveLen(3))
+				})
+			})
+		})
+
+		Describe("GetRole", func() {
+			It("returns the role", func() {
+				role, err := roleRepo.GetRole(ctx, authInfo, spaceRole.GUID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(role.GUID).To(Equal(spaceRole.GUID))
+				Expect(role.Type).To(Equal("space_developer"))
+				Expect(role.Space).To(Equal(cfSpace.Name))
+			})
+
+			When("the role does not exist", func() {
+				It("returns a not found error", func() {
+					_, err := roleRepo.GetRole(ctx, authInfo, "i-do-not-exist")
+					Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+
+		Describe("DeleteRole", func() {
+			var (
+				role      repositories.RoleRecord
+				deleteErr error
+			)
+
+			// Sha256 sum of "cf_user::myuser@example.com"
+			cfUserBindingName := "cf-156eb9a28b4143e61a5b43fb7e7a6b8de98495aa4b5da4ba871dc4eaa4c35433"
+
+			BeforeEach(func() {
+				role = spaceRole
+			})
+
+			JustBeforeEach(func() {
+				deleteErr = roleRepo.DeleteRole(ctx, authInfo, repositories.DeleteRoleMessage{
+					GUID:  role.GUID,
+					Type:  role.Type,
+					Space: role.Space,
+					Org:   role.Org,
+					User:  role.User,
+					Kind:  role.Kind,
+				})
+			})
+
+			It("deletes the role binding but keeps the cf_user binding while other roles remain", func() {
+				Expect(deleteErr).NotTo(HaveOccurred())
+
+				_, err := roleRepo.GetRole(ctx, authInfo, spaceRole.GUID)
+				Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+
+				getTheRoleBinding(cfUserBindingName, rootNamespace)
+			})
+
+			When("the user has no roles left", func() {
+				JustBeforeEach(func() {
+					Expect(deleteErr).NotTo(HaveOccurred())
+					Expect(roleRepo.DeleteRole(ctx, authInfo, repositories.DeleteRoleMessage{
+						GUID: orgRole.GUID,
+						Type: orgRole.Type,
+						Org:  orgRole.Org,
+						User: orgRole.User,
+						Kind: orgRole.Kind,
+					})).To(Succeed())
+				})
+
+				It("deletes the cf_user role binding in the root namespace", func() {
+					err := k8sClient.Get(ctx, types.NamespacedName{Name: cfUserBindingName, Namespace: rootNamespace}, &rbacv1.RoleBinding{})
+					Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+				})
+			})
+
+			When("the role is bound to a group", func() {
+				BeforeEach(func() {
+					role = createRole(repositories.CreateRoleMessage{
+						GUID: uuid.NewString(),
+						Type: "organization_user",
+						User: "myuser@example.com",
+						Kind: rbacv1.GroupKind,
+						Org:  cfOrg.Name,
+					})
+				})
+
+				It("deletes the group role binding only", func() {
+					Expect(deleteErr).NotTo(HaveOccurred())
+
+					_, err := roleRepo.GetRole(ctx, authInfo, role.GUID)
+					Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+
+					_, err = roleRepo.GetRole(ctx, authInfo, orgRole.GUID)
+					Expect(err).NotTo(HaveOccurred())
+					getTheRoleBinding(cfUserBindingName, rootNamespace)
+				})
+			})
+
+			When("the user is not allowed to delete role bindings", func() {
+				BeforeEach(func() {
+					roleBindings := &rbacv1.RoleBindingList{}
+					Expect(k8sClient.List(ctx, roleBindings, client.InNamespace(cfOrg.Name))).To(Succeed())
+					for i := range roleBindings.Items {
+						if roleBindings.Items[i].RoleRef.Name == adminRole.Name {
+							Expect(k8sClient.Delete(ctx, &roleBindings.Items[i])).To(Succeed())
+						}
+					}
+					role = otherUserRole
+				})
+
+				It("returns a forbidden error", func() {
+					Expect(deleteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+				})
+			})
+		})
+	})
+
+	Describe("Global roles", func() {
+		var (
+			globalRoleRepo *repositories.RoleRepo
+			globalRole     repositories.RoleRecord
+		)
+
+		// Sha256 sum of "admin::myuser@example.com"
+		const expectedName = "cf-b9c9fda5c3268929d63a064a55e0b73d6b142fc65d99db73710f6ea252f78ba1"
+
+		BeforeEach(func() {
+			roleMappings := map[string]config.Role{
+				"admin":   {Name: adminRole.Name, Propagate: true},
+				"cf_user": {Name: rootNamespaceUserRole.Name},
+			}
+			globalNsPerms := authorization.NewNamespacePermissions(k8sClient, idProvider, rootNamespace, []string{adminRole.Name})
+			orgRepo := repositories.NewOrgRepo(rootNamespace, k8sClient, userClientFactory, globalNsPerms, time.Millisecond*2000)
+			spaceRepo := repositories.NewSpaceRepo(namespaceRetriever, orgRepo, userClientFactory, globalNsPerms, time.Millisecond*2000)
+			globalRoleRepo = repositories.NewRoleRepo(
+				userClientFactory,
+				k8sClient,
+				spaceRepo,
+				authorizedInChecker,
+				globalNsPerms,
+				rootNamespace,
+				roleMappings,
+			)
+
+			createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+
+			var err error
+			globalRole, err = globalRoleRepo.CreateRole(ctx, authInfo, repositories.CreateRoleMessage{
+				GUID: uuid.NewString(),
+				Type: "admin",
+				User: "myuser@example.com",
+				Kind: rbacv1.UserKind,
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("binds the role in the root namespace", func() {
+			roleBinding := getTheRoleBinding(expectedName, rootNamespace)
+
+			Expect(roleBinding.Labels).To(HaveKeyWithValue(repositories.RoleGuidLabel, globalRole.GUID))
+			Expect(roleBinding.Annotations).To(HaveKeyWithValue(korifiv1alpha1.PropagateRoleBindingAnnotation, "true"))
+			Expect(roleBinding.RoleRef.Name).To(Equal(adminRole.Name))
+			Expect(roleBinding.Subjects).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Kind": Equal(rbacv1.UserKind),
+				"Name": Equal("myuser@example.com"),
+			})))
+		})
+
+		It("lists the role without an org or space to users with a global role", func() {
+			roles, err := globalRoleRepo.ListRoles(ctx, authInfo, repositories.ListRolesMessage{GUIDs: []string{globalRole.GUID}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(roles).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"GUID":  Equal(globalRole.GUID),
+				"Type":  Equal("admin"),
+				"Org":   BeEmpty(),
+				"Space": BeEmpty(),
+				"User":  Equal("myuser@example.com"),
+			})))
+		})
+
+		It("does not list the role to users without a global role", func() {
+			roles, err := roleRepo.ListRoles(ctx, authInfo, repositories.ListRolesMessage{GUIDs: []string{globalRole.GUID}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(roles).To(BeEmpty())
+		})
+
+		It("deletes the role binding in the root namespace", func() {
+			Expect(globalRoleRepo.DeleteRole(ctx, authInfo, repositories.DeleteRoleMessage{
+				GUID: globalRole.GUID,
+				Type: globalRole.Type,
+				User: globalRole.User,
+				Kind: globalRole.Kind,
+			})).To(Succeed())
+
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: expectedName, Namespace: rootNamespace}, &rbacv1.RoleBinding{})
+			Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+		})
+	})
 })
@@ -3,16 +3,21 @@ package repositories
 import (
 	"context"
 	"fmt"
+	"math/rand"
 
 	"code.cloudfoundry.org/korifi/api/apierrors"
 	"code.cloudfoundry.org/korifi/api/authorization"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/networking"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	"github.com/google/uuid"
+	authv1 "k8s.io/api/authorization/v1"
 	v1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -25,13 +30,15 @@ type RouteRepo struct {
 	namespaceRetriever   NamespaceRetriever
 	userClientFactory    authorization.UserK8sClientFactory
 	namespacePermissions *authorization.NamespacePermissions
+	rootNamespace        string
 }
 
-func NewRouteRepo(namespaceRetriever NamespaceRetriever, userClientFactory authorization.UserK8sClientFactory, authPerms *authorization.NamespacePermissions) *RouteRepo {
+func NewRouteRepo(namespaceRetriever NamespaceRetriever, userClientFactory authorization.UserK8sClientFactory, authPerms *authorization.NamespacePermissions, rootNamespace string) *RouteRepo {
 	return &RouteRepo{
 		namespaceRetriever:   namespaceRetriever,
 		userClientFactory:    userClientFactory,
 		namespacePermissions: authPerms,
+		rootNamespace:        rootNamespace,
 	}
 }
 
@@ -41,7 +48,9 @@ type DestinationRecord struct {
 	ProcessType string
 	Port        int
 	Protocol    string
-	// Weight intentionally omitted as experimental features
+	Weight      *int
+	// SpaceGUID is the space of the app. It is only set when the app is in one of the shared spaces of the route
+	SpaceGUID string
 }
 
 type RouteRecord struct {
@@ -51,11 +60,15 @@ type RouteRecord struct {
 	Host         string
 	Path         string
 	Protocol     string
+	Port         int
 	Destinations []DestinationRecord
-	Labels       map[string]string
-	Annotations  map[string]string
-	CreatedAt    string
-	UpdatedAt    string
+	// SharedSpaceGUIDs are the spaces, other than the space of the route, the route is shared with
+	SharedSpaceGUIDs []string
+	Options          map[string]string
+	Labels           map[string]string
+	Annotations      map[string]string
+	CreatedAt        string
+	UpdatedAt        string
 }
 
 type AddDestinationsToRouteMessage struct {
@@ -65,6 +78,13 @@ type AddDestinationsToRouteMessage struct {
 	NewDestinations      []DestinationMessage
 }
 
+type ReplaceRouteDestinationsMessage struct {
+	RouteGUID            string
+	SpaceGUID            string
+	ExistingDestinations []DestinationRecord
+	NewDestinations      []DestinationMessage
+}
+
 type RemoveDestinationFromRouteMessage struct {
 	RouteGUID            string
 	SpaceGUID            string
@@ -77,13 +97,35 @@ type DestinationMessage struct {
 	ProcessType string
 	Port        int
 	Protocol    string
-	// Weight intentionally omitted as experimental features
+	Weight      *int
+	// SpaceGUID is the space of the app. It is only set when the app is in one of the shared spaces of the route
+	SpaceGUID string
+}
+
+type ShareRouteMessage struct {
+	RouteGUID        string
+	SpaceGUID        string
+	SharedSpaceGUIDs []string
+}
+
+type UnshareRouteMessage struct {
+	RouteGUID       string
+	SpaceGUID       string
+	SharedSpaceGUID string
+}
+
+type TransferRouteMessage struct {
+	RouteGUID       string
+	SpaceGUID       string
+	TargetSpaceGUID string
 }
 
 type PatchRouteMetadataMessage struct {
 	MetadataPatch
 	RouteGUID string
 	SpaceGUID string
+	// Options are merged into the options of the route. Options with a nil value are removed
+	Options map[string]*string
 }
 
 func (m DestinationMessage) toCFDestination() korifiv1alpha1.Destination {
@@ -93,8 +135,10 @@ func (m DestinationMessage) toCFDestination() korifiv1alpha1.Destination {
 		AppRef: v1.LocalObjectReference{
 			Name: m.AppGUID,
 		},
+		Namespace:   m.SpaceGUID,
 		ProcessType: m.ProcessType,
 		Protocol:    m.Protocol,
+		Weight:      m.Weight,
 	}
 }
 
@@ -107,12 +151,17 @@ type ListRoutesMessage struct {
 }
 
 type CreateRouteMessage struct {
-	Host            string
-	Path            string
+	Host     string
+	Path     string
+	Protocol string
+	// Port is only set for tcp routes. When it is not set, a free port is picked from ReservablePorts
+	Port            int
+	ReservablePorts []int
 	SpaceGUID       string
 	DomainGUID      string
 	DomainName      string
 	DomainNamespace string
+	Options         map[string]string
 	Labels          map[string]string
 	Annotations     map[string]string
 }
@@ -137,11 +186,13 @@ func (m CreateRouteMessage) toCFRoute() korifiv1alpha1.CFRoute {
 		Spec: korifiv1alpha1.CFRouteSpec{
 			Host:     m.Host,
 			Path:     m.Path,
-			Protocol: "http",
+			Protocol: korifiv1alpha1.Protocol(m.Protocol),
+			Port:     m.Port,
 			DomainRef: v1.ObjectReference{
 				Name:      m.DomainGUID,
 				Namespace: m.DomainNamespace,
 			},
+			Options: m.Options,
 		},
 	}
 }
@@ -293,17 +344,30 @@ func cfRouteToRouteRecord(cfRoute korifiv1alpha1.CFRoute) RouteRecord {
 		Domain: DomainRecord{
 			GUID: cfRoute.Spec.DomainRef.Name,
 		},
-		Host:         cfRoute.Spec.Host,
-		Path:         cfRoute.Spec.Path,
-		Protocol:     "http", // TODO: Create a mutating webhook to set this default on the CFRoute
-		Destinations: destinations,
-		CreatedAt:    cfRoute.CreationTimestamp.UTC().Format(TimestampFormat),
-		UpdatedAt:    updatedAtTime,
-		Labels:       cfRoute.Labels,
-		Annotations:  cfRoute.Annotations,
+		Host:             cfRoute.Spec.Host,
+		Path:             cfRoute.Spec.Path,
+		Protocol:         routeProtocol(cfRoute),
+		Port:             cfRoute.Spec.Port,
+		Destinations:     destinations,
+		SharedSpaceGUIDs: cfRoute.Spec.SharedSpaces,
+		Options:          cfRoute.Spec.Options,
+		CreatedAt:        cfRoute.CreationTimestamp.UTC().Format(TimestampFormat),
+		UpdatedAt:        updatedAtTime,
+		Labels:           cfRoute.Labels,
+		Annotations:      cfRoute.Annotations,
 	}
 }
 
+// routeProtocol returns the protocol of the route. Routes created before the protocol was defaulted
+// by the mutating webhook have none, and are http routes
+func routeProtocol(cfRoute korifiv1alpha1.CFRoute) string {
+	if cfRoute.Spec.Protocol == "" {
+		return string(korifiv1alpha1.HTTPProtocol)
+	}
+
+	return string(cfRoute.Spec.Protocol)
+}
+
 func cfRouteDestinationToDestination(cfRouteDestination korifiv1alpha1.Destination) DestinationRecord {
 	return DestinationRecord{
 		GUID:        cfRouteDestination.GUID,
@@ -311,16 +375,35 @@ func cfRouteDestinationToDestination(cfRouteDestination korifiv1alpha1.Destinati
 		ProcessType: cfRouteDestination.ProcessType,
 		Port:        cfRouteDestination.Port,
 		Protocol:    cfRouteDestination.Protocol,
+		Weight:      cfRouteDestination.Weight,
+		SpaceGUID:   cfRouteDestination.Namespace,
 	}
 }
 
 func (f *RouteRepo) CreateRoute(ctx context.Context, authInfo authorization.Info, message CreateRouteMessage) (RouteRecord, error) {
-	cfRoute := message.toCFRoute()
 	userClient, err := f.userClientFactory.BuildClient(authInfo)
 	if err != nil {
 		return RouteRecord{}, fmt.Errorf("failed to build user client: %w", err)
 	}
 
+	// wildcard routes on shared domains catch the traffic of every unclaimed host of the domain, across
+	// all orgs, so only users who can manage shared domains may create them
+	if message.Host == korifiv1alpha1.WildcardHost && message.DomainNamespace == f.rootNamespace {
+		allowed, err := canICreateCFDomain(ctx, userClient, f.rootNamespace)
+		if err != nil {
+			return RouteRecord{}, err
+		}
+
+		if !allowed {
+			return RouteRecord{}, apierrors.NewUnprocessableEntityError(nil, "You do not have sufficient permissions to create a route with a wildcard host on a domain not scoped to an organization.")
+		}
+	}
+
+	if message.Protocol == string(korifiv1alpha1.TCPProtocol) && message.Port == 0 {
+		return f.createRouteOnFreePort(ctx, userClient, message)
+	}
+
+	cfRoute := message.toCFRoute()
 	err = userClient.Create(ctx, &cfRoute)
 	if err != nil {
 		return RouteRecord{}, apierrors.FromK8sError(err, RouteResourceType)
@@ -329,6 +412,36 @@ func (f *RouteRepo) CreateRoute(ctx context.Context, authInfo authorization.Info
 	return cfRouteToRouteRecord(cfRoute), nil
 }
 
+// createRouteOnFreePort creates a tcp route on the first reservable port that is not taken. The
+// route webhook is the source of truth for which ports are taken, so we try the ports in turn until
+// it stops rejecting the route as a duplicate. Starting from a random port makes concurrent requests
+// unlikely to compete for the same ports
+func (f *RouteRepo) createRouteOnFreePort(ctx context.Context, userClient client.Client, message CreateRouteMessage) (RouteRecord, error) {
+	portCount := len(message.ReservablePorts)
+	offset := 0
+	if portCount > 0 {
+		offset = rand.Intn(portCount)
+	}
+
+	for i := 0; i < portCount; i++ {
+		message.Port = message.ReservablePorts[(offset+i)%portCount]
+		cfRoute := message.toCFRoute()
+
+		err := userClient.Create(ctx, &cfRoute)
+		if err == nil {
+			return cfRouteToRouteRecord(cfRoute), nil
+		}
+
+		if validationError, ok := webhooks.WebhookErrorToValidationError(err); ok && validationError.Type == webhooks.DuplicateNameErrorType {
+			continue
+		}
+
+		return RouteRecord{}, apierrors.FromK8sError(err, RouteResourceType)
+	}
+
+	return RouteRecord{}, apierrors.NewUnprocessableEntityError(nil, "There are no more ports available for the router group of this domain.")
+}
+
 func (f *RouteRepo) DeleteRoute(ctx context.Context, authInfo authorization.Info, message DeleteRouteMessage) error {
 	userClient, err := f.userClientFactory.BuildClient(authInfo)
 	if err != nil {
@@ -379,6 +492,30 @@ func (f *RouteRepo) AddDestinationsToRoute(ctx context.Context, authInfo authori
 	return cfRouteToRouteRecord(*cfRoute), err
 }
 
+// ReplaceRouteDestinations replaces all destinations of the route in a single patch. Destinations that
+// are already on the route keep their guid, so that their backing services are not recreated
+func (f *RouteRepo) ReplaceRouteDestinations(ctx context.Context, authInfo authorization.Info, message ReplaceRouteDestinationsMessage) (RouteRecord, error) {
+	userClient, err := f.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return RouteRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfRoute := &korifiv1alpha1.CFRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      message.RouteGUID,
+			Namespace: message.SpaceGUID,
+		},
+	}
+	err = k8s.PatchResource(ctx, userClient, cfRoute, func() {
+		cfRoute.Spec.Destinations = replaceDestinations(message.ExistingDestinations, message.NewDestinations)
+	})
+	if err != nil {
+		return RouteRecord{}, fmt.Errorf("failed to replace destinations on route %q: %w", message.RouteGUID, apierrors.FromK8sError(err, RouteResourceType))
+	}
+
+	return cfRouteToRouteRecord(*cfRoute), nil
+}
+
 func (f *RouteRepo) RemoveDestinationFromRoute(ctx context.Context, authInfo authorization.Info, message RemoveDestinationFromRouteMessage) (RouteRecord, error) {
 	userClient, err := f.userClientFactory.BuildClient(authInfo)
 	if err != nil {
@@ -423,9 +560,33 @@ outer:
 	for _, newDest := range newDestinations {
 		for _, oldDest := range result {
 			if newDest.AppGUID == oldDest.AppRef.Name &&
+				newDest.SpaceGUID == oldDest.Namespace &&
+				newDest.ProcessType == oldDest.ProcessType &&
+				newDest.Port == oldDest.Port &&
+				newDest.Protocol == oldDest.Protocol {
+				continue outer
+			}
+		}
+		result = append(result, newDest.toCFDestination())
+	}
+
+	return result
+}
+
+func replaceDestinations(existingDestinations []DestinationRecord, newDestinations []DestinationMessage) []korifiv1alpha1.Destination {
+	result := []korifiv1alpha1.Destination{}
+
+outer:
+	for _, newDest := range newDestinations {
+		for _, oldDest := range existingDestinations {
+			if newDest.AppGUID == oldDest.AppGUID &&
+				newDest.SpaceGUID == oldDest.SpaceGUID &&
 				newDest.ProcessType == oldDest.ProcessType &&
 				newDest.Port == oldDest.Port &&
 				newDest.Protocol == oldDest.Protocol {
+				destination := newDest.toCFDestination()
+				destination.GUID = oldDest.GUID
+				result = append(result, destination)
 				continue outer
 			}
 		}
@@ -462,14 +623,232 @@ func destinationRecordsToCFDestinations(destinationRecords []DestinationRecord)
 			AppRef: v1.LocalObjectReference{
 				Name: destinationRecord.AppGUID,
 			},
+			Namespace:   destinationRecord.SpaceGUID,
 			ProcessType: destinationRecord.ProcessType,
 			Protocol:    destinationRecord.Protocol,
+			Weight:      destinationRecord.Weight,
 		})
 	}
 
 	return destinations
 }
 
+func (f *RouteRepo) ShareRoute(ctx context.Context, authInfo authorization.Info, message ShareRouteMessage) (RouteRecord, error) {
+	userClient, err := f.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return RouteRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	route := new(korifiv1alpha1.CFRoute)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: message.SpaceGUID, Name: message.RouteGUID}, route)
+	if err != nil {
+		return RouteRecord{}, fmt.Errorf("failed to get route: %w", apierrors.FromK8sError(err, RouteResourceType))
+	}
+
+	err = k8s.PatchResource(ctx, userClient, route, func() {
+		for _, spaceGUID := range message.SharedSpaceGUIDs {
+			if !isSharedWith(route, spaceGUID) {
+				route.Spec.SharedSpaces = append(route.Spec.SharedSpaces, spaceGUID)
+			}
+		}
+	})
+	if err != nil {
+		return RouteRecord{}, fmt.Errorf("failed to share route %q: %w", message.RouteGUID, apierrors.FromK8sError(err, RouteResourceType))
+	}
+
+	return cfRouteToRouteRecord(*route), nil
+}
+
+func isSharedWith(route *korifiv1alpha1.CFRoute, spaceGUID string) bool {
+	for _, sharedSpaceGUID := range route.Spec.SharedSpaces {
+		if sharedSpaceGUID == spaceGUID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// UnshareRoute stops sharing the route with the space, and removes the destinations of the route
+// whose apps are in that space
+func (f *RouteRepo) UnshareRoute(ctx context.Context, authInfo authorization.Info, message UnshareRouteMessage) error {
+	userClient, err := f.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	route := new(korifiv1alpha1.CFRoute)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: message.SpaceGUID, Name: message.RouteGUID}, route)
+	if err != nil {
+		return fmt.Errorf("failed to get route: %w", apierrors.FromK8sError(err, RouteResourceType))
+	}
+
+	err = k8s.PatchResource(ctx, userClient, route, func() {
+		var sharedSpaces []string
+		for _, spaceGUID := range route.Spec.SharedSpaces {
+			if spaceGUID != message.SharedSpaceGUID {
+				sharedSpaces = append(sharedSpaces, spaceGUID)
+			}
+		}
+		route.Spec.SharedSpaces = sharedSpaces
+
+		var destinations []korifiv1alpha1.Destination
+		for _, destination := range route.Spec.Destinations {
+			if destination.Namespace != message.SharedSpaceGUID {
+				destinations = append(destinations, destination)
+			}
+		}
+		route.Spec.Destinations = destinations
+	})
+	if err != nil {
+		return fmt.Errorf("failed to unshare route %q: %w", message.RouteGUID, apierrors.FromK8sError(err, RouteResourceType))
+	}
+
+	return nil
+}
+
+// TransferRoute moves the route to another space. As namespaces are immutable, the route is recreated
+// in the namespace of the target space. The original space becomes a shared space of the route, so that
+// its apps remain destinations of the route. The route is marked as being transferred before it is
+// recreated, so that the recreated route takes over its name and destination services. It is only deleted
+// once the recreated route exists
+func (f *RouteRepo) TransferRoute(ctx context.Context, authInfo authorization.Info, message TransferRouteMessage) (RouteRecord, error) {
+	userClient, err := f.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return RouteRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	route := new(korifiv1alpha1.CFRoute)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: message.SpaceGUID, Name: message.RouteGUID}, route)
+	if err != nil {
+		return RouteRecord{}, fmt.Errorf("failed to get route: %w", apierrors.FromK8sError(err, RouteResourceType))
+	}
+
+	allowed, err := canICreateCFRoute(ctx, userClient, message.TargetSpaceGUID)
+	if err != nil {
+		return RouteRecord{}, err
+	}
+	if !allowed {
+		return RouteRecord{}, apierrors.NewForbiddenError(nil, RouteResourceType)
+	}
+
+	err = k8s.PatchResource(ctx, userClient, route, func() {
+		if route.Annotations == nil {
+			route.Annotations = map[string]string{}
+		}
+		route.Annotations[korifiv1alpha1.CFRouteTransferredToAnnotation] = message.TargetSpaceGUID
+	})
+	if err != nil {
+		return RouteRecord{}, fmt.Errorf("failed to mark route %q as transferred: %w", message.RouteGUID, apierrors.FromK8sError(err, RouteResourceType))
+	}
+
+	transferredRoute := &korifiv1alpha1.CFRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        route.Name,
+			Namespace:   message.TargetSpaceGUID,
+			Labels:      route.Labels,
+			Annotations: transferredRouteAnnotations(route),
+		},
+		Spec: transferredRouteSpec(route, message.TargetSpaceGUID),
+	}
+
+	// the route webhook checks the transferred-to annotation on its cached copy of the original route,
+	// which may not have caught up with the patch yet
+	err = retry.OnError(authorization.NewDefaultBackoff(), isPendingTransferError, func() error {
+		return userClient.Create(ctx, transferredRoute)
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to transfer route %q: %w", message.RouteGUID, apierrors.FromK8sError(err, RouteResourceType))
+
+		rollbackErr := k8s.PatchResource(ctx, userClient, route, func() {
+			delete(route.Annotations, korifiv1alpha1.CFRouteTransferredToAnnotation)
+		})
+		if rollbackErr != nil {
+			return RouteRecord{}, fmt.Errorf("%w; failed to unmark route as transferred: %s", err, rollbackErr.Error())
+		}
+
+		return RouteRecord{}, err
+	}
+
+	// the route controller deletes the original route as well, so that the transfer completes even if
+	// we are interrupted
+	err = client.IgnoreNotFound(userClient.Delete(ctx, route))
+	if err != nil {
+		return RouteRecord{}, fmt.Errorf("failed to delete transferred route %q: %w", message.RouteGUID, apierrors.FromK8sError(err, RouteResourceType))
+	}
+
+	err = k8s.PatchResource(ctx, userClient, transferredRoute, func() {
+		delete(transferredRoute.Annotations, korifiv1alpha1.CFRouteTransferredFromAnnotation)
+	})
+	if err != nil {
+		return RouteRecord{}, fmt.Errorf("failed to complete the transfer of route %q: %w", message.RouteGUID, apierrors.FromK8sError(err, RouteResourceType))
+	}
+
+	return cfRouteToRouteRecord(*transferredRoute), nil
+}
+
+func isPendingTransferError(err error) bool {
+	validationError, ok := webhooks.WebhookErrorToValidationError(err)
+	if !ok {
+		return false
+	}
+
+	return validationError.Type == networking.RouteTransferValidationErrorType || validationError.Type == webhooks.DuplicateNameErrorType
+}
+
+func canICreateCFRoute(ctx context.Context, userClient client.Client, spaceGUID string) (bool, error) {
+	review := authv1.SelfSubjectAccessReview{
+		Spec: authv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authv1.ResourceAttributes{
+				Namespace: spaceGUID,
+				Verb:      "create",
+				Group:     "korifi.cloudfoundry.org",
+				Resource:  "cfroutes",
+			},
+		},
+	}
+	if err := userClient.Create(ctx, &review); err != nil {
+		return false, fmt.Errorf("canICreateCFRoute: failed to create self subject access review: %w", apierrors.FromK8sError(err, RouteResourceType))
+	}
+
+	return review.Status.Allowed, nil
+}
+
+func transferredRouteAnnotations(route *korifiv1alpha1.CFRoute) map[string]string {
+	annotations := map[string]string{korifiv1alpha1.CFRouteTransferredFromAnnotation: route.Namespace}
+	for key, value := range route.Annotations {
+		if key != korifiv1alpha1.CFRouteTransferredToAnnotation {
+			annotations[key] = value
+		}
+	}
+
+	return annotations
+}
+
+// transferredRouteSpec returns the spec of the route once moved to the target namespace. The destinations
+// keep their guids, and with them their services
+func transferredRouteSpec(route *korifiv1alpha1.CFRoute, targetNamespace string) korifiv1alpha1.CFRouteSpec {
+	spec := *route.Spec.DeepCopy()
+
+	spec.SharedSpaces = []string{route.Namespace}
+	for _, spaceGUID := range route.Spec.SharedSpaces {
+		if spaceGUID != targetNamespace {
+			spec.SharedSpaces = append(spec.SharedSpaces, spaceGUID)
+		}
+	}
+
+	for i, destination := range spec.Destinations {
+		namespace := route.DestinationNamespace(destination)
+		if namespace == targetNamespace {
+			namespace = ""
+		}
+
+		spec.Destinations[i].Namespace = namespace
+	}
+
+	return spec
+}
+
 func (f *RouteRepo) PatchRouteMetadata(ctx context.Context, authInfo authorization.Info, message PatchRouteMetadataMessage) (RouteRecord, error) {
 	userClient, err := f.userClientFactory.BuildClient(authInfo)
 	if err != nil {
@@ -484,6 +863,13 @@ func (f *RouteRepo) PatchRouteMetadata(ctx context.Context, authInfo authorizati
 
 	err = k8s.PatchResource(ctx, userClient, route, func() {
 		message.Apply(route)
+
+		if len(message.Options) > 0 {
+			if route.Spec.Options == nil {
+				route.Spec.Options = map[string]string{}
+			}
+			patchMap(route.Spec.Options, message.Options)
+		}
 	})
 	if err != nil {
 		return RouteRecord{}, apierrors.FromK8sError(err, RouteResourceType)
@@ -491,3 +877,21 @@ func (f *RouteRepo) PatchRouteMetadata(ctx context.Context, authInfo authorizati
 
 	return cfRouteToRouteRecord(*route), nil
 }
+
+func canICreateCFDomain(ctx context.Context, userClient client.Client, namespace string) (bool, error) {
+	review := authv1.SelfSubjectAccessReview{
+		Spec: authv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      "create",
+				Group:     "korifi.cloudfoundry.org",
+				Resource:  "cfdomains",
+			},
+		},
+	}
+	if err := userClient.Create(ctx, &review); err != nil {
+		return false, fmt.Errorf("canICreateCFDomain: failed to create self subject access review: %w", apierrors.FromK8sError(err, DomainResourceType))
+	}
+
+	return review.Status.Allowed, nil
+}
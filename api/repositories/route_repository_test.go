@@ -5,17 +5,22 @@ import (
 	"errors"
 	"time"
 
+	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
 	. "code.cloudfoundry.org/korifi/api/repositories"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/networking"
 	"code.cloudfoundry.org/korifi/tests/matchers"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -69,7 +74,7 @@ var _ = Describe("RouteRepository", func() {
 		route1GUID = prefixedGUID("route1")
 		route2GUID = prefixedGUID("route2")
 		domainGUID = prefixedGUID("domain")
-		routeRepo = NewRouteRepo(namespaceRetriever, userClientFactory, nsPerms)
+		routeRepo = NewRouteRepo(namespaceRetriever, userClientFactory, nsPerms, rootNamespace)
 
 		cfDomain := &korifiv1alpha1.CFDomain{
 			ObjectMeta: metav1.ObjectMeta{
@@ -623,6 +628,124 @@ var _ = Describe("RouteRepository", func() {
 					Expect(createdRouteErr).To(MatchError("an empty namespace may not be set during creation"))
 				})
 			})
+
+			When("the route has options", func() {
+				var createRouteMessage CreateRouteMessage
+
+				BeforeEach(func() {
+					createRouteMessage = buildCreateRouteMessage(testRouteHost, testRoutePath, domainGUID, space.Name, rootNamespace)
+					createRouteMessage.Options = map[string]string{"loadbalancing": "least-connection"}
+				})
+
+				JustBeforeEach(func() {
+					createdRouteRecord, createdRouteErr = routeRepo.CreateRoute(testCtx, authInfo, createRouteMessage)
+				})
+
+				It("creates the route with the options", func() {
+					Expect(createdRouteErr).NotTo(HaveOccurred())
+					Expect(createdRouteRecord.Options).To(Equal(map[string]string{"loadbalancing": "least-connection"}))
+
+					createdCFRoute := new(korifiv1alpha1.CFRoute)
+					Expect(k8sClient.Get(testCtx, types.NamespacedName{Name: createdRouteRecord.GUID, Namespace: space.Name}, createdCFRoute)).To(Succeed())
+					Expect(createdCFRoute.Spec.Options).To(Equal(map[string]string{"loadbalancing": "least-connection"}))
+				})
+			})
+
+			When("the route has a wildcard host", func() {
+				BeforeEach(func() {
+					testRouteHost = "*"
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(createdRouteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					Expect(createdRouteErr).To(MatchError(ContainSubstring("wildcard host")))
+				})
+
+				When("the user can manage shared domains", func() {
+					BeforeEach(func() {
+						createRoleBinding(testCtx, userName, adminRole.Name, rootNamespace)
+					})
+
+					It("creates the route", func() {
+						Expect(createdRouteErr).NotTo(HaveOccurred())
+						Expect(createdRouteRecord.Host).To(Equal("*"))
+					})
+				})
+
+				When("the domain is scoped to an organization", func() {
+					var createRouteMessage CreateRouteMessage
+
+					BeforeEach(func() {
+						orgDomainGUID := prefixedGUID("org-domain")
+						Expect(k8sClient.Create(testCtx, &korifiv1alpha1.CFDomain{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      orgDomainGUID,
+								Namespace: org.Name,
+							},
+							Spec: korifiv1alpha1.CFDomainSpec{
+								Name: orgDomainGUID + ".org.example.com",
+							},
+						})).To(Succeed())
+
+						createRouteMessage = buildCreateRouteMessage("*", testRoutePath, orgDomainGUID, space.Name, org.Name)
+					})
+
+					JustBeforeEach(func() {
+						createdRouteRecord, createdRouteErr = routeRepo.CreateRoute(testCtx, authInfo, createRouteMessage)
+					})
+
+					It("creates the route", func() {
+						Expect(createdRouteErr).NotTo(HaveOccurred())
+						Expect(createdRouteRecord.Host).To(Equal("*"))
+					})
+				})
+			})
+
+			When("creating a tcp route", func() {
+				var createRouteMessage CreateRouteMessage
+
+				BeforeEach(func() {
+					createRouteMessage = buildCreateRouteMessage("", "", domainGUID, space.Name, rootNamespace)
+					createRouteMessage.Protocol = "tcp"
+					createRouteMessage.ReservablePorts = []int{1024}
+				})
+
+				JustBeforeEach(func() {
+					createdRouteRecord, createdRouteErr = routeRepo.CreateRoute(testCtx, authInfo, createRouteMessage)
+				})
+
+				It("allocates a port from the reservable ports", func() {
+					Expect(createdRouteErr).NotTo(HaveOccurred())
+					Expect(createdRouteRecord.Protocol).To(Equal("tcp"))
+					Expect(createdRouteRecord.Port).To(Equal(1024))
+
+					createdCFRoute := new(korifiv1alpha1.CFRoute)
+					Expect(k8sClient.Get(testCtx, types.NamespacedName{Name: createdRouteRecord.GUID, Namespace: space.Name}, createdCFRoute)).To(Succeed())
+					Expect(createdCFRoute.Spec.Protocol).To(Equal(korifiv1alpha1.TCPProtocol))
+					Expect(createdCFRoute.Spec.Port).To(Equal(1024))
+				})
+
+				When("a port is requested", func() {
+					BeforeEach(func() {
+						createRouteMessage.Port = 2000
+					})
+
+					It("uses the requested port", func() {
+						Expect(createdRouteErr).NotTo(HaveOccurred())
+						Expect(createdRouteRecord.Port).To(Equal(2000))
+					})
+				})
+
+				When("there are no reservable ports", func() {
+					BeforeEach(func() {
+						createRouteMessage.ReservablePorts = nil
+					})
+
+					It("returns an unprocessable entity error", func() {
+						Expect(createdRouteErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					})
+				})
+			})
 		})
 	})
 
@@ -915,6 +1038,8 @@ var _ = Describe("RouteRepository", func() {
 									}),
 									"ProcessType": Equal("web"),
 									"Protocol":    Equal("http1"),
+									"Weight":      BeNil(),
+									"Namespace":   BeEmpty(),
 								},
 							),
 							MatchAllFields(
@@ -926,6 +1051,8 @@ var _ = Describe("RouteRepository", func() {
 									}),
 									"ProcessType": Equal("worker"),
 									"Protocol":    Equal("http1"),
+									"Weight":      BeNil(),
+									"Namespace":   BeEmpty(),
 								},
 							),
 						))
@@ -940,6 +1067,8 @@ var _ = Describe("RouteRepository", func() {
 									"AppGUID":     Equal(appGUID1),
 									"ProcessType": Equal("web"),
 									"Protocol":    Equal("http1"),
+									"Weight":      BeNil(),
+									"SpaceGUID":   BeEmpty(),
 								},
 							),
 							MatchAllFields(
@@ -949,6 +1078,8 @@ var _ = Describe("RouteRepository", func() {
 									"AppGUID":     Equal(appGUID2),
 									"ProcessType": Equal("worker"),
 									"Protocol":    Equal("http1"),
+									"Weight":      BeNil(),
+									"SpaceGUID":   BeEmpty(),
 								},
 							),
 						))
@@ -1067,6 +1198,8 @@ var _ = Describe("RouteRepository", func() {
 									}),
 									"ProcessType": Equal("web"),
 									"Protocol":    Equal("http1"),
+									"Weight":      BeNil(),
+									"Namespace":   BeEmpty(),
 								},
 							),
 							MatchAllFields(
@@ -1078,6 +1211,8 @@ var _ = Describe("RouteRepository", func() {
 									}),
 									"ProcessType": Equal("worker"),
 									"Protocol":    Equal("http1"),
+									"Weight":      BeNil(),
+									"Namespace":   BeEmpty(),
 								},
 							),
 							MatchAllFields(
@@ -1089,6 +1224,8 @@ var _ = Describe("RouteRepository", func() {
 									}),
 									"ProcessType": Equal("web"),
 									"Protocol":    Equal("http1"),
+									"Weight":      BeNil(),
+									"Namespace":   BeEmpty(),
 								},
 							),
 						))
@@ -1103,6 +1240,8 @@ var _ = Describe("RouteRepository", func() {
 									"AppGUID":     Equal(appGUID1),
 									"ProcessType": Equal("web"),
 									"Protocol":    Equal("http1"),
+									"Weight":      BeNil(),
+									"SpaceGUID":   BeEmpty(),
 								},
 							),
 							MatchAllFields(
@@ -1112,6 +1251,8 @@ var _ = Describe("RouteRepository", func() {
 									"AppGUID":     Equal(appGUID2),
 									"ProcessType": Equal("worker"),
 									"Protocol":    Equal("http1"),
+									"Weight":      BeNil(),
+									"SpaceGUID":   BeEmpty(),
 								},
 							),
 							MatchAllFields(
@@ -1121,6 +1262,8 @@ var _ = Describe("RouteRepository", func() {
 									"AppGUID":     Equal(appGUID),
 									"ProcessType": Equal("web"),
 									"Protocol":    Equal("http1"),
+									"Weight":      BeNil(),
+									"SpaceGUID":   BeEmpty(),
 								},
 							),
 						))
@@ -1188,6 +1331,8 @@ var _ = Describe("RouteRepository", func() {
 									}),
 									"ProcessType": Equal("worker"),
 									"Protocol":    Equal("http1"),
+									"Weight":      BeNil(),
+									"Namespace":   BeEmpty(),
 								},
 							),
 						))
@@ -1209,6 +1354,8 @@ var _ = Describe("RouteRepository", func() {
 									"AppGUID":     Equal(appGUID2),
 									"ProcessType": Equal("worker"),
 									"Protocol":    Equal("http1"),
+									"Weight":      BeNil(),
+									"SpaceGUID":   BeEmpty(),
 								},
 							),
 						))
@@ -1218,6 +1365,453 @@ var _ = Describe("RouteRepository", func() {
 		})
 	})
 
+	Describe("ReplaceRouteDestinations", func() {
+		const (
+			testRouteHost = "test-route-host"
+			testRoutePath = "/test/route/path"
+		)
+
+		var (
+			blueDestination korifiv1alpha1.Destination
+			greenAppGUID    string
+			replaceErr      error
+			replacedRoute   RouteRecord
+			newDestinations []DestinationMessage
+			existingRecord  RouteRecord
+		)
+
+		BeforeEach(func() {
+			cfRoute := initializeRouteCR(testRouteHost, testRoutePath, route1GUID, domainGUID, space.Name)
+			blueDestination = korifiv1alpha1.Destination{
+				GUID:        generateGUID(),
+				Port:        8080,
+				AppRef:      corev1.LocalObjectReference{Name: generateGUID()},
+				ProcessType: "web",
+				Protocol:    "http1",
+			}
+			cfRoute.Spec.Destinations = []korifiv1alpha1.Destination{blueDestination}
+			Expect(k8sClient.Create(testCtx, cfRoute)).To(Succeed())
+
+			greenAppGUID = generateGUID()
+			newDestinations = []DestinationMessage{
+				{AppGUID: blueDestination.AppRef.Name, ProcessType: "web", Port: 8080, Protocol: "http1", Weight: tools.PtrTo(10)},
+				{AppGUID: greenAppGUID, ProcessType: "web", Port: 8080, Protocol: "http1", Weight: tools.PtrTo(90)},
+			}
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			existingRecord, err = routeRepo.GetRoute(testCtx, authInfo, route1GUID)
+			Expect(err).NotTo(HaveOccurred())
+
+			replacedRoute, replaceErr = routeRepo.ReplaceRouteDestinations(testCtx, authInfo, ReplaceRouteDestinationsMessage{
+				RouteGUID:            existingRecord.GUID,
+				SpaceGUID:            existingRecord.SpaceGUID,
+				ExistingDestinations: existingRecord.Destinations,
+				NewDestinations:      newDestinations,
+			})
+		})
+
+		AfterEach(func() {
+			Expect(cleanupRoute(k8sClient, testCtx, route1GUID, space.Name)).To(Succeed())
+		})
+
+		When("the user is a space manager in this space", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceManagerRole.Name, space.Name)
+			})
+
+			It("returns a forbidden error", func() {
+				Expect(replaceErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+		})
+
+		When("the user is a space developer in this space", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("replaces the destinations, keeping the guid of existing ones", func() {
+				Expect(replaceErr).NotTo(HaveOccurred())
+
+				cfRoute := new(korifiv1alpha1.CFRoute)
+				Expect(k8sClient.Get(testCtx, types.NamespacedName{Name: route1GUID, Namespace: space.Name}, cfRoute)).To(Succeed())
+				Expect(cfRoute.Spec.Destinations).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{
+						"GUID":   Equal(blueDestination.GUID),
+						"AppRef": Equal(blueDestination.AppRef),
+						"Weight": PointTo(Equal(10)),
+					}),
+					MatchFields(IgnoreExtras, Fields{
+						"GUID":   Not(SatisfyAny(BeEmpty(), Equal(blueDestination.GUID))),
+						"AppRef": Equal(corev1.LocalObjectReference{Name: greenAppGUID}),
+						"Weight": PointTo(Equal(90)),
+					}),
+				))
+			})
+
+			It("returns the route with the new destinations", func() {
+				Expect(replaceErr).NotTo(HaveOccurred())
+				Expect(replacedRoute.Destinations).To(HaveLen(2))
+				Expect(replacedRoute.Destinations[0].GUID).To(Equal(blueDestination.GUID))
+				Expect(replacedRoute.Destinations[0].Weight).To(PointTo(Equal(10)))
+				Expect(replacedRoute.Destinations[1].AppGUID).To(Equal(greenAppGUID))
+				Expect(replacedRoute.Destinations[1].Weight).To(PointTo(Equal(90)))
+			})
+
+			When("the new destination list is empty", func() {
+				BeforeEach(func() {
+					newDestinations = []DestinationMessage{}
+				})
+
+				It("removes all destinations from the route", func() {
+					Expect(replaceErr).NotTo(HaveOccurred())
+
+					cfRoute := new(korifiv1alpha1.CFRoute)
+					Expect(k8sClient.Get(testCtx, types.NamespacedName{Name: route1GUID, Namespace: space.Name}, cfRoute)).To(Succeed())
+					Expect(cfRoute.Spec.Destinations).To(BeEmpty())
+				})
+			})
+		})
+	})
+
+	Describe("ShareRoute", func() {
+		var (
+			sharedSpace  *korifiv1alpha1.CFSpace
+			routeGUID    string
+			sharedRoute  RouteRecord
+			shareErr     error
+			sharedSpaces []string
+		)
+
+		BeforeEach(func() {
+			sharedSpace = createSpaceWithCleanup(testCtx, org.Name, prefixedGUID("shared-space"))
+			sharedSpaces = []string{sharedSpace.Name}
+			routeGUID = route1GUID
+
+			cfRoute := initializeRouteCR("test-route-host", "", route1GUID, domainGUID, space.Name)
+			Expect(k8sClient.Create(testCtx, cfRoute)).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			sharedRoute, shareErr = routeRepo.ShareRoute(testCtx, authInfo, ShareRouteMessage{
+				RouteGUID:        routeGUID,
+				SpaceGUID:        space.Name,
+				SharedSpaceGUIDs: sharedSpaces,
+			})
+		})
+
+		AfterEach(func() {
+			Expect(cleanupRoute(k8sClient, testCtx, route1GUID, space.Name)).To(Succeed())
+		})
+
+		When("the user is a space manager in this space", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceManagerRole.Name, space.Name)
+			})
+
+			It("returns a forbidden error", func() {
+				Expect(shareErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+		})
+
+		When("the user is a space developer in this space", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("shares the route with the space", func() {
+				Expect(shareErr).NotTo(HaveOccurred())
+				Expect(sharedRoute.SharedSpaceGUIDs).To(ConsistOf(sharedSpace.Name))
+
+				cfRoute := new(korifiv1alpha1.CFRoute)
+				Expect(k8sClient.Get(testCtx, types.NamespacedName{Name: route1GUID, Namespace: space.Name}, cfRoute)).To(Succeed())
+				Expect(cfRoute.Spec.SharedSpaces).To(ConsistOf(sharedSpace.Name))
+			})
+
+			When("the route is already shared with the space", func() {
+				BeforeEach(func() {
+					sharedSpaces = []string{sharedSpace.Name, sharedSpace.Name}
+				})
+
+				It("shares the route with the space once", func() {
+					Expect(shareErr).NotTo(HaveOccurred())
+					Expect(sharedRoute.SharedSpaceGUIDs).To(ConsistOf(sharedSpace.Name))
+				})
+			})
+		})
+
+		When("the route does not exist", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+				routeGUID = prefixedGUID("missing-route")
+			})
+
+			It("returns a not found error", func() {
+				Expect(shareErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+
+	Describe("UnshareRoute", func() {
+		var (
+			sharedSpace *korifiv1alpha1.CFSpace
+			unshareErr  error
+		)
+
+		BeforeEach(func() {
+			sharedSpace = createSpaceWithCleanup(testCtx, org.Name, prefixedGUID("shared-space"))
+
+			cfRoute := initializeRouteCR("test-route-host", "", route1GUID, domainGUID, space.Name)
+			cfRoute.Spec.SharedSpaces = []string{sharedSpace.Name}
+			cfRoute.Spec.Destinations = []korifiv1alpha1.Destination{
+				{
+					GUID:        generateGUID(),
+					Port:        8080,
+					AppRef:      corev1.LocalObjectReference{Name: "local-app"},
+					ProcessType: "web",
+					Protocol:    "http1",
+				},
+				{
+					GUID:        generateGUID(),
+					Port:        8080,
+					AppRef:      corev1.LocalObjectReference{Name: "shared-app"},
+					Namespace:   sharedSpace.Name,
+					ProcessType: "web",
+					Protocol:    "http1",
+				},
+			}
+			Expect(k8sClient.Create(testCtx, cfRoute)).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			unshareErr = routeRepo.UnshareRoute(testCtx, authInfo, UnshareRouteMessage{
+				RouteGUID:       route1GUID,
+				SpaceGUID:       space.Name,
+				SharedSpaceGUID: sharedSpace.Name,
+			})
+		})
+
+		AfterEach(func() {
+			Expect(cleanupRoute(k8sClient, testCtx, route1GUID, space.Name)).To(Succeed())
+		})
+
+		When("the user is a space manager in this space", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceManagerRole.Name, space.Name)
+			})
+
+			It("returns a forbidden error", func() {
+				Expect(unshareErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+		})
+
+		When("the user is a space developer in this space", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("unshares the route and removes the destinations in the space", func() {
+				Expect(unshareErr).NotTo(HaveOccurred())
+
+				cfRoute := new(korifiv1alpha1.CFRoute)
+				Expect(k8sClient.Get(testCtx, types.NamespacedName{Name: route1GUID, Namespace: space.Name}, cfRoute)).To(Succeed())
+				Expect(cfRoute.Spec.SharedSpaces).To(BeEmpty())
+				Expect(cfRoute.Spec.Destinations).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{
+						"AppRef":    Equal(corev1.LocalObjectReference{Name: "local-app"}),
+						"Namespace": BeEmpty(),
+					}),
+				))
+			})
+		})
+	})
+
+	Describe("TransferRoute", func() {
+		var (
+			targetSpace      *korifiv1alpha1.CFSpace
+			transferredRoute RouteRecord
+			transferErr      error
+			destinationGUID  string
+		)
+
+		BeforeEach(func() {
+			targetSpace = createSpaceWithCleanup(testCtx, org.Name, prefixedGUID("target-space"))
+			destinationGUID = generateGUID()
+
+			cfRoute := initializeRouteCR("test-route-host", "", route1GUID, domainGUID, space.Name)
+			cfRoute.Labels = map[string]string{"foo": "bar"}
+			cfRoute.Spec.Destinations = []korifiv1alpha1.Destination{
+				{
+					GUID:        destinationGUID,
+					Port:        8080,
+					AppRef:      corev1.LocalObjectReference{Name: "some-app"},
+					ProcessType: "web",
+					Protocol:    "http1",
+				},
+			}
+			Expect(k8sClient.Create(testCtx, cfRoute)).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			transferredRoute, transferErr = routeRepo.TransferRoute(testCtx, authInfo, TransferRouteMessage{
+				RouteGUID:       route1GUID,
+				SpaceGUID:       space.Name,
+				TargetSpaceGUID: targetSpace.Name,
+			})
+		})
+
+		AfterEach(func() {
+			Expect(client.IgnoreNotFound(cleanupRoute(k8sClient, testCtx, route1GUID, space.Name))).To(Succeed())
+			Expect(client.IgnoreNotFound(cleanupRoute(k8sClient, testCtx, route1GUID, targetSpace.Name))).To(Succeed())
+		})
+
+		When("the user is a space developer in the space of the route only", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns a forbidden error and keeps the original route", func() {
+				Expect(transferErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+
+				cfRoute := new(korifiv1alpha1.CFRoute)
+				Expect(k8sClient.Get(testCtx, types.NamespacedName{Name: route1GUID, Namespace: space.Name}, cfRoute)).To(Succeed())
+				Expect(cfRoute.Spec.Destinations).To(HaveLen(1))
+			})
+		})
+
+		When("the user is a space developer in both spaces", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, targetSpace.Name)
+			})
+
+			It("moves the route to the target space", func() {
+				Expect(transferErr).NotTo(HaveOccurred())
+				Expect(transferredRoute.GUID).To(Equal(route1GUID))
+				Expect(transferredRoute.SpaceGUID).To(Equal(targetSpace.Name))
+
+				cfRoute := new(korifiv1alpha1.CFRoute)
+				Expect(k8sClient.Get(testCtx, types.NamespacedName{Name: route1GUID, Namespace: targetSpace.Name}, cfRoute)).To(Succeed())
+				Expect(cfRoute.Labels).To(HaveKeyWithValue("foo", "bar"))
+				Expect(cfRoute.Spec.Host).To(Equal("test-route-host"))
+			})
+
+			It("shares the route with the original space, keeping its destinations", func() {
+				Expect(transferErr).NotTo(HaveOccurred())
+				Expect(transferredRoute.SharedSpaceGUIDs).To(ConsistOf(space.Name))
+				Expect(transferredRoute.Destinations).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{
+						"GUID":      Equal(destinationGUID),
+						"AppGUID":   Equal("some-app"),
+						"SpaceGUID": Equal(space.Name),
+					}),
+				))
+			})
+
+			It("deletes the original route", func() {
+				Expect(transferErr).NotTo(HaveOccurred())
+
+				err := k8sClient.Get(testCtx, types.NamespacedName{Name: route1GUID, Namespace: space.Name}, new(korifiv1alpha1.CFRoute))
+				Expect(err).To(MatchError(ContainSubstring("not found")))
+			})
+
+			It("does not leave the transfer annotations on the transferred route", func() {
+				Expect(transferErr).NotTo(HaveOccurred())
+
+				cfRoute := new(korifiv1alpha1.CFRoute)
+				Expect(k8sClient.Get(testCtx, types.NamespacedName{Name: route1GUID, Namespace: targetSpace.Name}, cfRoute)).To(Succeed())
+				Expect(cfRoute.Annotations).NotTo(HaveKey(korifiv1alpha1.CFRouteTransferredFromAnnotation))
+				Expect(cfRoute.Annotations).NotTo(HaveKey(korifiv1alpha1.CFRouteTransferredToAnnotation))
+			})
+
+			When("the route webhook has not seen the transfer of the original route yet", func() {
+				var rejectedCreates int
+
+				BeforeEach(func() {
+					rejectedCreates = 0
+					routeRepo = NewRouteRepo(namespaceRetriever, interceptingClientFactory{
+						UserK8sClientFactory: userClientFactory,
+						onCreate: func(obj client.Object) error {
+							if _, ok := obj.(*korifiv1alpha1.CFRoute); ok && rejectedCreates < 2 {
+								rejectedCreates++
+								return webhookError(networking.RouteTransferValidationErrorType)
+							}
+							return nil
+						},
+					}, nsPerms, rootNamespace)
+				})
+
+				It("retries until the route is created in the target space", func() {
+					Expect(transferErr).NotTo(HaveOccurred())
+					Expect(rejectedCreates).To(Equal(2))
+					Expect(k8sClient.Get(testCtx, types.NamespacedName{Name: route1GUID, Namespace: targetSpace.Name}, new(korifiv1alpha1.CFRoute))).To(Succeed())
+				})
+			})
+
+			When("deleting the original route fails", func() {
+				BeforeEach(func() {
+					routeRepo = NewRouteRepo(namespaceRetriever, interceptingClientFactory{
+						UserK8sClientFactory: userClientFactory,
+						onDelete: func(obj client.Object) error {
+							if _, ok := obj.(*korifiv1alpha1.CFRoute); ok {
+								return errors.New("interrupted")
+							}
+							return nil
+						},
+					}, nsPerms, rootNamespace)
+				})
+
+				It("returns an error and leaves the original route marked as transferred for the controller to delete", func() {
+					Expect(transferErr).To(MatchError(ContainSubstring("interrupted")))
+
+					Expect(k8sClient.Get(testCtx, types.NamespacedName{Name: route1GUID, Namespace: targetSpace.Name}, new(korifiv1alpha1.CFRoute))).To(Succeed())
+
+					cfRoute := new(korifiv1alpha1.CFRoute)
+					Expect(k8sClient.Get(testCtx, types.NamespacedName{Name: route1GUID, Namespace: space.Name}, cfRoute)).To(Succeed())
+					Expect(cfRoute.Annotations).To(HaveKeyWithValue(korifiv1alpha1.CFRouteTransferredToAnnotation, targetSpace.Name))
+				})
+			})
+
+			When("the original route has already been deleted by the controller", func() {
+				BeforeEach(func() {
+					routeRepo = NewRouteRepo(namespaceRetriever, interceptingClientFactory{
+						UserK8sClientFactory: userClientFactory,
+						onDelete: func(obj client.Object) error {
+							if _, ok := obj.(*korifiv1alpha1.CFRoute); ok {
+								Expect(k8sClient.Delete(testCtx, obj)).To(Succeed())
+							}
+							return nil
+						},
+					}, nsPerms, rootNamespace)
+				})
+
+				It("completes the transfer", func() {
+					Expect(transferErr).NotTo(HaveOccurred())
+					Expect(transferredRoute.SpaceGUID).To(Equal(targetSpace.Name))
+				})
+			})
+
+			When("the route cannot be created in the target space", func() {
+				BeforeEach(func() {
+					Expect(k8sClient.Create(testCtx, initializeRouteCR("other-host", "", route1GUID, domainGUID, targetSpace.Name))).To(Succeed())
+				})
+
+				It("returns an error and keeps the original route unchanged", func() {
+					Expect(transferErr).To(HaveOccurred())
+
+					cfRoute := new(korifiv1alpha1.CFRoute)
+					Expect(k8sClient.Get(testCtx, types.NamespacedName{Name: route1GUID, Namespace: space.Name}, cfRoute)).To(Succeed())
+					Expect(cfRoute.DeletionTimestamp).To(BeNil())
+					Expect(cfRoute.Annotations).NotTo(HaveKey(korifiv1alpha1.CFRouteTransferredToAnnotation))
+					Expect(cfRoute.Spec.Destinations).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"GUID": Equal(destinationGUID),
+					})))
+				})
+			})
+		})
+	})
+
 	Describe("RemoveDestinationFromRoute", func() {
 		const (
 			testRouteHost = "test-route-host"
@@ -1315,6 +1909,7 @@ var _ = Describe("RouteRepository", func() {
 		var (
 			cfRoute                       *korifiv1alpha1.CFRoute
 			labelsPatch, annotationsPatch map[string]*string
+			optionsPatch                  map[string]*string
 			patchErr                      error
 			routeRecord                   RouteRecord
 		)
@@ -1323,6 +1918,7 @@ var _ = Describe("RouteRepository", func() {
 			cfRoute = createRoute(route1GUID, space.Name, "my-subdomain-1-a", "", domainGUID, prefixedGUID("RoutePatchMetadata"))
 			labelsPatch = nil
 			annotationsPatch = nil
+			optionsPatch = nil
 		})
 
 		JustBeforeEach(func() {
@@ -1333,6 +1929,7 @@ var _ = Describe("RouteRepository", func() {
 					Annotations: annotationsPatch,
 					Labels:      labelsPatch,
 				},
+				Options: optionsPatch,
 			}
 
 			routeRecord, patchErr = routeRepo.PatchRouteMetadata(ctx, authInfo, patchMsg)
@@ -1343,6 +1940,34 @@ var _ = Describe("RouteRepository", func() {
 				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
 			})
 
+			When("options are patched", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, k8sClient, cfRoute, func() {
+						cfRoute.Spec.Options = map[string]string{
+							"loadbalancing": "round-robin",
+							"idle_timeout":  "5m",
+						}
+					})).To(Succeed())
+
+					optionsPatch = map[string]*string{
+						"loadbalancing":   nil,
+						"request_timeout": pointerTo("30s"),
+					}
+				})
+
+				It("merges the options into the options of the route", func() {
+					Expect(patchErr).NotTo(HaveOccurred())
+					Expect(routeRecord.Options).To(Equal(map[string]string{
+						"idle_timeout":    "5m",
+						"request_timeout": "30s",
+					}))
+
+					updatedCFRoute := new(korifiv1alpha1.CFRoute)
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfRoute), updatedCFRoute)).To(Succeed())
+					Expect(updatedCFRoute.Spec.Options).To(Equal(routeRecord.Options))
+				})
+			})
+
 			When("the route doesn't have any labels or annotations", func() {
 				BeforeEach(func() {
 					labelsPatch = map[string]*string{
@@ -1589,6 +2214,56 @@ func buildCreateRouteMessage(routeHost, routePath, domainGUID, spaceGUID, domain
 	}
 }
 
+// interceptingClientFactory builds user clients that run the intercept functions before creating or
+// deleting objects and fail with the error they return
+type interceptingClientFactory struct {
+	authorization.UserK8sClientFactory
+	onCreate func(client.Object) error
+	onDelete func(client.Object) error
+}
+
+func (f interceptingClientFactory) BuildClient(authInfo authorization.Info) (client.WithWatch, error) {
+	userClient, err := f.UserK8sClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return interceptingClient{WithWatch: userClient, onCreate: f.onCreate, onDelete: f.onDelete}, nil
+}
+
+type interceptingClient struct {
+	client.WithWatch
+	onCreate func(client.Object) error
+	onDelete func(client.Object) error
+}
+
+func (c interceptingClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if c.onCreate != nil {
+		if err := c.onCreate(obj); err != nil {
+			return err
+		}
+	}
+
+	return c.WithWatch.Create(ctx, obj, opts...)
+}
+
+func (c interceptingClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	if c.onDelete != nil {
+		if err := c.onDelete(obj); err != nil {
+			return err
+		}
+	}
+
+	return c.WithWatch.Delete(ctx, obj, opts...)
+}
+
+// webhookError builds the error the api server returns when a korifi webhook denies a request
+func webhookError(errorType string) error {
+	return &k8serrors.StatusError{ErrStatus: metav1.Status{
+		Reason: metav1.StatusReason(webhooks.ValidationError{Type: errorType, Message: "denied"}.ExportJSONError().Error()),
+	}}
+}
+
 func cleanupRoute(k8sClient client.Client, ctx context.Context, routeGUID, routeNamespace string) error {
 	return k8sClient.Delete(ctx, &korifiv1alpha1.CFRoute{
 		ObjectMeta: metav1.ObjectMeta{
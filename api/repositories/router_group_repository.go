@@ -0,0 +1,70 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/config"
+)
+
+const (
+	RouterGroupResourceType = "Router Group"
+	RouterGroupTypeTCP      = "tcp"
+)
+
+// RouterGroupRepo serves the router groups configured for the API. Router groups are not
+// kubernetes resources, so they are visible to all users
+type RouterGroupRepo struct {
+	routerGroups []RouterGroupRecord
+}
+
+type RouterGroupRecord struct {
+	GUID            string
+	Name            string
+	Type            string
+	ReservablePorts string
+	Ports           []int
+}
+
+type ListRouterGroupsMessage struct {
+	Names []string
+}
+
+func NewRouterGroupRepo(routerGroups []config.RouterGroup) *RouterGroupRepo {
+	records := make([]RouterGroupRecord, 0, len(routerGroups))
+	for _, routerGroup := range routerGroups {
+		// the reservable ports are validated when the config is loaded
+		ports, _ := routerGroup.Ports()
+		records = append(records, RouterGroupRecord{
+			GUID:            routerGroup.Name,
+			Name:            routerGroup.Name,
+			Type:            RouterGroupTypeTCP,
+			ReservablePorts: routerGroup.ReservablePorts,
+			Ports:           ports,
+		})
+	}
+
+	return &RouterGroupRepo{routerGroups: records}
+}
+
+func (r *RouterGroupRepo) ListRouterGroups(ctx context.Context, message ListRouterGroupsMessage) ([]RouterGroupRecord, error) {
+	records := []RouterGroupRecord{}
+	for _, routerGroup := range r.routerGroups {
+		if matchesFilter(routerGroup.Name, message.Names) {
+			records = append(records, routerGroup)
+		}
+	}
+
+	return records, nil
+}
+
+func (r *RouterGroupRepo) GetRouterGroup(ctx context.Context, guid string) (RouterGroupRecord, error) {
+	for _, routerGroup := range r.routerGroups {
+		if routerGroup.GUID == guid {
+			return routerGroup, nil
+		}
+	}
+
+	return RouterGroupRecord{}, apierrors.NewNotFoundError(errors.New("router group not found"), RouterGroupResourceType)
+}
@@ -0,0 +1,84 @@
+package repositories_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/config"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RouterGroupRepository", func() {
+	var routerGroupRepo *repositories.RouterGroupRepo
+
+	BeforeEach(func() {
+		routerGroupRepo = repositories.NewRouterGroupRepo([]config.RouterGroup{
+			{Name: "default-tcp", ReservablePorts: "1024-1026,2000"},
+			{Name: "other-tcp", ReservablePorts: "3000"},
+		})
+	})
+
+	Describe("ListRouterGroups", func() {
+		var (
+			message      repositories.ListRouterGroupsMessage
+			routerGroups []repositories.RouterGroupRecord
+			listErr      error
+		)
+
+		BeforeEach(func() {
+			message = repositories.ListRouterGroupsMessage{}
+		})
+
+		JustBeforeEach(func() {
+			routerGroups, listErr = routerGroupRepo.ListRouterGroups(ctx, message)
+		})
+
+		It("lists all the configured router groups", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(routerGroups).To(ConsistOf(
+				repositories.RouterGroupRecord{
+					GUID:            "default-tcp",
+					Name:            "default-tcp",
+					Type:            "tcp",
+					ReservablePorts: "1024-1026,2000",
+					Ports:           []int{1024, 1025, 1026, 2000},
+				},
+				repositories.RouterGroupRecord{
+					GUID:            "other-tcp",
+					Name:            "other-tcp",
+					Type:            "tcp",
+					ReservablePorts: "3000",
+					Ports:           []int{3000},
+				},
+			))
+		})
+
+		When("filtering by name", func() {
+			BeforeEach(func() {
+				message.Names = []string{"other-tcp"}
+			})
+
+			It("only returns the matching router groups", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(routerGroups).To(HaveLen(1))
+				Expect(routerGroups[0].Name).To(Equal("other-tcp"))
+			})
+		})
+	})
+
+	Describe("GetRouterGroup", func() {
+		It("returns the router group", func() {
+			routerGroup, err := routerGroupRepo.GetRouterGroup(ctx, "default-tcp")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(routerGroup.Name).To(Equal("default-tcp"))
+		})
+
+		When("the router group does not exist", func() {
+			It("returns a not found error", func() {
+				_, err := routerGroupRepo.GetRouterGroup(ctx, "nope")
+				Expect(err).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+})
@@ -0,0 +1,395 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/google/uuid"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	SecurityGroupResourceType = "Security Group"
+
+	SecurityGroupWorkloadRunning = "running"
+	SecurityGroupWorkloadStaging = "staging"
+)
+
+type SecurityGroupRule struct {
+	Protocol    string
+	Destination string
+	Ports       string
+	Type        *int
+	Code        *int
+	Description string
+	Log         bool
+}
+
+type SecurityGroupWorkloads struct {
+	Running bool
+	Staging bool
+}
+
+type SecurityGroupRecord struct {
+	GUID              string
+	Name              string
+	GloballyEnabled   SecurityGroupWorkloads
+	Rules             []SecurityGroupRule
+	RunningSpaceGUIDs []string
+	StagingSpaceGUIDs []string
+	CreatedAt         string
+	UpdatedAt         string
+}
+
+type CreateSecurityGroupMessage struct {
+	Name              string
+	GloballyEnabled   SecurityGroupWorkloads
+	Rules             []SecurityGroupRule
+	RunningSpaceGUIDs []string
+	StagingSpaceGUIDs []string
+}
+
+type ListSecurityGroupsMessage struct {
+	GUIDs                  []string
+	Names                  []string
+	GloballyEnabledRunning *bool
+	GloballyEnabledStaging *bool
+	RunningSpaceGUIDs      []string
+	StagingSpaceGUIDs      []string
+}
+
+type UpdateSecurityGroupMessage struct {
+	GUID                   string
+	Name                   *string
+	GloballyEnabledRunning *bool
+	GloballyEnabledStaging *bool
+	Rules                  *[]SecurityGroupRule
+}
+
+type BindSecurityGroupMessage struct {
+	GUID       string
+	Workload   string
+	SpaceGUIDs []string
+}
+
+type UnbindSecurityGroupMessage struct {
+	GUID      string
+	Workload  string
+	SpaceGUID string
+}
+
+// SecurityGroupRepo manages the CFSecurityGroups in the root namespace. The CFSpace controller renders the
+// groups bound to a space as NetworkPolicies in the space namespace
+type SecurityGroupRepo struct {
+	userClientFactory authorization.UserK8sClientFactory
+	rootNamespace     string
+}
+
+func NewSecurityGroupRepo(userClientFactory authorization.UserK8sClientFactory, rootNamespace string) *SecurityGroupRepo {
+	return &SecurityGroupRepo{
+		userClientFactory: userClientFactory,
+		rootNamespace:     rootNamespace,
+	}
+}
+
+func (r *SecurityGroupRepo) CreateSecurityGroup(ctx context.Context, authInfo authorization.Info, message CreateSecurityGroupMessage) (SecurityGroupRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return SecurityGroupRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	securityGroups, err := r.listSecurityGroups(ctx, userClient)
+	if err != nil {
+		return SecurityGroupRecord{}, err
+	}
+
+	if err = checkSecurityGroupNameIsUnique(securityGroups, "", message.Name); err != nil {
+		return SecurityGroupRecord{}, err
+	}
+
+	spaces := map[string]korifiv1alpha1.SecurityGroupWorkloads{}
+	for _, spaceGUID := range message.RunningSpaceGUIDs {
+		workloads := spaces[spaceGUID]
+		workloads.Running = true
+		spaces[spaceGUID] = workloads
+	}
+	for _, spaceGUID := range message.StagingSpaceGUIDs {
+		workloads := spaces[spaceGUID]
+		workloads.Staging = true
+		spaces[spaceGUID] = workloads
+	}
+
+	cfSecurityGroup := &korifiv1alpha1.CFSecurityGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      uuid.NewString(),
+			Namespace: r.rootNamespace,
+		},
+		Spec: korifiv1alpha1.CFSecurityGroupSpec{
+			DisplayName:     message.Name,
+			Rules:           securityGroupRulesToCRD(message.Rules),
+			GloballyEnabled: korifiv1alpha1.SecurityGroupWorkloads(message.GloballyEnabled),
+			Spaces:          spaces,
+		},
+	}
+
+	err = userClient.Create(ctx, cfSecurityGroup)
+	if err != nil {
+		return SecurityGroupRecord{}, fmt.Errorf("failed to create security group: %w", apierrors.FromK8sError(err, SecurityGroupResourceType))
+	}
+
+	return cfSecurityGroupToRecord(*cfSecurityGroup), nil
+}
+
+func (r *SecurityGroupRepo) ListSecurityGroups(ctx context.Context, authInfo authorization.Info, message ListSecurityGroupsMessage) ([]SecurityGroupRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	securityGroups, err := r.listSecurityGroups(ctx, userClient)
+	if err != nil {
+		return nil, err
+	}
+
+	records := []SecurityGroupRecord{}
+	for _, securityGroup := range securityGroups {
+		record := cfSecurityGroupToRecord(securityGroup)
+		if matchesSecurityGroupFilter(record, message) {
+			records = append(records, record)
+		}
+	}
+
+	return records, nil
+}
+
+func (r *SecurityGroupRepo) GetSecurityGroup(ctx context.Context, authInfo authorization.Info, guid string) (SecurityGroupRecord, error) {
+	cfSecurityGroup, _, err := r.getSecurityGroup(ctx, authInfo, guid)
+	if err != nil {
+		return SecurityGroupRecord{}, err
+	}
+
+	return cfSecurityGroupToRecord(*cfSecurityGroup), nil
+}
+
+func (r *SecurityGroupRepo) UpdateSecurityGroup(ctx context.Context, authInfo authorization.Info, message UpdateSecurityGroupMessage) (SecurityGroupRecord, error) {
+	cfSecurityGroup, userClient, err := r.getSecurityGroup(ctx, authInfo, message.GUID)
+	if err != nil {
+		return SecurityGroupRecord{}, err
+	}
+
+	if message.Name != nil {
+		securityGroups, err := r.listSecurityGroups(ctx, userClient)
+		if err != nil {
+			return SecurityGroupRecord{}, err
+		}
+
+		if err = checkSecurityGroupNameIsUnique(securityGroups, message.GUID, *message.Name); err != nil {
+			return SecurityGroupRecord{}, err
+		}
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfSecurityGroup, func() {
+		if message.Name != nil {
+			cfSecurityGroup.Spec.DisplayName = *message.Name
+		}
+		if message.GloballyEnabledRunning != nil {
+			cfSecurityGroup.Spec.GloballyEnabled.Running = *message.GloballyEnabledRunning
+		}
+		if message.GloballyEnabledStaging != nil {
+			cfSecurityGroup.Spec.GloballyEnabled.Staging = *message.GloballyEnabledStaging
+		}
+		if message.Rules != nil {
+			cfSecurityGroup.Spec.Rules = securityGroupRulesToCRD(*message.Rules)
+		}
+	})
+	if err != nil {
+		return SecurityGroupRecord{}, fmt.Errorf("failed to update security group: %w", apierrors.FromK8sError(err, SecurityGroupResourceType))
+	}
+
+	return cfSecurityGroupToRecord(*cfSecurityGroup), nil
+}
+
+func (r *SecurityGroupRepo) DeleteSecurityGroup(ctx context.Context, authInfo authorization.Info, guid string) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	err = userClient.Delete(ctx, &korifiv1alpha1.CFSecurityGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      guid,
+			Namespace: r.rootNamespace,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete security group: %w", apierrors.FromK8sError(err, SecurityGroupResourceType))
+	}
+
+	return nil
+}
+
+func (r *SecurityGroupRepo) BindSecurityGroup(ctx context.Context, authInfo authorization.Info, message BindSecurityGroupMessage) (SecurityGroupRecord, error) {
+	cfSecurityGroup, userClient, err := r.getSecurityGroup(ctx, authInfo, message.GUID)
+	if err != nil {
+		return SecurityGroupRecord{}, err
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfSecurityGroup, func() {
+		if cfSecurityGroup.Spec.Spaces == nil {
+			cfSecurityGroup.Spec.Spaces = map[string]korifiv1alpha1.SecurityGroupWorkloads{}
+		}
+
+		for _, spaceGUID := range message.SpaceGUIDs {
+			cfSecurityGroup.Spec.Spaces[spaceGUID] = withWorkload(cfSecurityGroup.Spec.Spaces[spaceGUID], message.Workload, true)
+		}
+	})
+	if err != nil {
+		return SecurityGroupRecord{}, fmt.Errorf("failed to bind security group: %w", apierrors.FromK8sError(err, SecurityGroupResourceType))
+	}
+
+	return cfSecurityGroupToRecord(*cfSecurityGroup), nil
+}
+
+func (r *SecurityGroupRepo) UnbindSecurityGroup(ctx context.Context, authInfo authorization.Info, message UnbindSecurityGroupMessage) error {
+	cfSecurityGroup, userClient, err := r.getSecurityGroup(ctx, authInfo, message.GUID)
+	if err != nil {
+		return err
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfSecurityGroup, func() {
+		workloads := withWorkload(cfSecurityGroup.Spec.Spaces[message.SpaceGUID], message.Workload, false)
+		if workloads.Running || workloads.Staging {
+			cfSecurityGroup.Spec.Spaces[message.SpaceGUID] = workloads
+		} else {
+			delete(cfSecurityGroup.Spec.Spaces, message.SpaceGUID)
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("failed to unbind security group: %w", apierrors.FromK8sError(err, SecurityGroupResourceType))
+	}
+
+	return nil
+}
+
+func (r *SecurityGroupRepo) getSecurityGroup(ctx context.Context, authInfo authorization.Info, guid string) (*korifiv1alpha1.CFSecurityGroup, client.WithWatch, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSecurityGroup := new(korifiv1alpha1.CFSecurityGroup)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: guid}, cfSecurityGroup)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get security group: %w", apierrors.FromK8sError(err, SecurityGroupResourceType))
+	}
+
+	return cfSecurityGroup, userClient, nil
+}
+
+func (r *SecurityGroupRepo) listSecurityGroups(ctx context.Context, userClient client.Client) ([]korifiv1alpha1.CFSecurityGroup, error) {
+	securityGroupList := new(korifiv1alpha1.CFSecurityGroupList)
+	err := userClient.List(ctx, securityGroupList, client.InNamespace(r.rootNamespace))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list security groups: %w", apierrors.FromK8sError(err, SecurityGroupResourceType))
+	}
+
+	sort.Slice(securityGroupList.Items, func(i, j int) bool {
+		return securityGroupList.Items[i].CreationTimestamp.Before(&securityGroupList.Items[j].CreationTimestamp)
+	})
+
+	return securityGroupList.Items, nil
+}
+
+func checkSecurityGroupNameIsUnique(securityGroups []korifiv1alpha1.CFSecurityGroup, skipGUID, name string) error {
+	for _, securityGroup := range securityGroups {
+		if securityGroup.Name != skipGUID && securityGroup.Spec.DisplayName == name {
+			return apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("Security group with name '%s' already exists.", name))
+		}
+	}
+
+	return nil
+}
+
+func matchesSecurityGroupFilter(record SecurityGroupRecord, message ListSecurityGroupsMessage) bool {
+	if !matchesFilter(record.GUID, message.GUIDs) || !matchesFilter(record.Name, message.Names) {
+		return false
+	}
+
+	if message.GloballyEnabledRunning != nil && record.GloballyEnabled.Running != *message.GloballyEnabledRunning {
+		return false
+	}
+
+	if message.GloballyEnabledStaging != nil && record.GloballyEnabled.Staging != *message.GloballyEnabledStaging {
+		return false
+	}
+
+	if len(message.RunningSpaceGUIDs) > 0 && !containsAny(record.RunningSpaceGUIDs, message.RunningSpaceGUIDs) {
+		return false
+	}
+
+	if len(message.StagingSpaceGUIDs) > 0 && !containsAny(record.StagingSpaceGUIDs, message.StagingSpaceGUIDs) {
+		return false
+	}
+
+	return true
+}
+
+func withWorkload(workloads korifiv1alpha1.SecurityGroupWorkloads, workload string, enabled bool) korifiv1alpha1.SecurityGroupWorkloads {
+	switch workload {
+	case SecurityGroupWorkloadRunning:
+		workloads.Running = enabled
+	case SecurityGroupWorkloadStaging:
+		workloads.Staging = enabled
+	}
+
+	return workloads
+}
+
+func securityGroupRulesToCRD(rules []SecurityGroupRule) []korifiv1alpha1.SecurityGroupRule {
+	crdRules := make([]korifiv1alpha1.SecurityGroupRule, 0, len(rules))
+	for _, rule := range rules {
+		crdRules = append(crdRules, korifiv1alpha1.SecurityGroupRule(rule))
+	}
+
+	return crdRules
+}
+
+func cfSecurityGroupToRecord(cfSecurityGroup korifiv1alpha1.CFSecurityGroup) SecurityGroupRecord {
+	updatedAt, _ := getTimeLastUpdatedTimestamp(&cfSecurityGroup.ObjectMeta)
+
+	rules := make([]SecurityGroupRule, 0, len(cfSecurityGroup.Spec.Rules))
+	for _, rule := range cfSecurityGroup.Spec.Rules {
+		rules = append(rules, SecurityGroupRule(rule))
+	}
+
+	runningSpaceGUIDs := []string{}
+	stagingSpaceGUIDs := []string{}
+	for spaceGUID, workloads := range cfSecurityGroup.Spec.Spaces {
+		if workloads.Running {
+			runningSpaceGUIDs = append(runningSpaceGUIDs, spaceGUID)
+		}
+		if workloads.Staging {
+			stagingSpaceGUIDs = append(stagingSpaceGUIDs, spaceGUID)
+		}
+	}
+	sort.Strings(runningSpaceGUIDs)
+	sort.Strings(stagingSpaceGUIDs)
+
+	return SecurityGroupRecord{
+		GUID:              cfSecurityGroup.Name,
+		Name:              cfSecurityGroup.Spec.DisplayName,
+		GloballyEnabled:   SecurityGroupWorkloads(cfSecurityGroup.Spec.GloballyEnabled),
+		Rules:             rules,
+		RunningSpaceGUIDs: runningSpaceGUIDs,
+		StagingSpaceGUIDs: stagingSpaceGUIDs,
+		CreatedAt:         formatTimestamp(cfSecurityGroup.CreationTimestamp),
+		UpdatedAt:         updatedAt,
+	}
+}
@@ -0,0 +1,156 @@
+package repositories_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("SecurityGroupRepository", func() {
+	var (
+		securityGroupRepo *repositories.SecurityGroupRepo
+		createMessage     repositories.CreateSecurityGroupMessage
+	)
+
+	BeforeEach(func() {
+		securityGroupRepo = repositories.NewSecurityGroupRepo(userClientFactory, rootNamespace)
+		createMessage = repositories.CreateSecurityGroupMessage{
+			Name:              "my-group",
+			GloballyEnabled:   repositories.SecurityGroupWorkloads{Staging: true},
+			Rules:             []repositories.SecurityGroupRule{{Protocol: "tcp", Destination: "10.0.0.0/16", Ports: "443"}},
+			RunningSpaceGUIDs: []string{"space-1", "space-2"},
+			StagingSpaceGUIDs: []string{"space-2"},
+		}
+	})
+
+	Describe("CreateSecurityGroup", func() {
+		var (
+			record    repositories.SecurityGroupRecord
+			createErr error
+		)
+
+		JustBeforeEach(func() {
+			record, createErr = securityGroupRepo.CreateSecurityGroup(ctx, authInfo, createMessage)
+		})
+
+		When("the user is not an admin", func() {
+			It("returns a forbidden error", func() {
+				Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("creates the security group in the root namespace", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(record.Name).To(Equal("my-group"))
+				Expect(record.GloballyEnabled).To(Equal(repositories.SecurityGroupWorkloads{Staging: true}))
+				Expect(record.RunningSpaceGUIDs).To(Equal([]string{"space-1", "space-2"}))
+				Expect(record.StagingSpaceGUIDs).To(Equal([]string{"space-2"}))
+
+				cfSecurityGroup := new(korifiv1alpha1.CFSecurityGroup)
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: rootNamespace, Name: record.GUID}, cfSecurityGroup)).To(Succeed())
+				Expect(cfSecurityGroup.Spec.Rules).To(Equal([]korifiv1alpha1.SecurityGroupRule{{Protocol: "tcp", Destination: "10.0.0.0/16", Ports: "443"}}))
+				Expect(cfSecurityGroup.Spec.Spaces).To(Equal(map[string]korifiv1alpha1.SecurityGroupWorkloads{
+					"space-1": {Running: true},
+					"space-2": {Running: true, Staging: true},
+				}))
+			})
+
+			When("a security group with the same name exists", func() {
+				BeforeEach(func() {
+					_, err := securityGroupRepo.CreateSecurityGroup(ctx, authInfo, repositories.CreateSecurityGroupMessage{Name: "my-group"})
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
+	})
+
+	Describe("list, get, update, bind, unbind and delete", func() {
+		var securityGroup repositories.SecurityGroupRecord
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+
+			var err error
+			securityGroup, err = securityGroupRepo.CreateSecurityGroup(ctx, authInfo, createMessage)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = securityGroupRepo.CreateSecurityGroup(ctx, authInfo, repositories.CreateSecurityGroupMessage{Name: "other-group"})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the security groups matching the filters", func() {
+			records, err := securityGroupRepo.ListSecurityGroups(ctx, authInfo, repositories.ListSecurityGroupsMessage{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(records).To(HaveLen(2))
+
+			records, err = securityGroupRepo.ListSecurityGroups(ctx, authInfo, repositories.ListSecurityGroupsMessage{
+				GloballyEnabledStaging: tools.PtrTo(true),
+				StagingSpaceGUIDs:      []string{"space-2"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(records).To(HaveLen(1))
+			Expect(records[0].GUID).To(Equal(securityGroup.GUID))
+		})
+
+		It("updates the security group", func() {
+			record, err := securityGroupRepo.UpdateSecurityGroup(ctx, authInfo, repositories.UpdateSecurityGroupMessage{
+				GUID:                   securityGroup.GUID,
+				Name:                   tools.PtrTo("renamed"),
+				GloballyEnabledRunning: tools.PtrTo(true),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(record.Name).To(Equal("renamed"))
+			Expect(record.GloballyEnabled).To(Equal(repositories.SecurityGroupWorkloads{Running: true, Staging: true}))
+			Expect(record.Rules).To(HaveLen(1))
+		})
+
+		It("does not allow renaming to the name of another group", func() {
+			_, err := securityGroupRepo.UpdateSecurityGroup(ctx, authInfo, repositories.UpdateSecurityGroupMessage{
+				GUID: securityGroup.GUID,
+				Name: tools.PtrTo("other-group"),
+			})
+			Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+		})
+
+		It("binds and unbinds spaces", func() {
+			record, err := securityGroupRepo.BindSecurityGroup(ctx, authInfo, repositories.BindSecurityGroupMessage{
+				GUID:       securityGroup.GUID,
+				Workload:   repositories.SecurityGroupWorkloadStaging,
+				SpaceGUIDs: []string{"space-3"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(record.StagingSpaceGUIDs).To(Equal([]string{"space-2", "space-3"}))
+
+			Expect(securityGroupRepo.UnbindSecurityGroup(ctx, authInfo, repositories.UnbindSecurityGroupMessage{
+				GUID:      securityGroup.GUID,
+				Workload:  repositories.SecurityGroupWorkloadRunning,
+				SpaceGUID: "space-1",
+			})).To(Succeed())
+
+			record, err = securityGroupRepo.GetSecurityGroup(ctx, authInfo, securityGroup.GUID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(record.RunningSpaceGUIDs).To(Equal([]string{"space-2"}))
+		})
+
+		It("deletes the security group", func() {
+			Expect(securityGroupRepo.DeleteSecurityGroup(ctx, authInfo, securityGroup.GUID)).To(Succeed())
+
+			_, err := securityGroupRepo.GetSecurityGroup(ctx, authInfo, securityGroup.GUID)
+			Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+		})
+	})
+})
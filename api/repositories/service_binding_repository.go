@@ -67,10 +67,11 @@ type ServiceBindingLastOperation struct {
 }
 
 type CreateServiceBindingMessage struct {
-	Name                *string
-	ServiceInstanceGUID string
-	AppGUID             string
-	SpaceGUID           string
+	Name                     *string
+	ServiceInstanceGUID      string
+	ServiceInstanceSpaceGUID string
+	AppGUID                  string
+	SpaceGUID                string
 }
 
 type DeleteServiceBindingMessage struct {
@@ -84,6 +85,13 @@ type ListServiceBindingsMessage struct {
 
 func (m CreateServiceBindingMessage) toCFServiceBinding() *korifiv1alpha1.CFServiceBinding {
 	guid := uuid.NewString()
+
+	// The service namespace is only set for instances shared from another space
+	serviceNamespace := ""
+	if m.ServiceInstanceSpaceGUID != m.SpaceGUID {
+		serviceNamespace = m.ServiceInstanceSpaceGUID
+	}
+
 	return &korifiv1alpha1.CFServiceBinding{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      guid,
@@ -96,6 +104,7 @@ func (m CreateServiceBindingMessage) toCFServiceBinding() *korifiv1alpha1.CFServ
 				Kind:       "CFServiceInstance",
 				APIVersion: korifiv1alpha1.GroupVersion.Identifier(),
 				Name:       m.ServiceInstanceGUID,
+				Namespace:  serviceNamespace,
 			},
 			AppRef: corev1.LocalObjectReference{Name: m.AppGUID},
 		},
@@ -8,6 +8,8 @@ import (
 	"code.cloudfoundry.org/korifi/api/apierrors"
 	"code.cloudfoundry.org/korifi/api/authorization"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	"github.com/google/uuid"
 	corev1 "k8s.io/api/core/v1"
@@ -31,28 +33,33 @@ type ServiceInstanceRepo struct {
 	namespaceRetriever   NamespaceRetriever
 	userClientFactory    authorization.UserK8sClientFactory
 	namespacePermissions *authorization.NamespacePermissions
+	privilegedClient     client.Client
 }
 
 func NewServiceInstanceRepo(
 	namespaceRetriever NamespaceRetriever,
 	userClientFactory authorization.UserK8sClientFactory,
 	namespacePermissions *authorization.NamespacePermissions,
+	privilegedClient client.Client,
 ) *ServiceInstanceRepo {
 	return &ServiceInstanceRepo{
 		namespaceRetriever:   namespaceRetriever,
 		userClientFactory:    userClientFactory,
 		namespacePermissions: namespacePermissions,
+		privilegedClient:     privilegedClient,
 	}
 }
 
 type CreateServiceInstanceMessage struct {
-	Name        string
-	SpaceGUID   string
-	Credentials map[string]string
-	Type        string
-	Tags        []string
-	Labels      map[string]string
-	Annotations map[string]string
+	Name            string
+	SpaceGUID       string
+	Credentials     map[string]string
+	Type            string
+	Tags            []string
+	RouteServiceURL *string
+	SyslogDrainURL  *string
+	Labels          map[string]string
+	Annotations     map[string]string
 }
 
 type ListServiceInstanceMessage struct {
@@ -67,15 +74,38 @@ type DeleteServiceInstanceMessage struct {
 	SpaceGUID string
 }
 
+type ShareServiceInstanceMessage struct {
+	GUID             string
+	SpaceGUID        string
+	SharedSpaceGUIDs []string
+}
+
+type UnshareServiceInstanceMessage struct {
+	GUID            string
+	SpaceGUID       string
+	SharedSpaceGUID string
+}
+
 type ServiceInstanceRecord struct {
-	Name       string
-	GUID       string
-	SpaceGUID  string
-	SecretName string
-	Tags       []string
-	Type       string
-	CreatedAt  string
-	UpdatedAt  string
+	Name             string
+	GUID             string
+	SpaceGUID        string
+	SecretName       string
+	Tags             []string
+	Type             string
+	RouteServiceURL  *string
+	SyslogDrainURL   *string
+	SharedSpaceGUIDs []string
+	LastOperation    *ServiceInstanceLastOperation
+	CreatedAt        string
+	UpdatedAt        string
+}
+
+type ServiceInstanceLastOperation struct {
+	Type        string
+	State       string
+	Description string
+	UpdatedAt   string
 }
 
 func (r *ServiceInstanceRepo) CreateServiceInstance(ctx context.Context, authInfo authorization.Info, message CreateServiceInstanceMessage) (ServiceInstanceRecord, error) {
@@ -121,6 +151,7 @@ func (r *ServiceInstanceRepo) ListServiceInstances(ctx context.Context, authInfo
 	}
 
 	var filteredServiceInstances []korifiv1alpha1.CFServiceInstance
+	listedNamespaces := map[string]bool{}
 	for ns := range nsList {
 		serviceInstanceList := new(korifiv1alpha1.CFServiceInstanceList)
 		err = userClient.List(ctx, serviceInstanceList, client.InNamespace(ns))
@@ -133,9 +164,16 @@ func (r *ServiceInstanceRepo) ListServiceInstances(ctx context.Context, authInfo
 				apierrors.FromK8sError(err, ServiceInstanceResourceType),
 			)
 		}
+		listedNamespaces[ns] = true
 		filteredServiceInstances = append(filteredServiceInstances, applyServiceInstanceListFilter(serviceInstanceList.Items, message)...)
 	}
 
+	sharedServiceInstances, err := r.listSharedServiceInstances(ctx, nsList, listedNamespaces, message)
+	if err != nil {
+		return []ServiceInstanceRecord{}, err
+	}
+	filteredServiceInstances = append(filteredServiceInstances, sharedServiceInstances...)
+
 	orderedServiceInstances := orderServiceInstances(filteredServiceInstances, message.OrderBy, message.DescendingOrder)
 
 	return returnServiceInstanceList(orderedServiceInstances), nil
@@ -154,12 +192,71 @@ func (r *ServiceInstanceRepo) GetServiceInstance(ctx context.Context, authInfo a
 
 	var serviceInstance korifiv1alpha1.CFServiceInstance
 	if err := userClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: guid}, &serviceInstance); err != nil {
+		if !k8serrors.IsForbidden(err) {
+			return ServiceInstanceRecord{}, fmt.Errorf("failed to get service instance: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+		}
+
+		// users of the spaces a service instance has been shared with have no access to the namespace of the owning space
+		return r.getSharedServiceInstance(ctx, authInfo, namespace, guid, err)
+	}
+
+	return cfServiceInstanceToServiceInstanceRecord(serviceInstance), nil
+}
+
+func (r *ServiceInstanceRepo) getSharedServiceInstance(ctx context.Context, authInfo authorization.Info, namespace, guid string, userErr error) (ServiceInstanceRecord, error) {
+	authorizedSpaces, err := r.namespacePermissions.GetAuthorizedSpaceNamespaces(ctx, authInfo)
+	if err != nil {
+		return ServiceInstanceRecord{}, fmt.Errorf("failed to list namespaces for spaces with user role bindings: %w", err)
+	}
+
+	var serviceInstance korifiv1alpha1.CFServiceInstance
+	if err = r.privilegedClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: guid}, &serviceInstance); err != nil {
 		return ServiceInstanceRecord{}, fmt.Errorf("failed to get service instance: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
 	}
 
+	if len(sharedSpacesIn(serviceInstance, authorizedSpaces)) == 0 {
+		return ServiceInstanceRecord{}, apierrors.NewForbiddenError(userErr, ServiceInstanceResourceType)
+	}
+
 	return cfServiceInstanceToServiceInstanceRecord(serviceInstance), nil
 }
 
+// listSharedServiceInstances returns the service instances shared with the authorized spaces of the user that
+// are not in one of the already listed namespaces. The space filter matches the spaces the instances are shared with
+func (r *ServiceInstanceRepo) listSharedServiceInstances(ctx context.Context, authorizedSpaces, listedNamespaces map[string]bool, message ListServiceInstanceMessage) ([]korifiv1alpha1.CFServiceInstance, error) {
+	serviceInstanceList := new(korifiv1alpha1.CFServiceInstanceList)
+	if err := r.privilegedClient.List(ctx, serviceInstanceList); err != nil {
+		return nil, fmt.Errorf("failed to list shared service instances: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+	}
+
+	var sharedServiceInstances []korifiv1alpha1.CFServiceInstance
+	for _, serviceInstance := range serviceInstanceList.Items {
+		if listedNamespaces[serviceInstance.Namespace] || !matchesFilter(serviceInstance.Spec.DisplayName, message.Names) {
+			continue
+		}
+
+		for _, sharedSpace := range sharedSpacesIn(serviceInstance, authorizedSpaces) {
+			if matchesFilter(sharedSpace, message.SpaceGuids) {
+				sharedServiceInstances = append(sharedServiceInstances, serviceInstance)
+				break
+			}
+		}
+	}
+
+	return sharedServiceInstances, nil
+}
+
+func sharedSpacesIn(serviceInstance korifiv1alpha1.CFServiceInstance, spaces map[string]bool) []string {
+	var sharedSpaces []string
+	for _, sharedSpace := range serviceInstance.Spec.SharedSpaces {
+		if spaces[sharedSpace] {
+			sharedSpaces = append(sharedSpaces, sharedSpace)
+		}
+	}
+
+	return sharedSpaces
+}
+
 func (r *ServiceInstanceRepo) DeleteServiceInstance(ctx context.Context, authInfo authorization.Info, message DeleteServiceInstanceMessage) error {
 	userClient, err := r.userClientFactory.BuildClient(authInfo)
 	if err != nil {
@@ -180,8 +277,91 @@ func (r *ServiceInstanceRepo) DeleteServiceInstance(ctx context.Context, authInf
 	return nil
 }
 
+func (r *ServiceInstanceRepo) ShareServiceInstance(ctx context.Context, authInfo authorization.Info, message ShareServiceInstanceMessage) (ServiceInstanceRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return ServiceInstanceRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	serviceInstance := new(korifiv1alpha1.CFServiceInstance)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: message.SpaceGUID, Name: message.GUID}, serviceInstance)
+	if err != nil {
+		return ServiceInstanceRecord{}, fmt.Errorf("failed to get service instance: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+	}
+
+	err = k8s.PatchResource(ctx, userClient, serviceInstance, func() {
+		for _, spaceGUID := range message.SharedSpaceGUIDs {
+			if !serviceInstance.IsSharedWith(spaceGUID) {
+				serviceInstance.Spec.SharedSpaces = append(serviceInstance.Spec.SharedSpaces, spaceGUID)
+			}
+		}
+	})
+	if err != nil {
+		return ServiceInstanceRecord{}, fmt.Errorf("failed to share service instance: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+	}
+
+	return cfServiceInstanceToServiceInstanceRecord(*serviceInstance), nil
+}
+
+// UnshareServiceInstance removes the space from the shared spaces of the service instance and deletes
+// any bindings to the service instance in that space
+func (r *ServiceInstanceRepo) UnshareServiceInstance(ctx context.Context, authInfo authorization.Info, message UnshareServiceInstanceMessage) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	serviceInstance := new(korifiv1alpha1.CFServiceInstance)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: message.SpaceGUID, Name: message.GUID}, serviceInstance)
+	if err != nil {
+		return fmt.Errorf("failed to get service instance: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+	}
+
+	serviceBindings := new(korifiv1alpha1.CFServiceBindingList)
+	err = userClient.List(ctx, serviceBindings, client.InNamespace(message.SharedSpaceGUID))
+	if err != nil {
+		return fmt.Errorf("failed to list service bindings in space %s: %w", message.SharedSpaceGUID, apierrors.FromK8sError(err, ServiceBindingResourceType))
+	}
+
+	for i := range serviceBindings.Items {
+		serviceBinding := &serviceBindings.Items[i]
+		if serviceBinding.Spec.Service.Name != message.GUID || serviceBinding.ServiceNamespace() != message.SpaceGUID {
+			continue
+		}
+
+		err = userClient.Delete(ctx, serviceBinding)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete service binding %s: %w", serviceBinding.Name, apierrors.FromK8sError(err, ServiceBindingResourceType))
+		}
+	}
+
+	err = k8s.PatchResource(ctx, userClient, serviceInstance, func() {
+		sharedSpaces := []string{}
+		for _, spaceGUID := range serviceInstance.Spec.SharedSpaces {
+			if spaceGUID != message.SharedSpaceGUID {
+				sharedSpaces = append(sharedSpaces, spaceGUID)
+			}
+		}
+		serviceInstance.Spec.SharedSpaces = sharedSpaces
+	})
+	if err != nil {
+		return fmt.Errorf("failed to unshare service instance: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+	}
+
+	return nil
+}
+
 func (m CreateServiceInstanceMessage) toCFServiceInstance() korifiv1alpha1.CFServiceInstance {
 	guid := uuid.NewString()
+	routeServiceURL := ""
+	if m.RouteServiceURL != nil {
+		routeServiceURL = *m.RouteServiceURL
+	}
+	syslogDrainURL := ""
+	if m.SyslogDrainURL != nil {
+		syslogDrainURL = *m.SyslogDrainURL
+	}
+
 	return korifiv1alpha1.CFServiceInstance{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        guid,
@@ -190,10 +370,12 @@ func (m CreateServiceInstanceMessage) toCFServiceInstance() korifiv1alpha1.CFSer
 			Annotations: m.Annotations,
 		},
 		Spec: korifiv1alpha1.CFServiceInstanceSpec{
-			DisplayName: m.Name,
-			SecretName:  guid,
-			Type:        korifiv1alpha1.InstanceType(m.Type),
-			Tags:        m.Tags,
+			DisplayName:     m.Name,
+			SecretName:      guid,
+			Type:            korifiv1alpha1.InstanceType(m.Type),
+			Tags:            m.Tags,
+			RouteServiceURL: routeServiceURL,
+			SyslogDrainURL:  syslogDrainURL,
 		},
 	}
 }
@@ -201,15 +383,39 @@ func (m CreateServiceInstanceMessage) toCFServiceInstance() korifiv1alpha1.CFSer
 func cfServiceInstanceToServiceInstanceRecord(cfServiceInstance korifiv1alpha1.CFServiceInstance) ServiceInstanceRecord {
 	updatedAtTime, _ := getTimeLastUpdatedTimestamp(&cfServiceInstance.ObjectMeta)
 
+	var routeServiceURL *string
+	if cfServiceInstance.Spec.RouteServiceURL != "" {
+		routeServiceURL = tools.PtrTo(cfServiceInstance.Spec.RouteServiceURL)
+	}
+
+	var syslogDrainURL *string
+	if cfServiceInstance.Spec.SyslogDrainURL != "" {
+		syslogDrainURL = tools.PtrTo(cfServiceInstance.Spec.SyslogDrainURL)
+	}
+
+	var lastOperation *ServiceInstanceLastOperation
+	if cfServiceInstance.Status.LastOperation != nil {
+		lastOperation = &ServiceInstanceLastOperation{
+			Type:        string(cfServiceInstance.Status.LastOperation.Type),
+			State:       string(cfServiceInstance.Status.LastOperation.State),
+			Description: cfServiceInstance.Status.LastOperation.Description,
+			UpdatedAt:   cfServiceInstance.Status.LastOperation.UpdatedAt.UTC().Format(TimestampFormat),
+		}
+	}
+
 	return ServiceInstanceRecord{
-		Name:       cfServiceInstance.Spec.DisplayName,
-		GUID:       cfServiceInstance.Name,
-		SpaceGUID:  cfServiceInstance.Namespace,
-		SecretName: cfServiceInstance.Spec.SecretName,
-		Tags:       cfServiceInstance.Spec.Tags,
-		Type:       string(cfServiceInstance.Spec.Type),
-		CreatedAt:  cfServiceInstance.CreationTimestamp.UTC().Format(TimestampFormat),
-		UpdatedAt:  updatedAtTime,
+		Name:             cfServiceInstance.Spec.DisplayName,
+		GUID:             cfServiceInstance.Name,
+		SpaceGUID:        cfServiceInstance.Namespace,
+		SecretName:       cfServiceInstance.Spec.SecretName,
+		Tags:             cfServiceInstance.Spec.Tags,
+		Type:             string(cfServiceInstance.Spec.Type),
+		RouteServiceURL:  routeServiceURL,
+		SyslogDrainURL:   syslogDrainURL,
+		SharedSpaceGUIDs: cfServiceInstance.Spec.SharedSpaces,
+		LastOperation:    lastOperation,
+		CreatedAt:        cfServiceInstance.CreationTimestamp.UTC().Format(TimestampFormat),
+		UpdatedAt:        updatedAtTime,
 	}
 }
 
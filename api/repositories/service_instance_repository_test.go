@@ -9,6 +9,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/apierrors"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -17,6 +18,7 @@ import (
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var _ = Describe("ServiceInstanceRepository", func() {
@@ -31,7 +33,7 @@ var _ = Describe("ServiceInstanceRepository", func() {
 
 	BeforeEach(func() {
 		testCtx = context.Background()
-		serviceInstanceRepo = repositories.NewServiceInstanceRepo(namespaceRetriever, userClientFactory, nsPerms)
+		serviceInstanceRepo = repositories.NewServiceInstanceRepo(namespaceRetriever, userClientFactory, nsPerms, k8sClient)
 
 		org = createOrgWithCleanup(testCtx, prefixedGUID("org"))
 		space = createSpaceWithCleanup(testCtx, org.Name, prefixedGUID("space1"))
@@ -218,6 +220,45 @@ var _ = Describe("ServiceInstanceRepository", func() {
 			})
 		})
 
+		When("a service instance of another space is shared with a space of the user", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+
+				Expect(k8s.PatchResource(testCtx, k8sClient, cfServiceInstance3, func() {
+					cfServiceInstance3.Spec.SharedSpaces = []string{space.Name}
+				})).To(Succeed())
+			})
+
+			It("returns the shared service instance along with the ones of the space", func() {
+				Expect(serviceInstanceList).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(cfServiceInstance1.Name)}),
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(cfServiceInstance3.Name), "SpaceGUID": Equal(space3.Name)}),
+				))
+			})
+
+			When("the spaceGUID filter is set to the space the instance is shared with", func() {
+				BeforeEach(func() {
+					filters = repositories.ListServiceInstanceMessage{SpaceGuids: []string{space.Name}}
+				})
+
+				It("returns the shared service instance", func() {
+					Expect(serviceInstanceList).To(ContainElement(
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(cfServiceInstance3.Name)}),
+					))
+				})
+			})
+
+			When("the spaceGUID filter is set to another space", func() {
+				BeforeEach(func() {
+					filters = repositories.ListServiceInstanceMessage{SpaceGuids: []string{space2.Name}}
+				})
+
+				It("does not return the shared service instance", func() {
+					Expect(serviceInstanceList).To(BeEmpty())
+				})
+			})
+		})
+
 		When("query parameters are provided", func() {
 			BeforeEach(func() {
 				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
@@ -442,6 +483,60 @@ var _ = Describe("ServiceInstanceRepository", func() {
 				Expect(record.SecretName).To(Equal(serviceInstance.Spec.SecretName))
 				Expect(record.Tags).To(Equal(serviceInstance.Spec.Tags))
 				Expect(record.Type).To(Equal(string(serviceInstance.Spec.Type)))
+				Expect(record.LastOperation).To(BeNil())
+			})
+
+			When("the service instance reports its last operation", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(testCtx, k8sClient, serviceInstance, func() {
+						serviceInstance.Status.Conditions = []metav1.Condition{}
+						serviceInstance.Status.LastOperation = &korifiv1alpha1.LastOperation{
+							Type:        korifiv1alpha1.CreateOperation,
+							State:       korifiv1alpha1.InProgressState,
+							Description: "Waiting for the credentials secret",
+							UpdatedAt:   metav1.NewTime(time.Date(2022, 11, 30, 10, 20, 30, 0, time.UTC)),
+						}
+					})).To(Succeed())
+				})
+
+				It("returns the last operation", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(record.LastOperation).To(PointTo(Equal(repositories.ServiceInstanceLastOperation{
+						Type:        "create",
+						State:       "in progress",
+						Description: "Waiting for the credentials secret",
+						UpdatedAt:   "2022-11-30T10:20:30Z",
+					})))
+				})
+			})
+		})
+
+		When("the service instance is shared with a space of the user", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space2.Name)
+
+				Expect(k8s.PatchResource(testCtx, k8sClient, serviceInstance, func() {
+					serviceInstance.Spec.SharedSpaces = []string{space2.Name}
+				})).To(Succeed())
+			})
+
+			It("returns the service instance", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(record.GUID).To(Equal(serviceInstance.Name))
+				Expect(record.SpaceGUID).To(Equal(space.Name))
+				Expect(record.SharedSpaceGUIDs).To(ConsistOf(space2.Name))
+			})
+		})
+
+		When("the service instance is shared with a space the user has no role in", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(testCtx, k8sClient, serviceInstance, func() {
+					serviceInstance.Spec.SharedSpaces = []string{space2.Name}
+				})).To(Succeed())
+			})
+
+			It("returns a forbidden error", func() {
+				Expect(errors.As(getErr, &apierrors.ForbiddenError{})).To(BeTrue())
 			})
 		})
 
@@ -535,6 +630,120 @@ var _ = Describe("ServiceInstanceRepository", func() {
 			})
 		})
 	})
+
+	Describe("ShareServiceInstance", func() {
+		var (
+			serviceInstance *korifiv1alpha1.CFServiceInstance
+			shareMessage    repositories.ShareServiceInstanceMessage
+			record          repositories.ServiceInstanceRecord
+			shareErr        error
+		)
+
+		BeforeEach(func() {
+			serviceInstance = createServiceInstanceCR(testCtx, k8sClient, prefixedGUID("service-instance"), space.Name, "the-service-instance", prefixedGUID("secret"))
+
+			shareMessage = repositories.ShareServiceInstanceMessage{
+				GUID:             serviceInstance.Name,
+				SpaceGUID:        space.Name,
+				SharedSpaceGUIDs: []string{"space-a", "space-b", "space-a"},
+			}
+		})
+
+		JustBeforeEach(func() {
+			record, shareErr = serviceInstanceRepo.ShareServiceInstance(testCtx, authInfo, shareMessage)
+		})
+
+		When("the user has permissions to update service instances", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("adds the spaces to the shared spaces of the service instance", func() {
+				Expect(shareErr).NotTo(HaveOccurred())
+				Expect(record.SharedSpaceGUIDs).To(Equal([]string{"space-a", "space-b"}))
+
+				updatedServiceInstance := new(korifiv1alpha1.CFServiceInstance)
+				Expect(k8sClient.Get(testCtx, client.ObjectKeyFromObject(serviceInstance), updatedServiceInstance)).To(Succeed())
+				Expect(updatedServiceInstance.Spec.SharedSpaces).To(Equal([]string{"space-a", "space-b"}))
+			})
+		})
+
+		When("there are no permissions on service instances", func() {
+			It("returns a forbidden error", func() {
+				Expect(errors.As(shareErr, &apierrors.ForbiddenError{})).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("UnshareServiceInstance", func() {
+		var (
+			serviceInstance *korifiv1alpha1.CFServiceInstance
+			sharedSpace     *korifiv1alpha1.CFSpace
+			sharedBinding   *korifiv1alpha1.CFServiceBinding
+			unshareErr      error
+		)
+
+		BeforeEach(func() {
+			sharedSpace = createSpaceWithCleanup(testCtx, org.Name, prefixedGUID("space2"))
+			serviceInstance = createServiceInstanceCR(testCtx, k8sClient, prefixedGUID("service-instance"), space.Name, "the-service-instance", prefixedGUID("secret"))
+			Expect(k8s.Patch(testCtx, k8sClient, serviceInstance, func() {
+				serviceInstance.Spec.SharedSpaces = []string{sharedSpace.Name, "another-space"}
+			})).To(Succeed())
+
+			sharedBinding = &korifiv1alpha1.CFServiceBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      prefixedGUID("binding"),
+					Namespace: sharedSpace.Name,
+				},
+				Spec: korifiv1alpha1.CFServiceBindingSpec{
+					Service: corev1.ObjectReference{
+						Kind:       "CFServiceInstance",
+						APIVersion: korifiv1alpha1.GroupVersion.Identifier(),
+						Name:       serviceInstance.Name,
+						Namespace:  space.Name,
+					},
+					AppRef: corev1.LocalObjectReference{Name: "some-app"},
+				},
+			}
+			Expect(k8sClient.Create(testCtx, sharedBinding)).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			unshareErr = serviceInstanceRepo.UnshareServiceInstance(testCtx, authInfo, repositories.UnshareServiceInstanceMessage{
+				GUID:            serviceInstance.Name,
+				SpaceGUID:       space.Name,
+				SharedSpaceGUID: sharedSpace.Name,
+			})
+		})
+
+		When("the user has permissions in both spaces", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, sharedSpace.Name)
+			})
+
+			It("removes the space from the shared spaces of the service instance", func() {
+				Expect(unshareErr).NotTo(HaveOccurred())
+
+				updatedServiceInstance := new(korifiv1alpha1.CFServiceInstance)
+				Expect(k8sClient.Get(testCtx, client.ObjectKeyFromObject(serviceInstance), updatedServiceInstance)).To(Succeed())
+				Expect(updatedServiceInstance.Spec.SharedSpaces).To(Equal([]string{"another-space"}))
+			})
+
+			It("deletes the bindings to the service instance in the unshared space", func() {
+				Expect(unshareErr).NotTo(HaveOccurred())
+
+				err := k8sClient.Get(testCtx, client.ObjectKeyFromObject(sharedBinding), &korifiv1alpha1.CFServiceBinding{})
+				Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			})
+		})
+
+		When("there are no permissions on service instances", func() {
+			It("returns a forbidden error", func() {
+				Expect(errors.As(unshareErr, &apierrors.ForbiddenError{})).To(BeTrue())
+			})
+		})
+	})
 })
 
 func initializeServiceInstanceCreateMessage(serviceInstanceName string, spaceGUID string, tags []string, credentials map[string]string) repositories.CreateServiceInstanceMessage {
@@ -0,0 +1,215 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks"
+
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	ServiceRouteBindingResourceType = "Service Route Binding"
+)
+
+type ServiceRouteBindingRepo struct {
+	userClientFactory       authorization.UserK8sClientFactory
+	namespacePermissions    *authorization.NamespacePermissions
+	namespaceRetriever      NamespaceRetriever
+	bindingConditionAwaiter ConditionAwaiter[*korifiv1alpha1.CFServiceRouteBinding]
+}
+
+func NewServiceRouteBindingRepo(
+	namespaceRetriever NamespaceRetriever,
+	userClientFactory authorization.UserK8sClientFactory,
+	namespacePermissions *authorization.NamespacePermissions,
+	bindingConditionAwaiter ConditionAwaiter[*korifiv1alpha1.CFServiceRouteBinding],
+) *ServiceRouteBindingRepo {
+	return &ServiceRouteBindingRepo{
+		userClientFactory:       userClientFactory,
+		namespacePermissions:    namespacePermissions,
+		namespaceRetriever:      namespaceRetriever,
+		bindingConditionAwaiter: bindingConditionAwaiter,
+	}
+}
+
+type ServiceRouteBindingRecord struct {
+	GUID                string
+	RouteGUID           string
+	ServiceInstanceGUID string
+	SpaceGUID           string
+	RouteServiceURL     *string
+	Labels              map[string]string
+	Annotations         map[string]string
+	CreatedAt           string
+	UpdatedAt           string
+}
+
+type CreateServiceRouteBindingMessage struct {
+	RouteGUID           string
+	ServiceInstanceGUID string
+	SpaceGUID           string
+	Labels              map[string]string
+	Annotations         map[string]string
+}
+
+type ListServiceRouteBindingsMessage struct {
+	RouteGUIDs           []string
+	ServiceInstanceGUIDs []string
+}
+
+func (m CreateServiceRouteBindingMessage) toCFServiceRouteBinding() *korifiv1alpha1.CFServiceRouteBinding {
+	return &korifiv1alpha1.CFServiceRouteBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        uuid.NewString(),
+			Namespace:   m.SpaceGUID,
+			Labels:      m.Labels,
+			Annotations: m.Annotations,
+		},
+		Spec: korifiv1alpha1.CFServiceRouteBindingSpec{
+			Service: corev1.ObjectReference{
+				Kind:       "CFServiceInstance",
+				APIVersion: korifiv1alpha1.GroupVersion.Identifier(),
+				Name:       m.ServiceInstanceGUID,
+			},
+			RouteRef: corev1.LocalObjectReference{Name: m.RouteGUID},
+		},
+	}
+}
+
+func (r *ServiceRouteBindingRepo) CreateServiceRouteBinding(ctx context.Context, authInfo authorization.Info, message CreateServiceRouteBindingMessage) (ServiceRouteBindingRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return ServiceRouteBindingRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfRouteBinding := message.toCFServiceRouteBinding()
+	err = userClient.Create(ctx, cfRouteBinding)
+	if err != nil {
+		if validationError, ok := webhooks.WebhookErrorToValidationError(err); ok {
+			if validationError.Type == webhooks.DuplicateNameErrorType {
+				return ServiceRouteBindingRecord{}, apierrors.NewUnprocessableEntityError(err, "The route and service instance are already bound.")
+			}
+		}
+
+		return ServiceRouteBindingRecord{}, apierrors.FromK8sError(err, ServiceRouteBindingResourceType)
+	}
+
+	cfRouteBinding, err = r.bindingConditionAwaiter.AwaitCondition(ctx, userClient, cfRouteBinding, StatusConditionReady)
+	if err != nil {
+		return ServiceRouteBindingRecord{}, err
+	}
+
+	return cfServiceRouteBindingToRecord(cfRouteBinding), nil
+}
+
+func (r *ServiceRouteBindingRepo) GetServiceRouteBinding(ctx context.Context, authInfo authorization.Info, guid string) (ServiceRouteBindingRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return ServiceRouteBindingRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	namespace, err := r.namespaceRetriever.NamespaceFor(ctx, guid, ServiceRouteBindingResourceType)
+	if err != nil {
+		return ServiceRouteBindingRecord{}, err
+	}
+
+	cfRouteBinding := new(korifiv1alpha1.CFServiceRouteBinding)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: guid}, cfRouteBinding)
+	if err != nil {
+		return ServiceRouteBindingRecord{}, apierrors.FromK8sError(err, ServiceRouteBindingResourceType)
+	}
+
+	return cfServiceRouteBindingToRecord(cfRouteBinding), nil
+}
+
+func (r *ServiceRouteBindingRepo) ListServiceRouteBindings(ctx context.Context, authInfo authorization.Info, message ListServiceRouteBindingsMessage) ([]ServiceRouteBindingRecord, error) {
+	nsList, err := r.namespacePermissions.GetAuthorizedSpaceNamespaces(ctx, authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces for spaces with user role bindings: %w", err)
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return []ServiceRouteBindingRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	records := []ServiceRouteBindingRecord{}
+	for ns := range nsList {
+		routeBindingList := new(korifiv1alpha1.CFServiceRouteBindingList)
+		err = userClient.List(ctx, routeBindingList, client.InNamespace(ns))
+		if k8serrors.IsForbidden(err) {
+			continue
+		}
+		if err != nil {
+			return []ServiceRouteBindingRecord{}, fmt.Errorf("failed to list service route bindings in namespace %s: %w",
+				ns,
+				apierrors.FromK8sError(err, ServiceRouteBindingResourceType),
+			)
+		}
+
+		for i := range routeBindingList.Items {
+			routeBinding := &routeBindingList.Items[i]
+			if matchesFilter(routeBinding.Spec.RouteRef.Name, message.RouteGUIDs) &&
+				matchesFilter(routeBinding.Spec.Service.Name, message.ServiceInstanceGUIDs) {
+				records = append(records, cfServiceRouteBindingToRecord(routeBinding))
+			}
+		}
+	}
+
+	return records, nil
+}
+
+func (r *ServiceRouteBindingRepo) DeleteServiceRouteBinding(ctx context.Context, authInfo authorization.Info, guid string) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	namespace, err := r.namespaceRetriever.NamespaceFor(ctx, guid, ServiceRouteBindingResourceType)
+	if err != nil {
+		return err
+	}
+
+	cfRouteBinding := new(korifiv1alpha1.CFServiceRouteBinding)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: guid}, cfRouteBinding)
+	if err != nil {
+		return apierrors.ForbiddenAsNotFound(apierrors.FromK8sError(err, ServiceRouteBindingResourceType))
+	}
+
+	err = userClient.Delete(ctx, cfRouteBinding)
+	if err != nil {
+		return apierrors.FromK8sError(err, ServiceRouteBindingResourceType)
+	}
+
+	return nil
+}
+
+func cfServiceRouteBindingToRecord(routeBinding *korifiv1alpha1.CFServiceRouteBinding) ServiceRouteBindingRecord {
+	updatedAt, _ := getTimeLastUpdatedTimestamp(&routeBinding.ObjectMeta)
+
+	var routeServiceURL *string
+	if routeBinding.Status.RouteServiceURL != "" {
+		routeServiceURL = &routeBinding.Status.RouteServiceURL
+	}
+
+	return ServiceRouteBindingRecord{
+		GUID:                routeBinding.Name,
+		RouteGUID:           routeBinding.Spec.RouteRef.Name,
+		ServiceInstanceGUID: routeBinding.Spec.Service.Name,
+		SpaceGUID:           routeBinding.Namespace,
+		RouteServiceURL:     routeServiceURL,
+		Labels:              routeBinding.Labels,
+		Annotations:         routeBinding.Annotations,
+		CreatedAt:           formatTimestamp(routeBinding.CreationTimestamp),
+		UpdatedAt:           updatedAt,
+	}
+}
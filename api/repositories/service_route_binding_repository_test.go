@@ -0,0 +1,278 @@
+package repositories_test
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/conditions"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("ServiceRouteBindingRepo", func() {
+	var (
+		repo                *repositories.ServiceRouteBindingRepo
+		testCtx             context.Context
+		org                 *korifiv1alpha1.CFOrg
+		space               *korifiv1alpha1.CFSpace
+		routeGUID           string
+		serviceInstanceGUID string
+	)
+
+	BeforeEach(func() {
+		testCtx = context.Background()
+		routeBindingConditionAwaiter := conditions.NewConditionAwaiter[*korifiv1alpha1.CFServiceRouteBinding, korifiv1alpha1.CFServiceRouteBindingList](time.Second)
+		repo = repositories.NewServiceRouteBindingRepo(namespaceRetriever, userClientFactory, nsPerms, routeBindingConditionAwaiter)
+
+		org = createOrgWithCleanup(testCtx, prefixedGUID("org"))
+		space = createSpaceWithCleanup(testCtx, org.Name, prefixedGUID("space1"))
+		routeGUID = prefixedGUID("route")
+		serviceInstanceGUID = prefixedGUID("service-instance")
+	})
+
+	createRouteBinding := func(name, routeGUID, serviceInstanceGUID string) *korifiv1alpha1.CFServiceRouteBinding {
+		routeBinding := &korifiv1alpha1.CFServiceRouteBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: space.Name,
+			},
+			Spec: korifiv1alpha1.CFServiceRouteBindingSpec{
+				Service: corev1.ObjectReference{
+					Kind:       "CFServiceInstance",
+					APIVersion: korifiv1alpha1.GroupVersion.Identifier(),
+					Name:       serviceInstanceGUID,
+				},
+				RouteRef: corev1.LocalObjectReference{Name: routeGUID},
+			},
+		}
+		Expect(k8sClient.Create(testCtx, routeBinding)).To(Succeed())
+
+		return routeBinding
+	}
+
+	Describe("CreateServiceRouteBinding", func() {
+		var (
+			record    repositories.ServiceRouteBindingRecord
+			createErr error
+			done      chan bool
+		)
+
+		BeforeEach(func() {
+			done = make(chan bool)
+		})
+
+		JustBeforeEach(func() {
+			record, createErr = repo.CreateServiceRouteBinding(testCtx, authInfo, repositories.CreateServiceRouteBindingMessage{
+				RouteGUID:           routeGUID,
+				ServiceInstanceGUID: serviceInstanceGUID,
+				SpaceGUID:           space.Name,
+				Labels:              map[string]string{"foo": "bar"},
+			})
+			close(done)
+		})
+
+		When("the user can create CFServiceRouteBindings in the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+
+				go func() {
+					defer GinkgoRecover()
+
+					for {
+						select {
+						case <-done:
+							return
+						default:
+						}
+
+						routeBindings := new(korifiv1alpha1.CFServiceRouteBindingList)
+						Expect(k8sClient.List(testCtx, routeBindings, client.InNamespace(space.Name))).To(Succeed())
+						if len(routeBindings.Items) == 1 {
+							routeBinding := &routeBindings.Items[0]
+							original := routeBinding.DeepCopy()
+							routeBinding.Status.RouteServiceURL = "https://route-service.example.com"
+							meta.SetStatusCondition(&routeBinding.Status.Conditions, metav1.Condition{
+								Type:    repositories.StatusConditionReady,
+								Status:  metav1.ConditionTrue,
+								Reason:  "RouteServiceConfigured",
+								Message: "",
+							})
+							Expect(k8sClient.Status().Patch(testCtx, routeBinding, client.MergeFrom(original))).To(Succeed())
+							return
+						}
+
+						time.Sleep(100 * time.Millisecond)
+					}
+				}()
+			})
+
+			It("creates a CFServiceRouteBinding and returns a record", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+
+				Expect(record.GUID).NotTo(BeEmpty())
+				Expect(record.RouteGUID).To(Equal(routeGUID))
+				Expect(record.ServiceInstanceGUID).To(Equal(serviceInstanceGUID))
+				Expect(record.SpaceGUID).To(Equal(space.Name))
+				Expect(record.RouteServiceURL).To(Equal(tools.PtrTo("https://route-service.example.com")))
+				Expect(record.Labels).To(Equal(map[string]string{"foo": "bar"}))
+				Expect(record.CreatedAt).NotTo(BeEmpty())
+				Expect(record.UpdatedAt).NotTo(BeEmpty())
+
+				routeBinding := new(korifiv1alpha1.CFServiceRouteBinding)
+				Expect(
+					k8sClient.Get(testCtx, types.NamespacedName{Name: record.GUID, Namespace: space.Name}, routeBinding),
+				).To(Succeed())
+				Expect(routeBinding.Spec.RouteRef.Name).To(Equal(routeGUID))
+				Expect(routeBinding.Spec.Service.Name).To(Equal(serviceInstanceGUID))
+			})
+		})
+
+		When("the user cannot create CFServiceRouteBindings in the space", func() {
+			It("returns a forbidden error", func() {
+				Expect(createErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+		})
+	})
+
+	Describe("GetServiceRouteBinding", func() {
+		var (
+			routeBinding *korifiv1alpha1.CFServiceRouteBinding
+			record       repositories.ServiceRouteBindingRecord
+			getErr       error
+		)
+
+		BeforeEach(func() {
+			routeBinding = createRouteBinding(prefixedGUID("route-binding"), routeGUID, serviceInstanceGUID)
+		})
+
+		JustBeforeEach(func() {
+			record, getErr = repo.GetServiceRouteBinding(testCtx, authInfo, routeBinding.Name)
+		})
+
+		When("the user can get CFServiceRouteBindings in the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns the record", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(record.GUID).To(Equal(routeBinding.Name))
+				Expect(record.RouteGUID).To(Equal(routeGUID))
+				Expect(record.ServiceInstanceGUID).To(Equal(serviceInstanceGUID))
+				Expect(record.SpaceGUID).To(Equal(space.Name))
+				Expect(record.RouteServiceURL).To(BeNil())
+			})
+		})
+
+		When("the user is not authorized in the space", func() {
+			It("returns a forbidden error", func() {
+				Expect(getErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+		})
+	})
+
+	Describe("ListServiceRouteBindings", func() {
+		var (
+			otherRouteGUID string
+			message        repositories.ListServiceRouteBindingsMessage
+			records        []repositories.ServiceRouteBindingRecord
+			listErr        error
+		)
+
+		BeforeEach(func() {
+			otherRouteGUID = prefixedGUID("other-route")
+			createRouteBinding(prefixedGUID("route-binding-1"), routeGUID, serviceInstanceGUID)
+			createRouteBinding(prefixedGUID("route-binding-2"), otherRouteGUID, serviceInstanceGUID)
+			message = repositories.ListServiceRouteBindingsMessage{}
+		})
+
+		JustBeforeEach(func() {
+			records, listErr = repo.ListServiceRouteBindings(testCtx, authInfo, message)
+		})
+
+		When("the user can list CFServiceRouteBindings in the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns all the bindings", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(records).To(HaveLen(2))
+			})
+
+			When("filtering by route guid", func() {
+				BeforeEach(func() {
+					message.RouteGUIDs = []string{otherRouteGUID}
+				})
+
+				It("returns only the matching bindings", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(records).To(HaveLen(1))
+					Expect(records[0].RouteGUID).To(Equal(otherRouteGUID))
+				})
+			})
+
+			When("filtering by service instance guid", func() {
+				BeforeEach(func() {
+					message.ServiceInstanceGUIDs = []string{"some-other-instance"}
+				})
+
+				It("returns no bindings", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(records).To(BeEmpty())
+				})
+			})
+		})
+
+		When("the user is not authorized in the space", func() {
+			It("returns an empty list", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(records).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("DeleteServiceRouteBinding", func() {
+		var (
+			routeBinding *korifiv1alpha1.CFServiceRouteBinding
+			deleteErr    error
+		)
+
+		BeforeEach(func() {
+			routeBinding = createRouteBinding(prefixedGUID("route-binding"), routeGUID, serviceInstanceGUID)
+		})
+
+		JustBeforeEach(func() {
+			deleteErr = repo.DeleteServiceRouteBinding(testCtx, authInfo, routeBinding.Name)
+		})
+
+		When("the user can delete CFServiceRouteBindings in the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("deletes the binding", func() {
+				Expect(deleteErr).NotTo(HaveOccurred())
+
+				err := k8sClient.Get(testCtx, client.ObjectKeyFromObject(routeBinding), &korifiv1alpha1.CFServiceRouteBinding{})
+				Expect(err).To(MatchError(ContainSubstring("not found")))
+			})
+		})
+
+		When("the user is not authorized in the space", func() {
+			It("returns a not found error", func() {
+				Expect(deleteErr).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+})
@@ -82,3 +82,34 @@ func matchesFilter(field string, filter []string) bool {
 
 	return false
 }
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsAny(values, candidates []string) bool {
+	for _, candidate := range candidates {
+		if contains(values, candidate) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func without(values, removed []string) []string {
+	result := []string{}
+	for _, value := range values {
+		if !contains(removed, value) {
+			result = append(result, value)
+		}
+	}
+
+	return result
+}
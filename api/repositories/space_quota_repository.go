@@ -0,0 +1,261 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/google/uuid"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	SpaceQuotaResourceType = "Space Quota"
+)
+
+type SpaceQuotaRecord struct {
+	GUID             string
+	Name             string
+	Limits           QuotaLimits
+	OrganizationGUID string
+	SpaceGUIDs       []string
+	CreatedAt        string
+	UpdatedAt        string
+}
+
+type CreateSpaceQuotaMessage struct {
+	Name             string
+	Limits           QuotaLimits
+	OrganizationGUID string
+	SpaceGUIDs       []string
+}
+
+type ListSpaceQuotasMessage struct {
+	GUIDs             []string
+	Names             []string
+	OrganizationGUIDs []string
+	SpaceGUIDs        []string
+}
+
+type ApplySpaceQuotaMessage struct {
+	GUID       string
+	SpaceGUIDs []string
+}
+
+// SpaceQuotaRepo manages the CFSpaceQuotas, which live in the namespace of the org owning them and can
+// only be applied to the spaces of that org. A space is limited by at most one space quota
+type SpaceQuotaRepo struct {
+	userClientFactory  authorization.UserK8sClientFactory
+	namespaceRetriever NamespaceRetriever
+	nsPerms            *authorization.NamespacePermissions
+}
+
+func NewSpaceQuotaRepo(
+	userClientFactory authorization.UserK8sClientFactory,
+	namespaceRetriever NamespaceRetriever,
+	nsPerms *authorization.NamespacePermissions,
+) *SpaceQuotaRepo {
+	return &SpaceQuotaRepo{
+		userClientFactory:  userClientFactory,
+		namespaceRetriever: namespaceRetriever,
+		nsPerms:            nsPerms,
+	}
+}
+
+func (r *SpaceQuotaRepo) CreateSpaceQuota(ctx context.Context, authInfo authorization.Info, message CreateSpaceQuotaMessage) (SpaceQuotaRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return SpaceQuotaRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	quotas, err := listSpaceQuotas(ctx, userClient, message.OrganizationGUID)
+	if err != nil {
+		return SpaceQuotaRecord{}, err
+	}
+
+	for _, quota := range quotas {
+		if quota.Spec.DisplayName == message.Name {
+			return SpaceQuotaRecord{}, apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("Space Quota '%s' already exists.", message.Name))
+		}
+	}
+
+	if err = removeSpacesFromQuotas(ctx, userClient, quotas, "", message.SpaceGUIDs); err != nil {
+		return SpaceQuotaRecord{}, err
+	}
+
+	cfSpaceQuota := &korifiv1alpha1.CFSpaceQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      uuid.NewString(),
+			Namespace: message.OrganizationGUID,
+		},
+		Spec: korifiv1alpha1.CFSpaceQuotaSpec{
+			DisplayName: message.Name,
+			QuotaLimits: message.Limits.toCRD(),
+			Spaces:      message.SpaceGUIDs,
+		},
+	}
+
+	err = userClient.Create(ctx, cfSpaceQuota)
+	if err != nil {
+		return SpaceQuotaRecord{}, fmt.Errorf("failed to create space quota: %w", apierrors.FromK8sError(err, SpaceQuotaResourceType))
+	}
+
+	return cfSpaceQuotaToRecord(*cfSpaceQuota), nil
+}
+
+func (r *SpaceQuotaRepo) ListSpaceQuotas(ctx context.Context, authInfo authorization.Info, message ListSpaceQuotasMessage) ([]SpaceQuotaRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	authorizedOrgNamespaces, err := r.nsPerms.GetAuthorizedOrgNamespaces(ctx, authInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	var quotas []korifiv1alpha1.CFSpaceQuota
+	for org := range authorizedOrgNamespaces {
+		if !matchesFilter(org, message.OrganizationGUIDs) {
+			continue
+		}
+
+		quotaList := new(korifiv1alpha1.CFSpaceQuotaList)
+		err = userClient.List(ctx, quotaList, client.InNamespace(org))
+		if k8serrors.IsForbidden(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list space quotas: %w", apierrors.FromK8sError(err, SpaceQuotaResourceType))
+		}
+
+		quotas = append(quotas, quotaList.Items...)
+	}
+
+	sort.Slice(quotas, func(i, j int) bool {
+		return quotas[i].CreationTimestamp.Before(&quotas[j].CreationTimestamp)
+	})
+
+	records := []SpaceQuotaRecord{}
+	for _, quota := range quotas {
+		if !matchesFilter(quota.Name, message.GUIDs) || !matchesFilter(quota.Spec.DisplayName, message.Names) {
+			continue
+		}
+
+		if len(message.SpaceGUIDs) > 0 && !containsAny(quota.Spec.Spaces, message.SpaceGUIDs) {
+			continue
+		}
+
+		records = append(records, cfSpaceQuotaToRecord(quota))
+	}
+
+	return records, nil
+}
+
+func (r *SpaceQuotaRepo) GetSpaceQuota(ctx context.Context, authInfo authorization.Info, guid string) (SpaceQuotaRecord, error) {
+	cfSpaceQuota, _, err := r.getSpaceQuota(ctx, authInfo, guid)
+	if err != nil {
+		return SpaceQuotaRecord{}, err
+	}
+
+	return cfSpaceQuotaToRecord(*cfSpaceQuota), nil
+}
+
+func (r *SpaceQuotaRepo) ApplySpaceQuota(ctx context.Context, authInfo authorization.Info, message ApplySpaceQuotaMessage) (SpaceQuotaRecord, error) {
+	cfSpaceQuota, userClient, err := r.getSpaceQuota(ctx, authInfo, message.GUID)
+	if err != nil {
+		return SpaceQuotaRecord{}, err
+	}
+
+	quotas, err := listSpaceQuotas(ctx, userClient, cfSpaceQuota.Namespace)
+	if err != nil {
+		return SpaceQuotaRecord{}, err
+	}
+
+	if err = removeSpacesFromQuotas(ctx, userClient, quotas, message.GUID, message.SpaceGUIDs); err != nil {
+		return SpaceQuotaRecord{}, err
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfSpaceQuota, func() {
+		for _, spaceGUID := range message.SpaceGUIDs {
+			if !contains(cfSpaceQuota.Spec.Spaces, spaceGUID) {
+				cfSpaceQuota.Spec.Spaces = append(cfSpaceQuota.Spec.Spaces, spaceGUID)
+			}
+		}
+	})
+	if err != nil {
+		return SpaceQuotaRecord{}, fmt.Errorf("failed to apply space quota: %w", apierrors.FromK8sError(err, SpaceQuotaResourceType))
+	}
+
+	return cfSpaceQuotaToRecord(*cfSpaceQuota), nil
+}
+
+func (r *SpaceQuotaRepo) getSpaceQuota(ctx context.Context, authInfo authorization.Info, guid string) (*korifiv1alpha1.CFSpaceQuota, client.WithWatch, error) {
+	ns, err := r.namespaceRetriever.NamespaceFor(ctx, guid, SpaceQuotaResourceType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSpaceQuota := new(korifiv1alpha1.CFSpaceQuota)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: ns, Name: guid}, cfSpaceQuota)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get space quota: %w", apierrors.FromK8sError(err, SpaceQuotaResourceType))
+	}
+
+	return cfSpaceQuota, userClient, nil
+}
+
+func listSpaceQuotas(ctx context.Context, userClient client.Client, orgGUID string) ([]korifiv1alpha1.CFSpaceQuota, error) {
+	quotaList := new(korifiv1alpha1.CFSpaceQuotaList)
+	err := userClient.List(ctx, quotaList, client.InNamespace(orgGUID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list space quotas: %w", apierrors.FromK8sError(err, SpaceQuotaResourceType))
+	}
+
+	return quotaList.Items, nil
+}
+
+// removeSpacesFromQuotas detaches the spaces from any quota other than the one with the skipped guid
+func removeSpacesFromQuotas(ctx context.Context, userClient client.Client, quotas []korifiv1alpha1.CFSpaceQuota, skipGUID string, spaceGUIDs []string) error {
+	for i := range quotas {
+		quota := &quotas[i]
+		if quota.Name == skipGUID || !containsAny(quota.Spec.Spaces, spaceGUIDs) {
+			continue
+		}
+
+		err := k8s.PatchResource(ctx, userClient, quota, func() {
+			quota.Spec.Spaces = without(quota.Spec.Spaces, spaceGUIDs)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to remove spaces from space quota %q: %w", quota.Name, apierrors.FromK8sError(err, SpaceQuotaResourceType))
+		}
+	}
+
+	return nil
+}
+
+func cfSpaceQuotaToRecord(cfSpaceQuota korifiv1alpha1.CFSpaceQuota) SpaceQuotaRecord {
+	updatedAt, _ := getTimeLastUpdatedTimestamp(&cfSpaceQuota.ObjectMeta)
+
+	return SpaceQuotaRecord{
+		GUID:             cfSpaceQuota.Name,
+		Name:             cfSpaceQuota.Spec.DisplayName,
+		Limits:           quotaLimitsFromCRD(cfSpaceQuota.Spec.QuotaLimits),
+		OrganizationGUID: cfSpaceQuota.Namespace,
+		SpaceGUIDs:       cfSpaceQuota.Spec.Spaces,
+		CreatedAt:        formatTimestamp(cfSpaceQuota.CreationTimestamp),
+		UpdatedAt:        updatedAt,
+	}
+}
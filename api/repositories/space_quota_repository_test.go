@@ -0,0 +1,149 @@
+package repositories_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("SpaceQuotaRepository", func() {
+	var (
+		spaceQuotaRepo *repositories.SpaceQuotaRepo
+		cfOrg          *korifiv1alpha1.CFOrg
+		cfSpace        *korifiv1alpha1.CFSpace
+	)
+
+	BeforeEach(func() {
+		spaceQuotaRepo = repositories.NewSpaceQuotaRepo(userClientFactory, namespaceRetriever, nsPerms)
+		cfOrg = createOrgWithCleanup(ctx, uuid.NewString())
+		cfSpace = createSpaceWithCleanup(ctx, cfOrg.Name, uuid.NewString())
+	})
+
+	Describe("CreateSpaceQuota", func() {
+		var (
+			message   repositories.CreateSpaceQuotaMessage
+			record    repositories.SpaceQuotaRecord
+			createErr error
+		)
+
+		BeforeEach(func() {
+			message = repositories.CreateSpaceQuotaMessage{
+				Name:             "my-quota",
+				Limits:           repositories.QuotaLimits{PerProcessMemoryInMB: tools.PtrTo(int64(512))},
+				OrganizationGUID: cfOrg.Name,
+				SpaceGUIDs:       []string{cfSpace.Name},
+			}
+		})
+
+		JustBeforeEach(func() {
+			record, createErr = spaceQuotaRepo.CreateSpaceQuota(ctx, authInfo, message)
+		})
+
+		When("the user has no role in the org", func() {
+			It("returns a forbidden error", func() {
+				Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+		})
+
+		When("the user is an org manager", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, orgManagerRole.Name, cfOrg.Name)
+			})
+
+			It("creates the quota in the org namespace", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(record.Name).To(Equal("my-quota"))
+				Expect(record.OrganizationGUID).To(Equal(cfOrg.Name))
+				Expect(record.SpaceGUIDs).To(ConsistOf(cfSpace.Name))
+
+				cfSpaceQuota := new(korifiv1alpha1.CFSpaceQuota)
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: cfOrg.Name, Name: record.GUID}, cfSpaceQuota)).To(Succeed())
+				Expect(cfSpaceQuota.Spec.QuotaLimits.Apps.PerProcessMemoryInMB).To(Equal(tools.PtrTo(int64(512))))
+			})
+
+			When("a quota with the same name exists in the org", func() {
+				BeforeEach(func() {
+					_, err := spaceQuotaRepo.CreateSpaceQuota(ctx, authInfo, repositories.CreateSpaceQuotaMessage{
+						Name:             "my-quota",
+						OrganizationGUID: cfOrg.Name,
+					})
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
+	})
+
+	Describe("List, get and apply", func() {
+		var quota1, quota2 repositories.SpaceQuotaRecord
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, orgManagerRole.Name, cfOrg.Name)
+
+			var err error
+			quota1, err = spaceQuotaRepo.CreateSpaceQuota(ctx, authInfo, repositories.CreateSpaceQuotaMessage{
+				Name:             "quota-1",
+				OrganizationGUID: cfOrg.Name,
+				SpaceGUIDs:       []string{cfSpace.Name},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			quota2, err = spaceQuotaRepo.CreateSpaceQuota(ctx, authInfo, repositories.CreateSpaceQuotaMessage{
+				Name:             "quota-2",
+				OrganizationGUID: cfOrg.Name,
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the quotas in the orgs visible to the user", func() {
+			otherOrg := createOrgWithCleanup(ctx, uuid.NewString())
+			Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFSpaceQuota{
+				ObjectMeta: metav1.ObjectMeta{Name: uuid.NewString(), Namespace: otherOrg.Name},
+				Spec:       korifiv1alpha1.CFSpaceQuotaSpec{DisplayName: "hidden"},
+			})).To(Succeed())
+
+			records, err := spaceQuotaRepo.ListSpaceQuotas(ctx, authInfo, repositories.ListSpaceQuotasMessage{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(records).To(HaveLen(2))
+			Expect(records[0].GUID).To(Equal(quota1.GUID))
+			Expect(records[1].GUID).To(Equal(quota2.GUID))
+		})
+
+		It("filters the quotas by space", func() {
+			records, err := spaceQuotaRepo.ListSpaceQuotas(ctx, authInfo, repositories.ListSpaceQuotasMessage{SpaceGUIDs: []string{cfSpace.Name}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(records).To(HaveLen(1))
+			Expect(records[0].GUID).To(Equal(quota1.GUID))
+		})
+
+		It("gets a quota", func() {
+			record, err := spaceQuotaRepo.GetSpaceQuota(ctx, authInfo, quota2.GUID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(record.Name).To(Equal("quota-2"))
+			Expect(record.OrganizationGUID).To(Equal(cfOrg.Name))
+		})
+
+		It("moves the space to the applied quota", func() {
+			record, err := spaceQuotaRepo.ApplySpaceQuota(ctx, authInfo, repositories.ApplySpaceQuotaMessage{
+				GUID:       quota2.GUID,
+				SpaceGUIDs: []string{cfSpace.Name},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(record.SpaceGUIDs).To(ConsistOf(cfSpace.Name))
+
+			record, err = spaceQuotaRepo.GetSpaceQuota(ctx, authInfo, quota1.GUID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(record.SpaceGUIDs).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,126 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+const (
+	UserResourceType = "User"
+
+	UserOriginKubernetes     = "kubernetes"
+	UserOriginServiceAccount = "kubernetes-service-account"
+)
+
+type UserRecord struct {
+	GUID   string
+	Name   string
+	Kind   string
+	Origin string
+}
+
+type ListUsersMessage struct {
+	GUIDs      []string
+	Names      []string
+	Origins    []string
+	OrgGUIDs   []string
+	SpaceGUIDs []string
+	RoleTypes  []string
+}
+
+// UserRepo derives users from the subjects of the role bindings created for
+// roles. Users are not kubernetes resources, so their guid is derived from
+// their name, which keeps it stable and consistent with the user relationship
+// of roles. See UserRecordForRole.
+type UserRepo struct {
+	roleRepo *RoleRepo
+}
+
+func NewUserRepo(roleRepo *RoleRepo) *UserRepo {
+	return &UserRepo{
+		roleRepo: roleRepo,
+	}
+}
+
+func (r *UserRepo) ListUsers(ctx context.Context, authInfo authorization.Info, message ListUsersMessage) ([]UserRecord, error) {
+	roles, err := r.roleRepo.ListRoles(ctx, authInfo, ListRolesMessage{
+		Types:      message.RoleTypes,
+		OrgGUIDs:   message.OrgGUIDs,
+		SpaceGUIDs: message.SpaceGUIDs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+
+	seen := map[string]bool{}
+	records := []UserRecord{}
+	for _, role := range roles {
+		// groups are not users, their members are only known to the identity provider
+		if role.Kind == rbacv1.GroupKind {
+			continue
+		}
+
+		user := UserRecordForRole(role)
+		if seen[user.GUID] {
+			continue
+		}
+		seen[user.GUID] = true
+
+		if !matchesFilter(user.GUID, message.GUIDs) ||
+			!matchesFilter(user.Name, message.Names) ||
+			!matchesFilter(user.Origin, message.Origins) {
+			continue
+		}
+
+		records = append(records, user)
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].Name == records[j].Name {
+			return records[i].Kind < records[j].Kind
+		}
+		return records[i].Name < records[j].Name
+	})
+
+	return records, nil
+}
+
+func (r *UserRepo) GetUser(ctx context.Context, authInfo authorization.Info, userGUID string) (UserRecord, error) {
+	records, err := r.ListUsers(ctx, authInfo, ListUsersMessage{GUIDs: []string{userGUID}})
+	if err != nil {
+		return UserRecord{}, err
+	}
+
+	if len(records) == 0 {
+		return UserRecord{}, apierrors.NewNotFoundError(fmt.Errorf("user %q not found", userGUID), UserResourceType)
+	}
+
+	return records[0], nil
+}
+
+// UserRecordForRole returns the user a role is assigned to. The guid of a user
+// is their name, while the guid of a service account is prefixed with its
+// origin, so that a service account cannot be mistaken for a user with the
+// same name
+func UserRecordForRole(role RoleRecord) UserRecord {
+	if role.Kind == rbacv1.ServiceAccountKind {
+		return UserRecord{
+			GUID:   UserOriginServiceAccount + ":" + role.User,
+			Name:   role.User,
+			Kind:   role.Kind,
+			Origin: UserOriginServiceAccount,
+		}
+	}
+
+	return UserRecord{
+		GUID:   role.User,
+		Name:   role.User,
+		Kind:   role.Kind,
+		Origin: UserOriginKubernetes,
+	}
+}
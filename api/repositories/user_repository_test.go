@@ -0,0 +1,146 @@
+package repositories_test
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/config"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/fake"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+var _ = Describe("UserRepository", func() {
+	var (
+		userRepo *repositories.UserRepo
+		cfOrg    *korifiv1alpha1.CFOrg
+		cfSpace  *korifiv1alpha1.CFSpace
+	)
+
+	BeforeEach(func() {
+		authorizedInChecker := new(fake.AuthorizedInChecker)
+		authorizedInChecker.AuthorizedInReturns(true, nil)
+		roleMappings := map[string]config.Role{
+			"space_developer":      {Name: spaceDeveloperRole.Name},
+			"organization_manager": {Name: orgManagerRole.Name, Propagate: true},
+			"organization_user":    {Name: orgUserRole.Name},
+			"cf_user":              {Name: rootNamespaceUserRole.Name},
+		}
+		orgRepo := repositories.NewOrgRepo(rootNamespace, k8sClient, userClientFactory, nsPerms, time.Millisecond*2000)
+		spaceRepo := repositories.NewSpaceRepo(namespaceRetriever, orgRepo, userClientFactory, nsPerms, time.Millisecond*2000)
+		roleRepo := repositories.NewRoleRepo(userClientFactory, k8sClient, spaceRepo, authorizedInChecker, nsPerms, rootNamespace, roleMappings)
+		userRepo = repositories.NewUserRepo(roleRepo)
+
+		cfOrg = createOrgWithCleanup(ctx, uuid.NewString())
+		cfSpace = createSpaceWithCleanup(ctx, cfOrg.Name, uuid.NewString())
+		createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+		createRoleBinding(ctx, userName, adminRole.Name, cfOrg.Name)
+		createRoleBinding(ctx, userName, adminRole.Name, cfSpace.Name)
+
+		for _, message := range []repositories.CreateRoleMessage{
+			{Type: "organization_user", User: "alice", Kind: rbacv1.UserKind, Org: cfOrg.Name},
+			{Type: "organization_manager", User: "bob", Kind: rbacv1.UserKind, Org: cfOrg.Name},
+			{Type: "space_developer", User: "alice", Kind: rbacv1.UserKind, Space: cfSpace.Name},
+			{Type: "organization_user", User: "robot", Kind: rbacv1.ServiceAccountKind, Org: cfOrg.Name},
+			{Type: "organization_auditor", User: "bob", Kind: rbacv1.ServiceAccountKind, Org: cfOrg.Name},
+			{Type: "organization_user", User: "developers", Kind: rbacv1.GroupKind, Org: cfOrg.Name},
+		} {
+			message.GUID = uuid.NewString()
+			_, err := roleRepo.CreateRole(ctx, authInfo, message)
+			Expect(err).NotTo(HaveOccurred())
+		}
+	})
+
+	Describe("ListUsers", func() {
+		var (
+			message repositories.ListUsersMessage
+			users   []repositories.UserRecord
+			listErr error
+		)
+
+		BeforeEach(func() {
+			message = repositories.ListUsersMessage{}
+		})
+
+		JustBeforeEach(func() {
+			users, listErr = userRepo.ListUsers(ctx, authInfo, message)
+		})
+
+		It("returns each user with a role once, sorted by name, without groups", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(users).To(Equal([]repositories.UserRecord{
+				{GUID: "alice", Name: "alice", Kind: rbacv1.UserKind, Origin: repositories.UserOriginKubernetes},
+				{GUID: "kubernetes-service-account:bob", Name: "bob", Kind: rbacv1.ServiceAccountKind, Origin: repositories.UserOriginServiceAccount},
+				{GUID: "bob", Name: "bob", Kind: rbacv1.UserKind, Origin: repositories.UserOriginKubernetes},
+				{GUID: "kubernetes-service-account:robot", Name: "robot", Kind: rbacv1.ServiceAccountKind, Origin: repositories.UserOriginServiceAccount},
+			}))
+		})
+
+		When("listing the users of a space", func() {
+			BeforeEach(func() {
+				message.SpaceGUIDs = []string{cfSpace.Name}
+			})
+
+			It("only returns users with a role in the space", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(users).To(HaveLen(1))
+				Expect(users[0].Name).To(Equal("alice"))
+			})
+		})
+
+		When("filtering the users of an org by role type", func() {
+			BeforeEach(func() {
+				message.OrgGUIDs = []string{cfOrg.Name}
+				message.RoleTypes = []string{"organization_manager"}
+			})
+
+			It("only returns users with that role in the org", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(users).To(HaveLen(1))
+				Expect(users[0].Name).To(Equal("bob"))
+			})
+		})
+
+		When("filtering by origin", func() {
+			BeforeEach(func() {
+				message.Origins = []string{repositories.UserOriginServiceAccount}
+			})
+
+			It("only returns service accounts", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(users).To(HaveLen(2))
+				Expect(users[0].Name).To(Equal("bob"))
+				Expect(users[1].Name).To(Equal("robot"))
+			})
+		})
+	})
+
+	Describe("GetUser", func() {
+		It("returns the user", func() {
+			user, err := userRepo.GetUser(ctx, authInfo, "bob")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(user.Name).To(Equal("bob"))
+			Expect(user.Origin).To(Equal(repositories.UserOriginKubernetes))
+		})
+
+		It("tells service accounts apart from users with the same name", func() {
+			user, err := userRepo.GetUser(ctx, authInfo, "kubernetes-service-account:bob")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(user.Name).To(Equal("bob"))
+			Expect(user.Origin).To(Equal(repositories.UserOriginServiceAccount))
+		})
+
+		When("the user has no visible roles", func() {
+			It("returns a not found error", func() {
+				_, err := userRepo.GetUser(ctx, authInfo, "nobody")
+				Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+})
@@ -40,6 +40,11 @@ type CFAppSpec struct {
 
 	// A reference to the CFBuild currently assigned to the app. The CFBuild must be in the same namespace.
 	CurrentDropletRef v1.LocalObjectReference `json:"currentDropletRef,omitempty"`
+
+	// Whether the services bound to the app are projected into its workloads following the servicebinding.io spec
+	// (the `service-binding-k8s` app feature). Defaults to true
+	// +optional
+	ServiceBindingK8sEnabled *bool `json:"serviceBindingK8sEnabled,omitempty"`
 }
 
 // DesiredState defines the desired state of CFApp.
@@ -88,3 +93,8 @@ func init() {
 func (a CFApp) StatusConditions() []metav1.Condition {
 	return a.Status.Conditions
 }
+
+// IsServiceBindingK8sEnabled reports whether servicebinding.io projections should be created for the app's service bindings
+func (a CFApp) IsServiceBindingK8sEnabled() bool {
+	return a.Spec.ServiceBindingK8sEnabled == nil || *a.Spec.ServiceBindingK8sEnabled
+}
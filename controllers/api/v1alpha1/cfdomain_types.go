@@ -30,12 +30,58 @@ type CFDomainSpec struct {
 
 	// The domain name. It is required and must conform to RFC 1035
 	Name string `json:"name"`
+
+	// The GUIDs of the orgs this domain is shared with. Only applies to private domains, i.e. domains
+	// created in the namespace of their owning org
+	// +optional
+	SharedOrganizations []string `json:"sharedOrganizations,omitempty"`
+
+	// The router group of a tcp domain. Domains with a router group only support tcp routes
+	// +optional
+	RouterGroup string `json:"routerGroup,omitempty"`
+
+	// TLS configures the certificate served for the routes on the domain. When not set, the routes are
+	// served with the workloads certificate
+	// +optional
+	TLS *CFDomainTLS `json:"tls,omitempty"`
+
+	// Internal domains are only resolvable from within the cluster. Routes on them are not exposed by the
+	// ingress, apps reach them directly through the destination services instead
+	// +optional
+	Internal bool `json:"internal,omitempty"`
+}
+
+// CFDomainTLS references the certificate of a domain, either as an existing TLS Secret or as a
+// Secret issued by cert-manager
+type CFDomainTLS struct {
+	// The name of a kubernetes.io/tls Secret in the namespace of the domain. When an issuer is set,
+	// the issued certificate is stored in this Secret
+	SecretName string `json:"secretName"`
+
+	// A cert-manager issuer to request the certificate of the domain from. The certificate covers the
+	// domain and all its subdomains
+	// +optional
+	IssuerRef *IssuerReference `json:"issuerRef,omitempty"`
+}
+
+// IssuerReference references a cert-manager Issuer in the namespace of the domain, or a ClusterIssuer
+type IssuerReference struct {
+	Name string `json:"name"`
+
+	// Kind is optional and defaults to Issuer
+	// +kubebuilder:validation:Enum=Issuer;ClusterIssuer
+	// +optional
+	Kind string `json:"kind,omitempty"`
 }
 
 // CFDomainStatus defines the observed state of CFDomain
 type CFDomainStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
+
+	// Conditions capture the state of the certificate of the domain
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -62,6 +108,17 @@ type CFDomainList struct {
 	Items           []CFDomain `json:"items"`
 }
 
+// IsSharedWith reports whether the domain has been shared with the given org
+func (d CFDomain) IsSharedWith(orgGUID string) bool {
+	for _, sharedOrg := range d.Spec.SharedOrganizations {
+		if sharedOrg == orgGUID {
+			return true
+		}
+	}
+
+	return false
+}
+
 func init() {
 	SchemeBuilder.Register(&CFDomain{}, &CFDomainList{})
 }
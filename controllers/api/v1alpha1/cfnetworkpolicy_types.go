@@ -0,0 +1,98 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	NetworkPolicyProtocolTCP = "tcp"
+	NetworkPolicyProtocolUDP = "udp"
+)
+
+// NetworkPolicySource identifies the app allowed to connect to the destination app
+type NetworkPolicySource struct {
+	AppGUID string `json:"appGUID"`
+
+	// The GUID of the space the source app lives in
+	SpaceGUID string `json:"spaceGUID"`
+}
+
+// NetworkPolicyPorts is an inclusive port range
+type NetworkPolicyPorts struct {
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Start int32 `json:"start"`
+
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	End int32 `json:"end"`
+}
+
+// CFNetworkPolicySpec defines the desired state of CFNetworkPolicy
+type CFNetworkPolicySpec struct {
+	Source NetworkPolicySource `json:"source"`
+
+	// A reference to the destination CFApp, which lives in the same namespace as the policy
+	DestinationAppRef corev1.LocalObjectReference `json:"destinationAppRef"`
+
+	// +kubebuilder:validation:Enum=tcp;udp
+	Protocol string `json:"protocol"`
+
+	Ports NetworkPolicyPorts `json:"ports"`
+}
+
+// CFNetworkPolicyStatus defines the observed state of CFNetworkPolicy
+type CFNetworkPolicyStatus struct {
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration captures the latest generation of the CFNetworkPolicy that has been reconciled
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Source App",type=string,JSONPath=`.spec.source.appGUID`
+//+kubebuilder:printcolumn:name="Destination App",type=string,JSONPath=`.spec.destinationAppRef.name`
+//+kubebuilder:printcolumn:name="Protocol",type=string,JSONPath=`.spec.protocol`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+
+// CFNetworkPolicy is the Schema for the cfnetworkpolicies API. It allows the source app to connect to the
+// destination app on the given ports and lives in the destination app's namespace
+type CFNetworkPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CFNetworkPolicySpec   `json:"spec,omitempty"`
+	Status CFNetworkPolicyStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CFNetworkPolicyList contains a list of CFNetworkPolicy
+type CFNetworkPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFNetworkPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFNetworkPolicy{}, &CFNetworkPolicyList{})
+}
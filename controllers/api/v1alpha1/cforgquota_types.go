@@ -0,0 +1,58 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CFOrgQuotaSpec defines the desired state of CFOrgQuota
+type CFOrgQuotaSpec struct {
+	// The mutable, user-friendly name of the quota
+	DisplayName string `json:"displayName"`
+
+	QuotaLimits `json:",inline"`
+
+	// The GUIDs of the orgs the quota applies to. An org is limited by at most one quota
+	// +optional
+	Organizations []string `json:"organizations,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Display Name",type=string,JSONPath=`.spec.displayName`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+
+// CFOrgQuota is the Schema for the cforgquotas API. Org quotas live in the root namespace
+type CFOrgQuota struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFOrgQuotaSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CFOrgQuotaList contains a list of CFOrgQuota
+type CFOrgQuotaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFOrgQuota `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFOrgQuota{}, &CFOrgQuotaList{})
+}
@@ -17,6 +17,9 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"strconv"
+	"strings"
+
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -24,6 +27,32 @@ import (
 const (
 	ValidStatus   CurrentStatus = "valid"
 	InvalidStatus CurrentStatus = "invalid"
+
+	HTTPProtocol Protocol = "http"
+	TCPProtocol  Protocol = "tcp"
+
+	HTTP1DestinationProtocol = "http1"
+	HTTP2DestinationProtocol = "http2"
+	TCPDestinationProtocol   = "tcp"
+
+	LoadBalancingRouteOption  = "loadbalancing"
+	RequestTimeoutRouteOption = "request_timeout"
+	IdleTimeoutRouteOption    = "idle_timeout"
+	RateLimitRouteOption      = "rate_limit"
+
+	RoundRobinLoadBalancing      = "round-robin"
+	LeastConnectionLoadBalancing = "least-connection"
+
+	// WildcardHost is the host of routes matching all the hosts of their domain that no other route matches
+	WildcardHost = "*"
+
+	// CFRouteTransferredToAnnotation marks a route that is being moved to the namespace in its value. The
+	// route recreated there takes over the name of the route and the services of its destinations, and the
+	// route controller deletes the route once it exists
+	CFRouteTransferredToAnnotation = "korifi.cloudfoundry.org/transferred-to"
+	// CFRouteTransferredFromAnnotation marks a route recreated from the route with the same name in the
+	// namespace in its value, see CFRouteTransferredToAnnotation
+	CFRouteTransferredFromAnnotation = "korifi.cloudfoundry.org/transferred-from"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -35,13 +64,24 @@ type Destination struct {
 	GUID string `json:"guid"`
 	// The port to use for the destination. Port is optional, and defaults to ProcessModel::DEFAULT_HTTP_PORT
 	Port int `json:"port,omitempty"`
-	// A required reference to the CFApp that will receive traffic. The CFApp must be in the same namespace
+	// A required reference to the CFApp that will receive traffic. The CFApp must be in the namespace of the
+	// destination
 	AppRef v1.LocalObjectReference `json:"appRef"`
+	// The namespace of the CFApp. Namespace is optional and defaults to the namespace of the route. Apps in other
+	// namespaces can only be destinations when the route is shared with their space
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
 	// The process type on the CFApp app which will receive traffic
 	ProcessType string `json:"processType"`
-	// Protocol is required, must be "http1"
-	// +kubebuilder:validation:Enum=http1
+	// Protocol is required, must be "http1" or "http2" for http routes and "tcp" for tcp routes
+	// +kubebuilder:validation:Enum=http1;http2;tcp
 	Protocol string `json:"protocol"`
+	// Weight is the share of the route traffic this destination receives. Weight is optional, but
+	// when set on one destination it must be set on all destinations of the route and add up to 100
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	Weight *int `json:"weight,omitempty"`
 }
 
 // Protocol defines the transport protocol of the route
@@ -58,12 +98,24 @@ type CFRouteSpec struct {
 	Host string `json:"host,omitempty"`
 	// Path is optional, defaults to empty
 	Path string `json:"path,omitempty"`
-	// Protocol is optional and defaults to http. Currently only http is supported
+	// Protocol is optional and defaults to http. tcp routes must be on a domain with a router group
 	Protocol Protocol `json:"protocol,omitempty"`
+	// The port of a tcp route. Required for tcp routes and not allowed for http routes
+	// +optional
+	Port int `json:"port,omitempty"`
 	// A reference to the CFDomain this CFRoute is assigned to, including name and namespace
 	DomainRef v1.ObjectReference `json:"domainRef"`
 	// Destinations are optional. A route can exist without any destinations, independently of any CFApps
 	Destinations []Destination `json:"destinations,omitempty"`
+	// The guids of the spaces, other than the space of the route, the route is shared with. Apps in shared
+	// spaces can be destinations of the route
+	// +optional
+	SharedSpaces []string `json:"sharedSpaces,omitempty"`
+	// Options tune how the requests of an http route are routed. The supported options are
+	// "loadbalancing" ("round-robin" or "least-connection"), "request_timeout" and "idle_timeout"
+	// (durations such as "30s"), and "rate_limit" ("<requests>/<second|minute|hour>")
+	// +optional
+	Options map[string]string `json:"options,omitempty"`
 }
 
 // CFRouteStatus defines the observed state of CFRoute
@@ -110,6 +162,39 @@ type CFRouteList struct {
 	Items           []CFRoute `json:"items"`
 }
 
+// IsTCP reports whether the route is a tcp route
+func (r CFRoute) IsTCP() bool {
+	return r.Spec.Protocol == TCPProtocol
+}
+
+// DestinationNamespace returns the namespace of the app of the destination
+func (r CFRoute) DestinationNamespace(destination Destination) string {
+	if destination.Namespace == "" {
+		return r.Namespace
+	}
+
+	return destination.Namespace
+}
+
+// UniqueName identifies the route among all the routes of the platform: two routes with the same unique
+// name cannot coexist. Tcp routes claim a port on the ingress listeners shared by all the domains of a
+// router group, so their name is made of the router group of their domain, which only tcp routes need
+// to be passed, and the port. Tcp route names have fewer parts, so they never clash with http route names
+func (r CFRoute) UniqueName(routerGroup string) string {
+	if r.IsTCP() {
+		return strings.Join([]string{string(TCPProtocol), routerGroup, strconv.Itoa(r.Spec.Port)}, "::")
+	}
+
+	return strings.Join([]string{strings.ToLower(r.Spec.Host), r.Spec.DomainRef.Namespace, r.Spec.DomainRef.Name, r.Spec.Path}, "::")
+}
+
+// InternalServiceName returns the name of the headless service that makes a route on an internal domain
+// resolvable. The service lives in the domain namespace, where the cluster DNS is expected to rewrite the
+// internal domain queries to, e.g. myapp.apps.internal to myapp-apps-internal.<domain namespace>.svc.cluster.local
+func (r CFRoute) InternalServiceName(domainName string) string {
+	return strings.ToLower(r.Spec.Host + "-" + strings.ReplaceAll(domainName, ".", "-"))
+}
+
 func init() {
 	SchemeBuilder.Register(&CFRoute{}, &CFRouteList{})
 }
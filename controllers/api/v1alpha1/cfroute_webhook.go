@@ -49,4 +49,8 @@ func (r *CFRoute) Default() {
 	routeLabels[CFDomainGUIDLabelKey] = r.Spec.DomainRef.Name
 	routeLabels[CFRouteGUIDLabelKey] = r.Name
 	r.SetLabels(routeLabels)
+
+	if r.Spec.Protocol == "" {
+		r.Spec.Protocol = HTTPProtocol
+	}
 }
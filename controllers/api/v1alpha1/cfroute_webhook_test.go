@@ -67,5 +67,9 @@ var _ = Describe("CFRouteMutatingWebhook Integration Tests", func() {
 		It("preserves the other labels", func() {
 			Expect(cfRoute.Labels).To(HaveKeyWithValue("foo", "bar"))
 		})
+
+		It("defaults the protocol to http", func() {
+			Expect(cfRoute.Spec.Protocol).To(Equal(korifiv1alpha1.HTTPProtocol))
+		})
 	})
 })
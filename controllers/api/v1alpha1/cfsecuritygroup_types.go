@@ -0,0 +1,110 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	SecurityGroupProtocolTCP  = "tcp"
+	SecurityGroupProtocolUDP  = "udp"
+	SecurityGroupProtocolICMP = "icmp"
+	SecurityGroupProtocolAll  = "all"
+)
+
+// SecurityGroupRule is an egress rule in the Cloud Foundry security group format
+type SecurityGroupRule struct {
+	// +kubebuilder:validation:Enum=tcp;udp;icmp;all
+	Protocol string `json:"protocol"`
+
+	// A single IP address, an IP address range (e.g. 192.168.0.1-192.168.0.255) or a CIDR block,
+	// or a comma separated list of them
+	Destination string `json:"destination"`
+
+	// A single port, a port range (e.g. 8000-9000) or a comma separated list of ports.
+	// Only used by the tcp and udp protocols
+	// +optional
+	Ports string `json:"ports,omitempty"`
+
+	// The ICMP type, only used by the icmp protocol
+	// +optional
+	Type *int `json:"type,omitempty"`
+
+	// The ICMP code, only used by the icmp protocol
+	// +optional
+	Code *int `json:"code,omitempty"`
+
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// +optional
+	Log bool `json:"log,omitempty"`
+}
+
+// SecurityGroupWorkloads tells which workloads a security group applies to
+type SecurityGroupWorkloads struct {
+	// Whether the group applies to running app and task workloads
+	// +optional
+	Running bool `json:"running,omitempty"`
+
+	// Whether the group applies to staging (build) workloads
+	// +optional
+	Staging bool `json:"staging,omitempty"`
+}
+
+// CFSecurityGroupSpec defines the desired state of CFSecurityGroup
+type CFSecurityGroupSpec struct {
+	// The mutable, user-friendly name of the security group
+	DisplayName string `json:"displayName"`
+
+	// +optional
+	Rules []SecurityGroupRule `json:"rules,omitempty"`
+
+	// The workloads the group applies to in every space
+	// +optional
+	GloballyEnabled SecurityGroupWorkloads `json:"globallyEnabled,omitempty"`
+
+	// The workloads the group applies to, keyed by space GUID
+	// +optional
+	Spaces map[string]SecurityGroupWorkloads `json:"spaces,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Display Name",type=string,JSONPath=`.spec.displayName`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+
+// CFSecurityGroup is the Schema for the cfsecuritygroups API. Security groups live in the root namespace
+type CFSecurityGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFSecurityGroupSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CFSecurityGroupList contains a list of CFSecurityGroup
+type CFSecurityGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFSecurityGroup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFSecurityGroup{}, &CFSecurityGroupList{})
+}
@@ -29,7 +29,9 @@ type CFServiceBindingSpec struct {
 	// The mutable, user-friendly name of the service binding. Unlike metadata.name, the user can change this field
 	DisplayName *string `json:"displayName,omitempty"`
 
-	// The Service this binding uses. When created by the korifi API, this will refer to a CFServiceInstance
+	// The Service this binding uses. When created by the korifi API, this will refer to a CFServiceInstance.
+	// The namespace may be omitted when the service is in the same namespace as the binding; otherwise the
+	// service must be shared with the binding's namespace
 	Service v1.ObjectReference `json:"service"`
 
 	// A reference to the CFApp that owns this service binding. The CFApp must be in the same namespace
@@ -75,6 +77,15 @@ func (b CFServiceBinding) StatusConditions() []metav1.Condition {
 	return b.Status.Conditions
 }
 
+// ServiceNamespace returns the namespace of the service this binding uses
+func (b CFServiceBinding) ServiceNamespace() string {
+	if b.Spec.Service.Namespace != "" {
+		return b.Spec.Service.Namespace
+	}
+
+	return b.Namespace
+}
+
 func init() {
 	SchemeBuilder.Register(&CFServiceBinding{}, &CFServiceBindingList{})
 }
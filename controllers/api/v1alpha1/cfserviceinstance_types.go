@@ -26,6 +26,15 @@ import (
 
 const (
 	UserProvidedType = "user-provided"
+
+	CreateOperation OperationType = "create"
+	UpdateOperation OperationType = "update"
+	DeleteOperation OperationType = "delete"
+
+	InitialState    OperationState = "initial"
+	InProgressState OperationState = "in progress"
+	SucceededState  OperationState = "succeeded"
+	FailedState     OperationState = "failed"
 )
 
 // CFServiceInstanceSpec defines the desired state of CFServiceInstance
@@ -41,6 +50,20 @@ type CFServiceInstanceSpec struct {
 
 	// Tags are used by apps to identify service instances
 	Tags []string `json:"tags,omitempty"`
+
+	// The URL of a route service. When set, the service instance can be bound to routes and
+	// traffic for those routes is forwarded through it
+	// +optional
+	RouteServiceURL string `json:"routeServiceURL,omitempty"`
+
+	// The URL of a syslog drain. When set, the logs of apps bound to the service instance are forwarded to it.
+	// Supported schemes are `syslog`, `syslog-tls` and `https`
+	// +optional
+	SyslogDrainURL string `json:"syslogDrainURL,omitempty"`
+
+	// The GUIDs of the spaces this service instance is shared with. Apps in those spaces can bind to it
+	// +optional
+	SharedSpaces []string `json:"sharedSpaces,omitempty"`
 }
 
 // InstanceType defines the type of the Service Instance
@@ -56,8 +79,39 @@ type CFServiceInstanceStatus struct {
 
 	// Conditions capture the current status of the CFServiceInstance
 	Conditions []metav1.Condition `json:"conditions"`
+
+	// The generation of the CFServiceInstance observed by the controller
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// The outcome of the most recent create or update of the CFServiceInstance
+	// +optional
+	LastOperation *LastOperation `json:"lastOperation,omitempty"`
 }
 
+type LastOperation struct {
+	// The kind of operation
+	Type OperationType `json:"type"`
+
+	// The state of the operation
+	State OperationState `json:"state"`
+
+	// A human readable description of the state of the operation
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// The time of the last change of the operation type or state
+	UpdatedAt metav1.Time `json:"updatedAt"`
+}
+
+// OperationType defines the kind of a service instance operation
+// +kubebuilder:validation:Enum=create;update;delete
+type OperationType string
+
+// OperationState defines the state of a service instance operation
+// +kubebuilder:validation:Enum=initial;in progress;succeeded;failed
+type OperationState string
+
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:printcolumn:name="Display Name",type=string,JSONPath=`.spec.displayName`
@@ -82,6 +136,17 @@ type CFServiceInstanceList struct {
 	Items           []CFServiceInstance `json:"items"`
 }
 
+// IsSharedWith reports whether the service instance has been shared with the given space
+func (si CFServiceInstance) IsSharedWith(spaceGUID string) bool {
+	for _, sharedSpace := range si.Spec.SharedSpaces {
+		if sharedSpace == spaceGUID {
+			return true
+		}
+	}
+
+	return false
+}
+
 func init() {
 	SchemeBuilder.Register(&CFServiceInstance{}, &CFServiceInstanceList{})
 }
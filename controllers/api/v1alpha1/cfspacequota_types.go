@@ -0,0 +1,60 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CFSpaceQuotaSpec defines the desired state of CFSpaceQuota
+type CFSpaceQuotaSpec struct {
+	// The mutable, user-friendly name of the quota
+	DisplayName string `json:"displayName"`
+
+	QuotaLimits `json:",inline"`
+
+	// The GUIDs of the spaces the quota applies to. They must belong to the org owning the quota. A space
+	// is limited by at most one quota
+	// +optional
+	Spaces []string `json:"spaces,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Display Name",type=string,JSONPath=`.spec.displayName`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+
+// CFSpaceQuota is the Schema for the cfspacequotas API. Space quotas live in the namespace of the org
+// owning them
+type CFSpaceQuota struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFSpaceQuotaSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CFSpaceQuotaList contains a list of CFSpaceQuota
+type CFSpaceQuotaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFSpaceQuota `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFSpaceQuota{}, &CFSpaceQuotaList{})
+}
@@ -14,7 +14,9 @@ const (
 	CFProcessTypeLabelKey   = "korifi.cloudfoundry.org/process-type"
 	CFDomainGUIDLabelKey    = "korifi.cloudfoundry.org/domain-guid"
 	CFRouteGUIDLabelKey     = "korifi.cloudfoundry.org/route-guid"
+	CFInternalRouteLabelKey = "korifi.cloudfoundry.org/internal-route-guid"
 	CFTaskGUIDLabelKey      = "korifi.cloudfoundry.org/task-guid"
+	BuildWorkloadLabelKey   = "korifi.cloudfoundry.org/build-workload-name"
 
 	StagingConditionType   = "Staging"
 	ReadyConditionType     = "Ready"
@@ -61,3 +63,47 @@ type Registry struct {
 type RequiredLocalObjectReference struct {
 	Name string `json:"name"`
 }
+
+// QuotaLimits are the limits shared by CFOrgQuota and CFSpaceQuota. Unset limits are unlimited
+type QuotaLimits struct {
+	// +optional
+	Apps AppsQuota `json:"apps,omitempty"`
+	// +optional
+	Services ServicesQuota `json:"services,omitempty"`
+	// +optional
+	Routes RoutesQuota `json:"routes,omitempty"`
+}
+
+type AppsQuota struct {
+	// The total memory of all instances of the processes of started apps
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TotalMemoryInMB *int64 `json:"totalMemoryInMB,omitempty"`
+
+	// The maximum memory of a single process instance
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	PerProcessMemoryInMB *int64 `json:"perProcessMemoryInMB,omitempty"`
+
+	// The total number of instances of the processes of started apps
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TotalInstances *int64 `json:"totalInstances,omitempty"`
+
+	// The maximum number of tasks running concurrently for a single app
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	PerAppTasks *int64 `json:"perAppTasks,omitempty"`
+}
+
+type ServicesQuota struct {
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TotalServiceInstances *int64 `json:"totalServiceInstances,omitempty"`
+}
+
+type RoutesQuota struct {
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TotalRoutes *int64 `json:"totalRoutes,omitempty"`
+}
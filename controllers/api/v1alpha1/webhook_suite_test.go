@@ -115,11 +115,13 @@ var _ = BeforeSuite(func() {
 	Expect((&korifiv1alpha1.CFApp{}).SetupWebhookWithManager(mgr)).To(Succeed())
 	Expect(workloads.NewCFAppValidator(
 		webhooks.NewDuplicateValidator(coordination.NewNameRegistry(mgr.GetClient(), workloads.AppEntityType)),
+		webhooks.NewCFQuotaValidator(mgr.GetClient(), namespace),
 	).SetupWebhookWithManager(mgr)).To(Succeed())
 
 	Expect((&korifiv1alpha1.CFRoute{}).SetupWebhookWithManager(mgr)).To(Succeed())
 	Expect(networking.NewCFRouteValidator(
 		webhooks.NewDuplicateValidator(coordination.NewNameRegistry(mgr.GetClient(), networking.RouteEntityType)),
+		webhooks.NewCFQuotaValidator(mgr.GetClient(), namespace),
 		namespace,
 		mgr.GetClient(),
 	).SetupWebhookWithManager(mgr)).To(Succeed())
@@ -161,6 +161,41 @@ func (in *AppWorkloadStatus) DeepCopy() *AppWorkloadStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppsQuota) DeepCopyInto(out *AppsQuota) {
+	*out = *in
+	if in.TotalMemoryInMB != nil {
+		in, out := &in.TotalMemoryInMB, &out.TotalMemoryInMB
+		*out = new(int64)
+		**out = **in
+	}
+	if in.PerProcessMemoryInMB != nil {
+		in, out := &in.PerProcessMemoryInMB, &out.PerProcessMemoryInMB
+		*out = new(int64)
+		**out = **in
+	}
+	if in.TotalInstances != nil {
+		in, out := &in.TotalInstances, &out.TotalInstances
+		*out = new(int64)
+		**out = **in
+	}
+	if in.PerAppTasks != nil {
+		in, out := &in.PerAppTasks, &out.PerAppTasks
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppsQuota.
+func (in *AppsQuota) DeepCopy() *AppsQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(AppsQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BuildDropletStatus) DeepCopyInto(out *BuildDropletStatus) {
 	*out = *in
@@ -515,6 +550,11 @@ func (in *CFAppSpec) DeepCopyInto(out *CFAppSpec) {
 	*out = *in
 	in.Lifecycle.DeepCopyInto(&out.Lifecycle)
 	out.CurrentDropletRef = in.CurrentDropletRef
+	if in.ServiceBindingK8sEnabled != nil {
+		in, out := &in.ServiceBindingK8sEnabled, &out.ServiceBindingK8sEnabled
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAppSpec.
@@ -658,8 +698,8 @@ func (in *CFDomain) DeepCopyInto(out *CFDomain) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFDomain.
@@ -715,6 +755,16 @@ func (in *CFDomainList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CFDomainSpec) DeepCopyInto(out *CFDomainSpec) {
 	*out = *in
+	if in.SharedOrganizations != nil {
+		in, out := &in.SharedOrganizations, &out.SharedOrganizations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(CFDomainTLS)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFDomainSpec.
@@ -730,6 +780,13 @@ func (in *CFDomainSpec) DeepCopy() *CFDomainSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CFDomainStatus) DeepCopyInto(out *CFDomainStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFDomainStatus.
@@ -742,6 +799,125 @@ func (in *CFDomainStatus) DeepCopy() *CFDomainStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFDomainTLS) DeepCopyInto(out *CFDomainTLS) {
+	*out = *in
+	if in.IssuerRef != nil {
+		in, out := &in.IssuerRef, &out.IssuerRef
+		*out = new(IssuerReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFDomainTLS.
+func (in *CFDomainTLS) DeepCopy() *CFDomainTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(CFDomainTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFNetworkPolicy) DeepCopyInto(out *CFNetworkPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFNetworkPolicy.
+func (in *CFNetworkPolicy) DeepCopy() *CFNetworkPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(CFNetworkPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFNetworkPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFNetworkPolicyList) DeepCopyInto(out *CFNetworkPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFNetworkPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFNetworkPolicyList.
+func (in *CFNetworkPolicyList) DeepCopy() *CFNetworkPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFNetworkPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFNetworkPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFNetworkPolicySpec) DeepCopyInto(out *CFNetworkPolicySpec) {
+	*out = *in
+	out.Source = in.Source
+	out.DestinationAppRef = in.DestinationAppRef
+	out.Ports = in.Ports
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFNetworkPolicySpec.
+func (in *CFNetworkPolicySpec) DeepCopy() *CFNetworkPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFNetworkPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFNetworkPolicyStatus) DeepCopyInto(out *CFNetworkPolicyStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFNetworkPolicyStatus.
+func (in *CFNetworkPolicyStatus) DeepCopy() *CFNetworkPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CFNetworkPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CFOrg) DeepCopyInto(out *CFOrg) {
 	*out = *in
@@ -801,6 +977,85 @@ func (in *CFOrgList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFOrgQuota) DeepCopyInto(out *CFOrgQuota) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrgQuota.
+func (in *CFOrgQuota) DeepCopy() *CFOrgQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(CFOrgQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFOrgQuota) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFOrgQuotaList) DeepCopyInto(out *CFOrgQuotaList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFOrgQuota, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrgQuotaList.
+func (in *CFOrgQuotaList) DeepCopy() *CFOrgQuotaList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFOrgQuotaList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFOrgQuotaList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFOrgQuotaSpec) DeepCopyInto(out *CFOrgQuotaSpec) {
+	*out = *in
+	in.QuotaLimits.DeepCopyInto(&out.QuotaLimits)
+	if in.Organizations != nil {
+		in, out := &in.Organizations, &out.Organizations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrgQuotaSpec.
+func (in *CFOrgQuotaSpec) DeepCopy() *CFOrgQuotaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFOrgQuotaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CFOrgSpec) DeepCopyInto(out *CFOrgSpec) {
 	*out = *in
@@ -1125,8 +1380,22 @@ func (in *CFRouteSpec) DeepCopyInto(out *CFRouteSpec) {
 	if in.Destinations != nil {
 		in, out := &in.Destinations, &out.Destinations
 		*out = make([]Destination, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SharedSpaces != nil {
+		in, out := &in.SharedSpaces, &out.SharedSpaces
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFRouteSpec.
@@ -1145,7 +1414,9 @@ func (in *CFRouteStatus) DeepCopyInto(out *CFRouteStatus) {
 	if in.Destinations != nil {
 		in, out := &in.Destinations, &out.Destinations
 		*out = make([]Destination, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -1167,26 +1438,25 @@ func (in *CFRouteStatus) DeepCopy() *CFRouteStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFServiceBinding) DeepCopyInto(out *CFServiceBinding) {
+func (in *CFSecurityGroup) DeepCopyInto(out *CFSecurityGroup) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceBinding.
-func (in *CFServiceBinding) DeepCopy() *CFServiceBinding {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSecurityGroup.
+func (in *CFSecurityGroup) DeepCopy() *CFSecurityGroup {
 	if in == nil {
 		return nil
 	}
-	out := new(CFServiceBinding)
+	out := new(CFSecurityGroup)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFServiceBinding) DeepCopyObject() runtime.Object {
+func (in *CFSecurityGroup) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1194,31 +1464,31 @@ func (in *CFServiceBinding) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFServiceBindingList) DeepCopyInto(out *CFServiceBindingList) {
+func (in *CFSecurityGroupList) DeepCopyInto(out *CFSecurityGroupList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]CFServiceBinding, len(*in))
+		*out = make([]CFSecurityGroup, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceBindingList.
-func (in *CFServiceBindingList) DeepCopy() *CFServiceBindingList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSecurityGroupList.
+func (in *CFSecurityGroupList) DeepCopy() *CFSecurityGroupList {
 	if in == nil {
 		return nil
 	}
-	out := new(CFServiceBindingList)
+	out := new(CFSecurityGroupList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFServiceBindingList) DeepCopyObject() runtime.Object {
+func (in *CFSecurityGroupList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1226,52 +1496,37 @@ func (in *CFServiceBindingList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFServiceBindingSpec) DeepCopyInto(out *CFServiceBindingSpec) {
-	*out = *in
-	if in.DisplayName != nil {
-		in, out := &in.DisplayName, &out.DisplayName
-		*out = new(string)
-		**out = **in
-	}
-	out.Service = in.Service
-	out.AppRef = in.AppRef
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceBindingSpec.
-func (in *CFServiceBindingSpec) DeepCopy() *CFServiceBindingSpec {
-	if in == nil {
-		return nil
-	}
-	out := new(CFServiceBindingSpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFServiceBindingStatus) DeepCopyInto(out *CFServiceBindingStatus) {
+func (in *CFSecurityGroupSpec) DeepCopyInto(out *CFSecurityGroupSpec) {
 	*out = *in
-	out.Binding = in.Binding
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]SecurityGroupRule, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	out.GloballyEnabled = in.GloballyEnabled
+	if in.Spaces != nil {
+		in, out := &in.Spaces, &out.Spaces
+		*out = make(map[string]SecurityGroupWorkloads, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceBindingStatus.
-func (in *CFServiceBindingStatus) DeepCopy() *CFServiceBindingStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSecurityGroupSpec.
+func (in *CFSecurityGroupSpec) DeepCopy() *CFSecurityGroupSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CFServiceBindingStatus)
+	out := new(CFSecurityGroupSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFServiceInstance) DeepCopyInto(out *CFServiceInstance) {
+func (in *CFServiceBinding) DeepCopyInto(out *CFServiceBinding) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -1279,18 +1534,18 @@ func (in *CFServiceInstance) DeepCopyInto(out *CFServiceInstance) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceInstance.
-func (in *CFServiceInstance) DeepCopy() *CFServiceInstance {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceBinding.
+func (in *CFServiceBinding) DeepCopy() *CFServiceBinding {
 	if in == nil {
 		return nil
 	}
-	out := new(CFServiceInstance)
+	out := new(CFServiceBinding)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFServiceInstance) DeepCopyObject() runtime.Object {
+func (in *CFServiceBinding) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1298,13 +1553,117 @@ func (in *CFServiceInstance) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFServiceInstanceList) DeepCopyInto(out *CFServiceInstanceList) {
+func (in *CFServiceBindingList) DeepCopyInto(out *CFServiceBindingList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]CFServiceInstance, len(*in))
+		*out = make([]CFServiceBinding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceBindingList.
+func (in *CFServiceBindingList) DeepCopy() *CFServiceBindingList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServiceBindingList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFServiceBindingList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServiceBindingSpec) DeepCopyInto(out *CFServiceBindingSpec) {
+	*out = *in
+	if in.DisplayName != nil {
+		in, out := &in.DisplayName, &out.DisplayName
+		*out = new(string)
+		**out = **in
+	}
+	out.Service = in.Service
+	out.AppRef = in.AppRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceBindingSpec.
+func (in *CFServiceBindingSpec) DeepCopy() *CFServiceBindingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServiceBindingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServiceBindingStatus) DeepCopyInto(out *CFServiceBindingStatus) {
+	*out = *in
+	out.Binding = in.Binding
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceBindingStatus.
+func (in *CFServiceBindingStatus) DeepCopy() *CFServiceBindingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServiceBindingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServiceInstance) DeepCopyInto(out *CFServiceInstance) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceInstance.
+func (in *CFServiceInstance) DeepCopy() *CFServiceInstance {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServiceInstance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFServiceInstance) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServiceInstanceList) DeepCopyInto(out *CFServiceInstanceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFServiceInstance, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1337,6 +1696,11 @@ func (in *CFServiceInstanceSpec) DeepCopyInto(out *CFServiceInstanceSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.SharedSpaces != nil {
+		in, out := &in.SharedSpaces, &out.SharedSpaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceInstanceSpec.
@@ -1360,6 +1724,11 @@ func (in *CFServiceInstanceStatus) DeepCopyInto(out *CFServiceInstanceStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LastOperation != nil {
+		in, out := &in.LastOperation, &out.LastOperation
+		*out = new(LastOperation)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceInstanceStatus.
@@ -1372,6 +1741,104 @@ func (in *CFServiceInstanceStatus) DeepCopy() *CFServiceInstanceStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServiceRouteBinding) DeepCopyInto(out *CFServiceRouteBinding) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceRouteBinding.
+func (in *CFServiceRouteBinding) DeepCopy() *CFServiceRouteBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServiceRouteBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFServiceRouteBinding) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServiceRouteBindingList) DeepCopyInto(out *CFServiceRouteBindingList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFServiceRouteBinding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceRouteBindingList.
+func (in *CFServiceRouteBindingList) DeepCopy() *CFServiceRouteBindingList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServiceRouteBindingList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFServiceRouteBindingList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServiceRouteBindingSpec) DeepCopyInto(out *CFServiceRouteBindingSpec) {
+	*out = *in
+	out.Service = in.Service
+	out.RouteRef = in.RouteRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceRouteBindingSpec.
+func (in *CFServiceRouteBindingSpec) DeepCopy() *CFServiceRouteBindingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServiceRouteBindingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServiceRouteBindingStatus) DeepCopyInto(out *CFServiceRouteBindingStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceRouteBindingStatus.
+func (in *CFServiceRouteBindingStatus) DeepCopy() *CFServiceRouteBindingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServiceRouteBindingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CFSpace) DeepCopyInto(out *CFSpace) {
 	*out = *in
@@ -1431,6 +1898,85 @@ func (in *CFSpaceList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFSpaceQuota) DeepCopyInto(out *CFSpaceQuota) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSpaceQuota.
+func (in *CFSpaceQuota) DeepCopy() *CFSpaceQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(CFSpaceQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFSpaceQuota) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFSpaceQuotaList) DeepCopyInto(out *CFSpaceQuotaList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFSpaceQuota, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSpaceQuotaList.
+func (in *CFSpaceQuotaList) DeepCopy() *CFSpaceQuotaList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFSpaceQuotaList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFSpaceQuotaList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFSpaceQuotaSpec) DeepCopyInto(out *CFSpaceQuotaSpec) {
+	*out = *in
+	in.QuotaLimits.DeepCopyInto(&out.QuotaLimits)
+	if in.Spaces != nil {
+		in, out := &in.Spaces, &out.Spaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSpaceQuotaSpec.
+func (in *CFSpaceQuotaSpec) DeepCopy() *CFSpaceQuotaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFSpaceQuotaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CFSpaceSpec) DeepCopyInto(out *CFSpaceSpec) {
 	*out = *in
@@ -1570,6 +2116,11 @@ func (in *CFTaskStatus) DeepCopy() *CFTaskStatus {
 func (in *Destination) DeepCopyInto(out *Destination) {
 	*out = *in
 	out.AppRef = in.AppRef
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(int)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Destination.
@@ -1613,6 +2164,37 @@ func (in *HealthCheckData) DeepCopy() *HealthCheckData {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IssuerReference) DeepCopyInto(out *IssuerReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IssuerReference.
+func (in *IssuerReference) DeepCopy() *IssuerReference {
+	if in == nil {
+		return nil
+	}
+	out := new(IssuerReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LastOperation) DeepCopyInto(out *LastOperation) {
+	*out = *in
+	in.UpdatedAt.DeepCopyInto(&out.UpdatedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LastOperation.
+func (in *LastOperation) DeepCopy() *LastOperation {
+	if in == nil {
+		return nil
+	}
+	out := new(LastOperation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Lifecycle) DeepCopyInto(out *Lifecycle) {
 	*out = *in
@@ -1649,6 +2231,36 @@ func (in *LifecycleData) DeepCopy() *LifecycleData {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicyPorts) DeepCopyInto(out *NetworkPolicyPorts) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyPorts.
+func (in *NetworkPolicyPorts) DeepCopy() *NetworkPolicyPorts {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicyPorts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicySource) DeepCopyInto(out *NetworkPolicySource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicySource.
+func (in *NetworkPolicySource) DeepCopy() *NetworkPolicySource {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicySource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PackageSource) DeepCopyInto(out *PackageSource) {
 	*out = *in
@@ -1680,6 +2292,24 @@ func (in *ProcessType) DeepCopy() *ProcessType {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QuotaLimits) DeepCopyInto(out *QuotaLimits) {
+	*out = *in
+	in.Apps.DeepCopyInto(&out.Apps)
+	in.Services.DeepCopyInto(&out.Services)
+	in.Routes.DeepCopyInto(&out.Routes)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuotaLimits.
+func (in *QuotaLimits) DeepCopy() *QuotaLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(QuotaLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Registry) DeepCopyInto(out *Registry) {
 	*out = *in
@@ -1715,6 +2345,86 @@ func (in *RequiredLocalObjectReference) DeepCopy() *RequiredLocalObjectReference
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoutesQuota) DeepCopyInto(out *RoutesQuota) {
+	*out = *in
+	if in.TotalRoutes != nil {
+		in, out := &in.TotalRoutes, &out.TotalRoutes
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoutesQuota.
+func (in *RoutesQuota) DeepCopy() *RoutesQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(RoutesQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityGroupRule) DeepCopyInto(out *SecurityGroupRule) {
+	*out = *in
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(int)
+		**out = **in
+	}
+	if in.Code != nil {
+		in, out := &in.Code, &out.Code
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupRule.
+func (in *SecurityGroupRule) DeepCopy() *SecurityGroupRule {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityGroupRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityGroupWorkloads) DeepCopyInto(out *SecurityGroupWorkloads) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupWorkloads.
+func (in *SecurityGroupWorkloads) DeepCopy() *SecurityGroupWorkloads {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityGroupWorkloads)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServicesQuota) DeepCopyInto(out *ServicesQuota) {
+	*out = *in
+	if in.TotalServiceInstances != nil {
+		in, out := &in.TotalServiceInstances, &out.TotalServiceInstances
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServicesQuota.
+func (in *ServicesQuota) DeepCopy() *ServicesQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(ServicesQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TaskWorkload) DeepCopyInto(out *TaskWorkload) {
 	*out = *in
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"fmt"
 	"path/filepath"
 	"time"
 
@@ -16,6 +17,10 @@ type ControllerConfig struct {
 	WorkloadsTLSSecretNamespace string            `yaml:"workloads_tls_secret_namespace"`
 	BuilderName                 string            `yaml:"builderName"`
 	RunnerName                  string            `yaml:"runnerName"`
+	IngressBackend              string            `yaml:"ingressBackend"`
+	WorkloadsGatewayName        string            `yaml:"workloadsGatewayName"`
+	WorkloadsGatewayNamespace   string            `yaml:"workloadsGatewayNamespace"`
+	IngressGatewayNamespace     string            `yaml:"ingressGatewayNamespace"`
 }
 
 type CFProcessDefaults struct {
@@ -24,9 +29,19 @@ type CFProcessDefaults struct {
 	Timeout     *int64 `yaml:"timeout"`
 }
 
+const (
+	IstioIngressBackend      = "istio"
+	ContourIngressBackend    = "contour"
+	GatewayAPIIngressBackend = "gateway-api"
+)
+
 const (
 	defaultTaskTTL       = 30 * 24 * time.Hour
 	defaultTimeout int64 = 60
+
+	defaultWorkloadsGatewayName      = "korifi-workloads-gateway"
+	defaultWorkloadsGatewayNamespace = "korifi"
+	defaultIngressGatewayNamespace   = "istio-system"
 )
 
 func LoadFromPath(path string) (*ControllerConfig, error) {
@@ -40,6 +55,27 @@ func LoadFromPath(path string) (*ControllerConfig, error) {
 		config.CFProcessDefaults.Timeout = tools.PtrTo(defaultTimeout)
 	}
 
+	switch config.IngressBackend {
+	case "":
+		config.IngressBackend = IstioIngressBackend
+	case IstioIngressBackend, ContourIngressBackend, GatewayAPIIngressBackend:
+	default:
+		return nil, fmt.Errorf("unsupported ingress backend %q, must be one of %q, %q or %q",
+			config.IngressBackend, IstioIngressBackend, ContourIngressBackend, GatewayAPIIngressBackend)
+	}
+
+	if config.WorkloadsGatewayName == "" {
+		config.WorkloadsGatewayName = defaultWorkloadsGatewayName
+	}
+
+	if config.WorkloadsGatewayNamespace == "" {
+		config.WorkloadsGatewayNamespace = defaultWorkloadsGatewayNamespace
+	}
+
+	if config.IngressGatewayNamespace == "" {
+		config.IngressGatewayNamespace = defaultIngressGatewayNamespace
+	}
+
 	return &config, nil
 }
 
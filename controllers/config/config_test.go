@@ -42,6 +42,10 @@ var _ = Describe("LoadFromPath", func() {
 			WorkloadsTLSSecretNamespace: "workloadsTLSSecretNamespace",
 			BuilderName:                 "buildReconciler",
 			RunnerName:                  "statefulset-runner",
+			IngressBackend:              "gateway-api",
+			WorkloadsGatewayName:        "workloadsGatewayName",
+			WorkloadsGatewayNamespace:   "workloadsGatewayNamespace",
+			IngressGatewayNamespace:     "ingressGatewayNamespace",
 		}
 	})
 
@@ -72,6 +76,10 @@ var _ = Describe("LoadFromPath", func() {
 			WorkloadsTLSSecretNamespace: "workloadsTLSSecretNamespace",
 			BuilderName:                 "buildReconciler",
 			RunnerName:                  "statefulset-runner",
+			IngressBackend:              "gateway-api",
+			WorkloadsGatewayName:        "workloadsGatewayName",
+			WorkloadsGatewayNamespace:   "workloadsGatewayNamespace",
+			IngressGatewayNamespace:     "ingressGatewayNamespace",
 		}))
 	})
 
@@ -84,6 +92,33 @@ var _ = Describe("LoadFromPath", func() {
 			Expect(retConfig.CFProcessDefaults.Timeout).To(gstruct.PointTo(Equal(int64(60))))
 		})
 	})
+
+	When("the ingress backend and workloads gateway are not set", func() {
+		BeforeEach(func() {
+			cfg.IngressBackend = ""
+			cfg.WorkloadsGatewayName = ""
+			cfg.WorkloadsGatewayNamespace = ""
+			cfg.IngressGatewayNamespace = ""
+		})
+
+		It("uses the istio backend and the korifi workloads gateway", func() {
+			Expect(retErr).NotTo(HaveOccurred())
+			Expect(retConfig.IngressBackend).To(Equal(config.IstioIngressBackend))
+			Expect(retConfig.WorkloadsGatewayName).To(Equal("korifi-workloads-gateway"))
+			Expect(retConfig.WorkloadsGatewayNamespace).To(Equal("korifi"))
+			Expect(retConfig.IngressGatewayNamespace).To(Equal("istio-system"))
+		})
+	})
+
+	When("the ingress backend is not supported", func() {
+		BeforeEach(func() {
+			cfg.IngressBackend = "nginx"
+		})
+
+		It("returns an error", func() {
+			Expect(retErr).To(MatchError(ContainSubstring(`unsupported ingress backend "nginx"`)))
+		})
+	})
 })
 
 var _ = Describe("ParseTaskTTL", func() {
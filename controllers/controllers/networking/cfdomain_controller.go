@@ -0,0 +1,487 @@
+package networking
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/config"
+	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/go-logr/logr"
+	contourv1 "github.com/projectcontour/contour/apis/projectcontour/v1"
+	"istio.io/api/networking/v1alpha3"
+	networkingv1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	CFDomainFinalizerName = "cfDomain.korifi.cloudfoundry.org"
+
+	CertificateReadyCondition    = "CertificateReady"
+	CertificateExpiringCondition = "CertificateExpiring"
+
+	// certificates expiring within this period are reported as expiring
+	certificateExpiryThreshold = 30 * 24 * time.Hour
+	// how long to wait for a missing certificate secret, e.g. while cert-manager issues it
+	certificatePendingRequeue = 30 * time.Second
+)
+
+// CFDomainReconciler reconciles the certificate of a CFDomain. With the istio ingress backend, domains
+// with their own certificate get their own gateway, which the routes on the domain are attached to. With
+// the contour ingress backend, the certificate is delegated to the namespaces of the routes on the domain
+type CFDomainReconciler struct {
+	client           client.Client
+	scheme           *runtime.Scheme
+	log              logr.Logger
+	controllerConfig *config.ControllerConfig
+}
+
+func NewCFDomainReconciler(
+	client client.Client,
+	scheme *runtime.Scheme,
+	log logr.Logger,
+	controllerConfig *config.ControllerConfig,
+) *k8s.PatchingReconciler[korifiv1alpha1.CFDomain, *korifiv1alpha1.CFDomain] {
+	domainReconciler := CFDomainReconciler{client: client, scheme: scheme, log: log, controllerConfig: controllerConfig}
+	return k8s.NewPatchingReconciler[korifiv1alpha1.CFDomain, *korifiv1alpha1.CFDomain](log, client, &domainReconciler)
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfdomains,verbs=get;list;watch;create;patch;update;delete
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfdomains/status,verbs=get;update;patch
+
+//+kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=networking.istio.io,resources=gateways,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=projectcontour.io,resources=tlscertificatedelegations,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;patch;delete
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfroutes,verbs=get;list;watch
+
+func (r *CFDomainReconciler) ReconcileResource(ctx context.Context, cfDomain *korifiv1alpha1.CFDomain) (ctrl.Result, error) {
+	log := r.log.WithValues("namespace", cfDomain.Namespace, "name", cfDomain.Name)
+
+	if !cfDomain.GetDeletionTimestamp().IsZero() {
+		return r.finalizeCFDomain(ctx, log, cfDomain)
+	}
+
+	if r.controllerConfig.IngressBackend == config.IstioIngressBackend {
+		if err := k8s.AddFinalizer(ctx, log, r.client, cfDomain, CFDomainFinalizerName); err != nil {
+			log.Error(err, "Error adding finalizer")
+			return ctrl.Result{}, err
+		}
+	}
+
+	if cfDomain.Spec.TLS == nil {
+		meta.RemoveStatusCondition(&cfDomain.Status.Conditions, CertificateReadyCondition)
+		meta.RemoveStatusCondition(&cfDomain.Status.Conditions, CertificateExpiringCondition)
+
+		switch r.controllerConfig.IngressBackend {
+		case config.IstioIngressBackend:
+			if err := r.deleteGateway(ctx, cfDomain); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{}, r.deleteIngressGatewaySecret(ctx, cfDomain)
+		case config.ContourIngressBackend:
+			return ctrl.Result{}, r.deleteCertificateDelegation(ctx, cfDomain)
+		default:
+			return ctrl.Result{}, nil
+		}
+	}
+
+	if cfDomain.Spec.TLS.IssuerRef != nil {
+		if err := r.createOrPatchCertificate(ctx, cfDomain); err != nil {
+			log.Error(err, "failed to create/patch certificate")
+			setCertificateNotReady(cfDomain, "CertificateRequestFailed", err.Error())
+			return ctrl.Result{}, err
+		}
+	}
+
+	result, err := r.reconcileCertificateStatus(ctx, cfDomain)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	switch r.controllerConfig.IngressBackend {
+	case config.IstioIngressBackend:
+		if err = r.createOrPatchIngressGatewaySecret(ctx, cfDomain); err != nil {
+			log.Error(err, "failed to copy the TLS secret to the ingress gateway namespace")
+			return ctrl.Result{}, err
+		}
+
+		if err = r.createOrPatchGateway(ctx, cfDomain); err != nil {
+			log.Error(err, "failed to create/patch gateway")
+			return ctrl.Result{}, err
+		}
+	case config.ContourIngressBackend:
+		if err = r.createOrPatchCertificateDelegation(ctx, cfDomain); err != nil {
+			log.Error(err, "failed to create/patch certificate delegation")
+			return ctrl.Result{}, err
+		}
+	}
+
+	return result, nil
+}
+
+func (r *CFDomainReconciler) finalizeCFDomain(ctx context.Context, log logr.Logger, cfDomain *korifiv1alpha1.CFDomain) (ctrl.Result, error) {
+	log = log.WithName("finalizeCFDomain")
+
+	if !controllerutil.ContainsFinalizer(cfDomain, CFDomainFinalizerName) {
+		return ctrl.Result{}, nil
+	}
+
+	if err := r.deleteIngressGatewaySecret(ctx, cfDomain); err != nil {
+		log.Error(err, "failed to delete the TLS secret copy")
+		return ctrl.Result{}, err
+	}
+
+	if controllerutil.RemoveFinalizer(cfDomain, CFDomainFinalizerName) {
+		log.Info("finalizer removed")
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// reconcileCertificateStatus sets the conditions of the domain from the certificate in its TLS secret
+func (r *CFDomainReconciler) reconcileCertificateStatus(ctx context.Context, cfDomain *korifiv1alpha1.CFDomain) (ctrl.Result, error) {
+	secret := new(corev1.Secret)
+	err := r.client.Get(ctx, types.NamespacedName{Name: cfDomain.Spec.TLS.SecretName, Namespace: cfDomain.Namespace}, secret)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			setCertificateNotReady(cfDomain, "SecretNotFound", fmt.Sprintf("TLS secret %q does not exist", cfDomain.Spec.TLS.SecretName))
+			return ctrl.Result{RequeueAfter: certificatePendingRequeue}, nil
+		}
+
+		return ctrl.Result{}, err
+	}
+
+	notAfter, err := certificateNotAfter(secret)
+	if err != nil {
+		setCertificateNotReady(cfDomain, "InvalidCertificate", err.Error())
+		return ctrl.Result{}, nil
+	}
+
+	return setCertificateReady(cfDomain, notAfter, time.Now()), nil
+}
+
+func (r *CFDomainReconciler) createOrPatchCertificate(ctx context.Context, cfDomain *korifiv1alpha1.CFDomain) error {
+	certificate := &unstructured.Unstructured{}
+	certificate.SetAPIVersion("cert-manager.io/v1")
+	certificate.SetKind("Certificate")
+	certificate.SetName(cfDomain.Name)
+	certificate.SetNamespace(cfDomain.Namespace)
+
+	issuerKind := cfDomain.Spec.TLS.IssuerRef.Kind
+	if issuerKind == "" {
+		issuerKind = "Issuer"
+	}
+
+	_, err := controllerutil.CreateOrPatch(ctx, r.client, certificate, func() error {
+		certificate.Object["spec"] = map[string]interface{}{
+			"secretName": cfDomain.Spec.TLS.SecretName,
+			"commonName": cfDomain.Spec.Name,
+			"dnsNames":   []interface{}{cfDomain.Spec.Name, "*." + cfDomain.Spec.Name},
+			"issuerRef": map[string]interface{}{
+				"name": cfDomain.Spec.TLS.IssuerRef.Name,
+				"kind": issuerKind,
+			},
+		}
+
+		return controllerutil.SetControllerReference(cfDomain, certificate, r.scheme)
+	})
+
+	return err
+}
+
+// createOrPatchGateway creates the gateway serving the domain and its subdomains with the certificate
+// of the domain
+func (r *CFDomainReconciler) createOrPatchGateway(ctx context.Context, cfDomain *korifiv1alpha1.CFDomain) error {
+	gateway := &networkingv1alpha3.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfDomain.Name,
+			Namespace: cfDomain.Namespace,
+		},
+	}
+
+	_, err := controllerutil.CreateOrPatch(ctx, r.client, gateway, func() error {
+		gateway.Spec.Selector = map[string]string{"istio": "ingressgateway"}
+		gateway.Spec.Servers = []*v1alpha3.Server{{
+			Port: &v1alpha3.Port{
+				Number:   443,
+				Name:     "https",
+				Protocol: "HTTPS",
+			},
+			Hosts: []string{cfDomain.Spec.Name, "*." + cfDomain.Spec.Name},
+			Tls: &v1alpha3.ServerTLSSettings{
+				Mode:           v1alpha3.ServerTLSSettings_SIMPLE,
+				CredentialName: ingressGatewaySecretName(cfDomain),
+			},
+		}}
+
+		return controllerutil.SetControllerReference(cfDomain, gateway, r.scheme)
+	})
+
+	return err
+}
+
+// createOrPatchIngressGatewaySecret copies the TLS secret of the domain to the namespace of the istio
+// ingress gateway, as istio only looks up the credentials of gateway servers in that namespace. The copy
+// cannot be owned by the domain, so it is deleted when the domain is finalized
+func (r *CFDomainReconciler) createOrPatchIngressGatewaySecret(ctx context.Context, cfDomain *korifiv1alpha1.CFDomain) error {
+	secret := new(corev1.Secret)
+	err := r.client.Get(ctx, types.NamespacedName{Name: cfDomain.Spec.TLS.SecretName, Namespace: cfDomain.Namespace}, secret)
+	if err != nil {
+		// the missing secret is reported in the certificate conditions
+		return client.IgnoreNotFound(err)
+	}
+
+	secretCopy := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ingressGatewaySecretName(cfDomain),
+			Namespace: r.controllerConfig.IngressGatewayNamespace,
+		},
+		Type: corev1.SecretTypeTLS,
+	}
+
+	_, err = controllerutil.CreateOrPatch(ctx, r.client, secretCopy, func() error {
+		if secretCopy.Labels == nil {
+			secretCopy.Labels = map[string]string{}
+		}
+		secretCopy.Labels[korifiv1alpha1.CFDomainGUIDLabelKey] = cfDomain.Name
+		secretCopy.Data = secret.Data
+
+		return nil
+	})
+
+	return err
+}
+
+func (r *CFDomainReconciler) deleteIngressGatewaySecret(ctx context.Context, cfDomain *korifiv1alpha1.CFDomain) error {
+	return client.IgnoreNotFound(r.client.Delete(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ingressGatewaySecretName(cfDomain),
+			Namespace: r.controllerConfig.IngressGatewayNamespace,
+		},
+	}))
+}
+
+func ingressGatewaySecretName(cfDomain *korifiv1alpha1.CFDomain) string {
+	return "cfdomain-" + cfDomain.Name
+}
+
+// createOrPatchCertificateDelegation allows the HTTPProxies of the routes on the domain to reference the
+// TLS secret of the domain, which lives in the namespace of the domain rather than in the namespaces of
+// the routes
+func (r *CFDomainReconciler) createOrPatchCertificateDelegation(ctx context.Context, cfDomain *korifiv1alpha1.CFDomain) error {
+	cfRoutes := new(korifiv1alpha1.CFRouteList)
+	if err := r.client.List(ctx, cfRoutes, client.MatchingFields{shared.IndexRouteDomainName: cfDomain.Name}); err != nil {
+		return fmt.Errorf("failed to list routes on domain: %w", err)
+	}
+
+	namespaces := map[string]bool{}
+	for _, cfRoute := range cfRoutes.Items {
+		if cfRoute.Spec.DomainRef.Namespace == cfDomain.Namespace {
+			namespaces[cfRoute.Namespace] = true
+		}
+	}
+
+	if len(namespaces) == 0 {
+		return r.deleteCertificateDelegation(ctx, cfDomain)
+	}
+
+	targetNamespaces := make([]string, 0, len(namespaces))
+	for namespace := range namespaces {
+		targetNamespaces = append(targetNamespaces, namespace)
+	}
+	sort.Strings(targetNamespaces)
+
+	delegation := &contourv1.TLSCertificateDelegation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfDomain.Name,
+			Namespace: cfDomain.Namespace,
+		},
+	}
+
+	_, err := controllerutil.CreateOrPatch(ctx, r.client, delegation, func() error {
+		delegation.Spec.Delegations = []contourv1.CertificateDelegation{{
+			SecretName:       cfDomain.Spec.TLS.SecretName,
+			TargetNamespaces: targetNamespaces,
+		}}
+
+		return controllerutil.SetControllerReference(cfDomain, delegation, r.scheme)
+	})
+
+	return err
+}
+
+func (r *CFDomainReconciler) deleteCertificateDelegation(ctx context.Context, cfDomain *korifiv1alpha1.CFDomain) error {
+	return client.IgnoreNotFound(r.client.Delete(ctx, &contourv1.TLSCertificateDelegation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfDomain.Name,
+			Namespace: cfDomain.Namespace,
+		},
+	}))
+}
+
+func (r *CFDomainReconciler) deleteGateway(ctx context.Context, cfDomain *korifiv1alpha1.CFDomain) error {
+	return client.IgnoreNotFound(r.client.Delete(ctx, &networkingv1alpha3.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfDomain.Name,
+			Namespace: cfDomain.Namespace,
+		},
+	}))
+}
+
+func certificateNotAfter(secret *corev1.Secret) (time.Time, error) {
+	block, _ := pem.Decode(secret.Data[corev1.TLSCertKey])
+	if block == nil {
+		return time.Time{}, errors.New("TLS secret does not contain a PEM encoded certificate")
+	}
+
+	certificate, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse certificate: %w", err)
+	}
+
+	return certificate.NotAfter, nil
+}
+
+// setCertificateReady records the expiry of the certificate and requeues the domain for when the
+// certificate starts expiring, so that the expiring condition is set in time
+func setCertificateReady(cfDomain *korifiv1alpha1.CFDomain, notAfter, now time.Time) ctrl.Result {
+	if !now.Before(notAfter) {
+		setCertificateNotReady(cfDomain, "CertificateExpired", fmt.Sprintf("Certificate expired at %s", notAfter.UTC().Format(time.RFC3339)))
+		return ctrl.Result{}
+	}
+
+	meta.SetStatusCondition(&cfDomain.Status.Conditions, metav1.Condition{
+		Type:    CertificateReadyCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  "CertificateFound",
+		Message: fmt.Sprintf("Certificate expires at %s", notAfter.UTC().Format(time.RFC3339)),
+	})
+
+	expiringAt := notAfter.Add(-certificateExpiryThreshold)
+	if now.Before(expiringAt) {
+		meta.SetStatusCondition(&cfDomain.Status.Conditions, metav1.Condition{
+			Type:   CertificateExpiringCondition,
+			Status: metav1.ConditionFalse,
+			Reason: "CertificateValid",
+		})
+		return ctrl.Result{RequeueAfter: expiringAt.Sub(now)}
+	}
+
+	meta.SetStatusCondition(&cfDomain.Status.Conditions, metav1.Condition{
+		Type:    CertificateExpiringCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  "CertificateExpiring",
+		Message: fmt.Sprintf("Certificate expires at %s", notAfter.UTC().Format(time.RFC3339)),
+	})
+	return ctrl.Result{RequeueAfter: notAfter.Sub(now)}
+}
+
+func setCertificateNotReady(cfDomain *korifiv1alpha1.CFDomain, reason, message string) {
+	meta.SetStatusCondition(&cfDomain.Status.Conditions, metav1.Condition{
+		Type:    CertificateReadyCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  reason,
+		Message: message,
+	})
+	meta.RemoveStatusCondition(&cfDomain.Status.Conditions, CertificateExpiringCondition)
+}
+
+func (r *CFDomainReconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
+	domainBuilder := ctrl.NewControllerManagedBy(mgr).
+		For(&korifiv1alpha1.CFDomain{}).
+		Watches(
+			&source.Kind{Type: &corev1.Secret{}},
+			handler.EnqueueRequestsFromMapFunc(r.secretToDomains),
+			builder.WithPredicates(predicate.NewPredicateFuncs(isTLSSecret)),
+		)
+
+	if r.controllerConfig.IngressBackend == config.ContourIngressBackend {
+		domainBuilder = domainBuilder.Watches(&source.Kind{Type: &korifiv1alpha1.CFRoute{}}, handler.EnqueueRequestsFromMapFunc(routeToDomain))
+	}
+
+	return domainBuilder
+}
+
+// isTLSSecret filters the secret watch down to the kubernetes.io/tls secrets that domain certificates
+// are stored in, see korifiv1alpha1.CFDomainTLS
+func isTLSSecret(o client.Object) bool {
+	secret, ok := o.(*corev1.Secret)
+	return ok && secret.Type == corev1.SecretTypeTLS
+}
+
+// routeToDomain enqueues the domain of the route, so that the certificate delegation of the domain
+// follows the namespaces of its routes
+func routeToDomain(o client.Object) []reconcile.Request {
+	cfRoute, ok := o.(*korifiv1alpha1.CFRoute)
+	if !ok {
+		return []reconcile.Request{}
+	}
+
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{
+		Name:      cfRoute.Spec.DomainRef.Name,
+		Namespace: cfRoute.Spec.DomainRef.Namespace,
+	}}}
+}
+
+// secretToDomains enqueues the domains of the namespace using the secret as their certificate, so that
+// renewed certificates are picked up. Changes to the copies of the certificates in the ingress gateway
+// namespace enqueue the domain they were copied from
+func (r *CFDomainReconciler) secretToDomains(o client.Object) []reconcile.Request {
+	if domainGUID, ok := o.GetLabels()[korifiv1alpha1.CFDomainGUIDLabelKey]; ok && o.GetNamespace() == r.controllerConfig.IngressGatewayNamespace {
+		return r.domainsNamed(domainGUID)
+	}
+
+	cfDomains := new(korifiv1alpha1.CFDomainList)
+	if err := r.client.List(context.Background(), cfDomains, client.InNamespace(o.GetNamespace())); err != nil {
+		r.log.Error(err, "failed to list domains", "namespace", o.GetNamespace())
+		return []reconcile.Request{}
+	}
+
+	requests := []reconcile.Request{}
+	for _, cfDomain := range cfDomains.Items {
+		if cfDomain.Spec.TLS != nil && cfDomain.Spec.TLS.SecretName == o.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&cfDomain)})
+		}
+	}
+
+	return requests
+}
+
+func (r *CFDomainReconciler) domainsNamed(name string) []reconcile.Request {
+	cfDomains := new(korifiv1alpha1.CFDomainList)
+	if err := r.client.List(context.Background(), cfDomains); err != nil {
+		r.log.Error(err, "failed to list domains")
+		return []reconcile.Request{}
+	}
+
+	requests := []reconcile.Request{}
+	for _, cfDomain := range cfDomains.Items {
+		if cfDomain.Name == name {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&cfDomain)})
+		}
+	}
+
+	return requests
+}
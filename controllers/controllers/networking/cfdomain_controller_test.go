@@ -0,0 +1,299 @@
+package networking_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/config"
+	. "code.cloudfoundry.org/korifi/controllers/controllers/networking"
+	. "code.cloudfoundry.org/korifi/controllers/controllers/workloads/testutils"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	contourv1 "github.com/projectcontour/contour/apis/projectcontour/v1"
+	networkingv1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("CFDomainReconciler Integration Tests", func() {
+	var (
+		ctx            context.Context
+		testNamespace  string
+		cfDomain       *korifiv1alpha1.CFDomain
+		ingressBackend string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		ingressBackend = config.IstioIngressBackend
+
+		testNamespace = GenerateGUID()
+		Expect(k8sClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: testNamespace},
+		})).To(Succeed())
+
+		cfDomain = &korifiv1alpha1.CFDomain{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      GenerateGUID(),
+				Namespace: testNamespace,
+			},
+			Spec: korifiv1alpha1.CFDomainSpec{
+				Name: "a" + GenerateGUID() + ".com",
+				TLS: &korifiv1alpha1.CFDomainTLS{
+					SecretName: "my-domain-tls",
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		startCFDomainReconciler(ingressBackend)
+		Expect(k8sClient.Create(ctx, cfDomain)).To(Succeed())
+	})
+
+	getCondition := func(conditionType string) *metav1.Condition {
+		domain := new(korifiv1alpha1.CFDomain)
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfDomain), domain)).To(Succeed())
+		return meta.FindStatusCondition(domain.Status.Conditions, conditionType)
+	}
+
+	createTLSSecret := func(certificate []byte) {
+		Expect(k8sClient.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-domain-tls",
+				Namespace: testNamespace,
+			},
+			Type: corev1.SecretTypeTLS,
+			Data: map[string][]byte{
+				corev1.TLSCertKey:       certificate,
+				corev1.TLSPrivateKeyKey: []byte("key"),
+			},
+		})).To(Succeed())
+	}
+
+	When("the TLS secret does not exist", func() {
+		It("reports the certificate as not ready", func() {
+			Eventually(func(g Gomega) {
+				condition := getCondition(CertificateReadyCondition)
+				g.Expect(condition).NotTo(BeNil())
+				g.Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+				g.Expect(condition.Reason).To(Equal("SecretNotFound"))
+			}).Should(Succeed())
+		})
+
+		When("the secret is created", func() {
+			JustBeforeEach(func() {
+				Eventually(func() *metav1.Condition {
+					return getCondition(CertificateReadyCondition)
+				}).ShouldNot(BeNil())
+
+				createTLSSecret(createCertificatePEM(time.Now().Add(90 * 24 * time.Hour)))
+			})
+
+			It("reports the certificate as ready", func() {
+				Eventually(func(g Gomega) {
+					condition := getCondition(CertificateReadyCondition)
+					g.Expect(condition).NotTo(BeNil())
+					g.Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+				}).Should(Succeed())
+			})
+		})
+	})
+
+	When("the TLS secret contains a valid certificate", func() {
+		BeforeEach(func() {
+			createTLSSecret(createCertificatePEM(time.Now().Add(90 * 24 * time.Hour)))
+		})
+
+		It("reports the certificate as ready and not expiring", func() {
+			Eventually(func(g Gomega) {
+				ready := getCondition(CertificateReadyCondition)
+				g.Expect(ready).NotTo(BeNil())
+				g.Expect(ready.Status).To(Equal(metav1.ConditionTrue))
+
+				expiring := getCondition(CertificateExpiringCondition)
+				g.Expect(expiring).NotTo(BeNil())
+				g.Expect(expiring.Status).To(Equal(metav1.ConditionFalse))
+			}).Should(Succeed())
+		})
+
+		It("copies the certificate to the namespace of the istio ingress gateway", func() {
+			Eventually(func(g Gomega) {
+				secretCopy := new(corev1.Secret)
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "cfdomain-" + cfDomain.Name, Namespace: "istio-system"}, secretCopy)).To(Succeed())
+				g.Expect(secretCopy.Type).To(Equal(corev1.SecretTypeTLS))
+				g.Expect(secretCopy.Data).To(HaveKeyWithValue(corev1.TLSPrivateKeyKey, []byte("key")))
+			}).Should(Succeed())
+		})
+
+		It("serves the copy of the certificate from the gateway of the domain", func() {
+			Eventually(func(g Gomega) {
+				gateway := new(networkingv1alpha3.Gateway)
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfDomain), gateway)).To(Succeed())
+				g.Expect(gateway.Spec.Servers).To(HaveLen(1))
+				g.Expect(gateway.Spec.Servers[0].Tls.CredentialName).To(Equal("cfdomain-" + cfDomain.Name))
+			}).Should(Succeed())
+		})
+
+		When("the domain is deleted", func() {
+			JustBeforeEach(func() {
+				Eventually(func(g Gomega) {
+					g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "cfdomain-" + cfDomain.Name, Namespace: "istio-system"}, new(corev1.Secret))).To(Succeed())
+				}).Should(Succeed())
+
+				Expect(k8sClient.Delete(ctx, cfDomain)).To(Succeed())
+			})
+
+			It("deletes the copy of the certificate", func() {
+				Eventually(func(g Gomega) {
+					err := k8sClient.Get(ctx, types.NamespacedName{Name: "cfdomain-" + cfDomain.Name, Namespace: "istio-system"}, new(corev1.Secret))
+					g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+				}).Should(Succeed())
+			})
+		})
+
+		When("the ingress backend is contour", func() {
+			var routeNamespace string
+
+			BeforeEach(func() {
+				ingressBackend = config.ContourIngressBackend
+
+				routeNamespace = GenerateGUID()
+				Expect(k8sClient.Create(ctx, &corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{Name: routeNamespace},
+				})).To(Succeed())
+
+				Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      GenerateGUID(),
+						Namespace: routeNamespace,
+					},
+					Spec: korifiv1alpha1.CFRouteSpec{
+						Host: "my-app",
+						Path: "/",
+						DomainRef: corev1.ObjectReference{
+							Name:      cfDomain.Name,
+							Namespace: testNamespace,
+						},
+					},
+				})).To(Succeed())
+			})
+
+			It("delegates the certificate to the namespaces of the routes on the domain", func() {
+				Eventually(func(g Gomega) {
+					delegation := new(contourv1.TLSCertificateDelegation)
+					g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfDomain), delegation)).To(Succeed())
+					g.Expect(delegation.Spec.Delegations).To(ConsistOf(contourv1.CertificateDelegation{
+						SecretName:       "my-domain-tls",
+						TargetNamespaces: []string{routeNamespace},
+					}))
+				}).Should(Succeed())
+			})
+		})
+	})
+
+	When("the certificate expires soon", func() {
+		BeforeEach(func() {
+			createTLSSecret(createCertificatePEM(time.Now().Add(7 * 24 * time.Hour)))
+		})
+
+		It("reports the certificate as expiring", func() {
+			Eventually(func(g Gomega) {
+				expiring := getCondition(CertificateExpiringCondition)
+				g.Expect(expiring).NotTo(BeNil())
+				g.Expect(expiring.Status).To(Equal(metav1.ConditionTrue))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the TLS secret does not contain a certificate", func() {
+		BeforeEach(func() {
+			createTLSSecret([]byte("not-a-certificate"))
+		})
+
+		It("reports the certificate as invalid", func() {
+			Eventually(func(g Gomega) {
+				condition := getCondition(CertificateReadyCondition)
+				g.Expect(condition).NotTo(BeNil())
+				g.Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+				g.Expect(condition.Reason).To(Equal("InvalidCertificate"))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the domain references an issuer", func() {
+		BeforeEach(func() {
+			cfDomain.Spec.TLS.IssuerRef = &korifiv1alpha1.IssuerReference{
+				Name: "letsencrypt",
+				Kind: "ClusterIssuer",
+			}
+		})
+
+		It("requests a certificate for the domain and its subdomains", func() {
+			certificate := &unstructured.Unstructured{}
+			certificate.SetAPIVersion("cert-manager.io/v1")
+			certificate.SetKind("Certificate")
+
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfDomain), certificate)).To(Succeed())
+				g.Expect(unstructured.NestedString(certificate.Object, "spec", "secretName")).To(Equal("my-domain-tls"))
+				g.Expect(unstructured.NestedStringSlice(certificate.Object, "spec", "dnsNames")).To(ConsistOf(cfDomain.Spec.Name, "*."+cfDomain.Spec.Name))
+				g.Expect(unstructured.NestedString(certificate.Object, "spec", "issuerRef", "kind")).To(Equal("ClusterIssuer"))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the domain TLS is removed", func() {
+		BeforeEach(func() {
+			createTLSSecret(createCertificatePEM(time.Now().Add(90 * 24 * time.Hour)))
+		})
+
+		JustBeforeEach(func() {
+			Eventually(func() *metav1.Condition {
+				return getCondition(CertificateReadyCondition)
+			}).ShouldNot(BeNil())
+
+			Expect(k8s.PatchResource(ctx, k8sClient, cfDomain, func() {
+				cfDomain.Spec.TLS = nil
+			})).To(Succeed())
+		})
+
+		It("clears the certificate conditions", func() {
+			Eventually(func() *metav1.Condition {
+				return getCondition(CertificateReadyCondition)
+			}).Should(BeNil())
+		})
+	})
+})
+
+func createCertificatePEM(notAfter time.Time) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).NotTo(HaveOccurred())
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	Expect(err).NotTo(HaveOccurred())
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certBytes})
+}
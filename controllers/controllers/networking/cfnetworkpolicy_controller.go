@@ -0,0 +1,271 @@
+package networking
+
+import (
+	"context"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	NetworkPolicyNamePrefix       = "korifi-c2c-"
+	EgressNetworkPolicyNamePrefix = "korifi-c2c-egress-"
+	CFNetworkPolicyFinalizerName  = "cfNetworkPolicy.korifi.cloudfoundry.org"
+)
+
+// CFNetworkPolicyReconciler renders a CFNetworkPolicy as a NetworkPolicy allowing ingress from the
+// source app pods to the destination app pods. Once an app is the destination of a policy, other apps
+// can only reach it through a policy, while traffic from outside of the space namespaces (e.g. the
+// ingress controller) is still allowed.
+//
+// When running security groups restrict the egress of the source space, a second NetworkPolicy in the
+// source space allows the source app pods to reach the destination app pods. It cannot be owned by the
+// CFNetworkPolicy across namespaces, so it is deleted by a finalizer
+type CFNetworkPolicyReconciler struct {
+	client client.Client
+	scheme *runtime.Scheme
+	log    logr.Logger
+}
+
+func NewCFNetworkPolicyReconciler(
+	client client.Client,
+	scheme *runtime.Scheme,
+	log logr.Logger,
+) *k8s.PatchingReconciler[korifiv1alpha1.CFNetworkPolicy, *korifiv1alpha1.CFNetworkPolicy] {
+	policyReconciler := CFNetworkPolicyReconciler{client: client, scheme: scheme, log: log}
+	return k8s.NewPatchingReconciler[korifiv1alpha1.CFNetworkPolicy, *korifiv1alpha1.CFNetworkPolicy](log, client, &policyReconciler)
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfnetworkpolicies,verbs=get;list;watch;create;patch;update;delete
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfnetworkpolicies/status,verbs=get;update;patch
+
+//+kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update;patch;delete
+
+func (r *CFNetworkPolicyReconciler) ReconcileResource(ctx context.Context, cfNetworkPolicy *korifiv1alpha1.CFNetworkPolicy) (ctrl.Result, error) {
+	log := r.log.WithValues("namespace", cfNetworkPolicy.Namespace, "name", cfNetworkPolicy.Name)
+
+	if err := k8s.AddFinalizer(ctx, log, r.client, cfNetworkPolicy, CFNetworkPolicyFinalizerName); err != nil {
+		log.Error(err, "Error adding finalizer")
+		return ctrl.Result{}, err
+	}
+
+	if !cfNetworkPolicy.GetDeletionTimestamp().IsZero() {
+		return r.finalizeCFNetworkPolicy(ctx, log, cfNetworkPolicy)
+	}
+
+	networkPolicy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      NetworkPolicyNamePrefix + cfNetworkPolicy.Name,
+			Namespace: cfNetworkPolicy.Namespace,
+		},
+	}
+
+	_, err := controllerutil.CreateOrPatch(ctx, r.client, networkPolicy, func() error {
+		networkPolicy.Spec = networkPolicySpec(cfNetworkPolicy)
+		return controllerutil.SetControllerReference(cfNetworkPolicy, networkPolicy, r.scheme)
+	})
+	if err == nil {
+		err = r.reconcileEgressNetworkPolicy(ctx, cfNetworkPolicy)
+	}
+	if err != nil {
+		log.Error(err, "failed to create or patch network policy")
+		meta.SetStatusCondition(&cfNetworkPolicy.Status.Conditions, metav1.Condition{
+			Type:               korifiv1alpha1.ReadyConditionType,
+			Status:             metav1.ConditionFalse,
+			Reason:             "NetworkPolicyFailed",
+			Message:            err.Error(),
+			ObservedGeneration: cfNetworkPolicy.Generation,
+		})
+		return ctrl.Result{}, err
+	}
+
+	meta.SetStatusCondition(&cfNetworkPolicy.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.ReadyConditionType,
+		Status:             metav1.ConditionTrue,
+		Reason:             "NetworkPolicyReady",
+		ObservedGeneration: cfNetworkPolicy.Generation,
+	})
+	cfNetworkPolicy.Status.ObservedGeneration = cfNetworkPolicy.Generation
+
+	return ctrl.Result{}, nil
+}
+
+func (r *CFNetworkPolicyReconciler) finalizeCFNetworkPolicy(ctx context.Context, log logr.Logger, cfNetworkPolicy *korifiv1alpha1.CFNetworkPolicy) (ctrl.Result, error) {
+	log = log.WithName("finalizeCFNetworkPolicy")
+
+	if !controllerutil.ContainsFinalizer(cfNetworkPolicy, CFNetworkPolicyFinalizerName) {
+		return ctrl.Result{}, nil
+	}
+
+	if err := client.IgnoreNotFound(r.client.Delete(ctx, egressNetworkPolicy(cfNetworkPolicy))); err != nil {
+		log.Error(err, "failed to delete egress network policy")
+		return ctrl.Result{}, err
+	}
+
+	if controllerutil.RemoveFinalizer(cfNetworkPolicy, CFNetworkPolicyFinalizerName) {
+		log.Info("finalizer removed")
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// reconcileEgressNetworkPolicy allows the source app pods to reach the destination app pods when the
+// running security groups policy of the source space restricts their egress. Without that policy the
+// egress of the source pods is unrestricted, and selecting them in an egress policy would isolate them
+func (r *CFNetworkPolicyReconciler) reconcileEgressNetworkPolicy(ctx context.Context, cfNetworkPolicy *korifiv1alpha1.CFNetworkPolicy) error {
+	egressPolicy := egressNetworkPolicy(cfNetworkPolicy)
+
+	err := r.client.Get(ctx, types.NamespacedName{Namespace: egressPolicy.Namespace, Name: workloads.RunningSecurityGroupsPolicyName}, new(networkingv1.NetworkPolicy))
+	if k8serrors.IsNotFound(err) {
+		return client.IgnoreNotFound(r.client.Delete(ctx, egressPolicy))
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = controllerutil.CreateOrPatch(ctx, r.client, egressPolicy, func() error {
+		egressPolicy.Spec = egressNetworkPolicySpec(cfNetworkPolicy)
+		return nil
+	})
+	return err
+}
+
+func egressNetworkPolicy(cfNetworkPolicy *korifiv1alpha1.CFNetworkPolicy) *networkingv1.NetworkPolicy {
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      EgressNetworkPolicyNamePrefix + cfNetworkPolicy.Name,
+			Namespace: cfNetworkPolicy.Spec.Source.SpaceGUID,
+		},
+	}
+}
+
+func networkPolicyPort(cfNetworkPolicy *korifiv1alpha1.CFNetworkPolicy) networkingv1.NetworkPolicyPort {
+	protocol := corev1.ProtocolTCP
+	if cfNetworkPolicy.Spec.Protocol == korifiv1alpha1.NetworkPolicyProtocolUDP {
+		protocol = corev1.ProtocolUDP
+	}
+
+	port := intstr.FromInt(int(cfNetworkPolicy.Spec.Ports.Start))
+	policyPort := networkingv1.NetworkPolicyPort{Protocol: &protocol, Port: &port}
+	if cfNetworkPolicy.Spec.Ports.End > cfNetworkPolicy.Spec.Ports.Start {
+		endPort := cfNetworkPolicy.Spec.Ports.End
+		policyPort.EndPort = &endPort
+	}
+
+	return policyPort
+}
+
+func egressNetworkPolicySpec(cfNetworkPolicy *korifiv1alpha1.CFNetworkPolicy) networkingv1.NetworkPolicySpec {
+	return networkingv1.NetworkPolicySpec{
+		PodSelector: metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				korifiv1alpha1.CFAppGUIDLabelKey: cfNetworkPolicy.Spec.Source.AppGUID,
+			},
+		},
+		PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+		Egress: []networkingv1.NetworkPolicyEgressRule{{
+			To: []networkingv1.NetworkPolicyPeer{{
+				NamespaceSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						corev1.LabelMetadataName: cfNetworkPolicy.Namespace,
+					},
+				},
+				PodSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						korifiv1alpha1.CFAppGUIDLabelKey: cfNetworkPolicy.Spec.DestinationAppRef.Name,
+					},
+				},
+			}},
+			Ports: []networkingv1.NetworkPolicyPort{networkPolicyPort(cfNetworkPolicy)},
+		}},
+	}
+}
+
+func networkPolicySpec(cfNetworkPolicy *korifiv1alpha1.CFNetworkPolicy) networkingv1.NetworkPolicySpec {
+	return networkingv1.NetworkPolicySpec{
+		PodSelector: metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				korifiv1alpha1.CFAppGUIDLabelKey: cfNetworkPolicy.Spec.DestinationAppRef.Name,
+			},
+		},
+		PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+		Ingress: []networkingv1.NetworkPolicyIngressRule{
+			{
+				From: []networkingv1.NetworkPolicyPeer{{
+					NamespaceSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							corev1.LabelMetadataName: cfNetworkPolicy.Spec.Source.SpaceGUID,
+						},
+					},
+					PodSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							korifiv1alpha1.CFAppGUIDLabelKey: cfNetworkPolicy.Spec.Source.AppGUID,
+						},
+					},
+				}},
+				Ports: []networkingv1.NetworkPolicyPort{networkPolicyPort(cfNetworkPolicy)},
+			},
+			{
+				From: []networkingv1.NetworkPolicyPeer{{
+					NamespaceSelector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{{
+							Key:      korifiv1alpha1.SpaceNameLabel,
+							Operator: metav1.LabelSelectorOpDoesNotExist,
+						}},
+					},
+				}},
+			},
+		},
+	}
+}
+
+func (r *CFNetworkPolicyReconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&korifiv1alpha1.CFNetworkPolicy{}).
+		Owns(&networkingv1.NetworkPolicy{}).
+		Watches(
+			&source.Kind{Type: &networkingv1.NetworkPolicy{}},
+			handler.EnqueueRequestsFromMapFunc(r.securityGroupsPolicyToNetworkPolicies),
+			builder.WithPredicates(predicate.NewPredicateFuncs(isRunningSecurityGroupsPolicy)),
+		)
+}
+
+func isRunningSecurityGroupsPolicy(obj client.Object) bool {
+	return obj.GetName() == workloads.RunningSecurityGroupsPolicyName
+}
+
+func (r *CFNetworkPolicyReconciler) securityGroupsPolicyToNetworkPolicies(obj client.Object) []reconcile.Request {
+	cfNetworkPolicies := new(korifiv1alpha1.CFNetworkPolicyList)
+	err := r.client.List(context.Background(), cfNetworkPolicies, client.MatchingFields{shared.IndexNetworkPolicySourceSpaceGUID: obj.GetNamespace()})
+	if err != nil {
+		r.log.Error(err, "failed to list network policies with source space", "namespace", obj.GetNamespace())
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+	for _, cfNetworkPolicy := range cfNetworkPolicies.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&cfNetworkPolicy)})
+	}
+
+	return requests
+}
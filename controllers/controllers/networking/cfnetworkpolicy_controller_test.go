@@ -0,0 +1,253 @@
+package networking_test
+
+import (
+	"context"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	. "code.cloudfoundry.org/korifi/controllers/controllers/networking"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads"
+	. "code.cloudfoundry.org/korifi/controllers/controllers/workloads/testutils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("CFNetworkPolicyReconciler Integration Tests", func() {
+	var (
+		ctx             context.Context
+		testNamespace   string
+		sourceNamespace string
+		cfNetworkPolicy *korifiv1alpha1.CFNetworkPolicy
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		testNamespace = GenerateGUID()
+		Expect(k8sClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: testNamespace},
+		})).To(Succeed())
+
+		sourceNamespace = GenerateGUID()
+		Expect(k8sClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: sourceNamespace},
+		})).To(Succeed())
+
+		cfNetworkPolicy = &korifiv1alpha1.CFNetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      GenerateGUID(),
+				Namespace: testNamespace,
+			},
+			Spec: korifiv1alpha1.CFNetworkPolicySpec{
+				Source: korifiv1alpha1.NetworkPolicySource{
+					AppGUID:   "source-app-guid",
+					SpaceGUID: sourceNamespace,
+				},
+				DestinationAppRef: corev1.LocalObjectReference{Name: "destination-app-guid"},
+				Protocol:          korifiv1alpha1.NetworkPolicyProtocolTCP,
+				Ports:             korifiv1alpha1.NetworkPolicyPorts{Start: 8080, End: 8090},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		Expect(k8sClient.Create(ctx, cfNetworkPolicy)).To(Succeed())
+	})
+
+	getNetworkPolicy := func(g Gomega) *networkingv1.NetworkPolicy {
+		networkPolicy := new(networkingv1.NetworkPolicy)
+		g.Expect(k8sClient.Get(ctx, types.NamespacedName{
+			Namespace: testNamespace,
+			Name:      NetworkPolicyNamePrefix + cfNetworkPolicy.Name,
+		}, networkPolicy)).To(Succeed())
+		return networkPolicy
+	}
+
+	It("creates a network policy owned by the CFNetworkPolicy that selects the destination app pods", func() {
+		Eventually(func(g Gomega) {
+			networkPolicy := getNetworkPolicy(g)
+			g.Expect(networkPolicy.OwnerReferences).To(ConsistOf(HaveField("Name", cfNetworkPolicy.Name)))
+			g.Expect(networkPolicy.Spec.PodSelector.MatchLabels).To(Equal(map[string]string{
+				korifiv1alpha1.CFAppGUIDLabelKey: "destination-app-guid",
+			}))
+			g.Expect(networkPolicy.Spec.PolicyTypes).To(ConsistOf(networkingv1.PolicyTypeIngress))
+		}).Should(Succeed())
+	})
+
+	It("allows ingress from the source app pods on the port range", func() {
+		Eventually(func(g Gomega) {
+			networkPolicy := getNetworkPolicy(g)
+			g.Expect(networkPolicy.Spec.Ingress).To(HaveLen(2))
+
+			sourceRule := networkPolicy.Spec.Ingress[0]
+			g.Expect(sourceRule.From).To(HaveLen(1))
+			g.Expect(sourceRule.From[0].NamespaceSelector.MatchLabels).To(Equal(map[string]string{
+				corev1.LabelMetadataName: sourceNamespace,
+			}))
+			g.Expect(sourceRule.From[0].PodSelector.MatchLabels).To(Equal(map[string]string{
+				korifiv1alpha1.CFAppGUIDLabelKey: "source-app-guid",
+			}))
+			g.Expect(sourceRule.Ports).To(HaveLen(1))
+			g.Expect(*sourceRule.Ports[0].Protocol).To(Equal(corev1.ProtocolTCP))
+			g.Expect(sourceRule.Ports[0].Port.IntValue()).To(Equal(8080))
+			g.Expect(sourceRule.Ports[0].EndPort).To(PointTo(BeEquivalentTo(8090)))
+		}).Should(Succeed())
+	})
+
+	It("allows ingress from namespaces that are not spaces", func() {
+		Eventually(func(g Gomega) {
+			networkPolicy := getNetworkPolicy(g)
+			g.Expect(networkPolicy.Spec.Ingress).To(HaveLen(2))
+
+			platformRule := networkPolicy.Spec.Ingress[1]
+			g.Expect(platformRule.Ports).To(BeEmpty())
+			g.Expect(platformRule.From).To(HaveLen(1))
+			g.Expect(platformRule.From[0].NamespaceSelector.MatchExpressions).To(ConsistOf(metav1.LabelSelectorRequirement{
+				Key:      korifiv1alpha1.SpaceNameLabel,
+				Operator: metav1.LabelSelectorOpDoesNotExist,
+			}))
+		}).Should(Succeed())
+	})
+
+	It("sets the ready condition", func() {
+		Eventually(func(g Gomega) {
+			policy := new(korifiv1alpha1.CFNetworkPolicy)
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfNetworkPolicy), policy)).To(Succeed())
+			g.Expect(meta.IsStatusConditionTrue(policy.Status.Conditions, korifiv1alpha1.ReadyConditionType)).To(BeTrue())
+			g.Expect(policy.Status.ObservedGeneration).To(Equal(policy.Generation))
+		}).Should(Succeed())
+	})
+
+	It("does not restrict the egress of the source app pods", func() {
+		Eventually(func(g Gomega) {
+			policy := new(korifiv1alpha1.CFNetworkPolicy)
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfNetworkPolicy), policy)).To(Succeed())
+			g.Expect(meta.IsStatusConditionTrue(policy.Status.Conditions, korifiv1alpha1.ReadyConditionType)).To(BeTrue())
+		}).Should(Succeed())
+
+		Consistently(func(g Gomega) {
+			err := k8sClient.Get(ctx, types.NamespacedName{
+				Namespace: sourceNamespace,
+				Name:      EgressNetworkPolicyNamePrefix + cfNetworkPolicy.Name,
+			}, new(networkingv1.NetworkPolicy))
+			g.Expect(errors.IsNotFound(err)).To(BeTrue())
+		}).Should(Succeed())
+	})
+
+	When("running security groups restrict the egress of the source space", func() {
+		var securityGroupsPolicy *networkingv1.NetworkPolicy
+
+		BeforeEach(func() {
+			securityGroupsPolicy = &networkingv1.NetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      workloads.RunningSecurityGroupsPolicyName,
+					Namespace: sourceNamespace,
+				},
+				Spec: networkingv1.NetworkPolicySpec{
+					PodSelector: metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{{
+							Key:      korifiv1alpha1.CFAppGUIDLabelKey,
+							Operator: metav1.LabelSelectorOpExists,
+						}},
+					},
+					PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+				},
+			}
+			Expect(k8sClient.Create(ctx, securityGroupsPolicy)).To(Succeed())
+		})
+
+		getEgressNetworkPolicy := func(g Gomega) *networkingv1.NetworkPolicy {
+			networkPolicy := new(networkingv1.NetworkPolicy)
+			g.Expect(k8sClient.Get(ctx, types.NamespacedName{
+				Namespace: sourceNamespace,
+				Name:      EgressNetworkPolicyNamePrefix + cfNetworkPolicy.Name,
+			}, networkPolicy)).To(Succeed())
+			return networkPolicy
+		}
+
+		It("allows egress from the source app pods to the destination app pods alongside the ingress policy", func() {
+			Eventually(func(g Gomega) {
+				networkPolicy := getNetworkPolicy(g)
+				g.Expect(networkPolicy.Spec.Ingress).NotTo(BeEmpty())
+
+				egressPolicy := getEgressNetworkPolicy(g)
+				g.Expect(egressPolicy.Spec.PodSelector.MatchLabels).To(Equal(map[string]string{
+					korifiv1alpha1.CFAppGUIDLabelKey: "source-app-guid",
+				}))
+				g.Expect(egressPolicy.Spec.PolicyTypes).To(ConsistOf(networkingv1.PolicyTypeEgress))
+				g.Expect(egressPolicy.Spec.Egress).To(HaveLen(1))
+
+				rule := egressPolicy.Spec.Egress[0]
+				g.Expect(rule.To).To(HaveLen(1))
+				g.Expect(rule.To[0].NamespaceSelector.MatchLabels).To(Equal(map[string]string{
+					corev1.LabelMetadataName: testNamespace,
+				}))
+				g.Expect(rule.To[0].PodSelector.MatchLabels).To(Equal(map[string]string{
+					korifiv1alpha1.CFAppGUIDLabelKey: "destination-app-guid",
+				}))
+				g.Expect(rule.Ports).To(Equal(networkPolicy.Spec.Ingress[0].Ports))
+			}).Should(Succeed())
+		})
+
+		When("the running security groups policy is deleted", func() {
+			JustBeforeEach(func() {
+				Eventually(getEgressNetworkPolicy).Should(Not(BeNil()))
+				Expect(k8sClient.Delete(ctx, securityGroupsPolicy)).To(Succeed())
+			})
+
+			It("deletes the egress policy", func() {
+				Eventually(func(g Gomega) {
+					err := k8sClient.Get(ctx, types.NamespacedName{
+						Namespace: sourceNamespace,
+						Name:      EgressNetworkPolicyNamePrefix + cfNetworkPolicy.Name,
+					}, new(networkingv1.NetworkPolicy))
+					g.Expect(errors.IsNotFound(err)).To(BeTrue())
+				}).Should(Succeed())
+			})
+		})
+
+		When("the CFNetworkPolicy is deleted", func() {
+			JustBeforeEach(func() {
+				Eventually(getEgressNetworkPolicy).Should(Not(BeNil()))
+				Expect(k8sClient.Delete(ctx, cfNetworkPolicy)).To(Succeed())
+			})
+
+			It("deletes the egress policy from the source space", func() {
+				Eventually(func(g Gomega) {
+					err := k8sClient.Get(ctx, types.NamespacedName{
+						Namespace: sourceNamespace,
+						Name:      EgressNetworkPolicyNamePrefix + cfNetworkPolicy.Name,
+					}, new(networkingv1.NetworkPolicy))
+					g.Expect(errors.IsNotFound(err)).To(BeTrue())
+				}).Should(Succeed())
+			})
+		})
+	})
+
+	When("the policy is for a single udp port", func() {
+		BeforeEach(func() {
+			cfNetworkPolicy.Spec.Protocol = korifiv1alpha1.NetworkPolicyProtocolUDP
+			cfNetworkPolicy.Spec.Ports = korifiv1alpha1.NetworkPolicyPorts{Start: 53, End: 53}
+		})
+
+		It("allows the single port without an end port", func() {
+			Eventually(func(g Gomega) {
+				networkPolicy := getNetworkPolicy(g)
+				g.Expect(networkPolicy.Spec.Ingress).NotTo(BeEmpty())
+				ports := networkPolicy.Spec.Ingress[0].Ports
+				g.Expect(ports).To(HaveLen(1))
+				g.Expect(*ports[0].Protocol).To(Equal(corev1.ProtocolUDP))
+				g.Expect(ports[0].Port.IntValue()).To(Equal(53))
+				g.Expect(ports[0].EndPort).To(BeNil())
+			}).Should(Succeed())
+		})
+	})
+})
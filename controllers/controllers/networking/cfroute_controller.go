@@ -24,6 +24,8 @@ import (
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/config"
+	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 	"istio.io/api/networking/v1alpha3"
 	networkingv1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
@@ -31,6 +33,7 @@ import (
 	"github.com/go-logr/logr"
 	contourv1 "github.com/projectcontour/contour/apis/projectcontour/v1"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -41,18 +44,24 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 const (
 	CFRouteFinalizerName = "cfRoute.korifi.cloudfoundry.org"
 )
 
-// CFRouteReconciler reconciles a CFRoute object to create Contour resources
+// CFRouteReconciler reconciles a CFRoute object to create the services of its destinations and the
+// resources of the configured ingress backend
 type CFRouteReconciler struct {
 	client           client.Client
 	scheme           *runtime.Scheme
 	log              logr.Logger
 	controllerConfig *config.ControllerConfig
+	ingress          ingressBackend
 }
 
 func NewCFRouteReconciler(
@@ -62,6 +71,7 @@ func NewCFRouteReconciler(
 	controllerConfig *config.ControllerConfig,
 ) *k8s.PatchingReconciler[korifiv1alpha1.CFRoute, *korifiv1alpha1.CFRoute] {
 	routeReconciler := CFRouteReconciler{client: client, scheme: scheme, log: log, controllerConfig: controllerConfig}
+	routeReconciler.ingress = newIngressBackend(&routeReconciler)
 	return k8s.NewPatchingReconciler[korifiv1alpha1.CFRoute, *korifiv1alpha1.CFRoute](log, client, &routeReconciler)
 }
 
@@ -78,6 +88,14 @@ func NewCFRouteReconciler(
 //+kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
 
 //+kubebuilder:rbac:groups=networking.istio.io,resources=virtualservices;gateways,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=networking.istio.io,resources=serviceentries;destinationrules,verbs=get;list;watch;create;update;patch;delete
+
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes;tcproutes;referencegrants,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gateways,verbs=get;list;watch;patch
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceroutebindings,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+//+kubebuilder:rbac:groups=discovery.k8s.io,resources=endpointslices,verbs=get;list;watch;create;patch;delete
 
 func (r *CFRouteReconciler) ReconcileResource(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute) (ctrl.Result, error) {
 	log := r.log.WithValues("namespace", cfRoute.Namespace, "name", cfRoute.Name)
@@ -91,8 +109,21 @@ func (r *CFRouteReconciler) ReconcileResource(ctx context.Context, cfRoute *kori
 		return r.finalizeCFRoute(ctx, log, cfRoute)
 	}
 
+	transferredRoute, err := r.getTransferredRoute(ctx, cfRoute)
+	if err != nil {
+		log.Error(err, "failed to get transferred route")
+		return ctrl.Result{}, err
+	}
+
+	// the API deletes the original route once it has been recreated in the target namespace, unless it
+	// is interrupted in between
+	if transferredRoute != nil {
+		log.Info("deleting the original of a transferred route", "transferredTo", transferredRoute.Namespace)
+		return ctrl.Result{}, client.IgnoreNotFound(r.client.Delete(ctx, cfRoute))
+	}
+
 	var cfDomain korifiv1alpha1.CFDomain
-	err := r.client.Get(ctx, types.NamespacedName{Name: cfRoute.Spec.DomainRef.Name, Namespace: cfRoute.Spec.DomainRef.Namespace}, &cfDomain)
+	err = r.client.Get(ctx, types.NamespacedName{Name: cfRoute.Spec.DomainRef.Name, Namespace: cfRoute.Spec.DomainRef.Namespace}, &cfDomain)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			cfRoute.Status = createInvalidRouteStatus(cfRoute, "CFDomain not found", "InvalidDomainRef", err.Error())
@@ -108,22 +139,26 @@ func (r *CFRouteReconciler) ReconcileResource(ctx context.Context, cfRoute *kori
 		return ctrl.Result{}, err
 	}
 
-	if err = r.createOrPatchVirtualService(ctx, cfRoute, cfDomain); err != nil {
-		cfRoute.Status = createInvalidRouteStatus(cfRoute, "Error creating/patching virtual service", "CreatePatchVirtualService", err.Error())
-		return ctrl.Result{}, err
-	}
-
-	// err = r.createOrPatchRouteProxy(ctx, log, cfRoute)
-	// if err != nil {
-	// 	cfRoute.Status = createInvalidRouteStatus(cfRoute, "Error creating/patching Route Proxy", "CreatePatchRouteProxy", err.Error())
-	// 	return ctrl.Result{}, err
-	// }
+	if cfDomain.Spec.Internal {
+		if err = r.reconcileInternalRoute(ctx, log, cfRoute, &cfDomain); err != nil {
+			cfRoute.Status = createInvalidRouteStatus(cfRoute, "Error creating/patching internal route service", "CreatePatchInternalRoute", err.Error())
+			return ctrl.Result{}, err
+		}
+	} else if cfRoute.IsTCP() {
+		if err = r.ingress.reconcileTCPRoute(ctx, log, cfRoute, &cfDomain); err != nil {
+			return ingressFailed(cfRoute, "Error creating/patching TCP route", "CreatePatchTCPRoute", err)
+		}
+	} else {
+		routeService, err := r.getRouteService(ctx, log, cfRoute)
+		if err != nil {
+			cfRoute.Status = createInvalidRouteStatus(cfRoute, "Error fetching route service", "FetchRouteService", err.Error())
+			return ctrl.Result{}, err
+		}
 
-	// err = r.createOrPatchFQDNProxy(ctx, log, cfRoute, &cfDomain)
-	// if err != nil {
-	// 	cfRoute.Status = createInvalidRouteStatus(cfRoute, "Error creating/patching FQDN Proxy", "CreatePatchFQDNProxy", err.Error())
-	// 	return ctrl.Result{}, err
-	// }
+		if err = r.ingress.reconcileHTTPRoute(ctx, log, cfRoute, &cfDomain, routeService); err != nil {
+			return ingressFailed(cfRoute, "Error creating/patching HTTP route", "CreatePatchHTTPRoute", err)
+		}
+	}
 
 	err = r.deleteOrphanedServices(ctx, log, cfRoute)
 	if err != nil {
@@ -135,11 +170,43 @@ func (r *CFRouteReconciler) ReconcileResource(ctx context.Context, cfRoute *kori
 	return ctrl.Result{}, nil
 }
 
+// ingressFailed marks the route as invalid. Routes the ingress backend does not support are not requeued,
+// as they cannot become valid until they are changed
+func ingressFailed(cfRoute *korifiv1alpha1.CFRoute, description, reason string, err error) (ctrl.Result, error) {
+	var unsupportedErr unsupportedRouteError
+	if errors.As(err, &unsupportedErr) {
+		cfRoute.Status = createInvalidRouteStatus(cfRoute, "Route not supported by the ingress backend", "UnsupportedByIngressBackend", err.Error())
+		return ctrl.Result{}, nil
+	}
+
+	cfRoute.Status = createInvalidRouteStatus(cfRoute, description, reason, err.Error())
+	return ctrl.Result{}, err
+}
+
+// routeFQDN returns the fully qualified domain name of the route. Routes on private domains may have
+// no host, in which case they claim the domain itself
+func routeFQDN(cfRoute *korifiv1alpha1.CFRoute, cfDomain *korifiv1alpha1.CFDomain) string {
+	if cfRoute.Spec.Host == "" {
+		return cfDomain.Spec.Name
+	}
+
+	return cfRoute.Spec.Host + "." + cfDomain.Spec.Name
+}
+
+// routeURI returns the address clients use to reach the route: the FQDN and path for http routes
+// and the domain and port for tcp routes
+func routeURI(cfRoute *korifiv1alpha1.CFRoute, cfDomain *korifiv1alpha1.CFDomain) string {
+	if cfRoute.IsTCP() {
+		return fmt.Sprintf("%s:%d", cfDomain.Spec.Name, cfRoute.Spec.Port)
+	}
+
+	return routeFQDN(cfRoute, cfDomain) + cfRoute.Spec.Path
+}
+
 func createValidRouteStatus(cfRoute *korifiv1alpha1.CFRoute, cfDomain *korifiv1alpha1.CFDomain, description, reason, message string) korifiv1alpha1.CFRouteStatus {
-	fqdn := cfRoute.Spec.Host + "." + cfDomain.Spec.Name
 	cfRouteStatus := korifiv1alpha1.CFRouteStatus{
-		FQDN:          fqdn,
-		URI:           fqdn + cfRoute.Spec.Path,
+		FQDN:          routeFQDN(cfRoute, cfDomain),
+		URI:           routeURI(cfRoute, cfDomain),
 		Destinations:  cfRoute.Spec.Destinations,
 		CurrentStatus: korifiv1alpha1.ValidStatus,
 		Description:   description,
@@ -175,7 +242,31 @@ func createInvalidRouteStatus(cfRoute *korifiv1alpha1.CFRoute, description, reas
 
 func (r *CFRouteReconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&korifiv1alpha1.CFRoute{})
+		For(&korifiv1alpha1.CFRoute{}).
+		Watches(&source.Kind{Type: &korifiv1alpha1.CFServiceRouteBinding{}}, handler.EnqueueRequestsFromMapFunc(serviceRouteBindingToRoute)).
+		Watches(&source.Kind{Type: &korifiv1alpha1.CFDomain{}}, handler.EnqueueRequestsFromMapFunc(r.domainToRoutes)).
+		Watches(
+			&source.Kind{Type: &discoveryv1.EndpointSlice{}},
+			handler.EnqueueRequestsFromMapFunc(r.destinationEndpointSliceToRoute),
+			builder.WithPredicates(predicate.NewPredicateFuncs(isDestinationEndpointSlice)),
+		)
+}
+
+// domainToRoutes enqueues the routes on the domain, so that they move to the gateway of the domain when
+// its certificate is configured
+func (r *CFRouteReconciler) domainToRoutes(o client.Object) []reconcile.Request {
+	cfRoutes := new(korifiv1alpha1.CFRouteList)
+	if err := r.client.List(context.Background(), cfRoutes, client.MatchingFields{shared.IndexRouteDomainName: o.GetName()}); err != nil {
+		r.log.Error(err, "failed to list routes", "domain", o.GetName())
+		return []reconcile.Request{}
+	}
+
+	requests := []reconcile.Request{}
+	for i := range cfRoutes.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&cfRoutes.Items[i])})
+	}
+
+	return requests
 }
 
 func (r *CFRouteReconciler) finalizeCFRoute(ctx context.Context, log logr.Logger, cfRoute *korifiv1alpha1.CFRoute) (ctrl.Result, error) {
@@ -185,22 +276,39 @@ func (r *CFRouteReconciler) finalizeCFRoute(ctx context.Context, log logr.Logger
 		return ctrl.Result{}, nil
 	}
 
-	if err := r.deleteVirtualService(ctx, cfRoute); err != nil {
+	if err := r.ingress.finalizeRoute(ctx, log, cfRoute); err != nil {
 		return ctrl.Result{}, err
 	}
 
-	// fqdnHTTPProxy, foundFQDNProxy, err := r.getFQDNProxy(ctx, log, cfRoute.Status.FQDN, cfRoute.Namespace, false)
-	// if err != nil {
-	// 	return ctrl.Result{}, err
-	// }
+	transferredRoute, err := r.getTransferredRoute(ctx, cfRoute)
+	if err != nil {
+		log.Error(err, "failed to get transferred route")
+		return ctrl.Result{}, err
+	}
+
+	// the destination services of a transferred route are taken over by the route it was recreated as
+	if transferredRoute != nil {
+		if err = r.releaseDestinationServices(ctx, log, cfRoute); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		// completes the transfer when the API did not get to it
+		err = k8s.PatchResource(ctx, r.client, transferredRoute, func() {
+			delete(transferredRoute.Annotations, korifiv1alpha1.CFRouteTransferredFromAnnotation)
+		})
+		if client.IgnoreNotFound(err) != nil {
+			log.Error(err, "failed to complete the transfer of the route")
+			return ctrl.Result{}, err
+		}
+	} else {
+		if err = r.deleteSharedSpaceServices(ctx, log, cfRoute); err != nil {
+			return ctrl.Result{}, err
+		}
 
-	// // Cleanup the FQDN HTTPProxy on delete
-	// if foundFQDNProxy {
-	// 	err := r.finalizeFQDNProxy(ctx, log, cfRoute.Name, fqdnHTTPProxy)
-	// 	if err != nil {
-	// 		return ctrl.Result{}, err
-	// 	}
-	// }
+		if err = r.deleteInternalRouteServices(ctx, log, cfRoute); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
 
 	if controllerutil.RemoveFinalizer(cfRoute, CFRouteFinalizerName) {
 		log.Info("finalizer removed")
@@ -209,11 +317,85 @@ func (r *CFRouteReconciler) finalizeCFRoute(ctx context.Context, log logr.Logger
 	return ctrl.Result{}, nil
 }
 
-func (r *CFRouteReconciler) finalizeFQDNProxy(ctx context.Context, log logr.Logger, cfRouteName string, fqdnProxy *contourv1.HTTPProxy) error {
+// getTransferredRoute returns the route recreated with the same name in the namespace the route is
+// transferred to, see korifiv1alpha1.CFRouteTransferredToAnnotation, or nil if the route is not transferred
+func (r *CFRouteReconciler) getTransferredRoute(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute) (*korifiv1alpha1.CFRoute, error) {
+	transferredTo, ok := cfRoute.Annotations[korifiv1alpha1.CFRouteTransferredToAnnotation]
+	if !ok {
+		return nil, nil
+	}
+
+	transferredRoute := new(korifiv1alpha1.CFRoute)
+	err := r.client.Get(ctx, types.NamespacedName{Namespace: transferredTo, Name: cfRoute.Name}, transferredRoute)
+	if err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+
+	return transferredRoute, nil
+}
+
+// releaseDestinationServices removes the route from the owners of its destination services, so that they
+// are not garbage collected along with it
+func (r *CFRouteReconciler) releaseDestinationServices(ctx context.Context, log logr.Logger, cfRoute *korifiv1alpha1.CFRoute) error {
+	for i := range cfRoute.Spec.Destinations {
+		service := new(corev1.Service)
+		err := r.client.Get(ctx, types.NamespacedName{Namespace: cfRoute.Namespace, Name: generateServiceName(&cfRoute.Spec.Destinations[i])}, service)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+
+			log.Error(err, "failed to get destination service")
+			return err
+		}
+
+		err = k8s.PatchResource(ctx, r.client, service, func() {
+			var ownerReferences []metav1.OwnerReference
+			for _, ownerReference := range service.OwnerReferences {
+				if ownerReference.UID != cfRoute.UID {
+					ownerReferences = append(ownerReferences, ownerReference)
+				}
+			}
+			service.OwnerReferences = ownerReferences
+		})
+		if err != nil {
+			log.Error(err, "failed to release destination service", "name", service.Name)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteSharedSpaceServices deletes the services of the destinations in shared spaces, which are not
+// garbage collected along with the route
+func (r *CFRouteReconciler) deleteSharedSpaceServices(ctx context.Context, log logr.Logger, cfRoute *korifiv1alpha1.CFRoute) error {
+	for i, destination := range cfRoute.Spec.Destinations {
+		namespace := cfRoute.DestinationNamespace(destination)
+		if namespace == cfRoute.Namespace {
+			continue
+		}
+
+		err := r.client.Delete(ctx, &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      generateServiceName(&cfRoute.Spec.Destinations[i]),
+				Namespace: namespace,
+			},
+		})
+		if client.IgnoreNotFound(err) != nil {
+			log.Error(err, "failed to delete shared space service", "namespace", namespace)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *CFRouteReconciler) finalizeFQDNProxy(ctx context.Context, log logr.Logger, cfRoute *korifiv1alpha1.CFRoute, fqdnProxy *contourv1.HTTPProxy) error {
 	return k8s.PatchResource(ctx, r.client, fqdnProxy, func() {
 		var retainedIncludes []contourv1.Include
 		for _, include := range fqdnProxy.Spec.Includes {
-			if include.Name != cfRouteName {
+			if include.Name != cfRoute.Name || include.Namespace != cfRoute.Namespace {
 				retainedIncludes = append(retainedIncludes, include)
 			} else {
 				log.Info("Removing sub-HTTPProxy from FQDN HTTPProxy")
@@ -233,7 +415,7 @@ func (r *CFRouteReconciler) createOrPatchServices(ctx context.Context, log logr.
 		service := &corev1.Service{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      serviceName,
-				Namespace: cfRoute.Namespace,
+				Namespace: cfRoute.DestinationNamespace(destination),
 			},
 		}
 
@@ -243,14 +425,19 @@ func (r *CFRouteReconciler) createOrPatchServices(ctx context.Context, log logr.
 				korifiv1alpha1.CFRouteGUIDLabelKey: cfRoute.Name,
 			}
 
-			err := controllerutil.SetOwnerReference(cfRoute, service, r.scheme)
-			if err != nil {
-				loopLog.Error(err, "failed to set OwnerRef on Service")
-				return err
+			// owner references cannot cross namespaces, so the services of destinations in shared
+			// spaces are deleted by the finalizer instead
+			if service.Namespace == cfRoute.Namespace {
+				err := controllerutil.SetOwnerReference(cfRoute, service, r.scheme)
+				if err != nil {
+					loopLog.Error(err, "failed to set OwnerRef on Service")
+					return err
+				}
 			}
 
 			service.Spec.Ports = []corev1.ServicePort{{
-				Port: int32(destination.Port),
+				Port:        int32(destination.Port),
+				AppProtocol: serviceAppProtocol(destination),
 			}}
 			service.Spec.Selector = map[string]string{
 				korifiv1alpha1.CFAppGUIDLabelKey:     destination.AppRef.Name,
@@ -270,15 +457,59 @@ func (r *CFRouteReconciler) createOrPatchServices(ctx context.Context, log logr.
 	return nil
 }
 
-func (r *CFRouteReconciler) createOrPatchVirtualService(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute, cfDomain korifiv1alpha1.CFDomain) error {
-	fqdn := strings.ToLower(fmt.Sprintf("%s.%s", cfRoute.Spec.Host, cfDomain.Spec.Name))
+// createOrPatchDestinationAliases creates an ExternalName service in the route namespace for every
+// destination in a shared space, named like the destination service it points to
+func (r *CFRouteReconciler) createOrPatchDestinationAliases(ctx context.Context, log logr.Logger, cfRoute *korifiv1alpha1.CFRoute) error {
+	log = log.WithName("createOrPatchDestinationAliases")
+
+	for _, destination := range cfRoute.Spec.Destinations {
+		if cfRoute.DestinationNamespace(destination) == cfRoute.Namespace {
+			continue
+		}
+
+		service := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      generateServiceName(&destination),
+				Namespace: cfRoute.Namespace,
+			},
+		}
+
+		result, err := controllerutil.CreateOrPatch(ctx, r.client, service, func() error {
+			service.Labels = map[string]string{
+				korifiv1alpha1.CFAppGUIDLabelKey:   destination.AppRef.Name,
+				korifiv1alpha1.CFRouteGUIDLabelKey: cfRoute.Name,
+			}
+			service.Spec.Type = corev1.ServiceTypeExternalName
+			service.Spec.ExternalName = destinationServiceFQDN(cfRoute, destination)
+			service.Spec.Selector = nil
+			service.Spec.Ports = []corev1.ServicePort{{
+				Port:        int32(destination.Port),
+				AppProtocol: serviceAppProtocol(destination),
+			}}
+
+			return controllerutil.SetOwnerReference(cfRoute, service, r.scheme)
+		})
+		if err != nil {
+			log.Error(err, "failed to patch destination alias Service", "serviceName", service.Name)
+			return err
+		}
+
+		log.Info("Destination alias Service reconciled", "serviceName", service.Name, "operation", result)
+	}
+
+	return nil
+}
+
+func (r *CFRouteReconciler) createOrPatchVirtualService(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute, cfDomain korifiv1alpha1.CFDomain, routeService *routeService) error {
+	fqdn := strings.ToLower(routeFQDN(cfRoute, &cfDomain))
 	destinations := []*v1alpha3.HTTPRouteDestination{}
 	for _, d := range cfRoute.Spec.Destinations {
 		destinations = append(destinations, &v1alpha3.HTTPRouteDestination{
 			Destination: &v1alpha3.Destination{
-				Host: generateServiceName(&d),
+				Host: destinationHost(cfRoute, d),
 				Port: &v1alpha3.PortSelector{Number: uint32(d.Port)},
 			},
+			Weight: int32(destinationWeight(d)),
 		})
 	}
 
@@ -291,8 +522,16 @@ func (r *CFRouteReconciler) createOrPatchVirtualService(ctx context.Context, cfR
 
 	_, err := controllerutil.CreateOrPatch(ctx, r.client, virtualService, func() error {
 		virtualService.Spec.Hosts = []string{fqdn}
-		virtualService.Spec.Gateways = []string{"korifi/korifi-workloads-gateway"}
-		virtualService.Spec.Http = []*v1alpha3.HTTPRoute{{Route: destinations}}
+		virtualService.Spec.Gateways = []string{r.routeGateway(&cfDomain)}
+		if routeService != nil {
+			virtualService.Spec.Http = routeServiceHTTPRoutes(routeService, destinations)
+		} else {
+			virtualService.Spec.Http = []*v1alpha3.HTTPRoute{{Route: destinations}}
+		}
+
+		for _, httpRoute := range virtualService.Spec.Http {
+			httpRoute.Timeout = istioRequestTimeout(cfRoute)
+		}
 
 		return nil
 	})
@@ -300,6 +539,26 @@ func (r *CFRouteReconciler) createOrPatchVirtualService(ctx context.Context, cfR
 	return err
 }
 
+// routeGateway returns the istio gateway serving the routes on the domain. Domains with their own
+// certificate have their own gateway, see CFDomainReconciler
+func (r *CFRouteReconciler) routeGateway(cfDomain *korifiv1alpha1.CFDomain) string {
+	if cfDomain.Spec.TLS != nil {
+		return cfDomain.Namespace + "/" + cfDomain.Name
+	}
+
+	return r.controllerConfig.WorkloadsGatewayNamespace + "/" + r.controllerConfig.WorkloadsGatewayName
+}
+
+// tlsSecretName returns the secret of the certificate served for the routes on the domain. Contour only
+// allows referencing the secret of a domain from the namespaces it is delegated to, see CFDomainReconciler
+func (r *CFRouteReconciler) tlsSecretName(cfDomain *korifiv1alpha1.CFDomain) string {
+	if cfDomain.Spec.TLS != nil {
+		return cfDomain.Namespace + "/" + cfDomain.Spec.TLS.SecretName
+	}
+
+	return r.controllerConfig.WorkloadsTLSSecretNameWithNamespace()
+}
+
 func (r *CFRouteReconciler) deleteVirtualService(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute) error {
 	return client.IgnoreNotFound(r.client.Delete(ctx, &networkingv1alpha3.VirtualService{
 		ObjectMeta: metav1.ObjectMeta{
@@ -309,18 +568,101 @@ func (r *CFRouteReconciler) deleteVirtualService(ctx context.Context, cfRoute *k
 	}))
 }
 
-func (r *CFRouteReconciler) createOrPatchRouteProxy(ctx context.Context, log logr.Logger, cfRoute *korifiv1alpha1.CFRoute) error {
+// createOrPatchTCPGateway creates the istio gateway listening on the port of the tcp route. The port must
+// be exposed by the service of the ingress gateway
+func (r *CFRouteReconciler) createOrPatchTCPGateway(ctx context.Context, log logr.Logger, cfRoute *korifiv1alpha1.CFRoute) error {
+	log = log.WithName("createOrPatchTCPGateway").WithValues("gatewayNamespace", cfRoute.Namespace, "gatewayName", cfRoute.Name)
+
+	gateway := &networkingv1alpha3.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfRoute.Name,
+			Namespace: cfRoute.Namespace,
+		},
+	}
+
+	result, err := controllerutil.CreateOrPatch(ctx, r.client, gateway, func() error {
+		gateway.Spec.Selector = map[string]string{"istio": "ingressgateway"}
+		gateway.Spec.Servers = []*v1alpha3.Server{{
+			Port: &v1alpha3.Port{
+				Number:   uint32(cfRoute.Spec.Port),
+				Name:     tcpListenerName(cfRoute.Spec.Port),
+				Protocol: "TCP",
+			},
+			Hosts: []string{"*"},
+		}}
+
+		return controllerutil.SetOwnerReference(cfRoute, gateway, r.scheme)
+	})
+	if err != nil {
+		log.Error(err, "failed to patch Gateway")
+		return err
+	}
+
+	log.Info("Gateway reconciled", "operation", result)
+	return nil
+}
+
+// createOrPatchTCPVirtualService routes the traffic on the port of the tcp route to its destinations
+func (r *CFRouteReconciler) createOrPatchTCPVirtualService(ctx context.Context, log logr.Logger, cfRoute *korifiv1alpha1.CFRoute) error {
+	log = log.WithName("createOrPatchTCPVirtualService").WithValues("virtualServiceNamespace", cfRoute.Namespace, "virtualServiceName", cfRoute.Name)
+
+	destinations := []*v1alpha3.RouteDestination{}
+	for _, d := range cfRoute.Spec.Destinations {
+		destinations = append(destinations, &v1alpha3.RouteDestination{
+			Destination: &v1alpha3.Destination{
+				Host: destinationHost(cfRoute, d),
+				Port: &v1alpha3.PortSelector{Number: uint32(d.Port)},
+			},
+			Weight: int32(destinationWeight(d)),
+		})
+	}
+
+	virtualService := &networkingv1alpha3.VirtualService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfRoute.Name,
+			Namespace: cfRoute.Namespace,
+		},
+	}
+
+	result, err := controllerutil.CreateOrPatch(ctx, r.client, virtualService, func() error {
+		virtualService.Spec.Hosts = []string{"*"}
+		virtualService.Spec.Gateways = []string{cfRoute.Namespace + "/" + cfRoute.Name}
+		virtualService.Spec.Tcp = []*v1alpha3.TCPRoute{{
+			Match: []*v1alpha3.L4MatchAttributes{{Port: uint32(cfRoute.Spec.Port)}},
+			Route: destinations,
+		}}
+
+		return controllerutil.SetOwnerReference(cfRoute, virtualService, r.scheme)
+	})
+	if err != nil {
+		log.Error(err, "failed to patch VirtualService")
+		return err
+	}
+
+	log.Info("VirtualService reconciled", "operation", result)
+	return nil
+}
+
+func (r *CFRouteReconciler) createOrPatchRouteProxy(ctx context.Context, log logr.Logger, cfRoute *korifiv1alpha1.CFRoute, routeService *routeService) error {
 	log = log.WithName("createOrPatchRouteProxy").WithValues("httpProxyNamespace", cfRoute.Namespace, "httpProxyName", cfRoute.Name)
 
 	services := make([]contourv1.Service, 0, len(cfRoute.Spec.Destinations))
 
 	for i, destination := range cfRoute.Spec.Destinations {
 		services = append(services, contourv1.Service{
-			Name: generateServiceName(&cfRoute.Spec.Destinations[i]),
-			Port: destination.Port,
+			Name:     generateServiceName(&cfRoute.Spec.Destinations[i]),
+			Port:     destination.Port,
+			Protocol: upstreamProtocol(destination),
+			Weight:   destinationWeight(destination),
 		})
 	}
 
+	if routeService != nil {
+		if err := r.createOrPatchRouteServiceExternalName(ctx, log, cfRoute, routeService); err != nil {
+			return err
+		}
+	}
+
 	routeHTTPProxy := &contourv1.HTTPProxy{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      cfRoute.Name,
@@ -331,18 +673,20 @@ func (r *CFRouteReconciler) createOrPatchRouteProxy(ctx context.Context, log log
 	result, err := controllerutil.CreateOrPatch(ctx, r.client, routeHTTPProxy, func() error {
 		if len(services) == 0 {
 			routeHTTPProxy.Spec.Routes = []contourv1.Route{}
+		} else if routeService != nil {
+			routeHTTPProxy.Spec.Routes = routeServiceProxyRoutes(cfRoute, routeService, services)
 		} else {
 			routeHTTPProxy.Spec.Routes = []contourv1.Route{
 				{
-					Conditions: []contourv1.MatchCondition{
-						{Prefix: cfRoute.Spec.Path},
-					},
+					Conditions:       pathConditions(cfRoute),
 					Services:         services,
 					EnableWebsockets: true,
 				},
 			}
 		}
 
+		applyRouteOptions(cfRoute, routeHTTPProxy.Spec.Routes)
+
 		err := controllerutil.SetOwnerReference(cfRoute, routeHTTPProxy, r.scheme)
 		if err != nil {
 			log.Error(err, "failed to set OwnerRef on route HTTPProxy")
@@ -360,8 +704,18 @@ func (r *CFRouteReconciler) createOrPatchRouteProxy(ctx context.Context, log log
 	return nil
 }
 
+// pathConditions matches the requests for the path of the route. Routes without a path match every request
+// of their FQDN, as Contour rejects empty prefix conditions
+func pathConditions(cfRoute *korifiv1alpha1.CFRoute) []contourv1.MatchCondition {
+	if cfRoute.Spec.Path == "" {
+		return nil
+	}
+
+	return []contourv1.MatchCondition{{Prefix: cfRoute.Spec.Path}}
+}
+
 func (r *CFRouteReconciler) createOrPatchFQDNProxy(ctx context.Context, log logr.Logger, cfRoute *korifiv1alpha1.CFRoute, cfDomain *korifiv1alpha1.CFDomain) error {
-	fqdn := strings.ToLower(fmt.Sprintf("%s.%s", cfRoute.Spec.Host, cfDomain.Spec.Name))
+	fqdn := strings.ToLower(routeFQDN(cfRoute, cfDomain))
 
 	log = log.WithName("createOrPatchFQDNProxy").WithValues("fqdn", fqdn)
 
@@ -373,7 +727,7 @@ func (r *CFRouteReconciler) createOrPatchFQDNProxy(ctx context.Context, log logr
 	if !foundFQDNProxy {
 		fqdnHTTPProxy = &contourv1.HTTPProxy{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:      fqdn,
+				Name:      fqdnProxyName(fqdn, cfDomain),
 				Namespace: cfRoute.Namespace,
 			},
 		}
@@ -384,7 +738,7 @@ func (r *CFRouteReconciler) createOrPatchFQDNProxy(ctx context.Context, log logr
 			Fqdn: fqdn,
 		}
 
-		if tlsSecret := r.controllerConfig.WorkloadsTLSSecretNameWithNamespace(); tlsSecret != "" {
+		if tlsSecret := r.tlsSecretName(cfDomain); tlsSecret != "" {
 			fqdnHTTPProxy.Spec.VirtualHost.TLS = &contourv1.TLS{SecretName: tlsSecret}
 		}
 
@@ -419,6 +773,16 @@ func (r *CFRouteReconciler) createOrPatchFQDNProxy(ctx context.Context, log logr
 	return nil
 }
 
+// fqdnProxyName returns the name of the FQDN HTTPProxy. Wildcard FQDNs are not valid resource names, so
+// their proxy is named after the domain instead, which cannot clash with the dotted names of the others
+func fqdnProxyName(fqdn string, cfDomain *korifiv1alpha1.CFDomain) string {
+	if strings.HasPrefix(fqdn, korifiv1alpha1.WildcardHost+".") {
+		return "wildcard-" + cfDomain.Name
+	}
+
+	return fqdn
+}
+
 func (r *CFRouteReconciler) getFQDNProxy(ctx context.Context, log logr.Logger, fqdn, namespace string, checkAllNamespaces bool) (*contourv1.HTTPProxy, bool, error) {
 	log = log.WithName("getFQDNProxy")
 
@@ -464,7 +828,8 @@ func (r *CFRouteReconciler) deleteOrphanedServices(ctx context.Context, log logr
 		korifiv1alpha1.CFRouteGUIDLabelKey: cfRoute.Name,
 	}
 
-	serviceList, err := r.fetchServicesByMatchingLabels(ctx, log, matchingLabelSet, cfRoute.Namespace)
+	// destination services live in the namespaces of the destination apps, which may be shared spaces
+	serviceList, err := r.fetchServicesByMatchingLabels(ctx, log, matchingLabelSet, "")
 	if err != nil {
 		log.Error(err, "Failed to fetch services using label", "label", korifiv1alpha1.CFRouteGUIDLabelKey, "value", cfRoute.Name)
 		return err
@@ -475,7 +840,9 @@ func (r *CFRouteReconciler) deleteOrphanedServices(ctx context.Context, log logr
 
 		isOrphan := true
 		for j := range cfRoute.Spec.Destinations {
-			if service.Name == generateServiceName(&cfRoute.Spec.Destinations[j]) {
+			// the service of a destination in a shared space may be aliased in the route namespace
+			if service.Name == generateServiceName(&cfRoute.Spec.Destinations[j]) &&
+				(service.Namespace == cfRoute.DestinationNamespace(cfRoute.Spec.Destinations[j]) || service.Namespace == cfRoute.Namespace) {
 				isOrphan = false
 				break
 			}
@@ -509,6 +876,48 @@ func (r *CFRouteReconciler) fetchServicesByMatchingLabels(ctx context.Context, l
 	return &serviceList, nil
 }
 
+// upstreamProtocol returns the protocol Contour uses to reach the destination. Destinations speaking
+// http2 (e.g. gRPC apps) are reached over cleartext http2, as apps do not terminate TLS
+func upstreamProtocol(destination korifiv1alpha1.Destination) *string {
+	if destination.Protocol == korifiv1alpha1.HTTP2DestinationProtocol {
+		return tools.PtrTo("h2c")
+	}
+
+	return nil
+}
+
+// serviceAppProtocol tells the ingress which protocol the destination speaks, so that it proxies
+// http2 destinations over http2
+func serviceAppProtocol(destination korifiv1alpha1.Destination) *string {
+	if destination.Protocol == korifiv1alpha1.HTTP2DestinationProtocol {
+		return tools.PtrTo(korifiv1alpha1.HTTP2DestinationProtocol)
+	}
+
+	return nil
+}
+
+// destinationWeight returns the share of the route traffic the destination receives. Unweighted
+// destinations are rendered with a zero weight, which makes the ingress split the traffic evenly
+func destinationWeight(destination korifiv1alpha1.Destination) int64 {
+	if destination.Weight == nil {
+		return 0
+	}
+
+	return int64(*destination.Weight)
+}
+
+// destinationHost returns the host the ingress uses to reach the destination service. Services in other
+// namespaces than the route's are addressed by their fully qualified name
+func destinationHost(cfRoute *korifiv1alpha1.CFRoute, destination korifiv1alpha1.Destination) string {
+	serviceName := generateServiceName(&destination)
+	namespace := cfRoute.DestinationNamespace(destination)
+	if namespace == cfRoute.Namespace {
+		return serviceName
+	}
+
+	return destinationServiceFQDN(cfRoute, destination)
+}
+
 func generateServiceName(destination *korifiv1alpha1.Destination) string {
 	return fmt.Sprintf("s-%s", destination.GUID)
 }
@@ -4,25 +4,36 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/config"
 	. "code.cloudfoundry.org/korifi/controllers/controllers/workloads/testutils"
+	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
 	contourv1 "github.com/projectcontour/contour/apis/projectcontour/v1"
+	"istio.io/api/networking/v1alpha3"
+	networkingv1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 )
 
 var _ = Describe("CFRouteReconciler Integration Tests", func() {
 	var (
-		ctx           context.Context
-		testRouteHost string
+		ctx            context.Context
+		ingressBackend string
+		testRouteHost  string
 
 		testNamespace  string
 		testDomainGUID string
@@ -38,6 +49,7 @@ var _ = Describe("CFRouteReconciler Integration Tests", func() {
 
 	BeforeEach(func() {
 		ctx = context.Background()
+		ingressBackend = config.IstioIngressBackend
 
 		testRouteHost = "test-route-host"
 
@@ -100,57 +112,372 @@ var _ = Describe("CFRouteReconciler Integration Tests", func() {
 	})
 
 	JustBeforeEach(func() {
+		startCFRouteReconciler(ingressBackend)
 		Expect(k8sClient.Create(ctx, cfRoute)).To(Succeed())
 	})
 
-	It("reconciles the CFRoute to a root Contour HTTPProxy which includes a proxy for a route destination", func() {
-		Eventually(func(g Gomega) {
-			var proxy contourv1.HTTPProxy
-			g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testFQDN, Namespace: testNamespace}, &proxy)).To(Succeed())
+	getVirtualService := func(g Gomega) *networkingv1alpha3.VirtualService {
+		virtualService := new(networkingv1alpha3.VirtualService)
+		g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testRouteGUID, Namespace: testNamespace}, virtualService)).To(Succeed())
+		return virtualService
+	}
 
-			g.Expect(proxy.Spec.VirtualHost.Fqdn).To(Equal(testFQDN))
-			g.Expect(proxy.Spec.VirtualHost.TLS.SecretName).To(Equal("korifi-controllers-system/korifi-workloads-ingress-cert"))
-			g.Expect(proxy.Spec.Includes).To(ConsistOf(contourv1.Include{
-				Name:      testRouteGUID,
+	// bindRouteService binds the route to a ready route service, as the CFServiceRouteBinding controller would
+	bindRouteService := func(routeServiceURL string) {
+		signatureSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      GenerateGUID(),
+				Namespace: testNamespace,
+			},
+			Data: map[string][]byte{
+				korifiv1alpha1.RouteServiceSignatureSecretKey: []byte("the-signature"),
+			},
+		}
+		Expect(k8sClient.Create(ctx, signatureSecret)).To(Succeed())
+
+		routeBinding := &korifiv1alpha1.CFServiceRouteBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      GenerateGUID(),
 				Namespace: testNamespace,
-			}))
-
-			g.Expect(proxy.ObjectMeta.OwnerReferences).To(ConsistOf(metav1.OwnerReference{
-				APIVersion: "korifi.cloudfoundry.org/v1alpha1",
-				Kind:       "CFRoute",
-				Name:       cfRoute.Name,
-				UID:        cfRoute.GetUID(),
-			}))
+			},
+			Spec: korifiv1alpha1.CFServiceRouteBindingSpec{
+				Service:  corev1.ObjectReference{Name: "route-service-instance-guid"},
+				RouteRef: corev1.LocalObjectReference{Name: testRouteGUID},
+			},
+		}
+		Expect(k8sClient.Create(ctx, routeBinding)).To(Succeed())
+
+		Expect(k8s.Patch(ctx, k8sClient, routeBinding, func() {
+			routeBinding.Status.RouteServiceURL = routeServiceURL
+			routeBinding.Status.SignatureSecretName = signatureSecret.Name
+			meta.SetStatusCondition(&routeBinding.Status.Conditions, metav1.Condition{
+				Type:   korifiv1alpha1.ReadyConditionType,
+				Status: metav1.ConditionTrue,
+				Reason: "Ready",
+			})
+		})).To(Succeed())
+	}
+
+	It("reconciles the CFRoute to a VirtualService for its FQDN on the workloads gateway", func() {
+		Eventually(func(g Gomega) {
+			virtualService := getVirtualService(g)
+			g.Expect(virtualService.Spec.Hosts).To(ConsistOf(testFQDN))
+			g.Expect(virtualService.Spec.Gateways).To(ConsistOf("korifi/korifi-workloads-gateway"))
+			g.Expect(virtualService.Spec.Http).To(HaveLen(1))
+			g.Expect(virtualService.Spec.Http[0].Route).To(BeEmpty())
 		}).Should(Succeed())
 	})
 
-	It("reconciles the CFRoute to a child proxy with no routes", func() {
+	It("marks the CFRoute as valid", func() {
 		Eventually(func(g Gomega) {
-			var proxy contourv1.HTTPProxy
-			g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testRouteGUID, Namespace: testNamespace}, &proxy)).To(Succeed())
-			g.Expect(proxy.Spec.VirtualHost).To(BeNil())
-			g.Expect(proxy.Spec.Routes).To(BeEmpty())
-			g.Expect(proxy.ObjectMeta.OwnerReferences).To(ConsistOf(metav1.OwnerReference{
-				APIVersion: "korifi.cloudfoundry.org/v1alpha1",
-				Kind:       "CFRoute",
-				Name:       cfRoute.Name,
-				UID:        cfRoute.GetUID(),
-			}))
+			var route korifiv1alpha1.CFRoute
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfRoute), &route)).To(Succeed())
+			g.Expect(route.Status.CurrentStatus).To(Equal(korifiv1alpha1.ValidStatus))
 		}).Should(Succeed())
 	})
 
+	When("the domain has its own certificate", func() {
+		BeforeEach(func() {
+			Expect(k8s.PatchResource(ctx, k8sClient, cfDomain, func() {
+				cfDomain.Spec.TLS = &korifiv1alpha1.CFDomainTLS{SecretName: "my-domain-tls"}
+			})).To(Succeed())
+		})
+
+		It("attaches the VirtualService to the gateway of the domain", func() {
+			Eventually(func(g Gomega) {
+				virtualService := getVirtualService(g)
+				g.Expect(virtualService.Spec.Gateways).To(ConsistOf(testNamespace + "/" + testDomainGUID))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the route is deleted", func() {
+		JustBeforeEach(func() {
+			Eventually(func(g Gomega) {
+				getVirtualService(g)
+			}).Should(Succeed())
+
+			Expect(k8sClient.Delete(ctx, cfRoute)).To(Succeed())
+		})
+
+		It("deletes the VirtualService", func() {
+			Eventually(func(g Gomega) {
+				err := k8sClient.Get(ctx, types.NamespacedName{Name: testRouteGUID, Namespace: testNamespace}, new(networkingv1alpha3.VirtualService))
+				g.Expect(errors.IsNotFound(err)).To(BeTrue())
+			}).Should(Succeed())
+		})
+	})
+
+	When("the CFRoute is a tcp route", func() {
+		BeforeEach(func() {
+			Expect(k8s.PatchResource(ctx, k8sClient, cfDomain, func() {
+				cfDomain.Spec.RouterGroup = "default-tcp"
+			})).To(Succeed())
+
+			cfRoute.Spec.Host = ""
+			cfRoute.Spec.Path = ""
+			cfRoute.Spec.Protocol = korifiv1alpha1.TCPProtocol
+			cfRoute.Spec.Port = 1024
+			cfRoute.Spec.Destinations = []korifiv1alpha1.Destination{{
+				GUID:        "destination-guid",
+				Port:        5432,
+				AppRef:      corev1.LocalObjectReference{Name: "the-app-guid"},
+				ProcessType: "web",
+				Protocol:    "tcp",
+			}}
+		})
+
+		It("sets the route URI to the domain and port", func() {
+			Eventually(func(g Gomega) {
+				var route korifiv1alpha1.CFRoute
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfRoute), &route)).To(Succeed())
+				g.Expect(route.Status.URI).To(Equal(testDomainName + ":1024"))
+			}).Should(Succeed())
+		})
+
+		It("creates a Gateway listening on the port of the route", func() {
+			Eventually(func(g Gomega) {
+				gateway := new(networkingv1alpha3.Gateway)
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testRouteGUID, Namespace: testNamespace}, gateway)).To(Succeed())
+				g.Expect(gateway.Spec.Selector).To(Equal(map[string]string{"istio": "ingressgateway"}))
+				g.Expect(gateway.Spec.Servers).To(HaveLen(1))
+				g.Expect(gateway.Spec.Servers[0].Port.Number).To(BeEquivalentTo(1024))
+				g.Expect(gateway.Spec.Servers[0].Port.Protocol).To(Equal("TCP"))
+			}).Should(Succeed())
+		})
+
+		It("routes the traffic on the port of the route to the destination Service", func() {
+			Eventually(func(g Gomega) {
+				virtualService := getVirtualService(g)
+				g.Expect(virtualService.Spec.Gateways).To(ConsistOf(testNamespace + "/" + testRouteGUID))
+				g.Expect(virtualService.Spec.Http).To(BeEmpty())
+				g.Expect(virtualService.Spec.Tcp).To(HaveLen(1))
+				g.Expect(virtualService.Spec.Tcp[0].Match).To(HaveLen(1))
+				g.Expect(virtualService.Spec.Tcp[0].Match[0].Port).To(BeEquivalentTo(1024))
+				g.Expect(virtualService.Spec.Tcp[0].Route).To(HaveLen(1))
+				g.Expect(virtualService.Spec.Tcp[0].Route[0].Destination.Host).To(Equal("s-destination-guid"))
+				g.Expect(virtualService.Spec.Tcp[0].Route[0].Destination.Port.Number).To(BeEquivalentTo(5432))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the CFRoute is on an internal domain", func() {
+		var internalServiceName string
+
+		createDestinationEndpointSlice := func(destinationGUID, appGUID, address string) *discoveryv1.EndpointSlice {
+			endpointSlice := &discoveryv1.EndpointSlice{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "s-" + destinationGUID + "-" + GenerateGUID()[:8],
+					Namespace: testNamespace,
+					Labels: map[string]string{
+						discoveryv1.LabelServiceName:       "s-" + destinationGUID,
+						korifiv1alpha1.CFAppGUIDLabelKey:   appGUID,
+						korifiv1alpha1.CFRouteGUIDLabelKey: testRouteGUID,
+					},
+				},
+				AddressType: discoveryv1.AddressTypeIPv4,
+				Endpoints: []discoveryv1.Endpoint{{
+					Addresses:  []string{address},
+					Conditions: discoveryv1.EndpointConditions{Ready: tools.PtrTo(true)},
+				}},
+			}
+			Expect(k8sClient.Create(ctx, endpointSlice)).To(Succeed())
+			return endpointSlice
+		}
+
+		getInternalEndpointSlices := func(g Gomega) []discoveryv1.EndpointSlice {
+			endpointSlices := new(discoveryv1.EndpointSliceList)
+			g.Expect(k8sClient.List(ctx, endpointSlices,
+				client.InNamespace(testNamespace),
+				client.MatchingLabels{discoveryv1.LabelServiceName: internalServiceName},
+			)).To(Succeed())
+			return endpointSlices.Items
+		}
+
+		BeforeEach(func() {
+			Expect(k8s.PatchResource(ctx, k8sClient, cfDomain, func() {
+				cfDomain.Spec.Internal = true
+			})).To(Succeed())
+
+			cfRoute.Spec.Path = ""
+			cfRoute.Spec.Destinations = []korifiv1alpha1.Destination{
+				{
+					GUID:        "destination-guid",
+					Port:        8080,
+					AppRef:      corev1.LocalObjectReference{Name: "the-app-guid"},
+					ProcessType: "web",
+					Protocol:    "http1",
+				},
+				{
+					GUID:        "other-destination-guid",
+					Port:        9000,
+					AppRef:      corev1.LocalObjectReference{Name: "the-other-app-guid"},
+					ProcessType: "web",
+					Protocol:    "http1",
+				},
+			}
+			internalServiceName = testRouteHost + "-" + strings.ReplaceAll(testDomainName, ".", "-")
+
+			createDestinationEndpointSlice("destination-guid", "the-app-guid", "10.0.0.1")
+			createDestinationEndpointSlice("other-destination-guid", "the-other-app-guid", "10.0.0.2")
+		})
+
+		It("creates a headless Service in the domain namespace", func() {
+			Eventually(func(g Gomega) {
+				var svc corev1.Service
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: internalServiceName, Namespace: testNamespace}, &svc)).To(Succeed())
+				g.Expect(svc.Spec.Type).To(Equal(corev1.ServiceTypeClusterIP))
+				g.Expect(svc.Spec.ClusterIP).To(Equal(corev1.ClusterIPNone))
+				g.Expect(svc.Spec.Selector).To(BeEmpty())
+				g.Expect(svc.Labels).To(HaveKeyWithValue(korifiv1alpha1.CFInternalRouteLabelKey, cfRoute.Name))
+				g.Expect(svc.Labels).NotTo(HaveKey(korifiv1alpha1.CFRouteGUIDLabelKey))
+			}).Should(Succeed())
+		})
+
+		It("mirrors the endpoints of all destinations into EndpointSlices of the Service", func() {
+			Eventually(func(g Gomega) {
+				endpointSlices := getInternalEndpointSlices(g)
+				g.Expect(endpointSlices).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{
+						"ObjectMeta": MatchFields(IgnoreExtras, Fields{
+							"Labels":          HaveKeyWithValue(discoveryv1.LabelManagedBy, "korifi.cloudfoundry.org"),
+							"OwnerReferences": ConsistOf(HaveField("Name", internalServiceName)),
+						}),
+						"AddressType": Equal(discoveryv1.AddressTypeIPv4),
+						"Endpoints":   ConsistOf(HaveField("Addresses", ConsistOf("10.0.0.1"))),
+						"Ports":       ConsistOf(HaveField("Port", PointTo(BeEquivalentTo(8080)))),
+					}),
+					MatchFields(IgnoreExtras, Fields{
+						"Endpoints": ConsistOf(HaveField("Addresses", ConsistOf("10.0.0.2"))),
+						"Ports":     ConsistOf(HaveField("Port", PointTo(BeEquivalentTo(9000)))),
+					}),
+				))
+			}).Should(Succeed())
+		})
+
+		It("keeps the Service when deleting orphaned destination services", func() {
+			Eventually(func(g Gomega) {
+				var route korifiv1alpha1.CFRoute
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfRoute), &route)).To(Succeed())
+				g.Expect(route.Status.CurrentStatus).To(Equal(korifiv1alpha1.ValidStatus))
+			}).Should(Succeed())
+
+			Consistently(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: internalServiceName, Namespace: testNamespace}, &corev1.Service{})).To(Succeed())
+			}, "1s").Should(Succeed())
+		})
+
+		It("creates the destination Services", func() {
+			Eventually(func(g Gomega) {
+				var svc corev1.Service
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "s-destination-guid", Namespace: testNamespace}, &svc)).To(Succeed())
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "s-other-destination-guid", Namespace: testNamespace}, &svc)).To(Succeed())
+			}).Should(Succeed())
+		})
+
+		It("does not expose the route through the ingress", func() {
+			Eventually(func(g Gomega) {
+				var route korifiv1alpha1.CFRoute
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfRoute), &route)).To(Succeed())
+				g.Expect(route.Status.CurrentStatus).To(Equal(korifiv1alpha1.ValidStatus))
+			}).Should(Succeed())
+
+			Consistently(func(g Gomega) {
+				err := k8sClient.Get(ctx, types.NamespacedName{Name: testRouteGUID, Namespace: testNamespace}, &networkingv1alpha3.VirtualService{})
+				g.Expect(errors.IsNotFound(err)).To(BeTrue())
+			}, "1s").Should(Succeed())
+		})
+
+		When("the endpoints of a destination change", func() {
+			JustBeforeEach(func() {
+				Eventually(func(g Gomega) {
+					g.Expect(getInternalEndpointSlices(g)).To(HaveLen(2))
+				}).Should(Succeed())
+
+				createDestinationEndpointSlice("destination-guid", "the-app-guid", "10.0.0.3")
+			})
+
+			It("updates the mirrored endpoints", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(getInternalEndpointSlices(g)).To(ContainElement(
+						HaveField("Endpoints", ConsistOf(
+							HaveField("Addresses", ConsistOf("10.0.0.1")),
+							HaveField("Addresses", ConsistOf("10.0.0.3")),
+						)),
+					))
+				}).Should(Succeed())
+			})
+		})
+
+		When("a destination is removed", func() {
+			JustBeforeEach(func() {
+				Eventually(func(g Gomega) {
+					g.Expect(getInternalEndpointSlices(g)).To(HaveLen(2))
+				}).Should(Succeed())
+
+				Expect(k8s.PatchResource(ctx, k8sClient, cfRoute, func() {
+					cfRoute.Spec.Destinations = cfRoute.Spec.Destinations[:1]
+				})).To(Succeed())
+			})
+
+			It("deletes the EndpointSlice of the destination", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(getInternalEndpointSlices(g)).To(ConsistOf(
+						HaveField("Endpoints", ConsistOf(HaveField("Addresses", ConsistOf("10.0.0.1")))),
+					))
+				}).Should(Succeed())
+			})
+		})
+
+		When("the route is deleted", func() {
+			JustBeforeEach(func() {
+				Eventually(func() error {
+					return k8sClient.Get(ctx, types.NamespacedName{Name: internalServiceName, Namespace: testNamespace}, &corev1.Service{})
+				}).Should(Succeed())
+
+				Expect(k8sClient.Delete(ctx, cfRoute)).To(Succeed())
+			})
+
+			It("deletes the headless Service", func() {
+				Eventually(func(g Gomega) {
+					err := k8sClient.Get(ctx, types.NamespacedName{Name: internalServiceName, Namespace: testNamespace}, &corev1.Service{})
+					g.Expect(errors.IsNotFound(err)).To(BeTrue())
+				}).Should(Succeed())
+			})
+		})
+	})
+
 	When("the route Host contains upper case characters", func() {
 		BeforeEach(func() {
 			testRouteHost = "My-App"
+			cfRoute.Spec.Host = testRouteHost
 			testFQDN = strings.ToLower(fmt.Sprintf("%s.%s", testRouteHost, testDomainName))
 		})
 
-		It("reconciles the CFRoute to a root Contour HTTPProxy which includes a proxy for a route destination", func() {
+		It("reconciles the CFRoute to a VirtualService for the lower case FQDN", func() {
 			Eventually(func(g Gomega) {
-				var proxy contourv1.HTTPProxy
-				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testFQDN, Namespace: testNamespace}, &proxy)).To(Succeed())
-				g.Expect(proxy.Spec.VirtualHost.Fqdn).To(Equal(testFQDN))
-			})
+				g.Expect(getVirtualService(g).Spec.Hosts).To(ConsistOf(testFQDN))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the route has a wildcard host", func() {
+		BeforeEach(func() {
+			cfRoute.Spec.Host = "*"
+		})
+
+		It("reconciles the CFRoute to a VirtualService for the wildcard FQDN", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(getVirtualService(g).Spec.Hosts).To(ConsistOf("*." + testDomainName))
+			}).Should(Succeed())
+		})
+
+		It("sets the wildcard FQDN in the status", func() {
+			Eventually(func(g Gomega) {
+				var route korifiv1alpha1.CFRoute
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfRoute), &route)).To(Succeed())
+				g.Expect(route.Status.FQDN).To(Equal("*." + testDomainName))
+			}).Should(Succeed())
 		})
 	})
 
@@ -172,35 +499,16 @@ var _ = Describe("CFRouteReconciler Integration Tests", func() {
 			cfRoute.Spec.Destinations = destinations
 		})
 
-		It("reconciles the CFRoute to a root Contour HTTPProxy which includes a proxy for a route destination", func() {
-			Eventually(func(g Gomega) {
-				var proxy contourv1.HTTPProxy
-				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testFQDN, Namespace: testNamespace}, &proxy)).To(Succeed())
-				g.Expect(proxy.Spec.Includes).To(ConsistOf(contourv1.Include{
-					Name:      testRouteGUID,
-					Namespace: testNamespace,
-				}))
-			}).Should(Succeed())
-		})
-
-		It("reconciles the CFRoute to a child proxy with a route", func() {
+		It("routes the traffic of the VirtualService to the destination Service", func() {
 			Eventually(func(g Gomega) {
-				var proxy contourv1.HTTPProxy
-				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testRouteGUID, Namespace: testNamespace}, &proxy)).To(Succeed())
-				g.Expect(proxy.Spec.Routes).To(ConsistOf(contourv1.Route{
-					Conditions: []contourv1.MatchCondition{
-						{
-							Prefix: "/test/path",
-						},
-					},
-					Services: []contourv1.Service{
-						{
-							Name: fmt.Sprintf("s-%s", cfRoute.Spec.Destinations[0].GUID),
-							Port: cfRoute.Spec.Destinations[0].Port,
-						},
-					},
-					EnableWebsockets: true,
-				}))
+				virtualService := getVirtualService(g)
+				g.Expect(virtualService.Spec.Http).To(HaveLen(1))
+				g.Expect(virtualService.Spec.Http[0].Route).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Destination": PointTo(MatchFields(IgnoreExtras, Fields{
+						"Host": Equal(fmt.Sprintf("s-%s", destinations[0].GUID)),
+						"Port": PointTo(MatchFields(IgnoreExtras, Fields{"Number": BeEquivalentTo(80)})),
+					})),
+				}))))
 			}).Should(Succeed())
 		})
 
@@ -242,192 +550,324 @@ var _ = Describe("CFRouteReconciler Integration Tests", func() {
 				g.Expect(cfRoute.Status.Destinations).To(Equal(destinations))
 			}).Should(Succeed())
 		})
-	})
-
-	When("the FQDN of a CFRoute is not unique within a space", func() {
-		var (
-			duplicateRouteGUID string
-			duplicateRoute     *korifiv1alpha1.CFRoute
-		)
 
-		BeforeEach(func() {
-			duplicateRouteGUID = GenerateGUID()
-			duplicateRoute = &korifiv1alpha1.CFRoute{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      duplicateRouteGUID,
-					Namespace: testNamespace,
-				},
-				Spec: korifiv1alpha1.CFRouteSpec{
-					Host:     testRouteHost,
-					Path:     "/",
-					Protocol: "http",
-					DomainRef: corev1.ObjectReference{
-						Name:      testDomainGUID,
-						Namespace: testNamespace,
-					},
-					Destinations: []korifiv1alpha1.Destination{
-						{
-							GUID: GenerateGUID(),
-							AppRef: corev1.LocalObjectReference{
-								Name: "app-guid-2",
-							},
-							ProcessType: "web",
-							Port:        80,
-							Protocol:    "http1",
-						},
-					},
-				},
-			}
-			Expect(k8sClient.Create(ctx, duplicateRoute)).To(Succeed())
+		When("the destination protocol is http2", func() {
+			BeforeEach(func() {
+				cfRoute.Spec.Destinations[0].Protocol = "http2"
+			})
 
-			Eventually(func(g Gomega) {
-				var proxy contourv1.HTTPProxy
-				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testFQDN, Namespace: testNamespace}, &proxy)).To(Succeed())
-			}).Should(Succeed())
+			It("sets the app protocol on the destination Service", func() {
+				serviceName := fmt.Sprintf("s-%s", cfRoute.Spec.Destinations[0].GUID)
+				Eventually(func(g Gomega) {
+					var svc corev1.Service
+					g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: serviceName, Namespace: testNamespace}, &svc)).To(Succeed())
+					g.Expect(svc.Spec.Ports).To(HaveLen(1))
+					g.Expect(svc.Spec.Ports[0].AppProtocol).To(PointTo(Equal("http2")))
+				}).Should(Succeed())
+			})
 		})
 
-		It("reconciles the CFRoute to the existing Contour HTTPProxy with the matching FQDN", func() {
-			Eventually(func(g Gomega) {
-				var proxy contourv1.HTTPProxy
-				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testFQDN, Namespace: testNamespace}, &proxy)).To(Succeed())
-				g.Expect(proxy.Spec.Includes).To(ConsistOf([]contourv1.Include{
-					{
-						Name:      testRouteGUID,
-						Namespace: testNamespace,
-					},
-					{
-						Name:      duplicateRouteGUID,
-						Namespace: testNamespace,
-					},
-				}), "HTTPProxy includes mismatch")
-			}).Should(Succeed())
-		})
+		When("the destination app is in a shared space", func() {
+			var sharedNamespace string
 
-		It("reconciles the duplicate CFRoute to a child proxy with a route", func() {
-			Eventually(func(g Gomega) {
-				var proxy contourv1.HTTPProxy
-				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: duplicateRouteGUID, Namespace: testNamespace}, &proxy)).To(Succeed())
-				g.Expect(proxy.Spec.VirtualHost).To(BeNil())
-				g.Expect(proxy.Spec.Routes).To(ConsistOf(contourv1.Route{
-					Conditions: []contourv1.MatchCondition{
-						{
-							Prefix: "/",
-						},
-					},
-					Services: []contourv1.Service{
-						{
-							Name: fmt.Sprintf("s-%s", duplicateRoute.Spec.Destinations[0].GUID),
-							Port: duplicateRoute.Spec.Destinations[0].Port,
-						},
-					},
-					EnableWebsockets: true,
-				}))
-			}).Should(Succeed())
-		})
+			BeforeEach(func() {
+				sharedNamespace = GenerateGUID()
+				Expect(k8sClient.Create(ctx, &corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{Name: sharedNamespace},
+				})).To(Succeed())
 
-		When("the duplicate is deleted", func() {
-			JustBeforeEach(func() {
+				cfRoute.Spec.SharedSpaces = []string{sharedNamespace}
+				cfRoute.Spec.Destinations[0].Namespace = sharedNamespace
+			})
+
+			It("creates the destination Service in the namespace of the app, without an owner", func() {
+				serviceName := fmt.Sprintf("s-%s", cfRoute.Spec.Destinations[0].GUID)
 				Eventually(func(g Gomega) {
-					var proxy contourv1.HTTPProxy
-					g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testFQDN, Namespace: testNamespace}, &proxy)).To(Succeed())
-					g.Expect(proxy.Spec.Includes).To(HaveLen(2))
-				})
-				Expect(k8sClient.Delete(ctx, duplicateRoute)).To(Succeed())
+					var svc corev1.Service
+					g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: serviceName, Namespace: sharedNamespace}, &svc)).To(Succeed())
+					g.Expect(svc.Labels).To(HaveKeyWithValue("korifi.cloudfoundry.org/route-guid", cfRoute.Name))
+					g.Expect(svc.OwnerReferences).To(BeEmpty())
+				}).Should(Succeed())
 			})
 
-			It("removes it from the proxy includes list", func() {
+			It("routes the traffic to the fully qualified name of the destination Service", func() {
 				Eventually(func(g Gomega) {
-					var proxy contourv1.HTTPProxy
-					g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testFQDN, Namespace: testNamespace}, &proxy)).To(Succeed())
-					g.Expect(proxy.Spec.Includes).To(ConsistOf(contourv1.Include{
-						Name:      testRouteGUID,
-						Namespace: testNamespace,
-					}))
+					virtualService := getVirtualService(g)
+					g.Expect(virtualService.Spec.Http).To(HaveLen(1))
+					g.Expect(virtualService.Spec.Http[0].Route).To(HaveLen(1))
+					g.Expect(virtualService.Spec.Http[0].Route[0].Destination.Host).To(Equal(
+						fmt.Sprintf("s-%s.%s.svc.cluster.local", cfRoute.Spec.Destinations[0].GUID, sharedNamespace),
+					))
 				}).Should(Succeed())
 			})
+
+			When("the route is deleted", func() {
+				JustBeforeEach(func() {
+					serviceName := fmt.Sprintf("s-%s", cfRoute.Spec.Destinations[0].GUID)
+					Eventually(func() error {
+						return k8sClient.Get(ctx, types.NamespacedName{Name: serviceName, Namespace: sharedNamespace}, &corev1.Service{})
+					}).Should(Succeed())
+
+					Expect(k8sClient.Delete(ctx, cfRoute)).To(Succeed())
+				})
+
+				It("deletes the destination Service", func() {
+					serviceName := fmt.Sprintf("s-%s", cfRoute.Spec.Destinations[0].GUID)
+					Eventually(func(g Gomega) {
+						err := k8sClient.Get(ctx, types.NamespacedName{Name: serviceName, Namespace: sharedNamespace}, &corev1.Service{})
+						g.Expect(errors.IsNotFound(err)).To(BeTrue())
+					}).Should(Succeed())
+				})
+			})
 		})
-	})
 
-	When("a destination is added to a CFRoute", func() {
-		BeforeEach(func() {
-			cfRoute.Spec.Destinations = []korifiv1alpha1.Destination{
-				{
-					GUID: GenerateGUID(),
-					AppRef: corev1.LocalObjectReference{
-						Name: "the-app-guid",
+		When("the destinations are weighted", func() {
+			BeforeEach(func() {
+				cfRoute.Spec.Destinations = []korifiv1alpha1.Destination{
+					{
+						GUID:        GenerateGUID(),
+						AppRef:      corev1.LocalObjectReference{Name: "blue-app-guid"},
+						ProcessType: "web",
+						Port:        80,
+						Protocol:    "http1",
+						Weight:      tools.PtrTo(90),
 					},
-					ProcessType: "web",
-					Port:        80,
-					Protocol:    "http1",
-				},
-			}
+					{
+						GUID:        GenerateGUID(),
+						AppRef:      corev1.LocalObjectReference{Name: "green-app-guid"},
+						ProcessType: "web",
+						Port:        80,
+						Protocol:    "http1",
+						Weight:      tools.PtrTo(10),
+					},
+				}
+			})
+
+			It("splits the traffic between the destinations by weight", func() {
+				Eventually(func(g Gomega) {
+					virtualService := getVirtualService(g)
+					g.Expect(virtualService.Spec.Http).To(HaveLen(1))
+					g.Expect(virtualService.Spec.Http[0].Route).To(ConsistOf(
+						PointTo(MatchFields(IgnoreExtras, Fields{
+							"Destination": PointTo(MatchFields(IgnoreExtras, Fields{"Host": Equal(fmt.Sprintf("s-%s", cfRoute.Spec.Destinations[0].GUID))})),
+							"Weight":      BeEquivalentTo(90),
+						})),
+						PointTo(MatchFields(IgnoreExtras, Fields{
+							"Destination": PointTo(MatchFields(IgnoreExtras, Fields{"Host": Equal(fmt.Sprintf("s-%s", cfRoute.Spec.Destinations[1].GUID))})),
+							"Weight":      BeEquivalentTo(10),
+						})),
+					))
+				}).Should(Succeed())
+			})
+		})
+	})
+
+	When("the route is bound to a route service", func() {
+		BeforeEach(func() {
+			cfRoute.Spec.Destinations = []korifiv1alpha1.Destination{{
+				GUID:        "destination-guid",
+				AppRef:      corev1.LocalObjectReference{Name: "the-app-guid"},
+				ProcessType: "web",
+				Port:        8080,
+				Protocol:    "http1",
+			}}
 		})
 
 		JustBeforeEach(func() {
+			bindRouteService("https://route-service.example.com/filter")
+		})
+
+		It("routes signed requests to the destinations and forwards everything else to the route service", func() {
 			Eventually(func(g Gomega) {
-				var proxy contourv1.HTTPProxy
-				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testFQDN, Namespace: testNamespace}, &proxy)).To(Succeed())
+				virtualService := getVirtualService(g)
+				g.Expect(virtualService.Spec.Http).To(HaveLen(2))
+
+				signedRoute := virtualService.Spec.Http[0]
+				g.Expect(signedRoute.Match).To(HaveLen(1))
+				g.Expect(signedRoute.Match[0].Headers).To(HaveKeyWithValue(
+					"x-cf-proxy-signature",
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"MatchType": PointTo(MatchFields(IgnoreExtras, Fields{"Exact": Equal("the-signature")})),
+					})),
+				))
+				g.Expect(signedRoute.Route).To(HaveLen(1))
+				g.Expect(signedRoute.Route[0].Destination.Host).To(Equal("s-destination-guid"))
+				g.Expect(signedRoute.Headers.Request.Remove).To(ConsistOf("X-CF-Proxy-Signature"))
+
+				forwardedRoute := virtualService.Spec.Http[1]
+				g.Expect(forwardedRoute.Match).To(BeEmpty())
+				g.Expect(forwardedRoute.Route).To(HaveLen(1))
+				g.Expect(forwardedRoute.Route[0].Destination.Host).To(Equal("route-service.example.com"))
+				g.Expect(forwardedRoute.Route[0].Destination.Port.Number).To(BeEquivalentTo(443))
+				g.Expect(forwardedRoute.Rewrite.Authority).To(Equal("route-service.example.com"))
+				g.Expect(forwardedRoute.Rewrite.Uri).To(Equal("/filter"))
+				g.Expect(forwardedRoute.Headers.Request.Set).To(SatisfyAll(
+					HaveKeyWithValue("X-CF-Forwarded-Url", "%REQ(x-forwarded-proto)%://%REQ(:authority)%%REQ(:path)%"),
+					HaveKeyWithValue("X-CF-Proxy-Signature", "the-signature"),
+				))
 			}).Should(Succeed())
+		})
 
-			Expect(k8s.Patch(ctx, k8sClient, cfRoute, func() {
-				cfRoute.Spec.Destinations = append(cfRoute.Spec.Destinations, korifiv1alpha1.Destination{
-					GUID: GenerateGUID(),
-					AppRef: corev1.LocalObjectReference{
-						Name: "app-guid-2",
-					},
-					ProcessType: "web",
-					Port:        8080,
-					Protocol:    "http1",
-				})
-			})).To(Succeed())
+		It("makes the route service reachable over TLS from the mesh", func() {
+			Eventually(func(g Gomega) {
+				var serviceEntry networkingv1alpha3.ServiceEntry
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "rs-" + testRouteGUID, Namespace: testNamespace}, &serviceEntry)).To(Succeed())
+				g.Expect(serviceEntry.Spec.Hosts).To(ConsistOf("route-service.example.com"))
+
+				var destinationRule networkingv1alpha3.DestinationRule
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "rs-" + testRouteGUID, Namespace: testNamespace}, &destinationRule)).To(Succeed())
+				g.Expect(destinationRule.Spec.Host).To(Equal("route-service.example.com"))
+				g.Expect(destinationRule.Spec.TrafficPolicy.Tls.Sni).To(Equal("route-service.example.com"))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the route has options", func() {
+		var destinationGUID string
+
+		BeforeEach(func() {
+			destinationGUID = GenerateGUID()
+			cfRoute.Spec.Destinations = []korifiv1alpha1.Destination{{
+				GUID:        destinationGUID,
+				AppRef:      corev1.LocalObjectReference{Name: "the-app-guid"},
+				ProcessType: "web",
+				Port:        8080,
+				Protocol:    "http1",
+			}}
+			cfRoute.Spec.Options = map[string]string{
+				"loadbalancing":   "least-connection",
+				"request_timeout": "30s",
+				"idle_timeout":    "5m",
+			}
 		})
 
-		It("reconciles the CFRoute to a child proxy with a route", func() {
+		It("sets the request timeout on the VirtualService", func() {
 			Eventually(func(g Gomega) {
-				var proxy contourv1.HTTPProxy
-				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testRouteGUID, Namespace: testNamespace}, &proxy)).To(Succeed())
-				g.Expect(proxy.Spec.Routes).To(ConsistOf([]contourv1.Route{
-					{
-						Conditions: []contourv1.MatchCondition{{Prefix: "/test/path"}},
-						Services: []contourv1.Service{
-							{
-								Name: fmt.Sprintf("s-%s", cfRoute.Spec.Destinations[0].GUID),
-								Port: cfRoute.Spec.Destinations[0].Port,
-							},
-							{
-								Name: fmt.Sprintf("s-%s", cfRoute.Spec.Destinations[1].GUID),
-								Port: cfRoute.Spec.Destinations[1].Port,
-							},
-						},
-						EnableWebsockets: true,
-					},
-				}))
+				virtualService := getVirtualService(g)
+				g.Expect(virtualService.Spec.Http).To(HaveLen(1))
+				g.Expect(virtualService.Spec.Http[0].Timeout.AsDuration()).To(Equal(30 * time.Second))
+			}).Should(Succeed())
+		})
+
+		It("applies the load balancing and idle timeout to the destination service", func() {
+			Eventually(func(g Gomega) {
+				var destinationRule networkingv1alpha3.DestinationRule
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "s-" + destinationGUID, Namespace: testNamespace}, &destinationRule)).To(Succeed())
+				g.Expect(destinationRule.Spec.Host).To(Equal("s-" + destinationGUID + "." + testNamespace + ".svc.cluster.local"))
+				g.Expect(destinationRule.Spec.TrafficPolicy.LoadBalancer.GetSimple()).To(Equal(v1alpha3.LoadBalancerSettings_LEAST_REQUEST))
+				g.Expect(destinationRule.Spec.TrafficPolicy.ConnectionPool.Http.IdleTimeout.AsDuration()).To(Equal(5 * time.Minute))
+				g.Expect(destinationRule.OwnerReferences).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"Kind": Equal("Service"),
+					"Name": Equal("s-" + destinationGUID),
+				})))
 			}).Should(Succeed())
 		})
 
-		It("reconciles the new destination to a Service for the app", func() {
-			serviceName := fmt.Sprintf("s-%s", cfRoute.Spec.Destinations[1].GUID)
+		When("the route asks for a rate limit", func() {
+			BeforeEach(func() {
+				cfRoute.Spec.Options["rate_limit"] = "100/minute"
+			})
+
+			It("marks the route as unsupported by the ingress backend", func() {
+				Eventually(func(g Gomega) {
+					var route korifiv1alpha1.CFRoute
+					g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfRoute), &route)).To(Succeed())
+
+					validCondition := meta.FindStatusCondition(route.Status.Conditions, "Valid")
+					g.Expect(validCondition).NotTo(BeNil())
+					g.Expect(validCondition.Status).To(Equal(metav1.ConditionFalse))
+					g.Expect(validCondition.Reason).To(Equal("UnsupportedByIngressBackend"))
+				}).Should(Succeed())
+
+				Consistently(func(g Gomega) {
+					err := k8sClient.Get(ctx, types.NamespacedName{Name: testRouteGUID, Namespace: testNamespace}, new(networkingv1alpha3.VirtualService))
+					g.Expect(errors.IsNotFound(err)).To(BeTrue())
+				}).Should(Succeed())
+			})
+		})
+	})
+
+	When("the route is transferred to another namespace", func() {
+		var (
+			targetNamespace  string
+			serviceName      string
+			transferredRoute *korifiv1alpha1.CFRoute
+		)
+
+		BeforeEach(func() {
+			targetNamespace = GenerateGUID()
+			Expect(k8sClient.Create(ctx, &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: targetNamespace},
+			})).To(Succeed())
+
+			cfRoute.Spec.Destinations = []korifiv1alpha1.Destination{{
+				GUID:        GenerateGUID(),
+				AppRef:      corev1.LocalObjectReference{Name: "the-app-guid"},
+				ProcessType: "web",
+				Port:        8080,
+				Protocol:    "http1",
+			}}
+			serviceName = "s-" + cfRoute.Spec.Destinations[0].GUID
+		})
 
+		JustBeforeEach(func() {
 			Eventually(func(g Gomega) {
-				var svc corev1.Service
-				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: serviceName, Namespace: testNamespace}, &svc)).To(Succeed())
-				g.Expect(svc.Labels).To(SatisfyAll(
-					HaveKeyWithValue("korifi.cloudfoundry.org/app-guid", cfRoute.Spec.Destinations[1].AppRef.Name),
-					HaveKeyWithValue("korifi.cloudfoundry.org/route-guid", cfRoute.Name),
-				))
-				g.Expect(svc.Spec.Selector).To(SatisfyAll(
-					HaveLen(2),
-					HaveKeyWithValue("korifi.cloudfoundry.org/app-guid", cfRoute.Spec.Destinations[1].AppRef.Name),
-					HaveKeyWithValue("korifi.cloudfoundry.org/process-type", cfRoute.Spec.Destinations[1].ProcessType),
-				))
-				g.Expect(svc.ObjectMeta.OwnerReferences).To(ConsistOf([]metav1.OwnerReference{
-					{
-						APIVersion: "korifi.cloudfoundry.org/v1alpha1",
-						Kind:       "CFRoute",
-						Name:       cfRoute.Name,
-						UID:        cfRoute.GetUID(),
-					},
-				}))
+				var service corev1.Service
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: serviceName, Namespace: testNamespace}, &service)).To(Succeed())
+				g.Expect(service.OwnerReferences).To(HaveLen(1))
+			}).Should(Succeed())
+
+			Expect(k8s.PatchResource(ctx, k8sClient, cfRoute, func() {
+				cfRoute.Annotations = map[string]string{korifiv1alpha1.CFRouteTransferredToAnnotation: targetNamespace}
+			})).To(Succeed())
+
+			transferredRoute = &korifiv1alpha1.CFRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        testRouteGUID,
+					Namespace:   targetNamespace,
+					Annotations: map[string]string{korifiv1alpha1.CFRouteTransferredFromAnnotation: testNamespace},
+				},
+				Spec: *cfRoute.Spec.DeepCopy(),
+			}
+			transferredRoute.Spec.SharedSpaces = []string{testNamespace}
+			transferredRoute.Spec.Destinations[0].Namespace = testNamespace
+			Expect(k8sClient.Create(ctx, transferredRoute)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, transferredRoute))).To(Succeed())
+			})
+		})
+
+		When("the original route is deleted", func() {
+			JustBeforeEach(func() {
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, cfRoute))).To(Succeed())
+			})
+
+			It("hands the destination Service over to the transferred route", func() {
+				Eventually(func(g Gomega) {
+					err := k8sClient.Get(ctx, client.ObjectKeyFromObject(cfRoute), new(korifiv1alpha1.CFRoute))
+					g.Expect(errors.IsNotFound(err)).To(BeTrue())
+
+					var service corev1.Service
+					g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: serviceName, Namespace: testNamespace}, &service)).To(Succeed())
+					g.Expect(service.OwnerReferences).To(BeEmpty())
+				}).Should(Succeed())
+			})
+		})
+
+		When("the transfer is interrupted before the original route is deleted", func() {
+			It("deletes the original route and completes the transfer", func() {
+				Eventually(func(g Gomega) {
+					err := k8sClient.Get(ctx, client.ObjectKeyFromObject(cfRoute), new(korifiv1alpha1.CFRoute))
+					g.Expect(errors.IsNotFound(err)).To(BeTrue())
+
+					var service corev1.Service
+					g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: serviceName, Namespace: testNamespace}, &service)).To(Succeed())
+					g.Expect(service.OwnerReferences).To(BeEmpty())
+
+					route := new(korifiv1alpha1.CFRoute)
+					g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(transferredRoute), route)).To(Succeed())
+					g.Expect(route.Annotations).NotTo(HaveKey(korifiv1alpha1.CFRouteTransferredFromAnnotation))
+				}).Should(Succeed())
 			})
 		})
 	})
@@ -447,19 +887,11 @@ var _ = Describe("CFRouteReconciler Integration Tests", func() {
 					Protocol:    "http1",
 				},
 			}
+			serviceName = fmt.Sprintf("s-%s", cfRoute.Spec.Destinations[0].GUID)
 		})
 
 		JustBeforeEach(func() {
 			Eventually(func(g Gomega) {
-				var proxy contourv1.HTTPProxy
-				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testFQDN, Namespace: testNamespace}, &proxy)).To(Succeed())
-
-				var routeProxy contourv1.HTTPProxy
-				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testRouteGUID, Namespace: testNamespace}, &routeProxy)).To(Succeed())
-				g.Expect(routeProxy.Spec.Routes).To(HaveLen(1))
-				g.Expect(routeProxy.Spec.Routes[0].Services).To(HaveLen(1))
-
-				serviceName = routeProxy.Spec.Routes[0].Services[0].Name
 				var svc corev1.Service
 				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: serviceName, Namespace: testNamespace}, &svc)).To(Succeed())
 			}).Should(Succeed())
@@ -469,11 +901,11 @@ var _ = Describe("CFRouteReconciler Integration Tests", func() {
 			})).To(Succeed())
 		})
 
-		It("deletes the Route on the HTTP proxy", func() {
+		It("removes the destination from the VirtualService", func() {
 			Eventually(func(g Gomega) {
-				var routeProxy contourv1.HTTPProxy
-				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testRouteGUID, Namespace: testNamespace}, &routeProxy)).To(Succeed())
-				g.Expect(routeProxy.Spec.Routes).To(BeEmpty())
+				virtualService := getVirtualService(g)
+				g.Expect(virtualService.Spec.Http).To(HaveLen(1))
+				g.Expect(virtualService.Spec.Http[0].Route).To(BeEmpty())
 			}).Should(Succeed())
 		})
 
@@ -483,69 +915,970 @@ var _ = Describe("CFRouteReconciler Integration Tests", func() {
 				g.Expect(errors.IsNotFound(err)).To(BeTrue())
 			}).Should(Succeed())
 		})
-
-		It("does not delete the FQDN proxy", func() {
-			Consistently(func(g Gomega) {
-				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testFQDN, Namespace: testNamespace}, new(contourv1.HTTPProxy))).To(Succeed())
-			}).Should(Succeed())
-		})
 	})
 
-	When("deleting a CFRoute that shares the same FQDN HTTPProxy", func() {
-		var (
-			secondRouteGUID      string
-			secondCFRouteForFQDN *korifiv1alpha1.CFRoute
-		)
-
-		JustBeforeEach(func() {
-			secondRouteGUID = GenerateGUID()
-			secondCFRouteForFQDN = &korifiv1alpha1.CFRoute{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      secondRouteGUID,
-					Namespace: testNamespace,
-				},
-				Spec: korifiv1alpha1.CFRouteSpec{
-					Host:     testRouteHost,
-					Path:     "/some/other/path",
-					Protocol: "http",
-					DomainRef: corev1.ObjectReference{
-						Name:      testDomainGUID,
-						Namespace: testNamespace,
-					},
-				},
-			}
-			Expect(k8sClient.Create(ctx, secondCFRouteForFQDN)).To(Succeed())
+	When("the ingress backend is contour", func() {
+		BeforeEach(func() {
+			ingressBackend = config.ContourIngressBackend
 		})
 
-		It("removes the route from the shared FQDN HTTPProxy", func() {
+		It("reconciles the CFRoute to a root Contour HTTPProxy which includes a proxy for a route destination", func() {
 			Eventually(func(g Gomega) {
 				var proxy contourv1.HTTPProxy
 				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testFQDN, Namespace: testNamespace}, &proxy)).To(Succeed())
 
 				g.Expect(proxy.Spec.VirtualHost.Fqdn).To(Equal(testFQDN))
-				g.Expect(proxy.Spec.Includes).To(ContainElement(contourv1.Include{
-					Name:      secondRouteGUID,
+				g.Expect(proxy.Spec.VirtualHost.TLS.SecretName).To(Equal("korifi-controllers-system/korifi-workloads-ingress-cert"))
+				g.Expect(proxy.Spec.Includes).To(ConsistOf(contourv1.Include{
+					Name:      testRouteGUID,
 					Namespace: testNamespace,
 				}))
-			}).Should(Succeed())
 
-			Expect(k8sClient.Delete(ctx, secondCFRouteForFQDN)).To(Succeed())
+				g.Expect(proxy.ObjectMeta.OwnerReferences).To(ConsistOf(metav1.OwnerReference{
+					APIVersion: "korifi.cloudfoundry.org/v1alpha1",
+					Kind:       "CFRoute",
+					Name:       cfRoute.Name,
+					UID:        cfRoute.GetUID(),
+				}))
+			}).Should(Succeed())
+		})
 
+		It("reconciles the CFRoute to a child proxy with no routes", func() {
 			Eventually(func(g Gomega) {
 				var proxy contourv1.HTTPProxy
-				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testFQDN, Namespace: testNamespace}, &proxy)).To(Succeed())
-
-				g.Expect(proxy.Spec.VirtualHost.Fqdn).To(Equal(testFQDN))
-				g.Expect(proxy.Spec.Includes).NotTo(ContainElement(contourv1.Include{
-					Name:      secondRouteGUID,
-					Namespace: testNamespace,
-				}))
-
-				g.Expect(proxy.Spec.Includes).To(ConsistOf(contourv1.Include{
-					Name:      testRouteGUID,
-					Namespace: testNamespace,
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testRouteGUID, Namespace: testNamespace}, &proxy)).To(Succeed())
+				g.Expect(proxy.Spec.VirtualHost).To(BeNil())
+				g.Expect(proxy.Spec.Routes).To(BeEmpty())
+				g.Expect(proxy.ObjectMeta.OwnerReferences).To(ConsistOf(metav1.OwnerReference{
+					APIVersion: "korifi.cloudfoundry.org/v1alpha1",
+					Kind:       "CFRoute",
+					Name:       cfRoute.Name,
+					UID:        cfRoute.GetUID(),
 				}))
 			}).Should(Succeed())
 		})
+
+		When("the CFRoute is a tcp route", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, k8sClient, cfDomain, func() {
+					cfDomain.Spec.RouterGroup = "default-tcp"
+				})).To(Succeed())
+
+				cfRoute.Spec.Host = ""
+				cfRoute.Spec.Path = ""
+				cfRoute.Spec.Protocol = korifiv1alpha1.TCPProtocol
+				cfRoute.Spec.Port = 1024
+				cfRoute.Spec.Destinations = []korifiv1alpha1.Destination{{
+					GUID:        "destination-guid",
+					Port:        5432,
+					AppRef:      corev1.LocalObjectReference{Name: "the-app-guid"},
+					ProcessType: "web",
+					Protocol:    "tcp",
+				}}
+			})
+
+			It("reconciles the CFRoute to a TCPRoute on a listener for its port", func() {
+				Eventually(func(g Gomega) {
+					tcpRoute := new(gatewayv1alpha2.TCPRoute)
+					g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testRouteGUID, Namespace: testNamespace}, tcpRoute)).To(Succeed())
+					g.Expect(tcpRoute.Spec.ParentRefs).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"Name":        BeEquivalentTo("korifi-workloads-gateway"),
+						"SectionName": PointTo(BeEquivalentTo("tcp-1024")),
+					})))
+				}).Should(Succeed())
+			})
+
+			It("does not create an HTTPProxy", func() {
+				Consistently(func(g Gomega) {
+					err := k8sClient.Get(ctx, types.NamespacedName{Name: testRouteGUID, Namespace: testNamespace}, new(contourv1.HTTPProxy))
+					g.Expect(errors.IsNotFound(err)).To(BeTrue())
+				}, "1s").Should(Succeed())
+			})
+		})
+
+		When("the route Host contains upper case characters", func() {
+			BeforeEach(func() {
+				testRouteHost = "My-App"
+				cfRoute.Spec.Host = testRouteHost
+				testFQDN = strings.ToLower(fmt.Sprintf("%s.%s", testRouteHost, testDomainName))
+			})
+
+			It("reconciles the CFRoute to a root Contour HTTPProxy for the lower case FQDN", func() {
+				Eventually(func(g Gomega) {
+					var proxy contourv1.HTTPProxy
+					g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testFQDN, Namespace: testNamespace}, &proxy)).To(Succeed())
+					g.Expect(proxy.Spec.VirtualHost.Fqdn).To(Equal(testFQDN))
+				}).Should(Succeed())
+			})
+		})
+
+		When("the route has a wildcard host", func() {
+			BeforeEach(func() {
+				cfRoute.Spec.Host = "*"
+			})
+
+			It("reconciles the CFRoute to a wildcard root Contour HTTPProxy named after the domain", func() {
+				Eventually(func(g Gomega) {
+					var proxy contourv1.HTTPProxy
+					g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "wildcard-" + testDomainGUID, Namespace: testNamespace}, &proxy)).To(Succeed())
+					g.Expect(proxy.Spec.VirtualHost.Fqdn).To(Equal("*." + testDomainName))
+					g.Expect(proxy.Spec.Includes).To(ConsistOf(contourv1.Include{
+						Name:      testRouteGUID,
+						Namespace: testNamespace,
+					}))
+				}).Should(Succeed())
+			})
+		})
+
+		When("the CFRoute includes destinations", func() {
+			BeforeEach(func() {
+				cfRoute.Spec.Destinations = []korifiv1alpha1.Destination{
+					{
+						GUID: GenerateGUID(),
+						AppRef: corev1.LocalObjectReference{
+							Name: "the-app-guid",
+						},
+						ProcessType: "web",
+						Port:        80,
+						Protocol:    "http1",
+					},
+				}
+			})
+
+			It("reconciles the CFRoute to a root Contour HTTPProxy which includes a proxy for a route destination", func() {
+				Eventually(func(g Gomega) {
+					var proxy contourv1.HTTPProxy
+					g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testFQDN, Namespace: testNamespace}, &proxy)).To(Succeed())
+					g.Expect(proxy.Spec.Includes).To(ConsistOf(contourv1.Include{
+						Name:      testRouteGUID,
+						Namespace: testNamespace,
+					}))
+				}).Should(Succeed())
+			})
+
+			It("reconciles the CFRoute to a child proxy with a route", func() {
+				Eventually(func(g Gomega) {
+					var proxy contourv1.HTTPProxy
+					g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testRouteGUID, Namespace: testNamespace}, &proxy)).To(Succeed())
+					g.Expect(proxy.Spec.Routes).To(ConsistOf(contourv1.Route{
+						Conditions: []contourv1.MatchCondition{
+							{
+								Prefix: "/test/path",
+							},
+						},
+						Services: []contourv1.Service{
+							{
+								Name: fmt.Sprintf("s-%s", cfRoute.Spec.Destinations[0].GUID),
+								Port: cfRoute.Spec.Destinations[0].Port,
+							},
+						},
+						EnableWebsockets: true,
+					}))
+				}).Should(Succeed())
+			})
+
+			When("the route has options", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.Options = map[string]string{
+						"loadbalancing":   "least-connection",
+						"request_timeout": "30s",
+						"idle_timeout":    "5m",
+						"rate_limit":      "100/minute",
+					}
+				})
+
+				It("sets the policies of the options on the route of the child proxy", func() {
+					Eventually(func(g Gomega) {
+						var proxy contourv1.HTTPProxy
+						g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testRouteGUID, Namespace: testNamespace}, &proxy)).To(Succeed())
+						g.Expect(proxy.Spec.Routes).To(HaveLen(1))
+						g.Expect(proxy.Spec.Routes[0].LoadBalancerPolicy).To(Equal(&contourv1.LoadBalancerPolicy{Strategy: "WeightedLeastRequest"}))
+						g.Expect(proxy.Spec.Routes[0].TimeoutPolicy).To(Equal(&contourv1.TimeoutPolicy{Response: "30s", Idle: "5m"}))
+						g.Expect(proxy.Spec.Routes[0].RateLimitPolicy).To(Equal(&contourv1.RateLimitPolicy{
+							Local: &contourv1.LocalRateLimitPolicy{Requests: 100, Unit: "minute"},
+						}))
+					}).Should(Succeed())
+				})
+			})
+
+			When("the destination protocol is http2", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.Destinations[0].Protocol = "http2"
+				})
+
+				It("proxies to the destination over h2c", func() {
+					Eventually(func(g Gomega) {
+						var proxy contourv1.HTTPProxy
+						g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testRouteGUID, Namespace: testNamespace}, &proxy)).To(Succeed())
+						g.Expect(proxy.Spec.Routes).To(HaveLen(1))
+						g.Expect(proxy.Spec.Routes[0].Services).To(HaveLen(1))
+						g.Expect(proxy.Spec.Routes[0].Services[0].Protocol).To(PointTo(Equal("h2c")))
+					}).Should(Succeed())
+				})
+			})
+
+			When("the destination app is in a shared space", func() {
+				var sharedNamespace string
+
+				BeforeEach(func() {
+					sharedNamespace = GenerateGUID()
+					Expect(k8sClient.Create(ctx, &corev1.Namespace{
+						ObjectMeta: metav1.ObjectMeta{Name: sharedNamespace},
+					})).To(Succeed())
+
+					cfRoute.Spec.SharedSpaces = []string{sharedNamespace}
+					cfRoute.Spec.Destinations[0].Namespace = sharedNamespace
+				})
+
+				It("aliases the destination Service in the route namespace", func() {
+					serviceName := fmt.Sprintf("s-%s", cfRoute.Spec.Destinations[0].GUID)
+					Eventually(func(g Gomega) {
+						var svc corev1.Service
+						g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: serviceName, Namespace: testNamespace}, &svc)).To(Succeed())
+						g.Expect(svc.Spec.Type).To(Equal(corev1.ServiceTypeExternalName))
+						g.Expect(svc.Spec.ExternalName).To(Equal(serviceName + "." + sharedNamespace + ".svc.cluster.local"))
+						g.Expect(svc.Spec.Ports).To(ConsistOf(MatchFields(IgnoreExtras, Fields{"Port": BeEquivalentTo(80)})))
+						g.Expect(svc.ObjectMeta.OwnerReferences).To(ConsistOf(MatchFields(IgnoreExtras, Fields{"Name": Equal(cfRoute.Name)})))
+					}).Should(Succeed())
+				})
+
+				It("routes the traffic of the child proxy to the alias", func() {
+					Eventually(func(g Gomega) {
+						var proxy contourv1.HTTPProxy
+						g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testRouteGUID, Namespace: testNamespace}, &proxy)).To(Succeed())
+						g.Expect(proxy.Spec.Routes).To(HaveLen(1))
+						g.Expect(proxy.Spec.Routes[0].Services).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+							"Name": Equal(fmt.Sprintf("s-%s", cfRoute.Spec.Destinations[0].GUID)),
+						})))
+					}).Should(Succeed())
+				})
+
+				It("keeps the destination Service in the namespace of the app", func() {
+					serviceName := fmt.Sprintf("s-%s", cfRoute.Spec.Destinations[0].GUID)
+					Eventually(func(g Gomega) {
+						var svc corev1.Service
+						g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: serviceName, Namespace: testNamespace}, &svc)).To(Succeed())
+					}).Should(Succeed())
+
+					Consistently(func(g Gomega) {
+						var svc corev1.Service
+						g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: serviceName, Namespace: sharedNamespace}, &svc)).To(Succeed())
+						g.Expect(svc.Spec.Selector).NotTo(BeEmpty())
+					}).Should(Succeed())
+				})
+			})
+
+			When("the destinations are weighted", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.Destinations = []korifiv1alpha1.Destination{
+						{
+							GUID:        GenerateGUID(),
+							AppRef:      corev1.LocalObjectReference{Name: "blue-app-guid"},
+							ProcessType: "web",
+							Port:        80,
+							Protocol:    "http1",
+							Weight:      tools.PtrTo(90),
+						},
+						{
+							GUID:        GenerateGUID(),
+							AppRef:      corev1.LocalObjectReference{Name: "green-app-guid"},
+							ProcessType: "web",
+							Port:        80,
+							Protocol:    "http1",
+							Weight:      tools.PtrTo(10),
+						},
+					}
+				})
+
+				It("splits the traffic between the destinations by weight", func() {
+					Eventually(func(g Gomega) {
+						var proxy contourv1.HTTPProxy
+						g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testRouteGUID, Namespace: testNamespace}, &proxy)).To(Succeed())
+						g.Expect(proxy.Spec.Routes).To(HaveLen(1))
+						g.Expect(proxy.Spec.Routes[0].Services).To(ConsistOf(
+							MatchFields(IgnoreExtras, Fields{
+								"Name":   Equal(fmt.Sprintf("s-%s", cfRoute.Spec.Destinations[0].GUID)),
+								"Weight": BeEquivalentTo(90),
+							}),
+							MatchFields(IgnoreExtras, Fields{
+								"Name":   Equal(fmt.Sprintf("s-%s", cfRoute.Spec.Destinations[1].GUID)),
+								"Weight": BeEquivalentTo(10),
+							}),
+						))
+					}).Should(Succeed())
+				})
+			})
+		})
+
+		When("the route has no path", func() {
+			BeforeEach(func() {
+				cfRoute.Spec.Path = ""
+				cfRoute.Spec.Destinations = []korifiv1alpha1.Destination{{
+					GUID:        "destination-guid",
+					AppRef:      corev1.LocalObjectReference{Name: "the-app-guid"},
+					ProcessType: "web",
+					Port:        8080,
+					Protocol:    "http1",
+				}}
+			})
+
+			It("reconciles the CFRoute to a child proxy route without conditions", func() {
+				Eventually(func(g Gomega) {
+					var proxy contourv1.HTTPProxy
+					g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testRouteGUID, Namespace: testNamespace}, &proxy)).To(Succeed())
+					g.Expect(proxy.Spec.Routes).To(HaveLen(1))
+					g.Expect(proxy.Spec.Routes[0].Conditions).To(BeEmpty())
+				}).Should(Succeed())
+			})
+		})
+
+		When("the route is bound to a route service", func() {
+			BeforeEach(func() {
+				cfRoute.Spec.Destinations = []korifiv1alpha1.Destination{{
+					GUID:        "destination-guid",
+					AppRef:      corev1.LocalObjectReference{Name: "the-app-guid"},
+					ProcessType: "web",
+					Port:        8080,
+					Protocol:    "http1",
+				}}
+			})
+
+			JustBeforeEach(func() {
+				bindRouteService("https://route-service.example.com/filter")
+			})
+
+			It("routes signed requests to the destinations and forwards everything else to the route service", func() {
+				Eventually(func(g Gomega) {
+					var proxy contourv1.HTTPProxy
+					g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testRouteGUID, Namespace: testNamespace}, &proxy)).To(Succeed())
+					g.Expect(proxy.Spec.Routes).To(HaveLen(2))
+
+					g.Expect(proxy.Spec.Routes[0].Conditions).To(ConsistOf(
+						contourv1.MatchCondition{Prefix: "/test/path"},
+						contourv1.MatchCondition{Header: &contourv1.HeaderMatchCondition{Name: "X-CF-Proxy-Signature", Exact: "the-signature"}},
+					))
+					g.Expect(proxy.Spec.Routes[0].Services).To(ConsistOf(MatchFields(IgnoreExtras, Fields{"Name": Equal("s-destination-guid")})))
+					g.Expect(proxy.Spec.Routes[0].RequestHeadersPolicy.Remove).To(ConsistOf("X-CF-Proxy-Signature"))
+
+					g.Expect(proxy.Spec.Routes[1].Conditions).To(ConsistOf(contourv1.MatchCondition{Prefix: "/test/path"}))
+					g.Expect(proxy.Spec.Routes[1].Services).To(ConsistOf(contourv1.Service{
+						Name:     "rs-" + testRouteGUID,
+						Port:     443,
+						Protocol: tools.PtrTo("tls"),
+					}))
+					g.Expect(proxy.Spec.Routes[1].PathRewritePolicy.ReplacePrefix).To(ConsistOf(contourv1.ReplacePrefix{
+						Prefix:      "/test/path",
+						Replacement: "/filter",
+					}))
+					g.Expect(proxy.Spec.Routes[1].RequestHeadersPolicy.Set).To(ConsistOf(
+						contourv1.HeaderValue{Name: "Host", Value: "route-service.example.com"},
+						contourv1.HeaderValue{Name: "X-CF-Forwarded-Url", Value: "%REQ(x-forwarded-proto)%://%REQ(:authority)%%REQ(:path)%"},
+						contourv1.HeaderValue{Name: "X-CF-Proxy-Signature", Value: "the-signature"},
+					))
+				}).Should(Succeed())
+			})
+
+			It("creates an ExternalName Service for the route service", func() {
+				Eventually(func(g Gomega) {
+					var svc corev1.Service
+					g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "rs-" + testRouteGUID, Namespace: testNamespace}, &svc)).To(Succeed())
+					g.Expect(svc.Spec.Type).To(Equal(corev1.ServiceTypeExternalName))
+					g.Expect(svc.Spec.ExternalName).To(Equal("route-service.example.com"))
+				}).Should(Succeed())
+			})
+
+			When("the route has no path", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.Path = ""
+				})
+
+				It("does not match on the path", func() {
+					Eventually(func(g Gomega) {
+						var proxy contourv1.HTTPProxy
+						g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testRouteGUID, Namespace: testNamespace}, &proxy)).To(Succeed())
+						g.Expect(proxy.Spec.Routes).To(HaveLen(2))
+						g.Expect(proxy.Spec.Routes[0].Conditions).To(ConsistOf(
+							contourv1.MatchCondition{Header: &contourv1.HeaderMatchCondition{Name: "X-CF-Proxy-Signature", Exact: "the-signature"}},
+						))
+						g.Expect(proxy.Spec.Routes[1].Conditions).To(BeEmpty())
+						g.Expect(proxy.Spec.Routes[1].PathRewritePolicy.ReplacePrefix).To(ConsistOf(contourv1.ReplacePrefix{
+							Prefix:      "/",
+							Replacement: "/filter",
+						}))
+					}).Should(Succeed())
+				})
+			})
+		})
+
+		When("the FQDN of a CFRoute is not unique within a space", func() {
+			var (
+				duplicateRouteGUID string
+				duplicateRoute     *korifiv1alpha1.CFRoute
+			)
+
+			JustBeforeEach(func() {
+				duplicateRouteGUID = GenerateGUID()
+				duplicateRoute = &korifiv1alpha1.CFRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      duplicateRouteGUID,
+						Namespace: testNamespace,
+					},
+					Spec: korifiv1alpha1.CFRouteSpec{
+						Host:     testRouteHost,
+						Path:     "/",
+						Protocol: "http",
+						DomainRef: corev1.ObjectReference{
+							Name:      testDomainGUID,
+							Namespace: testNamespace,
+						},
+						Destinations: []korifiv1alpha1.Destination{
+							{
+								GUID: GenerateGUID(),
+								AppRef: corev1.LocalObjectReference{
+									Name: "app-guid-2",
+								},
+								ProcessType: "web",
+								Port:        80,
+								Protocol:    "http1",
+							},
+						},
+					},
+				}
+				Expect(k8sClient.Create(ctx, duplicateRoute)).To(Succeed())
+			})
+
+			It("reconciles the CFRoute to the existing Contour HTTPProxy with the matching FQDN", func() {
+				Eventually(func(g Gomega) {
+					var proxy contourv1.HTTPProxy
+					g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testFQDN, Namespace: testNamespace}, &proxy)).To(Succeed())
+					g.Expect(proxy.Spec.Includes).To(ConsistOf([]contourv1.Include{
+						{
+							Name:      testRouteGUID,
+							Namespace: testNamespace,
+						},
+						{
+							Name:      duplicateRouteGUID,
+							Namespace: testNamespace,
+						},
+					}), "HTTPProxy includes mismatch")
+				}).Should(Succeed())
+			})
+
+			It("reconciles the duplicate CFRoute to a child proxy with a route", func() {
+				Eventually(func(g Gomega) {
+					var proxy contourv1.HTTPProxy
+					g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: duplicateRouteGUID, Namespace: testNamespace}, &proxy)).To(Succeed())
+					g.Expect(proxy.Spec.VirtualHost).To(BeNil())
+					g.Expect(proxy.Spec.Routes).To(ConsistOf(contourv1.Route{
+						Conditions: []contourv1.MatchCondition{
+							{
+								Prefix: "/",
+							},
+						},
+						Services: []contourv1.Service{
+							{
+								Name: fmt.Sprintf("s-%s", duplicateRoute.Spec.Destinations[0].GUID),
+								Port: duplicateRoute.Spec.Destinations[0].Port,
+							},
+						},
+						EnableWebsockets: true,
+					}))
+				}).Should(Succeed())
+			})
+
+			When("the duplicate is deleted", func() {
+				JustBeforeEach(func() {
+					Eventually(func(g Gomega) {
+						var proxy contourv1.HTTPProxy
+						g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testFQDN, Namespace: testNamespace}, &proxy)).To(Succeed())
+						g.Expect(proxy.Spec.Includes).To(HaveLen(2))
+					}).Should(Succeed())
+					Expect(k8sClient.Delete(ctx, duplicateRoute)).To(Succeed())
+				})
+
+				It("removes it from the proxy includes list", func() {
+					Eventually(func(g Gomega) {
+						var proxy contourv1.HTTPProxy
+						g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testFQDN, Namespace: testNamespace}, &proxy)).To(Succeed())
+						g.Expect(proxy.Spec.Includes).To(ConsistOf(contourv1.Include{
+							Name:      testRouteGUID,
+							Namespace: testNamespace,
+						}))
+					}).Should(Succeed())
+				})
+			})
+		})
+
+		When("a destination is added to a CFRoute", func() {
+			BeforeEach(func() {
+				cfRoute.Spec.Destinations = []korifiv1alpha1.Destination{
+					{
+						GUID: GenerateGUID(),
+						AppRef: corev1.LocalObjectReference{
+							Name: "the-app-guid",
+						},
+						ProcessType: "web",
+						Port:        80,
+						Protocol:    "http1",
+					},
+				}
+			})
+
+			JustBeforeEach(func() {
+				Eventually(func(g Gomega) {
+					var proxy contourv1.HTTPProxy
+					g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testFQDN, Namespace: testNamespace}, &proxy)).To(Succeed())
+				}).Should(Succeed())
+
+				Expect(k8s.Patch(ctx, k8sClient, cfRoute, func() {
+					cfRoute.Spec.Destinations = append(cfRoute.Spec.Destinations, korifiv1alpha1.Destination{
+						GUID: GenerateGUID(),
+						AppRef: corev1.LocalObjectReference{
+							Name: "app-guid-2",
+						},
+						ProcessType: "web",
+						Port:        8080,
+						Protocol:    "http1",
+					})
+				})).To(Succeed())
+			})
+
+			It("reconciles the CFRoute to a child proxy with a route", func() {
+				Eventually(func(g Gomega) {
+					var proxy contourv1.HTTPProxy
+					g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testRouteGUID, Namespace: testNamespace}, &proxy)).To(Succeed())
+					g.Expect(proxy.Spec.Routes).To(ConsistOf([]contourv1.Route{
+						{
+							Conditions: []contourv1.MatchCondition{{Prefix: "/test/path"}},
+							Services: []contourv1.Service{
+								{
+									Name: fmt.Sprintf("s-%s", cfRoute.Spec.Destinations[0].GUID),
+									Port: cfRoute.Spec.Destinations[0].Port,
+								},
+								{
+									Name: fmt.Sprintf("s-%s", cfRoute.Spec.Destinations[1].GUID),
+									Port: cfRoute.Spec.Destinations[1].Port,
+								},
+							},
+							EnableWebsockets: true,
+						},
+					}))
+				}).Should(Succeed())
+			})
+
+			It("reconciles the new destination to a Service for the app", func() {
+				serviceName := fmt.Sprintf("s-%s", cfRoute.Spec.Destinations[1].GUID)
+
+				Eventually(func(g Gomega) {
+					var svc corev1.Service
+					g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: serviceName, Namespace: testNamespace}, &svc)).To(Succeed())
+					g.Expect(svc.Labels).To(SatisfyAll(
+						HaveKeyWithValue("korifi.cloudfoundry.org/app-guid", cfRoute.Spec.Destinations[1].AppRef.Name),
+						HaveKeyWithValue("korifi.cloudfoundry.org/route-guid", cfRoute.Name),
+					))
+					g.Expect(svc.Spec.Selector).To(SatisfyAll(
+						HaveLen(2),
+						HaveKeyWithValue("korifi.cloudfoundry.org/app-guid", cfRoute.Spec.Destinations[1].AppRef.Name),
+						HaveKeyWithValue("korifi.cloudfoundry.org/process-type", cfRoute.Spec.Destinations[1].ProcessType),
+					))
+					g.Expect(svc.ObjectMeta.OwnerReferences).To(ConsistOf([]metav1.OwnerReference{
+						{
+							APIVersion: "korifi.cloudfoundry.org/v1alpha1",
+							Kind:       "CFRoute",
+							Name:       cfRoute.Name,
+							UID:        cfRoute.GetUID(),
+						},
+					}))
+				}).Should(Succeed())
+			})
+		})
+
+		When("a destination is removed from a CFRoute", func() {
+			BeforeEach(func() {
+				cfRoute.Spec.Destinations = []korifiv1alpha1.Destination{
+					{
+						GUID: GenerateGUID(),
+						AppRef: corev1.LocalObjectReference{
+							Name: "the-app-guid",
+						},
+						ProcessType: "web",
+						Port:        80,
+						Protocol:    "http1",
+					},
+				}
+			})
+
+			JustBeforeEach(func() {
+				Eventually(func(g Gomega) {
+					var proxy contourv1.HTTPProxy
+					g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testFQDN, Namespace: testNamespace}, &proxy)).To(Succeed())
+
+					var routeProxy contourv1.HTTPProxy
+					g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testRouteGUID, Namespace: testNamespace}, &routeProxy)).To(Succeed())
+					g.Expect(routeProxy.Spec.Routes).To(HaveLen(1))
+				}).Should(Succeed())
+
+				Expect(k8s.Patch(ctx, k8sClient, cfRoute, func() {
+					cfRoute.Spec.Destinations = []korifiv1alpha1.Destination{}
+				})).To(Succeed())
+			})
+
+			It("deletes the Route on the HTTP proxy", func() {
+				Eventually(func(g Gomega) {
+					var routeProxy contourv1.HTTPProxy
+					g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testRouteGUID, Namespace: testNamespace}, &routeProxy)).To(Succeed())
+					g.Expect(routeProxy.Spec.Routes).To(BeEmpty())
+				}).Should(Succeed())
+			})
+
+			It("does not delete the FQDN proxy", func() {
+				Consistently(func(g Gomega) {
+					g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testFQDN, Namespace: testNamespace}, new(contourv1.HTTPProxy))).To(Succeed())
+				}).Should(Succeed())
+			})
+		})
+
+		When("deleting a CFRoute that shares the same FQDN HTTPProxy", func() {
+			var (
+				secondRouteGUID      string
+				secondCFRouteForFQDN *korifiv1alpha1.CFRoute
+			)
+
+			JustBeforeEach(func() {
+				secondRouteGUID = GenerateGUID()
+				secondCFRouteForFQDN = &korifiv1alpha1.CFRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      secondRouteGUID,
+						Namespace: testNamespace,
+					},
+					Spec: korifiv1alpha1.CFRouteSpec{
+						Host:     testRouteHost,
+						Path:     "/some/other/path",
+						Protocol: "http",
+						DomainRef: corev1.ObjectReference{
+							Name:      testDomainGUID,
+							Namespace: testNamespace,
+						},
+					},
+				}
+				Expect(k8sClient.Create(ctx, secondCFRouteForFQDN)).To(Succeed())
+			})
+
+			It("removes the route from the shared FQDN HTTPProxy", func() {
+				Eventually(func(g Gomega) {
+					var proxy contourv1.HTTPProxy
+					g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testFQDN, Namespace: testNamespace}, &proxy)).To(Succeed())
+
+					g.Expect(proxy.Spec.VirtualHost.Fqdn).To(Equal(testFQDN))
+					g.Expect(proxy.Spec.Includes).To(ContainElement(contourv1.Include{
+						Name:      secondRouteGUID,
+						Namespace: testNamespace,
+					}))
+				}).Should(Succeed())
+
+				Expect(k8sClient.Delete(ctx, secondCFRouteForFQDN)).To(Succeed())
+
+				Eventually(func(g Gomega) {
+					var proxy contourv1.HTTPProxy
+					g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testFQDN, Namespace: testNamespace}, &proxy)).To(Succeed())
+
+					g.Expect(proxy.Spec.VirtualHost.Fqdn).To(Equal(testFQDN))
+					g.Expect(proxy.Spec.Includes).NotTo(ContainElement(contourv1.Include{
+						Name:      secondRouteGUID,
+						Namespace: testNamespace,
+					}))
+
+					g.Expect(proxy.Spec.Includes).To(ConsistOf(contourv1.Include{
+						Name:      testRouteGUID,
+						Namespace: testNamespace,
+					}))
+				}).Should(Succeed())
+			})
+		})
+	})
+
+	When("the ingress backend is gateway-api", func() {
+		getHTTPRoute := func(g Gomega) *gatewayv1beta1.HTTPRoute {
+			httpRoute := new(gatewayv1beta1.HTTPRoute)
+			g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testRouteGUID, Namespace: testNamespace}, httpRoute)).To(Succeed())
+			return httpRoute
+		}
+
+		BeforeEach(func() {
+			ingressBackend = config.GatewayAPIIngressBackend
+
+			cfRoute.Spec.Destinations = []korifiv1alpha1.Destination{{
+				GUID:        GenerateGUID(),
+				AppRef:      corev1.LocalObjectReference{Name: "the-app-guid"},
+				ProcessType: "web",
+				Port:        8080,
+				Protocol:    "http1",
+				Weight:      tools.PtrTo(100),
+			}}
+		})
+
+		It("reconciles the CFRoute to an HTTPRoute attached to the workloads gateway", func() {
+			Eventually(func(g Gomega) {
+				httpRoute := getHTTPRoute(g)
+				g.Expect(httpRoute.Spec.ParentRefs).To(ConsistOf(gatewayv1beta1.ParentReference{
+					Name:      "korifi-workloads-gateway",
+					Namespace: tools.PtrTo(gatewayv1beta1.Namespace("korifi")),
+				}))
+				g.Expect(httpRoute.Spec.Hostnames).To(ConsistOf(gatewayv1beta1.Hostname(testFQDN)))
+				g.Expect(httpRoute.Spec.Rules).To(HaveLen(1))
+				g.Expect(httpRoute.Spec.Rules[0].Matches).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"Path": PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  PointTo(Equal(gatewayv1beta1.PathMatchPathPrefix)),
+						"Value": PointTo(Equal("/test/path")),
+					})),
+				})))
+				g.Expect(httpRoute.Spec.Rules[0].BackendRefs).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"BackendRef": MatchFields(IgnoreExtras, Fields{
+						"BackendObjectReference": MatchFields(IgnoreExtras, Fields{
+							"Name":      BeEquivalentTo(fmt.Sprintf("s-%s", cfRoute.Spec.Destinations[0].GUID)),
+							"Namespace": BeNil(),
+							"Port":      PointTo(BeEquivalentTo(8080)),
+						}),
+						"Weight": PointTo(BeEquivalentTo(100)),
+					}),
+				})))
+			}).Should(Succeed())
+		})
+
+		When("the destination is unweighted", func() {
+			BeforeEach(func() {
+				cfRoute.Spec.Destinations[0].Weight = nil
+			})
+
+			It("leaves the weight of the backend unset", func() {
+				Eventually(func(g Gomega) {
+					httpRoute := getHTTPRoute(g)
+					g.Expect(httpRoute.Spec.Rules).To(HaveLen(1))
+					g.Expect(httpRoute.Spec.Rules[0].BackendRefs).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"BackendRef": MatchFields(IgnoreExtras, Fields{
+							"Weight": BeNil(),
+						}),
+					})))
+				}).Should(Succeed())
+			})
+		})
+
+		When("the CFRoute is a tcp route", func() {
+			getWorkloadsGateway := func(g Gomega) *gatewayv1beta1.Gateway {
+				gateway := new(gatewayv1beta1.Gateway)
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "korifi-workloads-gateway", Namespace: "korifi"}, gateway)).To(Succeed())
+				return gateway
+			}
+
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, k8sClient, cfDomain, func() {
+					cfDomain.Spec.RouterGroup = "default-tcp"
+				})).To(Succeed())
+
+				cfRoute.Spec.Host = ""
+				cfRoute.Spec.Path = ""
+				cfRoute.Spec.Protocol = korifiv1alpha1.TCPProtocol
+				cfRoute.Spec.Port = 1025
+				cfRoute.Spec.Destinations[0].Port = 5432
+				cfRoute.Spec.Destinations[0].Protocol = "tcp"
+			})
+
+			It("adds a tcp listener for the port of the route to the workloads gateway", func() {
+				Eventually(func(g Gomega) {
+					gateway := getWorkloadsGateway(g)
+					g.Expect(gateway.Spec.Listeners).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+						"Name":     BeEquivalentTo("tcp-1025"),
+						"Port":     BeEquivalentTo(1025),
+						"Protocol": Equal(gatewayv1beta1.TCPProtocolType),
+					})))
+					g.Expect(gateway.Spec.Listeners).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+						"Name": BeEquivalentTo("https-apps"),
+					})))
+				}).Should(Succeed())
+			})
+
+			It("reconciles the CFRoute to a TCPRoute attached to the listener", func() {
+				Eventually(func(g Gomega) {
+					tcpRoute := new(gatewayv1alpha2.TCPRoute)
+					g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testRouteGUID, Namespace: testNamespace}, tcpRoute)).To(Succeed())
+					g.Expect(tcpRoute.Spec.ParentRefs).To(ConsistOf(gatewayv1alpha2.ParentReference{
+						Name:        "korifi-workloads-gateway",
+						Namespace:   tools.PtrTo(gatewayv1alpha2.Namespace("korifi")),
+						SectionName: tools.PtrTo(gatewayv1alpha2.SectionName("tcp-1025")),
+					}))
+					g.Expect(tcpRoute.Spec.Rules).To(HaveLen(1))
+					g.Expect(tcpRoute.Spec.Rules[0].BackendRefs).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"BackendObjectReference": MatchFields(IgnoreExtras, Fields{
+							"Name": BeEquivalentTo(fmt.Sprintf("s-%s", cfRoute.Spec.Destinations[0].GUID)),
+							"Port": PointTo(BeEquivalentTo(5432)),
+						}),
+						"Weight": PointTo(BeEquivalentTo(100)),
+					})))
+				}).Should(Succeed())
+			})
+
+			When("the destination is unweighted", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.Destinations[0].Weight = nil
+				})
+
+				It("leaves the weight of the backend unset", func() {
+					Eventually(func(g Gomega) {
+						tcpRoute := new(gatewayv1alpha2.TCPRoute)
+						g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testRouteGUID, Namespace: testNamespace}, tcpRoute)).To(Succeed())
+						g.Expect(tcpRoute.Spec.Rules).To(HaveLen(1))
+						g.Expect(tcpRoute.Spec.Rules[0].BackendRefs).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+							"Weight": BeNil(),
+						})))
+					}).Should(Succeed())
+				})
+			})
+
+			When("the route is deleted", func() {
+				JustBeforeEach(func() {
+					Eventually(func(g Gomega) {
+						g.Expect(getWorkloadsGateway(g).Spec.Listeners).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+							"Name": BeEquivalentTo("tcp-1025"),
+						})))
+					}).Should(Succeed())
+
+					Expect(k8sClient.Delete(ctx, cfRoute)).To(Succeed())
+				})
+
+				It("removes the listener from the workloads gateway", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(getWorkloadsGateway(g).Spec.Listeners).NotTo(ContainElement(MatchFields(IgnoreExtras, Fields{
+							"Name": BeEquivalentTo("tcp-1025"),
+						})))
+					}).Should(Succeed())
+				})
+			})
+		})
+
+		When("the destination app is in a shared space", func() {
+			var sharedNamespace string
+
+			getReferenceGrant := func(g Gomega) *gatewayv1alpha2.ReferenceGrant {
+				referenceGrant := new(gatewayv1alpha2.ReferenceGrant)
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "route-" + testRouteGUID, Namespace: sharedNamespace}, referenceGrant)).To(Succeed())
+				return referenceGrant
+			}
+
+			BeforeEach(func() {
+				sharedNamespace = GenerateGUID()
+				Expect(k8sClient.Create(ctx, &corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{Name: sharedNamespace},
+				})).To(Succeed())
+
+				cfRoute.Spec.SharedSpaces = []string{sharedNamespace}
+				cfRoute.Spec.Destinations[0].Namespace = sharedNamespace
+			})
+
+			It("references the destination Service in the namespace of the app", func() {
+				Eventually(func(g Gomega) {
+					httpRoute := getHTTPRoute(g)
+					g.Expect(httpRoute.Spec.Rules).To(HaveLen(1))
+					g.Expect(httpRoute.Spec.Rules[0].BackendRefs).To(HaveLen(1))
+					g.Expect(httpRoute.Spec.Rules[0].BackendRefs[0].Namespace).To(PointTo(BeEquivalentTo(sharedNamespace)))
+				}).Should(Succeed())
+			})
+
+			It("grants the routes of the route namespace access to the Services of the shared space", func() {
+				Eventually(func(g Gomega) {
+					referenceGrant := getReferenceGrant(g)
+					g.Expect(referenceGrant.Labels).To(HaveKeyWithValue(korifiv1alpha1.CFRouteGUIDLabelKey, testRouteGUID))
+					g.Expect(referenceGrant.Spec.From).To(ContainElement(gatewayv1alpha2.ReferenceGrantFrom{
+						Group:     gatewayv1alpha2.GroupName,
+						Kind:      "HTTPRoute",
+						Namespace: gatewayv1alpha2.Namespace(testNamespace),
+					}))
+					g.Expect(referenceGrant.Spec.To).To(ConsistOf(gatewayv1alpha2.ReferenceGrantTo{Group: "", Kind: "Service"}))
+				}).Should(Succeed())
+			})
+
+			When("the destination is removed", func() {
+				JustBeforeEach(func() {
+					Eventually(func(g Gomega) {
+						getReferenceGrant(g)
+					}).Should(Succeed())
+
+					Expect(k8s.Patch(ctx, k8sClient, cfRoute, func() {
+						cfRoute.Spec.Destinations = []korifiv1alpha1.Destination{}
+					})).To(Succeed())
+				})
+
+				It("deletes the ReferenceGrant", func() {
+					Eventually(func(g Gomega) {
+						err := k8sClient.Get(ctx, types.NamespacedName{Name: "route-" + testRouteGUID, Namespace: sharedNamespace}, new(gatewayv1alpha2.ReferenceGrant))
+						g.Expect(errors.IsNotFound(err)).To(BeTrue())
+					}).Should(Succeed())
+				})
+			})
+
+			When("the route is deleted", func() {
+				JustBeforeEach(func() {
+					Eventually(func(g Gomega) {
+						getReferenceGrant(g)
+					}).Should(Succeed())
+
+					Expect(k8sClient.Delete(ctx, cfRoute)).To(Succeed())
+				})
+
+				It("deletes the ReferenceGrant", func() {
+					Eventually(func(g Gomega) {
+						err := k8sClient.Get(ctx, types.NamespacedName{Name: "route-" + testRouteGUID, Namespace: sharedNamespace}, new(gatewayv1alpha2.ReferenceGrant))
+						g.Expect(errors.IsNotFound(err)).To(BeTrue())
+					}).Should(Succeed())
+				})
+			})
+		})
+
+		When("the route has options", func() {
+			BeforeEach(func() {
+				cfRoute.Spec.Options = map[string]string{"loadbalancing": "least-connection"}
+			})
+
+			It("marks the route as unsupported by the ingress backend", func() {
+				Eventually(func(g Gomega) {
+					var route korifiv1alpha1.CFRoute
+					g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfRoute), &route)).To(Succeed())
+
+					validCondition := meta.FindStatusCondition(route.Status.Conditions, "Valid")
+					g.Expect(validCondition).NotTo(BeNil())
+					g.Expect(validCondition.Status).To(Equal(metav1.ConditionFalse))
+					g.Expect(validCondition.Reason).To(Equal("UnsupportedByIngressBackend"))
+				}).Should(Succeed())
+			})
+		})
+
+		When("the route is bound to a route service", func() {
+			JustBeforeEach(func() {
+				Eventually(func(g Gomega) {
+					getHTTPRoute(g)
+				}).Should(Succeed())
+
+				bindRouteService("https://route-service.example.com/filter")
+			})
+
+			It("marks the route as unsupported by the ingress backend", func() {
+				Eventually(func(g Gomega) {
+					var route korifiv1alpha1.CFRoute
+					g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfRoute), &route)).To(Succeed())
+					g.Expect(route.Status.CurrentStatus).To(Equal(korifiv1alpha1.InvalidStatus))
+
+					validCondition := meta.FindStatusCondition(route.Status.Conditions, "Valid")
+					g.Expect(validCondition).NotTo(BeNil())
+					g.Expect(validCondition.Status).To(Equal(metav1.ConditionFalse))
+					g.Expect(validCondition.Reason).To(Equal("UnsupportedByIngressBackend"))
+				}).Should(Succeed())
+			})
+
+			It("stops routing the traffic to the destinations", func() {
+				Eventually(func(g Gomega) {
+					err := k8sClient.Get(ctx, types.NamespacedName{Name: testRouteGUID, Namespace: testNamespace}, new(gatewayv1beta1.HTTPRoute))
+					g.Expect(errors.IsNotFound(err)).To(BeTrue())
+				}).Should(Succeed())
+			})
+		})
 	})
 })
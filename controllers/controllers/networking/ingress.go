@@ -0,0 +1,125 @@
+package networking
+
+import (
+	"context"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/config"
+
+	"github.com/go-logr/logr"
+)
+
+// ingressBackend programs the ingress of the cluster, so that the traffic of a CFRoute reaches the services
+// of its destinations. The backend is selected with the ingressBackend controller config
+type ingressBackend interface {
+	// reconcileHTTPRoute routes the traffic of an http route to its destinations, through the route
+	// service the route is bound to, if any
+	reconcileHTTPRoute(ctx context.Context, log logr.Logger, cfRoute *korifiv1alpha1.CFRoute, cfDomain *korifiv1alpha1.CFDomain, routeService *routeService) error
+	// reconcileTCPRoute routes the traffic of a tcp route to its destinations
+	reconcileTCPRoute(ctx context.Context, log logr.Logger, cfRoute *korifiv1alpha1.CFRoute, cfDomain *korifiv1alpha1.CFDomain) error
+	// finalizeRoute cleans up the ingress resources of a route that are not owned by it
+	finalizeRoute(ctx context.Context, log logr.Logger, cfRoute *korifiv1alpha1.CFRoute) error
+}
+
+// unsupportedRouteError is returned by the ingress backends for routes they cannot program. Such routes
+// are reported as invalid instead of being retried, until the route or its bindings change
+type unsupportedRouteError struct {
+	message string
+}
+
+func (e unsupportedRouteError) Error() string {
+	return e.message
+}
+
+func newIngressBackend(r *CFRouteReconciler) ingressBackend {
+	switch r.controllerConfig.IngressBackend {
+	case config.ContourIngressBackend:
+		return &contourIngress{r}
+	case config.GatewayAPIIngressBackend:
+		return &gatewayAPIIngress{r}
+	default:
+		return &istioIngress{r}
+	}
+}
+
+// istioIngress routes traffic with Istio virtual services. Every tcp route gets a gateway of its own, with
+// a server listening on the port of the route
+type istioIngress struct {
+	*CFRouteReconciler
+}
+
+func (i *istioIngress) reconcileHTTPRoute(ctx context.Context, log logr.Logger, cfRoute *korifiv1alpha1.CFRoute, cfDomain *korifiv1alpha1.CFDomain, routeService *routeService) error {
+	// istio can only rate limit with an external rate limit service. The route stops serving traffic
+	// rather than serving it without the limit it asks for
+	if _, ok := cfRoute.Spec.Options[korifiv1alpha1.RateLimitRouteOption]; ok {
+		if err := i.deleteVirtualService(ctx, cfRoute); err != nil {
+			log.Error(err, "failed to delete VirtualService")
+			return err
+		}
+
+		return unsupportedRouteError{message: "the rate_limit route option is not supported by the istio ingress backend"}
+	}
+
+	if err := i.reconcileRouteServiceEntry(ctx, log, cfRoute, routeService); err != nil {
+		return err
+	}
+
+	if err := i.reconcileDestinationRules(ctx, log, cfRoute); err != nil {
+		return err
+	}
+
+	return i.createOrPatchVirtualService(ctx, cfRoute, *cfDomain, routeService)
+}
+
+func (i *istioIngress) reconcileTCPRoute(ctx context.Context, log logr.Logger, cfRoute *korifiv1alpha1.CFRoute, cfDomain *korifiv1alpha1.CFDomain) error {
+	if err := i.createOrPatchTCPGateway(ctx, log, cfRoute); err != nil {
+		return err
+	}
+
+	return i.createOrPatchTCPVirtualService(ctx, log, cfRoute)
+}
+
+func (i *istioIngress) finalizeRoute(ctx context.Context, log logr.Logger, cfRoute *korifiv1alpha1.CFRoute) error {
+	return i.deleteVirtualService(ctx, cfRoute)
+}
+
+// contourIngress routes traffic with Contour HTTPProxies. Routes sharing an FQDN are included by a single
+// FQDN HTTPProxy, which terminates TLS. HTTPProxies can only reference services in their own namespace,
+// so the services of destinations in shared spaces are aliased in the route namespace. HTTPProxies cannot
+// listen on the port of a tcp route, so tcp routes are programmed with the Gateway API support of Contour
+type contourIngress struct {
+	*CFRouteReconciler
+}
+
+func (c *contourIngress) reconcileHTTPRoute(ctx context.Context, log logr.Logger, cfRoute *korifiv1alpha1.CFRoute, cfDomain *korifiv1alpha1.CFDomain, routeService *routeService) error {
+	if err := c.createOrPatchDestinationAliases(ctx, log, cfRoute); err != nil {
+		return err
+	}
+
+	if err := c.createOrPatchRouteProxy(ctx, log, cfRoute, routeService); err != nil {
+		return err
+	}
+
+	return c.createOrPatchFQDNProxy(ctx, log, cfRoute, cfDomain)
+}
+
+func (c *contourIngress) reconcileTCPRoute(ctx context.Context, log logr.Logger, cfRoute *korifiv1alpha1.CFRoute, cfDomain *korifiv1alpha1.CFDomain) error {
+	return (&gatewayAPIIngress{c.CFRouteReconciler}).reconcileTCPRoute(ctx, log, cfRoute, cfDomain)
+}
+
+func (c *contourIngress) finalizeRoute(ctx context.Context, log logr.Logger, cfRoute *korifiv1alpha1.CFRoute) error {
+	if cfRoute.IsTCP() {
+		return (&gatewayAPIIngress{c.CFRouteReconciler}).finalizeRoute(ctx, log, cfRoute)
+	}
+
+	fqdnHTTPProxy, foundFQDNProxy, err := c.getFQDNProxy(ctx, log, cfRoute.Status.FQDN, cfRoute.Namespace, false)
+	if err != nil {
+		return err
+	}
+
+	if !foundFQDNProxy {
+		return nil
+	}
+
+	return c.finalizeFQDNProxy(ctx, log, cfRoute, fqdnHTTPProxy)
+}
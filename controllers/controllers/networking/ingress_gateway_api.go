@@ -0,0 +1,351 @@
+package networking
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+// gatewayAPIIngress routes traffic with Gateway API routes attached to the workloads gateway. The gateway
+// must have an https listener terminating TLS for http routes. A tcp listener is added to the gateway for
+// the port of every tcp route
+type gatewayAPIIngress struct {
+	*CFRouteReconciler
+}
+
+func (g *gatewayAPIIngress) reconcileHTTPRoute(ctx context.Context, log logr.Logger, cfRoute *korifiv1alpha1.CFRoute, cfDomain *korifiv1alpha1.CFDomain, routeService *routeService) error {
+	log = log.WithName("reconcileHTTPRoute").WithValues("httpRouteNamespace", cfRoute.Namespace, "httpRouteName", cfRoute.Name)
+
+	// route services need the forwarded url of every request, which Gateway API header filters cannot build.
+	// The route stops serving traffic rather than bypassing the route service it is bound to
+	if routeService != nil {
+		if err := g.deleteHTTPRoute(ctx, cfRoute); err != nil {
+			log.Error(err, "failed to delete HTTPRoute")
+			return err
+		}
+
+		return unsupportedRouteError{message: "route services are not supported by the gateway-api ingress backend"}
+	}
+
+	// Gateway API has no load balancing, timeout or rate limit policies yet
+	if len(cfRoute.Spec.Options) > 0 {
+		if err := g.deleteHTTPRoute(ctx, cfRoute); err != nil {
+			log.Error(err, "failed to delete HTTPRoute")
+			return err
+		}
+
+		return unsupportedRouteError{message: "route options are not supported by the gateway-api ingress backend"}
+	}
+
+	backendRefs := make([]gatewayv1beta1.HTTPBackendRef, 0, len(cfRoute.Spec.Destinations))
+	for i, destination := range cfRoute.Spec.Destinations {
+		backendRefs = append(backendRefs, gatewayv1beta1.HTTPBackendRef{
+			BackendRef: gatewayv1beta1.BackendRef{
+				BackendObjectReference: g.backendObjectReference(cfRoute, &cfRoute.Spec.Destinations[i]),
+				Weight:                 backendWeight(destination),
+			},
+		})
+	}
+
+	if err := g.createOrPatchReferenceGrants(ctx, log, cfRoute); err != nil {
+		return err
+	}
+
+	path := cfRoute.Spec.Path
+	if path == "" {
+		path = "/"
+	}
+
+	httpRoute := &gatewayv1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfRoute.Name,
+			Namespace: cfRoute.Namespace,
+		},
+	}
+
+	result, err := controllerutil.CreateOrPatch(ctx, g.client, httpRoute, func() error {
+		httpRoute.Spec.ParentRefs = []gatewayv1beta1.ParentReference{{
+			Name:      gatewayv1beta1.ObjectName(g.controllerConfig.WorkloadsGatewayName),
+			Namespace: tools.PtrTo(gatewayv1beta1.Namespace(g.controllerConfig.WorkloadsGatewayNamespace)),
+		}}
+		httpRoute.Spec.Hostnames = []gatewayv1beta1.Hostname{gatewayv1beta1.Hostname(strings.ToLower(routeFQDN(cfRoute, cfDomain)))}
+		httpRoute.Spec.Rules = []gatewayv1beta1.HTTPRouteRule{{
+			Matches: []gatewayv1beta1.HTTPRouteMatch{{
+				Path: &gatewayv1beta1.HTTPPathMatch{
+					Type:  tools.PtrTo(gatewayv1beta1.PathMatchPathPrefix),
+					Value: tools.PtrTo(path),
+				},
+			}},
+			BackendRefs: backendRefs,
+		}}
+
+		return controllerutil.SetOwnerReference(cfRoute, httpRoute, g.scheme)
+	})
+	if err != nil {
+		log.Error(err, "failed to patch HTTPRoute")
+		return err
+	}
+
+	log.Info("HTTPRoute reconciled", "operation", result)
+	return nil
+}
+
+// reconcileTCPRoute routes the tcp route with a TCPRoute attached to a listener for the port of the route,
+// which is added to the workloads gateway
+func (g *gatewayAPIIngress) reconcileTCPRoute(ctx context.Context, log logr.Logger, cfRoute *korifiv1alpha1.CFRoute, cfDomain *korifiv1alpha1.CFDomain) error {
+	log = log.WithName("reconcileTCPRoute").WithValues("tcpRouteNamespace", cfRoute.Namespace, "tcpRouteName", cfRoute.Name)
+
+	backendRefs := make([]gatewayv1alpha2.BackendRef, 0, len(cfRoute.Spec.Destinations))
+	for i, destination := range cfRoute.Spec.Destinations {
+		backendRef := g.backendObjectReference(cfRoute, &cfRoute.Spec.Destinations[i])
+		backendRefs = append(backendRefs, gatewayv1alpha2.BackendRef{
+			BackendObjectReference: gatewayv1alpha2.BackendObjectReference{
+				Name:      gatewayv1alpha2.ObjectName(backendRef.Name),
+				Namespace: (*gatewayv1alpha2.Namespace)(backendRef.Namespace),
+				Port:      (*gatewayv1alpha2.PortNumber)(backendRef.Port),
+			},
+			Weight: backendWeight(destination),
+		})
+	}
+
+	if err := g.createOrPatchReferenceGrants(ctx, log, cfRoute); err != nil {
+		return err
+	}
+
+	if err := g.addTCPListener(ctx, log, cfRoute.Spec.Port); err != nil {
+		return err
+	}
+
+	tcpRoute := &gatewayv1alpha2.TCPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfRoute.Name,
+			Namespace: cfRoute.Namespace,
+		},
+	}
+
+	result, err := controllerutil.CreateOrPatch(ctx, g.client, tcpRoute, func() error {
+		tcpRoute.Spec.ParentRefs = []gatewayv1alpha2.ParentReference{{
+			Name:        gatewayv1alpha2.ObjectName(g.controllerConfig.WorkloadsGatewayName),
+			Namespace:   tools.PtrTo(gatewayv1alpha2.Namespace(g.controllerConfig.WorkloadsGatewayNamespace)),
+			SectionName: tools.PtrTo(gatewayv1alpha2.SectionName(tcpListenerName(cfRoute.Spec.Port))),
+		}}
+		tcpRoute.Spec.Rules = []gatewayv1alpha2.TCPRouteRule{{BackendRefs: backendRefs}}
+
+		return controllerutil.SetOwnerReference(cfRoute, tcpRoute, g.scheme)
+	})
+	if err != nil {
+		log.Error(err, "failed to patch TCPRoute")
+		return err
+	}
+
+	log.Info("TCPRoute reconciled", "operation", result)
+	return nil
+}
+
+// finalizeRoute deletes the reference grants of the route, which live in the namespaces of its destinations.
+// The Gateway API routes are owned by the CFRoute
+func (g *gatewayAPIIngress) finalizeRoute(ctx context.Context, log logr.Logger, cfRoute *korifiv1alpha1.CFRoute) error {
+	if cfRoute.IsTCP() {
+		if err := g.removeTCPListener(ctx, log, cfRoute); err != nil {
+			return err
+		}
+	}
+
+	// the reference grants of a transferred route are taken over by the route it was recreated as
+	transferredRoute, err := g.getTransferredRoute(ctx, cfRoute)
+	if err != nil || transferredRoute != nil {
+		return err
+	}
+
+	return g.deleteReferenceGrants(ctx, log, cfRoute, map[string]bool{})
+}
+
+// addTCPListener adds a listener for the port of a tcp route to the workloads gateway. The gateway is shared
+// by all routes, so its listeners are patched with optimistic locking
+func (g *gatewayAPIIngress) addTCPListener(ctx context.Context, log logr.Logger, port int) error {
+	return g.patchWorkloadsGateway(ctx, log, func(gateway *gatewayv1beta1.Gateway) {
+		for _, listener := range gateway.Spec.Listeners {
+			if listener.Name == gatewayv1beta1.SectionName(tcpListenerName(port)) {
+				return
+			}
+		}
+
+		gateway.Spec.Listeners = append(gateway.Spec.Listeners, gatewayv1beta1.Listener{
+			Name:     gatewayv1beta1.SectionName(tcpListenerName(port)),
+			Port:     gatewayv1beta1.PortNumber(port),
+			Protocol: gatewayv1beta1.TCPProtocolType,
+			AllowedRoutes: &gatewayv1beta1.AllowedRoutes{
+				Namespaces: &gatewayv1beta1.RouteNamespaces{From: tools.PtrTo(gatewayv1beta1.NamespacesFromAll)},
+				Kinds:      []gatewayv1beta1.RouteGroupKind{{Group: tools.PtrTo(gatewayv1beta1.Group(gatewayv1beta1.GroupName)), Kind: "TCPRoute"}},
+			},
+		})
+	})
+}
+
+// removeTCPListener removes the listener for the port of the route from the workloads gateway, unless
+// another tcp route uses the same port
+func (g *gatewayAPIIngress) removeTCPListener(ctx context.Context, log logr.Logger, cfRoute *korifiv1alpha1.CFRoute) error {
+	cfRoutes := new(korifiv1alpha1.CFRouteList)
+	if err := g.client.List(ctx, cfRoutes); err != nil {
+		log.Error(err, "failed to list CFRoutes")
+		return err
+	}
+
+	for _, other := range cfRoutes.Items {
+		if other.IsTCP() && other.Spec.Port == cfRoute.Spec.Port && other.UID != cfRoute.UID && other.GetDeletionTimestamp().IsZero() {
+			return nil
+		}
+	}
+
+	return g.patchWorkloadsGateway(ctx, log, func(gateway *gatewayv1beta1.Gateway) {
+		var retainedListeners []gatewayv1beta1.Listener
+		for _, listener := range gateway.Spec.Listeners {
+			if listener.Name != gatewayv1beta1.SectionName(tcpListenerName(cfRoute.Spec.Port)) {
+				retainedListeners = append(retainedListeners, listener)
+			}
+		}
+		gateway.Spec.Listeners = retainedListeners
+	})
+}
+
+func (g *gatewayAPIIngress) patchWorkloadsGateway(ctx context.Context, log logr.Logger, modify func(*gatewayv1beta1.Gateway)) error {
+	gateway := new(gatewayv1beta1.Gateway)
+	err := g.client.Get(ctx, types.NamespacedName{Namespace: g.controllerConfig.WorkloadsGatewayNamespace, Name: g.controllerConfig.WorkloadsGatewayName}, gateway)
+	if err != nil {
+		log.Error(err, "failed to get workloads Gateway")
+		return err
+	}
+
+	original := gateway.DeepCopy()
+	modify(gateway)
+	if equality.Semantic.DeepEqual(original.Spec, gateway.Spec) {
+		return nil
+	}
+
+	err = g.client.Patch(ctx, gateway, client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{}))
+	if err != nil {
+		log.Error(err, "failed to patch workloads Gateway")
+		return err
+	}
+
+	return nil
+}
+
+func tcpListenerName(port int) string {
+	return fmt.Sprintf("tcp-%d", port)
+}
+
+func (g *gatewayAPIIngress) deleteHTTPRoute(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute) error {
+	return client.IgnoreNotFound(g.client.Delete(ctx, &gatewayv1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfRoute.Name,
+			Namespace: cfRoute.Namespace,
+		},
+	}))
+}
+
+// backendObjectReference references the service of the destination. Services of destinations in shared
+// spaces are in another namespace, see createOrPatchReferenceGrants
+func (g *gatewayAPIIngress) backendObjectReference(cfRoute *korifiv1alpha1.CFRoute, destination *korifiv1alpha1.Destination) gatewayv1beta1.BackendObjectReference {
+	backendRef := gatewayv1beta1.BackendObjectReference{
+		Name: gatewayv1beta1.ObjectName(generateServiceName(destination)),
+		Port: tools.PtrTo(gatewayv1beta1.PortNumber(destination.Port)),
+	}
+
+	if namespace := cfRoute.DestinationNamespace(*destination); namespace != cfRoute.Namespace {
+		backendRef.Namespace = tools.PtrTo(gatewayv1beta1.Namespace(namespace))
+	}
+
+	return backendRef
+}
+
+// createOrPatchReferenceGrants allows the routes in the namespace of the route to reference the services
+// of the destinations in shared spaces. Every route has its own grants, so that they can be deleted along
+// with the destinations that need them
+func (g *gatewayAPIIngress) createOrPatchReferenceGrants(ctx context.Context, log logr.Logger, cfRoute *korifiv1alpha1.CFRoute) error {
+	grantNamespaces := map[string]bool{}
+
+	for _, destination := range cfRoute.Spec.Destinations {
+		namespace := cfRoute.DestinationNamespace(destination)
+		if namespace == cfRoute.Namespace || grantNamespaces[namespace] {
+			continue
+		}
+		grantNamespaces[namespace] = true
+
+		referenceGrant := &gatewayv1alpha2.ReferenceGrant{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      referenceGrantName(cfRoute),
+				Namespace: namespace,
+			},
+		}
+
+		_, err := controllerutil.CreateOrPatch(ctx, g.client, referenceGrant, func() error {
+			referenceGrant.Labels = map[string]string{
+				korifiv1alpha1.CFRouteGUIDLabelKey: cfRoute.Name,
+			}
+			referenceGrant.Spec.From = []gatewayv1alpha2.ReferenceGrantFrom{
+				{Group: gatewayv1alpha2.GroupName, Kind: "HTTPRoute", Namespace: gatewayv1alpha2.Namespace(cfRoute.Namespace)},
+				{Group: gatewayv1alpha2.GroupName, Kind: "TCPRoute", Namespace: gatewayv1alpha2.Namespace(cfRoute.Namespace)},
+			}
+			referenceGrant.Spec.To = []gatewayv1alpha2.ReferenceGrantTo{{Group: "", Kind: "Service"}}
+
+			return nil
+		})
+		if err != nil {
+			log.Error(err, "failed to patch ReferenceGrant", "namespace", namespace)
+			return err
+		}
+	}
+
+	return g.deleteReferenceGrants(ctx, log, cfRoute, grantNamespaces)
+}
+
+// deleteReferenceGrants deletes the reference grants of the route outside of the given namespaces. Owner
+// references cannot cross namespaces, so the grants are not garbage collected along with the route
+func (g *gatewayAPIIngress) deleteReferenceGrants(ctx context.Context, log logr.Logger, cfRoute *korifiv1alpha1.CFRoute, retainedNamespaces map[string]bool) error {
+	referenceGrants := new(gatewayv1alpha2.ReferenceGrantList)
+	err := g.client.List(ctx, referenceGrants, client.MatchingLabels{korifiv1alpha1.CFRouteGUIDLabelKey: cfRoute.Name})
+	if err != nil {
+		log.Error(err, "failed to list ReferenceGrants")
+		return err
+	}
+
+	for i := range referenceGrants.Items {
+		if retainedNamespaces[referenceGrants.Items[i].Namespace] {
+			continue
+		}
+
+		if err = g.client.Delete(ctx, &referenceGrants.Items[i]); client.IgnoreNotFound(err) != nil {
+			log.Error(err, "failed to delete ReferenceGrant", "namespace", referenceGrants.Items[i].Namespace)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func referenceGrantName(cfRoute *korifiv1alpha1.CFRoute) string {
+	return "route-" + cfRoute.Name
+}
+
+// backendWeight returns the weight of the backend of the destination. A zero weight would send no traffic
+// to the backend, so unweighted destinations are left without a weight, which splits the traffic evenly
+func backendWeight(destination korifiv1alpha1.Destination) *int32 {
+	if destination.Weight == nil {
+		return nil
+	}
+
+	return tools.PtrTo(int32(*destination.Weight))
+}
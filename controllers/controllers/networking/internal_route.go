@@ -0,0 +1,211 @@
+package networking
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const internalRouteEndpointSliceManager = "korifi.cloudfoundry.org"
+
+// reconcileInternalRoute makes a route on an internal domain resolvable by creating a headless service in
+// the domain namespace. A service selector cannot match pods in other namespaces, so the endpoints of the
+// destination services are mirrored into EndpointSlices of the headless service, which resolves to the pods
+// of all the route destinations. Internal routes are not exposed by the ingress
+func (r *CFRouteReconciler) reconcileInternalRoute(ctx context.Context, log logr.Logger, cfRoute *korifiv1alpha1.CFRoute, cfDomain *korifiv1alpha1.CFDomain) error {
+	log = log.WithName("reconcileInternalRoute")
+
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfRoute.InternalServiceName(cfDomain.Spec.Name),
+			Namespace: cfDomain.Namespace,
+		},
+	}
+
+	if len(cfRoute.Spec.Destinations) == 0 {
+		return client.IgnoreNotFound(r.client.Delete(ctx, service))
+	}
+
+	result, err := controllerutil.CreateOrPatch(ctx, r.client, service, func() error {
+		service.Labels = map[string]string{
+			korifiv1alpha1.CFInternalRouteLabelKey: cfRoute.Name,
+		}
+
+		service.Spec.Type = corev1.ServiceTypeClusterIP
+		service.Spec.ExternalName = ""
+		service.Spec.ClusterIP = corev1.ClusterIPNone
+		service.Spec.ClusterIPs = []string{corev1.ClusterIPNone}
+		service.Spec.Selector = nil
+		service.Spec.Ports = nil
+
+		return nil
+	})
+	if err != nil {
+		log.Error(err, "failed to patch internal route service")
+		return err
+	}
+
+	log.Info("Internal route service reconciled", "operation", result)
+
+	endpointSliceNames := map[string]bool{}
+	for _, destination := range cfRoute.Spec.Destinations {
+		names, err := r.mirrorDestinationEndpoints(ctx, cfRoute, destination, service)
+		if err != nil {
+			log.Error(err, "failed to mirror destination endpoints", "destinationGUID", destination.GUID)
+			return err
+		}
+
+		for _, name := range names {
+			endpointSliceNames[name] = true
+		}
+	}
+
+	return r.deleteOrphanedEndpointSlices(ctx, log, cfRoute, service.Namespace, endpointSliceNames)
+}
+
+// mirrorDestinationEndpoints copies the endpoints of the destination service into EndpointSlices of the
+// internal route service, one per address type, and returns their names
+func (r *CFRouteReconciler) mirrorDestinationEndpoints(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute, destination korifiv1alpha1.Destination, service *corev1.Service) ([]string, error) {
+	destinationSlices := new(discoveryv1.EndpointSliceList)
+	err := r.client.List(ctx, destinationSlices,
+		client.InNamespace(cfRoute.DestinationNamespace(destination)),
+		client.MatchingLabels{discoveryv1.LabelServiceName: generateServiceName(&destination)},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	endpointsByAddressType := map[discoveryv1.AddressType][]discoveryv1.Endpoint{}
+	for _, destinationSlice := range destinationSlices.Items {
+		endpointsByAddressType[destinationSlice.AddressType] = append(endpointsByAddressType[destinationSlice.AddressType], destinationSlice.Endpoints...)
+	}
+
+	names := []string{}
+	for addressType, endpoints := range endpointsByAddressType {
+		endpointSlice := &discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("%s-%s-%s", service.Name, destination.GUID, strings.ToLower(string(addressType))),
+				Namespace: service.Namespace,
+			},
+		}
+
+		_, err = controllerutil.CreateOrPatch(ctx, r.client, endpointSlice, func() error {
+			endpointSlice.Labels = map[string]string{
+				discoveryv1.LabelServiceName:           service.Name,
+				discoveryv1.LabelManagedBy:             internalRouteEndpointSliceManager,
+				korifiv1alpha1.CFInternalRouteLabelKey: cfRoute.Name,
+			}
+			endpointSlice.AddressType = addressType
+			endpointSlice.Endpoints = endpoints
+
+			port := int32(destination.Port)
+			protocol := corev1.ProtocolTCP
+			endpointSlice.Ports = []discoveryv1.EndpointPort{{
+				Port:        &port,
+				Protocol:    &protocol,
+				AppProtocol: serviceAppProtocol(destination),
+			}}
+
+			return controllerutil.SetControllerReference(service, endpointSlice, r.scheme)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		names = append(names, endpointSlice.Name)
+	}
+
+	return names, nil
+}
+
+func (r *CFRouteReconciler) deleteOrphanedEndpointSlices(ctx context.Context, log logr.Logger, cfRoute *korifiv1alpha1.CFRoute, namespace string, endpointSliceNames map[string]bool) error {
+	endpointSlices := new(discoveryv1.EndpointSliceList)
+	err := r.client.List(ctx, endpointSlices,
+		client.InNamespace(namespace),
+		client.MatchingLabels{korifiv1alpha1.CFInternalRouteLabelKey: cfRoute.Name},
+	)
+	if err != nil {
+		log.Error(err, "failed to list internal route endpoint slices")
+		return err
+	}
+
+	for i := range endpointSlices.Items {
+		if endpointSliceNames[endpointSlices.Items[i].Name] {
+			continue
+		}
+
+		if err = r.client.Delete(ctx, &endpointSlices.Items[i]); client.IgnoreNotFound(err) != nil {
+			log.Error(err, "failed to delete internal route endpoint slice", "name", endpointSlices.Items[i].Name)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteInternalRouteServices deletes the headless services of the route, which live in the domain
+// namespace and are not garbage collected along with the route. Their EndpointSlices are owned by them
+func (r *CFRouteReconciler) deleteInternalRouteServices(ctx context.Context, log logr.Logger, cfRoute *korifiv1alpha1.CFRoute) error {
+	services := new(corev1.ServiceList)
+	err := r.client.List(ctx, services,
+		client.InNamespace(cfRoute.Spec.DomainRef.Namespace),
+		client.MatchingLabels{korifiv1alpha1.CFInternalRouteLabelKey: cfRoute.Name},
+	)
+	if err != nil {
+		log.Error(err, "failed to list internal route services")
+		return err
+	}
+
+	for i := range services.Items {
+		if err = r.client.Delete(ctx, &services.Items[i]); client.IgnoreNotFound(err) != nil {
+			log.Error(err, "failed to delete internal route service", "name", services.Items[i].Name)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isDestinationEndpointSlice matches the EndpointSlices of the route destination services, which inherit
+// the labels of their service
+func isDestinationEndpointSlice(obj client.Object) bool {
+	_, ok := obj.GetLabels()[korifiv1alpha1.CFRouteGUIDLabelKey]
+	return ok
+}
+
+// destinationEndpointSliceToRoute enqueues the route of the destination service, so that the endpoints
+// of internal routes follow the destination pods
+func (r *CFRouteReconciler) destinationEndpointSliceToRoute(obj client.Object) []reconcile.Request {
+	cfRoutes := new(korifiv1alpha1.CFRouteList)
+	err := r.client.List(context.Background(), cfRoutes, client.MatchingFields{
+		shared.IndexRouteDestinationAppName: obj.GetLabels()[korifiv1alpha1.CFAppGUIDLabelKey],
+	})
+	if err != nil {
+		r.log.Error(err, "failed to list routes", "endpointSlice", obj.GetName())
+		return []reconcile.Request{}
+	}
+
+	requests := []reconcile.Request{}
+	for i := range cfRoutes.Items {
+		if cfRoutes.Items[i].Name == obj.GetLabels()[korifiv1alpha1.CFRouteGUIDLabelKey] {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&cfRoutes.Items[i])})
+		}
+	}
+
+	return requests
+}
+
+func destinationServiceFQDN(cfRoute *korifiv1alpha1.CFRoute, destination korifiv1alpha1.Destination) string {
+	return generateServiceName(&destination) + "." + cfRoute.DestinationNamespace(destination) + ".svc.cluster.local"
+}
@@ -0,0 +1,154 @@
+package networking
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	"github.com/go-logr/logr"
+	contourv1 "github.com/projectcontour/contour/apis/projectcontour/v1"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"istio.io/api/networking/v1alpha3"
+	networkingv1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+var contourLoadBalancingStrategies = map[string]string{
+	korifiv1alpha1.RoundRobinLoadBalancing:      "RoundRobin",
+	korifiv1alpha1.LeastConnectionLoadBalancing: "WeightedLeastRequest",
+}
+
+var istioLoadBalancingAlgorithms = map[string]v1alpha3.LoadBalancerSettings_SimpleLB{
+	korifiv1alpha1.RoundRobinLoadBalancing:      v1alpha3.LoadBalancerSettings_ROUND_ROBIN,
+	korifiv1alpha1.LeastConnectionLoadBalancing: v1alpha3.LoadBalancerSettings_LEAST_REQUEST,
+}
+
+// applyRouteOptions translates the options of the route into policies of the HTTPProxy routes. The
+// route webhook guarantees that the options are supported and well formed
+func applyRouteOptions(cfRoute *korifiv1alpha1.CFRoute, routes []contourv1.Route) {
+	options := cfRoute.Spec.Options
+
+	for i := range routes {
+		if algorithm, ok := options[korifiv1alpha1.LoadBalancingRouteOption]; ok {
+			routes[i].LoadBalancerPolicy = &contourv1.LoadBalancerPolicy{
+				Strategy: contourLoadBalancingStrategies[algorithm],
+			}
+		}
+
+		requestTimeout, hasRequestTimeout := options[korifiv1alpha1.RequestTimeoutRouteOption]
+		idleTimeout, hasIdleTimeout := options[korifiv1alpha1.IdleTimeoutRouteOption]
+		if hasRequestTimeout || hasIdleTimeout {
+			routes[i].TimeoutPolicy = &contourv1.TimeoutPolicy{
+				Response: requestTimeout,
+				Idle:     idleTimeout,
+			}
+		}
+
+		if rateLimit, ok := options[korifiv1alpha1.RateLimitRouteOption]; ok {
+			routes[i].RateLimitPolicy = contourRateLimitPolicy(rateLimit)
+		}
+	}
+}
+
+// contourRateLimitPolicy limits the requests of the route on every Envoy instance, as Contour global
+// rate limits need an external rate limit service
+func contourRateLimitPolicy(rateLimit string) *contourv1.RateLimitPolicy {
+	requests, unit, _ := strings.Cut(rateLimit, "/")
+	count, _ := strconv.ParseUint(requests, 10, 32)
+
+	return &contourv1.RateLimitPolicy{
+		Local: &contourv1.LocalRateLimitPolicy{
+			Requests: uint32(count),
+			Unit:     unit,
+		},
+	}
+}
+
+// istioRequestTimeout returns the timeout of the VirtualService routes of the route, if any
+func istioRequestTimeout(cfRoute *korifiv1alpha1.CFRoute) *durationpb.Duration {
+	requestTimeout, ok := cfRoute.Spec.Options[korifiv1alpha1.RequestTimeoutRouteOption]
+	if !ok {
+		return nil
+	}
+
+	timeout, _ := time.ParseDuration(requestTimeout)
+	return durationpb.New(timeout)
+}
+
+// istioTrafficPolicy translates the options of the route applying to the connections of the ingress
+// gateway to the destinations of the route. It returns nil when the route has no such options
+func istioTrafficPolicy(cfRoute *korifiv1alpha1.CFRoute) *v1alpha3.TrafficPolicy {
+	options := cfRoute.Spec.Options
+	algorithm, hasAlgorithm := options[korifiv1alpha1.LoadBalancingRouteOption]
+	idleTimeout, hasIdleTimeout := options[korifiv1alpha1.IdleTimeoutRouteOption]
+	if !hasAlgorithm && !hasIdleTimeout {
+		return nil
+	}
+
+	trafficPolicy := &v1alpha3.TrafficPolicy{}
+	if hasAlgorithm {
+		trafficPolicy.LoadBalancer = &v1alpha3.LoadBalancerSettings{
+			LbPolicy: &v1alpha3.LoadBalancerSettings_Simple{Simple: istioLoadBalancingAlgorithms[algorithm]},
+		}
+	}
+
+	if hasIdleTimeout {
+		timeout, _ := time.ParseDuration(idleTimeout)
+		trafficPolicy.ConnectionPool = &v1alpha3.ConnectionPoolSettings{
+			Http: &v1alpha3.ConnectionPoolSettings_HTTPSettings{IdleTimeout: durationpb.New(timeout)},
+		}
+	}
+
+	return trafficPolicy
+}
+
+// reconcileDestinationRules applies the options of the route to the destination services with istio
+// DestinationRules. Istio looks DestinationRules up in the namespace of the service, which may be a space
+// the route is shared with, so the rules are owned by the destination services rather than the route
+func (r *CFRouteReconciler) reconcileDestinationRules(ctx context.Context, log logr.Logger, cfRoute *korifiv1alpha1.CFRoute) error {
+	log = log.WithName("reconcileDestinationRules")
+
+	trafficPolicy := istioTrafficPolicy(cfRoute)
+	for _, destination := range cfRoute.Spec.Destinations {
+		destinationRule := &networkingv1alpha3.DestinationRule{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      generateServiceName(&destination),
+				Namespace: cfRoute.DestinationNamespace(destination),
+			},
+		}
+
+		if trafficPolicy == nil {
+			if err := client.IgnoreNotFound(r.client.Delete(ctx, destinationRule)); err != nil {
+				log.Error(err, "failed to delete DestinationRule", "name", destinationRule.Name)
+				return err
+			}
+			continue
+		}
+
+		service := new(corev1.Service)
+		if err := r.client.Get(ctx, types.NamespacedName{Name: destinationRule.Name, Namespace: destinationRule.Namespace}, service); err != nil {
+			log.Error(err, "failed to get destination service", "name", destinationRule.Name)
+			return err
+		}
+
+		_, err := controllerutil.CreateOrPatch(ctx, r.client, destinationRule, func() error {
+			destinationRule.Spec.Host = destinationServiceFQDN(cfRoute, destination)
+			destinationRule.Spec.TrafficPolicy = trafficPolicy
+
+			return controllerutil.SetOwnerReference(service, destinationRule, r.scheme)
+		})
+		if err != nil {
+			log.Error(err, "failed to patch DestinationRule", "name", destinationRule.Name)
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,301 @@
+package networking
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+
+	"github.com/go-logr/logr"
+	contourv1 "github.com/projectcontour/contour/apis/projectcontour/v1"
+	"istio.io/api/networking/v1alpha3"
+	networkingv1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// The value of the X-CF-Forwarded-Url header, built by the ingress from the original request
+const forwardedURLHeaderValue = "%REQ(x-forwarded-proto)%://%REQ(:authority)%%REQ(:path)%"
+
+// routeService holds what the ingress needs to forward the traffic of a route through a route service
+type routeService struct {
+	url       *url.URL
+	signature string
+}
+
+func (s *routeService) host() string {
+	return s.url.Hostname()
+}
+
+func (s *routeService) isTLS() bool {
+	return s.url.Scheme == "https"
+}
+
+func (s *routeService) port() uint32 {
+	if port, err := strconv.ParseUint(s.url.Port(), 10, 32); err == nil {
+		return uint32(port)
+	}
+
+	if s.isTLS() {
+		return 443
+	}
+
+	return 80
+}
+
+func (s *routeService) path() string {
+	if s.url.Path == "" {
+		return "/"
+	}
+
+	return s.url.Path
+}
+
+func routeServiceResourceName(cfRoute *korifiv1alpha1.CFRoute) string {
+	return fmt.Sprintf("rs-%s", cfRoute.Name)
+}
+
+// getRouteService returns the route service the route is bound to, or nil if the route is not bound to a
+// ready route service
+func (r *CFRouteReconciler) getRouteService(ctx context.Context, log logr.Logger, cfRoute *korifiv1alpha1.CFRoute) (*routeService, error) {
+	log = log.WithName("getRouteService")
+
+	routeBindings := new(korifiv1alpha1.CFServiceRouteBindingList)
+	err := r.client.List(ctx, routeBindings,
+		client.InNamespace(cfRoute.Namespace),
+		client.MatchingFields{shared.IndexServiceRouteBindingRouteGUID: cfRoute.Name},
+	)
+	if err != nil {
+		log.Error(err, "failed to list service route bindings")
+		return nil, err
+	}
+
+	for _, binding := range routeBindings.Items {
+		if !binding.GetDeletionTimestamp().IsZero() || !meta.IsStatusConditionTrue(binding.Status.Conditions, korifiv1alpha1.ReadyConditionType) {
+			continue
+		}
+
+		routeServiceURL, err := url.Parse(binding.Status.RouteServiceURL)
+		if err != nil {
+			log.Error(err, "invalid route service URL", "serviceRouteBinding", binding.Name)
+			return nil, err
+		}
+
+		signatureSecret := new(corev1.Secret)
+		err = r.client.Get(ctx, types.NamespacedName{Name: binding.Status.SignatureSecretName, Namespace: binding.Namespace}, signatureSecret)
+		if err != nil {
+			log.Error(err, "failed to get route service signature secret", "serviceRouteBinding", binding.Name)
+			return nil, err
+		}
+
+		return &routeService{
+			url:       routeServiceURL,
+			signature: string(signatureSecret.Data[korifiv1alpha1.RouteServiceSignatureSecretKey]),
+		}, nil
+	}
+
+	return nil, nil
+}
+
+// routeServiceHTTPRoutes sends requests carrying the route service signature to the app destinations and
+// forwards everything else to the route service. The signature is removed from the requests reaching the
+// destinations, so that apps cannot use it to bypass the route service
+func routeServiceHTTPRoutes(rs *routeService, destinations []*v1alpha3.HTTPRouteDestination) []*v1alpha3.HTTPRoute {
+	return []*v1alpha3.HTTPRoute{
+		{
+			Match: []*v1alpha3.HTTPMatchRequest{{
+				// istio only matches lower case header names
+				Headers: map[string]*v1alpha3.StringMatch{
+					strings.ToLower(korifiv1alpha1.RouteServiceSignatureHeader): {MatchType: &v1alpha3.StringMatch_Exact{Exact: rs.signature}},
+				},
+			}},
+			Route: destinations,
+			Headers: &v1alpha3.Headers{
+				Request: &v1alpha3.Headers_HeaderOperations{
+					Remove: []string{korifiv1alpha1.RouteServiceSignatureHeader},
+				},
+			},
+		},
+		{
+			Route: []*v1alpha3.HTTPRouteDestination{{
+				Destination: &v1alpha3.Destination{
+					Host: rs.host(),
+					Port: &v1alpha3.PortSelector{Number: rs.port()},
+				},
+			}},
+			Rewrite: &v1alpha3.HTTPRewrite{
+				Authority: rs.url.Host,
+				Uri:       rs.path(),
+			},
+			Headers: &v1alpha3.Headers{
+				Request: &v1alpha3.Headers_HeaderOperations{
+					Set: map[string]string{
+						korifiv1alpha1.RouteServiceForwardedURLHeader: forwardedURLHeaderValue,
+						korifiv1alpha1.RouteServiceSignatureHeader:    rs.signature,
+					},
+				},
+			},
+		},
+	}
+}
+
+// reconcileRouteServiceEntry makes the route service reachable from the mesh, originating TLS for https route services
+func (r *CFRouteReconciler) reconcileRouteServiceEntry(ctx context.Context, log logr.Logger, cfRoute *korifiv1alpha1.CFRoute, rs *routeService) error {
+	log = log.WithName("reconcileRouteServiceEntry")
+
+	serviceEntry := &networkingv1alpha3.ServiceEntry{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      routeServiceResourceName(cfRoute),
+			Namespace: cfRoute.Namespace,
+		},
+	}
+	destinationRule := &networkingv1alpha3.DestinationRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      routeServiceResourceName(cfRoute),
+			Namespace: cfRoute.Namespace,
+		},
+	}
+
+	if rs == nil {
+		if err := client.IgnoreNotFound(r.client.Delete(ctx, serviceEntry)); err != nil {
+			log.Error(err, "failed to delete route service ServiceEntry")
+			return err
+		}
+
+		if err := client.IgnoreNotFound(r.client.Delete(ctx, destinationRule)); err != nil {
+			log.Error(err, "failed to delete route service DestinationRule")
+			return err
+		}
+
+		return nil
+	}
+
+	_, err := controllerutil.CreateOrPatch(ctx, r.client, serviceEntry, func() error {
+		serviceEntry.Spec.Hosts = []string{rs.host()}
+		serviceEntry.Spec.Location = v1alpha3.ServiceEntry_MESH_EXTERNAL
+		serviceEntry.Spec.Resolution = v1alpha3.ServiceEntry_DNS
+		serviceEntry.Spec.Ports = []*v1alpha3.Port{{
+			Number:   rs.port(),
+			Name:     "http",
+			Protocol: "HTTP",
+		}}
+
+		return controllerutil.SetOwnerReference(cfRoute, serviceEntry, r.scheme)
+	})
+	if err != nil {
+		log.Error(err, "failed to patch route service ServiceEntry")
+		return err
+	}
+
+	if !rs.isTLS() {
+		if err = client.IgnoreNotFound(r.client.Delete(ctx, destinationRule)); err != nil {
+			log.Error(err, "failed to delete route service DestinationRule")
+			return err
+		}
+
+		return nil
+	}
+
+	_, err = controllerutil.CreateOrPatch(ctx, r.client, destinationRule, func() error {
+		destinationRule.Spec.Host = rs.host()
+		destinationRule.Spec.TrafficPolicy = &v1alpha3.TrafficPolicy{
+			Tls: &v1alpha3.ClientTLSSettings{
+				Mode: v1alpha3.ClientTLSSettings_SIMPLE,
+				Sni:  rs.host(),
+			},
+		}
+
+		return controllerutil.SetOwnerReference(cfRoute, destinationRule, r.scheme)
+	})
+	if err != nil {
+		log.Error(err, "failed to patch route service DestinationRule")
+		return err
+	}
+
+	return nil
+}
+
+// createOrPatchRouteServiceExternalName creates the ExternalName Service Contour uses to reach the route service
+func (r *CFRouteReconciler) createOrPatchRouteServiceExternalName(ctx context.Context, log logr.Logger, cfRoute *korifiv1alpha1.CFRoute, rs *routeService) error {
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      routeServiceResourceName(cfRoute),
+			Namespace: cfRoute.Namespace,
+		},
+	}
+
+	_, err := controllerutil.CreateOrPatch(ctx, r.client, service, func() error {
+		service.Spec.Type = corev1.ServiceTypeExternalName
+		service.Spec.ExternalName = rs.host()
+		service.Spec.Ports = []corev1.ServicePort{{Port: int32(rs.port())}}
+
+		return controllerutil.SetOwnerReference(cfRoute, service, r.scheme)
+	})
+	if err != nil {
+		log.Error(err, "failed to patch route service ExternalName Service")
+	}
+
+	return err
+}
+
+// routeServiceProxyRoutes is the Contour equivalent of routeServiceHTTPRoutes
+func routeServiceProxyRoutes(cfRoute *korifiv1alpha1.CFRoute, rs *routeService, services []contourv1.Service) []contourv1.Route {
+	routeServiceService := contourv1.Service{
+		Name: routeServiceResourceName(cfRoute),
+		Port: int(rs.port()),
+	}
+	if rs.isTLS() {
+		protocol := "tls"
+		routeServiceService.Protocol = &protocol
+	}
+
+	prefix := cfRoute.Spec.Path
+	if prefix == "" {
+		prefix = "/"
+	}
+
+	return []contourv1.Route{
+		{
+			Conditions: append(pathConditions(cfRoute), contourv1.MatchCondition{
+				Header: &contourv1.HeaderMatchCondition{Name: korifiv1alpha1.RouteServiceSignatureHeader, Exact: rs.signature},
+			}),
+			Services:         services,
+			EnableWebsockets: true,
+			RequestHeadersPolicy: &contourv1.HeadersPolicy{
+				Remove: []string{korifiv1alpha1.RouteServiceSignatureHeader},
+			},
+		},
+		{
+			Conditions: pathConditions(cfRoute),
+			Services:   []contourv1.Service{routeServiceService},
+			PathRewritePolicy: &contourv1.PathRewritePolicy{
+				ReplacePrefix: []contourv1.ReplacePrefix{{Prefix: prefix, Replacement: rs.path()}},
+			},
+			RequestHeadersPolicy: &contourv1.HeadersPolicy{
+				Set: []contourv1.HeaderValue{
+					{Name: "Host", Value: rs.url.Host},
+					{Name: korifiv1alpha1.RouteServiceForwardedURLHeader, Value: forwardedURLHeaderValue},
+					{Name: korifiv1alpha1.RouteServiceSignatureHeader, Value: rs.signature},
+				},
+			},
+		},
+	}
+}
+
+func serviceRouteBindingToRoute(o client.Object) []reconcile.Request {
+	routeBinding, ok := o.(*korifiv1alpha1.CFServiceRouteBinding)
+	if !ok {
+		return []reconcile.Request{}
+	}
+
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: routeBinding.Spec.RouteRef.Name, Namespace: routeBinding.Namespace}}}
+}
@@ -9,16 +9,22 @@ import (
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/config"
 	. "code.cloudfoundry.org/korifi/controllers/controllers/networking"
+	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	contourv1 "github.com/projectcontour/contour/apis/projectcontour/v1"
+	networkingv1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -49,6 +55,9 @@ var _ = BeforeSuite(func() {
 		CRDDirectoryPaths: []string{
 			filepath.Join("..", "..", "..", "helm", "controllers", "templates", "crds"),
 			filepath.Join("..", "..", "..", "tests", "vendor", "contour"),
+			filepath.Join("..", "..", "..", "tests", "vendor", "istio"),
+			filepath.Join("..", "..", "..", "tests", "vendor", "gateway-api"),
+			filepath.Join("..", "..", "..", "tests", "vendor", "cert-manager", "cert-manager.crds.yaml"),
 		},
 		ErrorIfCRDPathMissing: true,
 	}
@@ -59,11 +68,35 @@ var _ = BeforeSuite(func() {
 
 	Expect(korifiv1alpha1.AddToScheme(scheme.Scheme)).To(Succeed())
 	Expect(contourv1.AddToScheme(scheme.Scheme)).To(Succeed())
+	Expect(networkingv1alpha3.AddToScheme(scheme.Scheme)).To(Succeed())
+	Expect(gatewayv1alpha2.AddToScheme(scheme.Scheme)).To(Succeed())
+	Expect(gatewayv1beta1.AddToScheme(scheme.Scheme)).To(Succeed())
 
 	k8sClient, err = client.New(cfg, client.Options{Scheme: scheme.Scheme})
 	Expect(err).NotTo(HaveOccurred())
 	Expect(k8sClient).NotTo(BeNil())
 
+	// the workloads gateway of the gateway-api ingress backend, which tcp listeners are added to
+	Expect(k8sClient.Create(context.Background(), &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "korifi"},
+	})).To(Succeed())
+	Expect(k8sClient.Create(context.Background(), &gatewayv1beta1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "korifi-workloads-gateway", Namespace: "korifi"},
+		Spec: gatewayv1beta1.GatewaySpec{
+			GatewayClassName: "korifi",
+			Listeners: []gatewayv1beta1.Listener{{
+				Name:     "https-apps",
+				Port:     443,
+				Protocol: gatewayv1beta1.HTTPSProtocolType,
+			}},
+		},
+	})).To(Succeed())
+
+	// the namespace of the istio ingress gateway, which domain certificates are copied to
+	Expect(k8sClient.Create(context.Background(), &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "istio-system"},
+	})).To(Succeed())
+
 	webhookInstallOptions := &testEnv.WebhookInstallOptions
 	k8sManager, err := ctrl.NewManager(cfg, ctrl.Options{
 		Scheme:             scheme.Scheme,
@@ -75,6 +108,35 @@ var _ = BeforeSuite(func() {
 	})
 	Expect(err).ToNot(HaveOccurred())
 
+	err = (NewCFNetworkPolicyReconciler(
+		k8sManager.GetClient(),
+		k8sManager.GetScheme(),
+		ctrl.Log.WithName("controllers").WithName("CFNetworkPolicy"),
+	)).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = shared.SetupIndexWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	//+kubebuilder:scaffold:scheme
+
+	go func() {
+		defer GinkgoRecover()
+		err = k8sManager.Start(ctx)
+		Expect(err).ToNot(HaveOccurred())
+	}()
+})
+
+// startCFRouteReconciler runs a CFRoute reconciler with the given ingress backend until the end of the
+// current spec, so that the specs of every backend run against the same test environment
+func startCFRouteReconciler(ingressBackend string) {
+	k8sManager, err := ctrl.NewManager(testEnv.Config, ctrl.Options{
+		Scheme:             scheme.Scheme,
+		LeaderElection:     false,
+		MetricsBindAddress: "0",
+	})
+	Expect(err).NotTo(HaveOccurred())
+
 	err = (NewCFRouteReconciler(
 		k8sManager.GetClient(),
 		k8sManager.GetScheme(),
@@ -86,18 +148,55 @@ var _ = BeforeSuite(func() {
 			},
 			WorkloadsTLSSecretName:      "korifi-workloads-ingress-cert",
 			WorkloadsTLSSecretNamespace: "korifi-controllers-system",
+			WorkloadsGatewayName:        "korifi-workloads-gateway",
+			WorkloadsGatewayNamespace:   "korifi",
+			IngressBackend:              ingressBackend,
 		},
 	)).SetupWithManager(k8sManager)
-	Expect(err).ToNot(HaveOccurred())
+	Expect(err).NotTo(HaveOccurred())
 
-	//+kubebuilder:scaffold:scheme
+	Expect(shared.SetupIndexWithManager(k8sManager)).To(Succeed())
+
+	ctx, cancelManager := context.WithCancel(context.Background())
+	DeferCleanup(cancelManager)
 
 	go func() {
 		defer GinkgoRecover()
-		err = k8sManager.Start(ctx)
-		Expect(err).ToNot(HaveOccurred())
+		Expect(k8sManager.Start(ctx)).To(Succeed())
 	}()
-})
+}
+
+// startCFDomainReconciler runs a CFDomain reconciler with the given ingress backend until the end of the
+// current spec
+func startCFDomainReconciler(ingressBackend string) {
+	k8sManager, err := ctrl.NewManager(testEnv.Config, ctrl.Options{
+		Scheme:             scheme.Scheme,
+		LeaderElection:     false,
+		MetricsBindAddress: "0",
+	})
+	Expect(err).NotTo(HaveOccurred())
+
+	err = (NewCFDomainReconciler(
+		k8sManager.GetClient(),
+		k8sManager.GetScheme(),
+		ctrl.Log.WithName("controllers").WithName("CFDomain"),
+		&config.ControllerConfig{
+			IngressBackend:          ingressBackend,
+			IngressGatewayNamespace: "istio-system",
+		},
+	)).SetupWithManager(k8sManager)
+	Expect(err).NotTo(HaveOccurred())
+
+	Expect(shared.SetupIndexWithManager(k8sManager)).To(Succeed())
+
+	ctx, cancelManager := context.WithCancel(context.Background())
+	DeferCleanup(cancelManager)
+
+	go func() {
+		defer GinkgoRecover()
+		Expect(k8sManager.Start(ctx)).To(Succeed())
+	}()
+}
 
 var _ = AfterSuite(func() {
 	cancel()
@@ -0,0 +1,260 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/syslog"
+	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+//counterfeiter:generate -o fake -fake-name SyslogForwarder . SyslogForwarder
+
+type SyslogForwarder interface {
+	Sync(owner string, sources []syslog.Source, drainURLs []string)
+}
+
+// CFAppSyslogDrainReconciler forwards the logs of app instances to the syslog drains of the
+// service instances bound to the app. Unlike the other reconcilers it never modifies the CFApp.
+type CFAppSyslogDrainReconciler struct {
+	k8sClient client.Client
+	forwarder SyslogForwarder
+	log       logr.Logger
+}
+
+func NewCFAppSyslogDrainReconciler(
+	k8sClient client.Client,
+	forwarder SyslogForwarder,
+	log logr.Logger,
+) *CFAppSyslogDrainReconciler {
+	return &CFAppSyslogDrainReconciler{k8sClient: k8sClient, forwarder: forwarder, log: log}
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfapps,verbs=get;list;watch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfservicebindings,verbs=get;list;watch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceinstances,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=pods/log,verbs=get
+
+func (r *CFAppSyslogDrainReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("namespace", req.Namespace, "name", req.Name)
+	owner := req.NamespacedName.String()
+
+	cfApp := new(korifiv1alpha1.CFApp)
+	err := r.k8sClient.Get(ctx, req.NamespacedName, cfApp)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			r.forwarder.Sync(owner, nil, nil)
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "unable to fetch CFApp")
+		return ctrl.Result{}, err
+	}
+
+	if !cfApp.GetDeletionTimestamp().IsZero() {
+		r.forwarder.Sync(owner, nil, nil)
+		return ctrl.Result{}, nil
+	}
+
+	drainURLs, err := r.getDrainURLs(ctx, cfApp)
+	if err != nil {
+		log.Error(err, "failed to get syslog drain urls")
+		return ctrl.Result{}, err
+	}
+
+	sources, err := r.getSources(ctx, cfApp)
+	if err != nil {
+		log.Error(err, "failed to list app pods")
+		return ctrl.Result{}, err
+	}
+
+	r.forwarder.Sync(owner, sources, drainURLs)
+
+	return ctrl.Result{}, nil
+}
+
+func (r *CFAppSyslogDrainReconciler) getDrainURLs(ctx context.Context, cfApp *korifiv1alpha1.CFApp) ([]string, error) {
+	bindings := new(korifiv1alpha1.CFServiceBindingList)
+	err := r.k8sClient.List(ctx, bindings,
+		client.InNamespace(cfApp.Namespace),
+		client.MatchingFields{shared.IndexServiceBindingAppGUID: cfApp.Name},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service bindings: %w", err)
+	}
+
+	urls := map[string]bool{}
+	for _, binding := range bindings.Items {
+		instance := new(korifiv1alpha1.CFServiceInstance)
+		err = r.k8sClient.Get(ctx, types.NamespacedName{Namespace: binding.ServiceNamespace(), Name: binding.Spec.Service.Name}, instance)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get service instance: %w", err)
+		}
+
+		if instance.Spec.SyslogDrainURL != "" {
+			urls[instance.Spec.SyslogDrainURL] = true
+		}
+	}
+
+	drainURLs := make([]string, 0, len(urls))
+	for url := range urls {
+		drainURLs = append(drainURLs, url)
+	}
+	sort.Strings(drainURLs)
+
+	return drainURLs, nil
+}
+
+func (r *CFAppSyslogDrainReconciler) getSources(ctx context.Context, cfApp *korifiv1alpha1.CFApp) ([]syslog.Source, error) {
+	pods := new(corev1.PodList)
+	err := r.k8sClient.List(ctx, pods,
+		client.InNamespace(cfApp.Namespace),
+		client.MatchingLabels{korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var sources []syslog.Source
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning || !pod.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+
+		sources = append(sources, syslog.Source{
+			Namespace: pod.Namespace,
+			PodName:   pod.Name,
+			Hostname:  cfApp.Spec.DisplayName,
+			AppName:   cfApp.Name,
+			ProcID:    procID(pod),
+		})
+	}
+
+	return sources, nil
+}
+
+// procID follows the CF convention for app instance log sources, e.g. [APP/PROC/WEB/0]
+func procID(pod corev1.Pod) string {
+	processType := pod.Labels[korifiv1alpha1.CFProcessTypeLabelKey]
+	if processType == "" {
+		processType = korifiv1alpha1.ProcessTypeWeb
+	}
+
+	index := "0"
+	if i := strings.LastIndex(pod.Name, "-"); i >= 0 {
+		index = pod.Name[i+1:]
+	}
+
+	return fmt.Sprintf("[APP/PROC/%s/%s]", strings.ToUpper(processType), index)
+}
+
+func (r *CFAppSyslogDrainReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("cfapp-syslog-drain").
+		For(&korifiv1alpha1.CFApp{}).
+		Watches(
+			&source.Kind{Type: &corev1.Pod{}},
+			handler.EnqueueRequestsFromMapFunc(podToApp),
+			builder.WithPredicates(predicate.NewPredicateFuncs(isAppPod)),
+		).
+		Watches(
+			&source.Kind{Type: &korifiv1alpha1.CFServiceBinding{}},
+			handler.EnqueueRequestsFromMapFunc(serviceBindingToApp),
+		).
+		Watches(
+			&source.Kind{Type: &korifiv1alpha1.CFServiceInstance{}},
+			handler.EnqueueRequestsFromMapFunc(r.serviceInstanceToApps),
+		).
+		Complete(r)
+}
+
+// AppPodsSelector selects the pods that run app instances. The manager cache can be restricted to
+// these pods, as they are the only ones the controllers are interested in.
+func AppPodsSelector() labels.Selector {
+	requirement, err := labels.NewRequirement(korifiv1alpha1.CFAppGUIDLabelKey, selection.Exists, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	return labels.NewSelector().Add(*requirement)
+}
+
+func isAppPod(o client.Object) bool {
+	_, ok := o.GetLabels()[korifiv1alpha1.CFAppGUIDLabelKey]
+	return ok
+}
+
+func podToApp(o client.Object) []reconcile.Request {
+	appGUID, ok := o.GetLabels()[korifiv1alpha1.CFAppGUIDLabelKey]
+	if !ok {
+		return []reconcile.Request{}
+	}
+
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: o.GetNamespace(), Name: appGUID}}}
+}
+
+func serviceBindingToApp(o client.Object) []reconcile.Request {
+	binding, ok := o.(*korifiv1alpha1.CFServiceBinding)
+	if !ok {
+		return []reconcile.Request{}
+	}
+
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: binding.Namespace, Name: binding.Spec.AppRef.Name}}}
+}
+
+func (r *CFAppSyslogDrainReconciler) serviceInstanceToApps(o client.Object) []reconcile.Request {
+	bindings := new(korifiv1alpha1.CFServiceBindingList)
+	err := r.k8sClient.List(context.Background(), bindings,
+		client.MatchingFields{shared.IndexServiceBindingServiceInstanceGUID: o.GetName()},
+	)
+	if err != nil {
+		return []reconcile.Request{}
+	}
+
+	requests := []reconcile.Request{}
+	for _, binding := range bindings.Items {
+		if binding.ServiceNamespace() != o.GetNamespace() {
+			continue
+		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: binding.Namespace, Name: binding.Spec.AppRef.Name},
+		})
+	}
+
+	return requests
+}
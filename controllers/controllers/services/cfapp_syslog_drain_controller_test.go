@@ -0,0 +1,152 @@
+package services_test
+
+import (
+	"context"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/syslog"
+	. "code.cloudfoundry.org/korifi/controllers/controllers/workloads/testutils"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("CFAppSyslogDrain", func() {
+	var (
+		namespace         *corev1.Namespace
+		cfApp             *korifiv1alpha1.CFApp
+		cfServiceInstance *korifiv1alpha1.CFServiceInstance
+		cfServiceBinding  *korifiv1alpha1.CFServiceBinding
+		pod               *corev1.Pod
+	)
+
+	// lastSync returns the arguments of the most recent forwarder sync for the app
+	lastSync := func() ([]syslog.Source, []string) {
+		owner := cfApp.Namespace + "/" + cfApp.Name
+		for i := syslogForwarder.SyncCallCount() - 1; i >= 0; i-- {
+			actualOwner, sources, drainURLs := syslogForwarder.SyncArgsForCall(i)
+			if actualOwner == owner {
+				return sources, drainURLs
+			}
+		}
+		return nil, nil
+	}
+
+	BeforeEach(func() {
+		namespace = BuildNamespaceObject(GenerateGUID())
+		Expect(k8sClient.Create(context.Background(), namespace)).To(Succeed())
+
+		cfApp = BuildCFAppCRObject(GenerateGUID(), namespace.Name)
+		Expect(k8sClient.Create(context.Background(), cfApp)).To(Succeed())
+
+		cfServiceInstance = &korifiv1alpha1.CFServiceInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      GenerateGUID(),
+				Namespace: namespace.Name,
+			},
+			Spec: korifiv1alpha1.CFServiceInstanceSpec{
+				DisplayName:    "drain",
+				SecretName:     "drain-secret",
+				Type:           "user-provided",
+				SyslogDrainURL: "syslog://logs.example.com:514",
+			},
+		}
+		Expect(k8sClient.Create(context.Background(), cfServiceInstance)).To(Succeed())
+
+		cfServiceBinding = &korifiv1alpha1.CFServiceBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      GenerateGUID(),
+				Namespace: namespace.Name,
+			},
+			Spec: korifiv1alpha1.CFServiceBindingSpec{
+				Service: corev1.ObjectReference{
+					Kind:       "ServiceInstance",
+					Name:       cfServiceInstance.Name,
+					APIVersion: "korifi.cloudfoundry.org/v1alpha1",
+				},
+				AppRef: corev1.LocalObjectReference{
+					Name: cfApp.Name,
+				},
+			},
+		}
+		Expect(k8sClient.Create(context.Background(), cfServiceBinding)).To(Succeed())
+
+		pod = &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      cfApp.Name + "-web-1",
+				Namespace: namespace.Name,
+				Labels: map[string]string{
+					korifiv1alpha1.CFAppGUIDLabelKey:     cfApp.Name,
+					korifiv1alpha1.CFProcessTypeLabelKey: "web",
+				},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "application", Image: "app-image"}},
+			},
+		}
+		Expect(k8sClient.Create(context.Background(), pod)).To(Succeed())
+		Expect(k8s.Patch(context.Background(), k8sClient, pod, func() {
+			pod.Status.Phase = corev1.PodRunning
+		})).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(context.Background(), namespace)).To(Succeed())
+	})
+
+	It("forwards the logs of the running app pods to the drains of the bound service instances", func() {
+		Eventually(func(g Gomega) {
+			sources, drainURLs := lastSync()
+			g.Expect(drainURLs).To(ConsistOf("syslog://logs.example.com:514"))
+			g.Expect(sources).To(ConsistOf(syslog.Source{
+				Namespace: namespace.Name,
+				PodName:   pod.Name,
+				Hostname:  cfApp.Spec.DisplayName,
+				AppName:   cfApp.Name,
+				ProcID:    "[APP/PROC/WEB/1]",
+			}))
+		}).Should(Succeed())
+	})
+
+	When("the service instance drain url is removed", func() {
+		JustBeforeEach(func() {
+			Eventually(func(g Gomega) {
+				_, drainURLs := lastSync()
+				g.Expect(drainURLs).NotTo(BeEmpty())
+			}).Should(Succeed())
+
+			Expect(k8s.Patch(context.Background(), k8sClient, cfServiceInstance, func() {
+				cfServiceInstance.Spec.SyslogDrainURL = ""
+			})).To(Succeed())
+		})
+
+		It("stops forwarding", func() {
+			Eventually(func(g Gomega) {
+				_, drainURLs := lastSync()
+				g.Expect(drainURLs).To(BeEmpty())
+			}).Should(Succeed())
+		})
+	})
+
+	When("the app is deleted", func() {
+		JustBeforeEach(func() {
+			Eventually(func(g Gomega) {
+				sources, _ := lastSync()
+				g.Expect(sources).NotTo(BeEmpty())
+			}).Should(Succeed())
+
+			Expect(k8sClient.Delete(context.Background(), cfApp)).To(Succeed())
+		})
+
+		It("stops forwarding", func() {
+			Eventually(func(g Gomega) {
+				sources, drainURLs := lastSync()
+				g.Expect(sources).To(BeEmpty())
+				g.Expect(drainURLs).To(BeEmpty())
+			}).Should(Succeed())
+		})
+	})
+})
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 	"github.com/go-logr/logr"
 	servicebindingv1beta1 "github.com/servicebinding/service-binding-controller/apis/v1beta1"
@@ -36,6 +37,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 // CFServiceBindingReconciler reconciles a CFServiceBinding object
@@ -63,31 +67,55 @@ const (
 
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfservicebindings,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfservicebindings/status,verbs=get;update;patch
-//+kubebuilder:rbac:groups=servicebinding.io,resources=servicebindings,verbs=get;list;create;update;patch;watch
+//+kubebuilder:rbac:groups=servicebinding.io,resources=servicebindings,verbs=get;list;create;update;patch;watch;delete
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
 
 func (r *CFServiceBindingReconciler) ReconcileResource(ctx context.Context, cfServiceBinding *korifiv1alpha1.CFServiceBinding) (ctrl.Result, error) {
 	instance := new(korifiv1alpha1.CFServiceInstance)
-	err := r.k8sClient.Get(ctx, types.NamespacedName{Name: cfServiceBinding.Spec.Service.Name, Namespace: cfServiceBinding.Namespace}, instance)
+	err := r.k8sClient.Get(ctx, types.NamespacedName{Name: cfServiceBinding.Spec.Service.Name, Namespace: cfServiceBinding.ServiceNamespace()}, instance)
 	if err != nil {
 		// Unlike with CFApp cascading delete, CFServiceInstance delete cleans up CFServiceBindings itself as part of finalizing,
 		// so we do not check for deletion timestamp before returning here.
 		return r.handleGetError(ctx, err, cfServiceBinding, BindingSecretAvailableCondition, "ServiceInstanceNotFound", "Service instance")
 	}
 
-	err = controllerutil.SetOwnerReference(instance, cfServiceBinding, r.scheme)
-	if err != nil {
-		r.log.Error(err, "Error when making the service instance owner of the service binding")
-		return ctrl.Result{}, err
+	isShared := instance.Namespace != cfServiceBinding.Namespace
+	if isShared && !instance.IsSharedWith(cfServiceBinding.Namespace) {
+		cfServiceBinding.Status.Binding = corev1.LocalObjectReference{}
+		meta.SetStatusCondition(&cfServiceBinding.Status.Conditions, metav1.Condition{
+			Type:    BindingSecretAvailableCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  "ServiceInstanceNotShared",
+			Message: "Service instance is not shared with this space",
+		})
+		return ctrl.Result{}, nil
+	}
+
+	// Owner references cannot cross namespaces, so bindings to shared instances are not owned by the instance
+	if !isShared {
+		err = controllerutil.SetOwnerReference(instance, cfServiceBinding, r.scheme)
+		if err != nil {
+			r.log.Error(err, "Error when making the service instance owner of the service binding")
+			return ctrl.Result{}, err
+		}
 	}
 
 	secret := new(corev1.Secret)
 	// Note: is there a reason to fetch the secret name from the service instance spec?
-	err = r.k8sClient.Get(ctx, types.NamespacedName{Name: instance.Spec.SecretName, Namespace: cfServiceBinding.Namespace}, secret)
+	err = r.k8sClient.Get(ctx, types.NamespacedName{Name: instance.Spec.SecretName, Namespace: instance.Namespace}, secret)
 	if err != nil {
 		return r.handleGetError(ctx, err, cfServiceBinding, BindingSecretAvailableCondition, "SecretNotFound", "Binding secret")
 	}
 
-	cfServiceBinding.Status.Binding.Name = instance.Spec.SecretName
+	if isShared {
+		secret, err = r.copySharedSecret(ctx, cfServiceBinding, secret)
+		if err != nil {
+			r.log.Error(err, "Error when copying the shared service instance secret", "CFServiceBinding", cfServiceBinding.Name)
+			return ctrl.Result{}, err
+		}
+	}
+
+	cfServiceBinding.Status.Binding.Name = secret.Name
 	meta.SetStatusCondition(&cfServiceBinding.Status.Conditions, metav1.Condition{
 		Type:    BindingSecretAvailableCondition,
 		Status:  metav1.ConditionTrue,
@@ -128,6 +156,16 @@ func (r *CFServiceBindingReconciler) ReconcileResource(ctx context.Context, cfSe
 		},
 	}
 
+	if !cfApp.IsServiceBindingK8sEnabled() {
+		err = r.k8sClient.Delete(ctx, &actualSBServiceBinding)
+		if client.IgnoreNotFound(err) != nil {
+			r.log.Error(err, "Error deleting servicebinding.io ServiceBinding")
+			return ctrl.Result{}, err
+		}
+
+		return ctrl.Result{}, nil
+	}
+
 	desiredSBServiceBinding := generateDesiredServiceBinding(&actualSBServiceBinding, cfServiceBinding, cfApp, secret)
 
 	_, err = controllerutil.CreateOrPatch(ctx, r.k8sClient, &actualSBServiceBinding, sbServiceBindingMutateFn(&actualSBServiceBinding, desiredSBServiceBinding))
@@ -139,6 +177,37 @@ func (r *CFServiceBindingReconciler) ReconcileResource(ctx context.Context, cfSe
 	return ctrl.Result{}, nil
 }
 
+// copySharedSecret makes the credentials of a service instance shared from another space available
+// in the namespace of the binding
+func (r *CFServiceBindingReconciler) copySharedSecret(ctx context.Context, cfServiceBinding *korifiv1alpha1.CFServiceBinding, instanceSecret *corev1.Secret) (*corev1.Secret, error) {
+	sharedSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      sharedSecretName(cfServiceBinding),
+			Namespace: cfServiceBinding.Namespace,
+		},
+	}
+
+	_, err := controllerutil.CreateOrPatch(ctx, r.k8sClient, sharedSecret, func() error {
+		if sharedSecret.Labels == nil {
+			sharedSecret.Labels = map[string]string{}
+		}
+		sharedSecret.Labels[ServiceBindingGUIDLabel] = cfServiceBinding.Name
+		sharedSecret.Type = instanceSecret.Type
+		sharedSecret.Data = instanceSecret.Data
+
+		return controllerutil.SetControllerReference(cfServiceBinding, sharedSecret, r.scheme)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sharedSecret, nil
+}
+
+func sharedSecretName(cfServiceBinding *korifiv1alpha1.CFServiceBinding) string {
+	return fmt.Sprintf("cf-binding-%s-shared", cfServiceBinding.Name)
+}
+
 func (r *CFServiceBindingReconciler) handleGetError(ctx context.Context, err error, cfServiceBinding *korifiv1alpha1.CFServiceBinding, conditionType, notFoundReason, objectType string) (ctrl.Result, error) {
 	cfServiceBinding.Status.Binding = corev1.LocalObjectReference{}
 	if apierrors.IsNotFound(err) {
@@ -217,5 +286,54 @@ func generateDesiredServiceBinding(actualServiceBinding *servicebindingv1beta1.S
 // SetupWithManager sets up the controller with the Manager.
 func (r *CFServiceBindingReconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&korifiv1alpha1.CFServiceBinding{})
+		For(&korifiv1alpha1.CFServiceBinding{}).
+		Owns(&corev1.Secret{}).
+		Watches(
+			&source.Kind{Type: &korifiv1alpha1.CFServiceInstance{}},
+			handler.EnqueueRequestsFromMapFunc(r.serviceInstanceToServiceBindings),
+		).
+		Watches(
+			&source.Kind{Type: &korifiv1alpha1.CFApp{}},
+			handler.EnqueueRequestsFromMapFunc(r.appToServiceBindings),
+		)
+}
+
+func (r *CFServiceBindingReconciler) appToServiceBindings(o client.Object) []reconcile.Request {
+	serviceBindings := new(korifiv1alpha1.CFServiceBindingList)
+	err := r.k8sClient.List(context.Background(), serviceBindings,
+		client.InNamespace(o.GetNamespace()),
+		client.MatchingFields{shared.IndexServiceBindingAppGUID: o.GetName()},
+	)
+	if err != nil {
+		r.log.Error(err, "failed to list service bindings", "app", o.GetName())
+		return []reconcile.Request{}
+	}
+
+	requests := []reconcile.Request{}
+	for _, binding := range serviceBindings.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: binding.Name, Namespace: binding.Namespace}})
+	}
+
+	return requests
+}
+
+func (r *CFServiceBindingReconciler) serviceInstanceToServiceBindings(o client.Object) []reconcile.Request {
+	serviceBindings := new(korifiv1alpha1.CFServiceBindingList)
+	err := r.k8sClient.List(context.Background(), serviceBindings,
+		client.MatchingFields{shared.IndexServiceBindingServiceInstanceGUID: o.GetName()},
+	)
+	if err != nil {
+		r.log.Error(err, "failed to list service bindings", "serviceInstance", o.GetName())
+		return []reconcile.Request{}
+	}
+
+	requests := []reconcile.Request{}
+	for _, binding := range serviceBindings.Items {
+		if binding.ServiceNamespace() != o.GetNamespace() {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: binding.Name, Namespace: binding.Namespace}})
+	}
+
+	return requests
 }
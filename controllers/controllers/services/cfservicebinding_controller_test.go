@@ -7,6 +7,7 @@ import (
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services"
 	. "code.cloudfoundry.org/korifi/controllers/controllers/workloads/testutils"
+	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -14,6 +15,7 @@ import (
 	. "github.com/onsi/gomega/gstruct"
 	servicebindingv1beta1 "github.com/servicebinding/service-binding-controller/apis/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -184,6 +186,48 @@ var _ = Describe("CFServiceBinding", func() {
 		}).Should(Succeed())
 	})
 
+	When("the service-binding-k8s feature is disabled for the app", func() {
+		BeforeEach(func() {
+			Expect(k8s.PatchResource(context.Background(), k8sClient, desiredCFApp, func() {
+				desiredCFApp.Spec.ServiceBindingK8sEnabled = tools.PtrTo(false)
+			})).To(Succeed())
+		})
+
+		It("does not create a servicebinding.io ServiceBinding", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cfServiceBinding), cfServiceBinding)).To(Succeed())
+				g.Expect(meta.IsStatusConditionTrue(cfServiceBinding.Status.Conditions, services.VCAPServicesSecretAvailableCondition)).To(BeTrue())
+			}).Should(Succeed())
+
+			Consistently(func(g Gomega) {
+				sbServiceBinding := servicebindingv1beta1.ServiceBinding{}
+				err := k8sClient.Get(context.Background(), types.NamespacedName{Name: fmt.Sprintf("cf-binding-%s", cfServiceBindingGUID), Namespace: namespace.Name}, &sbServiceBinding)
+				g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			}, "1s").Should(Succeed())
+		})
+	})
+
+	When("the service-binding-k8s feature is disabled after the binding is created", func() {
+		JustBeforeEach(func() {
+			Eventually(func(g Gomega) {
+				sbServiceBinding := servicebindingv1beta1.ServiceBinding{}
+				g.Expect(k8sClient.Get(context.Background(), types.NamespacedName{Name: fmt.Sprintf("cf-binding-%s", cfServiceBindingGUID), Namespace: namespace.Name}, &sbServiceBinding)).To(Succeed())
+			}).Should(Succeed())
+
+			Expect(k8s.PatchResource(context.Background(), k8sClient, desiredCFApp, func() {
+				desiredCFApp.Spec.ServiceBindingK8sEnabled = tools.PtrTo(false)
+			})).To(Succeed())
+		})
+
+		It("deletes the servicebinding.io ServiceBinding", func() {
+			Eventually(func(g Gomega) {
+				sbServiceBinding := servicebindingv1beta1.ServiceBinding{}
+				err := k8sClient.Get(context.Background(), types.NamespacedName{Name: fmt.Sprintf("cf-binding-%s", cfServiceBindingGUID), Namespace: namespace.Name}, &sbServiceBinding)
+				g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			}).Should(Succeed())
+		})
+	})
+
 	When("the referenced secret does not exist", func() {
 		var otherSecret *corev1.Secret
 
@@ -263,4 +307,101 @@ var _ = Describe("CFServiceBinding", func() {
 			})
 		})
 	})
+
+	When("the service instance is shared from another namespace", func() {
+		var (
+			instanceNamespace *corev1.Namespace
+			sharedInstance    *korifiv1alpha1.CFServiceInstance
+		)
+
+		BeforeEach(func() {
+			ctx := context.Background()
+			instanceNamespace = BuildNamespaceObject(GenerateGUID())
+			Expect(k8sClient.Create(ctx, instanceNamespace)).To(Succeed())
+
+			sharedSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "shared-instance-secret",
+					Namespace: instanceNamespace.Name,
+				},
+				StringData: map[string]string{
+					"type":     "mysql",
+					"password": "s3cr3t",
+				},
+			}
+			Expect(k8sClient.Create(ctx, sharedSecret)).To(Succeed())
+
+			sharedInstance = &korifiv1alpha1.CFServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "shared-service-instance-guid",
+					Namespace: instanceNamespace.Name,
+				},
+				Spec: korifiv1alpha1.CFServiceInstanceSpec{
+					DisplayName:  "shared-service-instance-name",
+					SecretName:   sharedSecret.Name,
+					Type:         "user-provided",
+					Tags:         []string{},
+					SharedSpaces: []string{namespace.Name},
+				},
+			}
+			Expect(k8sClient.Create(ctx, sharedInstance)).To(Succeed())
+
+			cfServiceBinding.Spec.Service.Name = sharedInstance.Name
+			cfServiceBinding.Spec.Service.Namespace = instanceNamespace.Name
+		})
+
+		AfterEach(func() {
+			Expect(k8sClient.Delete(context.Background(), instanceNamespace)).To(Succeed())
+		})
+
+		It("copies the credentials secret into the binding namespace", func() {
+			Eventually(func(g Gomega) {
+				copiedSecret := new(corev1.Secret)
+				g.Expect(k8sClient.Get(context.Background(), types.NamespacedName{Name: fmt.Sprintf("cf-binding-%s-shared", cfServiceBindingGUID), Namespace: namespace.Name}, copiedSecret)).To(Succeed())
+				g.Expect(copiedSecret.Data).To(MatchAllKeys(Keys{
+					"type":     BeEquivalentTo("mysql"),
+					"password": BeEquivalentTo("s3cr3t"),
+				}))
+				g.Expect(copiedSecret.Labels).To(HaveKeyWithValue(services.ServiceBindingGUIDLabel, cfServiceBindingGUID))
+				g.Expect(copiedSecret.OwnerReferences).To(ConsistOf(HaveField("Name", cfServiceBindingGUID)))
+			}).Should(Succeed())
+		})
+
+		It("points the binding status to the copied secret", func() {
+			Eventually(func(g Gomega) {
+				updatedCFServiceBinding := new(korifiv1alpha1.CFServiceBinding)
+				g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cfServiceBinding), updatedCFServiceBinding)).To(Succeed())
+				g.Expect(updatedCFServiceBinding.Status.Binding.Name).To(Equal(fmt.Sprintf("cf-binding-%s-shared", cfServiceBindingGUID)))
+				g.Expect(meta.IsStatusConditionTrue(updatedCFServiceBinding.Status.Conditions, services.BindingSecretAvailableCondition)).To(BeTrue())
+				g.Expect(updatedCFServiceBinding.GetOwnerReferences()).To(BeEmpty())
+			}).Should(Succeed())
+		})
+
+		When("the service instance is unshared from the binding namespace", func() {
+			JustBeforeEach(func() {
+				Eventually(func(g Gomega) {
+					updatedCFServiceBinding := new(korifiv1alpha1.CFServiceBinding)
+					g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cfServiceBinding), updatedCFServiceBinding)).To(Succeed())
+					g.Expect(meta.IsStatusConditionTrue(updatedCFServiceBinding.Status.Conditions, services.BindingSecretAvailableCondition)).To(BeTrue())
+				}).Should(Succeed())
+
+				Expect(k8s.Patch(context.Background(), k8sClient, sharedInstance, func() {
+					sharedInstance.Spec.SharedSpaces = nil
+				})).To(Succeed())
+			})
+
+			It("marks the binding secret as unavailable", func() {
+				Eventually(func(g Gomega) {
+					updatedCFServiceBinding := new(korifiv1alpha1.CFServiceBinding)
+					g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cfServiceBinding), updatedCFServiceBinding)).To(Succeed())
+					g.Expect(updatedCFServiceBinding.Status.Binding.Name).To(BeEmpty())
+					g.Expect(updatedCFServiceBinding.Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+						"Type":   Equal(services.BindingSecretAvailableCondition),
+						"Status": Equal(metav1.ConditionFalse),
+						"Reason": Equal("ServiceInstanceNotShared"),
+					})))
+				}).Should(Succeed())
+			})
+		})
+	})
 })
@@ -57,53 +57,82 @@ func NewCFServiceInstanceReconciler(
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceinstances/status,verbs=get;update;patch
 
 func (r *CFServiceInstanceReconciler) ReconcileResource(ctx context.Context, cfServiceInstance *korifiv1alpha1.CFServiceInstance) (ctrl.Result, error) {
+	operationType := lastOperationType(cfServiceInstance)
+	cfServiceInstance.Status.ObservedGeneration = cfServiceInstance.Generation
+
 	secret := new(corev1.Secret)
 	err := r.k8sClient.Get(ctx, types.NamespacedName{Name: cfServiceInstance.Spec.SecretName, Namespace: cfServiceInstance.Namespace}, secret)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
-			cfServiceInstance.Status = bindSecretUnavailableStatus(cfServiceInstance, "SecretNotFound", "Binding secret does not exist")
+			setBindSecretUnavailable(cfServiceInstance, "SecretNotFound", "Binding secret does not exist")
+			setLastOperation(cfServiceInstance, operationType, korifiv1alpha1.InProgressState, "Waiting for the credentials secret")
 			return ctrl.Result{RequeueAfter: 2 * time.Second}, nil
 		}
 
-		cfServiceInstance.Status = bindSecretUnavailableStatus(cfServiceInstance, "UnknownError", "Error occurred while fetching secret: "+err.Error())
+		setBindSecretUnavailable(cfServiceInstance, "UnknownError", "Error occurred while fetching secret: "+err.Error())
+		setLastOperation(cfServiceInstance, operationType, korifiv1alpha1.FailedState, "Error occurred while fetching secret: "+err.Error())
 		return ctrl.Result{}, err
 	}
 
-	cfServiceInstance.Status = bindSecretAvailableStatus(cfServiceInstance)
+	setBindSecretAvailable(cfServiceInstance)
+	setLastOperation(cfServiceInstance, operationType, korifiv1alpha1.SucceededState, "Operation succeeded")
 	return ctrl.Result{}, nil
 }
 
-func bindSecretAvailableStatus(cfServiceInstance *korifiv1alpha1.CFServiceInstance) korifiv1alpha1.CFServiceInstanceStatus {
-	status := korifiv1alpha1.CFServiceInstanceStatus{
-		Binding: corev1.LocalObjectReference{
-			Name: cfServiceInstance.Spec.SecretName,
-		},
-		Conditions: cfServiceInstance.Status.Conditions,
+func setBindSecretAvailable(cfServiceInstance *korifiv1alpha1.CFServiceInstance) {
+	cfServiceInstance.Status.Binding = corev1.LocalObjectReference{
+		Name: cfServiceInstance.Spec.SecretName,
 	}
 
-	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+	meta.SetStatusCondition(&cfServiceInstance.Status.Conditions, metav1.Condition{
 		Type:   BindingSecretAvailableCondition,
 		Status: metav1.ConditionTrue,
 		Reason: "SecretFound",
 	})
-
-	return status
 }
 
-func bindSecretUnavailableStatus(cfServiceInstance *korifiv1alpha1.CFServiceInstance, reason, message string) korifiv1alpha1.CFServiceInstanceStatus {
-	status := korifiv1alpha1.CFServiceInstanceStatus{
-		Binding:    corev1.LocalObjectReference{},
-		Conditions: cfServiceInstance.Status.Conditions,
-	}
+func setBindSecretUnavailable(cfServiceInstance *korifiv1alpha1.CFServiceInstance, reason, message string) {
+	cfServiceInstance.Status.Binding = corev1.LocalObjectReference{}
 
-	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+	meta.SetStatusCondition(&cfServiceInstance.Status.Conditions, metav1.Condition{
 		Type:    BindingSecretAvailableCondition,
 		Status:  metav1.ConditionFalse,
 		Reason:  reason,
 		Message: message,
 	})
+}
+
+// lastOperationType returns "create" until the instance has been successfully created and
+// "update" for every later change of its spec
+func lastOperationType(cfServiceInstance *korifiv1alpha1.CFServiceInstance) korifiv1alpha1.OperationType {
+	lastOperation := cfServiceInstance.Status.LastOperation
+	if lastOperation == nil {
+		return korifiv1alpha1.CreateOperation
+	}
+
+	if cfServiceInstance.Generation == cfServiceInstance.Status.ObservedGeneration {
+		return lastOperation.Type
+	}
 
-	return status
+	if lastOperation.Type == korifiv1alpha1.CreateOperation && lastOperation.State != korifiv1alpha1.SucceededState {
+		return korifiv1alpha1.CreateOperation
+	}
+
+	return korifiv1alpha1.UpdateOperation
+}
+
+func setLastOperation(cfServiceInstance *korifiv1alpha1.CFServiceInstance, operationType korifiv1alpha1.OperationType, state korifiv1alpha1.OperationState, description string) {
+	lastOperation := cfServiceInstance.Status.LastOperation
+	if lastOperation != nil && lastOperation.Type == operationType && lastOperation.State == state && lastOperation.Description == description {
+		return
+	}
+
+	cfServiceInstance.Status.LastOperation = &korifiv1alpha1.LastOperation{
+		Type:        operationType,
+		State:       state,
+		Description: description,
+		UpdatedAt:   metav1.Now(),
+	}
 }
 
 func (r *CFServiceInstanceReconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
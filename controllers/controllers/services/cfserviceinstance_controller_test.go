@@ -8,6 +8,7 @@ import (
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	. "code.cloudfoundry.org/korifi/controllers/controllers/workloads/testutils"
+	"code.cloudfoundry.org/korifi/tools/k8s"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
@@ -76,11 +77,64 @@ var _ = Describe("CFServiceInstance", func() {
 		}).Should(Succeed())
 	})
 
+	It("records a succeeded create operation", func() {
+		Eventually(func(g Gomega) {
+			updatedCFServiceInstance := new(korifiv1alpha1.CFServiceInstance)
+			g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cfServiceInstance), updatedCFServiceInstance)).To(Succeed())
+
+			g.Expect(updatedCFServiceInstance.Status.ObservedGeneration).To(Equal(updatedCFServiceInstance.Generation))
+			g.Expect(updatedCFServiceInstance.Status.LastOperation).To(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":        Equal(korifiv1alpha1.CreateOperation),
+				"State":       Equal(korifiv1alpha1.SucceededState),
+				"Description": Equal("Operation succeeded"),
+				"UpdatedAt":   Not(BeZero()),
+			})))
+		}).Should(Succeed())
+	})
+
+	When("the service instance is updated", func() {
+		JustBeforeEach(func() {
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cfServiceInstance), cfServiceInstance)).To(Succeed())
+				g.Expect(cfServiceInstance.Status.LastOperation).To(PointTo(HaveField("State", korifiv1alpha1.SucceededState)))
+			}).Should(Succeed())
+
+			Expect(k8s.PatchResource(context.Background(), k8sClient, cfServiceInstance, func() {
+				cfServiceInstance.Spec.Tags = []string{"new-tag"}
+			})).To(Succeed())
+		})
+
+		It("records a succeeded update operation", func() {
+			Eventually(func(g Gomega) {
+				updatedCFServiceInstance := new(korifiv1alpha1.CFServiceInstance)
+				g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cfServiceInstance), updatedCFServiceInstance)).To(Succeed())
+
+				g.Expect(updatedCFServiceInstance.Status.LastOperation).To(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(korifiv1alpha1.UpdateOperation),
+					"State": Equal(korifiv1alpha1.SucceededState),
+				})))
+			}).Should(Succeed())
+		})
+	})
+
 	When("the referenced secret does not exist", func() {
 		BeforeEach(func() {
 			cfServiceInstance.Spec.SecretName = "other-secret-name"
 		})
 
+		It("records an in progress create operation", func() {
+			Eventually(func(g Gomega) {
+				updatedCFServiceInstance := new(korifiv1alpha1.CFServiceInstance)
+				g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cfServiceInstance), updatedCFServiceInstance)).To(Succeed())
+
+				g.Expect(updatedCFServiceInstance.Status.LastOperation).To(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":        Equal(korifiv1alpha1.CreateOperation),
+					"State":       Equal(korifiv1alpha1.InProgressState),
+					"Description": Equal("Waiting for the credentials secret"),
+				})))
+			}).Should(Succeed())
+		})
+
 		It("sets the BindingSecretAvailable condition to false in the CFServiceInstance status", func() {
 			Eventually(func(g Gomega) {
 				updatedCFServiceInstance := new(korifiv1alpha1.CFServiceInstance)
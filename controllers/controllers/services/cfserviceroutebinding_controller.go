@@ -0,0 +1,189 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const signatureLength = 32
+
+// CFServiceRouteBindingReconciler reconciles a CFServiceRouteBinding object
+type CFServiceRouteBindingReconciler struct {
+	k8sClient client.Client
+	scheme    *runtime.Scheme
+	log       logr.Logger
+}
+
+func NewCFServiceRouteBindingReconciler(
+	k8sClient client.Client,
+	scheme *runtime.Scheme,
+	log logr.Logger,
+) *k8s.PatchingReconciler[korifiv1alpha1.CFServiceRouteBinding, *korifiv1alpha1.CFServiceRouteBinding] {
+	routeBindingReconciler := &CFServiceRouteBindingReconciler{k8sClient: k8sClient, scheme: scheme, log: log}
+	return k8s.NewPatchingReconciler[korifiv1alpha1.CFServiceRouteBinding, *korifiv1alpha1.CFServiceRouteBinding](log, k8sClient, routeBindingReconciler)
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceroutebindings,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceroutebindings/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+
+func (r *CFServiceRouteBindingReconciler) ReconcileResource(ctx context.Context, cfRouteBinding *korifiv1alpha1.CFServiceRouteBinding) (ctrl.Result, error) {
+	instance := new(korifiv1alpha1.CFServiceInstance)
+	err := r.k8sClient.Get(ctx, types.NamespacedName{Name: cfRouteBinding.Spec.Service.Name, Namespace: cfRouteBinding.Namespace}, instance)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			setRouteBindingNotReady(cfRouteBinding, "ServiceInstanceNotFound", "Service instance does not exist")
+			return ctrl.Result{RequeueAfter: 2 * time.Second}, nil
+		}
+
+		setRouteBindingNotReady(cfRouteBinding, "UnknownError", "Error occurred while fetching service instance: "+err.Error())
+		return ctrl.Result{}, err
+	}
+
+	err = controllerutil.SetOwnerReference(instance, cfRouteBinding, r.scheme)
+	if err != nil {
+		r.log.Error(err, "Error when making the service instance owner of the service route binding")
+		return ctrl.Result{}, err
+	}
+
+	if instance.Spec.RouteServiceURL == "" {
+		cfRouteBinding.Status.RouteServiceURL = ""
+		setRouteBindingNotReady(cfRouteBinding, "RouteServiceURLMissing", "Service instance does not have a route service URL")
+		return ctrl.Result{}, nil
+	}
+
+	signatureSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-signature", cfRouteBinding.Name),
+			Namespace: cfRouteBinding.Namespace,
+		},
+	}
+
+	_, err = controllerutil.CreateOrPatch(ctx, r.k8sClient, signatureSecret, func() error {
+		if signatureSecret.Labels == nil {
+			signatureSecret.Labels = map[string]string{}
+		}
+		signatureSecret.Labels[korifiv1alpha1.CFServiceRouteBindingGUIDLabelKey] = cfRouteBinding.Name
+
+		// a secret left behind by a deleted binding with the same name must not hand its signature over
+		if len(signatureSecret.Data[korifiv1alpha1.RouteServiceSignatureSecretKey]) == 0 || !isOwnedBy(signatureSecret, cfRouteBinding) {
+			signature, genErr := generateSignature()
+			if genErr != nil {
+				return genErr
+			}
+			signatureSecret.Data = map[string][]byte{korifiv1alpha1.RouteServiceSignatureSecretKey: []byte(signature)}
+		}
+
+		return controllerutil.SetOwnerReference(cfRouteBinding, signatureSecret, r.scheme)
+	})
+	if err != nil {
+		r.log.Error(err, "Error when creating or patching the route service signature secret")
+		setRouteBindingNotReady(cfRouteBinding, "SignatureSecretError", "Error occurred while creating signature secret: "+err.Error())
+		return ctrl.Result{}, err
+	}
+
+	cfRouteBinding.Status.RouteServiceURL = instance.Spec.RouteServiceURL
+	cfRouteBinding.Status.SignatureSecretName = signatureSecret.Name
+	meta.SetStatusCondition(&cfRouteBinding.Status.Conditions, metav1.Condition{
+		Type:   korifiv1alpha1.ReadyConditionType,
+		Status: metav1.ConditionTrue,
+		Reason: "RouteServiceConfigured",
+	})
+
+	return ctrl.Result{}, nil
+}
+
+func setRouteBindingNotReady(cfRouteBinding *korifiv1alpha1.CFServiceRouteBinding, reason, message string) {
+	meta.SetStatusCondition(&cfRouteBinding.Status.Conditions, metav1.Condition{
+		Type:    korifiv1alpha1.ReadyConditionType,
+		Status:  metav1.ConditionFalse,
+		Reason:  reason,
+		Message: message,
+	})
+}
+
+func generateSignature() (string, error) {
+	signature := make([]byte, signatureLength)
+	if _, err := rand.Read(signature); err != nil {
+		return "", fmt.Errorf("failed to generate route service signature: %w", err)
+	}
+
+	return hex.EncodeToString(signature), nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *CFServiceRouteBindingReconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&korifiv1alpha1.CFServiceRouteBinding{}).
+		Owns(&corev1.Secret{}).
+		Watches(
+			&source.Kind{Type: &korifiv1alpha1.CFServiceInstance{}},
+			handler.EnqueueRequestsFromMapFunc(r.serviceInstanceToRouteBindings),
+		)
+}
+
+func (r *CFServiceRouteBindingReconciler) serviceInstanceToRouteBindings(o client.Object) []reconcile.Request {
+	routeBindings := new(korifiv1alpha1.CFServiceRouteBindingList)
+	err := r.k8sClient.List(context.Background(), routeBindings,
+		client.InNamespace(o.GetNamespace()),
+		client.MatchingFields{shared.IndexServiceRouteBindingServiceGUID: o.GetName()},
+	)
+	if err != nil {
+		r.log.Error(err, "failed to list service route bindings", "namespace", o.GetNamespace())
+		return []reconcile.Request{}
+	}
+
+	requests := []reconcile.Request{}
+	for _, binding := range routeBindings.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: binding.Name, Namespace: binding.Namespace}})
+	}
+
+	return requests
+}
+
+func isOwnedBy(object, owner metav1.Object) bool {
+	for _, ownerRef := range object.GetOwnerReferences() {
+		if ownerRef.UID == owner.GetUID() {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,189 @@
+package services_test
+
+import (
+	"context"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	. "code.cloudfoundry.org/korifi/controllers/controllers/workloads/testutils"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("CFServiceRouteBinding", func() {
+	var (
+		namespace         *corev1.Namespace
+		cfServiceInstance *korifiv1alpha1.CFServiceInstance
+		cfRouteBinding    *korifiv1alpha1.CFServiceRouteBinding
+	)
+
+	BeforeEach(func() {
+		namespace = BuildNamespaceObject(GenerateGUID())
+		Expect(k8sClient.Create(context.Background(), namespace)).To(Succeed())
+
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "service-instance-secret",
+				Namespace: namespace.Name,
+			},
+		}
+		Expect(k8sClient.Create(context.Background(), secret)).To(Succeed())
+
+		cfServiceInstance = &korifiv1alpha1.CFServiceInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      GenerateGUID(),
+				Namespace: namespace.Name,
+			},
+			Spec: korifiv1alpha1.CFServiceInstanceSpec{
+				DisplayName:     "route-service-instance",
+				SecretName:      secret.Name,
+				Type:            "user-provided",
+				RouteServiceURL: "https://route-service.example.com",
+			},
+		}
+		Expect(k8sClient.Create(context.Background(), cfServiceInstance)).To(Succeed())
+
+		cfRouteBinding = &korifiv1alpha1.CFServiceRouteBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      GenerateGUID(),
+				Namespace: namespace.Name,
+			},
+			Spec: korifiv1alpha1.CFServiceRouteBindingSpec{
+				Service: corev1.ObjectReference{
+					Kind:       "CFServiceInstance",
+					APIVersion: korifiv1alpha1.GroupVersion.Identifier(),
+					Name:       cfServiceInstance.Name,
+				},
+				RouteRef: corev1.LocalObjectReference{Name: "route-guid"},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(context.Background(), namespace)).To(Succeed())
+	})
+
+	JustBeforeEach(func() {
+		Expect(k8sClient.Create(context.Background(), cfRouteBinding)).To(Succeed())
+	})
+
+	It("makes the service instance owner of the service route binding", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cfRouteBinding), cfRouteBinding)).To(Succeed())
+			g.Expect(cfRouteBinding.GetOwnerReferences()).To(ConsistOf(HaveField("Name", cfServiceInstance.Name)))
+		}).Should(Succeed())
+	})
+
+	It("sets the route service URL and signature secret on the status and becomes ready", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cfRouteBinding), cfRouteBinding)).To(Succeed())
+			g.Expect(cfRouteBinding.Status.RouteServiceURL).To(Equal("https://route-service.example.com"))
+			g.Expect(cfRouteBinding.Status.SignatureSecretName).To(Equal(cfRouteBinding.Name + "-signature"))
+			g.Expect(cfRouteBinding.Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+				"Type":   Equal("Ready"),
+				"Status": Equal(metav1.ConditionTrue),
+			})))
+		}).Should(Succeed())
+	})
+
+	It("creates a signature secret owned by the service route binding", func() {
+		Eventually(func(g Gomega) {
+			signatureSecret := new(corev1.Secret)
+			g.Expect(k8sClient.Get(context.Background(), types.NamespacedName{Name: cfRouteBinding.Name + "-signature", Namespace: namespace.Name}, signatureSecret)).To(Succeed())
+			g.Expect(signatureSecret.Data).To(HaveKeyWithValue(korifiv1alpha1.RouteServiceSignatureSecretKey, HaveLen(64)))
+			g.Expect(signatureSecret.Labels).To(HaveKeyWithValue(korifiv1alpha1.CFServiceRouteBindingGUIDLabelKey, cfRouteBinding.Name))
+			g.Expect(signatureSecret.GetOwnerReferences()).To(ConsistOf(HaveField("Name", cfRouteBinding.Name)))
+		}).Should(Succeed())
+	})
+
+	When("the signature secret of a deleted binding with the same name still exists", func() {
+		BeforeEach(func() {
+			Expect(k8sClient.Create(context.Background(), &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      cfRouteBinding.Name + "-signature",
+					Namespace: namespace.Name,
+					OwnerReferences: []metav1.OwnerReference{{
+						APIVersion: korifiv1alpha1.GroupVersion.Identifier(),
+						Kind:       "CFServiceRouteBinding",
+						Name:       cfRouteBinding.Name,
+						UID:        "deleted-binding-uid",
+					}},
+				},
+				Data: map[string][]byte{
+					korifiv1alpha1.RouteServiceSignatureSecretKey: []byte("stale-signature"),
+				},
+			})).To(Succeed())
+		})
+
+		It("rotates the signature", func() {
+			Eventually(func(g Gomega) {
+				signatureSecret := new(corev1.Secret)
+				g.Expect(k8sClient.Get(context.Background(), types.NamespacedName{Name: cfRouteBinding.Name + "-signature", Namespace: namespace.Name}, signatureSecret)).To(Succeed())
+				g.Expect(signatureSecret.Data).To(HaveKeyWithValue(korifiv1alpha1.RouteServiceSignatureSecretKey, HaveLen(64)))
+				g.Expect(signatureSecret.GetOwnerReferences()).To(ConsistOf(HaveField("UID", cfRouteBinding.UID)))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the service instance route service URL changes", func() {
+		JustBeforeEach(func() {
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cfRouteBinding), cfRouteBinding)).To(Succeed())
+				g.Expect(cfRouteBinding.Status.RouteServiceURL).NotTo(BeEmpty())
+			}).Should(Succeed())
+
+			Expect(k8s.PatchResource(context.Background(), k8sClient, cfServiceInstance, func() {
+				cfServiceInstance.Spec.RouteServiceURL = "https://other-route-service.example.com"
+			})).To(Succeed())
+		})
+
+		It("updates the route service URL on the status", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cfRouteBinding), cfRouteBinding)).To(Succeed())
+				g.Expect(cfRouteBinding.Status.RouteServiceURL).To(Equal("https://other-route-service.example.com"))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the service instance does not have a route service URL", func() {
+		BeforeEach(func() {
+			Expect(k8s.PatchResource(context.Background(), k8sClient, cfServiceInstance, func() {
+				cfServiceInstance.Spec.RouteServiceURL = ""
+			})).To(Succeed())
+		})
+
+		It("sets the ready condition to false", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cfRouteBinding), cfRouteBinding)).To(Succeed())
+				g.Expect(cfRouteBinding.Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal("Ready"),
+					"Status": Equal(metav1.ConditionFalse),
+					"Reason": Equal("RouteServiceURLMissing"),
+				})))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the service instance does not exist", func() {
+		BeforeEach(func() {
+			cfRouteBinding.Spec.Service.Name = "does-not-exist"
+		})
+
+		It("sets the ready condition to false", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cfRouteBinding), cfRouteBinding)).To(Succeed())
+				g.Expect(cfRouteBinding.Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal("Ready"),
+					"Status": Equal(metav1.ConditionFalse),
+					"Reason": Equal("ServiceInstanceNotFound"),
+				})))
+			}).Should(Succeed())
+		})
+	})
+})
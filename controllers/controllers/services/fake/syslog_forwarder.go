@@ -0,0 +1,91 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/korifi/controllers/controllers/services"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/syslog"
+)
+
+type SyslogForwarder struct {
+	SyncStub        func(string, []syslog.Source, []string)
+	syncMutex       sync.RWMutex
+	syncArgsForCall []struct {
+		arg1 string
+		arg2 []syslog.Source
+		arg3 []string
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *SyslogForwarder) Sync(arg1 string, arg2 []syslog.Source, arg3 []string) {
+	var arg2Copy []syslog.Source
+	if arg2 != nil {
+		arg2Copy = make([]syslog.Source, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	var arg3Copy []string
+	if arg3 != nil {
+		arg3Copy = make([]string, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.syncMutex.Lock()
+	fake.syncArgsForCall = append(fake.syncArgsForCall, struct {
+		arg1 string
+		arg2 []syslog.Source
+		arg3 []string
+	}{arg1, arg2Copy, arg3Copy})
+	stub := fake.SyncStub
+	fake.recordInvocation("Sync", []interface{}{arg1, arg2Copy, arg3Copy})
+	fake.syncMutex.Unlock()
+	if stub != nil {
+		fake.SyncStub(arg1, arg2, arg3)
+	}
+}
+
+func (fake *SyslogForwarder) SyncCallCount() int {
+	fake.syncMutex.RLock()
+	defer fake.syncMutex.RUnlock()
+	return len(fake.syncArgsForCall)
+}
+
+func (fake *SyslogForwarder) SyncCalls(stub func(string, []syslog.Source, []string)) {
+	fake.syncMutex.Lock()
+	defer fake.syncMutex.Unlock()
+	fake.SyncStub = stub
+}
+
+func (fake *SyslogForwarder) SyncArgsForCall(i int) (string, []syslog.Source, []string) {
+	fake.syncMutex.RLock()
+	defer fake.syncMutex.RUnlock()
+	argsForCall := fake.syncArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *SyslogForwarder) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.syncMutex.RLock()
+	defer fake.syncMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *SyslogForwarder) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ services.SyslogForwarder = new(SyslogForwarder)
@@ -24,6 +24,7 @@ import (
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	. "code.cloudfoundry.org/korifi/controllers/controllers/services"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/fake"
 	. "code.cloudfoundry.org/korifi/controllers/controllers/shared"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -46,6 +47,8 @@ var (
 	cancel    context.CancelFunc
 	testEnv   *envtest.Environment
 	k8sClient client.Client
+
+	syslogForwarder *fake.SyslogForwarder
 )
 
 func TestAPIs(t *testing.T) {
@@ -107,6 +110,21 @@ var _ = BeforeSuite(func() {
 	)).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
+	err = (NewCFServiceRouteBindingReconciler(
+		k8sManager.GetClient(),
+		k8sManager.GetScheme(),
+		ctrl.Log.WithName("controllers").WithName("CFServiceRouteBinding"),
+	)).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	syslogForwarder = new(fake.SyslogForwarder)
+	err = (NewCFAppSyslogDrainReconciler(
+		k8sManager.GetClient(),
+		syslogForwarder,
+		ctrl.Log.WithName("controllers").WithName("CFAppSyslogDrain"),
+	)).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
 	// Add new reconcilers here
 
 	// Setup index for manager
@@ -0,0 +1,144 @@
+package syslog
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+const (
+	SyslogScheme    = "syslog"
+	SyslogTLSScheme = "syslog-tls"
+	HTTPSScheme     = "https"
+
+	drainTimeout = 10 * time.Second
+)
+
+// Drain is a destination that application logs are forwarded to
+type Drain interface {
+	Write(Message) error
+	Close() error
+}
+
+// NewDrain returns a Drain for the given URL. The scheme of the URL determines the transport:
+// `syslog` for plain TCP, `syslog-tls` for TCP over TLS and `https` for HTTP POST requests.
+// A nil tlsConfig uses the system defaults.
+func NewDrain(drainURL string, tlsConfig *tls.Config) (Drain, error) {
+	u, err := url.Parse(drainURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid drain url %q: %w", drainURL, err)
+	}
+
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12} // #nosec G402
+	}
+
+	switch u.Scheme {
+	case SyslogScheme:
+		return &tcpDrain{address: u.Host}, nil
+	case SyslogTLSScheme:
+		tlsConfig = tlsConfig.Clone()
+		if tlsConfig.ServerName == "" {
+			tlsConfig.ServerName = u.Hostname()
+		}
+		return &tcpDrain{address: u.Host, tlsConfig: tlsConfig}, nil
+	case HTTPSScheme:
+		return &httpsDrain{
+			url: drainURL,
+			client: &http.Client{
+				Timeout:   drainTimeout,
+				Transport: &http.Transport{TLSClientConfig: tlsConfig},
+			},
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported drain url scheme %q", u.Scheme)
+	}
+}
+
+// tcpDrain writes octet-counted messages (RFC6587) over a TCP connection that is
+// established lazily and re-established after a failed write
+type tcpDrain struct {
+	address   string
+	tlsConfig *tls.Config
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+func (d *tcpDrain) Write(message Message) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.conn == nil {
+		conn, err := d.dial()
+		if err != nil {
+			return fmt.Errorf("failed to connect to drain %s: %w", d.address, err)
+		}
+		d.conn = conn
+	}
+
+	msg := message.RFC5424()
+	err := d.conn.SetWriteDeadline(time.Now().Add(drainTimeout))
+	if err == nil {
+		_, err = fmt.Fprintf(d.conn, "%d %s", len(msg), msg)
+	}
+	if err != nil {
+		_ = d.conn.Close()
+		d.conn = nil
+		return fmt.Errorf("failed to write to drain %s: %w", d.address, err)
+	}
+
+	return nil
+}
+
+func (d *tcpDrain) dial() (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: drainTimeout}
+	if d.tlsConfig != nil {
+		return tls.DialWithDialer(dialer, "tcp", d.address, d.tlsConfig)
+	}
+
+	return dialer.Dial("tcp", d.address)
+}
+
+func (d *tcpDrain) Close() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.conn == nil {
+		return nil
+	}
+
+	err := d.conn.Close()
+	d.conn = nil
+	return err
+}
+
+// httpsDrain posts every message in the body of a separate request
+type httpsDrain struct {
+	url    string
+	client *http.Client
+}
+
+func (d *httpsDrain) Write(message Message) error {
+	resp, err := d.client.Post(d.url, "text/plain", bytes.NewReader(message.RFC5424()))
+	if err != nil {
+		return fmt.Errorf("failed to post to drain: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("drain responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func (d *httpsDrain) Close() error {
+	d.client.CloseIdleConnections()
+	return nil
+}
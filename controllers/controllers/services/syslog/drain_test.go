@@ -0,0 +1,190 @@
+package syslog_test
+
+import (
+	"bufio"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/syslog"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Drain", func() {
+	var (
+		drain     syslog.Drain
+		drainURL  string
+		tlsConfig *tls.Config
+		message   syslog.Message
+		writeErr  error
+	)
+
+	BeforeEach(func() {
+		tlsConfig = nil
+		message = syslog.Message{
+			Timestamp: time.Now(),
+			Hostname:  "my-host",
+			AppName:   "my-app",
+			ProcID:    "[APP/PROC/WEB/0]",
+			Body:      "hello",
+		}
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		drain, err = syslog.NewDrain(drainURL, tlsConfig)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(drain.Close)
+
+		writeErr = drain.Write(message)
+	})
+
+	Describe("syslog", func() {
+		var (
+			listener net.Listener
+			received chan string
+		)
+
+		BeforeEach(func() {
+			var err error
+			listener, err = net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(func() {
+				_ = listener.Close()
+			})
+
+			received = receiveOctetCounted(listener)
+			drainURL = "syslog://" + listener.Addr().String()
+		})
+
+		It("sends the octet-counted message", func() {
+			Expect(writeErr).NotTo(HaveOccurred())
+			Eventually(received).Should(Receive(Equal(string(message.RFC5424()))))
+		})
+
+		It("reuses the connection for subsequent messages", func() {
+			Eventually(received).Should(Receive())
+			Expect(drain.Write(message)).To(Succeed())
+			Eventually(received).Should(Receive(Equal(string(message.RFC5424()))))
+		})
+
+		When("the drain is not listening", func() {
+			BeforeEach(func() {
+				Expect(listener.Close()).To(Succeed())
+			})
+
+			It("returns an error", func() {
+				Expect(writeErr).To(MatchError(ContainSubstring("failed to connect to drain")))
+			})
+		})
+	})
+
+	Describe("syslog-tls", func() {
+		var received chan string
+
+		BeforeEach(func() {
+			server := httptest.NewTLSServer(http.NotFoundHandler())
+			DeferCleanup(server.Close)
+
+			listener, err := tls.Listen("tcp", "127.0.0.1:0", server.TLS)
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(listener.Close)
+
+			received = receiveOctetCounted(listener)
+			tlsConfig = server.Client().Transport.(*http.Transport).TLSClientConfig
+			drainURL = "syslog-tls://" + listener.Addr().String()
+		})
+
+		It("sends the octet-counted message over TLS", func() {
+			Expect(writeErr).NotTo(HaveOccurred())
+			Eventually(received).Should(Receive(Equal(string(message.RFC5424()))))
+		})
+	})
+
+	Describe("https", func() {
+		var (
+			received chan string
+			status   int
+		)
+
+		BeforeEach(func() {
+			received = make(chan string, 10)
+			status = http.StatusOK
+
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				received <- r.Header.Get("Content-Type") + ";" + string(body)
+				w.WriteHeader(status)
+			}))
+			DeferCleanup(server.Close)
+
+			tlsConfig = server.Client().Transport.(*http.Transport).TLSClientConfig
+			drainURL = server.URL
+		})
+
+		It("posts the message", func() {
+			Expect(writeErr).NotTo(HaveOccurred())
+			Eventually(received).Should(Receive(Equal("text/plain;" + string(message.RFC5424()))))
+		})
+
+		When("the drain responds with an error status", func() {
+			BeforeEach(func() {
+				status = http.StatusInternalServerError
+			})
+
+			It("returns an error", func() {
+				Expect(writeErr).To(MatchError(ContainSubstring("status 500")))
+			})
+		})
+	})
+})
+
+var _ = Describe("NewDrain", func() {
+	When("the url scheme is not supported", func() {
+		It("returns an error", func() {
+			_, err := syslog.NewDrain("ftp://example.com", nil)
+			Expect(err).To(MatchError(ContainSubstring(`unsupported drain url scheme "ftp"`)))
+		})
+	})
+})
+
+func receiveOctetCounted(listener net.Listener) chan string {
+	received := make(chan string, 10)
+
+	go func() {
+		defer GinkgoRecover()
+
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer conn.Close()
+
+				reader := bufio.NewReader(conn)
+				for {
+					var length int
+					if _, err := fmt.Fscanf(reader, "%d ", &length); err != nil {
+						return
+					}
+
+					var frame strings.Builder
+					if _, err := io.CopyN(&frame, reader, int64(length)); err != nil {
+						return
+					}
+					received <- frame.String()
+				}
+			}()
+		}
+	}()
+
+	return received
+}
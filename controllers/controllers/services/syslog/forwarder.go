@@ -0,0 +1,201 @@
+package syslog
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// MaxLineLength is the maximum length of a forwarded log line. Longer lines are truncated.
+const MaxLineLength = 64 * 1024
+
+// Source is a pod whose logs are forwarded, along with the syslog header values of its messages
+type Source struct {
+	Namespace string
+	PodName   string
+	Hostname  string
+	AppName   string
+	ProcID    string
+}
+
+type tailKey struct {
+	namespace string
+	podName   string
+	drainURL  string
+}
+
+// Forwarder tails the logs of pods and forwards them to syslog drains. Tails are grouped by an
+// owner key (e.g. the app the pods belong to) so that they can be synced as a whole.
+type Forwarder struct {
+	clientset     kubernetes.Interface
+	tlsConfig     *tls.Config
+	retryInterval time.Duration
+	log           logr.Logger
+
+	mu    sync.Mutex
+	tails map[string]map[tailKey]context.CancelFunc
+}
+
+func NewForwarder(clientset kubernetes.Interface, tlsConfig *tls.Config, retryInterval time.Duration, log logr.Logger) *Forwarder {
+	return &Forwarder{
+		clientset:     clientset,
+		tlsConfig:     tlsConfig,
+		retryInterval: retryInterval,
+		log:           log,
+		tails:         map[string]map[tailKey]context.CancelFunc{},
+	}
+}
+
+// Sync makes sure that the logs of exactly the given sources are forwarded to exactly the given
+// drains for the owner. Tails that are no longer needed are stopped, missing ones are started.
+func (f *Forwarder) Sync(owner string, sources []Source, drainURLs []string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	desired := map[tailKey]Source{}
+	for _, source := range sources {
+		for _, drainURL := range drainURLs {
+			desired[tailKey{namespace: source.Namespace, podName: source.PodName, drainURL: drainURL}] = source
+		}
+	}
+
+	current := f.tails[owner]
+	for key, cancel := range current {
+		if _, ok := desired[key]; !ok {
+			cancel()
+			delete(current, key)
+		}
+	}
+
+	if len(desired) == 0 {
+		delete(f.tails, owner)
+		return
+	}
+
+	if current == nil {
+		current = map[tailKey]context.CancelFunc{}
+		f.tails[owner] = current
+	}
+
+	for key, source := range desired {
+		if _, ok := current[key]; ok {
+			continue
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		current[key] = cancel
+		go f.tail(ctx, source, key.drainURL)
+	}
+}
+
+func (f *Forwarder) tail(ctx context.Context, source Source, drainURL string) {
+	log := f.log.WithValues("namespace", source.Namespace, "pod", source.PodName)
+
+	drain, err := NewDrain(drainURL, f.tlsConfig)
+	if err != nil {
+		log.Error(err, "invalid syslog drain")
+		return
+	}
+	defer drain.Close()
+
+	// the log stream starts at whole seconds, so lines up to the last forwarded timestamp are
+	// delivered again on reconnect and have to be skipped
+	lastForwarded := time.Now()
+	for {
+		err = f.forward(ctx, source, drain, &lastForwarded)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			log.Info("log stream interrupted", "reason", err.Error())
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(f.retryInterval):
+		}
+	}
+}
+
+func (f *Forwarder) forward(ctx context.Context, source Source, drain Drain, lastForwarded *time.Time) error {
+	sinceTime := metav1.NewTime(*lastForwarded)
+	stream, err := f.clientset.CoreV1().Pods(source.Namespace).GetLogs(source.PodName, &corev1.PodLogOptions{
+		Follow:     true,
+		Timestamps: true,
+		SinceTime:  &sinceTime,
+	}).Stream(ctx)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	reader := bufio.NewReaderSize(stream, MaxLineLength)
+	for {
+		line, err := ReadLine(reader)
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+
+		timestamp, body := parseLogLine(line)
+		if !timestamp.After(*lastForwarded) {
+			continue
+		}
+		*lastForwarded = timestamp
+
+		err = drain.Write(Message{
+			Timestamp: timestamp,
+			Hostname:  source.Hostname,
+			AppName:   source.AppName,
+			ProcID:    source.ProcID,
+			Body:      body,
+		})
+		if err != nil {
+			f.log.Info("dropped log message", "namespace", source.Namespace, "pod", source.PodName, "reason", err.Error())
+		}
+	}
+}
+
+// ReadLine reads the next line from the reader without its line ending. Lines longer than the
+// reader buffer are truncated to its size and the rest of the line is discarded.
+func ReadLine(reader *bufio.Reader) (string, error) {
+	line, isPrefix, err := reader.ReadLine()
+	if err != nil {
+		return "", err
+	}
+
+	// the returned slice is only valid until the next read
+	result := string(line)
+	for isPrefix {
+		_, isPrefix, err = reader.ReadLine()
+		if err != nil {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+// parseLogLine splits a pod log line retrieved with timestamps into its timestamp and message
+func parseLogLine(line string) (time.Time, string) {
+	timestamp, body, found := strings.Cut(line, " ")
+	if found {
+		if t, err := time.Parse(time.RFC3339Nano, timestamp); err == nil {
+			return t, body
+		}
+	}
+
+	return time.Now(), line
+}
@@ -0,0 +1,95 @@
+package syslog_test
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/syslog"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/kubernetes/fake"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+var _ = Describe("Forwarder", func() {
+	var (
+		forwarder *syslog.Forwarder
+		received  chan string
+		drainURL  string
+		source    syslog.Source
+	)
+
+	BeforeEach(func() {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(listener.Close)
+
+		received = receiveOctetCounted(listener)
+		drainURL = "syslog://" + listener.Addr().String()
+
+		source = syslog.Source{
+			Namespace: "space-guid",
+			PodName:   "app-guid-web-0",
+			Hostname:  "my-org.my-space.my-app",
+			AppName:   "app-guid",
+			ProcID:    "[APP/PROC/WEB/0]",
+		}
+
+		// the fake clientset serves "fake logs" as the log of any pod
+		forwarder = syslog.NewForwarder(fake.NewSimpleClientset(), nil, 10*time.Millisecond, ctrl.Log)
+		DeferCleanup(func() {
+			forwarder.Sync("app-guid", nil, nil)
+		})
+	})
+
+	JustBeforeEach(func() {
+		forwarder.Sync("app-guid", []syslog.Source{source}, []string{drainURL})
+	})
+
+	It("forwards the pod logs to the drain", func() {
+		Eventually(received).Should(Receive(SatisfyAll(
+			ContainSubstring(" my-org.my-space.my-app app-guid [APP/PROC/WEB/0] - - fake logs"),
+			HavePrefix("<14>1 "),
+		)))
+	})
+
+	When("the forwarding is synced without drains", func() {
+		JustBeforeEach(func() {
+			Eventually(received).Should(Receive())
+			forwarder.Sync("app-guid", []syslog.Source{source}, nil)
+		})
+
+		It("stops forwarding", func() {
+			// a message that was already in flight may still arrive
+			Eventually(func() bool {
+				select {
+				case <-received:
+					return false
+				case <-time.After(50 * time.Millisecond):
+					return true
+				}
+			}).Should(BeTrue())
+			Consistently(received, "200ms").ShouldNot(Receive())
+		})
+	})
+})
+
+var _ = Describe("ReadLine", func() {
+	var reader *bufio.Reader
+
+	BeforeEach(func() {
+		reader = bufio.NewReaderSize(strings.NewReader("short line\n"+strings.Repeat("x", 100)+"\nlast line"), 16)
+	})
+
+	It("reads lines and truncates the ones longer than the buffer", func() {
+		Expect(syslog.ReadLine(reader)).To(Equal("short line"))
+		Expect(syslog.ReadLine(reader)).To(Equal(strings.Repeat("x", 16)))
+		Expect(syslog.ReadLine(reader)).To(Equal("last line"))
+
+		_, err := syslog.ReadLine(reader)
+		Expect(err).To(MatchError(io.EOF))
+	})
+})
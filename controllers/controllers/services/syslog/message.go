@@ -0,0 +1,63 @@
+package syslog
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// user-level messages (1) at informational severity (6)
+	priorityUserInfo = 1*8 + 6
+	rfc5424Version   = 1
+	nilValue         = "-"
+
+	maxHostnameLength = 255
+	maxAppNameLength  = 48
+	maxProcIDLength   = 128
+)
+
+// Message is a single application log line to be forwarded to a syslog drain
+type Message struct {
+	Timestamp time.Time
+	Hostname  string
+	AppName   string
+	ProcID    string
+	Body      string
+}
+
+// RFC5424 formats the message as described in https://www.rfc-editor.org/rfc/rfc5424
+func (m Message) RFC5424() []byte {
+	return []byte(fmt.Sprintf("<%d>%d %s %s %s %s %s %s %s\n",
+		priorityUserInfo,
+		rfc5424Version,
+		m.Timestamp.UTC().Format(time.RFC3339Nano),
+		headerField(m.Hostname, maxHostnameLength),
+		headerField(m.AppName, maxAppNameLength),
+		headerField(m.ProcID, maxProcIDLength),
+		nilValue, // MSGID
+		nilValue, // STRUCTURED-DATA
+		strings.TrimRight(m.Body, "\r\n"),
+	))
+}
+
+// headerField makes the value a valid RFC5424 header field, i.e. a non-empty
+// string of printable US-ASCII characters not exceeding maxLength
+func headerField(value string, maxLength int) string {
+	field := strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 {
+			return '-'
+		}
+		return r
+	}, value)
+
+	if field == "" {
+		return nilValue
+	}
+
+	if len(field) > maxLength {
+		return field[:maxLength]
+	}
+
+	return field
+}
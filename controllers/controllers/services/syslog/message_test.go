@@ -0,0 +1,61 @@
+package syslog_test
+
+import (
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/syslog"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Message", func() {
+	var message syslog.Message
+
+	BeforeEach(func() {
+		message = syslog.Message{
+			Timestamp: time.Date(2022, 11, 30, 10, 20, 30, 123000000, time.UTC),
+			Hostname:  "my-org.my-space.my-app",
+			AppName:   "app-guid",
+			ProcID:    "[APP/PROC/WEB/0]",
+			Body:      "hello world\n",
+		}
+	})
+
+	It("formats the message as RFC5424", func() {
+		Expect(string(message.RFC5424())).To(Equal(
+			"<14>1 2022-11-30T10:20:30.123Z my-org.my-space.my-app app-guid [APP/PROC/WEB/0] - - hello world\n",
+		))
+	})
+
+	When("header fields are empty", func() {
+		BeforeEach(func() {
+			message.Hostname = ""
+			message.ProcID = ""
+		})
+
+		It("uses the nil value", func() {
+			Expect(string(message.RFC5424())).To(HavePrefix("<14>1 2022-11-30T10:20:30.123Z - app-guid - - - "))
+		})
+	})
+
+	When("header fields contain non-printable characters", func() {
+		BeforeEach(func() {
+			message.Hostname = "my host"
+		})
+
+		It("replaces them", func() {
+			Expect(string(message.RFC5424())).To(ContainSubstring(" my-host "))
+		})
+	})
+
+	When("header fields are too long", func() {
+		BeforeEach(func() {
+			message.AppName = strings.Repeat("a", 100)
+		})
+
+		It("truncates them", func() {
+			Expect(string(message.RFC5424())).To(ContainSubstring(" " + strings.Repeat("a", 48) + " "))
+		})
+	})
+})
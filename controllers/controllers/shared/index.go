@@ -10,9 +10,13 @@ import (
 
 const (
 	IndexRouteDestinationAppName           = "destinationAppName"
+	IndexRouteDomainName                   = "routeDomainName"
 	IndexServiceBindingAppGUID             = "serviceBindingAppGUID"
 	IndexServiceBindingServiceInstanceGUID = "serviceBindingServiceInstanceGUID"
 	IndexAppTasks                          = "appTasks"
+	IndexServiceRouteBindingRouteGUID      = "serviceRouteBindingRouteGUID"
+	IndexServiceRouteBindingServiceGUID    = "serviceRouteBindingServiceInstanceGUID"
+	IndexNetworkPolicySourceSpaceGUID      = "networkPolicySourceSpaceGUID"
 )
 
 func SetupIndexWithManager(mgr manager.Manager) error {
@@ -21,6 +25,11 @@ func SetupIndexWithManager(mgr manager.Manager) error {
 		return err
 	}
 
+	err = mgr.GetFieldIndexer().IndexField(context.Background(), new(korifiv1alpha1.CFRoute), IndexRouteDomainName, routeDomainNameIndexFn)
+	if err != nil {
+		return err
+	}
+
 	err = mgr.GetFieldIndexer().IndexField(context.Background(), new(korifiv1alpha1.CFServiceBinding), IndexServiceBindingAppGUID, serviceBindingAppGUIDIndexFn)
 	if err != nil {
 		return err
@@ -31,6 +40,21 @@ func SetupIndexWithManager(mgr manager.Manager) error {
 		return err
 	}
 
+	err = mgr.GetFieldIndexer().IndexField(context.Background(), new(korifiv1alpha1.CFServiceRouteBinding), IndexServiceRouteBindingRouteGUID, serviceRouteBindingRouteGUIDIndexFn)
+	if err != nil {
+		return err
+	}
+
+	err = mgr.GetFieldIndexer().IndexField(context.Background(), new(korifiv1alpha1.CFServiceRouteBinding), IndexServiceRouteBindingServiceGUID, serviceRouteBindingServiceInstanceGUIDIndexFn)
+	if err != nil {
+		return err
+	}
+
+	err = mgr.GetFieldIndexer().IndexField(context.Background(), new(korifiv1alpha1.CFNetworkPolicy), IndexNetworkPolicySourceSpaceGUID, networkPolicySourceSpaceGUIDIndexFn)
+	if err != nil {
+		return err
+	}
+
 	err = mgr.GetFieldIndexer().IndexField(context.Background(), &korifiv1alpha1.CFTask{}, IndexAppTasks, func(object client.Object) []string {
 		task := object.(*korifiv1alpha1.CFTask)
 		return []string{task.Spec.AppRef.Name}
@@ -51,6 +75,11 @@ func routeDestinationAppNameIndexFn(rawObj client.Object) []string {
 	return destinationAppNames
 }
 
+func routeDomainNameIndexFn(rawObj client.Object) []string {
+	route := rawObj.(*korifiv1alpha1.CFRoute)
+	return []string{route.Spec.DomainRef.Name}
+}
+
 func serviceBindingAppGUIDIndexFn(rawObj client.Object) []string {
 	serviceBinding := rawObj.(*korifiv1alpha1.CFServiceBinding)
 	return []string{serviceBinding.Spec.AppRef.Name}
@@ -60,3 +89,18 @@ func serviceBindingServiceInstanceGUIDIndexFn(rawObj client.Object) []string {
 	serviceBinding := rawObj.(*korifiv1alpha1.CFServiceBinding)
 	return []string{serviceBinding.Spec.Service.Name}
 }
+
+func serviceRouteBindingRouteGUIDIndexFn(rawObj client.Object) []string {
+	routeBinding := rawObj.(*korifiv1alpha1.CFServiceRouteBinding)
+	return []string{routeBinding.Spec.RouteRef.Name}
+}
+
+func serviceRouteBindingServiceInstanceGUIDIndexFn(rawObj client.Object) []string {
+	routeBinding := rawObj.(*korifiv1alpha1.CFServiceRouteBinding)
+	return []string{routeBinding.Spec.Service.Name}
+}
+
+func networkPolicySourceSpaceGUIDIndexFn(rawObj client.Object) []string {
+	networkPolicy := rawObj.(*korifiv1alpha1.CFNetworkPolicy)
+	return []string{networkPolicy.Spec.Source.SpaceGUID}
+}
@@ -265,7 +265,7 @@ func (r *CFAppReconciler) finalizeCFApp(ctx context.Context, log logr.Logger, cf
 }
 
 func (r *CFAppReconciler) finalizeCFAppRoutes(ctx context.Context, log logr.Logger, cfApp *korifiv1alpha1.CFApp) error {
-	cfRoutes, err := r.getCFRoutes(ctx, log, cfApp.Name, cfApp.Namespace)
+	cfRoutes, err := r.getCFRoutes(ctx, log, cfApp.Name)
 	if err != nil {
 		return err
 	}
@@ -325,10 +325,12 @@ func (r *CFAppReconciler) updateRouteDestinations(ctx context.Context, log logr.
 	return nil
 }
 
-func (r *CFAppReconciler) getCFRoutes(ctx context.Context, log logr.Logger, cfAppGUID string, cfAppNamespace string) ([]korifiv1alpha1.CFRoute, error) {
+// getCFRoutes lists the routes the app is a destination of. These include the routes of other spaces
+// that are shared with the space of the app
+func (r *CFAppReconciler) getCFRoutes(ctx context.Context, log logr.Logger, cfAppGUID string) ([]korifiv1alpha1.CFRoute, error) {
 	var foundRoutes korifiv1alpha1.CFRouteList
 	matchingFields := client.MatchingFields{shared.IndexRouteDestinationAppName: cfAppGUID}
-	err := r.k8sClient.List(context.Background(), &foundRoutes, client.InNamespace(cfAppNamespace), matchingFields)
+	err := r.k8sClient.List(context.Background(), &foundRoutes, matchingFields)
 	if err != nil {
 		log.Error(err, "failed to List CFRoutes")
 		return []korifiv1alpha1.CFRoute{}, err
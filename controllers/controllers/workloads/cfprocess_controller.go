@@ -293,7 +293,8 @@ func (r *CFProcessReconciler) fetchAppWorkloadsForProcess(ctx context.Context, c
 func (r *CFProcessReconciler) getPort(ctx context.Context, cfProcess *korifiv1alpha1.CFProcess, cfApp *korifiv1alpha1.CFApp) (int, error) {
 	// Get Routes for the process
 	var cfRoutesForProcess korifiv1alpha1.CFRouteList
-	err := r.k8sClient.List(ctx, &cfRoutesForProcess, client.InNamespace(cfApp.GetNamespace()), client.MatchingFields{shared.IndexRouteDestinationAppName: cfApp.Name})
+	// app guids are unique, so routes in shared spaces are found by the destination app name alone
+	err := r.k8sClient.List(ctx, &cfRoutesForProcess, client.MatchingFields{shared.IndexRouteDestinationAppName: cfApp.Name})
 	if err != nil {
 		return 0, err
 	}
@@ -19,19 +19,24 @@ package workloads
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/securitygroups"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -43,6 +48,9 @@ import (
 
 const (
 	spaceFinalizerName = "cfSpace.korifi.cloudfoundry.org"
+
+	RunningSecurityGroupsPolicyName = "korifi-running-security-groups"
+	StagingSecurityGroupsPolicyName = "korifi-staging-security-groups"
 )
 
 // CFSpaceReconciler reconciles a CFSpace object
@@ -79,6 +87,8 @@ func NewCFSpaceReconciler(
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=rolebindings,verbs=create;patch;delete;get;list;watch
 //+kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;patch;delete
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfsecuritygroups,verbs=get;list;watch
+//+kubebuilder:rbac:groups="networking.k8s.io",resources=networkpolicies,verbs=get;list;watch;create;patch;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -136,6 +146,12 @@ func (r *CFSpaceReconciler) ReconcileResource(ctx context.Context, cfSpace *kori
 		return ctrl.Result{}, err
 	}
 
+	err = r.reconcileSecurityGroups(ctx, cfSpace, log)
+	if err != nil {
+		log.Error(err, "Error reconciling security group network policies")
+		return ctrl.Result{}, err
+	}
+
 	cfSpace.Status.GUID = namespace.Name
 	meta.SetStatusCondition(&cfSpace.Status.Conditions, metav1.Condition{
 		Type:   StatusConditionReady,
@@ -250,6 +266,126 @@ func (r *CFSpaceReconciler) reconcileServiceAccounts(ctx context.Context, space
 	return nil
 }
 
+// reconcileSecurityGroups renders the security groups bound to the space, or globally enabled, as egress
+// NetworkPolicies for the app and task pods (running) and the build pods (staging). When no group applies
+// to a workload type its policy is removed, leaving the egress of those pods unrestricted
+func (r *CFSpaceReconciler) reconcileSecurityGroups(ctx context.Context, space *korifiv1alpha1.CFSpace, log logr.Logger) error {
+	log = log.WithName("reconcileSecurityGroups")
+
+	securityGroups := new(korifiv1alpha1.CFSecurityGroupList)
+	err := r.client.List(ctx, securityGroups, client.InNamespace(r.rootNamespace))
+	if err != nil {
+		log.Error(err, "Error listing security groups from root namespace")
+		return err
+	}
+
+	sort.Slice(securityGroups.Items, func(i, j int) bool {
+		return securityGroups.Items[i].Name < securityGroups.Items[j].Name
+	})
+
+	var runningGroups, stagingGroups []korifiv1alpha1.CFSecurityGroup
+	for _, securityGroup := range securityGroups.Items {
+		bound := securityGroup.Spec.Spaces[space.Name]
+		if securityGroup.Spec.GloballyEnabled.Running || bound.Running {
+			runningGroups = append(runningGroups, securityGroup)
+		}
+		if securityGroup.Spec.GloballyEnabled.Staging || bound.Staging {
+			stagingGroups = append(stagingGroups, securityGroup)
+		}
+	}
+
+	err = r.reconcileNetworkPolicy(ctx, log, space.Name, RunningSecurityGroupsPolicyName, korifiv1alpha1.CFAppGUIDLabelKey, runningGroups)
+	if err != nil {
+		return err
+	}
+
+	return r.reconcileNetworkPolicy(ctx, log, space.Name, StagingSecurityGroupsPolicyName, korifiv1alpha1.BuildWorkloadLabelKey, stagingGroups)
+}
+
+func (r *CFSpaceReconciler) reconcileNetworkPolicy(ctx context.Context, log logr.Logger, namespace, name, podLabelKey string, securityGroups []korifiv1alpha1.CFSecurityGroup) error {
+	log = log.WithValues("networkPolicy", name)
+
+	networkPolicy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+
+	if len(securityGroups) == 0 {
+		err := r.client.Delete(ctx, networkPolicy)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			log.Error(err, "Error deleting network policy")
+			return err
+		}
+
+		return nil
+	}
+
+	egressRules := platformEgressRules()
+	for _, securityGroup := range securityGroups {
+		groupRules, err := securitygroups.EgressRules(securityGroup.Spec.Rules)
+		if err != nil {
+			// an invalid group must not prevent the other groups from being applied
+			log.Info("Skipping security group with invalid rules", "securityGroup", securityGroup.Name, "reason", err.Error())
+			continue
+		}
+
+		egressRules = append(egressRules, groupRules...)
+	}
+
+	result, err := controllerutil.CreateOrPatch(ctx, r.client, networkPolicy, func() error {
+		networkPolicy.Spec = networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{
+					Key:      podLabelKey,
+					Operator: metav1.LabelSelectorOpExists,
+				}},
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+			Egress:      egressRules,
+		}
+
+		return nil
+	})
+	if err != nil {
+		log.Error(err, "Error creating/patching network policy")
+		return err
+	}
+
+	log.Info("Network policy reconciled", "operation", result)
+
+	return nil
+}
+
+// platformEgressRules keeps cluster DNS and the istio control plane reachable, as the pods cannot run without them
+func platformEgressRules() []networkingv1.NetworkPolicyEgressRule {
+	udp := corev1.ProtocolUDP
+	tcp := corev1.ProtocolTCP
+	dnsPort := intstr.FromInt(53)
+
+	return []networkingv1.NetworkPolicyEgressRule{
+		{
+			To: []networkingv1.NetworkPolicyPeer{{
+				NamespaceSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{corev1.LabelMetadataName: "kube-system"},
+				},
+			}},
+			Ports: []networkingv1.NetworkPolicyPort{
+				{Protocol: &udp, Port: &dnsPort},
+				{Protocol: &tcp, Port: &dnsPort},
+			},
+		},
+		{
+			To: []networkingv1.NetworkPolicyPeer{{
+				NamespaceSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{corev1.LabelMetadataName: "istio-system"},
+				},
+			}},
+		},
+	}
+}
+
 func keepSecrets(serviceAccountName string, secretRefs []corev1.ObjectReference) []corev1.ObjectReference {
 	var results []corev1.ObjectReference
 	for _, secretRef := range secretRefs {
@@ -283,7 +419,11 @@ func (r *CFSpaceReconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder
 		).
 		Watches(
 			&source.Kind{Type: &corev1.ServiceAccount{}},
-			handler.EnqueueRequestsFromMapFunc(r.enqueueCFSpaceRequestsForServiceAccount),
+			handler.EnqueueRequestsFromMapFunc(r.enqueueCFSpaceRequestsForRootNamespaceObject),
+		).
+		Watches(
+			&source.Kind{Type: &korifiv1alpha1.CFSecurityGroup{}},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueCFSpaceRequestsForRootNamespaceObject),
 		)
 }
 
@@ -302,7 +442,7 @@ func (r *CFSpaceReconciler) enqueueCFSpaceRequests(object client.Object) []recon
 	return requests
 }
 
-func (r *CFSpaceReconciler) enqueueCFSpaceRequestsForServiceAccount(object client.Object) []reconcile.Request {
+func (r *CFSpaceReconciler) enqueueCFSpaceRequestsForRootNamespaceObject(object client.Object) []reconcile.Request {
 	if object.GetNamespace() != r.rootNamespace {
 		return nil
 	}
@@ -8,6 +8,7 @@ import (
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -17,6 +18,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads"
 	. "code.cloudfoundry.org/korifi/controllers/controllers/workloads/testutils"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 )
@@ -200,6 +202,77 @@ var _ = Describe("CFSpaceReconciler Integration Tests", func() {
 		})
 	})
 
+	When("security groups are bound to the CFSpace", func() {
+		var securityGroup *korifiv1alpha1.CFSecurityGroup
+
+		BeforeEach(func() {
+			securityGroup = &korifiv1alpha1.CFSecurityGroup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      PrefixedGUID("security-group"),
+					Namespace: cfRootNamespace,
+				},
+				Spec: korifiv1alpha1.CFSecurityGroupSpec{
+					DisplayName: "my-group",
+					Rules: []korifiv1alpha1.SecurityGroupRule{{
+						Protocol:    korifiv1alpha1.SecurityGroupProtocolTCP,
+						Destination: "10.0.0.0/16",
+						Ports:       "443",
+					}},
+					Spaces: map[string]korifiv1alpha1.SecurityGroupWorkloads{
+						spaceGUID: {Running: true},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, securityGroup)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, securityGroup))).To(Succeed())
+			})
+
+			Expect(k8sClient.Create(ctx, cfSpace)).To(Succeed())
+		})
+
+		It("renders the running security groups as an egress network policy for the app pods", func() {
+			Eventually(func(g Gomega) {
+				var networkPolicy networkingv1.NetworkPolicy
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: spaceGUID, Name: workloads.RunningSecurityGroupsPolicyName}, &networkPolicy)).To(Succeed())
+				g.Expect(networkPolicy.Spec.PodSelector.MatchExpressions).To(ConsistOf(metav1.LabelSelectorRequirement{
+					Key:      korifiv1alpha1.CFAppGUIDLabelKey,
+					Operator: metav1.LabelSelectorOpExists,
+				}))
+				g.Expect(networkPolicy.Spec.PolicyTypes).To(ConsistOf(networkingv1.PolicyTypeEgress))
+				g.Expect(networkPolicy.Spec.Egress).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+					"To": ConsistOf(networkingv1.NetworkPolicyPeer{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/16"}}),
+				})))
+			}).Should(Succeed())
+		})
+
+		It("does not render a staging network policy", func() {
+			Consistently(func(g Gomega) {
+				err := k8sClient.Get(ctx, types.NamespacedName{Namespace: spaceGUID, Name: workloads.StagingSecurityGroupsPolicyName}, new(networkingv1.NetworkPolicy))
+				g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			}, "1s").Should(Succeed())
+		})
+
+		When("the security group is unbound from the space", func() {
+			BeforeEach(func() {
+				Eventually(func(g Gomega) {
+					g.Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: spaceGUID, Name: workloads.RunningSecurityGroupsPolicyName}, new(networkingv1.NetworkPolicy))).To(Succeed())
+				}).Should(Succeed())
+
+				Expect(k8s.Patch(ctx, k8sClient, securityGroup, func() {
+					securityGroup.Spec.Spaces = nil
+				})).To(Succeed())
+			})
+
+			It("deletes the network policy", func() {
+				Eventually(func(g Gomega) {
+					err := k8sClient.Get(ctx, types.NamespacedName{Namespace: spaceGUID, Name: workloads.RunningSecurityGroupsPolicyName}, new(networkingv1.NetworkPolicy))
+					g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+				}).Should(Succeed())
+			})
+		})
+	})
+
 	When("role-bindings are added/updated in CFOrg namespace after CFSpace creation", func() {
 		var newlyCreatedRoleBinding *rbacv1.RoleBinding
 		BeforeEach(func() {
@@ -235,6 +235,7 @@ func (r *CFTaskReconciler) createOrPatchTaskWorkload(ctx context.Context, cfTask
 			taskWorkload.Labels = map[string]string{}
 		}
 		taskWorkload.Labels[korifiv1alpha1.CFTaskGUIDLabelKey] = cfTask.Name
+		taskWorkload.Labels[korifiv1alpha1.CFAppGUIDLabelKey] = cfTask.Spec.AppRef.Name
 
 		taskWorkload.Spec.Command = []string{LifecycleLauncherPath, cfTask.Spec.Command}
 		taskWorkload.Spec.Image = cfDroplet.Status.Droplet.Registry.Image
@@ -146,6 +146,11 @@ func fromServiceBinding(
 		tags = []string{}
 	}
 
+	var syslogDrainURL *string
+	if serviceInstance.Spec.SyslogDrainURL != "" {
+		syslogDrainURL = &serviceInstance.Spec.SyslogDrainURL
+	}
+
 	return ServiceDetails{
 		Label:          "user-provided",
 		Name:           serviceName,
@@ -155,7 +160,7 @@ func fromServiceBinding(
 		BindingGUID:    serviceBinding.Name,
 		BindingName:    bindingName,
 		Credentials:    mapFromSecret(serviceBindingSecret),
-		SyslogDrainURL: nil,
+		SyslogDrainURL: syslogDrainURL,
 		VolumeMounts:   []string{},
 	}
 }
@@ -166,7 +171,7 @@ func buildSingleServiceEnv(ctx context.Context, k8sClient client.Client, service
 	}
 
 	serviceInstance := korifiv1alpha1.CFServiceInstance{}
-	err := k8sClient.Get(ctx, types.NamespacedName{Namespace: serviceBinding.Namespace, Name: serviceBinding.Spec.Service.Name}, &serviceInstance)
+	err := k8sClient.Get(ctx, types.NamespacedName{Namespace: serviceBinding.ServiceNamespace(), Name: serviceBinding.Spec.Service.Name}, &serviceInstance)
 	if err != nil {
 		return ServiceDetails{}, fmt.Errorf("error fetching CFServiceInstance: %w", err)
 	}
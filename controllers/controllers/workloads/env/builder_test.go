@@ -464,6 +464,24 @@ var _ = Describe("Builder", func() {
 			Expect(actualNsName.Name).To(Equal("service-binding-secret"))
 		})
 
+		When("the service instance is shared from another space", func() {
+			BeforeEach(func() {
+				serviceBinding.Spec.Service.Namespace = "service-instance-ns"
+			})
+
+			It("gets the service instance from its own namespace", func() {
+				_, actualNsName, _, _ := cfClient.GetArgsForCall(0)
+				Expect(actualNsName.Namespace).To(Equal("service-instance-ns"))
+				Expect(actualNsName.Name).To(Equal("my-service-instance-guid"))
+			})
+
+			It("gets the secret from the binding namespace", func() {
+				_, actualNsName, _, _ := cfClient.GetArgsForCall(1)
+				Expect(actualNsName.Namespace).To(Equal("service-binding-ns"))
+				Expect(actualNsName.Name).To(Equal("service-binding-secret"))
+			})
+		})
+
 		It("returns the service info", func() {
 			Expect(extractServiceInfo(vcapServicesString)).To(ContainElements(
 				SatisfyAll(
@@ -519,6 +537,19 @@ var _ = Describe("Builder", func() {
 			})
 		})
 
+		When("the service instance has a syslog drain url", func() {
+			BeforeEach(func() {
+				serviceInstance.Spec.SyslogDrainURL = "syslog://logs.example.com:514"
+			})
+
+			It("sets the syslog drain url", func() {
+				Expect(extractServiceInfo(vcapServicesString)).To(ContainElement(SatisfyAll(
+					HaveKeyWithValue("instance_guid", "my-service-instance-guid"),
+					HaveKeyWithValue("syslog_drain_url", "syslog://logs.example.com:514"),
+				)))
+			})
+		})
+
 		When("there are no service bindings for the app", func() {
 			BeforeEach(func() {
 				cfClient.ListReturns(nil)
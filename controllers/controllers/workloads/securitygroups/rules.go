@@ -0,0 +1,205 @@
+package securitygroups
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// EgressRules translates security group rules into NetworkPolicy egress rules.
+// NetworkPolicies cannot express ICMP traffic, so icmp rules are validated but produce no egress rule
+func EgressRules(rules []korifiv1alpha1.SecurityGroupRule) ([]networkingv1.NetworkPolicyEgressRule, error) {
+	egressRules := []networkingv1.NetworkPolicyEgressRule{}
+
+	for i, rule := range rules {
+		egressRule, err := egressRule(rule)
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i, err)
+		}
+
+		if egressRule != nil {
+			egressRules = append(egressRules, *egressRule)
+		}
+	}
+
+	return egressRules, nil
+}
+
+// ValidateRule checks that a rule can be translated into an egress rule
+func ValidateRule(rule korifiv1alpha1.SecurityGroupRule) error {
+	_, err := egressRule(rule)
+	return err
+}
+
+func egressRule(rule korifiv1alpha1.SecurityGroupRule) (*networkingv1.NetworkPolicyEgressRule, error) {
+	cidrs, err := ParseDestination(rule.Destination)
+	if err != nil {
+		return nil, err
+	}
+
+	var ports []networkingv1.NetworkPolicyPort
+	switch rule.Protocol {
+	case korifiv1alpha1.SecurityGroupProtocolAll, korifiv1alpha1.SecurityGroupProtocolICMP:
+		if rule.Ports != "" {
+			return nil, fmt.Errorf("ports are not allowed for protocols of type %s", rule.Protocol)
+		}
+		if rule.Protocol == korifiv1alpha1.SecurityGroupProtocolICMP {
+			return nil, nil
+		}
+	case korifiv1alpha1.SecurityGroupProtocolTCP, korifiv1alpha1.SecurityGroupProtocolUDP:
+		ports, err = parsePorts(rule.Ports, corev1.Protocol(strings.ToUpper(rule.Protocol)))
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("protocol %q is not one of tcp, udp, icmp or all", rule.Protocol)
+	}
+
+	peers := make([]networkingv1.NetworkPolicyPeer, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		peers = append(peers, networkingv1.NetworkPolicyPeer{IPBlock: &networkingv1.IPBlock{CIDR: cidr}})
+	}
+
+	return &networkingv1.NetworkPolicyEgressRule{To: peers, Ports: ports}, nil
+}
+
+// ParseDestination converts a comma separated list of IP addresses, IPv4 ranges and CIDR blocks into CIDR blocks
+func ParseDestination(destination string) ([]string, error) {
+	if strings.TrimSpace(destination) == "" {
+		return nil, fmt.Errorf("destination must not be empty")
+	}
+
+	var cidrs []string
+	for _, part := range strings.Split(destination, ",") {
+		part = strings.TrimSpace(part)
+
+		switch {
+		case strings.Contains(part, "/"):
+			prefix, err := netip.ParsePrefix(part)
+			if err != nil {
+				return nil, fmt.Errorf("invalid destination %q", part)
+			}
+			cidrs = append(cidrs, prefix.Masked().String())
+		case strings.Contains(part, "-"):
+			rangeCIDRs, err := rangeToCIDRs(part)
+			if err != nil {
+				return nil, err
+			}
+			cidrs = append(cidrs, rangeCIDRs...)
+		default:
+			addr, err := netip.ParseAddr(part)
+			if err != nil {
+				return nil, fmt.Errorf("invalid destination %q", part)
+			}
+			cidrs = append(cidrs, netip.PrefixFrom(addr, addr.BitLen()).String())
+		}
+	}
+
+	return cidrs, nil
+}
+
+// rangeToCIDRs splits an IPv4 address range into the smallest list of CIDR blocks covering it exactly
+func rangeToCIDRs(ipRange string) ([]string, error) {
+	bounds := strings.SplitN(ipRange, "-", 2)
+	first, err := netip.ParseAddr(strings.TrimSpace(bounds[0]))
+	if err != nil || !first.Is4() {
+		return nil, fmt.Errorf("invalid destination %q: ranges must be between IPv4 addresses", ipRange)
+	}
+	last, err := netip.ParseAddr(strings.TrimSpace(bounds[1]))
+	if err != nil || !last.Is4() {
+		return nil, fmt.Errorf("invalid destination %q: ranges must be between IPv4 addresses", ipRange)
+	}
+	if last.Less(first) {
+		return nil, fmt.Errorf("invalid destination %q: the range end is before its start", ipRange)
+	}
+
+	start := uint64(ipv4ToUint32(first))
+	end := uint64(ipv4ToUint32(last))
+
+	var cidrs []string
+	for start <= end {
+		// the largest block aligned on start which does not go past end
+		size := 32
+		if start != 0 {
+			size = bits.TrailingZeros64(start)
+			if size > 32 {
+				size = 32
+			}
+		}
+		for size > 0 && start+(uint64(1)<<size)-1 > end {
+			size--
+		}
+
+		cidrs = append(cidrs, netip.PrefixFrom(uint32ToIPv4(uint32(start)), 32-size).String())
+		start += uint64(1) << size
+	}
+
+	return cidrs, nil
+}
+
+func ipv4ToUint32(addr netip.Addr) uint32 {
+	bytes := addr.As4()
+	return binary.BigEndian.Uint32(bytes[:])
+}
+
+func uint32ToIPv4(value uint32) netip.Addr {
+	var bytes [4]byte
+	binary.BigEndian.PutUint32(bytes[:], value)
+	return netip.AddrFrom4(bytes)
+}
+
+func parsePorts(ports string, protocol corev1.Protocol) ([]networkingv1.NetworkPolicyPort, error) {
+	if strings.TrimSpace(ports) == "" {
+		return nil, fmt.Errorf("ports are required for protocols of type %s", strings.ToLower(string(protocol)))
+	}
+
+	var policyPorts []networkingv1.NetworkPolicyPort
+	for _, part := range strings.Split(ports, ",") {
+		part = strings.TrimSpace(part)
+		policyPort := networkingv1.NetworkPolicyPort{Protocol: &protocol}
+
+		bounds := strings.SplitN(part, "-", 2)
+		first, err := parsePort(bounds[0])
+		if err != nil {
+			return nil, err
+		}
+		port := intstr.FromInt(first)
+		policyPort.Port = &port
+
+		if len(bounds) == 2 {
+			last, err := parsePort(bounds[1])
+			if err != nil {
+				return nil, err
+			}
+			if last < first {
+				return nil, fmt.Errorf("invalid port range %q", part)
+			}
+			if last > first {
+				endPort := int32(last)
+				policyPort.EndPort = &endPort
+			}
+		}
+
+		policyPorts = append(policyPorts, policyPort)
+	}
+
+	return policyPorts, nil
+}
+
+func parsePort(value string) (int, error) {
+	port, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || port < 1 || port > 65535 {
+		return 0, fmt.Errorf("invalid port %q", value)
+	}
+
+	return port, nil
+}
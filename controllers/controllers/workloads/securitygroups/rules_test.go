@@ -0,0 +1,142 @@
+package securitygroups_test
+
+import (
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/securitygroups"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+var _ = Describe("EgressRules", func() {
+	var (
+		rules       []korifiv1alpha1.SecurityGroupRule
+		egressRules []networkingv1.NetworkPolicyEgressRule
+		err         error
+	)
+
+	JustBeforeEach(func() {
+		egressRules, err = securitygroups.EgressRules(rules)
+	})
+
+	When("the rule is a tcp rule", func() {
+		BeforeEach(func() {
+			rules = []korifiv1alpha1.SecurityGroupRule{{
+				Protocol:    "tcp",
+				Destination: "10.0.0.1,192.168.0.0/24",
+				Ports:       "443,8000-9000",
+			}}
+		})
+
+		It("allows the ports to the destinations", func() {
+			Expect(err).NotTo(HaveOccurred())
+
+			tcp := corev1.ProtocolTCP
+			Expect(egressRules).To(Equal([]networkingv1.NetworkPolicyEgressRule{{
+				To: []networkingv1.NetworkPolicyPeer{
+					{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.1/32"}},
+					{IPBlock: &networkingv1.IPBlock{CIDR: "192.168.0.0/24"}},
+				},
+				Ports: []networkingv1.NetworkPolicyPort{
+					{Protocol: &tcp, Port: tools.PtrTo(intstr.FromInt(443))},
+					{Protocol: &tcp, Port: tools.PtrTo(intstr.FromInt(8000)), EndPort: tools.PtrTo(int32(9000))},
+				},
+			}}))
+		})
+	})
+
+	When("the rule allows all protocols", func() {
+		BeforeEach(func() {
+			rules = []korifiv1alpha1.SecurityGroupRule{{Protocol: "all", Destination: "0.0.0.0/0"}}
+		})
+
+		It("allows any traffic to the destination", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(egressRules).To(Equal([]networkingv1.NetworkPolicyEgressRule{{
+				To: []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: "0.0.0.0/0"}}},
+			}}))
+		})
+	})
+
+	When("the rule is an icmp rule", func() {
+		BeforeEach(func() {
+			rules = []korifiv1alpha1.SecurityGroupRule{{Protocol: "icmp", Destination: "10.0.0.0/8", Type: tools.PtrTo(0), Code: tools.PtrTo(0)}}
+		})
+
+		It("produces no egress rule", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(egressRules).To(BeEmpty())
+		})
+	})
+
+	When("a tcp rule has no ports", func() {
+		BeforeEach(func() {
+			rules = []korifiv1alpha1.SecurityGroupRule{{Protocol: "tcp", Destination: "10.0.0.0/8"}}
+		})
+
+		It("returns an error", func() {
+			Expect(err).To(MatchError(ContainSubstring("ports are required")))
+		})
+	})
+
+	When("an all rule has ports", func() {
+		BeforeEach(func() {
+			rules = []korifiv1alpha1.SecurityGroupRule{{Protocol: "all", Destination: "10.0.0.0/8", Ports: "80"}}
+		})
+
+		It("returns an error", func() {
+			Expect(err).To(MatchError(ContainSubstring("ports are not allowed")))
+		})
+	})
+
+	When("a port is out of range", func() {
+		BeforeEach(func() {
+			rules = []korifiv1alpha1.SecurityGroupRule{{Protocol: "udp", Destination: "10.0.0.0/8", Ports: "70000"}}
+		})
+
+		It("returns an error", func() {
+			Expect(err).To(MatchError(ContainSubstring(`invalid port "70000"`)))
+		})
+	})
+
+	When("the protocol is unknown", func() {
+		BeforeEach(func() {
+			rules = []korifiv1alpha1.SecurityGroupRule{{Protocol: "sctp", Destination: "10.0.0.0/8", Ports: "80"}}
+		})
+
+		It("returns an error", func() {
+			Expect(err).To(MatchError(ContainSubstring(`protocol "sctp"`)))
+		})
+	})
+})
+
+var _ = Describe("ParseDestination", func() {
+	DescribeTable("valid destinations",
+		func(destination string, expected []string) {
+			Expect(securitygroups.ParseDestination(destination)).To(Equal(expected))
+		},
+		Entry("an IPv4 address", "10.0.0.1", []string{"10.0.0.1/32"}),
+		Entry("an IPv6 address", "2001:db8::1", []string{"2001:db8::1/128"}),
+		Entry("a CIDR block", "10.1.2.3/16", []string{"10.1.0.0/16"}),
+		Entry("an aligned range", "192.168.0.0-192.168.0.255", []string{"192.168.0.0/24"}),
+		Entry("an unaligned range", "10.0.0.1-10.0.0.6", []string{"10.0.0.1/32", "10.0.0.2/31", "10.0.0.4/31", "10.0.0.6/32"}),
+		Entry("the whole address space", "0.0.0.0-255.255.255.255", []string{"0.0.0.0/0"}),
+		Entry("a list", "10.0.0.1, 10.0.1.0/24", []string{"10.0.0.1/32", "10.0.1.0/24"}),
+	)
+
+	DescribeTable("invalid destinations",
+		func(destination string) {
+			_, err := securitygroups.ParseDestination(destination)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("empty", ""),
+		Entry("not an address", "example.com"),
+		Entry("a reversed range", "10.0.0.9-10.0.0.1"),
+		Entry("an IPv6 range", "2001:db8::1-2001:db8::9"),
+		Entry("an invalid CIDR block", "10.0.0.0/33"),
+	)
+})
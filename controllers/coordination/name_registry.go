@@ -8,6 +8,7 @@ import (
 	"code.cloudfoundry.org/korifi/controllers/webhooks"
 
 	coordinationv1 "k8s.io/api/coordination/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -115,6 +116,21 @@ func (r NameRegistry) UnlockName(ctx context.Context, namespace, name string) er
 	return nil
 }
 
+// IsNameRegistered reports whether the name has been registered, i.e. whether registering it would fail
+func (r NameRegistry) IsNameRegistered(ctx context.Context, namespace, name string) (bool, error) {
+	lease := &coordinationv1.Lease{}
+	err := r.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: hashName(r.entityType, name)}, lease)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("getting a lease failed: %w", err)
+	}
+
+	return true, nil
+}
+
 func hashName(entityType, name string) string {
 	input := fmt.Sprintf("%s::%s", entityType, name)
 	return fmt.Sprintf("%s%x", hashedNamePrefix, sha1.Sum([]byte(input)))
@@ -198,4 +198,54 @@ var _ = Describe("NameRegistry", func() {
 			})
 		})
 	})
+	Describe("IsNameRegistered", func() {
+		var registered bool
+
+		JustBeforeEach(func() {
+			registered, err = nameRegistry.IsNameRegistered(ctx, namespace, name)
+		})
+
+		It("gets the lease", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(registered).To(BeTrue())
+
+			Expect(client.GetCallCount()).To(Equal(1))
+			_, key, obj, _ := client.GetArgsForCall(0)
+			Expect(obj).To(BeAssignableToTypeOf(&coordinationv1.Lease{}))
+			Expect(key.Namespace).To(Equal(namespace))
+			Expect(key.Name).To(HavePrefix("n-"))
+		})
+
+		It("looks up the lease created by RegisterName", func() {
+			Expect(nameRegistry.RegisterName(ctx, namespace, name)).To(Succeed())
+			_, obj, _ := client.CreateArgsForCall(0)
+
+			_, key, _, _ := client.GetArgsForCall(0)
+			Expect(key.Name).To(Equal(obj.GetName()))
+		})
+
+		When("the lease does not exist", func() {
+			BeforeEach(func() {
+				client.GetReturns(k8serrors.NewNotFound(schema.GroupResource{}, "some-name"))
+			})
+
+			It("returns false", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(registered).To(BeFalse())
+			})
+		})
+
+		When("getting the lease fails", func() {
+			BeforeEach(func() {
+				client.GetReturns(errors.New("boom!"))
+			})
+
+			It("returns the error", func() {
+				Expect(err).To(MatchError(SatisfyAll(
+					ContainSubstring("boom!"),
+					ContainSubstring("getting a lease failed"),
+				)))
+			})
+		})
+	})
 })
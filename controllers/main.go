@@ -26,6 +26,7 @@ import (
 	"code.cloudfoundry.org/korifi/controllers/config"
 	networkingcontrollers "code.cloudfoundry.org/korifi/controllers/controllers/networking"
 	servicescontrollers "code.cloudfoundry.org/korifi/controllers/controllers/services"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/syslog"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
 	workloadscontrollers "code.cloudfoundry.org/korifi/controllers/controllers/workloads"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/env"
@@ -35,16 +36,22 @@ import (
 	"code.cloudfoundry.org/korifi/controllers/webhooks/services"
 	"code.cloudfoundry.org/korifi/controllers/webhooks/workloads"
 
+	contourv1 "github.com/projectcontour/contour/apis/projectcontour/v1"
 	servicebindingv1beta1 "github.com/servicebinding/service-binding-controller/apis/v1beta1"
 	"go.uber.org/zap/zapcore"
 	networkingv1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -62,6 +69,9 @@ func init() {
 	utilruntime.Must(korifiv1alpha1.AddToScheme(scheme))
 	utilruntime.Must(servicebindingv1beta1.AddToScheme(scheme))
 	utilruntime.Must(networkingv1alpha3.AddToScheme(scheme))
+	utilruntime.Must(contourv1.AddToScheme(scheme))
+	utilruntime.Must(gatewayv1alpha2.AddToScheme(scheme))
+	utilruntime.Must(gatewayv1beta1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
 
@@ -99,6 +109,12 @@ func main() {
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "13c200ec.cloudfoundry.org",
+		// only app pods are watched, see the syslog drain controller
+		NewCache: cache.BuilderWithOptions(cache.Options{
+			SelectorsByObject: cache.SelectorsByObject{
+				&corev1.Pod{}: {Label: servicescontrollers.AppPodsSelector()},
+			},
+		}),
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
@@ -170,6 +186,25 @@ func main() {
 			os.Exit(1)
 		}
 
+		if err = (networkingcontrollers.NewCFDomainReconciler(
+			mgr.GetClient(),
+			mgr.GetScheme(),
+			ctrl.Log.WithName("controllers").WithName("CFDomain"),
+			controllerConfig,
+		)).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "CFDomain")
+			os.Exit(1)
+		}
+
+		if err = (networkingcontrollers.NewCFNetworkPolicyReconciler(
+			mgr.GetClient(),
+			mgr.GetScheme(),
+			ctrl.Log.WithName("controllers").WithName("CFNetworkPolicy"),
+		)).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "CFNetworkPolicy")
+			os.Exit(1)
+		}
+
 		if err = (servicescontrollers.NewCFServiceInstanceReconciler(
 			mgr.GetClient(),
 			mgr.GetScheme(),
@@ -188,6 +223,30 @@ func main() {
 			os.Exit(1)
 		}
 
+		if err = (servicescontrollers.NewCFServiceRouteBindingReconciler(
+			mgr.GetClient(),
+			mgr.GetScheme(),
+			ctrl.Log.WithName("controllers").WithName("CFServiceRouteBinding"),
+		)).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "CFServiceRouteBinding")
+			os.Exit(1)
+		}
+
+		clientset, err := kubernetes.NewForConfig(mgr.GetConfig())
+		if err != nil {
+			setupLog.Error(err, "unable to create clientset")
+			os.Exit(1)
+		}
+
+		if err = (servicescontrollers.NewCFAppSyslogDrainReconciler(
+			mgr.GetClient(),
+			syslog.NewForwarder(clientset, nil, 5*time.Second, ctrl.Log.WithName("syslog-forwarder")),
+			ctrl.Log.WithName("controllers").WithName("CFAppSyslogDrain"),
+		)).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "CFAppSyslogDrain")
+			os.Exit(1)
+		}
+
 		if err = workloadscontrollers.NewCFOrgReconciler(
 			mgr.GetClient(),
 			mgr.GetScheme(),
@@ -263,15 +322,24 @@ func main() {
 			os.Exit(1)
 		}
 
+		quotaValidator := webhooks.NewCFQuotaValidator(mgr.GetClient(), controllerConfig.CFRootNamespace)
+
 		if err = workloads.NewCFAppValidator(
 			webhooks.NewDuplicateValidator(coordination.NewNameRegistry(mgr.GetClient(), workloads.AppEntityType)),
+			quotaValidator,
 		).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "CFApp")
 			os.Exit(1)
 		}
 
+		if err = workloads.NewCFProcessValidator(quotaValidator).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "CFProcess")
+			os.Exit(1)
+		}
+
 		if err = networking.NewCFRouteValidator(
 			webhooks.NewDuplicateValidator(coordination.NewNameRegistry(mgr.GetClient(), networking.RouteEntityType)),
+			quotaValidator,
 			controllerConfig.CFRootNamespace,
 			mgr.GetClient(),
 		).SetupWebhookWithManager(mgr); err != nil {
@@ -281,6 +349,7 @@ func main() {
 
 		if err = services.NewCFServiceInstanceValidator(
 			webhooks.NewDuplicateValidator(coordination.NewNameRegistry(mgr.GetClient(), services.ServiceInstanceEntityType)),
+			quotaValidator,
 		).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "CFServiceInstance")
 			os.Exit(1)
@@ -288,11 +357,19 @@ func main() {
 
 		if err = services.NewCFServiceBindingValidator(
 			webhooks.NewDuplicateValidator(coordination.NewNameRegistry(mgr.GetClient(), services.ServiceBindingEntityType)),
+			mgr.GetClient(),
 		).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "CFServiceBinding")
 			os.Exit(1)
 		}
 
+		if err = services.NewCFServiceRouteBindingValidator(
+			webhooks.NewDuplicateValidator(coordination.NewNameRegistry(mgr.GetClient(), services.ServiceRouteBindingEntityType)),
+		).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "CFServiceRouteBinding")
+			os.Exit(1)
+		}
+
 		if err = networking.NewCFDomainValidator(
 			mgr.GetClient(),
 		).SetupWebhookWithManager(mgr); err != nil {
@@ -326,7 +403,7 @@ func main() {
 			os.Exit(1)
 		}
 
-		if err = workloads.NewCFTaskValidator().SetupWebhookWithManager(mgr); err != nil {
+		if err = workloads.NewCFTaskValidator(quotaValidator).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "CFTask")
 			os.Exit(1)
 		}
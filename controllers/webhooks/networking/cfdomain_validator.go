@@ -79,6 +79,13 @@ func (v *CFDomainValidator) ValidateCreate(ctx context.Context, obj runtime.Obje
 		}.ExportJSONError()
 	}
 
+	if domain.Spec.Internal && domain.Spec.RouterGroup != "" {
+		return webhooks.ValidationError{
+			Type:    InvalidDomainErrorType,
+			Message: "Internal domains cannot have a router group",
+		}.ExportJSONError()
+	}
+
 	isOverlapping, err := v.domainIsOverlapping(ctx, domain.Spec.Name)
 	if err != nil {
 		log.Error(err, "Error checking for overlapping domain")
@@ -124,6 +131,13 @@ func (v *CFDomainValidator) ValidateUpdate(ctx context.Context, oldObj runtime.O
 		}.ExportJSONError()
 	}
 
+	if oldDomain.Spec.Internal != domain.Spec.Internal {
+		return webhooks.ValidationError{
+			Type:    webhooks.ImmutableFieldErrorType,
+			Message: fmt.Sprintf(webhooks.ImmutableFieldErrorMessageTemplate, "CFDomain.Spec.Internal"),
+		}.ExportJSONError()
+	}
+
 	return nil
 }
 
@@ -150,6 +150,20 @@ var _ = Describe("CFDomainValidator", func() {
 				))
 			})
 		})
+
+		When("an internal domain has a router group", func() {
+			BeforeEach(func() {
+				requestDomainCR.Spec.Internal = true
+				requestDomainCR.Spec.RouterGroup = "default-tcp"
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(matchers.BeValidationError(
+					networking.InvalidDomainErrorType,
+					Equal("Internal domains cannot have a router group"),
+				))
+			})
+		})
 	})
 
 	Describe("ValidateUpdate", func() {
@@ -174,6 +188,20 @@ var _ = Describe("CFDomainValidator", func() {
 			))
 		})
 
+		When("the internal flag is updated", func() {
+			BeforeEach(func() {
+				updatedCFDomain.Spec.Name = oldCFDomain.Spec.Name
+				updatedCFDomain.Spec.Internal = true
+			})
+
+			It("returns an error", func() {
+				Expect(retErr).To(matchers.BeValidationError(
+					webhooks.ImmutableFieldErrorType,
+					Equal("'CFDomain.Spec.Internal' field is immutable"),
+				))
+			})
+		})
+
 		When("the domain is being deleted", func() {
 			BeforeEach(func() {
 				updatedCFDomain.DeletionTimestamp = &metav1.Time{Time: time.Now()}
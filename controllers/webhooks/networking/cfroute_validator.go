@@ -5,12 +5,16 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/webhooks"
 	"github.com/hashicorp/go-multierror"
 
+	authv1 "k8s.io/api/authorization/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -19,6 +23,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
 const (
@@ -30,11 +35,19 @@ const (
 	RoutePathValidationErrorType           = "RoutePathValidationError"
 	RouteSubdomainValidationErrorType      = "RouteSubdomainValidationError"
 	RouteSubdomainValidationErrorMessage   = "Subdomains must each be at most 63 characters"
+	RouteProtocolValidationErrorType       = "RouteProtocolValidationError"
+	RoutePortValidationErrorType           = "RoutePortValidationError"
+	RouteWeightValidationErrorType         = "RouteWeightValidationError"
+	RouteSharedSpaceValidationErrorType    = "RouteSharedSpaceValidationError"
+	RouteOptionsValidationErrorType        = "RouteOptionsValidationError"
+	RouteTransferValidationErrorType       = "RouteTransferValidationError"
 
 	HostEmptyError  = "host cannot be empty"
 	HostLengthError = "host is too long (maximum is 63 characters)"
 	HostFormatError = "host must be either \"*\" or contain only alphanumeric characters, \"_\", or \"-\""
 
+	WildcardHostForbiddenError = "You do not have sufficient permissions to create a route with a wildcard host on a domain not scoped to an organization."
+
 	InvalidURIError          = "Invalid Route URI"
 	PathIsSlashError         = "Path cannot be a single slash"
 	PathHasQuestionMarkError = "Path cannot contain a question mark"
@@ -45,8 +58,11 @@ var logger = logf.Log.WithName("route-validation")
 
 //+kubebuilder:webhook:path=/validate-korifi-cloudfoundry-org-v1alpha1-cfroute,mutating=false,failurePolicy=fail,sideEffects=None,groups=korifi.cloudfoundry.org,resources=cfroutes,verbs=create;update;delete,versions=v1alpha1,name=vcfroute.korifi.cloudfoundry.org,admissionReviewVersions={v1,v1beta1}
 
+//+kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
 type CFRouteValidator struct {
 	duplicateValidator webhooks.NameValidator
+	quotaValidator     webhooks.QuotaValidator
 	rootNamespace      string
 	client             client.Client
 }
@@ -55,11 +71,13 @@ var _ webhook.CustomValidator = &CFRouteValidator{}
 
 func NewCFRouteValidator(
 	nameValidator webhooks.NameValidator,
+	quotaValidator webhooks.QuotaValidator,
 	rootNamespace string,
 	client client.Client,
 ) *CFRouteValidator {
 	return &CFRouteValidator{
 		duplicateValidator: nameValidator,
+		quotaValidator:     quotaValidator,
 		rootNamespace:      rootNamespace,
 		client:             client,
 	}
@@ -83,8 +101,22 @@ func (v *CFRouteValidator) ValidateCreate(ctx context.Context, obj runtime.Objec
 		return err
 	}
 
+	validationErr := v.quotaValidator.ValidateRouteCreate(ctx, logger, route.Namespace)
+	if validationErr != nil {
+		return validationErr.ExportJSONError()
+	}
+
+	// a transferred route takes over the name registered by the route it is recreated from
+	if transferredFrom, ok := route.Annotations[korifiv1alpha1.CFRouteTransferredFromAnnotation]; ok {
+		return v.validateTransfer(ctx, route, domain, transferredFrom)
+	}
+
+	if err = v.validateWildcardHost(ctx, route); err != nil {
+		return err
+	}
+
 	duplicateErrorMessage := generateDuplicateErrorMessage(route, domain)
-	validationErr := v.duplicateValidator.ValidateCreate(ctx, logger, v.rootNamespace, uniqueName(*route), duplicateErrorMessage)
+	validationErr = v.duplicateValidator.ValidateCreate(ctx, logger, v.rootNamespace, route.UniqueName(domain.Spec.RouterGroup), duplicateErrorMessage)
 	if validationErr != nil {
 		return validationErr.ExportJSONError()
 	}
@@ -92,6 +124,89 @@ func (v *CFRouteValidator) ValidateCreate(ctx context.Context, obj runtime.Objec
 	return nil
 }
 
+// validateTransfer checks that the route is recreated from a route with the same name that is being
+// transferred to the namespace of the route
+func (v *CFRouteValidator) validateTransfer(ctx context.Context, route *korifiv1alpha1.CFRoute, domain *korifiv1alpha1.CFDomain, transferredFrom string) error {
+	originalRoute := new(korifiv1alpha1.CFRoute)
+	err := v.client.Get(ctx, types.NamespacedName{Namespace: transferredFrom, Name: route.Name}, originalRoute)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return webhooks.ValidationError{
+				Type:    RouteTransferValidationErrorType,
+				Message: fmt.Sprintf("Route %q does not exist in namespace %q", route.Name, transferredFrom),
+			}.ExportJSONError()
+		}
+
+		return webhooks.ValidationError{
+			Type:    webhooks.UnknownErrorType,
+			Message: webhooks.UnknownErrorMessage,
+		}.ExportJSONError()
+	}
+
+	if originalRoute.Annotations[korifiv1alpha1.CFRouteTransferredToAnnotation] != route.Namespace || originalRoute.Spec.DomainRef != route.Spec.DomainRef ||
+		originalRoute.UniqueName(domain.Spec.RouterGroup) != route.UniqueName(domain.Spec.RouterGroup) {
+		return webhooks.ValidationError{
+			Type:    RouteTransferValidationErrorType,
+			Message: fmt.Sprintf("Route %q in namespace %q is not being transferred to namespace %q", route.Name, transferredFrom, route.Namespace),
+		}.ExportJSONError()
+	}
+
+	return nil
+}
+
+// validateWildcardHost only lets the users who can manage shared domains create wildcard routes on
+// them, as such routes catch the traffic of every unclaimed host of the domain, across all orgs
+func (v *CFRouteValidator) validateWildcardHost(ctx context.Context, route *korifiv1alpha1.CFRoute) error {
+	if route.Spec.Host != korifiv1alpha1.WildcardHost || route.Spec.DomainRef.Namespace != v.rootNamespace {
+		return nil
+	}
+
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		logger.Info("failed to get admission request from context", "reason", err)
+		return webhooks.ValidationError{
+			Type:    webhooks.UnknownErrorType,
+			Message: webhooks.UnknownErrorMessage,
+		}.ExportJSONError()
+	}
+
+	extra := map[string]authv1.ExtraValue{}
+	for key, value := range req.UserInfo.Extra {
+		extra[key] = authv1.ExtraValue(value)
+	}
+
+	review := &authv1.SubjectAccessReview{
+		Spec: authv1.SubjectAccessReviewSpec{
+			User:   req.UserInfo.Username,
+			Groups: req.UserInfo.Groups,
+			UID:    req.UserInfo.UID,
+			Extra:  extra,
+			ResourceAttributes: &authv1.ResourceAttributes{
+				Namespace: v.rootNamespace,
+				Verb:      "create",
+				Group:     korifiv1alpha1.GroupVersion.Group,
+				Resource:  "cfdomains",
+			},
+		},
+	}
+	if err = v.client.Create(ctx, review); err != nil {
+		logger.Info("failed to create subject access review", "reason", err)
+		return webhooks.ValidationError{
+			Type:    webhooks.UnknownErrorType,
+			Message: webhooks.UnknownErrorMessage,
+		}.ExportJSONError()
+	}
+
+	if !review.Status.Allowed {
+		return webhooks.ValidationError{
+			Type:    RouteHostNameValidationErrorType,
+			Message: WildcardHostForbiddenError,
+		}.ExportJSONError()
+	}
+
+	return nil
+}
+
 func (v *CFRouteValidator) ValidateUpdate(ctx context.Context, oldObj, obj runtime.Object) error {
 	route, ok := obj.(*korifiv1alpha1.CFRoute)
 	if !ok {
@@ -121,11 +236,16 @@ func (v *CFRouteValidator) ValidateUpdate(ctx context.Context, oldObj, obj runti
 		return immutableError.ExportJSONError()
 	}
 
-	if route.Spec.Protocol != oldRoute.Spec.Protocol {
+	if routeProtocol(*route) != routeProtocol(*oldRoute) {
 		immutableError.Message = fmt.Sprintf(webhooks.ImmutableFieldErrorMessageTemplate, "CFRoute.Spec.Protocol")
 		return immutableError.ExportJSONError()
 	}
 
+	if route.Spec.Port != oldRoute.Spec.Port {
+		immutableError.Message = fmt.Sprintf(webhooks.ImmutableFieldErrorMessageTemplate, "CFRoute.Spec.Port")
+		return immutableError.ExportJSONError()
+	}
+
 	if route.Spec.DomainRef.Name != oldRoute.Spec.DomainRef.Name {
 		immutableError.Message = fmt.Sprintf(webhooks.ImmutableFieldErrorMessageTemplate, "CFRoute.Spec.DomainRef.Name")
 		return immutableError.ExportJSONError()
@@ -136,8 +256,12 @@ func (v *CFRouteValidator) ValidateUpdate(ctx context.Context, oldObj, obj runti
 		return err
 	}
 
+	if err = validateOptions(route, domain); err != nil {
+		return err
+	}
+
 	duplicateErrorMessage := generateDuplicateErrorMessage(route, domain)
-	validationErr := v.duplicateValidator.ValidateUpdate(ctx, logger, v.rootNamespace, uniqueName(*oldRoute), uniqueName(*route), duplicateErrorMessage)
+	validationErr := v.duplicateValidator.ValidateUpdate(ctx, logger, v.rootNamespace, oldRoute.UniqueName(domain.Spec.RouterGroup), route.UniqueName(domain.Spec.RouterGroup), duplicateErrorMessage)
 	if validationErr != nil {
 		return validationErr.ExportJSONError()
 	}
@@ -151,7 +275,33 @@ func (v *CFRouteValidator) ValidateDelete(ctx context.Context, obj runtime.Objec
 		return apierrors.NewBadRequest(fmt.Sprintf("expected a CFRoute but got a %T", obj))
 	}
 
-	validationErr := v.duplicateValidator.ValidateDelete(ctx, logger, v.rootNamespace, uniqueName(*route))
+	// the name of a transferred route has been taken over by the route it was recreated as
+	transferred, err := v.isTransferred(ctx, route)
+	if err != nil {
+		return webhooks.ValidationError{
+			Type:    webhooks.UnknownErrorType,
+			Message: webhooks.UnknownErrorMessage,
+		}.ExportJSONError()
+	}
+	if transferred {
+		return nil
+	}
+
+	routerGroup, err := v.routerGroup(ctx, route)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// without its domain there is no telling which router group the port of a tcp route was claimed in
+			logger.Info("cannot deregister the name of a tcp route whose domain does not exist", "namespace", route.Namespace, "name", route.Name)
+			return nil
+		}
+
+		return webhooks.ValidationError{
+			Type:    webhooks.UnknownErrorType,
+			Message: webhooks.UnknownErrorMessage,
+		}.ExportJSONError()
+	}
+
+	validationErr := v.duplicateValidator.ValidateDelete(ctx, logger, v.rootNamespace, route.UniqueName(routerGroup))
 	if validationErr != nil {
 		return validationErr.ExportJSONError()
 	}
@@ -159,23 +309,131 @@ func (v *CFRouteValidator) ValidateDelete(ctx context.Context, obj runtime.Objec
 	return nil
 }
 
+func (v *CFRouteValidator) isTransferred(ctx context.Context, route *korifiv1alpha1.CFRoute) (bool, error) {
+	transferredTo, ok := route.Annotations[korifiv1alpha1.CFRouteTransferredToAnnotation]
+	if !ok {
+		return false, nil
+	}
+
+	transferredRoute := new(korifiv1alpha1.CFRoute)
+	err := v.client.Get(ctx, types.NamespacedName{Namespace: transferredTo, Name: route.Name}, transferredRoute)
+	if err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+
+	// routes on the same domain share the router group
+	return transferredRoute.Spec.DomainRef == route.Spec.DomainRef && transferredRoute.UniqueName("") == route.UniqueName(""), nil
+}
+
+// routerGroup returns the router group of the domain of tcp routes, which is part of their unique name
+func (v *CFRouteValidator) routerGroup(ctx context.Context, route *korifiv1alpha1.CFRoute) (string, error) {
+	if !route.IsTCP() {
+		return "", nil
+	}
+
+	domain := new(korifiv1alpha1.CFDomain)
+	err := v.client.Get(ctx, types.NamespacedName{Namespace: route.Spec.DomainRef.Namespace, Name: route.Spec.DomainRef.Name}, domain)
+	if err != nil {
+		return "", err
+	}
+
+	return domain.Spec.RouterGroup, nil
+}
+
 func (v *CFRouteValidator) validateRoute(ctx context.Context, route *korifiv1alpha1.CFRoute) (*korifiv1alpha1.CFDomain, error) {
 	domain, err := v.validateDestinations(ctx, route)
 	if err != nil {
 		return domain, err
 	}
 
+	if routeProtocol(*route) == korifiv1alpha1.TCPProtocol {
+		if err = validateTCPRoute(route, domain); err != nil {
+			return domain, err
+		}
+
+		return domain, validateOptions(route, domain)
+	}
+
+	if domain.Spec.RouterGroup != "" {
+		return nil, webhooks.ValidationError{
+			Type:    RouteProtocolValidationErrorType,
+			Message: fmt.Sprintf("Domain %q only supports tcp routes", domain.Spec.Name),
+		}.ExportJSONError()
+	}
+
+	if route.Spec.Port != 0 {
+		return nil, webhooks.ValidationError{
+			Type:    RoutePortValidationErrorType,
+			Message: "Ports are only supported for tcp routes",
+		}.ExportJSONError()
+	}
+
+	// only routes on private domains, which live in an org namespace, can claim the domain itself
+	if route.Spec.Host == "" && route.Spec.DomainRef.Namespace == v.rootNamespace {
+		return nil, webhooks.ValidationError{
+			Type:    RouteHostNameValidationErrorType,
+			Message: fmt.Sprintf("Routes on shared domain %q must have a host: %s", domain.Spec.Name, HostEmptyError),
+		}.ExportJSONError()
+	}
+
 	if err = validateFQDN(route.Spec.Host, domain.Spec.Name); err != nil {
 		return nil, err
 	}
 
+	if domain.Spec.Internal {
+		if err = validateInternalRoute(route, domain); err != nil {
+			return domain, err
+		}
+
+		return domain, validateOptions(route, domain)
+	}
+
 	if err = validatePath(route.Spec.Path); err != nil {
 		return nil, err
 	}
 
+	if err = validateOptions(route, domain); err != nil {
+		return nil, err
+	}
+
 	return domain, nil
 }
 
+func validateTCPRoute(route *korifiv1alpha1.CFRoute, domain *korifiv1alpha1.CFDomain) error {
+	if domain.Spec.RouterGroup == "" {
+		return webhooks.ValidationError{
+			Type:    RouteProtocolValidationErrorType,
+			Message: fmt.Sprintf("Domain %q does not support tcp routes", domain.Spec.Name),
+		}.ExportJSONError()
+	}
+
+	if route.Spec.Host != "" || route.Spec.Path != "" {
+		return webhooks.ValidationError{
+			Type:    RouteProtocolValidationErrorType,
+			Message: "Hosts and paths are not supported for tcp routes",
+		}.ExportJSONError()
+	}
+
+	if route.Spec.Port < 1 || route.Spec.Port > 65535 {
+		return webhooks.ValidationError{
+			Type:    RoutePortValidationErrorType,
+			Message: fmt.Sprintf("Port %d is not valid: must be between 1 and 65535", route.Spec.Port),
+		}.ExportJSONError()
+	}
+
+	return nil
+}
+
+// routeProtocol returns the protocol of the route, taking into account that routes created
+// before the protocol was defaulted have none
+func routeProtocol(route korifiv1alpha1.CFRoute) korifiv1alpha1.Protocol {
+	if route.Spec.Protocol == "" {
+		return korifiv1alpha1.HTTPProtocol
+	}
+
+	return route.Spec.Protocol
+}
+
 func (v *CFRouteValidator) fetchDomain(ctx context.Context, route *korifiv1alpha1.CFRoute) (*korifiv1alpha1.CFDomain, error) {
 	domain := &korifiv1alpha1.CFDomain{}
 	err := v.client.Get(ctx, types.NamespacedName{Name: route.Spec.DomainRef.Name, Namespace: route.Spec.DomainRef.Namespace}, domain)
@@ -195,6 +453,15 @@ func (v *CFRouteValidator) validateDestinations(ctx context.Context, route *kori
 	if err != nil {
 		return domain, err
 	}
+	if err = validateDestinationProtocols(*route); err != nil {
+		return domain, err
+	}
+	if err = validateDestinationWeights(*route); err != nil {
+		return domain, err
+	}
+	if err = validateSharedSpaces(*route); err != nil {
+		return domain, err
+	}
 	if err = v.checkDestinationsExistInNamespace(ctx, *route); err != nil {
 		validationErr := webhooks.ValidationError{}
 
@@ -212,7 +479,177 @@ func (v *CFRouteValidator) validateDestinations(ctx context.Context, route *kori
 	return domain, nil
 }
 
+func validateDestinationProtocols(route korifiv1alpha1.CFRoute) error {
+	allowedProtocols := []string{korifiv1alpha1.HTTP1DestinationProtocol, korifiv1alpha1.HTTP2DestinationProtocol}
+	if routeProtocol(route) == korifiv1alpha1.TCPProtocol {
+		allowedProtocols = []string{korifiv1alpha1.TCPDestinationProtocol}
+	}
+
+	for _, destination := range route.Spec.Destinations {
+		if !contains(allowedProtocols, destination.Protocol) {
+			return webhooks.ValidationError{
+				Type: RouteProtocolValidationErrorType,
+				Message: fmt.Sprintf("Destination protocol %q is not supported for %s routes, must be one of: %s",
+					destination.Protocol, routeProtocol(route), strings.Join(allowedProtocols, ", ")),
+			}.ExportJSONError()
+		}
+	}
+
+	return nil
+}
+
+func validateDestinationWeights(route korifiv1alpha1.CFRoute) error {
+	weighted := 0
+	totalWeight := 0
+	for _, destination := range route.Spec.Destinations {
+		if destination.Weight != nil {
+			weighted++
+			totalWeight += *destination.Weight
+		}
+	}
+
+	if weighted == 0 {
+		return nil
+	}
+
+	if weighted != len(route.Spec.Destinations) {
+		return webhooks.ValidationError{
+			Type:    RouteWeightValidationErrorType,
+			Message: "Destinations cannot contain both weighted and unweighted destinations",
+		}.ExportJSONError()
+	}
+
+	if totalWeight != 100 {
+		return webhooks.ValidationError{
+			Type:    RouteWeightValidationErrorType,
+			Message: fmt.Sprintf("Destination weights must add up to 100, got %d", totalWeight),
+		}.ExportJSONError()
+	}
+
+	return nil
+}
+
+func validateSharedSpaces(route korifiv1alpha1.CFRoute) error {
+	if contains(route.Spec.SharedSpaces, route.Namespace) {
+		return webhooks.ValidationError{
+			Type:    RouteSharedSpaceValidationErrorType,
+			Message: "Routes cannot be shared with the space they belong to",
+		}.ExportJSONError()
+	}
+
+	for _, destination := range route.Spec.Destinations {
+		namespace := route.DestinationNamespace(destination)
+		if namespace != route.Namespace && !contains(route.Spec.SharedSpaces, namespace) {
+			return webhooks.ValidationError{
+				Type:    RouteSharedSpaceValidationErrorType,
+				Message: fmt.Sprintf("Route destination app %q is in space %q, which the route is not shared with", destination.AppRef.Name, namespace),
+			}.ExportJSONError()
+		}
+	}
+
+	return nil
+}
+
+// validateOptions rejects the options of tcp routes and of routes on internal domains, which are not
+// served by the ingress, and validates the options of the other routes
+func validateOptions(route *korifiv1alpha1.CFRoute, domain *korifiv1alpha1.CFDomain) error {
+	if len(route.Spec.Options) == 0 {
+		return nil
+	}
+
+	if routeProtocol(*route) == korifiv1alpha1.TCPProtocol {
+		return webhooks.ValidationError{
+			Type:    RouteOptionsValidationErrorType,
+			Message: "Route options are not supported for tcp routes",
+		}.ExportJSONError()
+	}
+
+	if domain.Spec.Internal {
+		return webhooks.ValidationError{
+			Type:    RouteOptionsValidationErrorType,
+			Message: "Route options are not supported for routes on internal domains",
+		}.ExportJSONError()
+	}
+
+	return validateRouteOptions(route.Spec.Options)
+}
+
+func validateRouteOptions(options map[string]string) error {
+	names := make([]string, 0, len(options))
+	for name := range options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value := options[name]
+		var err error
+		switch name {
+		case korifiv1alpha1.LoadBalancingRouteOption:
+			if value != korifiv1alpha1.RoundRobinLoadBalancing && value != korifiv1alpha1.LeastConnectionLoadBalancing {
+				err = fmt.Errorf("must be one of %q or %q", korifiv1alpha1.RoundRobinLoadBalancing, korifiv1alpha1.LeastConnectionLoadBalancing)
+			}
+		case korifiv1alpha1.RequestTimeoutRouteOption, korifiv1alpha1.IdleTimeoutRouteOption:
+			err = validateTimeout(value)
+		case korifiv1alpha1.RateLimitRouteOption:
+			err = validateRateLimit(value)
+		default:
+			return webhooks.ValidationError{
+				Type: RouteOptionsValidationErrorType,
+				Message: fmt.Sprintf("Route option %q is not supported, supported options are: %s", name, strings.Join([]string{
+					korifiv1alpha1.LoadBalancingRouteOption,
+					korifiv1alpha1.RequestTimeoutRouteOption,
+					korifiv1alpha1.IdleTimeoutRouteOption,
+					korifiv1alpha1.RateLimitRouteOption,
+				}, ", ")),
+			}.ExportJSONError()
+		}
+
+		if err != nil {
+			return webhooks.ValidationError{
+				Type:    RouteOptionsValidationErrorType,
+				Message: fmt.Sprintf("Route option %q has invalid value %q: %s", name, value, err.Error()),
+			}.ExportJSONError()
+		}
+	}
+
+	return nil
+}
+
+func validateTimeout(value string) error {
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 {
+		return errors.New("must be a positive duration, such as \"30s\"")
+	}
+
+	return nil
+}
+
+func validateRateLimit(value string) error {
+	requests, unit, found := strings.Cut(value, "/")
+	count, err := strconv.Atoi(requests)
+	if !found || err != nil || count <= 0 || (unit != "second" && unit != "minute" && unit != "hour") {
+		return errors.New("must be a positive number of requests per second, minute or hour, such as \"100/second\"")
+	}
+
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
 func generateDuplicateErrorMessage(route *korifiv1alpha1.CFRoute, domain *korifiv1alpha1.CFDomain) string {
+	if routeProtocol(*route) == korifiv1alpha1.TCPProtocol {
+		return fmt.Sprintf("Route already exists with port '%d' for domain '%s'.", route.Spec.Port, domain.Spec.Name)
+	}
+
 	pathDetails := ""
 
 	if route.Spec.Path != "" {
@@ -223,8 +660,31 @@ func generateDuplicateErrorMessage(route *korifiv1alpha1.CFRoute, domain *korifi
 		route.Spec.Host, pathDetails, domain.Spec.Name)
 }
 
-func uniqueName(route korifiv1alpha1.CFRoute) string {
-	return strings.Join([]string{strings.ToLower(route.Spec.Host), route.Spec.DomainRef.Namespace, route.Spec.DomainRef.Name, route.Spec.Path}, "::")
+// validateInternalRoute checks that the route can be resolved through a service in the domain namespace,
+// see CFRoute.InternalServiceName
+func validateInternalRoute(route *korifiv1alpha1.CFRoute, domain *korifiv1alpha1.CFDomain) error {
+	if route.Spec.Host == "" || route.Spec.Host == korifiv1alpha1.WildcardHost {
+		return webhooks.ValidationError{
+			Type:    RouteHostNameValidationErrorType,
+			Message: fmt.Sprintf("Routes on internal domain %q must have a host other than %q", domain.Spec.Name, korifiv1alpha1.WildcardHost),
+		}.ExportJSONError()
+	}
+
+	if errs := validation.IsDNS1035Label(route.InternalServiceName(domain.Spec.Name)); len(errs) > 0 {
+		return webhooks.ValidationError{
+			Type:    RouteHostNameValidationErrorType,
+			Message: fmt.Sprintf("Host %q is not valid for internal domain %q: %s", route.Spec.Host, domain.Spec.Name, strings.Join(errs, ", ")),
+		}.ExportJSONError()
+	}
+
+	if route.Spec.Path != "" {
+		return webhooks.ValidationError{
+			Type:    RoutePathValidationErrorType,
+			Message: "Paths are not supported for routes on internal domains",
+		}.ExportJSONError()
+	}
+
+	return nil
 }
 
 func validateFQDN(host, domain string) error {
@@ -250,7 +710,8 @@ func validateFQDN(host, domain string) error {
 }
 
 func validateHost(host string) error {
-	if host == "*" {
+	// an empty host is only allowed on private domains, which is checked by the caller
+	if host == "" || host == korifiv1alpha1.WildcardHost {
 		return nil
 	}
 
@@ -306,7 +767,7 @@ func validatePath(path string) error {
 
 func (v *CFRouteValidator) checkDestinationsExistInNamespace(ctx context.Context, route korifiv1alpha1.CFRoute) error {
 	for _, destination := range route.Spec.Destinations {
-		err := v.client.Get(ctx, client.ObjectKey{Namespace: route.Namespace, Name: destination.AppRef.Name}, &korifiv1alpha1.CFApp{})
+		err := v.client.Get(ctx, client.ObjectKey{Namespace: route.DestinationNamespace(destination), Name: destination.AppRef.Name}, &korifiv1alpha1.CFApp{})
 		if err != nil {
 			return err
 		}
@@ -13,9 +13,13 @@ import (
 	"code.cloudfoundry.org/korifi/controllers/webhooks/fake"
 	"code.cloudfoundry.org/korifi/controllers/webhooks/networking"
 	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authv1 "k8s.io/api/authorization/v1"
 	v1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -23,16 +27,19 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
 var _ = Describe("CFRouteValidator", func() {
 	var (
 		ctx                context.Context
 		duplicateValidator *fake.NameValidator
+		quotaValidator     *fake.QuotaValidator
 		fakeClient         *controllerfake.Client
 		cfRoute            *korifiv1alpha1.CFRoute
 		cfDomain           *korifiv1alpha1.CFDomain
 		cfApp              *korifiv1alpha1.CFApp
+		otherRoute         *korifiv1alpha1.CFRoute
 		validatingWebhook  *networking.CFRouteValidator
 
 		testRouteGUID       string
@@ -81,8 +88,10 @@ var _ = Describe("CFRouteValidator", func() {
 		}
 
 		cfApp = &korifiv1alpha1.CFApp{}
+		otherRoute = nil
 
 		duplicateValidator = new(fake.NameValidator)
+		quotaValidator = new(fake.QuotaValidator)
 		fakeClient = new(controllerfake.Client)
 
 		fakeClient.GetStub = func(_ context.Context, _ types.NamespacedName, obj client.Object, _ ...client.GetOption) error {
@@ -93,12 +102,18 @@ var _ = Describe("CFRouteValidator", func() {
 			case *korifiv1alpha1.CFApp:
 				cfApp.DeepCopyInto(obj)
 				return getAppError
+			case *korifiv1alpha1.CFRoute:
+				if otherRoute == nil {
+					return k8serrors.NewNotFound(schema.GroupResource{}, "cfroute")
+				}
+				otherRoute.DeepCopyInto(obj)
+				return nil
 			default:
 				panic("TestClient Get provided an unexpected object type")
 			}
 		}
 
-		validatingWebhook = networking.NewCFRouteValidator(duplicateValidator, rootNamespace, fakeClient)
+		validatingWebhook = networking.NewCFRouteValidator(duplicateValidator, quotaValidator, rootNamespace, fakeClient)
 	})
 
 	Describe("ValidateCreate", func() {
@@ -118,13 +133,128 @@ var _ = Describe("CFRouteValidator", func() {
 			Expect(name).To(Equal(testRouteHost + "::" + testDomainNamespace + "::" + testDomainGUID + "::" + testRoutePath))
 		})
 
+		It("checks the routes quota of the route namespace", func() {
+			Expect(quotaValidator.ValidateRouteCreateCallCount()).To(Equal(1))
+			_, _, actualNamespace := quotaValidator.ValidateRouteCreateArgsForCall(0)
+			Expect(actualNamespace).To(Equal(testRouteNamespace))
+		})
+
+		When("the routes quota is exceeded", func() {
+			BeforeEach(func() {
+				quotaValidator.ValidateRouteCreateReturns(&webhooks.ValidationError{
+					Type:    webhooks.QuotaExceededErrorType,
+					Message: "Routes quota exceeded for organization 'my-org'.",
+				})
+			})
+
+			It("denies the request without reserving the route name", func() {
+				Expect(retErr).To(matchers.BeValidationError(
+					webhooks.QuotaExceededErrorType,
+					Equal("Routes quota exceeded for organization 'my-org'."),
+				))
+				Expect(duplicateValidator.ValidateCreateCallCount()).To(Equal(0))
+			})
+		})
+
 		When("the host is '*'", func() {
 			BeforeEach(func() {
 				cfRoute.Spec.Host = "*"
 			})
 
-			It("allows the request", func() {
+			It("allows the request on a private domain", func() {
 				Expect(retErr).NotTo(HaveOccurred())
+				Expect(fakeClient.CreateCallCount()).To(Equal(0))
+			})
+
+			When("the domain is shared", func() {
+				var allowed bool
+
+				BeforeEach(func() {
+					cfRoute.Spec.DomainRef.Namespace = rootNamespace
+					allowed = false
+
+					ctx = admission.NewContextWithRequest(ctx, admission.Request{
+						AdmissionRequest: admissionv1.AdmissionRequest{
+							UserInfo: authenticationv1.UserInfo{
+								Username: "alice",
+								Groups:   []string{"devs"},
+							},
+						},
+					})
+
+					fakeClient.CreateStub = func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+						review, ok := obj.(*authv1.SubjectAccessReview)
+						Expect(ok).To(BeTrue())
+						review.Status.Allowed = allowed
+						return nil
+					}
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						networking.RouteHostNameValidationErrorType,
+						Equal(networking.WildcardHostForbiddenError),
+					))
+					Expect(duplicateValidator.ValidateCreateCallCount()).To(Equal(0))
+				})
+
+				It("checks whether the requesting user can create shared domains", func() {
+					Expect(fakeClient.CreateCallCount()).To(Equal(1))
+					_, obj, _ := fakeClient.CreateArgsForCall(0)
+					review, ok := obj.(*authv1.SubjectAccessReview)
+					Expect(ok).To(BeTrue())
+					Expect(review.Spec.User).To(Equal("alice"))
+					Expect(review.Spec.Groups).To(ConsistOf("devs"))
+					Expect(*review.Spec.ResourceAttributes).To(Equal(authv1.ResourceAttributes{
+						Namespace: rootNamespace,
+						Verb:      "create",
+						Group:     "korifi.cloudfoundry.org",
+						Resource:  "cfdomains",
+					}))
+				})
+
+				When("the user can create shared domains", func() {
+					BeforeEach(func() {
+						allowed = true
+					})
+
+					It("allows the request", func() {
+						Expect(retErr).NotTo(HaveOccurred())
+					})
+				})
+
+				When("the access review fails", func() {
+					BeforeEach(func() {
+						fakeClient.CreateReturns(errors.New("boom"))
+					})
+
+					It("denies the request", func() {
+						Expect(retErr).To(matchers.BeValidationError(webhooks.UnknownErrorType, Equal(webhooks.UnknownErrorMessage)))
+					})
+				})
+			})
+		})
+
+		When("the host is empty", func() {
+			BeforeEach(func() {
+				cfRoute.Spec.Host = ""
+			})
+
+			It("allows the request on a private domain", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+			})
+
+			When("the domain is shared", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.DomainRef.Namespace = rootNamespace
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						networking.RouteHostNameValidationErrorType,
+						Equal(`Routes on shared domain "test.domain.name" must have a host: host cannot be empty`),
+					))
+				})
 			})
 		})
 
@@ -141,6 +271,46 @@ var _ = Describe("CFRouteValidator", func() {
 			})
 		})
 
+		When("the route is transferred from another namespace", func() {
+			BeforeEach(func() {
+				cfRoute.Annotations = map[string]string{korifiv1alpha1.CFRouteTransferredFromAnnotation: "original-ns"}
+
+				otherRoute = initializeRouteCR(testRouteProtocol, testRouteHost, testRoutePath, testRouteGUID, "original-ns", testDomainGUID, testDomainNamespace)
+				otherRoute.Annotations = map[string]string{korifiv1alpha1.CFRouteTransferredToAnnotation: testRouteNamespace}
+			})
+
+			It("allows the request without registering the route name again", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+				Expect(duplicateValidator.ValidateCreateCallCount()).To(BeZero())
+			})
+
+			When("the original route is not being transferred", func() {
+				BeforeEach(func() {
+					otherRoute.Annotations = nil
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						networking.RouteTransferValidationErrorType,
+						ContainSubstring("is not being transferred"),
+					))
+				})
+			})
+
+			When("the original route does not exist", func() {
+				BeforeEach(func() {
+					otherRoute = nil
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						networking.RouteTransferValidationErrorType,
+						ContainSubstring("does not exist"),
+					))
+				})
+			})
+		})
+
 		When("the route name is a duplicate", func() {
 			BeforeEach(func() {
 				duplicateValidator.ValidateCreateReturns(&webhooks.ValidationError{
@@ -254,6 +424,272 @@ var _ = Describe("CFRouteValidator", func() {
 			})
 		})
 
+		When("the route has a port", func() {
+			BeforeEach(func() {
+				cfRoute.Spec.Port = 1024
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(matchers.BeValidationError(
+					networking.RoutePortValidationErrorType,
+					Equal("Ports are only supported for tcp routes"),
+				))
+			})
+		})
+
+		When("the domain has a router group", func() {
+			BeforeEach(func() {
+				cfDomain.Spec.RouterGroup = "default-tcp"
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(matchers.BeValidationError(
+					networking.RouteProtocolValidationErrorType,
+					Equal(`Domain "test.domain.name" only supports tcp routes`),
+				))
+			})
+		})
+
+		When("the domain is internal", func() {
+			BeforeEach(func() {
+				cfDomain.Spec.Internal = true
+				cfRoute.Spec.Path = ""
+			})
+
+			It("allows the request", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+			})
+
+			When("the route has a path", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.Path = "/my-path"
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						networking.RoutePathValidationErrorType,
+						Equal("Paths are not supported for routes on internal domains"),
+					))
+				})
+			})
+
+			When("the host is '*'", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.Host = "*"
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						networking.RouteHostNameValidationErrorType,
+						Equal(`Routes on internal domain "test.domain.name" must have a host other than "*"`),
+					))
+				})
+			})
+
+			When("the host cannot be used as a service name", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.Host = "1-host"
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						networking.RouteHostNameValidationErrorType,
+						ContainSubstring(`Host "1-host" is not valid for internal domain "test.domain.name"`),
+					))
+				})
+			})
+
+			When("the route has options", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.Options = map[string]string{"loadbalancing": "round-robin"}
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						networking.RouteOptionsValidationErrorType,
+						Equal("Route options are not supported for routes on internal domains"),
+					))
+				})
+			})
+		})
+
+		When("the route is a tcp route", func() {
+			BeforeEach(func() {
+				cfDomain.Spec.RouterGroup = "default-tcp"
+				cfRoute.Spec.Protocol = korifiv1alpha1.TCPProtocol
+				cfRoute.Spec.Host = ""
+				cfRoute.Spec.Path = ""
+				cfRoute.Spec.Port = 1024
+			})
+
+			It("allows the request", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+			})
+
+			It("uses the router group and port as the unique name", func() {
+				Expect(duplicateValidator.ValidateCreateCallCount()).To(Equal(1))
+				_, _, _, name, _ := duplicateValidator.ValidateCreateArgsForCall(0)
+				Expect(name).To(Equal("tcp::default-tcp::1024"))
+			})
+
+			It("gives a route on the same port of another domain in the router group the same unique name", func() {
+				otherDomainRoute := cfRoute.DeepCopy()
+				otherDomainRoute.Name = "other-route-guid"
+				otherDomainRoute.Spec.DomainRef.Name = "other-domain-guid"
+				Expect(validatingWebhook.ValidateCreate(ctx, otherDomainRoute)).To(Succeed())
+
+				Expect(duplicateValidator.ValidateCreateCallCount()).To(Equal(2))
+				_, _, _, name, _ := duplicateValidator.ValidateCreateArgsForCall(0)
+				_, _, _, otherName, _ := duplicateValidator.ValidateCreateArgsForCall(1)
+				Expect(otherName).To(Equal(name))
+			})
+
+			When("the domain has no router group", func() {
+				BeforeEach(func() {
+					cfDomain.Spec.RouterGroup = ""
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						networking.RouteProtocolValidationErrorType,
+						Equal(`Domain "test.domain.name" does not support tcp routes`),
+					))
+				})
+			})
+
+			When("the route has a host", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.Host = "my-host"
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						networking.RouteProtocolValidationErrorType,
+						Equal("Hosts and paths are not supported for tcp routes"),
+					))
+				})
+			})
+
+			When("the route has a path", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.Path = "/my-path"
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						networking.RouteProtocolValidationErrorType,
+						Equal("Hosts and paths are not supported for tcp routes"),
+					))
+				})
+			})
+
+			When("the port is out of range", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.Port = 70000
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						networking.RoutePortValidationErrorType,
+						Equal("Port 70000 is not valid: must be between 1 and 65535"),
+					))
+				})
+			})
+
+			When("the route has options", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.Options = map[string]string{"loadbalancing": "round-robin"}
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						networking.RouteOptionsValidationErrorType,
+						Equal("Route options are not supported for tcp routes"),
+					))
+				})
+			})
+		})
+
+		When("the route has options", func() {
+			BeforeEach(func() {
+				cfRoute.Spec.Options = map[string]string{
+					"loadbalancing":   "least-connection",
+					"request_timeout": "30s",
+					"idle_timeout":    "5m",
+					"rate_limit":      "100/second",
+				}
+			})
+
+			It("allows the request", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+			})
+
+			When("an option is not supported", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.Options["retries"] = "3"
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						networking.RouteOptionsValidationErrorType,
+						Equal(`Route option "retries" is not supported, supported options are: loadbalancing, request_timeout, idle_timeout, rate_limit`),
+					))
+				})
+			})
+
+			When("the load balancing algorithm is not supported", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.Options["loadbalancing"] = "random"
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						networking.RouteOptionsValidationErrorType,
+						Equal(`Route option "loadbalancing" has invalid value "random": must be one of "round-robin" or "least-connection"`),
+					))
+				})
+			})
+
+			When("a timeout is not a duration", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.Options["request_timeout"] = "30"
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						networking.RouteOptionsValidationErrorType,
+						Equal(`Route option "request_timeout" has invalid value "30": must be a positive duration, such as "30s"`),
+					))
+				})
+			})
+
+			When("a timeout is not positive", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.Options["idle_timeout"] = "0s"
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						networking.RouteOptionsValidationErrorType,
+						ContainSubstring(`Route option "idle_timeout" has invalid value "0s"`),
+					))
+				})
+			})
+
+			When("the rate limit is malformed", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.Options["rate_limit"] = "100/day"
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						networking.RouteOptionsValidationErrorType,
+						Equal(`Route option "rate_limit" has invalid value "100/day": must be a positive number of requests per second, minute or hour, such as "100/second"`),
+					))
+				})
+			})
+		})
+
 		When("the route has destinations", func() {
 			BeforeEach(func() {
 				cfRoute.Spec.Destinations = []korifiv1alpha1.Destination{
@@ -261,6 +697,7 @@ var _ = Describe("CFRouteValidator", func() {
 						AppRef: v1.LocalObjectReference{
 							Name: "some-name",
 						},
+						Protocol: "http1",
 					},
 				}
 			})
@@ -269,6 +706,111 @@ var _ = Describe("CFRouteValidator", func() {
 				Expect(retErr).NotTo(HaveOccurred())
 			})
 
+			When("the destination protocol is http2", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.Destinations[0].Protocol = "http2"
+				})
+
+				It("allows the request", func() {
+					Expect(retErr).NotTo(HaveOccurred())
+				})
+			})
+
+			When("the destination protocol is not supported by the route", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.Destinations[0].Protocol = "tcp"
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						networking.RouteProtocolValidationErrorType,
+						Equal(`Destination protocol "tcp" is not supported for http routes, must be one of: http1, http2`),
+					))
+				})
+			})
+
+			When("the destination app is in a space the route is shared with", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.SharedSpaces = []string{"shared-ns"}
+					cfRoute.Spec.Destinations[0].Namespace = "shared-ns"
+				})
+
+				It("allows the request", func() {
+					Expect(retErr).NotTo(HaveOccurred())
+				})
+
+				It("looks the app up in the shared space", func() {
+					Expect(fakeClient.GetCallCount()).To(Equal(2))
+					_, key, _, _ := fakeClient.GetArgsForCall(1)
+					Expect(key).To(Equal(types.NamespacedName{Namespace: "shared-ns", Name: "some-name"}))
+				})
+			})
+
+			When("the destination app is in a space the route is not shared with", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.Destinations[0].Namespace = "other-ns"
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						networking.RouteSharedSpaceValidationErrorType,
+						Equal(`Route destination app "some-name" is in space "other-ns", which the route is not shared with`),
+					))
+				})
+			})
+
+			When("the route is shared with its own space", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.SharedSpaces = []string{testRouteNamespace}
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						networking.RouteSharedSpaceValidationErrorType,
+						Equal("Routes cannot be shared with the space they belong to"),
+					))
+				})
+			})
+
+			When("the destinations are weighted", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.Destinations = []korifiv1alpha1.Destination{
+						{AppRef: v1.LocalObjectReference{Name: "blue"}, Protocol: "http1", Weight: tools.PtrTo(80)},
+						{AppRef: v1.LocalObjectReference{Name: "green"}, Protocol: "http1", Weight: tools.PtrTo(20)},
+					}
+				})
+
+				It("allows the request", func() {
+					Expect(retErr).NotTo(HaveOccurred())
+				})
+
+				When("only some destinations are weighted", func() {
+					BeforeEach(func() {
+						cfRoute.Spec.Destinations[1].Weight = nil
+					})
+
+					It("denies the request", func() {
+						Expect(retErr).To(matchers.BeValidationError(
+							networking.RouteWeightValidationErrorType,
+							Equal("Destinations cannot contain both weighted and unweighted destinations"),
+						))
+					})
+				})
+
+				When("the weights do not add up to 100", func() {
+					BeforeEach(func() {
+						cfRoute.Spec.Destinations[1].Weight = tools.PtrTo(30)
+					})
+
+					It("denies the request", func() {
+						Expect(retErr).To(matchers.BeValidationError(
+							networking.RouteWeightValidationErrorType,
+							Equal("Destination weights must add up to 100, got 110"),
+						))
+					})
+				})
+			})
+
 			When("the destination contains an app not found in the route's namespace", func() {
 				BeforeEach(func() {
 					getAppError = k8serrors.NewNotFound(schema.GroupResource{}, "foo")
@@ -307,6 +849,7 @@ var _ = Describe("CFRouteValidator", func() {
 					AppRef: v1.LocalObjectReference{
 						Name: "some-name",
 					},
+					Protocol: "http1",
 				},
 			}
 		})
@@ -382,6 +925,54 @@ var _ = Describe("CFRouteValidator", func() {
 			})
 		})
 
+		When("the options are updated", func() {
+			BeforeEach(func() {
+				updatedCFRoute.Spec.Options = map[string]string{korifiv1alpha1.LoadBalancingRouteOption: korifiv1alpha1.LeastConnectionLoadBalancing}
+			})
+
+			It("allows the request", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+			})
+
+			When("an option is not valid", func() {
+				BeforeEach(func() {
+					updatedCFRoute.Spec.Options = map[string]string{korifiv1alpha1.RequestTimeoutRouteOption: "forever"}
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(networking.RouteOptionsValidationErrorType, ContainSubstring("request_timeout")))
+				})
+			})
+
+			When("the route is a tcp route", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.Protocol = korifiv1alpha1.TCPProtocol
+					updatedCFRoute.Spec.Protocol = korifiv1alpha1.TCPProtocol
+					updatedCFRoute.Spec.Destinations[0].Protocol = "tcp"
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						networking.RouteOptionsValidationErrorType,
+						Equal("Route options are not supported for tcp routes"),
+					))
+				})
+			})
+
+			When("the domain is internal", func() {
+				BeforeEach(func() {
+					cfDomain.Spec.Internal = true
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						networking.RouteOptionsValidationErrorType,
+						Equal("Route options are not supported for routes on internal domains"),
+					))
+				})
+			})
+		})
+
 		When("the path is updated", func() {
 			BeforeEach(func() {
 				updatedCFRoute.Spec.Path = "/%"
@@ -408,6 +999,30 @@ var _ = Describe("CFRouteValidator", func() {
 			})
 		})
 
+		When("the protocol is defaulted on a route that had none", func() {
+			BeforeEach(func() {
+				cfRoute.Spec.Protocol = ""
+				updatedCFRoute.Spec.Protocol = korifiv1alpha1.HTTPProtocol
+			})
+
+			It("allows the request", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the port is updated", func() {
+			BeforeEach(func() {
+				updatedCFRoute.Spec.Port = 1025
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(matchers.BeValidationError(
+					webhooks.ImmutableFieldErrorType,
+					Equal("'CFRoute.Spec.Port' field is immutable"),
+				))
+			})
+		})
+
 		When("the DomainRef is updated", func() {
 			BeforeEach(func() {
 				updatedCFRoute.Spec.DomainRef = v1.ObjectReference{Name: "newDomainRef"}
@@ -465,6 +1080,69 @@ var _ = Describe("CFRouteValidator", func() {
 			Expect(name).To(Equal(testRouteHost + "::" + testDomainNamespace + "::" + testDomainGUID + "::" + testRoutePath))
 		})
 
+		When("the route is a tcp route", func() {
+			BeforeEach(func() {
+				cfDomain.Spec.RouterGroup = "default-tcp"
+				cfRoute.Spec.Protocol = korifiv1alpha1.TCPProtocol
+				cfRoute.Spec.Host = ""
+				cfRoute.Spec.Path = ""
+				cfRoute.Spec.Port = 1024
+			})
+
+			It("deregisters the router group and port", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+				Expect(duplicateValidator.ValidateDeleteCallCount()).To(Equal(1))
+				_, _, _, name := duplicateValidator.ValidateDeleteArgsForCall(0)
+				Expect(name).To(Equal("tcp::default-tcp::1024"))
+			})
+
+			When("the domain does not exist", func() {
+				BeforeEach(func() {
+					getDomainError = k8serrors.NewNotFound(schema.GroupResource{}, "cfdomain")
+				})
+
+				It("allows the request without deregistering the name", func() {
+					Expect(retErr).NotTo(HaveOccurred())
+					Expect(duplicateValidator.ValidateDeleteCallCount()).To(BeZero())
+				})
+			})
+
+			When("getting the domain fails", func() {
+				BeforeEach(func() {
+					getDomainError = errors.New("boom")
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						webhooks.UnknownErrorType,
+						Equal(webhooks.UnknownErrorMessage),
+					))
+				})
+			})
+		})
+
+		When("the route has been transferred to another namespace", func() {
+			BeforeEach(func() {
+				cfRoute.Annotations = map[string]string{korifiv1alpha1.CFRouteTransferredToAnnotation: "target-ns"}
+				otherRoute = initializeRouteCR(testRouteProtocol, testRouteHost, testRoutePath, testRouteGUID, "target-ns", testDomainGUID, testDomainNamespace)
+			})
+
+			It("keeps the route name registered for the transferred route", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+				Expect(duplicateValidator.ValidateDeleteCallCount()).To(BeZero())
+			})
+
+			When("the transferred route does not exist", func() {
+				BeforeEach(func() {
+					otherRoute = nil
+				})
+
+				It("deregisters the route name", func() {
+					Expect(duplicateValidator.ValidateDeleteCallCount()).To(Equal(1))
+				})
+			})
+		})
+
 		When("delete validation fails", func() {
 			BeforeEach(func() {
 				duplicateValidator.ValidateDeleteReturns(&webhooks.ValidationError{
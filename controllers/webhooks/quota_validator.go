@@ -0,0 +1,447 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	"github.com/go-logr/logr"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	QuotaExceededErrorType = "QuotaExceededError"
+
+	// The messages below match the ones of the CF API
+	OrgMemoryQuotaExceededMessage             = "memory quota_exceeded"
+	SpaceMemoryQuotaExceededMessage           = "memory space_quota_exceeded"
+	OrgInstanceMemoryLimitExceededMessage     = "memory instance_memory_limit_exceeded"
+	SpaceInstanceMemoryLimitExceededMessage   = "memory space_instance_memory_limit_exceeded"
+	OrgInstanceLimitExceededMessage           = "app_instance_limit app_instance_limit_exceeded"
+	SpaceInstanceLimitExceededMessage         = "app_instance_limit space_app_instance_limit_exceeded"
+	OrgAppTaskLimitExceededMessage            = "app_task_limit app_task_limit_exceeded"
+	SpaceAppTaskLimitExceededMessage          = "app_task_limit space_app_task_limit_exceeded"
+	OrgRoutesQuotaExceededMessage             = "Routes quota exceeded for organization '%s'."
+	SpaceRoutesQuotaExceededMessage           = "Routes quota exceeded for space '%s'."
+	OrgServiceInstancesQuotaExceededMessage   = "You have exceeded your organization's services limit."
+	SpaceServiceInstancesQuotaExceededMessage = "You have exceeded your space's services limit."
+)
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cforgquotas;cfspacequotas,verbs=get;list;watch
+
+// CFQuotaValidator enforces the CFOrgQuota and CFSpaceQuota limiting the space a resource is created in.
+// Usage is computed from the resources in the namespaces of the org or space when validating, so
+// concurrent requests may exceed a quota by a small amount
+type CFQuotaValidator struct {
+	client        client.Client
+	rootNamespace string
+}
+
+func NewCFQuotaValidator(client client.Client, rootNamespace string) *CFQuotaValidator {
+	return &CFQuotaValidator{client: client, rootNamespace: rootNamespace}
+}
+
+// quotaScope describes the quotas limiting a space namespace
+type quotaScope struct {
+	orgName         string
+	orgNamespaces   []string
+	orgQuota        *korifiv1alpha1.CFOrgQuota
+	spaceName       string
+	spaceNamespaces []string
+	spaceQuota      *korifiv1alpha1.CFSpaceQuota
+}
+
+type quotaLimit struct {
+	limits     korifiv1alpha1.QuotaLimits
+	namespaces []string
+	isSpace    bool
+}
+
+func (s quotaScope) limits() []quotaLimit {
+	var limits []quotaLimit
+	if s.orgQuota != nil {
+		limits = append(limits, quotaLimit{limits: s.orgQuota.Spec.QuotaLimits, namespaces: s.orgNamespaces})
+	}
+	if s.spaceQuota != nil {
+		limits = append(limits, quotaLimit{limits: s.spaceQuota.Spec.QuotaLimits, namespaces: s.spaceNamespaces, isSpace: true})
+	}
+
+	return limits
+}
+
+// ValidateAppStart checks that the processes of the app fit the quotas when it is started
+func (v *CFQuotaValidator) ValidateAppStart(ctx context.Context, logger logr.Logger, app korifiv1alpha1.CFApp) *ValidationError {
+	processes, err := v.listAppProcesses(ctx, app.Namespace, app.Name)
+	if err != nil {
+		return unknownQuotaError(logger, err, app.Namespace)
+	}
+
+	return v.validateAppInstances(ctx, logger, app.Namespace, app.Name, processes, true)
+}
+
+// ValidateProcess checks that the process fits the quotas. Only processes of started apps count
+// towards the memory and instance limits, but the memory of a single instance is always limited
+func (v *CFQuotaValidator) ValidateProcess(ctx context.Context, logger logr.Logger, process korifiv1alpha1.CFProcess) *ValidationError {
+	var app korifiv1alpha1.CFApp
+	err := v.client.Get(ctx, types.NamespacedName{Namespace: process.Namespace, Name: process.Spec.AppRef.Name}, &app)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return unknownQuotaError(logger, err, process.Namespace)
+	}
+
+	if app.Spec.DesiredState != korifiv1alpha1.StartedState {
+		return v.validateAppInstances(ctx, logger, process.Namespace, app.Name, []korifiv1alpha1.CFProcess{process}, false)
+	}
+
+	appProcesses, err := v.listAppProcesses(ctx, process.Namespace, app.Name)
+	if err != nil {
+		return unknownQuotaError(logger, err, process.Namespace)
+	}
+
+	processes := []korifiv1alpha1.CFProcess{process}
+	for _, appProcess := range appProcesses {
+		if appProcess.Name != process.Name {
+			processes = append(processes, appProcess)
+		}
+	}
+
+	return v.validateAppInstances(ctx, logger, process.Namespace, app.Name, processes, true)
+}
+
+// validateAppInstances checks the processes of an app against the per process memory limit and, when the
+// app is started, that they fit the total limits on top of the processes of the other started apps
+func (v *CFQuotaValidator) validateAppInstances(ctx context.Context, logger logr.Logger, namespace, appGUID string, processes []korifiv1alpha1.CFProcess, started bool) *ValidationError {
+	scope, err := v.getScope(ctx, namespace)
+	if err != nil {
+		return unknownQuotaError(logger, err, namespace)
+	}
+
+	for _, limit := range scope.limits() {
+		appsQuota := limit.limits.Apps
+
+		if appsQuota.PerProcessMemoryInMB != nil {
+			for _, process := range processes {
+				if process.Spec.MemoryMB > *appsQuota.PerProcessMemoryInMB {
+					return quotaExceededError(limit.isSpace, OrgInstanceMemoryLimitExceededMessage, SpaceInstanceMemoryLimitExceededMessage)
+				}
+			}
+		}
+
+		if !started || (appsQuota.TotalMemoryInMB == nil && appsQuota.TotalInstances == nil) {
+			continue
+		}
+
+		memory, instances, err := v.startedProcessUsage(ctx, limit.namespaces, appGUID)
+		if err != nil {
+			return unknownQuotaError(logger, err, namespace)
+		}
+
+		for _, process := range processes {
+			processInstances := int64(desiredInstances(process))
+			memory += processInstances * process.Spec.MemoryMB
+			instances += processInstances
+		}
+
+		if appsQuota.TotalMemoryInMB != nil && memory > *appsQuota.TotalMemoryInMB {
+			return quotaExceededError(limit.isSpace, OrgMemoryQuotaExceededMessage, SpaceMemoryQuotaExceededMessage)
+		}
+
+		if appsQuota.TotalInstances != nil && instances > *appsQuota.TotalInstances {
+			return quotaExceededError(limit.isSpace, OrgInstanceLimitExceededMessage, SpaceInstanceLimitExceededMessage)
+		}
+	}
+
+	return nil
+}
+
+func (v *CFQuotaValidator) ValidateRouteCreate(ctx context.Context, logger logr.Logger, namespace string) *ValidationError {
+	scope, err := v.getScope(ctx, namespace)
+	if err != nil {
+		return unknownQuotaError(logger, err, namespace)
+	}
+
+	for _, limit := range scope.limits() {
+		if limit.limits.Routes.TotalRoutes == nil {
+			continue
+		}
+
+		count, err := v.countRoutes(ctx, limit.namespaces)
+		if err != nil {
+			return unknownQuotaError(logger, err, namespace)
+		}
+
+		if count+1 > *limit.limits.Routes.TotalRoutes {
+			if limit.isSpace {
+				return &ValidationError{Type: QuotaExceededErrorType, Message: fmt.Sprintf(SpaceRoutesQuotaExceededMessage, scope.spaceName)}
+			}
+			return &ValidationError{Type: QuotaExceededErrorType, Message: fmt.Sprintf(OrgRoutesQuotaExceededMessage, scope.orgName)}
+		}
+	}
+
+	return nil
+}
+
+func (v *CFQuotaValidator) ValidateServiceInstanceCreate(ctx context.Context, logger logr.Logger, namespace string) *ValidationError {
+	scope, err := v.getScope(ctx, namespace)
+	if err != nil {
+		return unknownQuotaError(logger, err, namespace)
+	}
+
+	for _, limit := range scope.limits() {
+		if limit.limits.Services.TotalServiceInstances == nil {
+			continue
+		}
+
+		count, err := countInNamespaces(ctx, v.client, &korifiv1alpha1.CFServiceInstanceList{}, limit.namespaces)
+		if err != nil {
+			return unknownQuotaError(logger, err, namespace)
+		}
+
+		if count+1 > *limit.limits.Services.TotalServiceInstances {
+			return quotaExceededError(limit.isSpace, OrgServiceInstancesQuotaExceededMessage, SpaceServiceInstancesQuotaExceededMessage)
+		}
+	}
+
+	return nil
+}
+
+func (v *CFQuotaValidator) ValidateTaskCreate(ctx context.Context, logger logr.Logger, namespace, appGUID string) *ValidationError {
+	scope, err := v.getScope(ctx, namespace)
+	if err != nil {
+		return unknownQuotaError(logger, err, namespace)
+	}
+
+	var runningTasks int64
+	countedTasks := false
+	for _, limit := range scope.limits() {
+		if limit.limits.Apps.PerAppTasks == nil {
+			continue
+		}
+
+		if !countedTasks {
+			runningTasks, err = v.countRunningTasks(ctx, namespace, appGUID)
+			if err != nil {
+				return unknownQuotaError(logger, err, namespace)
+			}
+			countedTasks = true
+		}
+
+		if runningTasks+1 > *limit.limits.Apps.PerAppTasks {
+			return quotaExceededError(limit.isSpace, OrgAppTaskLimitExceededMessage, SpaceAppTaskLimitExceededMessage)
+		}
+	}
+
+	return nil
+}
+
+func (v *CFQuotaValidator) getScope(ctx context.Context, namespace string) (quotaScope, error) {
+	var spaces korifiv1alpha1.CFSpaceList
+	if err := v.client.List(ctx, &spaces); err != nil {
+		return quotaScope{}, fmt.Errorf("failed to list spaces: %w", err)
+	}
+
+	var space *korifiv1alpha1.CFSpace
+	for i := range spaces.Items {
+		if spaces.Items[i].Name == namespace {
+			space = &spaces.Items[i]
+			break
+		}
+	}
+
+	if space == nil {
+		// resources outside of spaces are not subject to quotas
+		return quotaScope{}, nil
+	}
+
+	scope := quotaScope{
+		spaceName:       space.Spec.DisplayName,
+		spaceNamespaces: []string{space.Name},
+	}
+	for _, orgSpace := range spaces.Items {
+		if orgSpace.Namespace == space.Namespace {
+			scope.orgNamespaces = append(scope.orgNamespaces, orgSpace.Name)
+		}
+	}
+
+	var org korifiv1alpha1.CFOrg
+	if err := v.client.Get(ctx, types.NamespacedName{Namespace: v.rootNamespace, Name: space.Namespace}, &org); err != nil {
+		return quotaScope{}, fmt.Errorf("failed to get org %q: %w", space.Namespace, err)
+	}
+	scope.orgName = org.Spec.DisplayName
+
+	var orgQuotas korifiv1alpha1.CFOrgQuotaList
+	if err := v.client.List(ctx, &orgQuotas, client.InNamespace(v.rootNamespace)); err != nil {
+		return quotaScope{}, fmt.Errorf("failed to list org quotas: %w", err)
+	}
+	for i := range orgQuotas.Items {
+		if contains(orgQuotas.Items[i].Spec.Organizations, space.Namespace) {
+			scope.orgQuota = &orgQuotas.Items[i]
+			break
+		}
+	}
+
+	var spaceQuotas korifiv1alpha1.CFSpaceQuotaList
+	if err := v.client.List(ctx, &spaceQuotas, client.InNamespace(space.Namespace)); err != nil {
+		return quotaScope{}, fmt.Errorf("failed to list space quotas: %w", err)
+	}
+	for i := range spaceQuotas.Items {
+		if contains(spaceQuotas.Items[i].Spec.Spaces, space.Name) {
+			scope.spaceQuota = &spaceQuotas.Items[i]
+			break
+		}
+	}
+
+	return scope, nil
+}
+
+// startedProcessUsage returns the memory and instances used by the processes of the started apps in
+// the namespaces, ignoring the processes of the app being validated
+func (v *CFQuotaValidator) startedProcessUsage(ctx context.Context, namespaces []string, appGUID string) (int64, int64, error) {
+	var memory, instances int64
+	for _, namespace := range namespaces {
+		var apps korifiv1alpha1.CFAppList
+		if err := v.client.List(ctx, &apps, client.InNamespace(namespace)); err != nil {
+			return 0, 0, fmt.Errorf("failed to list apps: %w", err)
+		}
+
+		startedApps := map[string]bool{}
+		for _, app := range apps.Items {
+			if app.Spec.DesiredState == korifiv1alpha1.StartedState && app.Name != appGUID {
+				startedApps[app.Name] = true
+			}
+		}
+
+		var processes korifiv1alpha1.CFProcessList
+		if err := v.client.List(ctx, &processes, client.InNamespace(namespace)); err != nil {
+			return 0, 0, fmt.Errorf("failed to list processes: %w", err)
+		}
+
+		for _, process := range processes.Items {
+			if !startedApps[process.Spec.AppRef.Name] {
+				continue
+			}
+
+			processInstances := int64(desiredInstances(process))
+			memory += processInstances * process.Spec.MemoryMB
+			instances += processInstances
+		}
+	}
+
+	return memory, instances, nil
+}
+
+func (v *CFQuotaValidator) listAppProcesses(ctx context.Context, namespace, appGUID string) ([]korifiv1alpha1.CFProcess, error) {
+	var processes korifiv1alpha1.CFProcessList
+	if err := v.client.List(ctx, &processes, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list processes: %w", err)
+	}
+
+	var appProcesses []korifiv1alpha1.CFProcess
+	for _, process := range processes.Items {
+		if process.Spec.AppRef.Name == appGUID {
+			appProcesses = append(appProcesses, process)
+		}
+	}
+
+	return appProcesses, nil
+}
+
+// countRoutes does not count the routes being transferred to another space, which are deleted once their
+// copy has been created
+func (v *CFQuotaValidator) countRoutes(ctx context.Context, namespaces []string) (int64, error) {
+	var count int64
+	for _, namespace := range namespaces {
+		var routes korifiv1alpha1.CFRouteList
+		if err := v.client.List(ctx, &routes, client.InNamespace(namespace)); err != nil {
+			return 0, fmt.Errorf("failed to list routes: %w", err)
+		}
+
+		for _, route := range routes.Items {
+			if _, ok := route.Annotations[korifiv1alpha1.CFRouteTransferredToAnnotation]; ok {
+				continue
+			}
+
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (v *CFQuotaValidator) countRunningTasks(ctx context.Context, namespace, appGUID string) (int64, error) {
+	var tasks korifiv1alpha1.CFTaskList
+	if err := v.client.List(ctx, &tasks, client.InNamespace(namespace)); err != nil {
+		return 0, fmt.Errorf("failed to list tasks: %w", err)
+	}
+
+	var count int64
+	for _, task := range tasks.Items {
+		if task.Spec.AppRef.Name != appGUID {
+			continue
+		}
+
+		if meta.IsStatusConditionTrue(task.Status.Conditions, korifiv1alpha1.TaskSucceededConditionType) ||
+			meta.IsStatusConditionTrue(task.Status.Conditions, korifiv1alpha1.TaskFailedConditionType) {
+			continue
+		}
+
+		count++
+	}
+
+	return count, nil
+}
+
+func countInNamespaces(ctx context.Context, k8sClient client.Client, list client.ObjectList, namespaces []string) (int64, error) {
+	var count int64
+	for _, namespace := range namespaces {
+		if err := k8sClient.List(ctx, list, client.InNamespace(namespace)); err != nil {
+			return 0, fmt.Errorf("failed to list %T: %w", list, err)
+		}
+
+		count += int64(meta.LenList(list))
+	}
+
+	return count, nil
+}
+
+func desiredInstances(process korifiv1alpha1.CFProcess) int {
+	if process.Spec.DesiredInstances == nil {
+		return 0
+	}
+
+	return *process.Spec.DesiredInstances
+}
+
+func quotaExceededError(isSpace bool, orgMessage, spaceMessage string) *ValidationError {
+	message := orgMessage
+	if isSpace {
+		message = spaceMessage
+	}
+
+	return &ValidationError{Type: QuotaExceededErrorType, Message: message}
+}
+
+func unknownQuotaError(logger logr.Logger, err error, namespace string) *ValidationError {
+	logger.Info("failed to check quotas", "error", err, "namespace", namespace)
+
+	return &ValidationError{
+		Type:    UnknownErrorType,
+		Message: UnknownErrorMessage,
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,452 @@
+package webhooks_test
+
+import (
+	"context"
+	"errors"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/fake"
+	"code.cloudfoundry.org/korifi/controllers/webhooks"
+	"code.cloudfoundry.org/korifi/tools"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("CFQuotaValidator", func() {
+	const (
+		rootNamespace = "cf"
+		orgGUID       = "org-guid"
+		spaceGUID     = "space-guid"
+		otherSpace    = "other-space-guid"
+	)
+
+	var (
+		ctx            context.Context
+		fakeClient     *fake.Client
+		quotaValidator *webhooks.CFQuotaValidator
+		validationErr  *webhooks.ValidationError
+
+		orgQuota   korifiv1alpha1.CFOrgQuota
+		spaceQuota korifiv1alpha1.CFSpaceQuota
+		apps       []korifiv1alpha1.CFApp
+		processes  []korifiv1alpha1.CFProcess
+		routes     []korifiv1alpha1.CFRoute
+		tasks      []korifiv1alpha1.CFTask
+		listErr    error
+	)
+
+	makeProcess := func(name, namespace, appGUID string, instances int, memoryMB int64) korifiv1alpha1.CFProcess {
+		return korifiv1alpha1.CFProcess{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: korifiv1alpha1.CFProcessSpec{
+				AppRef:           corev1.LocalObjectReference{Name: appGUID},
+				DesiredInstances: tools.PtrTo(instances),
+				MemoryMB:         memoryMB,
+			},
+		}
+	}
+
+	makeApp := func(name, namespace string, state korifiv1alpha1.DesiredState) korifiv1alpha1.CFApp {
+		return korifiv1alpha1.CFApp{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       korifiv1alpha1.CFAppSpec{DesiredState: state},
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fakeClient = new(fake.Client)
+		listErr = nil
+
+		orgQuota = korifiv1alpha1.CFOrgQuota{
+			ObjectMeta: metav1.ObjectMeta{Name: "org-quota", Namespace: rootNamespace},
+			Spec: korifiv1alpha1.CFOrgQuotaSpec{
+				DisplayName:   "org-quota",
+				Organizations: []string{orgGUID},
+			},
+		}
+		spaceQuota = korifiv1alpha1.CFSpaceQuota{
+			ObjectMeta: metav1.ObjectMeta{Name: "space-quota", Namespace: orgGUID},
+			Spec: korifiv1alpha1.CFSpaceQuotaSpec{
+				DisplayName: "space-quota",
+				Spaces:      []string{spaceGUID},
+			},
+		}
+
+		apps = []korifiv1alpha1.CFApp{
+			makeApp("started-app", otherSpace, korifiv1alpha1.StartedState),
+			makeApp("stopped-app", otherSpace, korifiv1alpha1.StoppedState),
+			makeApp("my-app", spaceGUID, korifiv1alpha1.StoppedState),
+		}
+		processes = []korifiv1alpha1.CFProcess{
+			makeProcess("started-web", otherSpace, "started-app", 2, 256),
+			makeProcess("stopped-web", otherSpace, "stopped-app", 4, 1024),
+			makeProcess("my-web", spaceGUID, "my-app", 1, 128),
+		}
+		routes = []korifiv1alpha1.CFRoute{
+			{ObjectMeta: metav1.ObjectMeta{Name: "route-1", Namespace: spaceGUID}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "route-2", Namespace: otherSpace}},
+		}
+		tasks = []korifiv1alpha1.CFTask{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "running-task", Namespace: spaceGUID},
+				Spec:       korifiv1alpha1.CFTaskSpec{AppRef: corev1.LocalObjectReference{Name: "my-app"}},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "succeeded-task", Namespace: spaceGUID},
+				Spec:       korifiv1alpha1.CFTaskSpec{AppRef: corev1.LocalObjectReference{Name: "my-app"}},
+				Status: korifiv1alpha1.CFTaskStatus{
+					Conditions: []metav1.Condition{{Type: korifiv1alpha1.TaskSucceededConditionType, Status: metav1.ConditionTrue}},
+				},
+			},
+		}
+
+		fakeClient.GetStub = func(_ context.Context, key types.NamespacedName, obj client.Object, _ ...client.GetOption) error {
+			switch obj := obj.(type) {
+			case *korifiv1alpha1.CFOrg:
+				Expect(key).To(Equal(types.NamespacedName{Namespace: rootNamespace, Name: orgGUID}))
+				obj.Spec.DisplayName = "my-org"
+			case *korifiv1alpha1.CFApp:
+				for _, app := range apps {
+					if app.Namespace == key.Namespace && app.Name == key.Name {
+						app.DeepCopyInto(obj)
+						return nil
+					}
+				}
+				return errors.New("app not found")
+			default:
+				panic("TestClient Get provided an unexpected object type")
+			}
+			return nil
+		}
+
+		fakeClient.ListStub = func(_ context.Context, list client.ObjectList, opts ...client.ListOption) error {
+			if listErr != nil {
+				return listErr
+			}
+
+			listOpts := &client.ListOptions{}
+			listOpts.ApplyOptions(opts)
+			inNamespace := func(namespace string) bool {
+				return listOpts.Namespace == "" || listOpts.Namespace == namespace
+			}
+
+			switch list := list.(type) {
+			case *korifiv1alpha1.CFSpaceList:
+				list.Items = []korifiv1alpha1.CFSpace{
+					{ObjectMeta: metav1.ObjectMeta{Name: spaceGUID, Namespace: orgGUID}, Spec: korifiv1alpha1.CFSpaceSpec{DisplayName: "my-space"}},
+					{ObjectMeta: metav1.ObjectMeta{Name: otherSpace, Namespace: orgGUID}, Spec: korifiv1alpha1.CFSpaceSpec{DisplayName: "other-space"}},
+				}
+			case *korifiv1alpha1.CFOrgQuotaList:
+				list.Items = []korifiv1alpha1.CFOrgQuota{orgQuota}
+			case *korifiv1alpha1.CFSpaceQuotaList:
+				list.Items = []korifiv1alpha1.CFSpaceQuota{spaceQuota}
+			case *korifiv1alpha1.CFAppList:
+				list.Items = nil
+				for _, app := range apps {
+					if inNamespace(app.Namespace) {
+						list.Items = append(list.Items, app)
+					}
+				}
+			case *korifiv1alpha1.CFProcessList:
+				list.Items = nil
+				for _, process := range processes {
+					if inNamespace(process.Namespace) {
+						list.Items = append(list.Items, process)
+					}
+				}
+			case *korifiv1alpha1.CFRouteList:
+				list.Items = nil
+				for _, route := range routes {
+					if inNamespace(route.Namespace) {
+						list.Items = append(list.Items, route)
+					}
+				}
+			case *korifiv1alpha1.CFServiceInstanceList:
+				list.Items = []korifiv1alpha1.CFServiceInstance{}
+				if inNamespace(spaceGUID) {
+					list.Items = append(list.Items, korifiv1alpha1.CFServiceInstance{ObjectMeta: metav1.ObjectMeta{Name: "instance", Namespace: spaceGUID}})
+				}
+			case *korifiv1alpha1.CFTaskList:
+				list.Items = nil
+				for _, task := range tasks {
+					if inNamespace(task.Namespace) {
+						list.Items = append(list.Items, task)
+					}
+				}
+			default:
+				panic("TestClient List provided an unexpected list type")
+			}
+			return nil
+		}
+
+		quotaValidator = webhooks.NewCFQuotaValidator(fakeClient, rootNamespace)
+	})
+
+	Describe("ValidateAppStart", func() {
+		JustBeforeEach(func() {
+			validationErr = quotaValidator.ValidateAppStart(ctx, logr.Discard(), apps[2])
+		})
+
+		It("succeeds when there are no limits", func() {
+			Expect(validationErr).To(BeNil())
+		})
+
+		When("the org memory quota is exceeded by the started apps", func() {
+			BeforeEach(func() {
+				orgQuota.Spec.Apps.TotalMemoryInMB = tools.PtrTo(int64(600))
+			})
+
+			It("returns a quota exceeded error", func() {
+				Expect(validationErr).To(PointTo(Equal(webhooks.ValidationError{
+					Type:    webhooks.QuotaExceededErrorType,
+					Message: webhooks.OrgMemoryQuotaExceededMessage,
+				})))
+			})
+		})
+
+		When("the memory of stopped apps would exceed the org memory quota", func() {
+			BeforeEach(func() {
+				orgQuota.Spec.Apps.TotalMemoryInMB = tools.PtrTo(int64(640))
+			})
+
+			It("succeeds", func() {
+				Expect(validationErr).To(BeNil())
+			})
+		})
+
+		When("the space instances quota is exceeded", func() {
+			BeforeEach(func() {
+				spaceQuota.Spec.Apps.TotalInstances = tools.PtrTo(int64(0))
+			})
+
+			It("returns a space quota exceeded error", func() {
+				Expect(validationErr).To(PointTo(Equal(webhooks.ValidationError{
+					Type:    webhooks.QuotaExceededErrorType,
+					Message: webhooks.SpaceInstanceLimitExceededMessage,
+				})))
+			})
+		})
+
+		When("the quota applies to another org", func() {
+			BeforeEach(func() {
+				orgQuota.Spec.Organizations = []string{"another-org"}
+				orgQuota.Spec.Apps.TotalMemoryInMB = tools.PtrTo(int64(0))
+			})
+
+			It("succeeds", func() {
+				Expect(validationErr).To(BeNil())
+			})
+		})
+
+		When("listing the resources fails", func() {
+			BeforeEach(func() {
+				listErr = errors.New("boom")
+			})
+
+			It("returns an unknown error", func() {
+				Expect(validationErr).To(PointTo(Equal(webhooks.ValidationError{
+					Type:    webhooks.UnknownErrorType,
+					Message: webhooks.UnknownErrorMessage,
+				})))
+			})
+		})
+	})
+
+	Describe("ValidateProcess", func() {
+		var process korifiv1alpha1.CFProcess
+
+		BeforeEach(func() {
+			process = makeProcess("my-web", spaceGUID, "my-app", 10, 512)
+			orgQuota.Spec.Apps.TotalMemoryInMB = tools.PtrTo(int64(1024))
+		})
+
+		JustBeforeEach(func() {
+			validationErr = quotaValidator.ValidateProcess(ctx, logr.Discard(), process)
+		})
+
+		It("ignores the total limits while the app is stopped", func() {
+			Expect(validationErr).To(BeNil())
+		})
+
+		When("the process exceeds the per process memory limit", func() {
+			BeforeEach(func() {
+				spaceQuota.Spec.Apps.PerProcessMemoryInMB = tools.PtrTo(int64(256))
+			})
+
+			It("returns a space quota exceeded error", func() {
+				Expect(validationErr).To(PointTo(Equal(webhooks.ValidationError{
+					Type:    webhooks.QuotaExceededErrorType,
+					Message: webhooks.SpaceInstanceMemoryLimitExceededMessage,
+				})))
+			})
+		})
+
+		When("the app is started", func() {
+			BeforeEach(func() {
+				apps[2].Spec.DesiredState = korifiv1alpha1.StartedState
+			})
+
+			It("counts the scaled process towards the org memory quota", func() {
+				Expect(validationErr).To(PointTo(Equal(webhooks.ValidationError{
+					Type:    webhooks.QuotaExceededErrorType,
+					Message: webhooks.OrgMemoryQuotaExceededMessage,
+				})))
+			})
+
+			When("the process is scaled within the quota", func() {
+				BeforeEach(func() {
+					process.Spec.DesiredInstances = tools.PtrTo(1)
+				})
+
+				It("succeeds", func() {
+					Expect(validationErr).To(BeNil())
+				})
+			})
+		})
+	})
+
+	Describe("ValidateRouteCreate", func() {
+		JustBeforeEach(func() {
+			validationErr = quotaValidator.ValidateRouteCreate(ctx, logr.Discard(), spaceGUID)
+		})
+
+		It("succeeds when there are no limits", func() {
+			Expect(validationErr).To(BeNil())
+		})
+
+		When("the org routes quota is reached", func() {
+			BeforeEach(func() {
+				orgQuota.Spec.Routes.TotalRoutes = tools.PtrTo(int64(2))
+			})
+
+			It("returns a quota exceeded error naming the org", func() {
+				Expect(validationErr).To(PointTo(Equal(webhooks.ValidationError{
+					Type:    webhooks.QuotaExceededErrorType,
+					Message: "Routes quota exceeded for organization 'my-org'.",
+				})))
+			})
+		})
+
+		When("the space routes quota is reached", func() {
+			BeforeEach(func() {
+				spaceQuota.Spec.Routes.TotalRoutes = tools.PtrTo(int64(1))
+			})
+
+			It("returns a quota exceeded error naming the space", func() {
+				Expect(validationErr).To(PointTo(Equal(webhooks.ValidationError{
+					Type:    webhooks.QuotaExceededErrorType,
+					Message: "Routes quota exceeded for space 'my-space'.",
+				})))
+			})
+		})
+
+		When("a route is being transferred to the space", func() {
+			BeforeEach(func() {
+				orgQuota.Spec.Routes.TotalRoutes = tools.PtrTo(int64(2))
+				routes[1].Annotations = map[string]string{korifiv1alpha1.CFRouteTransferredToAnnotation: spaceGUID}
+			})
+
+			It("does not count the route being transferred", func() {
+				Expect(validationErr).To(BeNil())
+			})
+		})
+
+		When("a route is being transferred out of the space", func() {
+			BeforeEach(func() {
+				spaceQuota.Spec.Routes.TotalRoutes = tools.PtrTo(int64(1))
+				routes[0].Annotations = map[string]string{korifiv1alpha1.CFRouteTransferredToAnnotation: otherSpace}
+			})
+
+			It("does not count the route being transferred", func() {
+				Expect(validationErr).To(BeNil())
+			})
+		})
+
+		When("the space routes quota is not reached", func() {
+			BeforeEach(func() {
+				spaceQuota.Spec.Routes.TotalRoutes = tools.PtrTo(int64(2))
+			})
+
+			It("succeeds", func() {
+				Expect(validationErr).To(BeNil())
+			})
+		})
+
+		When("the namespace is not a space", func() {
+			JustBeforeEach(func() {
+				orgQuota.Spec.Routes.TotalRoutes = tools.PtrTo(int64(0))
+				validationErr = quotaValidator.ValidateRouteCreate(ctx, logr.Discard(), rootNamespace)
+			})
+
+			It("succeeds", func() {
+				Expect(validationErr).To(BeNil())
+			})
+		})
+	})
+
+	Describe("ValidateServiceInstanceCreate", func() {
+		JustBeforeEach(func() {
+			validationErr = quotaValidator.ValidateServiceInstanceCreate(ctx, logr.Discard(), spaceGUID)
+		})
+
+		When("the org service instances quota is reached", func() {
+			BeforeEach(func() {
+				orgQuota.Spec.Services.TotalServiceInstances = tools.PtrTo(int64(1))
+			})
+
+			It("returns a quota exceeded error", func() {
+				Expect(validationErr).To(PointTo(Equal(webhooks.ValidationError{
+					Type:    webhooks.QuotaExceededErrorType,
+					Message: webhooks.OrgServiceInstancesQuotaExceededMessage,
+				})))
+			})
+		})
+
+		When("the org service instances quota is not reached", func() {
+			BeforeEach(func() {
+				orgQuota.Spec.Services.TotalServiceInstances = tools.PtrTo(int64(2))
+			})
+
+			It("succeeds", func() {
+				Expect(validationErr).To(BeNil())
+			})
+		})
+	})
+
+	Describe("ValidateTaskCreate", func() {
+		JustBeforeEach(func() {
+			validationErr = quotaValidator.ValidateTaskCreate(ctx, logr.Discard(), spaceGUID, "my-app")
+		})
+
+		When("the space app tasks quota is reached by the running tasks", func() {
+			BeforeEach(func() {
+				spaceQuota.Spec.Apps.PerAppTasks = tools.PtrTo(int64(1))
+			})
+
+			It("returns a quota exceeded error", func() {
+				Expect(validationErr).To(PointTo(Equal(webhooks.ValidationError{
+					Type:    webhooks.QuotaExceededErrorType,
+					Message: webhooks.SpaceAppTaskLimitExceededMessage,
+				})))
+			})
+		})
+
+		When("completed tasks would exceed the quota", func() {
+			BeforeEach(func() {
+				spaceQuota.Spec.Apps.PerAppTasks = tools.PtrTo(int64(2))
+			})
+
+			It("succeeds", func() {
+				Expect(validationErr).To(BeNil())
+			})
+		})
+	})
+})
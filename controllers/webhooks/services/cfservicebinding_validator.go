@@ -9,7 +9,9 @@ import (
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 )
@@ -18,6 +20,7 @@ const (
 	ServiceBindingEntityType            = "servicebinding"
 	ServiceBindingErrorType             = "ServiceBindingValidationError"
 	duplicateServiceBindingErrorMessage = "Service binding already exists: App: %s Service Instance: %s"
+	ServiceInstanceNotSharedErrorType   = "ServiceInstanceNotSharedError"
 )
 
 // log is for logging in this package.
@@ -34,13 +37,15 @@ func (v *CFServiceBindingValidator) SetupWebhookWithManager(mgr ctrl.Manager) er
 
 type CFServiceBindingValidator struct {
 	duplicateValidator webhooks.NameValidator
+	client             client.Client
 }
 
 var _ webhook.CustomValidator = &CFServiceBindingValidator{}
 
-func NewCFServiceBindingValidator(duplicateValidator webhooks.NameValidator) *CFServiceBindingValidator {
+func NewCFServiceBindingValidator(duplicateValidator webhooks.NameValidator, client client.Client) *CFServiceBindingValidator {
 	return &CFServiceBindingValidator{
 		duplicateValidator: duplicateValidator,
+		client:             client,
 	}
 }
 
@@ -50,6 +55,10 @@ func (v *CFServiceBindingValidator) ValidateCreate(ctx context.Context, obj runt
 		return apierrors.NewBadRequest(fmt.Sprintf("expected a CFServiceBinding but got a %T", obj))
 	}
 
+	if err := v.validateServiceInstanceShared(ctx, serviceBinding); err != nil {
+		return err
+	}
+
 	lockName := generateServiceBindingLock(serviceBinding)
 
 	duplicateErrorMessage := fmt.Sprintf(duplicateServiceBindingErrorMessage, serviceBinding.Spec.AppRef.Name, serviceBinding.Spec.Service.Name)
@@ -107,6 +116,39 @@ func (v *CFServiceBindingValidator) ValidateDelete(ctx context.Context, obj runt
 	return nil
 }
 
+func (v *CFServiceBindingValidator) validateServiceInstanceShared(ctx context.Context, serviceBinding *korifiv1alpha1.CFServiceBinding) error {
+	if serviceBinding.ServiceNamespace() == serviceBinding.Namespace {
+		return nil
+	}
+
+	serviceInstance := new(korifiv1alpha1.CFServiceInstance)
+	err := v.client.Get(ctx, types.NamespacedName{Name: serviceBinding.Spec.Service.Name, Namespace: serviceBinding.ServiceNamespace()}, serviceInstance)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return webhooks.ValidationError{
+				Type:    ServiceInstanceNotSharedErrorType,
+				Message: fmt.Sprintf("Service instance %s is not shared with this space", serviceBinding.Spec.Service.Name),
+			}.ExportJSONError()
+		}
+
+		errMessage := "Error while retrieving CFServiceInstance object"
+		cfservicebindinglog.Error(err, errMessage)
+		return webhooks.ValidationError{
+			Type:    webhooks.UnknownErrorType,
+			Message: errMessage,
+		}.ExportJSONError()
+	}
+
+	if !serviceInstance.IsSharedWith(serviceBinding.Namespace) {
+		return webhooks.ValidationError{
+			Type:    ServiceInstanceNotSharedErrorType,
+			Message: fmt.Sprintf("Service instance %s is not shared with this space", serviceBinding.Spec.Service.Name),
+		}.ExportJSONError()
+	}
+
+	return nil
+}
+
 func generateServiceBindingLock(serviceBinding *korifiv1alpha1.CFServiceBinding) string {
 	return fmt.Sprintf("sb::%s::%s::%s", serviceBinding.Spec.AppRef.Name, serviceBinding.Spec.Service.Namespace, serviceBinding.Spec.Service.Name)
 }
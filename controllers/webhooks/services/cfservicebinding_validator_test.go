@@ -2,10 +2,12 @@ package services_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	controllerfake "code.cloudfoundry.org/korifi/controllers/fake"
 	"code.cloudfoundry.org/korifi/controllers/webhooks"
 	"code.cloudfoundry.org/korifi/controllers/webhooks/fake"
 	"code.cloudfoundry.org/korifi/controllers/webhooks/services"
@@ -14,8 +16,12 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var _ = Describe("CFServiceBindingValidatingWebhook", func() {
@@ -29,6 +35,8 @@ var _ = Describe("CFServiceBindingValidatingWebhook", func() {
 		serviceBindingGUID  string
 		ctx                 context.Context
 		duplicateValidator  *fake.NameValidator
+		fakeClient          *controllerfake.Client
+		serviceInstance     *korifiv1alpha1.CFServiceInstance
 		serviceBinding      *korifiv1alpha1.CFServiceBinding
 		validatingWebhook   *services.CFServiceBindingValidator
 		retErr              error
@@ -60,8 +68,23 @@ var _ = Describe("CFServiceBindingValidatingWebhook", func() {
 			},
 		}
 
+		serviceInstance = &korifiv1alpha1.CFServiceInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      serviceInstanceGUID,
+				Namespace: "other-namespace",
+			},
+			Spec: korifiv1alpha1.CFServiceInstanceSpec{
+				SharedSpaces: []string{defaultNamespace},
+			},
+		}
+
 		duplicateValidator = new(fake.NameValidator)
-		validatingWebhook = services.NewCFServiceBindingValidator(duplicateValidator)
+		fakeClient = new(controllerfake.Client)
+		fakeClient.GetStub = func(_ context.Context, _ types.NamespacedName, obj client.Object, _ ...client.GetOption) error {
+			serviceInstance.DeepCopyInto(obj.(*korifiv1alpha1.CFServiceInstance))
+			return nil
+		}
+		validatingWebhook = services.NewCFServiceBindingValidator(duplicateValidator, fakeClient)
 	})
 
 	Describe("ValidateCreate", func() {
@@ -80,6 +103,67 @@ var _ = Describe("CFServiceBindingValidatingWebhook", func() {
 			Expect(lock).To(Equal(fmt.Sprintf("sb::%s::%s::%s", appGUID, defaultNamespace, serviceInstanceGUID)))
 		})
 
+		It("does not look up the service instance when it is in the same namespace", func() {
+			Expect(fakeClient.GetCallCount()).To(BeZero())
+		})
+
+		When("the service instance is in another namespace", func() {
+			BeforeEach(func() {
+				serviceBinding.Spec.Service.Namespace = "other-namespace"
+			})
+
+			It("fetches the service instance from its namespace", func() {
+				Expect(fakeClient.GetCallCount()).To(Equal(1))
+				_, actualNsName, _, _ := fakeClient.GetArgsForCall(0)
+				Expect(actualNsName).To(Equal(types.NamespacedName{Name: serviceInstanceGUID, Namespace: "other-namespace"}))
+			})
+
+			It("allows the binding when the instance is shared with the binding namespace", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+			})
+
+			When("the service instance is not shared with the binding namespace", func() {
+				BeforeEach(func() {
+					serviceInstance.Spec.SharedSpaces = []string{"some-other-space"}
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						services.ServiceInstanceNotSharedErrorType,
+						Equal("Service instance "+serviceInstanceGUID+" is not shared with this space"),
+					))
+				})
+			})
+
+			When("the service instance does not exist", func() {
+				BeforeEach(func() {
+					fakeClient.GetReturns(k8serrors.NewNotFound(schema.GroupResource{}, serviceInstanceGUID))
+					fakeClient.GetStub = nil
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						services.ServiceInstanceNotSharedErrorType,
+						Equal("Service instance "+serviceInstanceGUID+" is not shared with this space"),
+					))
+				})
+			})
+
+			When("getting the service instance fails", func() {
+				BeforeEach(func() {
+					fakeClient.GetReturns(errors.New("boom"))
+					fakeClient.GetStub = nil
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						webhooks.UnknownErrorType,
+						Equal("Error while retrieving CFServiceInstance object"),
+					))
+				})
+			})
+		})
+
 		When("a duplicate service binding already exists", func() {
 			BeforeEach(func() {
 				duplicateValidator.ValidateCreateReturns(&webhooks.ValidationError{
@@ -26,13 +26,15 @@ var cfserviceinstancelog = logf.Log.WithName("cfserviceinstance-validate")
 
 type CFServiceInstanceValidator struct {
 	duplicateValidator webhooks.NameValidator
+	quotaValidator     webhooks.QuotaValidator
 }
 
 var _ webhook.CustomValidator = &CFServiceInstanceValidator{}
 
-func NewCFServiceInstanceValidator(duplicateValidator webhooks.NameValidator) *CFServiceInstanceValidator {
+func NewCFServiceInstanceValidator(duplicateValidator webhooks.NameValidator, quotaValidator webhooks.QuotaValidator) *CFServiceInstanceValidator {
 	return &CFServiceInstanceValidator{
 		duplicateValidator: duplicateValidator,
+		quotaValidator:     quotaValidator,
 	}
 }
 
@@ -49,8 +51,13 @@ func (v *CFServiceInstanceValidator) ValidateCreate(ctx context.Context, obj run
 		return apierrors.NewBadRequest(fmt.Sprintf("expected a CFServiceInstance but got a %T", obj))
 	}
 
+	validationErr := v.quotaValidator.ValidateServiceInstanceCreate(ctx, cfserviceinstancelog, serviceInstance.Namespace)
+	if validationErr != nil {
+		return validationErr.ExportJSONError()
+	}
+
 	duplicateErrorMessage := fmt.Sprintf(duplicateServiceInstanceNameErrorMessage, serviceInstance.Spec.DisplayName)
-	validationErr := v.duplicateValidator.ValidateCreate(ctx, cfserviceinstancelog, serviceInstance.Namespace, serviceInstance.Spec.DisplayName, duplicateErrorMessage)
+	validationErr = v.duplicateValidator.ValidateCreate(ctx, cfserviceinstancelog, serviceInstance.Namespace, serviceInstance.Spec.DisplayName, duplicateErrorMessage)
 	if validationErr != nil {
 		return validationErr.ExportJSONError()
 	}
@@ -26,6 +26,7 @@ var _ = Describe("CFServiceInstanceValidatingWebhook", func() {
 		serviceInstanceName string
 		ctx                 context.Context
 		duplicateValidator  *fake.NameValidator
+		quotaValidator      *fake.QuotaValidator
 		serviceInstance     *korifiv1alpha1.CFServiceInstance
 		validatingWebhook   *services.CFServiceInstanceValidator
 		retErr              error
@@ -52,7 +53,8 @@ var _ = Describe("CFServiceInstanceValidatingWebhook", func() {
 		}
 
 		duplicateValidator = new(fake.NameValidator)
-		validatingWebhook = services.NewCFServiceInstanceValidator(duplicateValidator)
+		quotaValidator = new(fake.QuotaValidator)
+		validatingWebhook = services.NewCFServiceInstanceValidator(duplicateValidator, quotaValidator)
 	})
 
 	Describe("ValidateCreate", func() {
@@ -72,6 +74,29 @@ var _ = Describe("CFServiceInstanceValidatingWebhook", func() {
 			Expect(name).To(Equal(serviceInstance.Spec.DisplayName))
 		})
 
+		It("checks the service instance quota of the namespace", func() {
+			Expect(quotaValidator.ValidateServiceInstanceCreateCallCount()).To(Equal(1))
+			_, _, actualNamespace := quotaValidator.ValidateServiceInstanceCreateArgsForCall(0)
+			Expect(actualNamespace).To(Equal(serviceInstance.Namespace))
+		})
+
+		When("the service instance quota is exceeded", func() {
+			BeforeEach(func() {
+				quotaValidator.ValidateServiceInstanceCreateReturns(&webhooks.ValidationError{
+					Type:    webhooks.QuotaExceededErrorType,
+					Message: webhooks.OrgServiceInstancesQuotaExceededMessage,
+				})
+			})
+
+			It("denies the request without reserving the name", func() {
+				Expect(retErr).To(matchers.BeValidationError(
+					webhooks.QuotaExceededErrorType,
+					Equal(webhooks.OrgServiceInstancesQuotaExceededMessage),
+				))
+				Expect(duplicateValidator.ValidateCreateCallCount()).To(Equal(0))
+			})
+		})
+
 		When("the serviceInstance name is a duplicate", func() {
 			BeforeEach(func() {
 				duplicateValidator.ValidateCreateReturns(&webhooks.ValidationError{
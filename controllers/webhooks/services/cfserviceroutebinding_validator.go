@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+const (
+	ServiceRouteBindingEntityType            = "serviceroutebinding"
+	ServiceRouteBindingErrorType             = "ServiceRouteBindingValidationError"
+	duplicateServiceRouteBindingErrorMessage = "Route %s is already bound to a route service"
+)
+
+// log is for logging in this package.
+var cfserviceroutebindinglog = logf.Log.WithName("cfserviceroutebinding-validator")
+
+//+kubebuilder:webhook:path=/validate-korifi-cloudfoundry-org-v1alpha1-cfserviceroutebinding,mutating=false,failurePolicy=fail,sideEffects=None,groups=korifi.cloudfoundry.org,resources=cfserviceroutebindings,verbs=create;update;delete,versions=v1alpha1,name=vcfserviceroutebinding.korifi.cloudfoundry.org,admissionReviewVersions={v1,v1beta1}
+
+func (v *CFServiceRouteBindingValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&korifiv1alpha1.CFServiceRouteBinding{}).
+		WithValidator(v).
+		Complete()
+}
+
+type CFServiceRouteBindingValidator struct {
+	duplicateValidator webhooks.NameValidator
+}
+
+var _ webhook.CustomValidator = &CFServiceRouteBindingValidator{}
+
+func NewCFServiceRouteBindingValidator(duplicateValidator webhooks.NameValidator) *CFServiceRouteBindingValidator {
+	return &CFServiceRouteBindingValidator{
+		duplicateValidator: duplicateValidator,
+	}
+}
+
+func (v *CFServiceRouteBindingValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	routeBinding, ok := obj.(*korifiv1alpha1.CFServiceRouteBinding)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected a CFServiceRouteBinding but got a %T", obj))
+	}
+
+	// A route can only be bound to a single route service, so the lock is on the route alone
+	lockName := generateServiceRouteBindingLock(routeBinding)
+
+	duplicateErrorMessage := fmt.Sprintf(duplicateServiceRouteBindingErrorMessage, routeBinding.Spec.RouteRef.Name)
+	validationErr := v.duplicateValidator.ValidateCreate(ctx, cfserviceroutebindinglog, routeBinding.Namespace, lockName, duplicateErrorMessage)
+	if validationErr != nil {
+		return validationErr.ExportJSONError()
+	}
+
+	return nil
+}
+
+func (v *CFServiceRouteBindingValidator) ValidateUpdate(ctx context.Context, oldObj, obj runtime.Object) error {
+	routeBinding, ok := obj.(*korifiv1alpha1.CFServiceRouteBinding)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected a CFServiceRouteBinding but got a %T", obj))
+	}
+
+	if !routeBinding.GetDeletionTimestamp().IsZero() {
+		return nil
+	}
+
+	oldRouteBinding, ok := oldObj.(*korifiv1alpha1.CFServiceRouteBinding)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected a CFServiceRouteBinding but got a %T", oldObj))
+	}
+
+	if oldRouteBinding.Spec.RouteRef.Name != routeBinding.Spec.RouteRef.Name {
+		return webhooks.ValidationError{Type: ServiceRouteBindingErrorType, Message: "RouteRef.Name is immutable"}
+	}
+
+	if oldRouteBinding.Spec.Service.Name != routeBinding.Spec.Service.Name {
+		return webhooks.ValidationError{Type: ServiceRouteBindingErrorType, Message: "Service.Name is immutable"}
+	}
+
+	return nil
+}
+
+func (v *CFServiceRouteBindingValidator) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	routeBinding, ok := obj.(*korifiv1alpha1.CFServiceRouteBinding)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected a CFServiceRouteBinding but got a %T", obj))
+	}
+
+	lockName := generateServiceRouteBindingLock(routeBinding)
+
+	validationErr := v.duplicateValidator.ValidateDelete(ctx, cfserviceroutebindinglog, routeBinding.Namespace, lockName)
+	if validationErr != nil {
+		return validationErr.ExportJSONError()
+	}
+
+	return nil
+}
+
+func generateServiceRouteBindingLock(routeBinding *korifiv1alpha1.CFServiceRouteBinding) string {
+	return fmt.Sprintf("srb::%s", routeBinding.Spec.RouteRef.Name)
+}
@@ -0,0 +1,172 @@
+package services_test
+
+import (
+	"context"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/fake"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/services"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("CFServiceRouteBindingValidatingWebhook", func() {
+	const (
+		defaultNamespace = "default"
+	)
+
+	var (
+		routeGUID           string
+		serviceInstanceGUID string
+		ctx                 context.Context
+		duplicateValidator  *fake.NameValidator
+		routeBinding        *korifiv1alpha1.CFServiceRouteBinding
+		validatingWebhook   *services.CFServiceRouteBindingValidator
+		retErr              error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		routeGUID = generateGUID("route")
+		serviceInstanceGUID = generateGUID("service-instance")
+		routeBinding = &korifiv1alpha1.CFServiceRouteBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      generateGUID("service-route-binding"),
+				Namespace: defaultNamespace,
+			},
+			Spec: korifiv1alpha1.CFServiceRouteBindingSpec{
+				RouteRef: v1.LocalObjectReference{
+					Name: routeGUID,
+				},
+				Service: v1.ObjectReference{
+					Name:      serviceInstanceGUID,
+					Namespace: defaultNamespace,
+				},
+			},
+		}
+
+		duplicateValidator = new(fake.NameValidator)
+		validatingWebhook = services.NewCFServiceRouteBindingValidator(duplicateValidator)
+	})
+
+	Describe("ValidateCreate", func() {
+		JustBeforeEach(func() {
+			retErr = validatingWebhook.ValidateCreate(ctx, routeBinding)
+		})
+
+		It("allows the creation of the service route binding", func() {
+			Expect(retErr).NotTo(HaveOccurred())
+		})
+
+		It("tries to create a lock for the route", func() {
+			Expect(duplicateValidator.ValidateCreateCallCount()).To(Equal(1))
+			_, _, actualNamespace, lock, _ := duplicateValidator.ValidateCreateArgsForCall(0)
+			Expect(actualNamespace).To(Equal(defaultNamespace))
+			Expect(lock).To(Equal("srb::" + routeGUID))
+		})
+
+		When("the route is already bound to a route service", func() {
+			BeforeEach(func() {
+				duplicateValidator.ValidateCreateReturns(&webhooks.ValidationError{
+					Type:    webhooks.DuplicateNameErrorType,
+					Message: "Route " + routeGUID + " is already bound to a route service",
+				})
+			})
+
+			It("prevents the creation of the service route binding", func() {
+				Expect(retErr).To(matchers.BeValidationError(
+					webhooks.DuplicateNameErrorType,
+					Equal("Route "+routeGUID+" is already bound to a route service"),
+				))
+			})
+		})
+	})
+
+	Describe("ValidateUpdate", func() {
+		var updatedRouteBinding *korifiv1alpha1.CFServiceRouteBinding
+
+		BeforeEach(func() {
+			updatedRouteBinding = routeBinding.DeepCopy()
+			updatedRouteBinding.Labels = map[string]string{"foo": "bar"}
+		})
+
+		JustBeforeEach(func() {
+			retErr = validatingWebhook.ValidateUpdate(ctx, routeBinding, updatedRouteBinding)
+		})
+
+		It("allows metadata changes", func() {
+			Expect(retErr).NotTo(HaveOccurred())
+		})
+
+		When("the service route binding is being deleted", func() {
+			BeforeEach(func() {
+				updatedRouteBinding.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+				updatedRouteBinding.Spec.RouteRef.Name = "other-route"
+			})
+
+			It("does not return an error", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the RouteRef name changes", func() {
+			BeforeEach(func() {
+				updatedRouteBinding.Spec.RouteRef.Name = "other-route"
+			})
+
+			It("does not allow the change", func() {
+				Expect(retErr).To(MatchError(ContainSubstring("RouteRef.Name is immutable")))
+			})
+		})
+
+		When("the Service name changes", func() {
+			BeforeEach(func() {
+				updatedRouteBinding.Spec.Service.Name = "other-service-instance"
+			})
+
+			It("does not allow the change", func() {
+				Expect(retErr).To(MatchError(ContainSubstring("Service.Name is immutable")))
+			})
+		})
+	})
+
+	Describe("ValidateDelete", func() {
+		JustBeforeEach(func() {
+			retErr = validatingWebhook.ValidateDelete(ctx, routeBinding)
+		})
+
+		It("allows the deletion of the service route binding", func() {
+			Expect(retErr).NotTo(HaveOccurred())
+		})
+
+		It("tries to delete the lock for the route", func() {
+			Expect(duplicateValidator.ValidateDeleteCallCount()).To(Equal(1))
+			_, _, actualNamespace, lock := duplicateValidator.ValidateDeleteArgsForCall(0)
+			Expect(actualNamespace).To(Equal(defaultNamespace))
+			Expect(lock).To(Equal("srb::" + routeGUID))
+		})
+
+		When("the lock cannot be deleted", func() {
+			BeforeEach(func() {
+				duplicateValidator.ValidateDeleteReturns(&webhooks.ValidationError{
+					Type:    webhooks.UnknownErrorType,
+					Message: webhooks.UnknownErrorMessage,
+				})
+			})
+
+			It("prevents the deletion of the service route binding", func() {
+				Expect(retErr).To(matchers.BeValidationError(
+					webhooks.UnknownErrorType,
+					Equal(webhooks.UnknownErrorMessage),
+				))
+			})
+		})
+	})
+})
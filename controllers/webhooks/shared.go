@@ -22,3 +22,13 @@ type NamespaceValidator interface {
 	ValidateOrgCreate(org korifiv1alpha1.CFOrg) *ValidationError
 	ValidateSpaceCreate(space korifiv1alpha1.CFSpace) *ValidationError
 }
+
+//counterfeiter:generate -o fake -fake-name QuotaValidator . QuotaValidator
+
+type QuotaValidator interface {
+	ValidateAppStart(ctx context.Context, logger logr.Logger, app korifiv1alpha1.CFApp) *ValidationError
+	ValidateProcess(ctx context.Context, logger logr.Logger, process korifiv1alpha1.CFProcess) *ValidationError
+	ValidateRouteCreate(ctx context.Context, logger logr.Logger, namespace string) *ValidationError
+	ValidateServiceInstanceCreate(ctx context.Context, logger logr.Logger, namespace string) *ValidationError
+	ValidateTaskCreate(ctx context.Context, logger logr.Logger, namespace, appGUID string) *ValidationError
+}
@@ -27,13 +27,15 @@ var cfapplog = logf.Log.WithName("cfapp-validate")
 
 type CFAppValidator struct {
 	duplicateValidator webhooks.NameValidator
+	quotaValidator     webhooks.QuotaValidator
 }
 
 var _ webhook.CustomValidator = &CFAppValidator{}
 
-func NewCFAppValidator(duplicateValidator webhooks.NameValidator) *CFAppValidator {
+func NewCFAppValidator(duplicateValidator webhooks.NameValidator, quotaValidator webhooks.QuotaValidator) *CFAppValidator {
 	return &CFAppValidator{
 		duplicateValidator: duplicateValidator,
+		quotaValidator:     quotaValidator,
 	}
 }
 
@@ -80,6 +82,13 @@ func (v *CFAppValidator) ValidateUpdate(ctx context.Context, oldObj, obj runtime
 		return validationErr.ExportJSONError()
 	}
 
+	if app.Spec.DesiredState == korifiv1alpha1.StartedState && oldApp.Spec.DesiredState != korifiv1alpha1.StartedState {
+		validationErr = v.quotaValidator.ValidateAppStart(ctx, cfapplog, *app)
+		if validationErr != nil {
+			return validationErr.ExportJSONError()
+		}
+	}
+
 	return nil
 }
 
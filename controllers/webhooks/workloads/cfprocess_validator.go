@@ -0,0 +1,92 @@
+package workloads
+
+import (
+	"context"
+	"fmt"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+var cfprocesslog = logf.Log.WithName("cfprocess-validate")
+
+//+kubebuilder:webhook:path=/validate-korifi-cloudfoundry-org-v1alpha1-cfprocess,mutating=false,failurePolicy=fail,sideEffects=None,groups=korifi.cloudfoundry.org,resources=cfprocesses,verbs=create;update,versions=v1alpha1,name=vcfprocess.korifi.cloudfoundry.org,admissionReviewVersions={v1,v1beta1}
+
+type CFProcessValidator struct {
+	quotaValidator webhooks.QuotaValidator
+}
+
+var _ webhook.CustomValidator = &CFProcessValidator{}
+
+func NewCFProcessValidator(quotaValidator webhooks.QuotaValidator) *CFProcessValidator {
+	return &CFProcessValidator{
+		quotaValidator: quotaValidator,
+	}
+}
+
+func (v *CFProcessValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&korifiv1alpha1.CFProcess{}).
+		WithValidator(v).
+		Complete()
+}
+
+func (v *CFProcessValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	process, ok := obj.(*korifiv1alpha1.CFProcess)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected a CFProcess but got a %T", obj))
+	}
+
+	validationErr := v.quotaValidator.ValidateProcess(ctx, cfprocesslog, *process)
+	if validationErr != nil {
+		return validationErr.ExportJSONError()
+	}
+
+	return nil
+}
+
+func (v *CFProcessValidator) ValidateUpdate(ctx context.Context, oldObj, obj runtime.Object) error {
+	process, ok := obj.(*korifiv1alpha1.CFProcess)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected a CFProcess but got a %T", obj))
+	}
+
+	if !process.GetDeletionTimestamp().IsZero() {
+		return nil
+	}
+
+	oldProcess, ok := oldObj.(*korifiv1alpha1.CFProcess)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected a CFProcess but got a %T", oldObj))
+	}
+
+	// Only scaling up can exceed a quota, so other updates are not checked
+	if process.Spec.MemoryMB <= oldProcess.Spec.MemoryMB && desiredInstances(*process) <= desiredInstances(*oldProcess) {
+		return nil
+	}
+
+	validationErr := v.quotaValidator.ValidateProcess(ctx, cfprocesslog, *process)
+	if validationErr != nil {
+		return validationErr.ExportJSONError()
+	}
+
+	return nil
+}
+
+func (v *CFProcessValidator) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	return nil
+}
+
+func desiredInstances(process korifiv1alpha1.CFProcess) int {
+	if process.Spec.DesiredInstances == nil {
+		return 0
+	}
+
+	return *process.Spec.DesiredInstances
+}
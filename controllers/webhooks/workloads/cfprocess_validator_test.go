@@ -0,0 +1,101 @@
+package workloads_test
+
+import (
+	"context"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks"
+	"code.cloudfoundry.org/korifi/tools"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("CFProcessValidatingWebhook", func() {
+	var (
+		ctx            context.Context
+		orgNamespace   string
+		spaceNamespace string
+		cfApp          *korifiv1alpha1.CFApp
+		cfProcess      *korifiv1alpha1.CFProcess
+		createErr      error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		orgNamespace = "test-org-" + uuid.NewString()
+		spaceNamespace = "test-space-" + uuid.NewString()
+
+		Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFOrg{
+			ObjectMeta: metav1.ObjectMeta{Name: orgNamespace, Namespace: rootNamespace},
+			Spec:       korifiv1alpha1.CFOrgSpec{DisplayName: orgNamespace},
+		})).To(Succeed())
+		Expect(k8sClient.Create(ctx, &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: orgNamespace}})).To(Succeed())
+		Eventually(func() error {
+			return internalWebhookK8sClient.Get(ctx, types.NamespacedName{Name: orgNamespace, Namespace: rootNamespace}, new(korifiv1alpha1.CFOrg))
+		}).Should(Succeed())
+
+		Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFSpace{
+			ObjectMeta: metav1.ObjectMeta{Name: spaceNamespace, Namespace: orgNamespace},
+			Spec:       korifiv1alpha1.CFSpaceSpec{DisplayName: spaceNamespace},
+		})).To(Succeed())
+		Expect(k8sClient.Create(ctx, &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: spaceNamespace}})).To(Succeed())
+
+		Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFSpaceQuota{
+			ObjectMeta: metav1.ObjectMeta{Name: uuid.NewString(), Namespace: orgNamespace},
+			Spec: korifiv1alpha1.CFSpaceQuotaSpec{
+				DisplayName: "small",
+				QuotaLimits: korifiv1alpha1.QuotaLimits{
+					Apps: korifiv1alpha1.AppsQuota{PerProcessMemoryInMB: tools.PtrTo(int64(512))},
+				},
+				Spaces: []string{spaceNamespace},
+			},
+		})).To(Succeed())
+
+		cfApp = makeCFApp(uuid.NewString(), spaceNamespace, uuid.NewString())
+		Expect(k8sClient.Create(ctx, cfApp)).To(Succeed())
+
+		// Ensure the webhook client sees the space and the app before validating the process
+		Eventually(func() error {
+			return internalWebhookK8sClient.Get(ctx, types.NamespacedName{Name: cfApp.Name, Namespace: spaceNamespace}, new(korifiv1alpha1.CFApp))
+		}).Should(Succeed())
+
+		cfProcess = &korifiv1alpha1.CFProcess{
+			ObjectMeta: metav1.ObjectMeta{Name: uuid.NewString(), Namespace: spaceNamespace},
+			Spec: korifiv1alpha1.CFProcessSpec{
+				AppRef:           v1.LocalObjectReference{Name: cfApp.Name},
+				ProcessType:      "web",
+				DesiredInstances: tools.PtrTo(1),
+				MemoryMB:         256,
+				HealthCheck:      korifiv1alpha1.HealthCheck{Type: "port"},
+				Ports:            []int32{8080},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		createErr = k8sClient.Create(ctx, cfProcess)
+	})
+
+	It("succeeds", func() {
+		Expect(createErr).NotTo(HaveOccurred())
+	})
+
+	When("the process memory exceeds the space quota", func() {
+		BeforeEach(func() {
+			cfProcess.Spec.MemoryMB = 1024
+		})
+
+		It("fails", func() {
+			validationErr, ok := webhooks.WebhookErrorToValidationError(createErr)
+			Expect(ok).To(BeTrue())
+			Expect(validationErr.Type).To(Equal(webhooks.QuotaExceededErrorType))
+			Expect(validationErr.Message).To(Equal(webhooks.SpaceInstanceMemoryLimitExceededMessage))
+		})
+	})
+})
@@ -42,12 +42,16 @@ var cftasklog = logf.Log.WithName("cftask-resource")
 
 //+kubebuilder:webhook:path=/validate-korifi-cloudfoundry-org-v1alpha1-cftask,mutating=false,failurePolicy=fail,sideEffects=None,groups=korifi.cloudfoundry.org,resources=cftasks;cftasks/status,verbs=create;update,versions=v1alpha1,name=vcftask.korifi.cloudfoundry.org,admissionReviewVersions={v1,v1beta1}
 
-type CFTaskValidator struct{}
+type CFTaskValidator struct {
+	quotaValidator webhooks.QuotaValidator
+}
 
 var _ webhook.CustomValidator = &CFTaskValidator{}
 
-func NewCFTaskValidator() *CFTaskValidator {
-	return &CFTaskValidator{}
+func NewCFTaskValidator(quotaValidator webhooks.QuotaValidator) *CFTaskValidator {
+	return &CFTaskValidator{
+		quotaValidator: quotaValidator,
+	}
 }
 
 func (v *CFTaskValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
@@ -81,6 +85,10 @@ func (v *CFTaskValidator) ValidateCreate(ctx context.Context, obj runtime.Object
 		}.ExportJSONError()
 	}
 
+	if validationErr := v.quotaValidator.ValidateTaskCreate(ctx, cftasklog, task.Namespace, task.Spec.AppRef.Name); validationErr != nil {
+		return validationErr.ExportJSONError()
+	}
+
 	return nil
 }
 
@@ -102,8 +102,11 @@ var _ = BeforeSuite(func() {
 
 	Expect((&korifiv1alpha1.CFApp{}).SetupWebhookWithManager(mgr)).To(Succeed())
 
+	quotaValidator := webhooks.NewCFQuotaValidator(mgr.GetClient(), rootNamespace)
+
 	appNameDuplicateValidator := webhooks.NewDuplicateValidator(coordination.NewNameRegistry(mgr.GetClient(), workloads.AppEntityType))
-	Expect(workloads.NewCFAppValidator(appNameDuplicateValidator).SetupWebhookWithManager(mgr)).To(Succeed())
+	Expect(workloads.NewCFAppValidator(appNameDuplicateValidator, quotaValidator).SetupWebhookWithManager(mgr)).To(Succeed())
+	Expect(workloads.NewCFProcessValidator(quotaValidator).SetupWebhookWithManager(mgr)).To(Succeed())
 
 	orgNameDuplicateValidator := webhooks.NewDuplicateValidator(coordination.NewNameRegistry(mgr.GetClient(), workloads.CFOrgEntityType))
 	orgPlacementValidator := webhooks.NewPlacementValidator(mgr.GetClient(), rootNamespace)
@@ -118,7 +121,7 @@ var _ = BeforeSuite(func() {
 		DiskQuotaMB: 512,
 	}
 	Expect(workloads.NewCFTaskDefaulter(cfProcessDefaults).SetupWebhookWithManager(mgr)).To(Succeed())
-	Expect(workloads.NewCFTaskValidator().SetupWebhookWithManager(mgr)).To(Succeed())
+	Expect(workloads.NewCFTaskValidator(quotaValidator).SetupWebhookWithManager(mgr)).To(Succeed())
 
 	//+kubebuilder:scaffold:webhook
 
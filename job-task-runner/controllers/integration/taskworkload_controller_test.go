@@ -26,6 +26,9 @@ var _ = Describe("Job TaskWorkload Controller Integration Test", func() {
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      prefixedGUID("taskworkload"),
 				Namespace: testNamespace.Name,
+				Labels: map[string]string{
+					korifiv1alpha1.CFAppGUIDLabelKey: "my-app-guid",
+				},
 			},
 			Spec: korifiv1alpha1.TaskWorkloadSpec{
 				Image:   "my-image",
@@ -73,6 +76,11 @@ var _ = Describe("Job TaskWorkload Controller Integration Test", func() {
 		Expect(job.Spec.BackoffLimit).To(Equal(tools.PtrTo(int32(0))))
 		Expect(job.Spec.TTLSecondsAfterFinished).To(Equal(tools.PtrTo(int32(60))))
 
+		Expect(job.Spec.Template.Labels).To(Equal(map[string]string{
+			korifiv1alpha1.CFAppGUIDLabelKey: "my-app-guid",
+			"sidecar.istio.io/inject":        "false",
+		}))
+
 		podSpec := job.Spec.Template.Spec
 		Expect(podSpec.RestartPolicy).To(Equal(corev1.RestartPolicyNever))
 		Expect(podSpec.SecurityContext).To(Equal(&corev1.PodSecurityContext{
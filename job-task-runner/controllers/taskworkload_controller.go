@@ -153,9 +153,7 @@ func (r *TaskWorkloadReconciler) workloadToJob(taskWorkload *korifiv1alpha1.Task
 			TTLSecondsAfterFinished: tools.PtrTo(int32(r.jobTTL.Seconds())),
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: map[string]string{
-						"sidecar.istio.io/inject": "false",
-					},
+					Labels: podLabels(taskWorkload),
 				},
 				Spec: corev1.PodSpec{
 					RestartPolicy: corev1.RestartPolicyNever,
@@ -206,3 +204,14 @@ func (r *TaskWorkloadReconciler) updateTaskWorkloadStatus(ctx context.Context, t
 
 	return nil
 }
+
+// podLabels copies the task workload labels onto its pod, so that the pod can be selected like the app pods
+func podLabels(taskWorkload *korifiv1alpha1.TaskWorkload) map[string]string {
+	labels := map[string]string{}
+	for key, value := range taskWorkload.Labels {
+		labels[key] = value
+	}
+	labels["sidecar.istio.io/inject"] = "false"
+
+	return labels
+}
@@ -178,14 +178,16 @@ type manifestRouteResource struct {
 }
 
 type domainResource struct {
-	resource `json:",inline"`
-	Internal bool `json:"internal"`
+	resource    `json:",inline"`
+	Internal    bool      `json:"internal"`
+	RouterGroup *resource `json:"router_group,omitempty"`
 }
 
 type routeResource struct {
 	resource `json:",inline"`
 	Host     string `json:"host"`
 	Path     string `json:"path"`
+	Port     int    `json:"port,omitempty"`
 	URL      string `json:"url,omitempty"`
 }
 
@@ -3,7 +3,11 @@ package e2e_test
 import (
 	"crypto/tls"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
+	"os"
+	"time"
 
 	"code.cloudfoundry.org/korifi/tests/e2e/helpers"
 
@@ -531,4 +535,79 @@ var _ = Describe("Routes", func() {
 			})
 		})
 	})
+
+	Describe("tcp routes", func() {
+		var (
+			tcpDomainGUID string
+			tcpDomainName string
+			route         routeResource
+		)
+
+		BeforeEach(func() {
+			tcpDomainGUID = ""
+
+			// the domain has to resolve to the ingress of the cluster, which has to expose the ports of the
+			// default-tcp router group
+			tcpDomainName = os.Getenv("TCP_DOMAIN")
+			if tcpDomainName == "" {
+				Skip("No tcp domain provided")
+			}
+
+			var domain responseResource
+			resp, err := adminClient.R().
+				SetBody(domainResource{
+					resource:    resource{Name: tcpDomainName},
+					RouterGroup: &resource{GUID: "default-tcp"},
+				}).
+				SetResult(&domain).
+				Post("/v3/domains")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(HaveRestyStatusCode(http.StatusCreated))
+			tcpDomainGUID = domain.GUID
+
+			resp, err = adminClient.R().
+				SetBody(routeResource{
+					resource: resource{
+						Relationships: relationships{
+							"domain": {Data: resource{GUID: tcpDomainGUID}},
+							"space":  {Data: resource{GUID: spaceGUID}},
+						},
+					},
+				}).
+				SetResult(&route).
+				Post("/v3/routes")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(HaveRestyStatusCode(http.StatusCreated))
+			Expect(route.Port).NotTo(BeZero())
+
+			appGUID := pushTestApp(spaceGUID, appBitsFile)
+			addDestinationForRoute(appGUID, route.GUID)
+		})
+
+		AfterEach(func() {
+			if tcpDomainGUID == "" {
+				return
+			}
+
+			resp, err := adminClient.R().Delete("/v3/domains/" + tcpDomainGUID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.StatusCode()).To(BeNumerically("<", http.StatusInternalServerError))
+		})
+
+		It("routes the connections to the port of the route to the app", func() {
+			Eventually(func(g Gomega) {
+				conn, err := net.DialTimeout("tcp", fmt.Sprintf("%s:%d", tcpDomainName, route.Port), 5*time.Second)
+				g.Expect(err).NotTo(HaveOccurred())
+				defer conn.Close()
+
+				g.Expect(conn.SetDeadline(time.Now().Add(5 * time.Second))).To(Succeed())
+				_, err = fmt.Fprintf(conn, "GET / HTTP/1.0\r\nHost: %s\r\n\r\n", tcpDomainName)
+				g.Expect(err).NotTo(HaveOccurred())
+
+				response, err := io.ReadAll(conn)
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(string(response)).To(ContainSubstring("hello-world"))
+			}).Should(Succeed())
+		})
+	})
 })